@@ -53,6 +53,8 @@ var (
 		distr.AppModuleBasic{},
 		gov.NewAppModuleBasic(
 			paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler,
+			bonds.ClaimStuckFundsProposalHandler, bonds.MigrateCurveVersionProposalHandler,
+			bonds.MigrateReserveTokenProposalHandler, bonds.EditBondProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -73,9 +75,12 @@ var (
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 		gov.ModuleName:            {supply.Burner},
 
-		bonds.BondsMintBurnAccount:       {supply.Minter, supply.Burner},
-		bonds.BatchesIntermediaryAccount: nil,
-		bonds.BondsReserveAccount:        nil,
+		bonds.BondsMintBurnAccount:        {supply.Minter, supply.Burner},
+		bonds.BatchesIntermediaryAccount:  nil,
+		bonds.BondsReserveAccount:         nil,
+		bonds.BondProposalsAccount:        nil,
+		bonds.BondVestingAccount:          nil,
+		bonds.NotificationDepositsAccount: nil,
 	}
 
 	// module accounts that are allowed to receive tokens
@@ -179,6 +184,7 @@ func NewBondsApp(
 	app.subspaces[gov.ModuleName] = app.paramsKeeper.Subspace(gov.DefaultParamspace).WithKeyTable(gov.ParamKeyTable())
 	app.subspaces[evidence.ModuleName] = app.paramsKeeper.Subspace(evidence.DefaultParamspace)
 	app.subspaces[crisis.ModuleName] = app.paramsKeeper.Subspace(crisis.DefaultParamspace)
+	app.subspaces[bonds.ModuleName] = app.paramsKeeper.Subspace(bonds.DefaultParamspace)
 
 	// Add keepers
 	app.AccountKeeper = auth.NewAccountKeeper(
@@ -215,16 +221,6 @@ func NewBondsApp(
 	evidenceKeeper.SetRouter(evidenceRouter)
 	app.evidenceKeeper = *evidenceKeeper
 
-	// register the proposal types
-	govRouter := gov.NewRouter()
-	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
-		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
-		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
-		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
-	app.govKeeper = gov.NewKeeper(
-		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName], app.SupplyKeeper, &stakingKeeper, govRouter,
-	)
-
 	// register the staking hooks
 	// NOTE: StakingKeeper above is passed by reference, so that it will contain these hooks
 	app.StakingKeeper = *stakingKeeper.SetHooks(
@@ -236,9 +232,23 @@ func NewBondsApp(
 		app.SupplyKeeper,
 		app.AccountKeeper,
 		app.StakingKeeper,
+		app.distrKeeper,
 		keys[bonds.StoreKey],
+		app.subspaces[bonds.ModuleName],
 		app.cdc,
 	)
+	app.BondsKeeper.SetUpgradeKeeper(app.upgradeKeeper)
+
+	// register the proposal types
+	govRouter := gov.NewRouter()
+	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
+		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
+		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper)).
+		AddRoute(bonds.RouterKey, bonds.NewProposalHandler(app.BondsKeeper))
+	app.govKeeper = gov.NewKeeper(
+		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName], app.SupplyKeeper, &stakingKeeper, govRouter,
+	)
 
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
@@ -304,9 +314,17 @@ func NewBondsApp(
 	// initialize BaseApp
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(ante.NewAnteHandler(
+	anteHandler := ante.NewAnteHandler(
 		app.AccountKeeper, app.SupplyKeeper, auth.DefaultSigVerificationGasConsumer,
-	))
+	)
+	nonTransferableDecorator := bonds.NewNonTransferableDecorator(app.BondsKeeper)
+	orderPrecheckDecorator := bonds.NewOrderPrecheckDecorator(app.BondsKeeper)
+	precheckingAnteHandler := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return orderPrecheckDecorator.AnteHandle(ctx, tx, simulate, anteHandler)
+	}
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return nonTransferableDecorator.AnteHandle(ctx, tx, simulate, precheckingAnteHandler)
+	})
 	app.SetEndBlocker(app.EndBlocker)
 
 	if loadLatest {
@@ -18,6 +18,7 @@ import (
 
 	"github.com/ixoworld/bonds/app"
 	"github.com/ixoworld/bonds/types"
+	bondscli "github.com/ixoworld/bonds/x/bonds/client/cli"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/server"
@@ -63,8 +64,12 @@ func main() {
 	)
 	rootCmd.AddCommand(genutilcli.ValidateGenesisCmd(ctx, cdc, app.ModuleBasics))
 	rootCmd.AddCommand(AddGenesisAccountCmd(ctx, cdc, app.DefaultNodeHome, app.DefaultCLIHome))
+	rootCmd.AddCommand(BondsCmd(ctx))
 	rootCmd.AddCommand(flags.NewCompletionCmd(rootCmd, true))
-	rootCmd.AddCommand(debug.Cmd(cdc))
+
+	debugCmd := debug.Cmd(cdc)
+	debugCmd.AddCommand(bondscli.GetCmdCurveVectors(cdc))
+	rootCmd.AddCommand(debugCmd)
 
 	server.AddCommands(ctx, cdc, rootCmd, newApp, exportAppStateAndTMValidators)
 
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/ixoworld/bonds/app"
+)
+
+// BondsCmd returns the bonds cobra Command, which groups the offline
+// commands of the bonds module that run against a node's home.
+func BondsCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bonds",
+		Short: "Offline commands of the bonds module, run against a node's home",
+	}
+	cmd.AddCommand(RebuildIndexesCmd(ctx))
+	return cmd
+}
+
+// RebuildIndexesCmd returns the rebuild-indexes cobra Command.
+func RebuildIndexesCmd(ctx *server.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rebuild-indexes",
+		Short: "Rebuild the bonds module's indexes and stats from the node's latest state",
+		Long: `Rebuild the bonds module's derived data (the bond search index, the module
+stats, and the bond histories) from the bonds and batches in the node's latest
+state, and report how the rebuilt data differs from the stored data.
+
+The node must be stopped. Since the derived data is part of the consensus state,
+the node's state is not modified, as this would change its app hash. Instead,
+chains upgrading to a version that introduces any of these stores rebuild them
+by calling the bonds keeper's RebuildIndexes from the upgrade's handler, and this
+command can be used to preview (or, after the upgrade, verify) the result.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			db, err := sdk.NewLevelDB("application", filepath.Join(config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			bondsApp := app.NewBondsApp(log.NewNopLogger(), db, nil, true,
+				map[int64]bool{}, 0)
+			height := bondsApp.LastBlockHeight()
+			if height == 0 {
+				return fmt.Errorf("no state found in %s", config.RootDir)
+			}
+
+			// The check state's context is never committed
+			sdkCtx := bondsApp.NewContext(true, abci.Header{Height: height})
+			report := bondsApp.BondsKeeper.RebuildIndexes(sdkCtx)
+
+			fmt.Printf("Rebuilt from state at height %d:\n%s\n", height, report)
+			if !report.HasChanges() {
+				fmt.Println("The stored indexes are up to date.")
+			}
+			return nil
+		},
+	}
+}
@@ -89,9 +89,6 @@ rest_from_m bonds/edit_bond '{
                               "token":"abc",
                               "name":"New A B C",
                               "description":"New description about A B C",
-                              "sanity_rate":"[do-not-modify]",
-                              "sanity_margin_percentage":"[do-not-modify]",
-                              "order_quantity_limits":"[do-not-modify]",
                               "signers":"'$MIGUEL'"
                             }'
 echo "Edited bond..."
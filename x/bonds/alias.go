@@ -11,27 +11,118 @@ import (
 )
 
 const (
-	PowerFunction     = types.PowerFunction
-	SigmoidFunction   = types.SigmoidFunction
-	SwapperFunction   = types.SwapperFunction
-	AugmentedFunction = types.AugmentedFunction
+	PowerFunction           = types.PowerFunction
+	SigmoidFunction         = types.SigmoidFunction
+	SwapperFunction         = types.SwapperFunction
+	AugmentedFunction       = types.AugmentedFunction
+	ExponentialFunction     = types.ExponentialFunction
+	LogarithmicFunction     = types.LogarithmicFunction
+	PolynomialFunction      = types.PolynomialFunction
+	BancorFunction          = types.BancorFunction
+	WeightedSwapperFunction = types.WeightedSwapperFunction
+	StableSwapFunction      = types.StableSwapFunction
+	PiecewiseFunction       = types.PiecewiseFunction
 
 	HatchState  = types.HatchState
 	OpenState   = types.OpenState
 	SettleState = types.SettleState
+	FailedState = types.FailedState
 
-	DoNotModifyField = types.DoNotModifyField
+	ActionBuy            = types.ActionBuy
+	ActionSell           = types.ActionSell
+	ActionSwap           = types.ActionSwap
+	ActionOutcomePayment = types.ActionOutcomePayment
+	ActionWithdrawShare  = types.ActionWithdrawShare
+	ActionWithdrawFunds  = types.ActionWithdrawFunds
 
 	AnyNumberOfReserveTokens = types.AnyNumberOfReserveTokens
 
 	DefaultCodespace = types.DefaultCodespace
 
+	DefaultFunctionParamsCacheSize = types.DefaultFunctionParamsCacheSize
+
+	DefaultParamspace = types.DefaultParamspace
+
+	CreationFeeDestinationBurn          = types.CreationFeeDestinationBurn
+	CreationFeeDestinationCommunityPool = types.CreationFeeDestinationCommunityPool
+
+	ProposalTypeClaimStuckFunds     = types.ProposalTypeClaimStuckFunds
+	ProposalTypeMigrateCurveVersion = types.ProposalTypeMigrateCurveVersion
+	ProposalTypeMigrateReserveToken = types.ProposalTypeMigrateReserveToken
+	ProposalTypeEditBond            = types.ProposalTypeEditBond
+
+	CurveVersion1      = types.CurveVersion1
+	LatestCurveVersion = types.LatestCurveVersion
+
+	MaxPercentageDecimalPlaces = types.MaxPercentageDecimalPlaces
+
+	TxFeeName   = types.TxFeeName
+	ExitFeeName = types.ExitFeeName
+
+	AtMaxSupplyAllowRebuys = types.AtMaxSupplyAllowRebuys
+	AtMaxSupplyCloseToBuys = types.AtMaxSupplyCloseToBuys
+	AtMaxSupplyAutoSettle  = types.AtMaxSupplyAutoSettle
+
+	DefaultBondSearchLimit = types.DefaultBondSearchLimit
+	MaxBondSearchLimit     = types.MaxBondSearchLimit
+
+	DefaultBatchOrdersLimit   = types.DefaultBatchOrdersLimit
+	MaxBatchOrdersLimit       = types.MaxBatchOrdersLimit
+	BatchOrderStatusPending   = types.BatchOrderStatusPending
+	BatchOrderStatusCancelled = types.BatchOrderStatusCancelled
+
+	MaxEventAttributes           = types.MaxEventAttributes
+	MaxEventAttributeValueLength = types.MaxEventAttributeValueLength
+
+	MaxFunctionParams          = types.MaxFunctionParams
+	MaxFunctionParamNameLength = types.MaxFunctionParamNameLength
+	MaxBondNameLength          = types.MaxBondNameLength
+	MaxBondDescriptionLength   = types.MaxBondDescriptionLength
+
+	MaxBondTranslations = types.MaxBondTranslations
+
+	MaxNoOfSigners = types.MaxNoOfSigners
+
+	MaxCurveSegments = types.MaxCurveSegments
+
+	BondProposalTypeText       = types.BondProposalTypeText
+	BondProposalTypeFunding    = types.BondProposalTypeFunding
+	BondProposalStatusVoting   = types.BondProposalStatusVoting
+	BondProposalStatusPassed   = types.BondProposalStatusPassed
+	BondProposalStatusRejected = types.BondProposalStatusRejected
+	BondProposalStatusFailed   = types.BondProposalStatusFailed
+	VoteOptionYes              = types.VoteOptionYes
+	VoteOptionNo               = types.VoteOptionNo
+
 	ModuleName = types.ModuleName
 	StoreKey   = types.StoreKey
 
-	BondsMintBurnAccount       = types.BondsMintBurnAccount
-	BatchesIntermediaryAccount = types.BatchesIntermediaryAccount
-	BondsReserveAccount        = types.BondsReserveAccount
+	BondsMintBurnAccount        = types.BondsMintBurnAccount
+	BatchesIntermediaryAccount  = types.BatchesIntermediaryAccount
+	BondsReserveAccount         = types.BondsReserveAccount
+	BondProposalsAccount        = types.BondProposalsAccount
+	BondVestingAccount          = types.BondVestingAccount
+	NotificationDepositsAccount = types.NotificationDepositsAccount
+
+	NotificationTypeFills       = types.NotificationTypeFills
+	NotificationTypeSettlements = types.NotificationTypeSettlements
+
+	ExportFormatCSV = types.ExportFormatCSV
+
+	LedgerAccountExternal     = types.LedgerAccountExternal
+	LedgerAccountEscrow       = types.LedgerAccountEscrow
+	LedgerAccountReserve      = types.LedgerAccountReserve
+	LedgerAccountSupply       = types.LedgerAccountSupply
+	LedgerEntryEscrowIn       = types.LedgerEntryEscrowIn
+	LedgerEntryEscrowOut      = types.LedgerEntryEscrowOut
+	LedgerEntryReserveIn      = types.LedgerEntryReserveIn
+	LedgerEntryReserveOut     = types.LedgerEntryReserveOut
+	LedgerEntryFee            = types.LedgerEntryFee
+	LedgerEntryRefund         = types.LedgerEntryRefund
+	LedgerEntryMint           = types.LedgerEntryMint
+	LedgerEntryBurn           = types.LedgerEntryBurn
+	DefaultLedgerEntriesLimit = types.DefaultLedgerEntriesLimit
+	MaxLedgerEntriesLimit     = types.MaxLedgerEntriesLimit
 
 	QuerierRoute = types.QuerierRoute
 	RouterKey    = types.RouterKey
@@ -47,16 +138,112 @@ var (
 	AllInvariants      = keeper.AllInvariants
 	SupplyInvariant    = keeper.SupplyInvariant
 	ReserveInvariant   = keeper.ReserveInvariant
+	EscrowInvariant    = keeper.EscrowInvariant
+	LedgerInvariant    = keeper.LedgerInvariant
 
 	RegisterCodec = types.RegisterCodec
 
-	NewBatch         = types.NewBatch
-	NewBaseOrder     = types.NewBaseOrder
-	NewBuyOrder      = types.NewBuyOrder
-	NewSellOrder     = types.NewSellOrder
-	NewSwapOrder     = types.NewSwapOrder
-	NewFunctionParam = types.NewFunctionParam
-	NewBond          = types.NewBond
+	NewBatch                 = types.NewBatch
+	NewBaseOrder             = types.NewBaseOrder
+	NewBuyOrder              = types.NewBuyOrder
+	NewSellOrder             = types.NewSellOrder
+	NewSwapOrder             = types.NewSwapOrder
+	NewRebalanceSwapOrder    = types.NewRebalanceSwapOrder
+	RankBuysByPriority       = types.RankBuysByPriority
+	NewCancelledOrder        = types.NewCancelledOrder
+	NewBatchResult           = types.NewBatchResult
+	NewModuleStats           = types.NewModuleStats
+	NewFunctionParam         = types.NewFunctionParam
+	NewRationalFunctionParam = types.NewRationalFunctionParam
+	NewRational              = types.NewRational
+	NewRationalFromDec       = types.NewRationalFromDec
+	ParseRational            = types.ParseRational
+	NewBond                  = types.NewBond
+	NewBatchStress           = types.NewBatchStress
+	NewSignerThreshold       = types.NewSignerThreshold
+	NewCurveSegment          = types.NewCurveSegment
+	ValidateCurveSegments    = types.ValidateCurveSegments
+
+	NewBondSearchIndexEntry = types.NewBondSearchIndexEntry
+
+	NewQueryBatchOrdersParams    = types.NewQueryBatchOrdersParams
+	NewQueryIsTradeAllowedParams = types.NewQueryIsTradeAllowedParams
+	NewQueryIsTradeAllowed       = types.NewQueryIsTradeAllowed
+	NewQueryBatchOrders          = types.NewQueryBatchOrders
+	NewQueryBondAtHeight         = types.NewQueryBondAtHeight
+	NewQueryBondExport           = types.NewQueryBondExport
+	NewQueryEffectiveBatchBlocks = types.NewQueryEffectiveBatchBlocks
+	NewQueryFeePreview           = types.NewQueryFeePreview
+	NewQueryBond                 = types.NewQueryBond
+	NewBondAccountingCSV         = types.NewBondAccountingCSV
+	NewQueryLedgerParams         = types.NewQueryLedgerParams
+	NewQueryLedgerExport         = types.NewQueryLedgerExport
+	NewBondLedgerCSV             = types.NewBondLedgerCSV
+	NewBondLedger                = types.NewBondLedger
+	NewLedgerEntry               = types.NewLedgerEntry
+
+	NewBuyOrderReceipt  = types.NewBuyOrderReceipt
+	NewSellOrderReceipt = types.NewSellOrderReceipt
+	NewSwapOrderReceipt = types.NewSwapOrderReceipt
+	IsValidReceipt      = types.IsValidReceipt
+
+	NewScheduledParamChange             = types.NewScheduledParamChange
+	CheckFunctionTypeAllowsParamChanges = types.CheckFunctionTypeAllowsParamChanges
+	ValidateScheduledParamChange        = types.ValidateScheduledParamChange
+	NewInterpolatedParamChange          = types.NewInterpolatedParamChange
+	ValidateFunctionParamsForBond       = types.ValidateFunctionParamsForBond
+	CheckParamsBackedByReserve          = types.CheckParamsBackedByReserve
+
+	NewMilestone       = types.NewMilestone
+	ValidateMilestones = types.ValidateMilestones
+
+	ValidateSoftCap = types.ValidateSoftCap
+
+	IsValidBondState         = types.IsValidBondState
+	IsFinalBondState         = types.IsFinalBondState
+	CheckBondStateTransition = types.CheckBondStateTransition
+
+	NewEventAttribute = types.NewEventAttribute
+
+	NewBondTranslation = types.NewBondTranslation
+
+	NewSpendRecord       = types.NewSpendRecord
+	NewSpendRecordsEntry = types.NewSpendRecordsEntry
+	CheckSpendCap        = types.CheckSpendCap
+
+	DenomsExceedingValueLockedCap = types.DenomsExceedingValueLockedCap
+
+	NewVestingSchedule = types.NewVestingSchedule
+	CheckPreMine       = types.CheckPreMine
+
+	CheckAtMaxSupplyBehavior = types.CheckAtMaxSupplyBehavior
+
+	NewBondProposal             = types.NewBondProposal
+	NewBondProposalVote         = types.NewBondProposalVote
+	ValidateBondProposalContent = types.ValidateBondProposalContent
+	CheckVoteOption             = types.CheckVoteOption
+	CheckBondAllowsBondProposal = types.CheckBondAllowsBondProposal
+
+	NewBondSnapshot = types.NewBondSnapshot
+
+	NewParams     = types.NewParams
+	DefaultParams = types.DefaultParams
+	ParamKeyTable = types.ParamKeyTable
+
+	NewClaimStuckFundsProposal     = types.NewClaimStuckFundsProposal
+	NewMigrateCurveVersionProposal = types.NewMigrateCurveVersionProposal
+	NewMigrateReserveTokenProposal = types.NewMigrateReserveTokenProposal
+	NewEditBondProposal            = types.NewEditBondProposal
+	NewPendingBondEdit             = types.NewPendingBondEdit
+	NewPendingBondEditFromMsg      = types.NewPendingBondEditFromMsg
+	IsBondsModuleAccount           = types.IsBondsModuleAccount
+	IsValidCurveVersion            = types.IsValidCurveVersion
+
+	NewPercentage   = types.NewPercentage
+	ParsePercentage = types.ParsePercentage
+	MaxPercentage   = types.MaxPercentage
+
+	NewFunctionParamsCache = types.NewFunctionParamsCache
 
 	RoundReservePrice     = types.RoundReservePrice
 	RoundReserveReturn    = types.RoundReserveReturn
@@ -71,21 +258,57 @@ var (
 	DivideDecCoinsByDec   = types.DivideDecCoinsByDec
 	AdjustFees            = types.AdjustFees
 
+	NewFeeOrder               = types.NewFeeOrder
+	NewFeeChain               = types.NewFeeChain
+	NewPercentageFeeDecorator = types.NewPercentageFeeDecorator
+	GetPercentageFee          = types.GetPercentageFee
+	GetPercentageFees         = types.GetPercentageFees
+	GetExactPercentageFee     = types.GetExactPercentageFee
+	GetExactPercentageFees    = types.GetExactPercentageFees
+
+	NewPendingRefunds = types.NewPendingRefunds
+
 	NewGenesisState     = types.NewGenesisState
 	ValidateGenesis     = types.ValidateGenesis
 	DefaultGenesisState = types.DefaultGenesisState
 
-	GetBondKey      = types.GetBondKey
-	GetBatchKey     = types.GetBatchKey
-	GetLastBatchKey = types.GetLastBatchKey
+	GetBondKey            = types.GetBondKey
+	GetBatchKey           = types.GetBatchKey
+	GetLastBatchKey       = types.GetLastBatchKey
+	GetLastBatchResultKey = types.GetLastBatchResultKey
+	GetBondSearchIndexKey = types.GetBondSearchIndexKey
+	GetOrderReceiptKey    = types.GetOrderReceiptKey
+	GetScheduledChangeKey = types.GetScheduledChangeKey
+	GetBondHistoryKey     = types.GetBondHistoryKey
+
+	GetBondProposalKey      = types.GetBondProposalKey
+	GetBondProposalVotesKey = types.GetBondProposalVotesKey
+	GetBondProposalVoteKey  = types.GetBondProposalVoteKey
 
-	NewMsgCreateBond         = types.NewMsgCreateBond
-	NewMsgEditBond           = types.NewMsgEditBond
-	NewMsgBuy                = types.NewMsgBuy
-	NewMsgSell               = types.NewMsgSell
-	NewMsgSwap               = types.NewMsgSwap
-	NewMsgMakeOutcomePayment = types.NewMsgMakeOutcomePayment
-	NewMsgWithdrawShare      = types.NewMsgWithdrawShare
+	GetBondEscrowAddress = types.GetBondEscrowAddress
+
+	NewMsgCreateBond              = types.NewMsgCreateBond
+	NewMsgEditBond                = types.NewMsgEditBond
+	NewMsgBuy                     = types.NewMsgBuy
+	NewMsgSell                    = types.NewMsgSell
+	NewMsgSellByValue             = types.NewMsgSellByValue
+	NewMsgSwap                    = types.NewMsgSwap
+	NewMsgMakeOutcomePayment      = types.NewMsgMakeOutcomePayment
+	NewMsgWithdrawShare           = types.NewMsgWithdrawShare
+	NewMsgAuthorizedTransfer      = types.NewMsgAuthorizedTransfer
+	NewMsgScheduleParamChange     = types.NewMsgScheduleParamChange
+	NewMsgCancelParamChange       = types.NewMsgCancelParamChange
+	NewMsgSubmitBondProposal      = types.NewMsgSubmitBondProposal
+	NewMsgVoteBondProposal        = types.NewMsgVoteBondProposal
+	NewMsgSetBondTranslations     = types.NewMsgSetBondTranslations
+	NewMsgSetSanityRate           = types.NewMsgSetSanityRate
+	NewMsgRebalanceSwap           = types.NewMsgRebalanceSwap
+	NewMsgSweepFeeDust            = types.NewMsgSweepFeeDust
+	NewMsgRegisterNotifications   = types.NewMsgRegisterNotifications
+	NewMsgUnregisterNotifications = types.NewMsgUnregisterNotifications
+
+	NewNotificationRegistration = types.NewNotificationRegistration
+	ValidateNotificationTypes   = types.ValidateNotificationTypes
 
 	ParseFunctionParams = client.ParseFunctionParams
 	ParseSigners        = client.ParseSigners
@@ -138,34 +361,214 @@ var (
 	ErrInvalidFunctionParameter             = types.ErrInvalidFunctionParameter
 	ErrArgumentMissingOrNonUInteger         = types.ErrArgumentMissingOrNonUInteger
 	ErrArgumentMissingOrNonBoolean          = types.ErrArgumentMissingOrNonBoolean
+	ErrMaxAmountInsufficientForReturns      = types.ErrMaxAmountInsufficientForReturns
+	ErrDuplicateFunctionParameter           = types.ErrDuplicateFunctionParameter
+	ErrFeeAddressCannotBeModuleAccount      = types.ErrFeeAddressCannotBeModuleAccount
+	ErrDuplicateSigner                      = types.ErrDuplicateSigner
+	ErrNetSellCapDenomDoesNotMatchToken     = types.ErrNetSellCapDenomDoesNotMatchToken
+	ErrBondTokenIsNonTransferable           = types.ErrBondTokenIsNonTransferable
+	ErrBondTokenIsTransferable              = types.ErrBondTokenIsTransferable
+	ErrReserveBalancesNotEqual              = types.ErrReserveBalancesNotEqual
+	ErrOrderSubmissionHalted                = types.ErrOrderSubmissionHalted
+	ErrUnknownBondsModuleAccount            = types.ErrUnknownBondsModuleAccount
+	ErrClaimExceedsStuckFunds               = types.ErrClaimExceedsStuckFunds
+	ErrUnrecognizedCurveVersion             = types.ErrUnrecognizedCurveVersion
+	ErrCurveVersionNotNewer                 = types.ErrCurveVersionNotNewer
+	ErrTooManyDecimalPlaces                 = types.ErrTooManyDecimalPlaces
+	ErrAttestationRequired                  = types.ErrAttestationRequired
+	ErrInvalidEffectiveHeight               = types.ErrInvalidEffectiveHeight
+	ErrParamChangeAlreadyScheduled          = types.ErrParamChangeAlreadyScheduled
+	ErrNoParamChangeScheduled               = types.ErrNoParamChangeScheduled
+	ErrInvalidMilestone                     = types.ErrInvalidMilestone
+	ErrBondGovernanceDisabled               = types.ErrBondGovernanceDisabled
+	ErrBondProposalDoesNotExist             = types.ErrBondProposalDoesNotExist
+	ErrBondProposalVotingEnded              = types.ErrBondProposalVotingEnded
+	ErrAlreadyVotedOnBondProposal           = types.ErrAlreadyVotedOnBondProposal
+	ErrInvalidBondProposalType              = types.ErrInvalidBondProposalType
+	ErrInvalidVoteOption                    = types.ErrInvalidVoteOption
+	ErrInvalidAnchorPoint                   = types.ErrInvalidAnchorPoint
+	ErrInvalidEventAttribute                = types.ErrInvalidEventAttribute
+	ErrArgumentTooLong                      = types.ErrArgumentTooLong
+	ErrInvalidBondTranslation               = types.ErrInvalidBondTranslation
+	ErrSpendCapExceeded                     = types.ErrSpendCapExceeded
+	ErrInvalidReserveMigration              = types.ErrInvalidReserveMigration
+	ErrSanityRateChangeTooLarge             = types.ErrSanityRateChangeTooLarge
+	ErrPreMineTooLarge                      = types.ErrPreMineTooLarge
+	ErrOracleRateUnavailable                = types.ErrOracleRateUnavailable
+	ErrOracleRateWithinSanityBand           = types.ErrOracleRateWithinSanityBand
+	ErrInvalidAtMaxSupplyBehavior           = types.ErrInvalidAtMaxSupplyBehavior
+	ErrBondClosedToBuys                     = types.ErrBondClosedToBuys
+	ErrBondTokenReserved                    = types.ErrBondTokenReserved
+	ErrOrdersFrozenForUpgrade               = types.ErrOrdersFrozenForUpgrade
+	ErrBondHasNoQuoteDenom                  = types.ErrBondHasNoQuoteDenom
+	ErrNoFeeDustToSweep                     = types.ErrNoFeeDustToSweep
+	ErrBatchSettlementFailed                = types.ErrBatchSettlementFailed
+	ErrInvalidNotificationType              = types.ErrInvalidNotificationType
+	ErrNotificationRegistrationDoesNotExist = types.ErrNotificationRegistrationDoesNotExist
+	ErrTooManyNotificationRegistrations     = types.ErrTooManyNotificationRegistrations
+	ErrBondHistoryNotAvailable              = types.ErrBondHistoryNotAvailable
+	ErrUnsupportedExportFormat              = types.ErrUnsupportedExportFormat
+	ErrSwapReturnBelowMinReturn             = types.ErrSwapReturnBelowMinReturn
+	ErrTooManySigners                       = types.ErrTooManySigners
+	ErrInvalidSignerThreshold               = types.ErrInvalidSignerThreshold
+	ErrInvalidCurveSegment                  = types.ErrInvalidCurveSegment
+	ErrCannotInterpolateFunctionParams      = types.ErrCannotInterpolateFunctionParams
+	ErrInvalidBondState                     = types.ErrInvalidBondState
+	ErrInvalidOrderReceipt                  = types.ErrInvalidOrderReceipt
+	ErrParamsNotBackedByReserve             = types.ErrParamsNotBackedByReserve
+	ErrInvalidRaiseDeadline                 = types.ErrInvalidRaiseDeadline
+
+	BondsKeyPrefix            = types.BondsKeyPrefix
+	BatchesKeyPrefix          = types.BatchesKeyPrefix
+	LastBatchesKeyPrefix      = types.LastBatchesKeyPrefix
+	LastBatchResultsKeyPrefix = types.LastBatchResultsKeyPrefix
+	ModuleStatsKey            = types.ModuleStatsKey
+	NextOrderIDKey            = types.NextOrderIDKey
+	OrderReceiptsKeyPrefix    = types.OrderReceiptsKeyPrefix
+	ScheduledChangesKeyPrefix = types.ScheduledChangesKeyPrefix
+	BondProposalsKeyPrefix    = types.BondProposalsKeyPrefix
+	BondProposalVotesPrefix   = types.BondProposalVotesPrefix
+	NextBondProposalIDKey     = types.NextBondProposalIDKey
+	BondHistoriesKeyPrefix    = types.BondHistoriesKeyPrefix
+	SpendRecordsKeyPrefix     = types.SpendRecordsKeyPrefix
+
+	KeyOrderSubmissionHalted  = types.KeyOrderSubmissionHalted
+	KeyBondProposalQuorum     = types.KeyBondProposalQuorum
+	KeyBondCreationFee        = types.KeyBondCreationFee
+	KeyCreationFeeDestination = types.KeyCreationFeeDestination
+	KeyMaxNameLength          = types.KeyMaxNameLength
+	KeyMaxDescriptionLength   = types.KeyMaxDescriptionLength
+	KeyBuySpendCap            = types.KeyBuySpendCap
+	KeySpendCapWindowBlocks   = types.KeySpendCapWindowBlocks
 
-	BondsKeyPrefix       = types.BondsKeyPrefix
-	BatchesKeyPrefix     = types.BatchesKeyPrefix
-	LastBatchesKeyPrefix = types.LastBatchesKeyPrefix
+	DefaultBondProposalQuorum     = types.DefaultBondProposalQuorum
+	DefaultBondCreationFee        = types.DefaultBondCreationFee
+	DefaultCreationFeeDestination = types.DefaultCreationFeeDestination
+	DefaultMaxNameLength          = types.DefaultMaxNameLength
+	DefaultMaxDescriptionLength   = types.DefaultMaxDescriptionLength
+	DefaultBuySpendCap            = types.DefaultBuySpendCap
+	DefaultSpendCapWindowBlocks   = types.DefaultSpendCapWindowBlocks
 )
 
 type (
-	Keeper = keeper.Keeper
+	Keeper                = keeper.Keeper
+	NoOpAttestationKeeper = keeper.NoOpAttestationKeeper
+	NoOpReserveConverter  = keeper.NoOpReserveConverter
+	NoOpOracleKeeper      = keeper.NoOpOracleKeeper
+	NoOpUpgradeKeeper     = keeper.NoOpUpgradeKeeper
+	BondPrices            = keeper.BondPrices
+
+	AttestationKeeper = types.AttestationKeeper
+	ReserveConverter  = types.ReserveConverter
+	OracleKeeper      = types.OracleKeeper
+	UpgradeKeeper     = types.UpgradeKeeper
+
+	Batch          = types.Batch
+	BaseOrder      = types.BaseOrder
+	BuyOrder       = types.BuyOrder
+	SellOrder      = types.SellOrder
+	SwapOrder      = types.SwapOrder
+	CancelledOrder = types.CancelledOrder
+	BatchResult    = types.BatchResult
+	BatchStress    = types.BatchStress
+	BondCount      = types.BondCount
+	ModuleStats    = types.ModuleStats
+
+	RebuildIndexesReport = types.RebuildIndexesReport
+	SignerThreshold      = types.SignerThreshold
+	CurveSegment         = types.CurveSegment
+
+	BondSearchIndexEntry = types.BondSearchIndexEntry
 
-	Batch     = types.Batch
-	BaseOrder = types.BaseOrder
-	BuyOrder  = types.BuyOrder
-	SellOrder = types.SellOrder
-	SwapOrder = types.SwapOrder
+	QueryBatchOrdersParams    = types.QueryBatchOrdersParams
+	QueryIsTradeAllowedParams = types.QueryIsTradeAllowedParams
+	QueryIsTradeAllowed       = types.QueryIsTradeAllowed
+	QueryBatchOrders          = types.QueryBatchOrders
+	QueryEffectiveBatchBlocks = types.QueryEffectiveBatchBlocks
+	QueryFeePreview           = types.QueryFeePreview
+	QueryBond                 = types.QueryBond
+	BatchOrder                = types.BatchOrder
+	QueryLedgerParams         = types.QueryLedgerParams
+	QueryLedger               = types.QueryLedger
+	BondLedger                = types.BondLedger
+	LedgerBalance             = types.LedgerBalance
+	LedgerEntry               = types.LedgerEntry
+
+	OrderReceipt = types.OrderReceipt
+
+	ScheduledParamChange = types.ScheduledParamChange
+
+	Milestone = types.Milestone
+
+	EventAttribute  = types.EventAttribute
+	EventAttributes = types.EventAttributes
+
+	BondTranslation  = types.BondTranslation
+	BondTranslations = types.BondTranslations
+
+	SpendRecord       = types.SpendRecord
+	SpendRecords      = types.SpendRecords
+	SpendRecordsEntry = types.SpendRecordsEntry
+
+	VestingSchedule = types.VestingSchedule
+
+	BondProposal     = types.BondProposal
+	BondProposalVote = types.BondProposalVote
+
+	BondSnapshot = types.BondSnapshot
+	BondHistory  = types.BondHistory
+
+	NotificationRegistration = types.NotificationRegistration
+
+	Params = types.Params
+
+	ClaimStuckFundsProposal     = types.ClaimStuckFundsProposal
+	MigrateCurveVersionProposal = types.MigrateCurveVersionProposal
+	MigrateReserveTokenProposal = types.MigrateReserveTokenProposal
+	EditBondProposal            = types.EditBondProposal
+	PendingBondEdit             = types.PendingBondEdit
 
 	FunctionParamRestrictions = types.FunctionParamRestrictions
 	FunctionParam             = types.FunctionParam
+	Rational                  = types.Rational
 	FunctionParams            = types.FunctionParams
 
 	Bond = types.Bond
 
+	Percentage = types.Percentage
+
+	FeeOrder               = types.FeeOrder
+	Fee                    = types.Fee
+	Fees                   = types.Fees
+	FeeDecorator           = types.FeeDecorator
+	FeeChain               = types.FeeChain
+	PercentageFeeDecorator = types.PercentageFeeDecorator
+	SellFeesCapDecorator   = types.SellFeesCapDecorator
+
+	Refund         = types.Refund
+	PendingRefunds = types.PendingRefunds
+
+	FunctionParamsCache = types.FunctionParamsCache
+
 	GenesisState = types.GenesisState
 
-	MsgCreateBond         = types.MsgCreateBond
-	MsgEditBond           = types.MsgEditBond
-	MsgBuy                = types.MsgBuy
-	MsgSell               = types.MsgSell
-	MsgSwap               = types.MsgSwap
-	MsgMakeOutcomePayment = types.MsgMakeOutcomePayment
-	MsgWithdrawShare      = types.MsgWithdrawShare
+	MsgCreateBond              = types.MsgCreateBond
+	MsgEditBond                = types.MsgEditBond
+	MsgBuy                     = types.MsgBuy
+	MsgSell                    = types.MsgSell
+	MsgSellByValue             = types.MsgSellByValue
+	MsgSwap                    = types.MsgSwap
+	MsgMakeOutcomePayment      = types.MsgMakeOutcomePayment
+	MsgWithdrawShare           = types.MsgWithdrawShare
+	MsgAuthorizedTransfer      = types.MsgAuthorizedTransfer
+	MsgScheduleParamChange     = types.MsgScheduleParamChange
+	MsgCancelParamChange       = types.MsgCancelParamChange
+	MsgSubmitBondProposal      = types.MsgSubmitBondProposal
+	MsgVoteBondProposal        = types.MsgVoteBondProposal
+	MsgSetBondTranslations     = types.MsgSetBondTranslations
+	MsgSetSanityRate           = types.MsgSetSanityRate
+	MsgRebalanceSwap           = types.MsgRebalanceSwap
+	MsgSweepFeeDust            = types.MsgSweepFeeDust
+	MsgRegisterNotifications   = types.MsgRegisterNotifications
+	MsgUnregisterNotifications = types.MsgUnregisterNotifications
 )
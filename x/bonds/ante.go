@@ -0,0 +1,155 @@
+package bonds
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// NonTransferableDecorator rejects any transaction that uses the bank module
+// to send bond tokens of a non-transferable bond. Such tokens can thus only be
+// minted to the account that bought them and burned from it when sold.
+//
+// Note: the bank module does not provide a way to restrict sends, so transfers
+// are restricted at the transaction level, by inspecting bank messages.
+type NonTransferableDecorator struct {
+	keeper keeper.Keeper
+}
+
+func NewNonTransferableDecorator(keeper keeper.Keeper) NonTransferableDecorator {
+	return NonTransferableDecorator{
+		keeper: keeper,
+	}
+}
+
+func (ntd NonTransferableDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	for _, msg := range tx.GetMsgs() {
+		var sent []sdk.Coins
+		switch msg := msg.(type) {
+		case bank.MsgSend:
+			sent = append(sent, msg.Amount)
+		case bank.MsgMultiSend:
+			for _, in := range msg.Inputs {
+				sent = append(sent, in.Coins)
+			}
+		}
+
+		for _, coins := range sent {
+			for _, c := range coins {
+				if ntd.keeper.IsNonTransferable(ctx, c.Denom) {
+					return ctx, sdkerrors.Wrap(types.ErrBondTokenIsNonTransferable, c.Denom)
+				}
+			}
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// OrderPrecheckDecorator rejects bond orders that are bound to fail in the
+// handler, so that they are kept out of the mempool instead of taking up space
+// in a block. Only cheap checks against the bond are performed (i.e. the bond
+// exists, orders are not halted or frozen, the bond's state and settings allow
+// the order, the denominations match, and order quantity limits are not
+// exceeded), and only in CheckTx, since the handler performs the same checks.
+//
+// Note: the decorator is optional, and is not required for the correctness of
+// the bonds module. Checks that depend on balances or on the bond's batch are
+// left to the handler, since these can change before the order is delivered.
+type OrderPrecheckDecorator struct {
+	keeper keeper.Keeper
+}
+
+func NewOrderPrecheckDecorator(keeper keeper.Keeper) OrderPrecheckDecorator {
+	return OrderPrecheckDecorator{
+		keeper: keeper,
+	}
+}
+
+func (opd OrderPrecheckDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	if !ctx.IsCheckTx() {
+		return next(ctx, tx, simulate)
+	}
+
+	for _, msg := range tx.GetMsgs() {
+		if err := opd.precheck(ctx, msg); err != nil {
+			return ctx, err
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+func (opd OrderPrecheckDecorator) precheck(ctx sdk.Context, msg sdk.Msg) error {
+	var token string
+	switch msg := msg.(type) {
+	case types.MsgBuy:
+		token = msg.Amount.Denom
+	case types.MsgSell:
+		token = msg.Amount.Denom
+	case types.MsgSellByValue:
+		token = msg.MaxAmount.Denom
+	case types.MsgSwap:
+		token = msg.BondToken
+	default:
+		return nil
+	}
+
+	if opd.keeper.OrderSubmissionHalted(ctx) {
+		return types.ErrOrderSubmissionHalted
+	}
+
+	bond, found := opd.keeper.GetBond(ctx, token)
+	if !found {
+		return sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	} else if opd.keeper.OrdersFrozenForUpgrade(ctx, bond) {
+		return sdkerrors.Wrap(types.ErrOrdersFrozenForUpgrade, bond.Token)
+	}
+
+	switch msg := msg.(type) {
+	case types.MsgBuy:
+		// Max prices are not checked, since they can also be given in
+		// derivatives of the reserve tokens
+		if err := bond.CheckStateAllows(types.ActionBuy); err != nil {
+			return err
+		} else if bond.BuysClosed {
+			return sdkerrors.Wrap(types.ErrBondClosedToBuys, bond.Token)
+		} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
+			return sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+		}
+		for _, c := range msg.PriorityFee {
+			if !bond.IsReserveToken(c.Denom) {
+				return sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "priority fee %s does not match reserve", msg.PriorityFee)
+			}
+		}
+	case types.MsgSell:
+		if !bond.AllowSells {
+			return sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, token)
+		} else if err := bond.CheckStateAllows(types.ActionSell); err != nil {
+			return err
+		} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
+			return sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+		}
+	case types.MsgSellByValue:
+		if !bond.AllowSells {
+			return sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, token)
+		} else if err := bond.CheckStateAllows(types.ActionSell); err != nil {
+			return err
+		} else if !bond.ReserveDenomsEqualTo(msg.Returns) {
+			return sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve", msg.Returns)
+		}
+	case types.MsgSwap:
+		if !types.IsSwapperFunctionType(bond.FunctionType) {
+			return sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+		} else if err := bond.CheckStateAllows(types.ActionSwap); err != nil {
+			return err
+		} else if _, err := opd.keeper.GetSwapVia(ctx, bond, msg.From.Denom, msg.ToToken); err != nil {
+			return err
+		} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.From}) {
+			return sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.From.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package bonds_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/ixoworld/bonds/x/bonds"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func nextAnteHandler(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+	return ctx, nil
+}
+
+func TestNonTransferableDecoratorAllowsTransferableBondTokens(t *testing.T) {
+	app, ctx := createTestApp(false)
+	decorator := bonds.NewNonTransferableDecorator(app.BondsKeeper)
+
+	// Create transferable bond
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
+
+	msg := bank.NewMsgSend(userAddress, anotherAddress,
+		sdk.NewCoins(sdk.NewInt64Coin(token, 1)))
+	tx := auth.NewStdTx([]sdk.Msg{msg}, auth.StdFee{}, nil, "")
+	_, err := decorator.AnteHandle(ctx, tx, false, nextAnteHandler)
+
+	require.NoError(t, err)
+}
+
+func TestNonTransferableDecoratorRejectsNonTransferableBondTokens(t *testing.T) {
+	app, ctx := createTestApp(false)
+	decorator := bonds.NewNonTransferableDecorator(app.BondsKeeper)
+
+	// Create non-transferable bond
+	bond := newSimpleBond()
+	bond.NonTransferable = true
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	msg := bank.NewMsgSend(userAddress, anotherAddress,
+		sdk.NewCoins(sdk.NewInt64Coin(token, 1)))
+	tx := auth.NewStdTx([]sdk.Msg{msg}, auth.StdFee{}, nil, "")
+	_, err := decorator.AnteHandle(ctx, tx, false, nextAnteHandler)
+
+	require.Error(t, err)
+	require.True(t, types.ErrBondTokenIsNonTransferable.Is(err))
+}
+
+func TestNonTransferableDecoratorRejectsNonTransferableBondTokensInMultiSend(t *testing.T) {
+	app, ctx := createTestApp(false)
+	decorator := bonds.NewNonTransferableDecorator(app.BondsKeeper)
+
+	// Create non-transferable bond
+	bond := newSimpleBond()
+	bond.NonTransferable = true
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	coins := sdk.NewCoins(sdk.NewInt64Coin(token, 1))
+	msg := bank.NewMsgMultiSend(
+		[]bank.Input{bank.NewInput(userAddress, coins)},
+		[]bank.Output{bank.NewOutput(anotherAddress, coins)})
+	tx := auth.NewStdTx([]sdk.Msg{msg}, auth.StdFee{}, nil, "")
+	_, err := decorator.AnteHandle(ctx, tx, false, nextAnteHandler)
+
+	require.Error(t, err)
+	require.True(t, types.ErrBondTokenIsNonTransferable.Is(err))
+}
+
+func TestNonTransferableBondCanStillBeBoughtAndSold(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create non-transferable bond
+	msg := newValidMsgCreateBond()
+	msg.NonTransferable = true
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+	require.True(t, app.BondsKeeper.IsNonTransferable(ctx, token))
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 2 tokens
+	_, err = h(ctx, newValidMsgSell(2))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token))
+}
+
+func TestOrderPrecheckDecoratorRejectsDoomedOrdersInCheckTx(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	decorator := bonds.NewOrderPrecheckDecorator(app.BondsKeeper)
+	checkCtx := ctx.WithIsCheckTx(true)
+
+	// Create bond with an order quantity limit of 100 tokens
+	createMsg := newValidMsgCreateBond()
+	createMsg.OrderQuantityLimits = sdk.NewCoins(sdk.NewInt64Coin(token, 100))
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	priorityFeeMsg := newValidMsgBuy(10, 1000)
+	priorityFeeMsg.PriorityFee = sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 1))
+
+	testCases := []struct {
+		msg         sdk.Msg
+		expectedErr *sdkerrors.Error
+	}{
+		{newValidMsgBuy(10, 1000), nil},
+		{newValidMsgBuy(101, 1000), types.ErrOrderQuantityLimitExceeded},
+		{types.NewMsgBuy(userAddress, sdk.NewInt64Coin("nonexistent", 10),
+			sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000))), types.ErrBondDoesNotExist},
+		{priorityFeeMsg, types.ErrReserveDenomsMismatch},
+		{newValidMsgSell(10), nil},
+		{newValidMsgSell(101), types.ErrOrderQuantityLimitExceeded},
+		{newValidMsgSellByValue(10, 10), nil},
+		{newValidMsgSwap(reserveToken, reserveToken2, 10), types.ErrFunctionNotAvailableForFunctionType},
+		{bank.NewMsgSend(userAddress, anotherAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 1))), nil},
+	}
+	for i, tc := range testCases {
+		tx := auth.NewStdTx([]sdk.Msg{tc.msg}, auth.StdFee{}, nil, "")
+		_, err := decorator.AnteHandle(checkCtx, tx, false, nextAnteHandler)
+		if tc.expectedErr == nil {
+			require.NoError(t, err, "test case #%d", i)
+		} else {
+			require.True(t, tc.expectedErr.Is(err), "test case #%d: %v", i, err)
+		}
+
+		// Orders are never rejected in DeliverTx, where the handler
+		// performs the same checks
+		_, err = decorator.AnteHandle(ctx, tx, false, nextAnteHandler)
+		require.NoError(t, err, "test case #%d", i)
+	}
+}
+
+func TestOrderPrecheckDecoratorRejectsSellsWhenNotAllowed(t *testing.T) {
+	app, ctx := createTestApp(false)
+	decorator := bonds.NewOrderPrecheckDecorator(app.BondsKeeper)
+	checkCtx := ctx.WithIsCheckTx(true)
+
+	// Open bond that does not allow sells
+	bond := newSimpleBond()
+	bond.State = types.OpenState
+	bond.ReserveTokens = powerReserves()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	tx := auth.NewStdTx([]sdk.Msg{newValidMsgSell(10)}, auth.StdFee{}, nil, "")
+	_, err := decorator.AnteHandle(checkCtx, tx, false, nextAnteHandler)
+	require.True(t, types.ErrBondDoesNotAllowSelling.Is(err))
+
+	// Sells are let through once they are allowed
+	bond.AllowSells = true
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	_, err = decorator.AnteHandle(checkCtx, tx, false, nextAnteHandler)
+	require.NoError(t, err)
+
+	// Sells by value are also checked against the reserve tokens
+	tx = auth.NewStdTx([]sdk.Msg{types.NewMsgSellByValue(userAddress,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 10)), sdk.NewInt64Coin(token, 10))},
+		auth.StdFee{}, nil, "")
+	_, err = decorator.AnteHandle(checkCtx, tx, false, nextAnteHandler)
+	require.True(t, types.ErrReserveDenomsMismatch.Is(err))
+}
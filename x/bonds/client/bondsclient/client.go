@@ -0,0 +1,231 @@
+package bondsclient
+
+import (
+	gocontext "context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	// subscriber is the name under which the client subscribes to events
+	subscriber = "bondsclient"
+
+	// newBlockQuery matches every new block, whose end-block events include
+	// the events emitted when the bond's batch of orders is processed
+	newBlockQuery = "tm.event='NewBlock'"
+)
+
+// Client is a typed client for the bonds module that performs queries and
+// transactions against a node through the given CLIContext. The CLIContext
+// should have a codec with the module's types registered and, for txs, the
+// name and address of the signing key set.
+type Client struct {
+	cliCtx     context.CLIContext
+	queryRoute string
+}
+
+func NewClient(cliCtx context.CLIContext) Client {
+	return Client{
+		cliCtx:     cliCtx,
+		queryRoute: types.QuerierRoute,
+	}
+}
+
+// AtHeight returns a client whose queries are made against the state at the
+// specified height, which requires the node to not have pruned that state.
+// GetBondAtHeight can be used instead for the bond's supply, reserve, and
+// prices at past batch boundaries, even against pruned nodes.
+func (c Client) AtHeight(height int64) Client {
+	c.cliCtx = c.cliCtx.WithHeight(height)
+	return c
+}
+
+func (c Client) query(out interface{}, path string, args ...interface{}) error {
+	res, _, err := c.cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s",
+		c.queryRoute, fmt.Sprintf(path, args...)), nil)
+	if err != nil {
+		return err
+	}
+	return c.cliCtx.Codec.UnmarshalJSON(res, out)
+}
+
+// GetBond returns the bond with the specified token
+func (c Client) GetBond(bondToken string) (bond types.Bond, err error) {
+	queryBond, err := c.GetBondWithDerivedFields(bondToken)
+	return queryBond.Bond, err
+}
+
+// GetBondWithDerivedFields returns the bond with the specified token along
+// with its spot prices, implied reserve, reserve ratio, phase, and next batch
+// height
+func (c Client) GetBondWithDerivedFields(bondToken string) (queryBond types.QueryBond, err error) {
+	err = c.query(&queryBond, "bond/%s", bondToken)
+	return queryBond, err
+}
+
+// ListBonds returns all bonds, in the order of their tokens
+func (c Client) ListBonds() (bonds []types.Bond, err error) {
+	var bondTokens types.QueryBonds
+	if err := c.query(&bondTokens, "bonds"); err != nil {
+		return nil, err
+	}
+
+	for _, bondToken := range bondTokens {
+		bond, err := c.GetBond(bondToken)
+		if err != nil {
+			return nil, err
+		}
+		bonds = append(bonds, bond)
+	}
+	return bonds, nil
+}
+
+// GetBondAtHeight returns the supply, reserve, and prices of the bond with the
+// specified token at its last batch at or before the height, as recorded in
+// the bond's history
+func (c Client) GetBondAtHeight(bondToken string, height int64) (bondAtHeight types.QueryBondAtHeight, err error) {
+	err = c.query(&bondAtHeight, "bond_at_height/%s/%d", bondToken, height)
+	return bondAtHeight, err
+}
+
+// GetQuote returns the prices and fees that buying the specified amount of
+// bond tokens would currently be charged
+func (c Client) GetQuote(bondAmount sdk.Coin) (quote types.QueryBuyPrice, err error) {
+	err = c.query(&quote, "buy_price/%s/%s", bondAmount.Denom, bondAmount.Amount)
+	return quote, err
+}
+
+// GetSellQuote returns the returns and fees that selling the specified amount
+// of bond tokens would currently be given
+func (c Client) GetSellQuote(bondAmount sdk.Coin) (quote types.QuerySellReturn, err error) {
+	err = c.query(&quote, "sell_return/%s/%s", bondAmount.Denom, bondAmount.Amount)
+	return quote, err
+}
+
+// GetCurrentPrice returns the current price of the bond with the specified token
+func (c Client) GetCurrentPrice(bondToken string) (prices sdk.DecCoins, err error) {
+	err = c.query(&prices, "current_price/%s", bondToken)
+	return prices, err
+}
+
+// GetBatch returns the current batch of the bond with the specified token
+func (c Client) GetBatch(bondToken string) (batch types.Batch, err error) {
+	err = c.query(&batch, "batch/%s", bondToken)
+	return batch, err
+}
+
+// GetOrderByReceipt returns the order receipt with the specified receipt
+func (c Client) GetOrderByReceipt(receipt string) (orderReceipt types.OrderReceipt, err error) {
+	err = c.query(&orderReceipt, "order_by_receipt/%s", receipt)
+	return orderReceipt, err
+}
+
+// SubmitMsgs signs the msgs with the CLIContext's key and broadcasts them in
+// a tx, using the CLIContext's broadcast mode. The account number and
+// sequence are looked up if not set in the TxBuilder.
+func (c Client) SubmitMsgs(txBldr auth.TxBuilder, msgs ...sdk.Msg) (res sdk.TxResponse, err error) {
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return res, err
+		}
+	}
+
+	txBldr, err = utils.PrepareTxBuilder(txBldr, c.cliCtx)
+	if err != nil {
+		return res, err
+	}
+
+	if txBldr.SimulateAndExecute() {
+		txBldr, err = utils.EnrichWithGas(txBldr, c.cliCtx, msgs)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	txBytes, err := txBldr.BuildAndSign(c.cliCtx.GetFromName(), keys.DefaultKeyPass, msgs)
+	if err != nil {
+		return res, err
+	}
+
+	res, err = c.cliCtx.BroadcastTx(txBytes)
+	if err != nil {
+		return res, err
+	} else if res.Code != abci.CodeTypeOK {
+		return res, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest,
+			"tx %s failed with code %d: %s", res.TxHash, res.Code, res.RawLog)
+	}
+	return res, nil
+}
+
+// SubmitBuy submits a buy of the specified amount of bond tokens from the
+// CLIContext's address, with the specified max prices
+func (c Client) SubmitBuy(txBldr auth.TxBuilder, bondAmount sdk.Coin,
+	maxPrices sdk.Coins) (sdk.TxResponse, error) {
+	msg := types.NewMsgBuy(c.cliCtx.GetFromAddress(), bondAmount, maxPrices)
+	return c.SubmitMsgs(txBldr, msg)
+}
+
+// SubmitBuyAndWaitForBatch submits a buy like SubmitBuy and then waits until
+// the buy is fulfilled or cancelled at the end of the bond's batch, following
+// any deferrals, or until the context is done. Since fulfillment events do not
+// identify individual orders, the first buy outcome for the bond and address
+// is taken as the outcome of this buy, so the address should not have other
+// buys for the bond pending at the same time.
+func (c Client) SubmitBuyAndWaitForBatch(ctx gocontext.Context, txBldr auth.TxBuilder,
+	bondAmount sdk.Coin, maxPrices sdk.Coins) (result BuyResult, err error) {
+
+	if c.cliCtx.Client == nil {
+		return result, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"no RPC client is available to subscribe to events")
+	} else if !c.cliCtx.Client.IsRunning() {
+		if err := c.cliCtx.Client.Start(); err != nil {
+			return result, err
+		}
+	}
+
+	// Subscribe before submitting, so that the block that processes the
+	// buy cannot be missed if the batch ends in the block that includes it
+	blocks, err := c.cliCtx.Client.Subscribe(ctx, subscriber, newBlockQuery)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		_ = c.cliCtx.Client.Unsubscribe(gocontext.Background(), subscriber, newBlockQuery)
+	}()
+
+	res, err := c.SubmitBuy(txBldr, bondAmount, maxPrices)
+	if err != nil {
+		return result, err
+	}
+	result.TxResponse = res
+	result.OrderReceipt = findOrderReceipt(res, types.EventTypeBuy)
+
+	address := c.cliCtx.GetFromAddress()
+	for {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case event := <-blocks:
+			data, ok := event.Data.(tmtypes.EventDataNewBlock)
+			if !ok {
+				continue
+			}
+			outcome, found := findOrderOutcome(data.ResultEndBlock.Events,
+				bondAmount.Denom, types.AttributeValueBuyOrder, address)
+			if found {
+				result.Height = data.Block.Height
+				result.Outcome = outcome
+				return result, nil
+			}
+		}
+	}
+}
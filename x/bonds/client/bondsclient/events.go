@@ -0,0 +1,72 @@
+package bondsclient
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// OrderOutcome is the fulfillment or cancellation of an order, as emitted at
+// the end of its batch. Attributes holds all attributes of the event.
+type OrderOutcome struct {
+	Fulfilled    bool              `json:"fulfilled" yaml:"fulfilled"`
+	CancelReason string            `json:"cancel_reason,omitempty" yaml:"cancel_reason,omitempty"`
+	Attributes   map[string]string `json:"attributes" yaml:"attributes"`
+}
+
+// BuyResult is the result of a buy submitted by SubmitBuyAndWaitForBatch
+type BuyResult struct {
+	TxResponse   sdk.TxResponse `json:"tx_response" yaml:"tx_response"`
+	OrderReceipt string         `json:"order_receipt" yaml:"order_receipt"`
+	Height       int64          `json:"height" yaml:"height"`
+	Outcome      OrderOutcome   `json:"outcome" yaml:"outcome"`
+}
+
+// findOrderReceipt returns the order receipt of the first event of the
+// specified type in the tx's logs, or a blank string if there is none
+func findOrderReceipt(res sdk.TxResponse, eventType string) string {
+	for _, log := range res.Logs {
+		for _, event := range log.Events {
+			if event.Type != eventType {
+				continue
+			}
+			for _, attr := range event.Attributes {
+				if attr.Key == types.AttributeKeyOrderReceipt {
+					return attr.Value
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// findOrderOutcome returns the first order fulfill or cancel event for the
+// specified bond, order type, and address. Deferrals are not outcomes, since
+// deferred orders are retried in the next batch.
+func findOrderOutcome(events []abci.Event, bondToken, orderType string,
+	address sdk.AccAddress) (outcome OrderOutcome, found bool) {
+	for _, event := range events {
+		if event.Type != types.EventTypeOrderFulfill &&
+			event.Type != types.EventTypeOrderCancel {
+			continue
+		}
+
+		attributes := make(map[string]string)
+		for _, attr := range event.Attributes {
+			attributes[string(attr.Key)] = string(attr.Value)
+		}
+
+		if attributes[types.AttributeKeyBond] != bondToken ||
+			attributes[types.AttributeKeyOrderType] != orderType ||
+			attributes[types.AttributeKeyAddress] != address.String() {
+			continue
+		}
+
+		return OrderOutcome{
+			Fulfilled:    event.Type == types.EventTypeOrderFulfill,
+			CancelReason: attributes[types.AttributeKeyCancelReason],
+			Attributes:   attributes,
+		}, true
+	}
+	return OrderOutcome{}, false
+}
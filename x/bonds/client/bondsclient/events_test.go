@@ -0,0 +1,69 @@
+package bondsclient
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+var (
+	addr1 = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr2 = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+)
+
+func abciEvents(events ...types.TypedEvent) []abci.Event {
+	return newSdkEvents(events...).ToABCIEvents()
+}
+
+func newSdkEvents(events ...types.TypedEvent) (sdkEvents sdk.Events) {
+	for _, e := range events {
+		sdkEvents = append(sdkEvents, types.NewEvent(e))
+	}
+	return sdkEvents
+}
+
+func TestFindOrderOutcome(t *testing.T) {
+	events := abciEvents(
+		types.OrderDeferEvent{Bond: "abc", OrderType: types.AttributeValueBuyOrder,
+			Address: addr1, TokensDeferred: sdk.NewInt(10)},
+		types.BuyOrderFulfillEvent{Bond: "xyz", OrderType: types.AttributeValueBuyOrder,
+			Address: addr1, TokensMinted: sdk.NewInt(10)},
+		types.SellOrderFulfillEvent{Bond: "abc", OrderType: types.AttributeValueSellOrder,
+			Address: addr1, TokensBurned: sdk.NewInt(10)},
+		types.BuyOrderFulfillEvent{Bond: "abc", OrderType: types.AttributeValueBuyOrder,
+			Address: addr2, TokensMinted: sdk.NewInt(20)},
+		types.OrderCancelEvent{Bond: "abc", OrderType: types.AttributeValueBuyOrder,
+			Address: addr1, CancelReason: "max prices exceeded"},
+	)
+
+	// Deferral and events for other bonds, order types, and addresses ignored
+	outcome, found := findOrderOutcome(events, "abc", types.AttributeValueBuyOrder, addr1)
+	require.True(t, found)
+	require.False(t, outcome.Fulfilled)
+	require.Equal(t, "max prices exceeded", outcome.CancelReason)
+
+	outcome, found = findOrderOutcome(events, "abc", types.AttributeValueBuyOrder, addr2)
+	require.True(t, found)
+	require.True(t, outcome.Fulfilled)
+	require.Equal(t, "20", outcome.Attributes[types.AttributeKeyTokensMinted])
+
+	// No outcome if the order was only deferred
+	_, found = findOrderOutcome(events[:1], "abc", types.AttributeValueBuyOrder, addr1)
+	require.False(t, found)
+}
+
+func TestFindOrderReceipt(t *testing.T) {
+	res := sdk.TxResponse{Logs: sdk.ABCIMessageLogs{
+		sdk.NewABCIMessageLog(0, "", newSdkEvents(
+			types.BuyEvent{Bond: "abc", Amount: sdk.NewInt(10),
+				MaxPrices: sdk.NewCoins(sdk.NewInt64Coin("res", 100)),
+				OrderID:   1, OrderReceipt: "receipt"},
+		)),
+	}}
+
+	require.Equal(t, "receipt", findOrderReceipt(res, types.EventTypeBuy))
+	require.Equal(t, "", findOrderReceipt(res, types.EventTypeSell))
+}
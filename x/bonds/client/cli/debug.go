@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/codec"
+	client2 "github.com/ixoworld/bonds/x/bonds/client"
+	"github.com/spf13/cobra"
+)
+
+// GetCmdCurveVectors returns a hidden command, meant to be added to the debug
+// command of the daemon, which prints the canonical pricing test vectors of
+// the bonds module as JSON.
+func GetCmdCurveVectors(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "curve-vectors",
+		Short: "Print the canonical pricing test vectors of the bonds module as JSON",
+		Long: `Print the canonical pricing test vectors of the bonds module as JSON, i.e.
+the prices and returns calculated by the module for each function type with a
+number of function parameter sets, supplies, reserves, and order amounts.
+Alternative client implementations can use these to verify that their pricing
+matches the chain's pricing exactly. This command does not query the chain.`,
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vectors, err := client2.GenerateCurveVectors()
+			if err != nil {
+				return err
+			}
+
+			bz, err := codec.MarshalJSONIndent(cdc, vectors)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return err
+		},
+	}
+}
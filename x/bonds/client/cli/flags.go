@@ -20,9 +20,41 @@ const (
 	FlagSanityRate             = "sanity-rate"
 	FlagSanityMarginPercentage = "sanity-margin-percentage"
 	FlagAllowSells             = "allow-sells"
+	FlagNonTransferable        = "non-transferable"
+	FlagRequireAttestation     = "require-attestation"
 	FlagSigners                = "signers"
 	FlagBatchBlocks            = "batch-blocks"
 	FlagOutcomePayment         = "outcome-payment"
+	FlagMilestones             = "milestones"
+	FlagProposalVotingBlocks   = "proposal-voting-blocks"
+	FlagEventAttributes        = "event-attributes"
+	FlagNetSellCap             = "net-sell-cap"
+	FlagNetSellCapPercentage   = "net-sell-cap-percentage"
+	FlagDemurrageRate          = "demurrage-rate"
+	FlagQuoteDenom             = "quote-denom"
+	FlagMinReserve             = "min-reserve"
+	FlagMinReservePercentage   = "min-reserve-percentage"
+	FlagLimit                  = "limit"
+	FlagProposalType           = "proposal-type"
+	FlagFundingRecipient       = "funding-recipient"
+	FlagFundingAmount          = "funding-amount"
+	FlagMaxFitError            = "max-fit-error"
+	FlagCallbackPayload        = "callback-payload"
+	FlagOrderMemo              = "order-memo"
+	FlagPage                   = "page"
+	FlagSide                   = "side"
+	FlagAccount                = "account"
+	FlagStatus                 = "status"
+	FlagPreMine                = "pre-mine"
+	FlagAtMaxSupplyBehavior    = "at-max-supply-behavior"
+	FlagPriorityFee            = "priority-fee"
+	FlagMinReturn              = "min-return"
+	FlagFormat                 = "format"
+	FlagSignerThreshold        = "signer-threshold"
+	FlagCurveSegments          = "curve-segments"
+	FlagInterpolate            = "interpolate"
+	FlagSoftCap                = "soft-cap"
+	FlagRaiseDeadline          = "raise-deadline"
 )
 
 var (
@@ -39,7 +71,7 @@ func init() {
 	fsBondCreate.String(FlagName, "", "The bond's name")
 	fsBondCreate.String(FlagDescription, "", "The bond's description")
 	fsBondCreate.String(FlagFunctionType, "", "The type of function that the bond will be")
-	fsBondCreate.String(FlagFunctionParameters, "", "The parameters that will define the function")
+	fsBondCreate.String(FlagFunctionParameters, "", "The parameters that will define the function (values can be given as fractions, e.g. m:1/3)")
 	fsBondCreate.String(FlagReserveTokens, "", "The token(s) that will serve as the reserve token(s)")
 	fsBondCreate.String(FlagTxFeePercentage, "", "The percentage fee charged on buys and sells")
 	fsBondCreate.String(FlagExitFeePercentage, "", "The percentage fee charged on sells")
@@ -49,12 +81,31 @@ func init() {
 	fsBondCreate.String(FlagSanityRate, "", "For swappers, this is the typical t1 per t2 rate")
 	fsBondCreate.String(FlagSanityMarginPercentage, "", "For swappers, this is the acceptable deviation from the sanity rate")
 	fsBondCreate.Bool(FlagAllowSells, false, "Whether or not sells will be allowed")
+	fsBondCreate.Bool(FlagNonTransferable, false, "Whether or not bond tokens will be bound to the account that bought them")
+	fsBondCreate.Bool(FlagRequireAttestation, false, "Whether or not buyers and sellers will require a valid attestation")
 	fsBondCreate.String(FlagBatchBlocks, "", "The duration in terms of blocks of each orders batch")
 	fsBondCreate.String(FlagOutcomePayment, "", "The payment that would be required to transition the bond to settlement")
+	fsBondCreate.String(FlagMilestones, "", "The bond's reserve milestones as a JSON array")
+	fsBondCreate.Uint64(FlagProposalVotingBlocks, 0, "The voting period in blocks of bond proposals (0 to disable bond governance)")
+	fsBondCreate.String(FlagEventAttributes, "", "The static key:value attributes attached to every event of the bond")
+	fsBondCreate.String(FlagPreMine, "", "The amount of bond tokens pre-mined for the creator, subject to vesting")
+	fsBondCreate.String(FlagCurveSegments, "", "The curve segments of a piecewise function as a JSON array")
+	fsBondCreate.String(FlagSignerThreshold, "", "The weight of signers required to authorize the bond's administrative messages, optionally followed by one weight per signer, e.g. 2 or 3:2,1,1 (blank for all signers)")
+	fsBondCreate.String(FlagSoftCap, "", "The minimum raise, in reserve tokens, that the reserve must reach by the raise deadline")
+	fsBondCreate.Int64(FlagRaiseDeadline, 0, "The block height by which the soft cap must be reached, otherwise the bond fails and its holders are refunded")
+	fsBondCreate.String(FlagAtMaxSupplyBehavior, types.AtMaxSupplyAllowRebuys, "What happens once the supply reaches the max supply (allow_rebuys, close_to_buys, or auto_settle)")
 
-	fsBondEdit.String(FlagName, types.DoNotModifyField, "The bond's name")
-	fsBondEdit.String(FlagDescription, types.DoNotModifyField, "The bond's description")
-	fsBondEdit.String(FlagOrderQuantityLimits, types.DoNotModifyField, "The max number of tokens bought/sold/swapped per order")
-	fsBondEdit.String(FlagSanityRate, types.DoNotModifyField, "For swappers, this is the typical t1 per t2 rate")
-	fsBondEdit.String(FlagSanityMarginPercentage, types.DoNotModifyField, "For swappers, this is the acceptable deviation from the sanity rate")
+	fsBondEdit.String(FlagName, "", "The bond's name")
+	fsBondEdit.String(FlagDescription, "", "The bond's description")
+	fsBondEdit.String(FlagOrderQuantityLimits, "", "The max number of tokens bought/sold/swapped per order")
+	fsBondEdit.String(FlagSanityRate, "", "For swappers, this is the typical t1 per t2 rate")
+	fsBondEdit.String(FlagSanityMarginPercentage, "", "For swappers, this is the acceptable deviation from the sanity rate")
+	fsBondEdit.String(FlagNetSellCap, "", "The max net amount of tokens sold per batch (excess sells are deferred)")
+	fsBondEdit.String(FlagNetSellCapPercentage, "", "The max net amount of tokens sold per batch as a percentage of supply")
+	fsBondEdit.String(FlagDemurrageRate, "", "The percentage of the tokens' redemption value that decays every block")
+	fsBondEdit.String(FlagQuoteDenom, "", "The denomination in which the bond's prices are also quoted (converted using the oracle)")
+	fsBondEdit.String(FlagMinReserve, "", "The reserve balance below which sells are deferred")
+	fsBondEdit.String(FlagMinReservePercentage, "", "The reserve balance below which sells are deferred as a percentage of the reserve implied by the supply")
+	fsBondEdit.String(FlagFeeAddress, "", "The address that will hold any charged fees")
+	fsBondEdit.String(FlagSignerThreshold, "", "The weight of signers required to authorize the bond's administrative messages, optionally followed by one weight per signer, e.g. 2 or 3:2,1,1 (blank for all signers)")
 }
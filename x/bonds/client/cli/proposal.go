@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"bufio"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	client2 "github.com/ixoworld/bonds/x/bonds/client"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"strconv"
+)
+
+func GetCmdSubmitClaimStuckFundsProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "claim-stuck-funds [module-account] [recipient] [amount]",
+		Example: "claim-stuck-funds bonds_reserve_account cosmos1... 10res --title=... --description=... --deposit=10stake",
+		Short:   "Submit a proposal to claim funds stuck in one of the bonds module accounts",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			recipient, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoins(args[2])
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			content := types.NewClaimStuckFundsProposal(
+				viper.GetString(govcli.FlagTitle),
+				viper.GetString(govcli.FlagDescription),
+				args[0], recipient, amount)
+
+			msg := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(govcli.FlagTitle, "", "The proposal title")
+	cmd.Flags().String(govcli.FlagDescription, "", "The proposal description")
+	cmd.Flags().String(govcli.FlagDeposit, "", "The proposal deposit")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(govcli.FlagTitle)
+	_ = cmd.MarkFlagRequired(govcli.FlagDescription)
+
+	return cmd
+}
+
+func GetCmdSubmitMigrateCurveVersionProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate-curve-version [bond-token] [curve-version]",
+		Example: "migrate-curve-version abc 2 --title=... --description=... --deposit=10stake",
+		Short:   "Submit a proposal to migrate a bond to a newer curve engine version",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			curveVersion, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			content := types.NewMigrateCurveVersionProposal(
+				viper.GetString(govcli.FlagTitle),
+				viper.GetString(govcli.FlagDescription),
+				args[0], curveVersion)
+
+			msg := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(govcli.FlagTitle, "", "The proposal title")
+	cmd.Flags().String(govcli.FlagDescription, "", "The proposal description")
+	cmd.Flags().String(govcli.FlagDeposit, "", "The proposal deposit")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(govcli.FlagTitle)
+	_ = cmd.MarkFlagRequired(govcli.FlagDescription)
+
+	return cmd
+}
+
+func GetCmdSubmitMigrateReserveTokenProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate-reserve-token [bond-token] [from-denom] [to-denom] [rate]",
+		Example: "migrate-reserve-token abc res newres 1000 --title=... --description=... --deposit=10stake",
+		Short:   "Submit a proposal to migrate a bond's reserve token to a new denomination",
+		Args:    cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			rate, err := sdk.NewDecFromStr(args[3])
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			content := types.NewMigrateReserveTokenProposal(
+				viper.GetString(govcli.FlagTitle),
+				viper.GetString(govcli.FlagDescription),
+				args[0], args[1], args[2], rate)
+
+			msg := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(govcli.FlagTitle, "", "The proposal title")
+	cmd.Flags().String(govcli.FlagDescription, "", "The proposal description")
+	cmd.Flags().String(govcli.FlagDeposit, "", "The proposal deposit")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(govcli.FlagTitle)
+	_ = cmd.MarkFlagRequired(govcli.FlagDescription)
+
+	return cmd
+}
+
+func GetCmdSubmitEditBondProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "edit-bond [bond-token]",
+		Example: "edit-bond abc --tx-fee-percentage=0.5 --allow-sells=false --title=... --description=... --deposit=10stake",
+		Short:   "Submit a proposal to edit a bond's fees, order quantity limits, sanity values, or whether it allows sells",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Only the fields whose flags were specified are edited
+			var fields client2.EditBondProposalFields
+			for flag, field := range map[string]**string{
+				FlagTxFeePercentage:        &fields.TxFeePercentage,
+				FlagExitFeePercentage:      &fields.ExitFeePercentage,
+				FlagOrderQuantityLimits:    &fields.OrderQuantityLimits,
+				FlagSanityRate:             &fields.SanityRate,
+				FlagSanityMarginPercentage: &fields.SanityMarginPercentage,
+				FlagAllowSells:             &fields.AllowSells,
+			} {
+				if cmd.Flags().Changed(flag) {
+					value := viper.GetString(flag)
+					*field = &value
+				}
+			}
+
+			deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			content, err := client2.ParseEditBondProposal(
+				viper.GetString(govcli.FlagTitle),
+				viper.GetString(govcli.FlagDescription),
+				args[0], fields)
+			if err != nil {
+				return err
+			}
+
+			msg := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagTxFeePercentage, "", "The percentage fee charged on buys and sells")
+	cmd.Flags().String(FlagExitFeePercentage, "", "The percentage fee charged on sells")
+	cmd.Flags().String(FlagOrderQuantityLimits, "", "The max number of tokens bought/sold/swapped per order")
+	cmd.Flags().String(FlagSanityRate, "", "For swappers, this is the typical t1 per t2 rate")
+	cmd.Flags().String(FlagSanityMarginPercentage, "", "For swappers, this is the acceptable deviation from the sanity rate")
+	cmd.Flags().String(FlagAllowSells, "", "Whether or not sells will be allowed (true or false)")
+	cmd.Flags().String(govcli.FlagTitle, "", "The proposal title")
+	cmd.Flags().String(govcli.FlagDescription, "", "The proposal description")
+	cmd.Flags().String(govcli.FlagDeposit, "", "The proposal deposit")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(govcli.FlagTitle)
+	_ = cmd.MarkFlagRequired(govcli.FlagDescription)
+
+	return cmd
+}
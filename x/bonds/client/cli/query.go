@@ -3,12 +3,14 @@ package cli
 import (
 	"fmt"
 	"github.com/cosmos/cosmos-sdk/client"
+	client2 "github.com/ixoworld/bonds/x/bonds/client"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -24,14 +26,41 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	bondsQueryCmd.AddCommand(flags.GetCommands(
 		GetCmdBonds(storeKey, cdc),
 		GetCmdBond(storeKey, cdc),
+		GetCmdBondAdmin(storeKey, cdc),
+		GetCmdSearchBonds(storeKey, cdc),
 		GetCmdBatch(storeKey, cdc),
+		GetCmdBatchOrders(storeKey, cdc),
 		GetCmdLastBatch(storeKey, cdc),
+		GetCmdLastBatchResult(storeKey, cdc),
+		GetCmdEffectiveBatchBlocks(storeKey, cdc),
+		GetCmdBatchAuction(storeKey, cdc),
+		GetCmdSimulateBatch(storeKey, cdc),
+		GetCmdSupplyHistory(storeKey, cdc),
+		GetCmdReserveHistory(storeKey, cdc),
+		GetCmdEffectiveAPR(storeKey, cdc),
+		GetCmdBondAtHeight(storeKey, cdc),
+		GetCmdExport(storeKey, cdc),
+		GetCmdLedger(storeKey, cdc),
+		GetCmdExportLedger(storeKey, cdc),
 		GetCmdCurrentPrice(storeKey, cdc),
+		GetCmdQuotePrice(storeKey, cdc),
 		GetCmdCurrentReserve(storeKey, cdc),
 		GetCmdCustomPrice(storeKey, cdc),
 		GetCmdBuyPrice(storeKey, cdc),
 		GetCmdSellReturn(storeKey, cdc),
 		GetCmdSwapReturn(storeKey, cdc),
+		GetCmdFeePreview(storeKey, cdc),
+		GetCmdPriceImpact(storeKey, cdc),
+		GetCmdSanityCheck(storeKey, cdc),
+		GetCmdIsTradeAllowed(storeKey, cdc),
+		GetCmdOrderByReceipt(storeKey, cdc),
+		GetCmdScheduledParamChange(storeKey, cdc),
+		GetCmdBondProposals(storeKey, cdc),
+		GetCmdNotificationRegistrations(storeKey, cdc),
+		GetCmdBondProposal(storeKey, cdc),
+		GetCmdModuleStats(storeKey, cdc),
+		GetCmdParams(storeKey, cdc),
+		GetCmdFitFunction(cdc),
 	)...)
 
 	return bondsQueryCmd
@@ -77,13 +106,71 @@ func GetCmdBond(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return nil
 			}
 
-			var out types.Bond
+			var out types.QueryBond
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
 	}
 }
 
+func GetCmdBondAdmin(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bond-admin [bond-token]",
+		Short: "Query all administrative state of a bond needed by its issuer",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/bond_admin/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryBondAdmin
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdSearchBonds(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "search-bonds [query]",
+		Example: "search-bonds \"my bond\" --limit 10",
+		Short:   "Search bonds by (case-insensitive) name or description substring",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			query := args[0]
+
+			limit, err := cmd.Flags().GetInt(FlagLimit)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/search_bonds/%d/%s",
+					queryRoute, limit, query), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryBonds
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().Int(FlagLimit, types.DefaultBondSearchLimit,
+		fmt.Sprintf("The max number of results (at most %d)", types.MaxBondSearchLimit))
+	return cmd
+}
+
 func GetCmdBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "batch [bond-token]",
@@ -108,6 +195,80 @@ func GetCmdBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+func GetCmdBatchOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "batch-orders [bond-token]",
+		Example: "batch-orders abc --side buy --status pending --page 2 --limit 20",
+		Short:   "Query a page of the orders in a bond's current batch",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			page, err := cmd.Flags().GetInt(FlagPage)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+			limit, err := cmd.Flags().GetInt(FlagLimit)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+			side, err := cmd.Flags().GetString(FlagSide)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+			status, err := cmd.Flags().GetString(FlagStatus)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+			accountStr, err := cmd.Flags().GetString(FlagAccount)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var account sdk.AccAddress
+			if accountStr != "" {
+				account, err = sdk.AccAddressFromBech32(accountStr)
+				if err != nil {
+					fmt.Printf("%s", err.Error())
+					return nil
+				}
+			}
+
+			params := types.NewQueryBatchOrdersParams(page, limit, side, account, status)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/batch_orders/%s",
+					queryRoute, bondToken), bz)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryBatchOrders
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().Int(FlagPage, 1, "The page of results")
+	cmd.Flags().Int(FlagLimit, types.DefaultBatchOrdersLimit,
+		fmt.Sprintf("The max number of results per page (at most %d)", types.MaxBatchOrdersLimit))
+	cmd.Flags().String(FlagSide, "", "Only include orders of this side (buy, sell or swap)")
+	cmd.Flags().String(FlagAccount, "", "Only include orders by this address")
+	cmd.Flags().String(FlagStatus, "", "Only include orders with this status (pending or cancelled)")
+	return cmd
+}
+
 func GetCmdLastBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "last-batch [bond-token]",
@@ -125,31 +286,368 @@ func GetCmdLastBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return nil
 			}
 
-			var out types.Batch
+			var out types.Batch
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdLastBatchResult(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "last-batch-result [bond-token]",
+		Short: "Query summarised result of a bond's last batch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/last_batch_result/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.BatchResult
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdEffectiveBatchBlocks(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "effective-batch-blocks [bond-token]",
+		Short: "Query a bond's current batch length, including any stress-mode lengthening",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/effective_batch_blocks/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryEffectiveBatchBlocks
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdBatchAuction(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "batch-auction [bond-token]",
+		Short: "Query bids, indicative clearing price, and oversubscription of a bond's current batch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/batch_auction/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryBatchAuction
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdSimulateBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "simulate-batch [bond-token]",
+		Short: "Query the outcome of settling a bond's current batch against the current state, without committing it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/simulate_batch/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QuerySimulateBatch
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdSupplyHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "supply-history [bond-token]",
+		Short: "Query a bond's supply after each of its recent batches",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/supply_history/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QuerySupplyHistory
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdReserveHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reserve-history [bond-token]",
+		Short: "Query a bond's reserve after each of its recent batches",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/reserve_history/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryReserveHistory
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdBondAtHeight(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bond-at-height [bond-token] [height]",
+		Short: "Query a bond's supply, reserve, and prices at the last batch before a height",
+		Long: "Query a bond's supply, reserve, and prices at the last batch at or before a " +
+			"height, reconstructed from the bond's history. Unlike querying with --height, " +
+			"this does not require the state at the height, so it also works against pruned nodes.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			height := args[1]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/bond_at_height/%s/%s",
+					queryRoute, bondToken, height), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryBondAtHeight
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdExport(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "export [bond-token]",
+		Example: "export abc --format csv > abc.csv",
+		Short:   "Export a bond's batch-by-batch accounting from the bond's history",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			format, err := cmd.Flags().GetString(FlagFormat)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/export/%s/%s",
+					queryRoute, bondToken, format), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			// The export is printed as is, so that it can be saved to a file
+			var out types.QueryBondExport
+			cdc.MustUnmarshalJSON(res, &out)
+			fmt.Print(out.Content)
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagFormat, types.ExportFormatCSV, "The export format (csv)")
+	return cmd
+}
+
+func GetCmdLedger(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ledger [bond-token]",
+		Example: "ledger abc --page 2 --limit 20",
+		Short:   "Query a bond's ledger balances and a page of its ledger entries",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			page, err := cmd.Flags().GetInt(FlagPage)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+			limit, err := cmd.Flags().GetInt(FlagLimit)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			params := types.NewQueryLedgerParams(page, limit)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/ledger/%s",
+					queryRoute, bondToken), bz)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryLedger
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().Int(FlagPage, 1, "The page of results")
+	cmd.Flags().Int(FlagLimit, types.DefaultLedgerEntriesLimit,
+		fmt.Sprintf("The max number of results per page (at most %d)", types.MaxLedgerEntriesLimit))
+	return cmd
+}
+
+func GetCmdExportLedger(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "export-ledger [bond-token]",
+		Example: "export-ledger abc --format csv > abc-ledger.csv",
+		Short:   "Export every entry of a bond's ledger",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			format, err := cmd.Flags().GetString(FlagFormat)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/ledger_export/%s/%s",
+					queryRoute, bondToken, format), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			// The export is printed as is, so that it can be saved to a file
+			var out types.QueryBondExport
+			cdc.MustUnmarshalJSON(res, &out)
+			fmt.Print(out.Content)
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagFormat, types.ExportFormatCSV, "The export format (csv)")
+	return cmd
+}
+
+func GetCmdEffectiveAPR(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "effective-apr [bond-token]",
+		Short: "Query the trailing annualised yield of a bond token from the bond's history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/effective_apr/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryEffectiveAPR
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdCurrentPrice(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "current-price [bond-token]",
+		Short: "Query current price(s) of the bond",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/current_price/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out sdk.DecCoins
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
 	}
 }
 
-func GetCmdCurrentPrice(queryRoute string, cdc *codec.Codec) *cobra.Command {
+func GetCmdQuotePrice(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "current-price [bond-token]",
-		Short: "Query current price(s) of the bond",
+		Use:   "quote-price [bond-token]",
+		Short: "Query current price(s) of the bond converted into its quote denomination",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			bondToken := args[0]
 
 			res, _, err := cliCtx.QueryWithData(
-				fmt.Sprintf("custom/%s/current_price/%s",
+				fmt.Sprintf("custom/%s/quote_price/%s",
 					queryRoute, bondToken), nil)
 			if err != nil {
 				fmt.Printf("%s", err.Error())
 				return nil
 			}
 
-			var out sdk.DecCoins
+			var out types.QueryQuotePrice
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
@@ -310,3 +808,422 @@ func GetCmdSwapReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func GetCmdFeePreview(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "fee-preview [bond-token] [reserve-amounts]",
+		Example: "fee-preview abc 100.5res",
+		Short:   "Query the exact and rounded fees that a bond would charge on reserve amount(s)",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			reserveAmounts, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/fee_preview/%s/%s",
+					queryRoute, bondToken, reserveAmounts.String()), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryFeePreview
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdPriceImpact(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use: "price-impact [bond-token] [order-type] [token-with-amount] [to-token]",
+		Example: "" +
+			"price-impact abc buy 10abc\n" +
+			"price-impact abc sell 10abc\n" +
+			"price-impact abc swap 10res1 res2",
+		Short: "Query the impact of a buy, sell or swap on the price(s) of a bond",
+		Args:  cobra.RangeArgs(3, 4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			orderType := args[1]
+			tokenWithAmount := args[2]
+
+			coinWithAmount, err := sdk.ParseCoin(tokenWithAmount)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var path string
+			if orderType == types.AttributeValueSwapOrder {
+				if len(args) != 4 {
+					fmt.Printf("to-token is required for a swap")
+					return nil
+				}
+				path = fmt.Sprintf("custom/%s/price_impact/%s/%s/%s/%s/%s",
+					queryRoute, bondToken, orderType, coinWithAmount.Denom,
+					coinWithAmount.Amount.String(), args[3])
+			} else if coinWithAmount.Denom != bondToken {
+				fmt.Printf("token-with-amount must be in the bond token denomination")
+				return nil
+			} else {
+				path = fmt.Sprintf("custom/%s/price_impact/%s/%s/%s",
+					queryRoute, bondToken, orderType,
+					coinWithAmount.Amount.String())
+			}
+
+			res, _, err := cliCtx.QueryWithData(path, nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryPriceImpact
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdSanityCheck(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use: "sanity-check [bond-token] [order-type] [token-with-amount] [to-token|max-prices]",
+		Example: "" +
+			"sanity-check abc buy 10abc 500res1,1000res2\n" +
+			"sanity-check abc sell 10abc\n" +
+			"sanity-check abc swap 10res1 res2",
+		Short: "Query whether a buy, sell or swap would violate a swapper bond's sanity rate",
+		Long: `Query whether the reserves resulting from a buy, sell or swap would violate
+a swapper bond's sanity rate, and how far the resulting exchange rate would be
+from the allowed band of rates. Max prices are only required for the first buy,
+which provides the initial liquidity.`,
+		Args: cobra.RangeArgs(3, 4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			orderType := args[1]
+			tokenWithAmount := args[2]
+
+			coinWithAmount, err := sdk.ParseCoin(tokenWithAmount)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var path string
+			if orderType == types.AttributeValueSwapOrder {
+				if len(args) != 4 {
+					fmt.Printf("to-token is required for a swap")
+					return nil
+				}
+				path = fmt.Sprintf("custom/%s/sanity_check/%s/%s/%s/%s/%s",
+					queryRoute, bondToken, orderType, coinWithAmount.Denom,
+					coinWithAmount.Amount.String(), args[3])
+			} else if coinWithAmount.Denom != bondToken {
+				fmt.Printf("token-with-amount must be in the bond token denomination")
+				return nil
+			} else {
+				path = fmt.Sprintf("custom/%s/sanity_check/%s/%s/%s",
+					queryRoute, bondToken, orderType,
+					coinWithAmount.Amount.String())
+				if len(args) == 4 {
+					path = fmt.Sprintf("%s/%s", path, args[3])
+				}
+			}
+
+			res, _, err := cliCtx.QueryWithData(path, nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QuerySanityCheck
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdIsTradeAllowed(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use: "is-trade-allowed [bond-token] [order-type] [account] [token-with-amount] [to-token|max-prices]",
+		Example: "" +
+			"is-trade-allowed abc buy cosmos1... 10abc 500res1,1000res2\n" +
+			"is-trade-allowed abc sell cosmos1... 10abc\n" +
+			"is-trade-allowed abc swap cosmos1... 10res1 res2",
+		Short: "Query whether a buy, sell or swap by an account would currently be accepted",
+		Long: `Query whether a buy, sell or swap by an account would currently be accepted,
+by applying every check of an actual order submission without changing the
+state. If the order would be rejected, the error that it would be rejected with
+is included. Max prices are required for buys and the to-token for swaps.`,
+		Args: cobra.RangeArgs(4, 5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			orderType := args[1]
+
+			account, err := sdk.AccAddressFromBech32(args[2])
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			coinWithAmount, err := sdk.ParseCoin(args[3])
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var maxPrices sdk.Coins
+			var toToken string
+			if len(args) == 5 {
+				if orderType == types.AttributeValueSwapOrder {
+					toToken = args[4]
+				} else {
+					maxPrices, err = sdk.ParseCoins(args[4])
+					if err != nil {
+						fmt.Printf("%s", err.Error())
+						return nil
+					}
+				}
+			}
+
+			params := types.NewQueryIsTradeAllowedParams(
+				orderType, account, coinWithAmount, maxPrices, toToken)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/is_trade_allowed/%s",
+					queryRoute, bondToken), bz)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryIsTradeAllowed
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdOrderByReceipt(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "order-by-receipt [receipt]",
+		Short: "Query the order with the given order receipt",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			receipt := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/order_by_receipt/%s",
+					queryRoute, receipt), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.OrderReceipt
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdScheduledParamChange(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "scheduled-param-change [bond-token]",
+		Short: "Query the function parameters change scheduled for a bond",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/scheduled_param_change/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.ScheduledParamChange
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdBondProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bond-proposals [bond-token]",
+		Short: "Query the bond proposals submitted for a bond",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/bond_proposals/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out []types.BondProposal
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdNotificationRegistrations(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "notification-registrations [bond-token]",
+		Short: "Query the relayers registered for notifications about a bond",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/notification_registrations/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out []types.NotificationRegistration
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdBondProposal(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bond-proposal [proposal-id]",
+		Short: "Query the bond proposal with the given ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			proposalID := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/bond_proposal/%s",
+					queryRoute, proposalID), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.BondProposal
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdModuleStats(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "module-stats",
+		Short: "Query module-wide statistics",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/module_stats",
+					queryRoute), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.ModuleStats
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query the current bonds module parameters",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/params",
+					queryRoute), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.Params
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdFitFunction(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "fit-function [function-type] [anchor-points]",
+		Example: "fit-function power_function \"0:1,1000000:11,2000000:41\" --max-fit-error 0.5",
+		Short:   "Derive power or sigmoid function parameters from target prices at specific supplies",
+		Long: `Derive the parameters of a power or sigmoid function whose prices best fit
+the anchor points, specified as supply:price pairs. A power function requires
+at least two anchor points and a sigmoid function at least three. The fit error
+is the largest deviation of a fitted price from its target price, as a
+percentage of the target price. This command does not query the chain.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			functionType := args[0]
+
+			maxFitErrorStr, err := cmd.Flags().GetString(FlagMaxFitError)
+			if err != nil {
+				return err
+			}
+			maxFitError, err := sdk.NewDecFromStr(maxFitErrorStr)
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "max fit error")
+			}
+
+			points, err := client2.ParseAnchorPoints(args[1])
+			if err != nil {
+				return err
+			}
+
+			fit, err := client2.FitFunctionParams(functionType, points)
+			if err != nil {
+				return err
+			} else if fit.FitErrorPercentage.GT(maxFitError) {
+				return fmt.Errorf("fit error %s%% exceeds max fit error %s%% for %s",
+					fit.FitErrorPercentage, maxFitError, fit.FunctionParameters)
+			}
+
+			return cliCtx.PrintOutput(fit)
+		},
+	}
+	cmd.Flags().String(FlagMaxFitError, "1", "The max fit error (percentage) accepted")
+	return cmd
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"strconv"
 	"strings"
 )
 
@@ -32,10 +33,23 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		GetCmdEditBond(cdc),
 		GetCmdBuy(cdc),
 		GetCmdSell(cdc),
+		GetCmdSellByValue(cdc),
 		GetCmdSwap(cdc),
 		GetCmdMakeOutcomePayment(cdc),
 		GetCmdWithdrawShare(cdc),
+		GetCmdAuthorizedTransfer(cdc),
+		GetCmdScheduleParamChange(cdc),
+		GetCmdCancelParamChange(cdc),
+		GetCmdSubmitBondProposal(cdc),
+		GetCmdVoteBondProposal(cdc),
+		GetCmdSetBondTranslations(cdc),
+		GetCmdSetSanityRate(cdc),
+		GetCmdRebalanceSwap(cdc),
+		GetCmdSweepFeeDust(cdc),
+		GetCmdRegisterNotifications(cdc),
+		GetCmdUnregisterNotifications(cdc),
 	)...)
+	bondsTxCmd.AddCommand(flags.PostCommands(getDevTxCmds(cdc)...)...)
 
 	return bondsTxCmd
 }
@@ -59,9 +73,20 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 			_sanityRate := viper.GetString(FlagSanityRate)
 			_sanityMarginPercentage := viper.GetString(FlagSanityMarginPercentage)
 			_allowSells := viper.GetBool(FlagAllowSells)
+			_nonTransferable := viper.GetBool(FlagNonTransferable)
+			_requireAttestation := viper.GetBool(FlagRequireAttestation)
 			_signers := viper.GetString(FlagSigners)
 			_batchBlocks := viper.GetString(FlagBatchBlocks)
 			_outcomePayment := viper.GetString(FlagOutcomePayment)
+			_milestones := viper.GetString(FlagMilestones)
+			_proposalVotingBlocks := viper.GetUint64(FlagProposalVotingBlocks)
+			_eventAttributes := viper.GetString(FlagEventAttributes)
+			_preMine := viper.GetString(FlagPreMine)
+			_atMaxSupplyBehavior := viper.GetString(FlagAtMaxSupplyBehavior)
+			_signerThreshold := viper.GetString(FlagSignerThreshold)
+			_curveSegments := viper.GetString(FlagCurveSegments)
+			_softCap := viper.GetString(FlagSoftCap)
+			_raiseDeadline := viper.GetInt64(FlagRaiseDeadline)
 
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
@@ -136,11 +161,62 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			// Parse milestones
+			var milestones []types.Milestone
+			if len(_milestones) != 0 {
+				err = cdc.UnmarshalJSON([]byte(_milestones), &milestones)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Parse curve segments
+			var curveSegments []types.CurveSegment
+			if len(_curveSegments) != 0 {
+				err = cdc.UnmarshalJSON([]byte(_curveSegments), &curveSegments)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Parse event attributes
+			eventAttributes, err := client2.ParseEventAttributes(_eventAttributes)
+			if err != nil {
+				return err
+			}
+
+			// Parse pre-mine
+			preMine, err := sdk.ParseCoins(_preMine)
+			if err != nil {
+				return err
+			}
+
+			// Parse soft cap
+			softCap, err := sdk.ParseCoins(_softCap)
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgCreateBond(_token, _name, _description,
 				cliCtx.GetFromAddress(), _functionType, functionParams,
 				reserveTokens, txFeePercentage, exitFeePercentage, feeAddress,
 				maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
-				_allowSells, signers, batchBlocks, outcomePayment)
+				_allowSells, _nonTransferable, _requireAttestation, signers,
+				batchBlocks, outcomePayment, milestones, _proposalVotingBlocks,
+				eventAttributes, preMine, _atMaxSupplyBehavior)
+			msg.CurveSegments = curveSegments
+			msg.SoftCap = softCap
+			msg.RaiseDeadline = _raiseDeadline
+
+			// Parse signer threshold (if any)
+			if strings.TrimSpace(_signerThreshold) != "" {
+				signerThreshold, err := client2.ParseSignerThreshold(_signerThreshold)
+				if err != nil {
+					return err
+				}
+				msg.SignerThreshold = &signerThreshold
+			}
+
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -164,6 +240,15 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 	_ = cmd.MarkFlagRequired(FlagSigners)
 	_ = cmd.MarkFlagRequired(FlagBatchBlocks)
 	// _ = cmd.MarkFlagRequired(FlagOutcomePayment) // Optional
+	// _ = cmd.MarkFlagRequired(FlagMilestones) // Optional
+	// _ = cmd.MarkFlagRequired(FlagProposalVotingBlocks) // Optional
+	// _ = cmd.MarkFlagRequired(FlagEventAttributes) // Optional
+	// _ = cmd.MarkFlagRequired(FlagPreMine) // Optional
+	// _ = cmd.MarkFlagRequired(FlagAtMaxSupplyBehavior) // Optional
+	// _ = cmd.MarkFlagRequired(FlagSignerThreshold) // Optional
+	// _ = cmd.MarkFlagRequired(FlagCurveSegments) // Optional
+	// _ = cmd.MarkFlagRequired(FlagSoftCap) // Optional
+	// _ = cmd.MarkFlagRequired(FlagRaiseDeadline) // Optional
 
 	return cmd
 }
@@ -174,13 +259,31 @@ func GetCmdEditBond(cdc *codec.Codec) *cobra.Command {
 		Short: "Edit bond",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			_token := viper.GetString(FlagToken)
-			_name := viper.GetString(FlagName)
-			_description := viper.GetString(FlagDescription)
-			_orderQuantityLimits := viper.GetString(FlagOrderQuantityLimits)
-			_sanityRate := viper.GetString(FlagSanityRate)
-			_sanityMarginPercentage := viper.GetString(FlagSanityMarginPercentage)
 			_signers := viper.GetString(FlagSigners)
 
+			// Only the fields whose flags were specified are edited
+			var fields client2.EditBondFields
+			for flag, field := range map[string]**string{
+				FlagName:                   &fields.Name,
+				FlagDescription:            &fields.Description,
+				FlagOrderQuantityLimits:    &fields.OrderQuantityLimits,
+				FlagSanityRate:             &fields.SanityRate,
+				FlagSanityMarginPercentage: &fields.SanityMarginPercentage,
+				FlagNetSellCap:             &fields.NetSellCap,
+				FlagNetSellCapPercentage:   &fields.NetSellCapPercentage,
+				FlagDemurrageRate:          &fields.DemurrageRate,
+				FlagQuoteDenom:             &fields.QuoteDenom,
+				FlagMinReserve:             &fields.MinReserve,
+				FlagMinReservePercentage:   &fields.MinReservePercentage,
+				FlagFeeAddress:             &fields.FeeAddress,
+				FlagSignerThreshold:        &fields.SignerThreshold,
+			} {
+				if cmd.Flags().Changed(flag) {
+					value := viper.GetString(flag)
+					*field = &value
+				}
+			}
+
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -191,9 +294,11 @@ func GetCmdEditBond(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := types.NewMsgEditBond(
-				_token, _name, _description, _orderQuantityLimits, _sanityRate,
-				_sanityMarginPercentage, cliCtx.GetFromAddress(), signers)
+			msg, err := client2.ParseMsgEditBond(
+				_token, fields, cliCtx.GetFromAddress(), signers)
+			if err != nil {
+				return err
+			}
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -232,11 +337,40 @@ func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			callbackPayload, err := cmd.Flags().GetString(FlagCallbackPayload)
+			if err != nil {
+				return err
+			}
+
+			orderMemo, err := cmd.Flags().GetString(FlagOrderMemo)
+			if err != nil {
+				return err
+			}
+
+			priorityFeeStr, err := cmd.Flags().GetString(FlagPriorityFee)
+			if err != nil {
+				return err
+			}
+
+			priorityFee, err := sdk.ParseCoins(priorityFeeStr)
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgBuy(cliCtx.GetFromAddress(),
 				bondCoinWithAmount, maxPrices)
+			msg.CallbackPayload = callbackPayload
+			msg.Memo = orderMemo
+			msg.PriorityFee = priorityFee
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().String(FlagCallbackPayload, "",
+		"Opaque payload included in the events emitted for the buy order")
+	cmd.Flags().String(FlagOrderMemo, "",
+		"Memo stored with the order and included in the events emitted for it")
+	cmd.Flags().String(FlagPriorityFee, "",
+		"Fee paid to the bond's fee address (in reserve tokens) to prioritise the buy if its batch is constrained")
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
 	return cmd
 }
@@ -258,10 +392,59 @@ func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			orderMemo, err := cmd.Flags().GetString(FlagOrderMemo)
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgSell(cliCtx.GetFromAddress(), bondCoinWithAmount)
+			msg.Memo = orderMemo
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().String(FlagOrderMemo, "",
+		"Memo stored with the order and included in the events emitted for it")
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdSellByValue(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "sell-by-value [returns] [max-bond-token-with-amount]",
+		Example: "" +
+			"sell-by-value 1000res1 10abc\n" +
+			"sell-by-value 1000res1,1000res2 10abc",
+		Short: "Sell the amount of bond tokens needed to receive the specified returns",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			returns, err := sdk.ParseCoins(args[0])
+			if err != nil {
+				return err
+			}
+
+			maxBondCoinWithAmount, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			orderMemo, err := cmd.Flags().GetString(FlagOrderMemo)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSellByValue(cliCtx.GetFromAddress(),
+				returns, maxBondCoinWithAmount)
+			msg.Memo = orderMemo
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(FlagOrderMemo, "",
+		"Memo stored with the order and included in the events emitted for it")
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
 	return cmd
 }
@@ -271,7 +454,8 @@ func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 		Use: "swap [bond-token] [from-amount] [from-token] [to-token]",
 		Example: "" +
 			"swap abc 100 res1 res2\n" +
-			"swap abc 100 res2 res1",
+			"swap abc 100 res2 res1\n" +
+			"swap abc 100 res3 res2 --min-return=90",
 		Short: "Perform a swap between two tokens",
 		Args:  cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -286,10 +470,32 @@ func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			orderMemo, err := cmd.Flags().GetString(FlagOrderMemo)
+			if err != nil {
+				return err
+			}
+
+			minReturnStr, err := cmd.Flags().GetString(FlagMinReturn)
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgSwap(cliCtx.GetFromAddress(), args[0], from, args[3])
+			msg.Memo = orderMemo
+			if minReturnStr != "" {
+				minReturn, err := client2.ParseTwoPartCoin(minReturnStr, args[3])
+				if err != nil {
+					return err
+				}
+				msg.MinReturn = &minReturn
+			}
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().String(FlagOrderMemo, "",
+		"Memo stored with the order and included in the events emitted for it")
+	cmd.Flags().String(FlagMinReturn, "",
+		"Least amount of the to-token to receive (required if the swap is routed through another bond's token)")
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
 	return cmd
 }
@@ -333,3 +539,394 @@ func GetCmdWithdrawShare(cdc *codec.Codec) *cobra.Command {
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
 	return cmd
 }
+
+func GetCmdAuthorizedTransfer(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "authorized-transfer [from-address] [to-address] [bond-token-with-amount] [reason]",
+		Example: "authorized-transfer cosmos1... cosmos1... 10abc \"lost key recovery\" --signers=cosmos1...",
+		Short:   "Move a holder's non-transferable bond tokens, authorized by the bond's signers",
+		Args:    cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			from, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			to, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgAuthorizedTransfer(from, to, amount, args[3], signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSigners, "", "The bond's list of signers authorizing the transfer")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdScheduleParamChange(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "schedule-param-change [bond-token] [function-parameters] [effective-height]",
+		Example: "" +
+			"schedule-param-change abc \"m:12,n:2,c:100\" 100000 --signers=cosmos1...\n" +
+			"schedule-param-change abc \"m:12,n:2,c:100\" 100000 --interpolate --signers=cosmos1...",
+		Short: "Schedule a change to a bond's function parameters at a future block height",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse function parameters
+			functionParams, err := client2.ParseFunctionParams(args[1])
+			if err != nil {
+				return fmt.Errorf(err.Error())
+			}
+
+			// Parse effective height
+			effectiveHeight, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "effective height")
+			}
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgScheduleParamChange(args[0], functionParams,
+				effectiveHeight, viper.GetBool(FlagInterpolate), cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSigners, "", "The bond's list of signers authorizing the change")
+	cmd.Flags().Bool(FlagInterpolate, false, "Move the function parameters gradually to the new ones by the effective height")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdCancelParamChange(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cancel-param-change [bond-token]",
+		Example: "cancel-param-change abc --signers=cosmos1...",
+		Short:   "Cancel a bond's scheduled function parameters change before it takes effect",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelParamChange(args[0], cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSigners, "", "The bond's list of signers authorizing the cancellation")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdSubmitBondProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "submit-bond-proposal [bond-token] [title] [description]",
+		Example: "submit-bond-proposal abc \"Title\" \"Description\" --proposal-type=funding --funding-recipient=cosmos1... --funding-amount=100res",
+		Short:   "Submit a proposal to be voted on by a bond's token holders",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_proposalType := viper.GetString(FlagProposalType)
+			_fundingRecipient := viper.GetString(FlagFundingRecipient)
+			_fundingAmount := viper.GetString(FlagFundingAmount)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse funding recipient (optional)
+			var fundingRecipient sdk.AccAddress
+			if _fundingRecipient != "" {
+				addr, err := sdk.AccAddressFromBech32(_fundingRecipient)
+				if err != nil {
+					return err
+				}
+				fundingRecipient = addr
+			}
+
+			// Parse funding amount
+			fundingAmount, err := sdk.ParseCoins(_fundingAmount)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSubmitBondProposal(args[0], cliCtx.GetFromAddress(),
+				args[1], args[2], _proposalType, fundingRecipient, fundingAmount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagProposalType, types.BondProposalTypeText, "The type of proposal (text or funding)")
+	cmd.Flags().String(FlagFundingRecipient, "", "For funding proposals, the address that will receive the funding")
+	cmd.Flags().String(FlagFundingAmount, "", "For funding proposals, the amount withdrawn from the reserve")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+func GetCmdVoteBondProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "vote-bond-proposal [proposal-id] [option]",
+		Example: "vote-bond-proposal 1 yes",
+		Short:   "Vote yes or no on a bond proposal using all of your bond tokens",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse proposal ID
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "proposal ID")
+			}
+
+			msg := types.NewMsgVoteBondProposal(proposalID, cliCtx.GetFromAddress(), args[1])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+func GetCmdSetBondTranslations(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set-bond-translations [bond-token] [translations-json]",
+		Example: "set-bond-translations abc '[{\"locale\":\"fr\",\"name\":\"Nom\",\"description\":\"Description\"}]' --signers=cosmos1...",
+		Short:   "Set (replace) a bond's localized names and descriptions",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse translations
+			var translations types.BondTranslations
+			err := cdc.UnmarshalJSON([]byte(args[1]), &translations)
+			if err != nil {
+				return err
+			}
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetBondTranslations(args[0], translations,
+				cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSigners, "", "The bond's list of signers authorizing the translations")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdSetSanityRate(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set-sanity-rate [bond-token] [sanity-rate] [sanity-margin-percentage]",
+		Example: "set-sanity-rate abc 0.5 20 --signers=cosmos1...",
+		Short:   "Set a swapper bond's sanity rate and sanity margin percentage",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse sanity rate and sanity margin percentage
+			sanityRate, err := sdk.NewDecFromStr(args[1])
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "sanity rate")
+			}
+			sanityMarginPercentage, err := sdk.NewDecFromStr(args[2])
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "sanity margin percentage")
+			}
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetSanityRate(args[0], sanityRate,
+				sanityMarginPercentage, cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSigners, "", "The bond's list of signers authorizing the change")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdRebalanceSwap(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rebalance-swap [bond-token] [from-amount] [from-token] [to-token]",
+		Example: "rebalance-swap abc 100 res1 res2 --signers=cosmos1...",
+		Short:   "Perform a swap that may move the reserves outside of the sanity band to the oracle rate",
+		Args:    cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Check that from amount and token can be parsed to a coin
+			from, err := client2.ParseTwoPartCoin(args[1], args[2])
+			if err != nil {
+				return err
+			}
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRebalanceSwap(cliCtx.GetFromAddress(),
+				args[0], from, args[3], signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSigners, "", "The bond's list of signers (including the swapper) authorizing the swap")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdSweepFeeDust(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sweep-fee-dust [target-denom]",
+		Example: "sweep-fee-dust res1",
+		Short:   "Swap balances below the fee dust threshold to the target denom, or send them to the community pool",
+		Args:    cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Dust is sent to the community pool if no target denom is given
+			targetDenom := ""
+			if len(args) == 1 {
+				targetDenom = args[0]
+			}
+
+			msg := types.NewMsgSweepFeeDust(cliCtx.GetFromAddress(), targetDenom)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdRegisterNotifications(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "register-notifications [bond-token] [notification-types]",
+		Example: "register-notifications abc fills,settlements",
+		Short:   "Register for notifications about a bond, or change the types of notifications registered for",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			notificationTypes := strings.Split(args[1], ",")
+
+			msg := types.NewMsgRegisterNotifications(
+				cliCtx.GetFromAddress(), args[0], notificationTypes)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdUnregisterNotifications(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unregister-notifications [bond-token]",
+		Example: "unregister-notifications abc",
+		Short:   "Unregister from notifications about a bond and get the registration deposit back",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgUnregisterNotifications(cliCtx.GetFromAddress(), args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
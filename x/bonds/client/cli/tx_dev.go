@@ -0,0 +1,71 @@
+//go:build testing
+// +build testing
+
+package cli
+
+import (
+	"bufio"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/spf13/cobra"
+)
+
+// getDevTxCmds returns the commands of the developer messages, which are only
+// compiled into builds with the testing build tag
+func getDevTxCmds(cdc *codec.Codec) []*cobra.Command {
+	return []*cobra.Command{
+		GetCmdDevMintReserve(cdc),
+		GetCmdDevAdvanceBatch(cdc),
+	}
+}
+
+func GetCmdDevMintReserve(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dev-mint-reserve [bond-token] [amount]",
+		Example: "dev-mint-reserve abc 1000res,1000rez",
+		Short:   "Mint reserve tokens of a bond to the sender (testing builds only)",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			amount, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+			}
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgDevMintReserve(cliCtx.GetFromAddress(), args[0], amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdDevAdvanceBatch(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dev-advance-batch [bond-token]",
+		Example: "dev-advance-batch abc",
+		Short:   "Make a bond's current batch due at the end of the block (testing builds only)",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgDevAdvanceBatch(cliCtx.GetFromAddress(), args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
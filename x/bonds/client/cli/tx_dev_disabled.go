@@ -0,0 +1,15 @@
+//go:build !testing
+// +build !testing
+
+package cli
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/spf13/cobra"
+)
+
+// getDevTxCmds returns no commands, since the developer messages are only
+// compiled into builds with the testing build tag
+func getDevTxCmds(*codec.Codec) []*cobra.Command {
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -21,17 +23,39 @@ func paramsListToMap(paramValuePairs []string) (paramsFieldMap map[string]string
 	for _, pv := range paramValuePairs {
 		// Split each "a:1" into ["a","1"]
 		pvArray := strings.SplitN(pv, ":", 2)
-		if len(pvArray) != 2 {
+		if len(pvArray) != 2 || strings.TrimSpace(pvArray[0]) == "" {
 			return nil, sdkerrors.Wrap(types.ErrInvalidFunctionParameter, pv)
 		}
+
+		// Check for duplicate parameters, which would otherwise be overwritten
+		if _, ok := paramsFieldMap[pvArray[0]]; ok {
+			return nil, sdkerrors.Wrap(types.ErrDuplicateFunctionParameter, pvArray[0])
+		}
 		paramsFieldMap[pvArray[0]] = pvArray[1]
 	}
 	return paramsFieldMap, nil
 }
 
 func paramsMapToObj(paramsFieldMap map[string]string) (functionParams types.FunctionParams, err error) {
-	for p, v := range paramsFieldMap {
-		vDec, err := sdk.NewDecFromStr(v)
+	// Sort parameter names so that the order of the parameters is deterministic
+	var params []string
+	for p := range paramsFieldMap {
+		params = append(params, p)
+	}
+	sort.Strings(params)
+
+	for _, p := range params {
+		// Values given as fractions (e.g. "1/3") are kept exactly
+		if strings.Contains(paramsFieldMap[p], "/") {
+			vRat, err := types.ParseRational(paramsFieldMap[p])
+			if err != nil {
+				return nil, sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, p)
+			}
+			functionParams = append(functionParams, types.NewRationalFunctionParam(p, vRat))
+			continue
+		}
+
+		vDec, err := sdk.NewDecFromStr(paramsFieldMap[p])
 		if err != nil {
 			return nil, sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, p)
 		} else {
@@ -60,6 +84,20 @@ func ParseFunctionParams(fnParamsStr string) (fnParams types.FunctionParams, err
 	return functionParams, nil
 }
 
+func ParseEventAttributes(eventAttributesStr string) (eventAttributes types.EventAttributes, err error) {
+	// Split "a:1,b:2" (if not empty) into ["a:1","b:2"], and each of these
+	// into a key and a value, keeping the order in which they were specified
+	for _, kv := range splitParameters(eventAttributesStr) {
+		kvArray := strings.SplitN(kv, ":", 2)
+		if len(kvArray) != 2 {
+			return nil, sdkerrors.Wrap(types.ErrInvalidEventAttribute, kv)
+		}
+		eventAttributes = append(eventAttributes,
+			types.NewEventAttribute(kvArray[0], kvArray[1]))
+	}
+	return eventAttributes, nil
+}
+
 func ParseSigners(signersStr string) (signers []sdk.AccAddress, err error) {
 
 	// Split by comma
@@ -68,14 +106,46 @@ func ParseSigners(signersStr string) (signers []sdk.AccAddress, err error) {
 	// Parse into sdk.AccAddresses
 	signers = make([]sdk.AccAddress, len(signersSplit))
 	for i, a := range signersSplit {
+		// Blank addresses would otherwise be parsed as empty addresses
+		if strings.TrimSpace(a) == "" {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "signer address cannot be blank")
+		}
 		signers[i], err = sdk.AccAddressFromBech32(a)
 		if err != nil {
-			return nil, err
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
 		}
 	}
 	return signers, nil
 }
 
+// ParseSignerThreshold parses a signer threshold given as a threshold and,
+// optionally, one weight per signer, e.g. "2" or "3:2,1,1". A blank signer
+// threshold is parsed as a zero threshold, i.e. all signers must sign.
+func ParseSignerThreshold(thresholdStr string) (threshold types.SignerThreshold, err error) {
+	if strings.TrimSpace(thresholdStr) == "" {
+		return types.SignerThreshold{}, nil
+	}
+
+	// Split "3:2,1,1" into "3" and "2,1,1"
+	thresholdSplit := strings.SplitN(thresholdStr, ":", 2)
+	threshold.Threshold, err = strconv.ParseUint(thresholdSplit[0], 10, 64)
+	if err != nil {
+		return types.SignerThreshold{}, sdkerrors.Wrap(
+			types.ErrArgumentMissingOrNonUInteger, "signer threshold")
+	}
+	if len(thresholdSplit) == 2 {
+		for _, w := range strings.Split(thresholdSplit[1], ",") {
+			weight, err := strconv.ParseUint(w, 10, 64)
+			if err != nil {
+				return types.SignerThreshold{}, sdkerrors.Wrap(
+					types.ErrArgumentMissingOrNonUInteger, "signer weight")
+			}
+			threshold.Weights = append(threshold.Weights, weight)
+		}
+	}
+	return threshold, nil
+}
+
 func ParseTwoPartCoin(amount, denom string) (coin sdk.Coin, err error) {
 	coin, err = sdk.ParseCoin(amount + denom)
 	if err != nil {
@@ -85,3 +155,151 @@ func ParseTwoPartCoin(amount, denom string) (coin sdk.Coin, err error) {
 	}
 	return coin, nil
 }
+
+// EditBondFields holds the bond fields to be edited as strings, as they are
+// given to the CLI or in a REST request. Fields that are nil are not edited,
+// while fields that are blank are reset.
+type EditBondFields struct {
+	Name                   *string
+	Description            *string
+	OrderQuantityLimits    *string
+	SanityRate             *string
+	SanityMarginPercentage *string
+	NetSellCap             *string
+	NetSellCapPercentage   *string
+	DemurrageRate          *string
+	QuoteDenom             *string
+	MinReserve             *string
+	MinReservePercentage   *string
+	FeeAddress             *string
+	SignerThreshold        *string
+}
+
+func parseOptionalDec(str *string, name string) (*sdk.Dec, error) {
+	if str == nil {
+		return nil, nil
+	}
+	dec := sdk.ZeroDec()
+	if strings.TrimSpace(*str) != "" {
+		parsed, err := sdk.NewDecFromStr(*str)
+		if err != nil {
+			return nil, sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, name)
+		}
+		dec = parsed
+	}
+	return &dec, nil
+}
+
+func parseOptionalCoins(str *string) (*sdk.Coins, error) {
+	if str == nil {
+		return nil, nil
+	}
+	coins, err := sdk.ParseCoins(*str)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
+	return &coins, nil
+}
+
+// ParseMsgEditBond parses the fields to be edited into a MsgEditBond. A blank
+// net sell cap is parsed as a zero amount of the bond token, and other blank
+// numeric fields are parsed as zero.
+func ParseMsgEditBond(token string, fields EditBondFields, editor sdk.AccAddress,
+	signers []sdk.AccAddress) (msg types.MsgEditBond, err error) {
+	msg = types.NewMsgEditBond(token, editor, signers)
+	msg.Name = fields.Name
+	msg.Description = fields.Description
+	msg.QuoteDenom = fields.QuoteDenom
+
+	if msg.OrderQuantityLimits, err = parseOptionalCoins(fields.OrderQuantityLimits); err != nil {
+		return types.MsgEditBond{}, err
+	} else if msg.MinReserve, err = parseOptionalCoins(fields.MinReserve); err != nil {
+		return types.MsgEditBond{}, err
+	}
+
+	if msg.SanityRate, err = parseOptionalDec(fields.SanityRate, "sanity rate"); err != nil {
+		return types.MsgEditBond{}, err
+	} else if msg.SanityMarginPercentage, err = parseOptionalDec(fields.SanityMarginPercentage, "sanity margin percentage"); err != nil {
+		return types.MsgEditBond{}, err
+	} else if msg.NetSellCapPercentage, err = parseOptionalDec(fields.NetSellCapPercentage, "net sell cap percentage"); err != nil {
+		return types.MsgEditBond{}, err
+	} else if msg.DemurrageRate, err = parseOptionalDec(fields.DemurrageRate, "demurrage rate"); err != nil {
+		return types.MsgEditBond{}, err
+	} else if msg.MinReservePercentage, err = parseOptionalDec(fields.MinReservePercentage, "min reserve percentage"); err != nil {
+		return types.MsgEditBond{}, err
+	}
+
+	if fields.NetSellCap != nil {
+		netSellCap := sdk.Coin{Denom: token, Amount: sdk.ZeroInt()}
+		if strings.TrimSpace(*fields.NetSellCap) != "" {
+			netSellCap, err = sdk.ParseCoin(*fields.NetSellCap)
+			if err != nil {
+				return types.MsgEditBond{}, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+			}
+		}
+		msg.NetSellCap = &netSellCap
+	}
+
+	if fields.FeeAddress != nil {
+		feeAddress, err := sdk.AccAddressFromBech32(*fields.FeeAddress)
+		if err != nil {
+			return types.MsgEditBond{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+		}
+		msg.FeeAddress = &feeAddress
+	}
+
+	if fields.SignerThreshold != nil {
+		signerThreshold, err := ParseSignerThreshold(*fields.SignerThreshold)
+		if err != nil {
+			return types.MsgEditBond{}, err
+		}
+		msg.SignerThreshold = &signerThreshold
+	}
+
+	return msg, nil
+}
+
+// EditBondProposalFields holds the bond fields to be edited by an
+// EditBondProposal as strings, as they are given to the CLI or in a REST
+// request. Fields that are nil are not edited, while numeric fields that are
+// blank are reset.
+type EditBondProposalFields struct {
+	TxFeePercentage        *string
+	ExitFeePercentage      *string
+	OrderQuantityLimits    *string
+	SanityRate             *string
+	SanityMarginPercentage *string
+	AllowSells             *string
+}
+
+// ParseEditBondProposal parses the fields to be edited into an
+// EditBondProposal, in the same way as ParseMsgEditBond.
+func ParseEditBondProposal(title, description, token string,
+	fields EditBondProposalFields) (p types.EditBondProposal, err error) {
+	p = types.NewEditBondProposal(title, description, token,
+		nil, nil, nil, nil, nil, nil)
+
+	if p.OrderQuantityLimits, err = parseOptionalCoins(fields.OrderQuantityLimits); err != nil {
+		return types.EditBondProposal{}, err
+	}
+
+	if p.TxFeePercentage, err = parseOptionalDec(fields.TxFeePercentage, "tx fee percentage"); err != nil {
+		return types.EditBondProposal{}, err
+	} else if p.ExitFeePercentage, err = parseOptionalDec(fields.ExitFeePercentage, "exit fee percentage"); err != nil {
+		return types.EditBondProposal{}, err
+	} else if p.SanityRate, err = parseOptionalDec(fields.SanityRate, "sanity rate"); err != nil {
+		return types.EditBondProposal{}, err
+	} else if p.SanityMarginPercentage, err = parseOptionalDec(fields.SanityMarginPercentage, "sanity margin percentage"); err != nil {
+		return types.EditBondProposal{}, err
+	}
+
+	if fields.AllowSells != nil {
+		allowSells, err := strconv.ParseBool(strings.TrimSpace(*fields.AllowSells))
+		if err != nil {
+			return types.EditBondProposal{}, sdkerrors.Wrap(types.ErrArgumentMissingOrNonBoolean, "allow sells")
+		}
+		p.AllowSells = &allowSells
+	}
+
+	return p, nil
+}
@@ -0,0 +1,54 @@
+//go:build go1.18
+// +build go1.18
+
+package client
+
+import (
+	"testing"
+)
+
+// Fuzz targets require Go 1.18 or later, and can be run using, for example:
+//
+//   go test ./x/bonds/client -run=^$ -fuzz=FuzzParseFunctionParams
+
+func FuzzParseFunctionParams(f *testing.F) {
+	f.Add("m:12,n:2,c:100")
+	f.Add("m:12,,c:100")
+	f.Add("m:12,m:13")
+	f.Add(":")
+	f.Add("m:99999999999999999999999999999999999999999999999999999999999999999")
+	f.Add("ᐈ:1.5")
+	f.Fuzz(func(t *testing.T, input string) {
+		params, err := ParseFunctionParams(input)
+		if err != nil && params != nil {
+			t.Errorf("parameters returned alongside error for %q", input)
+		}
+	})
+}
+
+func FuzzParseSigners(f *testing.F) {
+	f.Add("cosmos1g9ahr6xhht5rmqven628nklxluzyv8z9jqjcmc")
+	f.Add("")
+	f.Add(",,")
+	f.Add("ᐈ")
+	f.Fuzz(func(t *testing.T, input string) {
+		signers, err := ParseSigners(input)
+		if err != nil && signers != nil {
+			t.Errorf("signers returned alongside error for %q", input)
+		}
+	})
+}
+
+func FuzzParseTwoPartCoin(f *testing.F) {
+	f.Add("100", "abc")
+	f.Add("", "")
+	f.Add("100abc", "")
+	f.Add("1000000000000000000000000000000000000000000000000000000000000000000000000000000", "abc")
+	f.Add("１００", "abc")
+	f.Fuzz(func(t *testing.T, amount, denom string) {
+		coin, err := ParseTwoPartCoin(amount, denom)
+		if err == nil && coin.Denom != denom {
+			t.Errorf("denom %q parsed as %q", denom, coin.Denom)
+		}
+	})
+}
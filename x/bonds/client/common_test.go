@@ -0,0 +1,240 @@
+package client
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestParseFunctionParams(t *testing.T) {
+	params, err := ParseFunctionParams("n:2,m:12,c:100")
+	require.Nil(t, err)
+	require.Equal(t, types.FunctionParams{
+		types.NewFunctionParam("c", sdk.NewDec(100)),
+		types.NewFunctionParam("m", sdk.NewDec(12)),
+		types.NewFunctionParam("n", sdk.NewDec(2)),
+	}, params)
+
+	// Fractions are kept exactly
+	params, err = ParseFunctionParams("n:2,m:1/3,c:100")
+	require.Nil(t, err)
+	require.Equal(t, types.NewRationalFunctionParam("m",
+		types.NewRational(sdk.NewInt(1), sdk.NewInt(3))), params[1])
+
+	// Empty string gives no parameters
+	params, err = ParseFunctionParams("  ")
+	require.Nil(t, err)
+	require.Empty(t, params)
+}
+
+func TestParseFunctionParamsInvalidGivesError(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expectedErr *sdkerrors.Error
+	}{
+		{"m:12,,c:100", types.ErrInvalidFunctionParameter},
+		{"m:12,c", types.ErrInvalidFunctionParameter},
+		{":12", types.ErrInvalidFunctionParameter},
+		{" :12", types.ErrInvalidFunctionParameter},
+		{"m:12,m:13", types.ErrDuplicateFunctionParameter},
+		{"m:", types.ErrArgumentMissingOrNonFloat},
+		{"m:abc", types.ErrArgumentMissingOrNonFloat},
+		{"m:1.0000000000000000001", types.ErrArgumentMissingOrNonFloat},
+		{"m:١٢", types.ErrArgumentMissingOrNonFloat},
+		{"m:1/0", types.ErrArgumentMissingOrNonFloat},
+		{"m:1/3/4", types.ErrArgumentMissingOrNonFloat},
+		{"m:1.5/3", types.ErrArgumentMissingOrNonFloat},
+	}
+	for i, tc := range testCases {
+		_, err := ParseFunctionParams(tc.input)
+		require.True(t, tc.expectedErr.Is(err),
+			"unexpected result for test case #%d, input: %s", i, tc.input)
+	}
+}
+
+func TestParseEventAttributes(t *testing.T) {
+	eventAttributes, err := ParseEventAttributes("project_id:did:ixo:abc,region:eu")
+	require.Nil(t, err)
+	require.Equal(t, types.EventAttributes{
+		types.NewEventAttribute("project_id", "did:ixo:abc"),
+		types.NewEventAttribute("region", "eu"),
+	}, eventAttributes)
+
+	eventAttributes, err = ParseEventAttributes("")
+	require.Nil(t, err)
+	require.Empty(t, eventAttributes)
+}
+
+func TestParseEventAttributesInvalidGivesError(t *testing.T) {
+	testCases := []string{
+		"project_id",
+		"project_id:abc,",
+		"project_id:abc,region",
+	}
+	for i, tc := range testCases {
+		_, err := ParseEventAttributes(tc)
+		require.True(t, types.ErrInvalidEventAttribute.Is(err),
+			"unexpected result for test case #%d, input: %s", i, tc)
+	}
+}
+
+func TestParseSigners(t *testing.T) {
+	address1 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	address2 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+	signers, err := ParseSigners(address1.String() + "," + address2.String())
+	require.Nil(t, err)
+	require.Equal(t, []sdk.AccAddress{address1, address2}, signers)
+}
+
+func TestParseSignersInvalidGivesError(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+	testCases := []string{
+		"",
+		",",
+		address.String() + ",",
+		address.String() + ",,",
+		"notanaddress",
+		"ᐈ" + address.String(),
+	}
+	for i, tc := range testCases {
+		_, err := ParseSigners(tc)
+		require.True(t, sdkerrors.ErrInvalidAddress.Is(err),
+			"unexpected result for test case #%d, input: %s", i, tc)
+	}
+}
+
+func TestParseSignerThreshold(t *testing.T) {
+	threshold, err := ParseSignerThreshold("2")
+	require.Nil(t, err)
+	require.Equal(t, types.NewSignerThreshold(2, nil), threshold)
+
+	threshold, err = ParseSignerThreshold("3:2,1,1")
+	require.Nil(t, err)
+	require.Equal(t, types.NewSignerThreshold(3, []uint64{2, 1, 1}), threshold)
+
+	threshold, err = ParseSignerThreshold("")
+	require.Nil(t, err)
+	require.True(t, threshold.IsZero())
+}
+
+func TestParseSignerThresholdInvalidGivesError(t *testing.T) {
+	testCases := []string{
+		"a",
+		"-1",
+		"2:",
+		"2:1,,1",
+		"2:1,a",
+	}
+	for i, tc := range testCases {
+		_, err := ParseSignerThreshold(tc)
+		require.True(t, types.ErrArgumentMissingOrNonUInteger.Is(err),
+			"unexpected result for test case #%d, input: %s", i, tc)
+	}
+}
+
+func TestParseTwoPartCoin(t *testing.T) {
+	coin, err := ParseTwoPartCoin("100", "abc")
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin("abc", 100), coin)
+
+	testCases := []struct {
+		amount string
+		denom  string
+	}{
+		{"", "abc"},
+		{"100", ""},
+		{"-100", "abc"},
+		{"1.5", "abc"},
+		{"100abc", ""},
+		{"100", "ABC"},
+		{"100", "abc,100def"},
+		{"100000000000000000000000000000000000000000000000000000000000000000000000000000000", "abc"},
+		{"１００", "abc"},
+	}
+	for i, tc := range testCases {
+		_, err := ParseTwoPartCoin(tc.amount, tc.denom)
+		require.Error(t, err,
+			"unexpected result for test case #%d, input: %s %s", i, tc.amount, tc.denom)
+	}
+}
+
+func TestParseMsgEditBond(t *testing.T) {
+	editor := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	signers := []sdk.AccAddress{editor}
+	name, blank, rate := "new name", "", "1.5"
+
+	msg, err := ParseMsgEditBond("abc", EditBondFields{
+		Name:          &name,
+		SanityRate:    &rate,
+		NetSellCap:    &blank,
+		MinReserve:    &blank,
+		DemurrageRate: &blank,
+	}, editor, signers)
+	require.Nil(t, err)
+	require.Equal(t, []string{"name", "sanity_rate", "net_sell_cap",
+		"demurrage_rate", "min_reserve"}, msg.EditedFields())
+	require.Equal(t, name, *msg.Name)
+	require.Equal(t, sdk.MustNewDecFromStr("1.5"), *msg.SanityRate)
+
+	// Blank fields are reset
+	require.Equal(t, sdk.NewInt64Coin("abc", 0), *msg.NetSellCap)
+	require.True(t, msg.MinReserve.Empty())
+	require.True(t, msg.DemurrageRate.IsZero())
+}
+
+func TestParseMsgEditBondInvalidGivesError(t *testing.T) {
+	editor := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	invalid := func(s string) *string { return &s }
+
+	testCases := []struct {
+		fields      EditBondFields
+		expectedErr *sdkerrors.Error
+	}{
+		{EditBondFields{OrderQuantityLimits: invalid("10.5abc")}, sdkerrors.ErrInvalidCoins},
+		{EditBondFields{MinReserve: invalid("res")}, sdkerrors.ErrInvalidCoins},
+		{EditBondFields{NetSellCap: invalid("10abc,10res")}, sdkerrors.ErrInvalidCoins},
+		{EditBondFields{SanityRate: invalid("20t")}, types.ErrArgumentMissingOrNonFloat},
+		{EditBondFields{SanityMarginPercentage: invalid("20t")}, types.ErrArgumentMissingOrNonFloat},
+		{EditBondFields{NetSellCapPercentage: invalid("x")}, types.ErrArgumentMissingOrNonFloat},
+		{EditBondFields{DemurrageRate: invalid("1%")}, types.ErrArgumentMissingOrNonFloat},
+		{EditBondFields{MinReservePercentage: invalid("five")}, types.ErrArgumentMissingOrNonFloat},
+		{EditBondFields{FeeAddress: invalid("cosmos1invalid")}, sdkerrors.ErrInvalidAddress},
+	}
+	for i, tc := range testCases {
+		_, err := ParseMsgEditBond("abc", tc.fields, editor, []sdk.AccAddress{editor})
+		require.True(t, tc.expectedErr.Is(err), "unexpected result for test case #%d", i)
+	}
+}
+
+func TestParseEditBondProposal(t *testing.T) {
+	blank, fee, allowSells := "", "0.5", "false"
+
+	proposal, err := ParseEditBondProposal("title", "description", "abc",
+		EditBondProposalFields{
+			TxFeePercentage: &fee,
+			SanityRate:      &blank,
+			AllowSells:      &allowSells,
+		})
+	require.Nil(t, err)
+	require.Equal(t, []string{"tx_fee_percentage", "sanity_rate", "allow_sells"},
+		proposal.EditedFields())
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), *proposal.TxFeePercentage)
+	require.False(t, *proposal.AllowSells)
+
+	// Blank fields are reset
+	require.True(t, proposal.SanityRate.IsZero())
+
+	// Invalid fields give an error
+	invalid := "maybe"
+	_, err = ParseEditBondProposal("title", "description", "abc",
+		EditBondProposalFields{AllowSells: &invalid})
+	require.True(t, types.ErrArgumentMissingOrNonBoolean.Is(err))
+	_, err = ParseEditBondProposal("title", "description", "abc",
+		EditBondProposalFields{ExitFeePercentage: &invalid})
+	require.True(t, types.ErrArgumentMissingOrNonFloat.Is(err))
+}
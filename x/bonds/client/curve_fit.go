@@ -0,0 +1,422 @@
+package client
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Largest exponent n considered when fitting a power function
+	maxFitPowerExponent = 5
+
+	// Nelder-Mead settings used when fitting a sigmoid function
+	sigmoidFitIterations = 5000
+	sigmoidFitTolerance  = 1e-15
+)
+
+// AnchorPoint is a target price at a specific supply, used to derive the
+// function parameters of a bond from the prices that the issuer has in mind.
+type AnchorPoint struct {
+	Supply sdk.Int `json:"supply" yaml:"supply"`
+	Price  sdk.Dec `json:"price" yaml:"price"`
+}
+
+// FunctionFit is the result of fitting a function type to a set of anchor
+// points. The fit error is the largest deviation of a price given by the
+// fitted parameters from its target price, as a percentage of the target
+// price (or of the largest target price, for a target price of zero).
+type FunctionFit struct {
+	FunctionType       string               `json:"function_type" yaml:"function_type"`
+	FunctionParameters types.FunctionParams `json:"function_parameters" yaml:"function_parameters"`
+	FitErrorPercentage sdk.Dec              `json:"fit_error_percentage" yaml:"fit_error_percentage"`
+}
+
+func (f FunctionFit) String() string {
+	return fmt.Sprintf("%s %s (fit error %s%%)",
+		f.FunctionType, f.FunctionParameters, f.FitErrorPercentage)
+}
+
+// ParseAnchorPoints parses a list of anchor points in the format
+// "supply:price,supply:price" (e.g. "0:1,1000000:10"), sorted by supply.
+func ParseAnchorPoints(anchorPointsStr string) (points []AnchorPoint, err error) {
+	supplies := make(map[string]bool)
+	for _, sp := range splitParameters(anchorPointsStr) {
+		// Split each "supply:price" into ["supply","price"]
+		spArray := strings.SplitN(sp, ":", 2)
+		if len(spArray) != 2 {
+			return nil, sdkerrors.Wrap(types.ErrInvalidAnchorPoint, sp)
+		}
+
+		supply, ok := sdk.NewIntFromString(strings.TrimSpace(spArray[0]))
+		if !ok || supply.IsNegative() {
+			return nil, sdkerrors.Wrapf(types.ErrInvalidAnchorPoint,
+				"invalid supply %s", spArray[0])
+		}
+		price, err := sdk.NewDecFromStr(strings.TrimSpace(spArray[1]))
+		if err != nil || price.IsNegative() {
+			return nil, sdkerrors.Wrapf(types.ErrInvalidAnchorPoint,
+				"invalid price %s", spArray[1])
+		}
+
+		// Check for duplicate supplies, which would make the fit ambiguous
+		if supplies[supply.String()] {
+			return nil, sdkerrors.Wrapf(types.ErrInvalidAnchorPoint,
+				"duplicate supply %s", supply)
+		}
+		supplies[supply.String()] = true
+
+		points = append(points, AnchorPoint{Supply: supply, Price: price})
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Supply.LT(points[j].Supply)
+	})
+	return points, nil
+}
+
+// FitFunctionParams derives the parameters of a power or sigmoid function that
+// best fit the anchor points, and calculates the resulting fit error using the
+// same price calculation as the bonds module. A power function requires at
+// least two anchor points and a sigmoid function at least three.
+func FitFunctionParams(functionType string, points []AnchorPoint) (FunctionFit, error) {
+	var fps types.FunctionParams
+	var err error
+	switch functionType {
+	case types.PowerFunction:
+		fps, err = fitPowerFunction(points)
+	case types.SigmoidFunction:
+		fps, err = fitSigmoidFunction(points)
+	default:
+		return FunctionFit{}, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, functionType)
+	}
+	if err != nil {
+		return FunctionFit{}, err
+	} else if err := fps.Validate(functionType); err != nil {
+		return FunctionFit{}, err
+	}
+
+	fitError, err := FitErrorPercentage(functionType, fps, points)
+	if err != nil {
+		return FunctionFit{}, err
+	}
+
+	return FunctionFit{
+		FunctionType:       functionType,
+		FunctionParameters: fps,
+		FitErrorPercentage: fitError,
+	}, nil
+}
+
+// FitErrorPercentage calculates the largest deviation of a price given by the
+// function parameters from its target price (see FunctionFit).
+func FitErrorPercentage(functionType string, fps types.FunctionParams,
+	points []AnchorPoint) (sdk.Dec, error) {
+	bond := types.Bond{
+		Token:              "fit",
+		FunctionType:       functionType,
+		FunctionParameters: fps,
+		ReserveTokens:      []string{"fit"},
+	}
+
+	maxPrice := sdk.ZeroDec()
+	for _, p := range points {
+		maxPrice = sdk.MaxDec(maxPrice, p.Price)
+	}
+
+	fitError := sdk.ZeroDec()
+	for _, p := range points {
+		prices, err := bond.GetPricesAtSupply(p.Supply)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+
+		reference := p.Price
+		if reference.IsZero() {
+			reference = maxPrice
+		}
+		if reference.IsZero() {
+			continue // all target prices are zero, so deviation is undefined
+		}
+
+		deviation := prices.AmountOf(bond.ReserveTokens[0]).Sub(p.Price).Abs()
+		fitError = sdk.MaxDec(fitError, deviation.Quo(reference).MulInt64(100))
+	}
+	return fitError, nil
+}
+
+// anchorPointsToFloats converts the anchor points into supplies, prices, and
+// weights, with each weight chosen so that the fit minimises relative errors.
+func anchorPointsToFloats(points []AnchorPoint) (xs, ps, ws []float64, err error) {
+	maxPrice := 0.0
+	for _, p := range points {
+		x, err := strconv.ParseFloat(p.Supply.String(), 64)
+		if err != nil {
+			return nil, nil, nil, sdkerrors.Wrap(types.ErrInvalidAnchorPoint, err.Error())
+		}
+		price, err := strconv.ParseFloat(p.Price.String(), 64)
+		if err != nil {
+			return nil, nil, nil, sdkerrors.Wrap(types.ErrInvalidAnchorPoint, err.Error())
+		}
+		xs = append(xs, x)
+		ps = append(ps, price)
+		maxPrice = math.Max(maxPrice, price)
+	}
+	if maxPrice == 0 {
+		return nil, nil, nil, sdkerrors.Wrap(types.ErrInvalidAnchorPoint,
+			"at least one target price must be positive")
+	}
+
+	for _, price := range ps {
+		if price > 0 {
+			ws = append(ws, 1/(price*price))
+		} else {
+			ws = append(ws, 1/(maxPrice*maxPrice))
+		}
+	}
+	return xs, ps, ws, nil
+}
+
+// fitPowerFunction fits m*x^n+c to the anchor points by weighted least squares
+// for every integer n up to maxFitPowerExponent, keeping the parameters with
+// the lowest fit error. Since m and c cannot be negative, they are clamped to
+// zero where necessary. The fit error is calculated from the parameters after
+// rounding to 18 decimal places, since a large n can require an m too small to
+// be represented accurately.
+func fitPowerFunction(points []AnchorPoint) (types.FunctionParams, error) {
+	if len(points) < 2 {
+		return nil, sdkerrors.Wrap(types.ErrInvalidAnchorPoint,
+			"power function requires at least two anchor points")
+	}
+	xs, ps, ws, err := anchorPointsToFloats(points)
+	if err != nil {
+		return nil, err
+	}
+
+	var best types.FunctionParams
+	var bestError sdk.Dec
+	for n := 1; n <= maxFitPowerExponent; n++ {
+		var sw, su, suu, sp, sup float64
+		for i := range xs {
+			u := math.Pow(xs[i], float64(n))
+			sw += ws[i]
+			su += ws[i] * u
+			suu += ws[i] * u * u
+			sp += ws[i] * ps[i]
+			sup += ws[i] * u * ps[i]
+		}
+
+		det := sw*suu - su*su
+		var m, c float64
+		if det != 0 {
+			m = (sw*sup - su*sp) / det
+			c = (suu*sp - su*sup) / det
+		}
+		if det == 0 || c < 0 {
+			c = 0
+			m = sup / suu
+		}
+		if m < 0 {
+			m = 0
+			c = sp / sw
+		}
+
+		mDec, err := decFromFloat(m)
+		if err != nil {
+			continue
+		}
+		cDec, err := decFromFloat(c)
+		if err != nil {
+			continue
+		}
+		fps := types.FunctionParams{
+			types.NewFunctionParam("m", mDec),
+			types.NewFunctionParam("n", sdk.NewDec(int64(n))),
+			types.NewFunctionParam("c", cDec),
+		}
+
+		fitError, err := FitErrorPercentage(types.PowerFunction, fps, points)
+		if err != nil {
+			continue
+		}
+		if best == nil || fitError.LT(bestError) {
+			best, bestError = fps, fitError
+		}
+	}
+
+	if best == nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalidAnchorPoint,
+			"anchor points cannot be fitted")
+	}
+	return best, nil
+}
+
+// fitSigmoidFunction fits a*((x-b)/sqrt((x-b)^2+c)+1) to the anchor points by
+// minimising the weighted squared errors using the Nelder-Mead method, once
+// for every anchor point's supply as the initial guess for b. Parameters a
+// and c are searched in log space and b in absolute value, so that none of
+// the parameters can become negative (or, in the case of c, zero).
+func fitSigmoidFunction(points []AnchorPoint) (types.FunctionParams, error) {
+	if len(points) < 3 {
+		return nil, sdkerrors.Wrap(types.ErrInvalidAnchorPoint,
+			"sigmoid function requires at least three anchor points")
+	}
+	xs, ps, ws, err := anchorPointsToFloats(points)
+	if err != nil {
+		return nil, err
+	}
+
+	unpack := func(v []float64) (a, b, c float64) {
+		return math.Exp(v[0]), math.Abs(v[1]), math.Exp(v[2])
+	}
+	cost := func(v []float64) float64 {
+		a, b, c := unpack(v)
+		total := 0.0
+		for i := range xs {
+			r := a*((xs[i]-b)/math.Sqrt((xs[i]-b)*(xs[i]-b)+c)+1) - ps[i]
+			total += ws[i] * r * r
+		}
+		return total
+	}
+
+	maxPrice := 0.0
+	for _, p := range ps {
+		maxPrice = math.Max(maxPrice, p)
+	}
+	spread := math.Max(xs[len(xs)-1]-xs[0], 1)
+
+	var best []float64
+	bestCost := math.Inf(1)
+	for _, b0 := range xs {
+		start := []float64{math.Log(maxPrice / 2), b0, math.Log(spread * spread / 16)}
+		steps := []float64{0.5, spread / 4, 1}
+		v := nelderMead(cost, start, steps, sigmoidFitIterations, sigmoidFitTolerance)
+		if c := cost(v); c < bestCost {
+			best, bestCost = v, c
+		}
+	}
+	if best == nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalidAnchorPoint,
+			"anchor points cannot be fitted")
+	}
+
+	a, b, c := unpack(best)
+	aDec, err := decFromFloat(a)
+	if err != nil {
+		return nil, err
+	}
+	bDec, err := decFromFloat(b)
+	if err != nil {
+		return nil, err
+	}
+	cDec, err := decFromFloat(c)
+	if err != nil {
+		return nil, err
+	}
+	return types.FunctionParams{
+		types.NewFunctionParam("a", aDec),
+		types.NewFunctionParam("b", bDec),
+		types.NewFunctionParam("c", cDec),
+	}, nil
+}
+
+// nelderMead minimises f starting from a simplex made up of the start vertex
+// and one vertex offset from it by the step along each axis, stopping once the
+// spread of the simplex's values is within the tolerance or after the maximum
+// number of iterations.
+func nelderMead(f func([]float64) float64, start, steps []float64,
+	maxIterations int, tolerance float64) []float64 {
+	dims := len(start)
+
+	simplex := make([][]float64, dims+1)
+	values := make([]float64, dims+1)
+	for i := range simplex {
+		simplex[i] = append([]float64{}, start...)
+		if i > 0 {
+			simplex[i][i-1] += steps[i-1]
+		}
+		values[i] = f(simplex[i])
+	}
+
+	along := func(from, to []float64, t float64) []float64 {
+		v := make([]float64, dims)
+		for j := range v {
+			v[j] = from[j] + t*(to[j]-from[j])
+		}
+		return v
+	}
+
+	for iter := 0; iter < maxIterations; iter++ {
+		// Order vertices from best to worst
+		order := make([]int, dims+1)
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+		sortedSimplex := make([][]float64, dims+1)
+		sortedValues := make([]float64, dims+1)
+		for i, o := range order {
+			sortedSimplex[i], sortedValues[i] = simplex[o], values[o]
+		}
+		simplex, values = sortedSimplex, sortedValues
+
+		if values[dims]-values[0] <= tolerance*(math.Abs(values[0])+tolerance) {
+			break
+		}
+
+		// Centroid of all vertices except the worst
+		centroid := make([]float64, dims)
+		for _, v := range simplex[:dims] {
+			for j := range centroid {
+				centroid[j] += v[j] / float64(dims)
+			}
+		}
+
+		worst := simplex[dims]
+		reflected := along(centroid, worst, -1)
+		fr := f(reflected)
+		switch {
+		case fr < values[0]:
+			expanded := along(centroid, worst, -2)
+			if fe := f(expanded); fe < fr {
+				simplex[dims], values[dims] = expanded, fe
+			} else {
+				simplex[dims], values[dims] = reflected, fr
+			}
+		case fr < values[dims-1]:
+			simplex[dims], values[dims] = reflected, fr
+		default:
+			contracted := along(centroid, worst, 0.5)
+			if fc := f(contracted); fc < values[dims] {
+				simplex[dims], values[dims] = contracted, fc
+			} else {
+				// Shrink all vertices towards the best vertex
+				for i := 1; i <= dims; i++ {
+					simplex[i] = along(simplex[0], simplex[i], 0.5)
+					values[i] = f(simplex[i])
+				}
+			}
+		}
+	}
+
+	bestIndex := 0
+	for i := range values {
+		if values[i] < values[bestIndex] {
+			bestIndex = i
+		}
+	}
+	return simplex[bestIndex]
+}
+
+func decFromFloat(f float64) (sdk.Dec, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return sdk.Dec{}, sdkerrors.Wrap(types.ErrInvalidAnchorPoint,
+			"anchor points cannot be fitted")
+	}
+	return sdk.NewDecFromStr(strconv.FormatFloat(f, 'f', sdk.Precision, 64))
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseAnchorPoints(t *testing.T) {
+	points, err := ParseAnchorPoints("1000:10.5, 0:1")
+	require.Nil(t, err)
+	require.Equal(t, []AnchorPoint{
+		{Supply: sdk.NewInt(0), Price: sdk.NewDec(1)},
+		{Supply: sdk.NewInt(1000), Price: sdk.MustNewDecFromStr("10.5")},
+	}, points)
+}
+
+func TestParseAnchorPointsInvalidGivesError(t *testing.T) {
+	testCases := []string{
+		"1000",
+		"1000:",
+		"abc:10",
+		"-1:10",
+		"1.5:10",
+		"1000:-10",
+		"1000:10,1000:20",
+	}
+	for _, tc := range testCases {
+		_, err := ParseAnchorPoints(tc)
+		require.Error(t, err, tc)
+		require.True(t, types.ErrInvalidAnchorPoint.Is(err), tc)
+	}
+}
+
+func TestFitPowerFunction(t *testing.T) {
+	// Prices of 10x^2+1
+	points, err := ParseAnchorPoints("0:1,100:100001,200:400001")
+	require.Nil(t, err)
+
+	fit, err := FitFunctionParams(types.PowerFunction, points)
+	require.Nil(t, err)
+
+	paramsMap := fit.FunctionParameters.AsMap()
+	require.Equal(t, sdk.NewDec(2), paramsMap["n"])
+	require.True(t, fit.FitErrorPercentage.LT(sdk.MustNewDecFromStr("0.0001")))
+}
+
+func TestFitSigmoidFunction(t *testing.T) {
+	// Prices of 10((x-500000)/sqrt((x-500000)^2+10^10)+1)
+	points, err := ParseAnchorPoints("0:0.19419324309079777," +
+		"250000:0.7152330911474059,500000:10,750000:19.284766908852596," +
+		"1000000:19.805806756909202")
+	require.Nil(t, err)
+
+	fit, err := FitFunctionParams(types.SigmoidFunction, points)
+	require.Nil(t, err)
+	require.True(t, fit.FitErrorPercentage.LT(sdk.MustNewDecFromStr("0.01")))
+}
+
+func TestFitFunctionParamsWithTooFewPointsGivesError(t *testing.T) {
+	points, err := ParseAnchorPoints("0:1,1000:10")
+	require.Nil(t, err)
+
+	_, err = FitFunctionParams(types.PowerFunction, points[:1])
+	require.True(t, types.ErrInvalidAnchorPoint.Is(err))
+
+	_, err = FitFunctionParams(types.SigmoidFunction, points)
+	require.True(t, types.ErrInvalidAnchorPoint.Is(err))
+}
+
+func TestFitFunctionParamsWithUnsupportedFunctionTypeGivesError(t *testing.T) {
+	points, err := ParseAnchorPoints("0:1,1000:10,2000:20")
+	require.Nil(t, err)
+
+	_, err = FitFunctionParams(types.AugmentedFunction, points)
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
+}
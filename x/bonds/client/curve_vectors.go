@@ -0,0 +1,244 @@
+package client
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// Token used for the bonds from which the curve vectors are generated. Only
+// the function parameters cache is keyed by the bond token, so any token works.
+const curveVectorsToken = "vectors"
+
+// Supplies and order amounts at which the curve vectors are generated
+var (
+	curveVectorSupplies = []int64{0, 1, 10, 1000, 1000000}
+	curveVectorAmounts  = []int64{1, 10, 100}
+
+	swapperVectorSupplies = []int64{1, 10, 1000}
+	swapperVectorReserves = []sdk.Coins{
+		sdk.NewCoins(sdk.NewInt64Coin("res", 1000), sdk.NewInt64Coin("rez", 1000)),
+		sdk.NewCoins(sdk.NewInt64Coin("res", 1000), sdk.NewInt64Coin("rez", 5000)),
+		sdk.NewCoins(sdk.NewInt64Coin("res", 123456789), sdk.NewInt64Coin("rez", 987654321)),
+	}
+)
+
+// CurveVectors are the canonical pricing test vectors of the bonds module,
+// which allow alternative client implementations to verify that their pricing
+// matches the chain's pricing exactly. All values are calculated using the
+// same functions that the bonds module uses to price orders.
+type CurveVectors struct {
+	Curves   []CurveVectorCase   `json:"curves" yaml:"curves"`
+	Swappers []SwapperVectorCase `json:"swappers" yaml:"swappers"`
+}
+
+// CurveVectorCase holds the vectors of a power, sigmoid, or augmented function
+// bond with a specific set of function parameters (and state).
+type CurveVectorCase struct {
+	FunctionType       string               `json:"function_type" yaml:"function_type"`
+	FunctionParameters types.FunctionParams `json:"function_parameters" yaml:"function_parameters"`
+	State              string               `json:"state" yaml:"state"`
+	CurveVersion       uint64               `json:"curve_version" yaml:"curve_version"`
+	ReserveTokens      []string             `json:"reserve_tokens" yaml:"reserve_tokens"`
+	Vectors            []CurveVector        `json:"vectors" yaml:"vectors"`
+}
+
+// CurveVector holds the results of pricing an order amount at a supply. The
+// reserve is the reserve at the supply rounded up, as held by a bond that was
+// bought up to the supply. Returns for burn are empty if the amount exceeds
+// the supply.
+type CurveVector struct {
+	Supply          sdk.Int      `json:"supply" yaml:"supply"`
+	Reserve         sdk.Coins    `json:"reserve" yaml:"reserve"`
+	Amount          sdk.Int      `json:"amount" yaml:"amount"`
+	PricesAtSupply  sdk.DecCoins `json:"prices_at_supply" yaml:"prices_at_supply"`
+	ReserveAtSupply sdk.Dec      `json:"reserve_at_supply" yaml:"reserve_at_supply"`
+	PricesToMint    sdk.DecCoins `json:"prices_to_mint" yaml:"prices_to_mint"`
+	ReturnsForBurn  sdk.DecCoins `json:"returns_for_burn" yaml:"returns_for_burn"`
+}
+
+// SwapperVectorCase holds the vectors of a swapper function bond with a
+// specific tx fee percentage.
+type SwapperVectorCase struct {
+	FunctionType    string          `json:"function_type" yaml:"function_type"`
+	CurveVersion    uint64          `json:"curve_version" yaml:"curve_version"`
+	ReserveTokens   []string        `json:"reserve_tokens" yaml:"reserve_tokens"`
+	TxFeePercentage sdk.Dec         `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	Vectors         []SwapperVector `json:"vectors" yaml:"vectors"`
+}
+
+// SwapperVector holds the results of pricing an order amount at a supply and
+// reserve. The swap is a swap of the amount of the first reserve token to the
+// second reserve token. Returns for burn are empty if the amount exceeds the
+// supply, and returns for swap are empty if the swap would be rejected.
+type SwapperVector struct {
+	Supply         sdk.Int      `json:"supply" yaml:"supply"`
+	Reserve        sdk.Coins    `json:"reserve" yaml:"reserve"`
+	Amount         sdk.Int      `json:"amount" yaml:"amount"`
+	PricesToMint   sdk.DecCoins `json:"prices_to_mint" yaml:"prices_to_mint"`
+	ReturnsForBurn sdk.DecCoins `json:"returns_for_burn" yaml:"returns_for_burn"`
+	ReturnsForSwap sdk.Coins    `json:"returns_for_swap" yaml:"returns_for_swap"`
+	SwapTxFee      sdk.Coin     `json:"swap_tx_fee" yaml:"swap_tx_fee"`
+}
+
+func mustParseVectorParams(fpsStr string) types.FunctionParams {
+	fps, err := ParseFunctionParams(fpsStr)
+	if err != nil {
+		panic(err)
+	}
+	return fps
+}
+
+// augmentedVectorParams returns the augmented function parameters, including
+// the R0, S0, and V0 parameters derived from them when a bond is created.
+func augmentedVectorParams(fpsStr string) types.FunctionParams {
+	fps := mustParseVectorParams(fpsStr)
+	paramsMap := fps.AsMap()
+	d0, p0 := paramsMap["d0"], paramsMap["p0"]
+	theta, kappa := paramsMap["theta"], paramsMap["kappa"]
+
+	R0 := d0.Mul(sdk.OneDec().Sub(theta))
+	S0 := d0.Quo(p0)
+	V0 := types.Invariant(R0, S0, kappa.TruncateInt64())
+	return append(fps, types.FunctionParams{
+		types.NewFunctionParam("R0", R0),
+		types.NewFunctionParam("S0", S0),
+		types.NewFunctionParam("V0", V0),
+	}...)
+}
+
+// curveVectorBonds returns the bonds from which the curve vectors are
+// generated, covering each function type and state.
+func curveVectorBonds() []types.Bond {
+	newBond := func(functionType string, fps types.FunctionParams, state string) types.Bond {
+		return types.Bond{
+			Token:              curveVectorsToken,
+			FunctionType:       functionType,
+			FunctionParameters: fps,
+			ReserveTokens:      []string{"res"},
+			State:              state,
+			CurveVersion:       types.LatestCurveVersion,
+		}
+	}
+
+	augmentedParams := augmentedVectorParams("d0:500.0,p0:0.01,theta:0.4,kappa:3.0")
+	return []types.Bond{
+		newBond(types.PowerFunction, mustParseVectorParams("m:12,n:2,c:100"), types.OpenState),
+		newBond(types.PowerFunction, mustParseVectorParams("m:0.000001,n:3,c:0"), types.OpenState),
+		newBond(types.PowerFunction, mustParseVectorParams("m:1.5,n:1,c:0.5"), types.OpenState),
+		newBond(types.SigmoidFunction, mustParseVectorParams("a:3,b:5,c:1"), types.OpenState),
+		newBond(types.SigmoidFunction, mustParseVectorParams("a:100,b:500000,c:100000000"), types.OpenState),
+		newBond(types.AugmentedFunction, augmentedParams, types.HatchState),
+		newBond(types.AugmentedFunction, augmentedParams, types.OpenState),
+	}
+}
+
+// GenerateCurveVectors generates the canonical pricing test vectors of the
+// bonds module. The vectors are deterministic, so generating them on two
+// different machines gives the exact same result.
+func GenerateCurveVectors() (vectors CurveVectors, err error) {
+	for _, bond := range curveVectorBonds() {
+		c := CurveVectorCase{
+			FunctionType:       bond.FunctionType,
+			FunctionParameters: bond.FunctionParameters,
+			State:              bond.State,
+			CurveVersion:       bond.CurveVersion,
+			ReserveTokens:      bond.ReserveTokens,
+		}
+		for _, s := range curveVectorSupplies {
+			supply := sdk.NewInt(s)
+			bond.CurrentSupply = sdk.NewCoin(curveVectorsToken, supply)
+			reserveAtSupply := bond.ReserveAtSupply(supply)
+			reserve := sdk.NewCoins(sdk.NewCoin("res", reserveAtSupply.Ceil().TruncateInt()))
+
+			pricesAtSupply, err := bond.GetPricesAtSupply(supply)
+			if err != nil {
+				return CurveVectors{}, err
+			}
+
+			for _, a := range curveVectorAmounts {
+				amount := sdk.NewInt(a)
+				pricesToMint, err := bond.GetPricesToMint(amount, reserve)
+				if err != nil {
+					return CurveVectors{}, err
+				}
+
+				var returnsForBurn sdk.DecCoins
+				if amount.LTE(supply) {
+					returnsForBurn, err = bond.GetReturnsForBurn(amount, reserve)
+					if err != nil {
+						return CurveVectors{}, err
+					}
+				}
+
+				c.Vectors = append(c.Vectors, CurveVector{
+					Supply:          supply,
+					Reserve:         reserve,
+					Amount:          amount,
+					PricesAtSupply:  pricesAtSupply,
+					ReserveAtSupply: reserveAtSupply,
+					PricesToMint:    pricesToMint,
+					ReturnsForBurn:  returnsForBurn,
+				})
+			}
+		}
+		vectors.Curves = append(vectors.Curves, c)
+	}
+
+	for _, txFeePercentage := range []sdk.Dec{sdk.ZeroDec(), sdk.MustNewDecFromStr("0.3")} {
+		bond := types.Bond{
+			Token:           curveVectorsToken,
+			FunctionType:    types.SwapperFunction,
+			ReserveTokens:   []string{"res", "rez"},
+			TxFeePercentage: txFeePercentage,
+			State:           types.OpenState,
+			CurveVersion:    types.LatestCurveVersion,
+		}
+		c := SwapperVectorCase{
+			FunctionType:    bond.FunctionType,
+			CurveVersion:    bond.CurveVersion,
+			ReserveTokens:   bond.ReserveTokens,
+			TxFeePercentage: bond.TxFeePercentage,
+		}
+		for _, s := range swapperVectorSupplies {
+			supply := sdk.NewInt(s)
+			bond.CurrentSupply = sdk.NewCoin(curveVectorsToken, supply)
+			for _, reserve := range swapperVectorReserves {
+				for _, a := range curveVectorAmounts {
+					amount := sdk.NewInt(a)
+					pricesToMint, err := bond.GetPricesToMint(amount, reserve)
+					if err != nil {
+						return CurveVectors{}, err
+					}
+
+					var returnsForBurn sdk.DecCoins
+					if amount.LTE(supply) {
+						returnsForBurn, err = bond.GetReturnsForBurn(amount, reserve)
+						if err != nil {
+							return CurveVectors{}, err
+						}
+					}
+
+					// A swap that would be rejected has no returns
+					from := sdk.NewCoin("res", amount)
+					returnsForSwap, swapTxFee, err := bond.GetReturnsForSwap(from, "rez", reserve)
+					if err != nil {
+						returnsForSwap, swapTxFee = nil, sdk.NewCoin("res", sdk.ZeroInt())
+					}
+
+					c.Vectors = append(c.Vectors, SwapperVector{
+						Supply:         supply,
+						Reserve:        reserve,
+						Amount:         amount,
+						PricesToMint:   pricesToMint,
+						ReturnsForBurn: returnsForBurn,
+						ReturnsForSwap: returnsForSwap,
+						SwapTxFee:      swapTxFee,
+					})
+				}
+			}
+		}
+		vectors.Swappers = append(vectors.Swappers, c)
+	}
+
+	return vectors, nil
+}
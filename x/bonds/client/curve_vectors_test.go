@@ -0,0 +1,48 @@
+package client
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGenerateCurveVectors(t *testing.T) {
+	vectors, err := GenerateCurveVectors()
+	require.Nil(t, err)
+	require.Len(t, vectors.Curves, len(curveVectorBonds()))
+	require.Len(t, vectors.Swappers, 2)
+
+	// Check one of the vectors against the power function m*x^n+c
+	powerCase := vectors.Curves[0]
+	require.Equal(t, types.PowerFunction, powerCase.FunctionType)
+	for _, v := range powerCase.Vectors {
+		if v.Supply.Equal(sdk.NewInt(10)) {
+			// Price at supply 10 is 12*10^2+100 = 1300
+			require.True(t, sdk.NewDec(1300).Equal(v.PricesAtSupply.AmountOf("res")))
+			// Reserve at supply 10 is 12*10^3/3+100*10 = 5000
+			require.True(t, sdk.NewDec(5000).Equal(v.ReserveAtSupply))
+		}
+		if v.Amount.GT(v.Supply) {
+			require.Nil(t, v.ReturnsForBurn)
+		} else {
+			require.NotNil(t, v.ReturnsForBurn)
+		}
+	}
+}
+
+func TestGenerateCurveVectorsIsDeterministic(t *testing.T) {
+	cdc := codec.New()
+
+	vectors1, err := GenerateCurveVectors()
+	require.Nil(t, err)
+	vectors2, err := GenerateCurveVectors()
+	require.Nil(t, err)
+
+	bz1, err := cdc.MarshalJSON(vectors1)
+	require.Nil(t, err)
+	bz2, err := cdc.MarshalJSON(vectors2)
+	require.Nil(t, err)
+	require.Equal(t, bz1, bz2)
+}
@@ -0,0 +1,280 @@
+package rest
+
+import (
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/ixoworld/bonds/x/bonds/client"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"net/http"
+	"strconv"
+)
+
+type claimStuckFundsProposalReq struct {
+	BaseReq       rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Title         string       `json:"title" yaml:"title"`
+	Description   string       `json:"description" yaml:"description"`
+	ModuleAccount string       `json:"module_account" yaml:"module_account"`
+	Recipient     string       `json:"recipient" yaml:"recipient"`
+	Amount        string       `json:"amount" yaml:"amount"`
+	Deposit       string       `json:"deposit" yaml:"deposit"`
+}
+
+type migrateCurveVersionProposalReq struct {
+	BaseReq      rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Title        string       `json:"title" yaml:"title"`
+	Description  string       `json:"description" yaml:"description"`
+	BondToken    string       `json:"bond_token" yaml:"bond_token"`
+	CurveVersion string       `json:"curve_version" yaml:"curve_version"`
+	Deposit      string       `json:"deposit" yaml:"deposit"`
+}
+
+type migrateReserveTokenProposalReq struct {
+	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	BondToken   string       `json:"bond_token" yaml:"bond_token"`
+	FromDenom   string       `json:"from_denom" yaml:"from_denom"`
+	ToDenom     string       `json:"to_denom" yaml:"to_denom"`
+	Rate        string       `json:"rate" yaml:"rate"`
+	Deposit     string       `json:"deposit" yaml:"deposit"`
+}
+
+// editBondProposalReq only edits the fields that are present in the request,
+// while numeric fields that are present but blank are reset.
+type editBondProposalReq struct {
+	BaseReq                rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Title                  string       `json:"title" yaml:"title"`
+	Description            string       `json:"description" yaml:"description"`
+	BondToken              string       `json:"bond_token" yaml:"bond_token"`
+	TxFeePercentage        *string      `json:"tx_fee_percentage,omitempty" yaml:"tx_fee_percentage,omitempty"`
+	ExitFeePercentage      *string      `json:"exit_fee_percentage,omitempty" yaml:"exit_fee_percentage,omitempty"`
+	OrderQuantityLimits    *string      `json:"order_quantity_limits,omitempty" yaml:"order_quantity_limits,omitempty"`
+	SanityRate             *string      `json:"sanity_rate,omitempty" yaml:"sanity_rate,omitempty"`
+	SanityMarginPercentage *string      `json:"sanity_margin_percentage,omitempty" yaml:"sanity_margin_percentage,omitempty"`
+	AllowSells             *string      `json:"allow_sells,omitempty" yaml:"allow_sells,omitempty"`
+	Deposit                string       `json:"deposit" yaml:"deposit"`
+}
+
+func ClaimStuckFundsProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "claim_stuck_funds",
+		Handler:  claimStuckFundsProposalHandler(cliCtx),
+	}
+}
+
+func claimStuckFundsProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req claimStuckFundsProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		recipient, err := sdk.AccAddressFromBech32(req.Recipient)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		amount, err := sdk.ParseCoins(req.Amount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		deposit, err := sdk.ParseCoins(req.Deposit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		content := types.NewClaimStuckFundsProposal(
+			req.Title, req.Description, req.ModuleAccount, recipient, amount)
+
+		msg := govtypes.NewMsgSubmitProposal(content, deposit, proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+func MigrateCurveVersionProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "migrate_curve_version",
+		Handler:  migrateCurveVersionProposalHandler(cliCtx),
+	}
+}
+
+func migrateCurveVersionProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req migrateCurveVersionProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		curveVersion, err := strconv.ParseUint(req.CurveVersion, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		deposit, err := sdk.ParseCoins(req.Deposit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		content := types.NewMigrateCurveVersionProposal(
+			req.Title, req.Description, req.BondToken, curveVersion)
+
+		msg := govtypes.NewMsgSubmitProposal(content, deposit, proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+func MigrateReserveTokenProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "migrate_reserve_token",
+		Handler:  migrateReserveTokenProposalHandler(cliCtx),
+	}
+}
+
+func migrateReserveTokenProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req migrateReserveTokenProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		rate, err := sdk.NewDecFromStr(req.Rate)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		deposit, err := sdk.ParseCoins(req.Deposit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		content := types.NewMigrateReserveTokenProposal(req.Title,
+			req.Description, req.BondToken, req.FromDenom, req.ToDenom, rate)
+
+		msg := govtypes.NewMsgSubmitProposal(content, deposit, proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+func EditBondProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "edit_bond",
+		Handler:  editBondProposalHandler(cliCtx),
+	}
+}
+
+func editBondProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req editBondProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		deposit, err := sdk.ParseCoins(req.Deposit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fields := client.EditBondProposalFields{
+			TxFeePercentage:        req.TxFeePercentage,
+			ExitFeePercentage:      req.ExitFeePercentage,
+			OrderQuantityLimits:    req.OrderQuantityLimits,
+			SanityRate:             req.SanityRate,
+			SanityMarginPercentage: req.SanityMarginPercentage,
+			AllowSells:             req.AllowSells,
+		}
+		content, err := client.ParseEditBondProposal(
+			req.Title, req.Description, req.BondToken, fields)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := govtypes.NewMsgSubmitProposal(content, deposit, proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
@@ -6,7 +6,9 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/gorilla/mux"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	"net/http"
+	"strconv"
 )
 
 func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute string) {
@@ -14,11 +16,38 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		"/bonds", queryBondsHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		"/bonds/module_stats", queryModuleStatsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/bonds/params", queryParamsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/bonds/search", querySearchBondsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/order_receipts/{%s}", RestOrderReceipt),
+		queryOrderByReceiptHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/bond_proposals/{%s}", RestProposalID),
+		queryBondProposalHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/bonds/{%s}", RestBondToken),
 		queryBondHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/admin", RestBondToken),
+		queryBondAdminHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/bonds/{%s}/batch", RestBondToken),
 		queryBatchHandler(cliCtx, queryRoute),
@@ -29,11 +58,91 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		queryLastBatchHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/last_batch_result", RestBondToken),
+		queryLastBatchResultHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/effective_batch_blocks", RestBondToken),
+		queryEffectiveBatchBlocksHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/batch_orders", RestBondToken),
+		queryBatchOrdersHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/batch_auction", RestBondToken),
+		queryBatchAuctionHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/simulate_batch", RestBondToken),
+		querySimulateBatchHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/supply_history", RestBondToken),
+		querySupplyHistoryHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/reserve_history", RestBondToken),
+		queryReserveHistoryHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/at_height/{%s}", RestBondToken, RestAtHeight),
+		queryBondAtHeightHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/export", RestBondToken),
+		queryExportHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/ledger", RestBondToken),
+		queryLedgerHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/ledger_export", RestBondToken),
+		queryLedgerExportHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/effective_apr", RestBondToken),
+		queryEffectiveAPRHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/scheduled_param_change", RestBondToken),
+		queryScheduledParamChangeHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/bond_proposals", RestBondToken),
+		queryBondProposalsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/notification_registrations", RestBondToken),
+		queryNotificationRegistrationsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/bonds/{%s}/current_price", RestBondToken),
 		queryCurrentPriceHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/quote_price", RestBondToken),
+		queryQuotePriceHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/bonds/{%s}/current_reserve", RestBondToken),
 		queryCurrentReserveHandler(cliCtx, queryRoute),
@@ -58,26 +167,129 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		fmt.Sprintf("/bonds/{%s}/swap_return/{%s}/{%s}", RestBondToken, RestFromTokenWithAmount, RestToToken),
 		querySwapReturnHandler(cliCtx, queryRoute),
 	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/fee_preview/{%s}", RestBondToken, RestReserveAmounts),
+		queryFeePreviewHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/price_impact/{%s}/{%s}", RestBondToken, RestOrderType, RestBondAmount),
+		queryPriceImpactHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/price_impact/swap/{%s}/{%s}", RestBondToken, RestFromTokenWithAmount, RestToToken),
+		querySwapPriceImpactHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/sanity_check/{%s}/{%s}", RestBondToken, RestOrderType, RestBondAmount),
+		querySanityCheckHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/sanity_check/swap/{%s}/{%s}", RestBondToken, RestFromTokenWithAmount, RestToToken),
+		querySwapSanityCheckHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/is_trade_allowed/{%s}/{%s}", RestBondToken, RestOrderType, RestTokenWithAmount),
+		queryIsTradeAllowedHandler(cliCtx, queryRoute),
+	).Methods("GET")
 }
 
 func queryBondsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		res, _, err := cliCtx.QueryWithData(
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
 			fmt.Sprintf("custom/%s/bonds", queryRoute), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryModuleStatsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/module_stats", queryRoute), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryParamsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/params", queryRoute), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func querySearchBondsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		query := r.URL.Query().Get(RestSearchQuery)
+		limit := r.URL.Query().Get(RestSearchLimit)
+		if limit == "" {
+			limit = strconv.Itoa(types.DefaultBondSearchLimit)
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/search_bonds/%s/%s",
+				queryRoute, limit, query), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
 func queryBondHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		bondToken := vars[RestBondToken]
 
-		res, _, err := cliCtx.QueryWithData(
+		res, height, err := cliCtx.QueryWithData(
 			fmt.Sprintf("custom/%s/bond/%s",
 				queryRoute, bondToken), nil)
 		if err != nil {
@@ -85,16 +297,45 @@ func queryBondHandler(cliCtx context.CLIContext, queryRoute string) http.Handler
 			return
 		}
 
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBondAdminHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/bond_admin/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
 func queryBatchHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		bondToken := vars[RestBondToken]
 
-		res, _, err := cliCtx.QueryWithData(
+		res, height, err := cliCtx.QueryWithData(
 			fmt.Sprintf("custom/%s/batch/%s",
 				queryRoute, bondToken), nil)
 		if err != nil {
@@ -102,16 +343,79 @@ func queryBatchHandler(cliCtx context.CLIContext, queryRoute string) http.Handle
 			return
 		}
 
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBatchOrdersHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		page, limit := 1, types.DefaultBatchOrdersLimit
+		var err error
+		if pageStr := r.URL.Query().Get(RestPage); pageStr != "" {
+			page, err = strconv.Atoi(pageStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		if limitStr := r.URL.Query().Get(RestSearchLimit); limitStr != "" {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		var account sdk.AccAddress
+		if accountStr := r.URL.Query().Get(RestAccount); accountStr != "" {
+			account, err = sdk.AccAddressFromBech32(accountStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		params := types.NewQueryBatchOrdersParams(page, limit,
+			r.URL.Query().Get(RestSide), account, r.URL.Query().Get(RestStatus))
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/batch_orders/%s",
+				queryRoute, bondToken), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
 func queryLastBatchHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		bondToken := vars[RestBondToken]
 
-		res, _, err := cliCtx.QueryWithData(
+		res, height, err := cliCtx.QueryWithData(
 			fmt.Sprintf("custom/%s/last_batch/%s",
 				queryRoute, bondToken), nil)
 		if err != nil {
@@ -119,120 +423,786 @@ func queryLastBatchHandler(cliCtx context.CLIContext, queryRoute string) http.Ha
 			return
 		}
 
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
-func queryCurrentPriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+func queryLastBatchResultHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		bondToken := vars[RestBondToken]
 
-		res, _, err := cliCtx.QueryWithData(
-			fmt.Sprintf("custom/%s/current_price/%s",
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/last_batch_result/%s",
 				queryRoute, bondToken), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
 
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
-func queryCurrentReserveHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+func queryEffectiveBatchBlocksHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		bondToken := vars[RestBondToken]
 
-		res, _, err := cliCtx.QueryWithData(
-			fmt.Sprintf("custom/%s/current_reserve/%s",
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/effective_batch_blocks/%s",
 				queryRoute, bondToken), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
 
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
-func queryCustomPriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+func queryBatchAuctionHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		bondToken := vars[RestBondToken]
-		bondAmount := vars[RestBondAmount]
 
-		res, _, err := cliCtx.QueryWithData(
-			fmt.Sprintf("custom/%s/custom_price/%s/%s",
-				queryRoute, bondToken, bondAmount), nil)
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/batch_auction/%s",
+				queryRoute, bondToken), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
 
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
-func queryBuyPriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+func querySimulateBatchHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		bondToken := vars[RestBondToken]
-		bondAmount := vars[RestBondAmount]
 
-		res, _, err := cliCtx.QueryWithData(
-			fmt.Sprintf("custom/%s/buy_price/%s/%s",
-				queryRoute, bondToken, bondAmount), nil)
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/simulate_batch/%s",
+				queryRoute, bondToken), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
 
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
-func querySellReturnHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+func querySupplyHistoryHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		bondToken := vars[RestBondToken]
-		bondAmount := vars[RestBondAmount]
 
-		res, _, err := cliCtx.QueryWithData(
-			fmt.Sprintf("custom/%s/sell_return/%s/%s",
-				queryRoute, bondToken, bondAmount), nil)
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/supply_history/%s",
+				queryRoute, bondToken), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
 
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
 
-func querySwapReturnHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+func queryReserveHistoryHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
 		vars := mux.Vars(r)
 		bondToken := vars[RestBondToken]
-		fromTokenWithAmount := vars[RestFromTokenWithAmount]
-		toToken := vars[RestToToken]
 
-		reserveCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/reserve_history/%s",
+				queryRoute, bondToken), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
 
-		res, _, err := cliCtx.QueryWithData(
-			fmt.Sprintf("custom/%s/swap_return/%s/%s/%s/%s",
-				queryRoute, bondToken, reserveCoinWithAmount.Denom,
-				reserveCoinWithAmount.Amount.String(), toToken), nil)
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBondAtHeightHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		atHeight := vars[RestAtHeight]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/bond_at_height/%s/%s",
+				queryRoute, bondToken, atHeight), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryExportHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		format := r.URL.Query().Get(RestFormat)
+		if format == "" {
+			format = types.ExportFormatCSV
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/export/%s/%s",
+				queryRoute, bondToken, format), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryLedgerHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		page, limit := 1, types.DefaultLedgerEntriesLimit
+		var err error
+		if pageStr := r.URL.Query().Get(RestPage); pageStr != "" {
+			page, err = strconv.Atoi(pageStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		if limitStr := r.URL.Query().Get(RestSearchLimit); limitStr != "" {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		params := types.NewQueryLedgerParams(page, limit)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/ledger/%s",
+				queryRoute, bondToken), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryLedgerExportHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		format := r.URL.Query().Get(RestFormat)
+		if format == "" {
+			format = types.ExportFormatCSV
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/ledger_export/%s/%s",
+				queryRoute, bondToken, format), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryEffectiveAPRHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/effective_apr/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryScheduledParamChangeHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/scheduled_param_change/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBondProposalsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/bond_proposals/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryNotificationRegistrationsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/notification_registrations/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBondProposalHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		proposalID := vars[RestProposalID]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/bond_proposal/%s",
+				queryRoute, proposalID), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryCurrentPriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/current_price/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryQuotePriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/quote_price/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryCurrentReserveHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/current_reserve/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryCustomPriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		bondAmount := vars[RestBondAmount]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/custom_price/%s/%s",
+				queryRoute, bondToken, bondAmount), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBuyPriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		bondAmount := vars[RestBondAmount]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/buy_price/%s/%s",
+				queryRoute, bondToken, bondAmount), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func querySellReturnHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		bondAmount := vars[RestBondAmount]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/sell_return/%s/%s",
+				queryRoute, bondToken, bondAmount), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func querySwapReturnHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		fromTokenWithAmount := vars[RestFromTokenWithAmount]
+		toToken := vars[RestToToken]
+
+		reserveCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/swap_return/%s/%s/%s/%s",
+				queryRoute, bondToken, reserveCoinWithAmount.Denom,
+				reserveCoinWithAmount.Amount.String(), toToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryFeePreviewHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		reserveAmounts, err := sdk.ParseDecCoins(vars[RestReserveAmounts])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/fee_preview/%s/%s",
+				queryRoute, bondToken, reserveAmounts.String()), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryPriceImpactHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		orderType := vars[RestOrderType]
+		bondAmount := vars[RestBondAmount]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/price_impact/%s/%s/%s",
+				queryRoute, bondToken, orderType, bondAmount), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func querySwapPriceImpactHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		fromTokenWithAmount := vars[RestFromTokenWithAmount]
+		toToken := vars[RestToToken]
+
+		reserveCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/price_impact/%s/%s/%s/%s/%s",
+				queryRoute, bondToken, types.AttributeValueSwapOrder,
+				reserveCoinWithAmount.Denom,
+				reserveCoinWithAmount.Amount.String(), toToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func querySanityCheckHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		orderType := vars[RestOrderType]
+		bondAmount := vars[RestBondAmount]
+
+		path := fmt.Sprintf("custom/%s/sanity_check/%s/%s/%s",
+			queryRoute, bondToken, orderType, bondAmount)
+
+		// Max prices are only required for the first buy
+		if maxPrices := r.URL.Query().Get(RestMaxPrices); maxPrices != "" {
+			path = fmt.Sprintf("%s/%s", path, maxPrices)
+		}
+
+		res, height, err := cliCtx.QueryWithData(path, nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func querySwapSanityCheckHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		fromTokenWithAmount := vars[RestFromTokenWithAmount]
+		toToken := vars[RestToToken]
+
+		reserveCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/sanity_check/%s/%s/%s/%s/%s",
+				queryRoute, bondToken, types.AttributeValueSwapOrder,
+				reserveCoinWithAmount.Denom,
+				reserveCoinWithAmount.Amount.String(), toToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryIsTradeAllowedHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		orderType := vars[RestOrderType]
+
+		coinWithAmount, err := sdk.ParseCoin(vars[RestTokenWithAmount])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		account, err := sdk.AccAddressFromBech32(r.URL.Query().Get(RestAccount))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Max prices are only used for buys and the to token for swaps
+		var maxPrices sdk.Coins
+		if maxPricesStr := r.URL.Query().Get(RestMaxPrices); maxPricesStr != "" {
+			maxPrices, err = sdk.ParseCoins(maxPricesStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		params := types.NewQueryIsTradeAllowedParams(orderType, account,
+			coinWithAmount, maxPrices, r.URL.Query().Get(RestToToken))
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/is_trade_allowed/%s",
+				queryRoute, bondToken), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryOrderByReceiptHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		receipt := vars[RestOrderReceipt]
+
+		res, height, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/order_by_receipt/%s",
+				queryRoute, receipt), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
 		}
 
+		cliCtx = cliCtx.WithHeight(height)
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
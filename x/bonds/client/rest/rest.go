@@ -12,8 +12,22 @@ import (
 const (
 	RestBondToken           = "bond_token"
 	RestBondAmount          = "bond_amount"
+	RestOrderType           = "order_type"
+	RestOrderReceipt        = "receipt"
+	RestProposalID          = "proposal_id"
 	RestFromTokenWithAmount = "from_token_with_amount"
 	RestToToken             = "to_token"
+	RestTokenWithAmount     = "token_with_amount"
+	RestMaxPrices           = "max_prices"
+	RestReserveAmounts      = "reserve_amounts"
+	RestSearchQuery         = "q"
+	RestSearchLimit         = "limit"
+	RestPage                = "page"
+	RestSide                = "side"
+	RestAccount             = "account"
+	RestStatus              = "status"
+	RestAtHeight            = "at_height"
+	RestFormat              = "format"
 )
 
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, queryRoute string) {
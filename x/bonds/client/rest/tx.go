@@ -10,6 +10,7 @@ import (
 	"github.com/ixoworld/bonds/x/bonds/client"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -18,9 +19,21 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/bonds/edit_bond", editBondHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/buy", buyHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/sell", sellHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/sell_by_value", sellByValueHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/swap", swapHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/make_outcome_payment", makeOutcomePaymentHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/withdraw_share", withdrawShareHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/authorized_transfer", authorizedTransferHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/schedule_param_change", scheduleParamChangeHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/cancel_param_change", cancelParamChangeHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/submit_bond_proposal", submitBondProposalHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/vote_bond_proposal", voteBondProposalHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/set_bond_translations", setBondTranslationsHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/set_sanity_rate", setSanityRateHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/rebalance_swap", rebalanceSwapHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/sweep_fee_dust", sweepFeeDustHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/register_notifications", registerNotificationsHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/unregister_notifications", unregisterNotificationsHandler(cliCtx)).Methods("POST")
 }
 
 type createBondReq struct {
@@ -39,9 +52,20 @@ type createBondReq struct {
 	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
 	SanityMarginPercentage string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
 	AllowSells             string       `json:"allow_sells" yaml:"allow_sells"`
+	NonTransferable        string       `json:"non_transferable" yaml:"non_transferable"`
+	RequireAttestation     string       `json:"require_attestation" yaml:"require_attestation"`
 	Signers                string       `json:"signers" yaml:"signers"`
 	BatchBlocks            string       `json:"batch_blocks" yaml:"batch_blocks"`
 	OutcomePayment         string       `json:"outcome_payment" yaml:"outcome_payment"`
+	Milestones             string       `json:"milestones" yaml:"milestones"`
+	ProposalVotingBlocks   string       `json:"proposal_voting_blocks" yaml:"proposal_voting_blocks"`
+	EventAttributes        string       `json:"event_attributes" yaml:"event_attributes"`
+	PreMine                string       `json:"pre_mine" yaml:"pre_mine"`
+	AtMaxSupplyBehavior    string       `json:"at_max_supply_behavior" yaml:"at_max_supply_behavior"`
+	SignerThreshold        string       `json:"signer_threshold" yaml:"signer_threshold"`
+	CurveSegments          string       `json:"curve_segments" yaml:"curve_segments"`
+	SoftCap                string       `json:"soft_cap" yaml:"soft_cap"`
+	RaiseDeadline          string       `json:"raise_deadline" yaml:"raise_deadline"`
 }
 
 func createBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -138,6 +162,32 @@ func createBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse nonTransferable (optional, defaults to false)
+		var nonTransferable bool
+		nonTransferableStrLower := strings.ToLower(req.NonTransferable)
+		if nonTransferableStrLower == "true" {
+			nonTransferable = true
+		} else if nonTransferableStrLower == "false" || nonTransferableStrLower == "" {
+			nonTransferable = false
+		} else {
+			err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonBoolean, "non_transferable")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse requireAttestation (optional, defaults to false)
+		var requireAttestation bool
+		requireAttestationStrLower := strings.ToLower(req.RequireAttestation)
+		if requireAttestationStrLower == "true" {
+			requireAttestation = true
+		} else if requireAttestationStrLower == "false" || requireAttestationStrLower == "" {
+			requireAttestation = false
+		} else {
+			err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonBoolean, "require_attestation")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		// Parse signers
 		signers, err := client.ParseSigners(req.Signers)
 		if err != nil {
@@ -160,24 +210,112 @@ func createBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse milestones
+		var milestones []types.Milestone
+		if len(req.Milestones) != 0 {
+			err2 = cliCtx.Codec.UnmarshalJSON([]byte(req.Milestones), &milestones)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
+		// Parse curve segments
+		var curveSegments []types.CurveSegment
+		if len(req.CurveSegments) != 0 {
+			err2 = cliCtx.Codec.UnmarshalJSON([]byte(req.CurveSegments), &curveSegments)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
+		// Parse proposal voting blocks (optional, defaults to 0)
+		var proposalVotingBlocks uint64
+		if len(req.ProposalVotingBlocks) != 0 {
+			proposalVotingBlocks, err2 = strconv.ParseUint(req.ProposalVotingBlocks, 10, 64)
+			if err2 != nil {
+				err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "proposal voting blocks")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse event attributes
+		eventAttributes, err2 := client.ParseEventAttributes(req.EventAttributes)
+		if err2 != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+			return
+		}
+
+		// Parse pre-mine
+		preMine, err2 := sdk.ParseCoins(req.PreMine)
+		if err2 != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+			return
+		}
+
+		// Parse soft cap
+		softCap, err2 := sdk.ParseCoins(req.SoftCap)
+		if err2 != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+			return
+		}
+
+		// Parse raise deadline (optional, defaults to 0)
+		var raiseDeadline int64
+		if len(req.RaiseDeadline) != 0 {
+			raiseDeadline, err2 = strconv.ParseInt(req.RaiseDeadline, 10, 64)
+			if err2 != nil {
+				err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "raise deadline")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
 		msg := types.NewMsgCreateBond(req.Token, req.Name, req.Description,
 			creator, req.FunctionType, functionParams, reserveTokens,
 			txFeePercentageDec, exitFeePercentageDec, feeAddress, maxSupply,
 			orderQuantityLimits, sanityRate, sanityMarginPercentage,
-			allowSells, signers, batchBlocks, outcomePayment)
+			allowSells, nonTransferable, requireAttestation, signers,
+			batchBlocks, outcomePayment, milestones, proposalVotingBlocks,
+			eventAttributes, preMine, req.AtMaxSupplyBehavior)
+		msg.CurveSegments = curveSegments
+		msg.SoftCap = softCap
+		msg.RaiseDeadline = raiseDeadline
+
+		// Parse signer threshold (if any)
+		if strings.TrimSpace(req.SignerThreshold) != "" {
+			signerThreshold, err2 := client.ParseSignerThreshold(req.SignerThreshold)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+			msg.SignerThreshold = &signerThreshold
+		}
 
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
+// editBondReq only edits the fields that are present in the request, while
+// fields that are present but blank are reset.
 type editBondReq struct {
 	BaseReq                rest.BaseReq `json:"base_req" yaml:"base_req"`
 	Token                  string       `json:"token" yaml:"token"`
-	Name                   string       `json:"name" yaml:"name"`
-	Description            string       `json:"description" yaml:"description"`
-	OrderQuantityLimits    string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
-	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
-	SanityMarginPercentage string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	Name                   *string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Description            *string      `json:"description,omitempty" yaml:"description,omitempty"`
+	OrderQuantityLimits    *string      `json:"order_quantity_limits,omitempty" yaml:"order_quantity_limits,omitempty"`
+	SanityRate             *string      `json:"sanity_rate,omitempty" yaml:"sanity_rate,omitempty"`
+	SanityMarginPercentage *string      `json:"sanity_margin_percentage,omitempty" yaml:"sanity_margin_percentage,omitempty"`
+	NetSellCap             *string      `json:"net_sell_cap,omitempty" yaml:"net_sell_cap,omitempty"`
+	NetSellCapPercentage   *string      `json:"net_sell_cap_percentage,omitempty" yaml:"net_sell_cap_percentage,omitempty"`
+	DemurrageRate          *string      `json:"demurrage_rate,omitempty" yaml:"demurrage_rate,omitempty"`
+	QuoteDenom             *string      `json:"quote_denom,omitempty" yaml:"quote_denom,omitempty"`
+	MinReserve             *string      `json:"min_reserve,omitempty" yaml:"min_reserve,omitempty"`
+	MinReservePercentage   *string      `json:"min_reserve_percentage,omitempty" yaml:"min_reserve_percentage,omitempty"`
+	FeeAddress             *string      `json:"fee_address,omitempty" yaml:"fee_address,omitempty"`
+	SignerThreshold        *string      `json:"signer_threshold,omitempty" yaml:"signer_threshold,omitempty"`
 	Signers                string       `json:"signers" yaml:"signers"`
 }
 
@@ -208,19 +346,39 @@ func editBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgEditBond(req.Token, req.Name, req.Description,
-			req.OrderQuantityLimits, req.SanityRate, req.SanityMarginPercentage,
-			editor, signers)
+		fields := client.EditBondFields{
+			Name:                   req.Name,
+			Description:            req.Description,
+			OrderQuantityLimits:    req.OrderQuantityLimits,
+			SanityRate:             req.SanityRate,
+			SanityMarginPercentage: req.SanityMarginPercentage,
+			NetSellCap:             req.NetSellCap,
+			NetSellCapPercentage:   req.NetSellCapPercentage,
+			DemurrageRate:          req.DemurrageRate,
+			QuoteDenom:             req.QuoteDenom,
+			MinReserve:             req.MinReserve,
+			MinReservePercentage:   req.MinReservePercentage,
+			FeeAddress:             req.FeeAddress,
+			SignerThreshold:        req.SignerThreshold,
+		}
+		msg, err := client.ParseMsgEditBond(req.Token, fields, editor, signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
 type buyReq struct {
-	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	BondToken  string       `json:"bond_token" yaml:"bond_token"`
-	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
-	MaxPrices  string       `json:"max_prices" yaml:"max_prices"`
+	BaseReq         rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken       string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount      string       `json:"bond_amount" yaml:"bond_amount"`
+	MaxPrices       string       `json:"max_prices" yaml:"max_prices"`
+	CallbackPayload string       `json:"callback_payload" yaml:"callback_payload"`
+	OrderMemo       string       `json:"order_memo" yaml:"order_memo"`
+	PriorityFee     string       `json:"priority_fee" yaml:"priority_fee"`
 }
 
 func buyHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -255,7 +413,16 @@ func buyHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		priorityFee, err := sdk.ParseCoins(req.PriorityFee)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		msg := types.NewMsgBuy(buyer, bondCoin, maxPrices)
+		msg.CallbackPayload = req.CallbackPayload
+		msg.Memo = req.OrderMemo
+		msg.PriorityFee = priorityFee
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
@@ -264,6 +431,7 @@ type sellReq struct {
 	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
 	BondToken  string       `json:"bond_token" yaml:"bond_token"`
 	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
+	OrderMemo  string       `json:"order_memo" yaml:"order_memo"`
 }
 
 func sellHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -293,6 +461,53 @@ func sellHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		}
 
 		msg := types.NewMsgSell(seller, bondCoin)
+		msg.Memo = req.OrderMemo
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type sellByValueReq struct {
+	BaseReq       rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken     string       `json:"bond_token" yaml:"bond_token"`
+	MaxBondAmount string       `json:"max_bond_amount" yaml:"max_bond_amount"`
+	Returns       string       `json:"returns" yaml:"returns"`
+	OrderMemo     string       `json:"order_memo" yaml:"order_memo"`
+}
+
+func sellByValueHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req sellByValueReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		seller, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		maxBondCoin, err := client.ParseTwoPartCoin(req.MaxBondAmount, req.BondToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		returns, err := sdk.ParseCoins(req.Returns)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSellByValue(seller, returns, maxBondCoin)
+		msg.Memo = req.OrderMemo
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
@@ -303,6 +518,8 @@ type swapReq struct {
 	FromAmount string       `json:"from_amount" yaml:"from_amount"`
 	FromToken  string       `json:"from_token" yaml:"from_token"`
 	ToToken    string       `json:"to_token" yaml:"to_token"`
+	OrderMemo  string       `json:"order_memo" yaml:"order_memo"`
+	MinReturn  string       `json:"min_return" yaml:"min_return"`
 }
 
 func swapHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -333,6 +550,15 @@ func swapHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		}
 
 		msg := types.NewMsgSwap(swapper, req.BondToken, fromCoin, req.ToToken)
+		msg.Memo = req.OrderMemo
+		if req.MinReturn != "" {
+			minReturn, err := client.ParseTwoPartCoin(req.MinReturn, req.ToToken)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			msg.MinReturn = &minReturn
+		}
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
@@ -396,3 +622,487 @@ func withdrawShareHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
+
+type authorizedTransferReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken  string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
+	FromAddr   string       `json:"from_address" yaml:"from_address"`
+	ToAddr     string       `json:"to_address" yaml:"to_address"`
+	Reason     string       `json:"reason" yaml:"reason"`
+	Signers    string       `json:"signers" yaml:"signers"`
+}
+
+func authorizedTransferHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req authorizedTransferReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		from, err := sdk.AccAddressFromBech32(req.FromAddr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		to, err := sdk.AccAddressFromBech32(req.ToAddr)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		amount, err := client.ParseTwoPartCoin(req.BondAmount, req.BondToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgAuthorizedTransfer(from, to, amount, req.Reason, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type scheduleParamChangeReq struct {
+	BaseReq            rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken          string       `json:"bond_token" yaml:"bond_token"`
+	FunctionParameters string       `json:"function_parameters" yaml:"function_parameters"`
+	EffectiveHeight    string       `json:"effective_height" yaml:"effective_height"`
+	Interpolate        bool         `json:"interpolate" yaml:"interpolate"`
+	Signers            string       `json:"signers" yaml:"signers"`
+}
+
+func scheduleParamChangeHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req scheduleParamChangeReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse function parameters
+		functionParams, err := client.ParseFunctionParams(req.FunctionParameters)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse effective height
+		effectiveHeight, err := strconv.ParseInt(req.EffectiveHeight, 10, 64)
+		if err != nil {
+			err = sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "effective height")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgScheduleParamChange(req.BondToken, functionParams,
+			effectiveHeight, req.Interpolate, editor, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type cancelParamChangeReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Signers   string       `json:"signers" yaml:"signers"`
+}
+
+func cancelParamChangeHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req cancelParamChangeReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCancelParamChange(req.BondToken, editor, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type submitBondProposalReq struct {
+	BaseReq          rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken        string       `json:"bond_token" yaml:"bond_token"`
+	Title            string       `json:"title" yaml:"title"`
+	Description      string       `json:"description" yaml:"description"`
+	ProposalType     string       `json:"proposal_type" yaml:"proposal_type"`
+	FundingRecipient string       `json:"funding_recipient" yaml:"funding_recipient"`
+	FundingAmount    string       `json:"funding_amount" yaml:"funding_amount"`
+}
+
+func submitBondProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req submitBondProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse funding recipient (optional)
+		var fundingRecipient sdk.AccAddress
+		if req.FundingRecipient != "" {
+			fundingRecipient, err = sdk.AccAddressFromBech32(req.FundingRecipient)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse funding amount
+		fundingAmount, err := sdk.ParseCoins(req.FundingAmount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSubmitBondProposal(req.BondToken, proposer, req.Title,
+			req.Description, req.ProposalType, fundingRecipient, fundingAmount)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type voteBondProposalReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	ProposalID string       `json:"proposal_id" yaml:"proposal_id"`
+	Option     string       `json:"option" yaml:"option"`
+}
+
+func voteBondProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req voteBondProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		voter, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse proposal ID
+		proposalID, err := strconv.ParseUint(req.ProposalID, 10, 64)
+		if err != nil {
+			err = sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "proposal ID")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgVoteBondProposal(proposalID, voter, req.Option)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setBondTranslationsReq struct {
+	BaseReq      rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken    string       `json:"bond_token" yaml:"bond_token"`
+	Translations string       `json:"translations" yaml:"translations"`
+	Signers      string       `json:"signers" yaml:"signers"`
+}
+
+func setBondTranslationsHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setBondTranslationsReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse translations (blank to remove all translations)
+		var translations types.BondTranslations
+		if len(req.Translations) != 0 {
+			err = cliCtx.Codec.UnmarshalJSON([]byte(req.Translations), &translations)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSetBondTranslations(req.BondToken, translations, editor, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setSanityRateReq struct {
+	BaseReq                rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken              string       `json:"bond_token" yaml:"bond_token"`
+	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	Signers                string       `json:"signers" yaml:"signers"`
+}
+
+func setSanityRateHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setSanityRateReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse sanity rate and sanity margin percentage
+		sanityRate, err := sdk.NewDecFromStr(req.SanityRate)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sanityMarginPercentage, err := sdk.NewDecFromStr(req.SanityMarginPercentage)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSetSanityRate(req.BondToken, sanityRate,
+			sanityMarginPercentage, editor, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type rebalanceSwapReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken  string       `json:"bond_token" yaml:"bond_token"`
+	FromAmount string       `json:"from_amount" yaml:"from_amount"`
+	FromToken  string       `json:"from_token" yaml:"from_token"`
+	ToToken    string       `json:"to_token" yaml:"to_token"`
+	Signers    string       `json:"signers" yaml:"signers"`
+}
+
+func rebalanceSwapHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req rebalanceSwapReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		swapper, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Check that from amount and token can be parsed to a coin
+		fromCoin, err := client.ParseTwoPartCoin(req.FromAmount, req.FromToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRebalanceSwap(swapper, req.BondToken, fromCoin, req.ToToken, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type sweepFeeDustReq struct {
+	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
+	TargetDenom string       `json:"target_denom" yaml:"target_denom"`
+}
+
+func sweepFeeDustHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req sweepFeeDustReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSweepFeeDust(owner, req.TargetDenom)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type registerNotificationsReq struct {
+	BaseReq           rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken         string       `json:"bond_token" yaml:"bond_token"`
+	NotificationTypes string       `json:"notification_types" yaml:"notification_types"`
+}
+
+func registerNotificationsHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req registerNotificationsReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		relayer, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		notificationTypes := strings.Split(req.NotificationTypes, ",")
+
+		msg := types.NewMsgRegisterNotifications(relayer, req.BondToken, notificationTypes)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type unregisterNotificationsReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+}
+
+func unregisterNotificationsHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req unregisterNotificationsReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		relayer, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUnregisterNotifications(relayer, req.BondToken)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
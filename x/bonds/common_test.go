@@ -19,6 +19,7 @@ var (
 	blankSanityMarginPercentage = "0"
 	reserveToken                = "res"
 	reserveToken2               = "rez"
+	reserveToken3               = "rec"
 
 	anotherAddress = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	userAddress    = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
@@ -35,6 +36,8 @@ var (
 	initSanityRate             = sdk.MustNewDecFromStr(blankSanityRate)
 	initSanityMarginPercentage = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
 	initAllowSell              = true
+	initNonTransferable        = false
+	initRequireAttestation     = false
 	initSigners                = []sdk.AccAddress{initCreator}
 	initBatchBlocks            = sdk.OneUint()
 	initOutcomePayment         = sdk.Coins(nil)
@@ -113,6 +116,43 @@ func newValidMsgCreateSwapperBond() types.MsgCreateBond {
 	return validMsg
 }
 
+func newValidMsgCreateWeightedSwapperBond() types.MsgCreateBond {
+	validMsg := newValidMsgCreateBond()
+	validMsg.FunctionType = types.WeightedSwapperFunction
+	validMsg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam("w0", sdk.MustNewDecFromStr("0.5")),
+		types.NewFunctionParam("w1", sdk.MustNewDecFromStr("0.25")),
+		types.NewFunctionParam("w2", sdk.MustNewDecFromStr("0.25"))}
+	validMsg.ReserveTokens = []string{reserveToken, reserveToken2, reserveToken3}
+	return validMsg
+}
+
+func newValidMsgCreateStableSwapBond() types.MsgCreateBond {
+	validMsg := newValidMsgCreateBond()
+	validMsg.FunctionType = types.StableSwapFunction
+	validMsg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam("A", sdk.NewDec(100))}
+	validMsg.ReserveTokens = []string{reserveToken, reserveToken2}
+	return validMsg
+}
+
+// newValidMsgCreatePiecewiseBond returns a message creating a bond with a flat
+// price of 10 until a supply of 100, and a price equal to the supply after
+func newValidMsgCreatePiecewiseBond() types.MsgCreateBond {
+	validMsg := newValidMsgCreateBond()
+	validMsg.FunctionType = types.PiecewiseFunction
+	validMsg.FunctionParameters = nil
+	validMsg.CurveSegments = []types.CurveSegment{
+		types.NewCurveSegment(sdk.ZeroInt(), types.PolynomialFunction, types.FunctionParams{
+			types.NewFunctionParam("c0", sdk.NewDec(10))}),
+		types.NewCurveSegment(sdk.NewInt(100), types.PowerFunction, types.FunctionParams{
+			types.NewFunctionParam("m", sdk.OneDec()),
+			types.NewFunctionParam("n", sdk.OneDec()),
+			types.NewFunctionParam("c", sdk.ZeroDec())}),
+	}
+	return validMsg
+}
+
 func newValidMsgCreateAugmentedBond() types.MsgCreateBond {
 	validMsg := newValidMsgCreateBond()
 	validMsg.FunctionType = types.AugmentedFunction
@@ -129,7 +169,31 @@ func newValidMsgCreateBond() types.MsgCreateBond {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment)
+		initAllowSell, initNonTransferable, initRequireAttestation, initSigners,
+		initBatchBlocks, initOutcomePayment, nil, 0, nil, nil,
+		types.AtMaxSupplyAllowRebuys)
+}
+
+func newValidMsgEditBond() types.MsgEditBond {
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.Name = stringPtr(initName)
+	msg.Description = stringPtr(initDescription)
+	return msg
+}
+
+func newValidMsgScheduleParamChange(effectiveHeight int64) types.MsgScheduleParamChange {
+	newFunctionParams := types.FunctionParams{
+		types.NewFunctionParam("m", sdk.NewDec(10)),
+		types.NewFunctionParam("n", sdk.NewDec(2)),
+		types.NewFunctionParam("c", sdk.NewDec(50))}
+	return types.NewMsgScheduleParamChange(token, newFunctionParams,
+		effectiveHeight, false, initCreator, initSigners)
+}
+
+func newValidMsgSubmitBondProposal(fundingAmount int64) types.MsgSubmitBondProposal {
+	amount := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, fundingAmount))
+	return types.NewMsgSubmitBondProposal(token, userAddress, "title",
+		"description", types.BondProposalTypeFunding, anotherAddress, amount)
 }
 
 func newValidMsgBuy(amount int64, maxPrice int64) types.MsgBuy {
@@ -143,6 +207,12 @@ func newValidMsgSell(amount int64) types.MsgSell {
 	return types.NewMsgSell(userAddress, amountCoin)
 }
 
+func newValidMsgSellByValue(returns int64, maxAmount int64) types.MsgSellByValue {
+	returnsCoins := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, returns))
+	maxAmountCoin := sdk.NewInt64Coin(token, maxAmount)
+	return types.NewMsgSellByValue(userAddress, returnsCoins, maxAmountCoin)
+}
+
 func newValidMsgSwap(fromToken, toToken string, amount int64) types.MsgSwap {
 	fromAmount := sdk.NewInt64Coin(fromToken, amount)
 	return types.NewMsgSwap(userAddress, token, fromAmount, toToken)
@@ -161,7 +231,22 @@ func addCoinsToUser(app *simapp.BondsApp, ctx sdk.Context, coins sdk.Coins) erro
 	return err
 }
 
+func mintCoinsToCreator(app *simapp.BondsApp, ctx sdk.Context, coins sdk.Coins) error {
+	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, coins)
+	if err != nil {
+		return err
+	}
+	return app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.BondsMintBurnAccount, initCreator, coins)
+}
+
 func addCoinsToUser2(app *simapp.BondsApp, ctx sdk.Context, coins sdk.Coins) error {
 	_, err := app.BondsKeeper.BankKeeper.AddCoins(ctx, anotherAddress, coins)
 	return err
 }
+
+func stringPtr(s string) *string                  { return &s }
+func decPtr(d sdk.Dec) *sdk.Dec                   { return &d }
+func boolPtr(b bool) *bool                        { return &b }
+func coinPtr(c sdk.Coin) *sdk.Coin                { return &c }
+func coinsPtr(c sdk.Coins) *sdk.Coins             { return &c }
+func addressPtr(a sdk.AccAddress) *sdk.AccAddress { return &a }
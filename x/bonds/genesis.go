@@ -15,6 +15,101 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	for _, b := range data.Batches {
 		keeper.SetBatch(ctx, b.Token, b)
 	}
+
+	// Initialise scheduled param changes
+	for _, c := range data.ScheduledParamChanges {
+		keeper.SetScheduledParamChange(ctx, c)
+	}
+
+	// Initialise bond proposals and votes, and the next bond proposal ID
+	nextProposalID := uint64(1)
+	for _, p := range data.BondProposals {
+		keeper.SetBondProposal(ctx, p)
+		if p.ProposalID >= nextProposalID {
+			nextProposalID = p.ProposalID + 1
+		}
+	}
+	for _, v := range data.BondProposalVotes {
+		keeper.SetBondProposalVote(ctx, v)
+	}
+	keeper.SetNextBondProposalID(ctx, nextProposalID)
+
+	// Initialise vesting schedules
+	for _, s := range data.VestingSchedules {
+		keeper.SetVestingSchedule(ctx, s)
+	}
+
+	// Initialise notification registrations
+	for _, r := range data.NotificationRegistrations {
+		keeper.SetNotificationRegistration(ctx, r)
+	}
+
+	// Initialise ledgers and their entries
+	for _, l := range data.Ledgers {
+		keeper.SetLedger(ctx, l)
+	}
+	for _, e := range data.LedgerEntries {
+		keeper.SetLedgerEntry(ctx, e)
+	}
+
+	// Initialise pending bond edits
+	for _, e := range data.PendingBondEdits {
+		keeper.SetPendingBondEdit(ctx, e)
+	}
+
+	// Initialise bond histories
+	for _, h := range data.BondHistories {
+		keeper.SetBondHistory(ctx, h.BondToken, h.Snapshots)
+	}
+
+	// Initialise fees collected by each bond and in total, where the total
+	// cannot be lower than the sum of the fees collected by each bond
+	bondFeesCollected := sdk.Coins{}
+	for _, f := range data.BondFeesCollected {
+		keeper.SetBondFeesCollected(ctx, f.BondToken, f.Fees)
+		bondFeesCollected = bondFeesCollected.Add(f.Fees...)
+	}
+	totalFeesCollected := bondFeesCollected
+	if data.TotalFeesCollected.IsAllGTE(bondFeesCollected) {
+		totalFeesCollected = data.TotalFeesCollected
+	}
+	if !totalFeesCollected.IsZero() {
+		stats := keeper.GetModuleStats(ctx)
+		stats.TotalFeesCollected = totalFeesCollected
+		keeper.SetModuleStats(ctx, stats)
+	}
+
+	// Initialise spend records
+	for _, e := range data.SpendRecords {
+		keeper.SetSpendRecords(ctx, e.Address, e.Records)
+	}
+
+	// Initialise sanity rate windows
+	for _, w := range data.SanityRateWindows {
+		keeper.SetSanityRateWindow(ctx, w.BondToken, w.Window)
+	}
+
+	// Initialise order receipts and the next order ID, which cannot be lower
+	// than the order ID of any receipt (an exported next order ID of zero is
+	// treated as unset)
+	nextOrderID := data.NextOrderID
+	if nextOrderID == 0 {
+		nextOrderID = 1
+	}
+	for _, r := range data.OrderReceipts {
+		keeper.SetOrderReceipt(ctx, r)
+		if r.OrderID >= nextOrderID {
+			nextOrderID = r.OrderID + 1
+		}
+	}
+	keeper.SetNextOrderID(ctx, nextOrderID)
+
+	// Initialise params
+	keeper.SetParams(ctx, data.Params)
+
+	// Move the funds of pending orders exported from the batches intermediary
+	// account (by versions before per-bond escrow) to each bond's escrow
+	keeper.MigrateEscrowedFunds(ctx)
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
@@ -25,12 +120,28 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 	for ; iterator.Valid(); iterator.Next() {
 		bond := k.MustGetBondByKey(ctx, iterator.Key())
 		batch := k.MustGetBatch(ctx, bond.Token)
-		bonds = append(bonds, bond)
+		bonds = append(bonds, bond.WithFunctionParamsCache(nil))
 		batches = append(batches, batch)
 	}
 
 	return GenesisState{
-		Bonds:   bonds,
-		Batches: batches,
+		Bonds:                     bonds,
+		Batches:                   batches,
+		ScheduledParamChanges:     k.GetScheduledParamChanges(ctx),
+		BondProposals:             k.GetBondProposals(ctx),
+		BondProposalVotes:         k.GetAllBondProposalVotes(ctx),
+		VestingSchedules:          k.GetVestingSchedules(ctx),
+		NotificationRegistrations: k.GetAllNotificationRegistrations(ctx),
+		Ledgers:                   k.GetLedgers(ctx),
+		LedgerEntries:             k.GetAllLedgerEntries(ctx),
+		PendingBondEdits:          k.GetPendingBondEdits(ctx),
+		NextOrderID:               k.GetNextOrderID(ctx),
+		OrderReceipts:             k.GetOrderReceipts(ctx),
+		BondHistories:             k.GetBondHistories(ctx),
+		BondFeesCollected:         k.GetAllBondFeesCollected(ctx),
+		TotalFeesCollected:        k.GetModuleStats(ctx).TotalFeesCollected,
+		SpendRecords:              k.GetAllSpendRecords(ctx),
+		SanityRateWindows:         k.GetAllSanityRateWindows(ctx),
+		Params:                    k.GetParams(ctx),
 	}
 }
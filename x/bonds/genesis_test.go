@@ -14,6 +14,7 @@ func TestInitAndExportGenesis(t *testing.T) {
 	genesisState := bonds.DefaultGenesisState()
 	require.Equal(t, 0, len(genesisState.Bonds))
 	require.Equal(t, 0, len(genesisState.Batches))
+	require.Equal(t, types.DefaultParams(), genesisState.Params)
 
 	token := "testtoken"
 	name := "test token"
@@ -37,6 +38,8 @@ func TestInitAndExportGenesis(t *testing.T) {
 	sanityRate := sdk.MustNewDecFromStr("0.3")
 	sanityMarginPercentage := sdk.MustNewDecFromStr("0.4")
 	allowSell := true
+	nonTransferable := false
+	requireAttestation := false
 	signers := []sdk.AccAddress{creator}
 	batchBlocks := sdk.NewUint(10)
 	outcomePayment := sdk.NewCoins(
@@ -49,21 +52,121 @@ func TestInitAndExportGenesis(t *testing.T) {
 	bond := types.NewBond(token, name, description, creator, functionType,
 		functionParameters, reserveTokens, txFeePercentage, exitFeePercentage,
 		feeAddress, maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
-		allowSell, signers, batchBlocks, outcomePayment, state)
+		allowSell, nonTransferable, requireAttestation, signers, batchBlocks,
+		outcomePayment, nil, 0, nil, state)
 	batch := types.NewBatch(bond.Token, bond.BatchBlocks)
+	change := types.NewScheduledParamChange(token, functionParameters, 100, 1)
+	proposal := types.NewBondProposal(3, token, creator, "title", "description",
+		types.BondProposalTypeText, nil, nil, 100)
+	vote := types.NewBondProposalVote(3, creator, types.VoteOptionYes, sdk.NewInt(10))
+	registration := types.NewNotificationRegistration(token, creator,
+		[]string{types.NotificationTypeFills}, sdk.NewCoins(sdk.NewInt64Coin(reserveTokens[0], 10)), 1)
+	entry := types.NewLedgerEntry(token, 0, 1, types.LedgerEntryMint,
+		types.LedgerAccountExternal, types.LedgerAccountSupply,
+		sdk.NewCoins(sdk.NewInt64Coin(token, 10)), creator)
+	ledger := types.NewBondLedger(token).Record(entry)
+	allowSells := false
+	pendingEdit := types.NewPendingBondEdit(token, &txFeePercentage, nil, &allowSells)
+	receipt := types.NewSellOrderReceipt(6, creator, sdk.NewInt64Coin(token, 10), 1)
+	history := types.NewBondHistoryEntry(token, types.BondHistory{}.Add(
+		types.NewBondSnapshot(bond, nil, 1)))
+	bondFees := types.NewBondFeesEntry(token, sdk.NewCoins(sdk.NewInt64Coin(reserveTokens[0], 5)))
+	totalFees := sdk.NewCoins(sdk.NewInt64Coin(reserveTokens[0], 7))
+	spendRecords := types.NewSpendRecordsEntry(creator, types.SpendRecords{
+		types.NewSpendRecord(1, sdk.NewCoins(sdk.NewInt64Coin(reserveTokens[0], 8)))})
+	sanityWindow := types.NewSanityRateWindowEntry(token, types.NewSanityRateWindow(
+		2, sdk.MustNewDecFromStr("0.25"), sdk.MustNewDecFromStr("0.5")))
 
-	genesisState = bonds.NewGenesisState(
-		[]types.Bond{bond}, []types.Batch{batch})
+	genesisState = bonds.NewGenesisState([]types.Bond{bond}, []types.Batch{batch},
+		[]types.ScheduledParamChange{change}, []types.BondProposal{proposal},
+		[]types.BondProposalVote{vote}, nil,
+		[]types.NotificationRegistration{registration}, []types.BondLedger{ledger},
+		[]types.LedgerEntry{entry}, []types.PendingBondEdit{pendingEdit},
+		9, []types.OrderReceipt{receipt}, []types.BondHistoryEntry{history},
+		[]types.BondFeesEntry{bondFees}, totalFees,
+		[]types.SpendRecordsEntry{spendRecords},
+		[]types.SanityRateWindowEntry{sanityWindow},
+		types.NewParams(true, types.DefaultBondProposalQuorum,
+			types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+			types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+			types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+			types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+			types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+			types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+			types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+			types.DefaultMaxBondValueLocked,
+			types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+			types.DefaultFeeDustSweepBlocks,
+			types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+			types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+			types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+			types.DefaultStressMaxExtraBlocks,
+			types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+			types.DefaultOrderReceiptRetentionBlocks,
+			types.DefaultLedgerEntryRetentionBlocks))
 
 	bonds.InitGenesis(ctx, app.BondsKeeper, genesisState)
 
 	returnedBond := app.BondsKeeper.MustGetBond(ctx, token)
-	require.EqualValues(t, bond, returnedBond)
+	require.EqualValues(t, bond, returnedBond.WithFunctionParamsCache(nil))
 
 	returnedBatch := app.BondsKeeper.MustGetBatch(ctx, token)
 	require.Equal(t, batch, returnedBatch)
 
+	returnedChange, found := app.BondsKeeper.GetScheduledParamChange(ctx, token)
+	require.True(t, found)
+	require.Equal(t, change, returnedChange)
+
+	returnedProposal, found := app.BondsKeeper.GetBondProposal(ctx, 3)
+	require.True(t, found)
+	require.Equal(t, proposal, returnedProposal)
+	require.Equal(t, uint64(4), app.BondsKeeper.GetNextBondProposalID(ctx))
+
+	returnedRegistration, found := app.BondsKeeper.GetNotificationRegistration(ctx, token, creator)
+	require.True(t, found)
+	require.Equal(t, registration, returnedRegistration)
+
+	returnedLedger, found := app.BondsKeeper.GetLedger(ctx, token)
+	require.True(t, found)
+	require.Equal(t, ledger, returnedLedger)
+	require.Equal(t, []types.LedgerEntry{entry}, app.BondsKeeper.GetLedgerEntries(ctx, token))
+
+	returnedPendingEdit, found := app.BondsKeeper.GetPendingBondEdit(ctx, token)
+	require.True(t, found)
+	require.Equal(t, pendingEdit, returnedPendingEdit)
+
+	returnedReceipt, found := app.BondsKeeper.GetOrderReceipt(ctx, receipt.Receipt)
+	require.True(t, found)
+	require.Equal(t, receipt, returnedReceipt)
+	require.Equal(t, uint64(9), app.BondsKeeper.GetNextOrderID(ctx))
+
+	require.Equal(t, history.Snapshots, app.BondsKeeper.GetBondHistory(ctx, token))
+
+	require.Equal(t, bondFees.Fees, app.BondsKeeper.GetBondFeesCollected(ctx, token))
+	require.Equal(t, totalFees, app.BondsKeeper.GetModuleStats(ctx).TotalFeesCollected)
+
+	require.Equal(t, spendRecords.Records, app.BondsKeeper.GetSpendRecords(ctx, creator))
+
+	returnedSanityWindow, found := app.BondsKeeper.GetSanityRateWindow(ctx, token)
+	require.True(t, found)
+	require.Equal(t, sanityWindow.Window, returnedSanityWindow)
+
 	exportedGenesisState := bonds.ExportGenesis(ctx, app.BondsKeeper)
 	require.Equal(t, genesisState.Bonds, exportedGenesisState.Bonds)
 	require.Equal(t, genesisState.Batches, exportedGenesisState.Batches)
+	require.Equal(t, genesisState.ScheduledParamChanges, exportedGenesisState.ScheduledParamChanges)
+	require.Equal(t, genesisState.BondProposals, exportedGenesisState.BondProposals)
+	require.Equal(t, genesisState.BondProposalVotes, exportedGenesisState.BondProposalVotes)
+	require.Equal(t, genesisState.NotificationRegistrations, exportedGenesisState.NotificationRegistrations)
+	require.Equal(t, genesisState.Ledgers, exportedGenesisState.Ledgers)
+	require.Equal(t, genesisState.LedgerEntries, exportedGenesisState.LedgerEntries)
+	require.Equal(t, genesisState.PendingBondEdits, exportedGenesisState.PendingBondEdits)
+	require.Equal(t, genesisState.NextOrderID, exportedGenesisState.NextOrderID)
+	require.Equal(t, genesisState.OrderReceipts, exportedGenesisState.OrderReceipts)
+	require.Equal(t, genesisState.BondHistories, exportedGenesisState.BondHistories)
+	require.Equal(t, genesisState.BondFeesCollected, exportedGenesisState.BondFeesCollected)
+	require.Equal(t, genesisState.TotalFeesCollected, exportedGenesisState.TotalFeesCollected)
+	require.Equal(t, genesisState.SpendRecords, exportedGenesisState.SpendRecords)
+	require.Equal(t, genesisState.SanityRateWindows, exportedGenesisState.SanityRateWindows)
+	require.Equal(t, genesisState.Params, exportedGenesisState.Params)
 }
@@ -7,7 +7,6 @@ import (
 	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
-	"strconv"
 	"strings"
 )
 
@@ -23,20 +22,56 @@ func NewHandler(keeper keeper.Keeper) sdk.Handler {
 			return handleMsgBuy(ctx, keeper, msg)
 		case types.MsgSell:
 			return handleMsgSell(ctx, keeper, msg)
+		case types.MsgSellByValue:
+			return handleMsgSellByValue(ctx, keeper, msg)
 		case types.MsgSwap:
 			return handleMsgSwap(ctx, keeper, msg)
 		case types.MsgMakeOutcomePayment:
 			return handleMsgMakeOutcomePayment(ctx, keeper, msg)
 		case types.MsgWithdrawShare:
 			return handleMsgWithdrawShare(ctx, keeper, msg)
+		case types.MsgAuthorizedTransfer:
+			return handleMsgAuthorizedTransfer(ctx, keeper, msg)
+		case types.MsgScheduleParamChange:
+			return handleMsgScheduleParamChange(ctx, keeper, msg)
+		case types.MsgCancelParamChange:
+			return handleMsgCancelParamChange(ctx, keeper, msg)
+		case types.MsgSubmitBondProposal:
+			return handleMsgSubmitBondProposal(ctx, keeper, msg)
+		case types.MsgVoteBondProposal:
+			return handleMsgVoteBondProposal(ctx, keeper, msg)
+		case types.MsgSetBondTranslations:
+			return handleMsgSetBondTranslations(ctx, keeper, msg)
+		case types.MsgSetSanityRate:
+			return handleMsgSetSanityRate(ctx, keeper, msg)
+		case types.MsgRebalanceSwap:
+			return handleMsgRebalanceSwap(ctx, keeper, msg)
+		case types.MsgSweepFeeDust:
+			return handleMsgSweepFeeDust(ctx, keeper, msg)
+		case types.MsgRegisterNotifications:
+			return handleMsgRegisterNotifications(ctx, keeper, msg)
+		case types.MsgUnregisterNotifications:
+			return handleMsgUnregisterNotifications(ctx, keeper, msg)
 		default:
+			if res, err, ok := handleDevMsg(ctx, keeper, msg); ok {
+				return res, err
+			}
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "Unrecognized bonds Msg type: %v", msg.Type())
 		}
 	}
 }
 
+func BeginBlocker(ctx sdk.Context, keeper keeper.Keeper) {
+
+	// Make any pending batches that would otherwise still be pending at the
+	// height of a scheduled upgrade due at the end of this block
+	keeper.SettleBatchesBeforeUpgrade(ctx)
+}
+
 func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 
+	// Subtract one block from every batch and collect the batches that are due
+	var dueTokens []string
 	iterator := keeper.GetBondIterator(ctx)
 	for ; iterator.Valid(); iterator.Next() {
 		bond := keeper.MustGetBondByKey(ctx, iterator.Key())
@@ -51,44 +86,107 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 			continue
 		}
 
-		// Perform orders
-		keeper.PerformOrders(ctx, bond.Token)
-
-		// Get bond again just in case current supply was updated
-		// Get batch again just in case orders were cancelled
-		bond = keeper.MustGetBond(ctx, bond.Token)
-		batch = keeper.MustGetBatch(ctx, bond.Token)
-
-		// For augmented, if hatch phase and newSupply >= S0, go to open phase
-		if bond.FunctionType == types.AugmentedFunction &&
-			bond.State == types.HatchState {
-			args := bond.FunctionParameters.AsMap()
-			if bond.CurrentSupply.Amount.ToDec().GTE(args["S0"]) {
-				keeper.SetBondState(ctx, bond.Token, types.OpenState)
-				bond = keeper.MustGetBond(ctx, bond.Token) // get bond again
-				bond.AllowSells = true                     // enable sells
-				keeper.SetBond(ctx, bond.Token, bond)      // update bond
-			}
-		}
-
-		// Save current batch as last batch and reset current batch
-		keeper.SetLastBatch(ctx, bond.Token, batch)
-		keeper.SetBatch(ctx, bond.Token, types.NewBatch(bond.Token, bond.BatchBlocks))
+		dueTokens = append(dueTokens, bond.Token)
+	}
+	iterator.Close()
+
+	// Compute the current prices of the due bonds in parallel, given that
+	// bonds are independent, which the batches measure their price impact by
+	currentPrices := keeper.GetBondsCurrentPrices(ctx, dueTokens)
+
+	// Perform the due batches one at a time in the order of their tokens, so
+	// that all state writes remain deterministic. The orders are performed at
+	// the prices stored in the batch when they were added. A batch that fails
+	// to settle is restarted rather than halting the chain.
+	for i, token := range dueTokens {
+		_ = keeper.TrySettleBatch(ctx, token, &currentPrices[i])
 	}
+
+	// Apply any scheduled function parameter changes that are due, after the
+	// due batches have been performed using the current parameters
+	keeper.ApplyDueScheduledParamChanges(ctx)
+
+	// Tally any bond proposals whose voting period has ended
+	keeper.TallyDueBondProposals(ctx)
+
+	// Release any pre-mined bond tokens that have vested
+	keeper.ReleaseVestedTokens(ctx)
+
+	// Sweep the fee dust of the bonds' fee addresses, if periodic sweeps are
+	// enabled and one is due
+	keeper.SweepFeeDustOfFeeAddresses(ctx)
+
+	// Prune the order receipts and ledger entries that are no longer retained
+	keeper.PruneOrderReceipts(ctx)
+	keeper.PruneLedgerEntries(ctx)
+
+	// Clear the bond token reservations made by this block's bond creations
+	keeper.ClearReservations(ctx)
+
 	return []abci.ValidatorUpdate{}
 }
 
 func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCreateBond) (*sdk.Result, error) {
-	if keeper.BankKeeper.BlacklistedAddr(msg.FeeAddress) {
+	if err := keeper.CheckFeeAddress(ctx, msg.Token, msg.FeeAddress); err != nil {
+		return nil, err
+	} else if keeper.BankKeeper.BlacklistedAddr(msg.FeeAddress) {
 		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive transactions", msg.FeeAddress)
 	}
 
-	if keeper.BondExists(ctx, msg.Token) {
+	// The first creation of the token in block order wins
+	if _, found := keeper.GetReservation(ctx, msg.Token); found {
+		return nil, sdkerrors.Wrap(types.ErrBondTokenReserved, msg.Token)
+	} else if keeper.BondExists(ctx, msg.Token) {
 		return nil, sdkerrors.Wrap(types.ErrBondAlreadyExists, msg.Token)
 	} else if msg.Token == keeper.StakingKeeper.GetParams(ctx).BondDenom {
 		return nil, sdkerrors.Wrap(types.ErrBondTokenCannotBeStakingToken, msg.Token)
 	}
 
+	// Check name and description lengths against the (possibly lower)
+	// limits set in the module parameters
+	if err := types.CheckNameLength(msg.Name, keeper.MaxNameLength(ctx)); err != nil {
+		return nil, err
+	} else if err := types.CheckDescriptionLength(msg.Description, keeper.MaxDescriptionLength(ctx)); err != nil {
+		return nil, err
+	}
+
+	// Check batch blocks against the bounds set in the module parameters
+	if err := types.CheckBatchBlocks(msg.BatchBlocks, keeper.MinBatchBlocks(ctx), keeper.MaxBatchBlocks(ctx)); err != nil {
+		return nil, err
+	}
+
+	// Check that the raise deadline (if any) has not already passed
+	if !msg.SoftCap.Empty() && msg.RaiseDeadline <= ctx.BlockHeight() {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidRaiseDeadline,
+			"raise deadline %d must be after the current height %d",
+			msg.RaiseDeadline, ctx.BlockHeight())
+	}
+
+	// Check pre-mine against the (possibly lower) max pre-mine percentage set
+	// in the module parameters. Only bonds whose prices follow the curve from
+	// the start can be pre-mined, since augmented bonds start with a hatch
+	// phase and swapper bonds are initialised by the first buy.
+	if !msg.PreMine.Empty() {
+		if msg.FunctionType != types.PowerFunction &&
+			msg.FunctionType != types.SigmoidFunction &&
+			msg.FunctionType != types.ExponentialFunction &&
+			msg.FunctionType != types.LogarithmicFunction &&
+			msg.FunctionType != types.PolynomialFunction &&
+			msg.FunctionType != types.BancorFunction &&
+			msg.FunctionType != types.PiecewiseFunction {
+			return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, msg.FunctionType)
+		} else if err := types.CheckPreMine(msg.PreMine, msg.MaxSupply,
+			keeper.MaxPreMinePercentage(ctx)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Charge bond creation fee (burned or sent to the community pool)
+	creationFee, err := keeper.ChargeBondCreationFee(ctx, msg.Creator)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set state to open by default (overridden below if augmented function)
 	state := types.OpenState
 
@@ -123,38 +221,70 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 		msg.FunctionType, msg.FunctionParameters, msg.ReserveTokens,
 		msg.TxFeePercentage, msg.ExitFeePercentage, msg.FeeAddress,
 		msg.MaxSupply, msg.OrderQuantityLimits, msg.SanityRate,
-		msg.SanityMarginPercentage, msg.AllowSells, msg.Signers,
-		msg.BatchBlocks, msg.OutcomePayment, state)
+		msg.SanityMarginPercentage, msg.AllowSells, msg.NonTransferable,
+		msg.RequireAttestation, msg.Signers, msg.BatchBlocks,
+		msg.OutcomePayment, msg.Milestones, msg.ProposalVotingBlocks,
+		msg.EventAttributes, state)
+	if msg.AtMaxSupplyBehavior != "" {
+		bond.AtMaxSupplyBehavior = msg.AtMaxSupplyBehavior
+	}
+	if msg.SignerThreshold != nil {
+		bond.SignerThreshold = *msg.SignerThreshold
+	}
+	bond.CurveSegments = msg.CurveSegments
+	bond.SoftCap = msg.SoftCap
+	bond.RaiseDeadline = msg.RaiseDeadline
+
+	// Reserve the token until the end of the block. Like the rest of the
+	// creation, the reservation is reverted if the creation then fails.
+	keeper.ReserveBondToken(ctx, msg.Token, msg.Creator)
 
 	keeper.SetBond(ctx, msg.Token, bond)
 	keeper.SetBatch(ctx, msg.Token, types.NewBatch(bond.Token, msg.BatchBlocks))
+	keeper.OpenLedger(ctx, msg.Token)
+
+	// Mint pre-mine (if any) and lock it under a vesting schedule
+	if !msg.PreMine.Empty() {
+		err = keeper.PreMine(ctx, msg.Token, msg.Creator, msg.PreMine[0])
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	logger := keeper.Logger(ctx)
 	logger.Info(fmt.Sprintf("bond %s [%s] with reserve(s) [%s] created by %s", msg.Token,
 		msg.FunctionType, strings.Join(bond.ReserveTokens, ","), msg.Creator.String()))
 
 	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeCreateBond,
-			sdk.NewAttribute(types.AttributeKeyBond, msg.Token),
-			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
-			sdk.NewAttribute(types.AttributeKeyDescription, msg.Description),
-			sdk.NewAttribute(types.AttributeKeyFunctionType, msg.FunctionType),
-			sdk.NewAttribute(types.AttributeKeyFunctionParameters, msg.FunctionParameters.String()),
-			sdk.NewAttribute(types.AttributeKeyReserveTokens, types.StringsToString(msg.ReserveTokens)),
-			sdk.NewAttribute(types.AttributeKeyTxFeePercentage, msg.TxFeePercentage.String()),
-			sdk.NewAttribute(types.AttributeKeyExitFeePercentage, msg.ExitFeePercentage.String()),
-			sdk.NewAttribute(types.AttributeKeyFeeAddress, msg.FeeAddress.String()),
-			sdk.NewAttribute(types.AttributeKeyMaxSupply, msg.MaxSupply.String()),
-			sdk.NewAttribute(types.AttributeKeyOrderQuantityLimits, msg.OrderQuantityLimits.String()),
-			sdk.NewAttribute(types.AttributeKeySanityRate, msg.SanityRate.String()),
-			sdk.NewAttribute(types.AttributeKeySanityMarginPercentage, msg.SanityMarginPercentage.String()),
-			sdk.NewAttribute(types.AttributeKeyAllowSells, strconv.FormatBool(msg.AllowSells)),
-			sdk.NewAttribute(types.AttributeKeySigners, types.AccAddressesToString(msg.Signers)),
-			sdk.NewAttribute(types.AttributeKeyBatchBlocks, msg.BatchBlocks.String()),
-			sdk.NewAttribute(types.AttributeKeyOutcomePayment, msg.OutcomePayment.String()),
-			sdk.NewAttribute(types.AttributeKeyState, state),
-		),
+		types.NewEvent(types.CreateBondEvent{
+			Bond:                   msg.Token,
+			Name:                   msg.Name,
+			Description:            msg.Description,
+			FunctionType:           msg.FunctionType,
+			FunctionParameters:     msg.FunctionParameters,
+			ReserveTokens:          msg.ReserveTokens,
+			TxFeePercentage:        msg.TxFeePercentage,
+			ExitFeePercentage:      msg.ExitFeePercentage,
+			FeeAddress:             msg.FeeAddress,
+			MaxSupply:              msg.MaxSupply,
+			OrderQuantityLimits:    msg.OrderQuantityLimits,
+			SanityRate:             msg.SanityRate,
+			SanityMarginPercentage: msg.SanityMarginPercentage,
+			AllowSells:             msg.AllowSells,
+			NonTransferable:        msg.NonTransferable,
+			RequireAttestation:     msg.RequireAttestation,
+			Signers:                msg.Signers,
+			BatchBlocks:            msg.BatchBlocks,
+			OutcomePayment:         msg.OutcomePayment,
+			ProposalVotingBlocks:   msg.ProposalVotingBlocks,
+			CreationFee:            creationFee,
+			State:                  state,
+			CurveVersion:           bond.CurveVersion,
+			PreMine:                msg.PreMine,
+			AtMaxSupplyBehavior:    bond.AtMaxSupplyBehavior,
+			SoftCap:                msg.SoftCap,
+			RaiseDeadline:          msg.RaiseDeadline,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
@@ -172,66 +302,150 @@ func handleMsgEditBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgEditB
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.Token)
 	}
 
-	if !bond.SignersEqualTo(msg.Signers) {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not match the one in the bond")
+	if !bond.SignersMeetThreshold(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not meet the bond's signer threshold")
 	}
 
-	if msg.Name != types.DoNotModifyField {
-		bond.Name = msg.Name
-	}
-	if msg.Description != types.DoNotModifyField {
-		bond.Description = msg.Description
+	// Settled and failed bonds only allow their holders to withdraw their
+	// share of the reserve, so there is nothing left to edit
+	if types.IsFinalBondState(bond.State) {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidStateForAction,
+			"cannot edit a bond in state %s", bond.State)
 	}
 
-	if msg.OrderQuantityLimits != types.DoNotModifyField {
-		orderQuantityLimits, err := sdk.ParseCoins(msg.OrderQuantityLimits)
-		if err != nil {
-			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	// Name and description lengths are checked against the (possibly lower)
+	// limits set in the module parameters
+	if msg.Name != nil {
+		if err := types.CheckNameLength(*msg.Name, keeper.MaxNameLength(ctx)); err != nil {
+			return nil, err
+		}
+		bond.Name = *msg.Name
+	}
+	if msg.Description != nil {
+		if err := types.CheckDescriptionLength(*msg.Description, keeper.MaxDescriptionLength(ctx)); err != nil {
+			return nil, err
 		}
-		bond.OrderQuantityLimits = orderQuantityLimits
+		bond.Description = *msg.Description
 	}
 
-	if msg.SanityRate != types.DoNotModifyField {
-		var sanityRate, sanityMarginPercentage sdk.Dec
-		if msg.SanityRate == "" {
-			sanityRate = sdk.ZeroDec()
-			sanityMarginPercentage = sdk.ZeroDec()
-		} else {
-			parsedSanityRate, err := sdk.NewDecFromStr(msg.SanityRate)
-			if err != nil {
-				return nil, sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "sanity rate")
-			} else if parsedSanityRate.IsNegative() {
-				return nil, sdkerrors.Wrap(types.ErrArgumentCannotBeNegative, "sanity rate")
-			}
-			parsedSanityMarginPercentage, err := sdk.NewDecFromStr(msg.SanityMarginPercentage)
+	if msg.OrderQuantityLimits != nil {
+		bond.OrderQuantityLimits = *msg.OrderQuantityLimits
+	}
+
+	if msg.SanityRate != nil || msg.SanityMarginPercentage != nil {
+		sanityRate := bond.SanityRate
+		if msg.SanityRate != nil {
+			sanityRate = *msg.SanityRate
+		}
+		sanityMarginPercentage := bond.SanityMarginPercentage
+		if msg.SanityMarginPercentage != nil {
+			sanityMarginPercentage = *msg.SanityMarginPercentage
+		}
+
+		// Sanity values of swapper bonds can only change within the limits
+		// that also apply to MsgSetSanityRate
+		if bond.FunctionType == types.SwapperFunction {
+			err := keeper.CheckSanityRateChange(ctx, bond, sanityRate, sanityMarginPercentage)
 			if err != nil {
-				return nil, sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "sanity margin percentage")
-			} else if parsedSanityMarginPercentage.IsNegative() {
-				return nil, sdkerrors.Wrap(types.ErrArgumentCannotBeNegative, "sanity margin percentage")
+				return nil, err
 			}
-			sanityRate = parsedSanityRate
-			sanityMarginPercentage = parsedSanityMarginPercentage
+		} else if (bond.FunctionType == types.WeightedSwapperFunction ||
+			bond.FunctionType == types.StableSwapFunction) && !sanityRate.IsZero() {
+			return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, "SanityRate")
 		}
 		bond.SanityRate = sanityRate
 		bond.SanityMarginPercentage = sanityMarginPercentage
 	}
 
+	if msg.NetSellCap != nil && msg.NetSellCap.Denom != bond.Token {
+		return nil, sdkerrors.Wrap(types.ErrNetSellCapDenomDoesNotMatchToken, msg.NetSellCap.Denom)
+	}
+
+	if msg.QuoteDenom != nil {
+		bond.QuoteDenom = *msg.QuoteDenom
+	}
+
+	if msg.MinReserve != nil {
+		for _, c := range *msg.MinReserve {
+			if !bond.IsReserveToken(c.Denom) {
+				return nil, sdkerrors.Wrap(types.ErrTokenIsNotAValidReserveToken, c.Denom)
+			}
+		}
+	}
+
+	// Swapper bonds do not have a reserve implied by their supply
+	if msg.MinReservePercentage != nil && !msg.MinReservePercentage.IsZero() &&
+		types.IsSwapperFunctionType(bond.FunctionType) {
+		return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	}
+
+	if msg.FeeAddress != nil {
+		if err := keeper.CheckFeeAddress(ctx, msg.Token, *msg.FeeAddress); err != nil {
+			return nil, err
+		} else if keeper.BankKeeper.BlacklistedAddr(*msg.FeeAddress) {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive transactions", *msg.FeeAddress)
+		}
+	}
+
+	if msg.SignerThreshold != nil {
+		// The signers themselves cannot be edited, so the threshold is
+		// validated against the bond's existing signers
+		if err := msg.SignerThreshold.Validate(len(bond.Signers)); err != nil {
+			return nil, err
+		}
+		bond.SignerThreshold = *msg.SignerThreshold
+	}
+
+	// The fee address, net sell caps, demurrage rate, and min reserve are only
+	// edited once the bond's current batch is settled, so that the orders in
+	// it are performed on the terms that they were submitted under
+	pendingEdit := types.NewPendingBondEditFromMsg(msg)
+	if !pendingEdit.IsEmpty() {
+		if err := keeper.AddPendingBondEdit(ctx, pendingEdit); err != nil {
+			return nil, err
+		}
+	}
+
 	logger := keeper.Logger(ctx)
 	logger.Info(fmt.Sprintf("bond %s edited by %s",
 		msg.Token, msg.Editor.String()))
 
 	keeper.SetBond(ctx, msg.Token, bond)
+	keeper.InvalidateFunctionParamsCache(msg.Token)
+
+	// Only the edited fields are included in the event
+	edited := func(value fmt.Stringer, set bool) string {
+		if !set {
+			return ""
+		}
+		return value.String()
+	}
+	event := types.EditBondEvent{
+		Bond:                   msg.Token,
+		EditedFields:           msg.EditedFields(),
+		OrderQuantityLimits:    edited(msg.OrderQuantityLimits, msg.OrderQuantityLimits != nil),
+		SanityRate:             edited(msg.SanityRate, msg.SanityRate != nil),
+		SanityMarginPercentage: edited(msg.SanityMarginPercentage, msg.SanityMarginPercentage != nil),
+		NetSellCap:             edited(msg.NetSellCap, msg.NetSellCap != nil),
+		NetSellCapPercentage:   edited(msg.NetSellCapPercentage, msg.NetSellCapPercentage != nil),
+		DemurrageRate:          edited(msg.DemurrageRate, msg.DemurrageRate != nil),
+		MinReserve:             edited(msg.MinReserve, msg.MinReserve != nil),
+		MinReservePercentage:   edited(msg.MinReservePercentage, msg.MinReservePercentage != nil),
+		FeeAddress:             edited(msg.FeeAddress, msg.FeeAddress != nil),
+		SignerThreshold:        edited(msg.SignerThreshold, msg.SignerThreshold != nil),
+	}
+	if msg.Name != nil {
+		event.Name = *msg.Name
+	}
+	if msg.Description != nil {
+		event.Description = *msg.Description
+	}
+	if msg.QuoteDenom != nil {
+		event.QuoteDenom = *msg.QuoteDenom
+	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeEditBond,
-			sdk.NewAttribute(types.AttributeKeyBond, msg.Token),
-			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
-			sdk.NewAttribute(types.AttributeKeyDescription, msg.Description),
-			sdk.NewAttribute(types.AttributeKeyOrderQuantityLimits, msg.OrderQuantityLimits),
-			sdk.NewAttribute(types.AttributeKeySanityRate, msg.SanityRate),
-			sdk.NewAttribute(types.AttributeKeySanityMarginPercentage, msg.SanityMarginPercentage),
-		),
+		types.NewEvent(event).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
@@ -243,124 +457,345 @@ func handleMsgEditBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgEditB
 }
 
 func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk.Result, error) {
+	receipt, err := keeper.SubmitBuy(ctx, msg, "")
+	if err != nil {
+		return nil, err
+	}
 
-	token := msg.Amount.Denom
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Buyer.String()),
+	))
+
+	return &sdk.Result{
+		Data:   []byte(receipt.Receipt),
+		Events: ctx.EventManager().Events(),
+	}, nil
+}
+
+func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*sdk.Result, error) {
+	receipt, err := keeper.SubmitSell(ctx, msg, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Seller.String()),
+	))
+
+	return &sdk.Result{
+		Data:   []byte(receipt.Receipt),
+		Events: ctx.EventManager().Events(),
+	}, nil
+}
+
+func handleMsgSellByValue(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSellByValue) (*sdk.Result, error) {
+
+	// Check that order submission has not been halted module-wide
+	if keeper.OrderSubmissionHalted(ctx) {
+		return nil, types.ErrOrderSubmissionHalted
+	}
+
+	token := msg.MaxAmount.Denom
 	bond, found := keeper.GetBond(ctx, token)
 	if !found {
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
 	}
 
-	// Check current state is HATCH/OPEN, max prices, order quantity limits
-	if bond.State != types.OpenState && bond.State != types.HatchState {
-		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
-	} else if !bond.ReserveDenomsEqualTo(msg.MaxPrices) {
-		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.MaxPrices.String(), strings.Join(bond.ReserveTokens, ","))
-	} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
-		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+	// Check sells allowed, current state is OPEN, and returns denoms valid
+	if !bond.AllowSells {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, token)
+	} else if err := bond.CheckStateAllows(types.ActionSell); err != nil {
+		return nil, err
+	} else if !bond.ReserveDenomsEqualTo(msg.Returns) {
+		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.Returns.String(), strings.Join(bond.ReserveTokens, ","))
 	}
 
-	// For the swapper, the first buy is the initialisation of the reserves
-	// The max prices are used as the actual prices and one token is minted
-	// The amount of token serves to define the price of adding more liquidity
-	if bond.CurrentSupply.IsZero() && bond.FunctionType == types.SwapperFunction {
-		return performFirstSwapperFunctionBuy(ctx, keeper, msg)
+	// Calculate amount of bond tokens that need to be sold for the returns
+	amount, err := keeper.GetSellAmountForReturns(ctx, msg.Seller, msg.Returns, msg.MaxAmount)
+	if err != nil {
+		return nil, err
 	}
 
-	// Take max that buyer is willing to pay (enforces maxPrice <= balance)
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Buyer,
-		types.BatchesIntermediaryAccount, msg.MaxPrices)
+	// Sell the calculated amount as a normal sell order
+	sellMsg := types.NewMsgSell(msg.Seller, amount)
+	sellMsg.Memo = msg.Memo
+	return handleMsgSell(ctx, keeper, sellMsg)
+}
+
+func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) (*sdk.Result, error) {
+	receipt, err := keeper.SubmitSwap(ctx, msg, "")
 	if err != nil {
 		return nil, err
 	}
 
-	// Create order
-	order := types.NewBuyOrder(msg.Buyer, msg.Amount, msg.MaxPrices)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Swapper.String()),
+	))
+
+	return &sdk.Result{
+		Data:   []byte(receipt.Receipt),
+		Events: ctx.EventManager().Events(),
+	}, nil
+}
+
+func handleMsgRebalanceSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgRebalanceSwap) (*sdk.Result, error) {
+
+	// Check that order submission has not been halted module-wide
+	if keeper.OrderSubmissionHalted(ctx) {
+		return nil, types.ErrOrderSubmissionHalted
+	}
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	if !bond.SignersMeetThreshold(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not meet the bond's signer threshold")
+	}
+
+	// Confirm that function type is swapper_function and state is OPEN
+	if bond.FunctionType != types.SwapperFunction {
+		return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	} else if err := bond.CheckStateAllows(types.ActionSwap); err != nil {
+		return nil, err
+	}
+
+	// Check that from and to use reserve token names
+	fromAndTo := sdk.NewCoins(msg.From, sdk.NewCoin(msg.ToToken, sdk.OneInt()))
+	fromAndToDenoms := msg.From.Denom + "," + msg.ToToken
+	if !bond.ReserveDenomsEqualTo(fromAndTo) {
+		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", fromAndToDenoms, bond.ReserveTokens)
+	}
+
+	// Check if order quantity limit exceeded
+	if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.From}) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.From.String())
+	}
+
+	// Check that the oracle confirms that the market rate has moved outside
+	// of the sanity band
+	oracleRate, err := keeper.GetRebalanceOracleRate(ctx, bond)
+	if err != nil {
+		return nil, err
+	}
 
-	// Get buy price and check if can add buy order to batch
-	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterBuy(ctx, token, order)
+	// Take coins to be swapped from swapper (enforces swapAmount <= balance)
+	err = keeper.EscrowOrderFunds(ctx, msg.BondToken, msg.Swapper, sdk.Coins{msg.From})
 	if err != nil {
 		return nil, err
 	}
 
-	// Add buy order to batch
-	keeper.AddBuyOrder(ctx, token, order, buyPrices, sellPrices)
+	// Create order and add it to batch
+	order := types.NewRebalanceSwapOrder(msg.Swapper, msg.From, msg.ToToken, oracleRate)
+	keeper.AddSwapOrder(ctx, msg.BondToken, order)
 
-	// Cancel unfulfillable orders
-	keeper.CancelUnfulfillableOrders(ctx, token)
+	// Issue order receipt
+	receipt := keeper.IssueSwapOrderReceipt(ctx, msg.BondToken, msg.Swapper, msg.From, msg.ToToken)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("rebalance swap of %s to %s in %s by %s using oracle rate %s (sanity rate %s)",
+		msg.From, msg.ToToken, msg.BondToken, msg.Swapper.String(), oracleRate, bond.SanityRate))
 
 	ctx.EventManager().EmitEvents(sdk.Events{
+		types.NewEvent(types.RebalanceSwapEvent{
+			Bond:          msg.BondToken,
+			Amount:        msg.From.Amount,
+			SwapFromToken: msg.From.Denom,
+			SwapToToken:   msg.ToToken,
+			SanityRate:    bond.SanityRate,
+			OracleRate:    oracleRate,
+			OrderID:       receipt.OrderID,
+			OrderReceipt:  receipt.Receipt,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
 		sdk.NewEvent(
-			types.EventTypeBuy,
-			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
-			sdk.NewAttribute(types.AttributeKeyMaxPrices, msg.MaxPrices.String()),
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Swapper.String()),
 		),
+	})
+
+	return &sdk.Result{
+		Data:   []byte(receipt.Receipt),
+		Events: ctx.EventManager().Events(),
+	}, nil
+}
+
+func handleMsgSweepFeeDust(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSweepFeeDust) (*sdk.Result, error) {
+	_, _, err := keeper.SweepFeeDust(ctx, msg.Owner, msg.TargetDenom, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+	))
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRegisterNotifications(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgRegisterNotifications) (*sdk.Result, error) {
+	registration, err := keeper.RegisterNotifications(
+		ctx, msg.BondToken, msg.Relayer, msg.NotificationTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		types.NewEvent(types.RegisterNotificationsEvent{
+			Bond:              msg.BondToken,
+			Relayer:           msg.Relayer,
+			NotificationTypes: registration.NotificationTypes,
+			Deposit:           registration.Deposit,
+		}),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Buyer.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Relayer.String()),
 		),
 	})
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
-func performFirstSwapperFunctionBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk.Result, error) {
+func handleMsgUnregisterNotifications(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgUnregisterNotifications) (*sdk.Result, error) {
+	registration, err := keeper.UnregisterNotifications(ctx, msg.BondToken, msg.Relayer)
+	if err != nil {
+		return nil, err
+	}
 
-	// TODO: investigate effect that a high amount has on future buyers' ability to buy.
+	ctx.EventManager().EmitEvents(sdk.Events{
+		types.NewEvent(types.UnregisterNotificationsEvent{
+			Bond:     msg.BondToken,
+			Relayer:  msg.Relayer,
+			Refunded: registration.Deposit,
+		}),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Relayer.String()),
+		),
+	})
 
-	token := msg.Amount.Denom
-	bond, found := keeper.GetBond(ctx, token)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgMakeOutcomePayment(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgMakeOutcomePayment) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
 	if !found {
-		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Confirm that state is OPEN and that outcome payment is not nil
+	if err := bond.CheckStateAllows(types.ActionOutcomePayment); err != nil {
+		return nil, err
+	} else if bond.OutcomePayment.Empty() {
+		return nil, types.ErrCannotMakeZeroOutcomePayment
+	}
+
+	// Send outcome payment to reserve
+	err := keeper.DepositReserve(ctx, bond.Token, msg.Sender, bond.OutcomePayment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set bond state to SETTLE
+	err = keeper.SetBondState(ctx, bond.Token, types.SettleState)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		types.NewEvent(types.MakeOutcomePaymentEvent{
+			Bond:    msg.BondToken,
+			Address: msg.Sender,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgWithdrawShare(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgWithdrawShare) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Check that state is SETTLE or FAILED
+	if err := bond.CheckStateAllows(types.ActionWithdrawShare); err != nil {
+		return nil, err
 	}
 
-	// Check if initial liquidity violates sanity rate
-	if bond.ReservesViolateSanityRate(msg.MaxPrices) {
-		return nil, sdkerrors.Wrap(types.ErrValuesViolateSanityRate, msg.MaxPrices.String())
+	// Get number of bond tokens owned by the recipient
+	bondTokensOwnedAmount := keeper.BankKeeper.GetCoins(ctx, msg.Recipient).AmountOf(msg.BondToken)
+	if bondTokensOwnedAmount.IsZero() {
+		return nil, sdkerrors.Wrap(types.ErrNoBondTokensOwned, bondTokensOwnedAmount.String())
 	}
+	bondTokensOwned := sdk.NewCoin(msg.BondToken, bondTokensOwnedAmount)
 
-	// Use max prices as the amount to send to the liquidity pool (i.e. price)
-	err := keeper.DepositReserve(ctx, bond.Token, msg.Buyer, msg.MaxPrices)
+	// Send coins to be burned from recipient
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(
+		ctx, msg.Recipient, types.BondsMintBurnAccount, sdk.NewCoins(bondTokensOwned))
 	if err != nil {
 		return nil, err
 	}
 
-	// Mint bond tokens
-	err = keeper.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount,
-		sdk.Coins{msg.Amount})
+	// Burn bond tokens
+	err = keeper.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount,
+		sdk.NewCoins(sdk.NewCoin(msg.BondToken, bondTokensOwnedAmount)))
 	if err != nil {
 		return nil, err
 	}
+	keeper.RecordLedgerEntry(ctx, bond.Token, types.LedgerEntryBurn,
+		types.LedgerAccountSupply, types.LedgerAccountExternal,
+		sdk.NewCoins(bondTokensOwned), msg.Recipient)
 
-	// Send bond tokens to buyer
-	err = keeper.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-		types.BondsMintBurnAccount, msg.Buyer, sdk.Coins{msg.Amount})
+	// Calculate amount owned
+	remainingReserve := keeper.GetReserveBalances(ctx, bond.Token)
+	reserveOwed := types.ProRataCoins(remainingReserve,
+		bondTokensOwnedAmount, bond.CurrentSupply.Amount)
+
+	// Send coins owed to recipient
+	err = keeper.WithdrawReserve(ctx, bond.Token, msg.Recipient, reserveOwed)
 	if err != nil {
 		return nil, err
 	}
 
 	// Update supply
-	keeper.SetCurrentSupply(ctx, bond.Token, bond.CurrentSupply.Add(msg.Amount))
+	keeper.SetCurrentSupply(ctx, bond.Token, bond.CurrentSupply.Sub(bondTokensOwned))
 
 	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeInitSwapper,
-			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
-			sdk.NewAttribute(types.AttributeKeyChargedPrices, msg.MaxPrices.String()),
-		),
+		types.NewEvent(types.WithdrawShareEvent{
+			Bond:    msg.BondToken,
+			Address: msg.Recipient,
+			Amount:  reserveOwed,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Buyer.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Recipient.String()),
 		),
 	})
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
-func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*sdk.Result, error) {
+func handleMsgAuthorizedTransfer(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgAuthorizedTransfer) (*sdk.Result, error) {
 
 	token := msg.Amount.Denom
 	bond, found := keeper.GetBond(ctx, token)
@@ -368,218 +803,314 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*s
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
 	}
 
-	// Check sells allowed, current state is OPEN, and order limits not exceeded
-	if !bond.AllowSells {
-		return nil, sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, token)
-	} else if bond.State != types.OpenState {
-		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
-	} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
-		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+	// Authorized transfers are only required (and allowed) for bond tokens
+	// that cannot otherwise be transferred by their holders
+	if !bond.NonTransferable {
+		return nil, sdkerrors.Wrap(types.ErrBondTokenIsTransferable, token)
 	}
 
-	// Send coins to be burned from seller (enforces sellAmount <= balance)
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Seller,
-		types.BondsMintBurnAccount, sdk.Coins{msg.Amount})
-	if err != nil {
-		return nil, err
+	if !bond.SignersMeetThreshold(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not meet the bond's signer threshold")
 	}
 
-	// Burn bond tokens to be sold
-	err = keeper.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount,
-		sdk.Coins{msg.Amount})
+	if keeper.BankKeeper.BlacklistedAddr(msg.To) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive transactions", msg.To)
+	}
+
+	// Move bond tokens (enforces amount <= balance)
+	err := keeper.BankKeeper.SendCoins(ctx, msg.From, msg.To, sdk.Coins{msg.Amount})
 	if err != nil {
 		return nil, err
 	}
 
-	// Create order
-	order := types.NewSellOrder(msg.Seller, msg.Amount)
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("authorized transfer of %s from %s to %s", msg.Amount.String(),
+		msg.From.String(), msg.To.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		types.NewEvent(types.AuthorizedTransferEvent{
+			Bond:        token,
+			FromAddress: msg.From,
+			ToAddress:   msg.To,
+			Amount:      msg.Amount.Amount,
+			Reason:      msg.Reason,
+			Signers:     msg.Signers,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Signers[0].String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgScheduleParamChange(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgScheduleParamChange) (*sdk.Result, error) {
 
-	// Get sell price and check if can add sell order to batch
-	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterSell(ctx, token, order)
-	if err != nil {
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	if !bond.SignersMeetThreshold(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not meet the bond's signer threshold")
+	}
+
+	// Only one change can be scheduled at a time, so that traders never have
+	// to reason about multiple pending curves
+	if _, found := keeper.GetScheduledParamChange(ctx, msg.BondToken); found {
+		return nil, sdkerrors.Wrap(types.ErrParamChangeAlreadyScheduled, msg.BondToken)
+	}
+
+	change := types.NewScheduledParamChange(msg.BondToken,
+		msg.FunctionParameters, msg.EffectiveHeight, ctx.BlockHeight())
+	if msg.Interpolate {
+		change = types.NewInterpolatedParamChange(msg.BondToken, bond.FunctionParameters,
+			msg.FunctionParameters, msg.EffectiveHeight, ctx.BlockHeight())
+	}
+	if err := types.ValidateScheduledParamChange(bond, change, ctx.BlockHeight()); err != nil {
 		return nil, err
 	}
 
-	// Add sell order to batch
-	keeper.AddSellOrder(ctx, token, order, buyPrices, sellPrices)
+	keeper.SetScheduledParamChange(ctx, change)
 
-	//// Cancel unfulfillable orders (Note: no need)
-	//keeper.CancelUnfulfillableOrders(ctx, token)
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("function parameters change for %s scheduled at height %d by %s",
+		msg.BondToken, msg.EffectiveHeight, msg.Editor.String()))
 
 	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeSell,
-			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
-		),
+		types.NewEvent(types.ScheduleParamChangeEvent{
+			Bond:               msg.BondToken,
+			FunctionParameters: msg.FunctionParameters,
+			EffectiveHeight:    msg.EffectiveHeight,
+			Interpolate:        msg.Interpolate,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Seller.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
 		),
 	})
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
-func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) (*sdk.Result, error) {
+func handleMsgCancelParamChange(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelParamChange) (*sdk.Result, error) {
 
 	bond, found := keeper.GetBond(ctx, msg.BondToken)
 	if !found {
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
 	}
 
-	// Confirm that function type is swapper_function and state is OPEN
-	if bond.FunctionType != types.SwapperFunction {
-		return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
-	} else if bond.State != types.OpenState {
-		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	if !bond.SignersMeetThreshold(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not meet the bond's signer threshold")
 	}
 
-	// Check that from and to use reserve token names
-	fromAndTo := sdk.NewCoins(msg.From, sdk.NewCoin(msg.ToToken, sdk.OneInt()))
-	fromAndToDenoms := msg.From.Denom + "," + msg.ToToken
-	if !bond.ReserveDenomsEqualTo(fromAndTo) {
-		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", fromAndToDenoms, bond.ReserveTokens)
+	// Changes are applied (and deleted) at the end of the block at their
+	// effective height, so any change that is found has not yet been applied
+	change, found := keeper.GetScheduledParamChange(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrNoParamChangeScheduled, msg.BondToken)
 	}
 
-	// Check if order quantity limit exceeded
-	if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.From}) {
-		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.From.String())
+	keeper.DeleteScheduledParamChange(ctx, msg.BondToken)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("function parameters change for %s scheduled at height %d cancelled by %s",
+		msg.BondToken, change.EffectiveHeight, msg.Editor.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		types.NewEvent(types.CancelParamChangeEvent{
+			Bond:               msg.BondToken,
+			FunctionParameters: change.FunctionParameters,
+			EffectiveHeight:    change.EffectiveHeight,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSubmitBondProposal(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSubmitBondProposal) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
 	}
 
-	// Take coins to be swapped from swapper (enforces swapAmount <= balance)
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Swapper,
-		types.BatchesIntermediaryAccount, sdk.Coins{msg.From})
-	if err != nil {
+	if err := types.CheckBondAllowsBondProposal(bond, msg.FundingAmount); err != nil {
 		return nil, err
 	}
 
-	// Create order
-	order := types.NewSwapOrder(msg.Swapper, msg.From, msg.ToToken)
+	// Only bond token holders can submit proposals
+	if !keeper.BankKeeper.GetCoins(ctx, msg.Proposer).AmountOf(bond.Token).IsPositive() {
+		return nil, types.ErrNoBondTokensOwned
+	}
 
-	// Add swap order to batch
-	keeper.AddSwapOrder(ctx, msg.BondToken, order)
+	proposal := keeper.SubmitBondProposal(ctx, bond, msg.Proposer, msg.Title,
+		msg.Description, msg.ProposalType, msg.FundingRecipient, msg.FundingAmount)
 
-	//// Cancel unfulfillable orders (Note: no need)
-	//keeper.CancelUnfulfillableOrders(ctx, token)
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond proposal %d for %s submitted by %s",
+		proposal.ProposalID, msg.BondToken, msg.Proposer.String()))
 
 	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeSwap,
-			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.From.Amount.String()),
-			sdk.NewAttribute(types.AttributeKeySwapFromToken, msg.From.Denom),
-			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.ToToken),
-		),
+		types.NewEvent(types.SubmitBondProposalEvent{
+			Bond:             msg.BondToken,
+			ProposalID:       proposal.ProposalID,
+			ProposalType:     msg.ProposalType,
+			FundingRecipient: msg.FundingRecipient,
+			FundingAmount:    msg.FundingAmount,
+			VotingEndHeight:  proposal.VotingEndHeight,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Swapper.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Proposer.String()),
 		),
 	})
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
-func handleMsgMakeOutcomePayment(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgMakeOutcomePayment) (*sdk.Result, error) {
+func handleMsgVoteBondProposal(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgVoteBondProposal) (*sdk.Result, error) {
 
-	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	proposal, found := keeper.GetBondProposal(ctx, msg.ProposalID)
 	if !found {
-		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+		return nil, sdkerrors.Wrapf(types.ErrBondProposalDoesNotExist, "%d", msg.ProposalID)
 	}
 
-	// Confirm that state is OPEN and that outcome payment is not nil
-	if bond.State != types.OpenState {
-		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
-	} else if bond.OutcomePayment.Empty() {
-		return nil, types.ErrCannotMakeZeroOutcomePayment
+	// Proposals are tallied at the end of the block at which voting ends, so
+	// a proposal can still be voted on at its voting end height
+	if !proposal.IsVoting() {
+		return nil, sdkerrors.Wrapf(types.ErrBondProposalVotingEnded, "%d", msg.ProposalID)
 	}
 
-	// Send outcome payment to reserve
-	err := keeper.DepositReserve(ctx, bond.Token, msg.Sender, bond.OutcomePayment)
+	if _, found := keeper.GetBondProposalVote(ctx, msg.ProposalID, msg.Voter); found {
+		return nil, sdkerrors.Wrapf(types.ErrAlreadyVotedOnBondProposal, "%d", msg.ProposalID)
+	}
+
+	vote, err := keeper.VoteOnBondProposal(ctx, proposal, msg.Voter, msg.Option)
 	if err != nil {
 		return nil, err
 	}
 
-	// Set bond state to SETTLE
-	keeper.SetBondState(ctx, bond.Token, types.SettleState)
+	logger := keeper.Logger(ctx)
+	logger.Info(vote.String())
+
+	bond := keeper.MustGetBond(ctx, proposal.BondToken)
 
 	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeMakeOutcomePayment,
-			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
-			sdk.NewAttribute(types.AttributeKeyAddress, msg.Sender.String()),
-		),
+		types.NewEvent(types.VoteBondProposalEvent{
+			Bond:        proposal.BondToken,
+			ProposalID:  msg.ProposalID,
+			Voter:       msg.Voter,
+			VoteOption:  msg.Option,
+			VotingPower: vote.Power,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Voter.String()),
 		),
 	})
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
-func handleMsgWithdrawShare(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgWithdrawShare) (*sdk.Result, error) {
+func handleMsgSetBondTranslations(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSetBondTranslations) (*sdk.Result, error) {
 
 	bond, found := keeper.GetBond(ctx, msg.BondToken)
 	if !found {
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
 	}
 
-	// Check that state is SETTLE
-	if bond.State != types.SettleState {
-		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	if !bond.SignersMeetThreshold(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not meet the bond's signer threshold")
 	}
 
-	// Get number of bond tokens owned by the recipient
-	bondTokensOwnedAmount := keeper.BankKeeper.GetCoins(ctx, msg.Recipient).AmountOf(msg.BondToken)
-	if bondTokensOwnedAmount.IsZero() {
-		return nil, sdkerrors.Wrap(types.ErrNoBondTokensOwned, bondTokensOwnedAmount.String())
+	// Check translations against the (possibly lower) name and description
+	// length limits set in the module parameters
+	maxNameLength := keeper.MaxNameLength(ctx)
+	maxDescriptionLength := keeper.MaxDescriptionLength(ctx)
+	for _, t := range msg.Translations {
+		if err := t.Validate(maxNameLength, maxDescriptionLength); err != nil {
+			return nil, err
+		}
 	}
-	bondTokensOwned := sdk.NewCoin(msg.BondToken, bondTokensOwnedAmount)
 
-	// Send coins to be burned from recipient
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(
-		ctx, msg.Recipient, types.BondsMintBurnAccount, sdk.NewCoins(bondTokensOwned))
-	if err != nil {
-		return nil, err
+	// Translations replace any existing translations
+	bond.Translations = msg.Translations
+	keeper.SetBond(ctx, bond.Token, bond)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("translations of %s set to [%s] by %s",
+		msg.BondToken, msg.Translations.String(), msg.Editor.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		types.NewEvent(types.SetBondTranslationsEvent{
+			Bond:    msg.BondToken,
+			Locales: strings.Join(msg.Translations.Locales(), ","),
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSetSanityRate(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSetSanityRate) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
 	}
 
-	// Burn bond tokens
-	err = keeper.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount,
-		sdk.NewCoins(sdk.NewCoin(msg.BondToken, bondTokensOwnedAmount)))
-	if err != nil {
-		return nil, err
+	if !bond.SignersMeetThreshold(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not meet the bond's signer threshold")
 	}
 
-	// Calculate amount owned
-	remainingReserve := keeper.GetReserveBalances(ctx, bond.Token)
-	bondTokensShare := bondTokensOwnedAmount.ToDec().QuoInt(bond.CurrentSupply.Amount)
-	reserveOwedDec := sdk.NewDecCoinsFromCoins(remainingReserve...).MulDec(bondTokensShare)
-	reserveOwed, _ := reserveOwedDec.TruncateDecimal()
+	// Only swapper bonds use the sanity rate
+	if bond.FunctionType != types.SwapperFunction {
+		return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	}
 
-	// Send coins owed to recipient
-	err = keeper.WithdrawReserve(ctx, bond.Token, msg.Recipient, reserveOwed)
+	// Check the change against the max step and window percentages
+	err := keeper.CheckSanityRateChange(ctx, bond, msg.SanityRate, msg.SanityMarginPercentage)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update supply
-	keeper.SetCurrentSupply(ctx, bond.Token, bond.CurrentSupply.Sub(bondTokensOwned))
+	oldSanityRate := bond.SanityRate
+	oldSanityMarginPercentage := bond.SanityMarginPercentage
+	bond.SanityRate = msg.SanityRate
+	bond.SanityMarginPercentage = msg.SanityMarginPercentage
+	keeper.SetBond(ctx, bond.Token, bond)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("sanity rate of %s set to %s (margin %s%%) by %s",
+		msg.BondToken, msg.SanityRate, msg.SanityMarginPercentage, msg.Editor.String()))
 
 	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeWithdrawShare,
-			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
-			sdk.NewAttribute(types.AttributeKeyAddress, msg.Recipient.String()),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, reserveOwed.String()),
-		),
+		types.NewEvent(types.SetSanityRateEvent{
+			Bond:                      msg.BondToken,
+			OldSanityRate:             oldSanityRate,
+			OldSanityMarginPercentage: oldSanityMarginPercentage,
+			NewSanityRate:             msg.SanityRate,
+			NewSanityMarginPercentage: msg.SanityMarginPercentage,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Recipient.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
 		),
 	})
 
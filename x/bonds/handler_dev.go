@@ -0,0 +1,89 @@
+//go:build testing
+// +build testing
+
+package bonds
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// handleDevMsg handles the developer messages, which are only compiled into
+// builds with the testing build tag. The returned bool is false if the
+// message is not a developer message.
+func handleDevMsg(ctx sdk.Context, keeper keeper.Keeper, msg sdk.Msg) (*sdk.Result, error, bool) {
+	switch msg := msg.(type) {
+	case types.MsgDevMintReserve:
+		res, err := handleMsgDevMintReserve(ctx, keeper, msg)
+		return res, err, true
+	case types.MsgDevAdvanceBatch:
+		res, err := handleMsgDevAdvanceBatch(ctx, keeper, msg)
+		return res, err, true
+	default:
+		return nil, nil, false
+	}
+}
+
+func handleMsgDevMintReserve(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgDevMintReserve) (*sdk.Result, error) {
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Check that only the bond's reserve tokens are minted
+	for _, c := range msg.Amount {
+		if !bond.IsReserveToken(c.Denom) {
+			return nil, sdkerrors.Wrap(types.ErrTokenIsNotAValidReserveToken, c.Denom)
+		}
+	}
+
+	err := keeper.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+	err = keeper.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BondsMintBurnAccount, msg.Sender, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("dev minted %s reserve of %s to %s",
+		msg.Amount, msg.BondToken, msg.Sender.String()))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+	))
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgDevAdvanceBatch(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgDevAdvanceBatch) (*sdk.Result, error) {
+	if !keeper.BondExists(ctx, msg.BondToken) {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// The batch is due once the end blocker subtracts its last block
+	batch := keeper.MustGetBatch(ctx, msg.BondToken)
+	if batch.BlocksRemaining.GT(sdk.OneUint()) {
+		batch.BlocksRemaining = sdk.OneUint()
+		keeper.SetBatch(ctx, msg.BondToken, batch)
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("dev advanced batch of %s to the end of the block by %s",
+		msg.BondToken, msg.Sender.String()))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+	))
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
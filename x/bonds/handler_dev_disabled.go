@@ -0,0 +1,15 @@
+//go:build !testing
+// +build !testing
+
+package bonds
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
+)
+
+// handleDevMsg never handles a message, since the developer messages are
+// only compiled into builds with the testing build tag
+func handleDevMsg(sdk.Context, keeper.Keeper, sdk.Msg) (*sdk.Result, error, bool) {
+	return nil, nil, false
+}
@@ -0,0 +1,58 @@
+//go:build testing
+// +build testing
+
+package bonds_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDevMintReserveMintsReserveTokensToSender(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.Nil(t, err)
+
+	// Mint reserve tokens to user
+	amount := sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)}
+	_, err = h(ctx, types.NewMsgDevMintReserve(userAddress, token, amount))
+	require.Nil(t, err)
+	require.Equal(t, amount, app.BankKeeper.GetCoins(ctx, userAddress))
+
+	// Minting a token that is not a reserve token fails
+	notReserve := sdk.Coins{sdk.NewInt64Coin(reserveToken2, 1000)}
+	_, err = h(ctx, types.NewMsgDevMintReserve(userAddress, token, notReserve))
+	require.True(t, types.ErrTokenIsNotAValidReserveToken.Is(err))
+}
+
+func TestDevAdvanceBatchMakesBatchDueAtEndOfBlock(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with batches of 5 blocks
+	createMsg := newValidMsgCreateBond()
+	createMsg.BatchBlocks = sdk.NewUint(5)
+	_, err := h(ctx, createMsg)
+	require.Nil(t, err)
+
+	// Fund user and buy 10 tokens
+	amount := sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)}
+	_, err = h(ctx, types.NewMsgDevMintReserve(userAddress, token, amount))
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(10, 10000))
+	require.Nil(t, err)
+
+	// Advance batch, which is then performed at the end of the block
+	_, err = h(ctx, types.NewMsgDevAdvanceBatch(userAddress, token))
+	require.Nil(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	require.Equal(t, int64(10), app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64())
+	require.Equal(t, sdk.NewUint(5), app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+}
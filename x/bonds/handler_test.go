@@ -1,13 +1,21 @@
 package bonds_test
 
 import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+	simapp "github.com/ixoworld/bonds/app"
 	"github.com/ixoworld/bonds/x/bonds"
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"reflect"
+	"strings"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 func TestInvalidMsgFails(t *testing.T) {
@@ -72,77 +80,223 @@ func TestCreateValidAugmentedBondHatchState(t *testing.T) {
 	require.Len(t, bond.FunctionParameters, 7)
 }
 
-func TestCreateBondThatAlreadyExistsFails(t *testing.T) {
+func TestCreateBondWithPreMineLocksItUnderVesting(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Set bond to simulate creation
-	bond := types.Bond{Token: token}
-	app.BondsKeeper.SetBond(ctx, token, bond)
+	// Creator has enough reserve to pay for a pre-mine of 1000 tokens
+	reservePaid := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 4000100000))
+	err := mintCoinsToCreator(app, ctx, reservePaid)
+	require.Nil(t, err)
 
-	// Create bond with same token
-	_, err := h(ctx, newValidMsgCreateBond())
+	// Create bond with a pre-mine of 10% of max supply at height 100
+	ctx = ctx.WithBlockHeight(100)
+	msg := newValidMsgCreateBond()
+	msg.PreMine = sdk.NewCoins(sdk.NewInt64Coin(token, 1000))
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+
+	// Pre-mine is part of the supply and is backed by the reserve paid by the
+	// creator, i.e. (12/3)*1000^3 + 100*1000 = 4000100000res
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 1000), bond.CurrentSupply)
+	require.Equal(t, reservePaid, app.BondsKeeper.GetReserveBalances(ctx, token))
+	require.True(t, app.BankKeeper.GetCoins(ctx, initCreator).AmountOf(reserveToken).IsZero())
+	vestingAddress := app.SupplyKeeper.GetModuleAddress(types.BondVestingAccount)
+	require.Equal(t, msg.PreMine, app.BankKeeper.GetCoins(ctx, vestingAddress))
+	require.True(t, app.BankKeeper.GetCoins(ctx, initCreator).AmountOf(token).IsZero())
+
+	// Half of the pre-mine is released halfway through the vesting period
+	vestingBlocks := int64(types.DefaultPreMineVestingBlocks)
+	ctx = ctx.WithBlockHeight(100 + vestingBlocks/2)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, sdk.NewInt(500), app.BankKeeper.GetCoins(ctx, initCreator).AmountOf(token))
+	schedule, found := app.BondsKeeper.GetVestingSchedule(ctx, token)
+	require.True(t, found)
+	require.Equal(t, sdk.NewInt64Coin(token, 500), schedule.Locked())
+
+	// The rest is released at the end, after which the schedule is deleted
+	ctx = ctx.WithBlockHeight(100 + vestingBlocks)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, sdk.NewInt(1000), app.BankKeeper.GetCoins(ctx, initCreator).AmountOf(token))
+	require.True(t, app.BankKeeper.GetCoins(ctx, vestingAddress).IsZero())
+	_, found = app.BondsKeeper.GetVestingSchedule(ctx, token)
+	require.False(t, found)
+}
+
+func TestCreateBondWithPreMineWithoutReserveFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Creator cannot pay the reserve for the pre-mine
+	msg := newValidMsgCreateBond()
+	msg.PreMine = sdk.NewCoins(sdk.NewInt64Coin(token, 1000))
+	_, err := h(ctx, msg)
 
 	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
 }
 
-func TestCreatingABondUsingStakingTokenFails(t *testing.T) {
+func TestSellingReleasedPreMineDoesNotTakeBuyersReserve(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond with token set to staking token
+	// Create bond with a pre-mine of 100 tokens at height 100, for which the
+	// creator pays (12/3)*100^3 + 100*100 = 4010000res
+	ctx = ctx.WithBlockHeight(100)
+	err := mintCoinsToCreator(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4010000)})
+	require.Nil(t, err)
 	msg := newValidMsgCreateBond()
-	msg.Token = app.StakingKeeper.GetParams(ctx).BondDenom
+	msg.PreMine = sdk.NewCoins(sdk.NewInt64Coin(token, 100))
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+
+	// User buys 10 tokens along the curve, after the pre-mine
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 2000000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(10, 2000000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, sdk.NewInt(10), app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token))
+
+	// The whole pre-mine is released to the creator
+	ctx = ctx.WithBlockHeight(100 + int64(types.DefaultPreMineVestingBlocks))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, sdk.NewInt(100), app.BankKeeper.GetCoins(ctx, initCreator).AmountOf(token))
+
+	// Creator sells the whole pre-mine like any other holder
+	_, err = h(ctx, types.NewMsgSell(initCreator, sdk.NewInt64Coin(token, 100)))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.True(t, app.BankKeeper.GetCoins(ctx, initCreator).AmountOf(token).IsZero())
+
+	// User can still sell all of their tokens
+	_, err = h(ctx, newValidMsgSell(10))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.True(t, app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).IsZero())
+	require.True(t, app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply.IsZero())
+
+	_, broken := keeper.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestCreateBondWithPreMineAboveMaxPercentageFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with a pre-mine of more than 10% of max supply
+	msg := newValidMsgCreateBond()
+	msg.PreMine = sdk.NewCoins(sdk.NewInt64Coin(token, 1001))
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
+	require.True(t, types.ErrPreMineTooLarge.Is(err))
 	require.False(t, app.BondsKeeper.BondExists(ctx, token))
 }
 
-func TestEditingANonExistingBondFails(t *testing.T) {
+func TestCreateSwapperBondWithPreMineFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Edit bond
-	msg := types.NewMsgEditBond(token, initName, initDescription, "",
-		"0", "0", initCreator, initSigners)
+	msg := newValidMsgCreateSwapperBond()
+	msg.PreMine = sdk.NewCoins(sdk.NewInt64Coin(token, 10))
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
-	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
 }
 
-func TestEditingABondWithDifferentCreatorAndSignersFails(t *testing.T) {
+func TestCreateBondThatAlreadyExistsFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
 	// Set bond to simulate creation
-	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
+	bond := types.Bond{Token: token}
+	app.BondsKeeper.SetBond(ctx, token, bond)
 
-	// Edit bond
-	msg := types.NewMsgEditBond(token, initName, initDescription, "",
-		"0", "0", initCreator, []sdk.AccAddress{anotherAddress})
-	_, err := h(ctx, msg)
+	// Create bond with same token
+	_, err := h(ctx, newValidMsgCreateBond())
 
 	require.Error(t, err)
 }
 
-func TestEditingABondWithNegativeOrderQuantityLimitsFails(t *testing.T) {
+func TestCreateBondOfTokenReservedInBlockFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Set bond to simulate creation
-	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
+	// First creation in the block reserves the token and creates the bond
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	creator, found := app.BondsKeeper.GetReservation(ctx, token)
+	require.True(t, found)
+	require.Equal(t, initCreator, creator)
+
+	// Second creation of the same token (by another creator) is rejected
+	msg := newValidMsgCreateBond()
+	msg.Creator = anotherAddress
+	_, err = h(ctx, msg)
+	require.True(t, types.ErrBondTokenReserved.Is(err))
+	require.Equal(t, initCreator, app.BondsKeeper.MustGetBond(ctx, token).Creator)
+
+	// Reservation is cleared by the end blocker, after which the creation
+	// fails since the bond already exists
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	_, found = app.BondsKeeper.GetReservation(ctx, token)
+	require.False(t, found)
+	_, err = h(ctx, msg)
+	require.True(t, types.ErrBondAlreadyExists.Is(err))
+}
+
+func TestFailedCreateBondDoesNotReserveToken(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Creation fails once the bond is set up, since the creator cannot pay
+	// the reserve for the pre-mine, and, as when delivering a transaction,
+	// its state changes are discarded
+	cacheCtx, _ := ctx.CacheContext()
+	msg := newValidMsgCreateBond()
+	msg.PreMine = sdk.NewCoins(sdk.NewInt64Coin(token, 1000))
+	_, err := h(cacheCtx, msg)
+	require.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+	_, found := app.BondsKeeper.GetReservation(ctx, token)
+	require.False(t, found)
+
+	// Another creation of the same token in the same block succeeds
+	msg = newValidMsgCreateBond()
+	msg.Creator = anotherAddress
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, anotherAddress, app.BondsKeeper.MustGetBond(ctx, token).Creator)
+}
+
+func TestCreatingABondUsingStakingTokenFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with token set to staking token
+	msg := newValidMsgCreateBond()
+	msg.Token = app.StakingKeeper.GetParams(ctx).BondDenom
+	_, err := h(ctx, msg)
+
+	require.Error(t, err)
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+}
+
+func TestEditingANonExistingBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
 
 	// Edit bond
-	msg := types.NewMsgEditBond(token, initName, initDescription, "-10testtoken",
-		"0", "0", initCreator, initSigners)
+	msg := newValidMsgEditBond()
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
 }
 
-func TestEditingABondWithFloatOrderQuantityLimitsFails(t *testing.T) {
+func TestEditingABondWithDifferentCreatorAndSignersFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
@@ -150,14 +304,21 @@ func TestEditingABondWithFloatOrderQuantityLimitsFails(t *testing.T) {
 	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
 
 	// Edit bond
-	msg := types.NewMsgEditBond(token, initName, initDescription, "10.5testtoken",
-		"0", "0", initCreator, initSigners)
+	msg := newValidMsgEditBond()
+	msg.Signers = []sdk.AccAddress{anotherAddress}
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
 }
 
-func TestEditingABondWithSanityRateEmptyStringMakesSanityFieldsZero(t *testing.T) {
+func TestEditingABondWithNegativeOrderQuantityLimitsFails(t *testing.T) {
+	msg := newValidMsgEditBond()
+	msg.OrderQuantityLimits = coinsPtr(sdk.Coins{{Denom: token, Amount: sdk.NewInt(-10)}})
+
+	require.Error(t, msg.ValidateBasic())
+}
+
+func TestEditingABondWithZeroSanityRateMakesSanityFieldsZero(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
@@ -173,8 +334,9 @@ func TestEditingABondWithSanityRateEmptyStringMakesSanityFieldsZero(t *testing.T
 	require.NotEqual(t, sdk.ZeroDec(), bond.SanityMarginPercentage)
 
 	// Edit bond
-	msg := types.NewMsgEditBond(token, initName, initDescription, "10testtoken",
-		"", "", initCreator, initSigners)
+	msg := newValidMsgEditBond()
+	msg.SanityRate = decPtr(sdk.ZeroDec())
+	msg.SanityMarginPercentage = decPtr(sdk.ZeroDec())
 	_, err := h(ctx, msg)
 
 	// Check sanity values after
@@ -185,6 +347,21 @@ func TestEditingABondWithSanityRateEmptyStringMakesSanityFieldsZero(t *testing.T
 }
 
 func TestEditingABondWithNegativeSanityRateFails(t *testing.T) {
+	msg := newValidMsgEditBond()
+	msg.SanityRate = decPtr(sdk.NewDec(-10))
+
+	require.Error(t, msg.ValidateBasic())
+}
+
+func TestEditingABondWithNegativeSanityMarginPercentageFails(t *testing.T) {
+	msg := newValidMsgEditBond()
+	msg.SanityRate = decPtr(sdk.NewDec(10))
+	msg.SanityMarginPercentage = decPtr(sdk.NewDec(-5))
+
+	require.Error(t, msg.ValidateBasic())
+}
+
+func TestEditingABondCorrectlyPasses(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
@@ -192,44 +369,260 @@ func TestEditingABondWithNegativeSanityRateFails(t *testing.T) {
 	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
 
 	// Edit bond
-	msg := types.NewMsgEditBond(token, initName, initDescription, "10testtoken",
-		"-10", "", initCreator, initSigners)
+	newName := "a new name"
+	newDescription := "a new description"
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.Name = &newName
+	msg.Description = &newDescription
+	msg.OrderQuantityLimits = coinsPtr(nil)
+	msg.SanityRate = decPtr(sdk.ZeroDec())
+	msg.SanityMarginPercentage = decPtr(sdk.ZeroDec())
 	_, err := h(ctx, msg)
 
-	require.Error(t, err)
+	require.NoError(t, err)
+	bond, _ := app.BondsKeeper.GetBond(ctx, token)
+	require.Equal(t, newName, bond.Name)
+	require.Equal(t, newDescription, bond.Description)
+	require.Equal(t, sdk.Coins(nil), bond.OrderQuantityLimits)
+	require.Equal(t, sdk.ZeroDec(), bond.SanityRate)
+	require.Equal(t, sdk.ZeroDec(), bond.SanityMarginPercentage)
 }
 
-func TestEditingABondWithNonFloatSanityRateFails(t *testing.T) {
+func TestEditingABondWithSignerThreshold(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Set bond to simulate creation
-	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
+	// Create bond with 3 signers, any 2 of which can edit the bond
+	signers := []sdk.AccAddress{initCreator, anotherAddress, userAddress}
+	createMsg := newValidMsgCreateBond()
+	createMsg.Signers = signers
+	createMsg.SignerThreshold = &types.SignerThreshold{Threshold: 2}
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
 
-	// Edit bond
-	msg := types.NewMsgEditBond(token, initName, initDescription, "10testtoken",
-		"20t", "", initCreator, initSigners)
-	_, err := h(ctx, msg)
+	// Edit bond with 1 of 3 signers fails
+	msg := types.NewMsgEditBond(token, initCreator, signers[2:])
+	msg.Name = stringPtr("a new name")
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+
+	// Edit bond with 2 of 3 signers (in any order) passes
+	msg.Signers = []sdk.AccAddress{userAddress, initCreator}
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, "a new name", bond.Name)
+
+	// Signer threshold cannot exceed the signers' weight
+	msg = types.NewMsgEditBond(token, initCreator, signers[:2])
+	msg.SignerThreshold = &types.SignerThreshold{Threshold: 4}
+	_, err = h(ctx, msg)
+	require.Error(t, err)
 
+	// Resetting the signer threshold requires all signers again
+	msg.SignerThreshold = &types.SignerThreshold{}
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	_, err = h(ctx, msg)
 	require.Error(t, err)
+	msg.Signers = signers
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
 }
 
-func TestEditingABondWithNegativeSanityMarginPercentageFails(t *testing.T) {
+func TestEditingABondOnlyChangesEditedFields(t *testing.T) {
+	// Each edit changes exactly one field, which is checked by comparing the
+	// encoded bond after the edit with the encoded bond before the edit with
+	// only that field changed. Unedited fields must be preserved exactly.
+	testCases := []struct {
+		field  string
+		edit   func(msg *types.MsgEditBond)
+		expect func(bond *types.Bond, height int64)
+	}{
+		{"name",
+			func(msg *types.MsgEditBond) { msg.Name = stringPtr("a new name") },
+			func(bond *types.Bond, _ int64) { bond.Name = "a new name" }},
+		{"description",
+			func(msg *types.MsgEditBond) { msg.Description = stringPtr("a new description") },
+			func(bond *types.Bond, _ int64) { bond.Description = "a new description" }},
+		{"order_quantity_limits",
+			func(msg *types.MsgEditBond) {
+				msg.OrderQuantityLimits = coinsPtr(sdk.NewCoins(sdk.NewInt64Coin(token, 5)))
+			},
+			func(bond *types.Bond, _ int64) {
+				bond.OrderQuantityLimits = sdk.NewCoins(sdk.NewInt64Coin(token, 5))
+			}},
+		{"sanity_rate",
+			func(msg *types.MsgEditBond) { msg.SanityRate = decPtr(sdk.NewDec(3)) },
+			func(bond *types.Bond, _ int64) { bond.SanityRate = sdk.NewDec(3) }},
+		{"sanity_margin_percentage",
+			func(msg *types.MsgEditBond) { msg.SanityMarginPercentage = decPtr(sdk.NewDec(20)) },
+			func(bond *types.Bond, _ int64) { bond.SanityMarginPercentage = sdk.NewDec(20) }},
+		{"net_sell_cap",
+			func(msg *types.MsgEditBond) { msg.NetSellCap = coinPtr(sdk.NewInt64Coin(token, 7)) },
+			func(bond *types.Bond, _ int64) { bond.NetSellCap = sdk.NewInt64Coin(token, 7) }},
+		{"net_sell_cap_percentage",
+			func(msg *types.MsgEditBond) { msg.NetSellCapPercentage = decPtr(sdk.NewDec(15)) },
+			func(bond *types.Bond, _ int64) { bond.NetSellCapPercentage = sdk.NewDec(15) }},
+		{"demurrage_rate",
+			func(msg *types.MsgEditBond) { msg.DemurrageRate = decPtr(sdk.NewDec(2)) },
+			func(bond *types.Bond, height int64) {
+				// The demurrage index is brought up to date whenever the
+				// rate is changed
+				bond.DemurrageIndex = bond.GetDemurrageIndexAt(height)
+				bond.DemurrageHeight = height
+				bond.DemurrageRate = sdk.NewDec(2)
+			}},
+		{"quote_denom",
+			func(msg *types.MsgEditBond) { msg.QuoteDenom = stringPtr("eur") },
+			func(bond *types.Bond, _ int64) { bond.QuoteDenom = "eur" }},
+		{"min_reserve",
+			func(msg *types.MsgEditBond) {
+				msg.MinReserve = coinsPtr(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50)))
+			},
+			func(bond *types.Bond, _ int64) {
+				bond.MinReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50))
+			}},
+		{"min_reserve_percentage",
+			func(msg *types.MsgEditBond) { msg.MinReservePercentage = decPtr(sdk.NewDec(25)) },
+			func(bond *types.Bond, _ int64) { bond.MinReservePercentage = sdk.NewDec(25) }},
+		{"fee_address",
+			func(msg *types.MsgEditBond) { msg.FeeAddress = addressPtr(anotherAddress) },
+			func(bond *types.Bond, _ int64) { bond.FeeAddress = anotherAddress }},
+		{"signer_threshold",
+			func(msg *types.MsgEditBond) {
+				msg.SignerThreshold = &types.SignerThreshold{Threshold: 2, Weights: []uint64{2}}
+			},
+			func(bond *types.Bond, _ int64) {
+				bond.SignerThreshold = types.NewSignerThreshold(2, []uint64{2})
+			}},
+	}
+
+	// Every editable field of MsgEditBond (i.e. every optional field) is
+	// covered, in the order in which the fields are declared
+	var editable []string
+	msgType := reflect.TypeOf(types.MsgEditBond{})
+	for i := 0; i < msgType.NumField(); i++ {
+		if field := msgType.Field(i); field.Type.Kind() == reflect.Ptr {
+			editable = append(editable, strings.Split(field.Tag.Get("json"), ",")[0])
+		}
+	}
+	var covered []string
+	for _, tc := range testCases {
+		covered = append(covered, tc.field)
+	}
+	require.Equal(t, editable, covered)
+
+	// EditedFields reports every editable field
+	var allEdits types.MsgEditBond
+	for _, tc := range testCases {
+		tc.edit(&allEdits)
+	}
+	require.Equal(t, editable, allEdits.EditedFields())
+
+	for _, tc := range testCases {
+		app, ctx := createTestApp(false)
+		h := bonds.NewHandler(app.BondsKeeper)
+		ctx = ctx.WithBlockHeight(100)
+
+		// Bond with a non-default value in every editable field, so that
+		// resetting any unedited field would be noticed
+		bond := newSimpleBond()
+		bond.ReserveTokens = powerReserves()
+		bond.OrderQuantityLimits = sdk.NewCoins(sdk.NewInt64Coin(token, 10))
+		bond.SanityRate = sdk.NewDec(2)
+		bond.SanityMarginPercentage = sdk.NewDec(10)
+		bond.NetSellCap = sdk.NewInt64Coin(token, 5)
+		bond.NetSellCapPercentage = sdk.NewDec(5)
+		bond.DemurrageRate = sdk.OneDec()
+		bond.DemurrageIndex = sdk.OneDec()
+		bond.DemurrageHeight = 90
+		bond.QuoteDenom = "usd"
+		bond.MinReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+		bond.MinReservePercentage = sdk.NewDec(5)
+		bond.SignerThreshold = types.NewSignerThreshold(1, nil)
+		app.BondsKeeper.SetBond(ctx, token, bond)
+
+		before := app.BondsKeeper.MustGetBond(ctx, token)
+		expected := app.BondsKeeper.MustGetBond(ctx, token)
+		tc.expect(&expected, ctx.BlockHeight())
+
+		msg := types.NewMsgEditBond(token, initCreator, initSigners)
+		tc.edit(&msg)
+		require.NoError(t, msg.ValidateBasic(), tc.field)
+		require.Equal(t, []string{tc.field}, msg.EditedFields())
+		_, err := h(ctx, msg)
+		require.NoError(t, err, tc.field)
+
+		// Apply any edit deferred until the bond's batch is settled
+		app.BondsKeeper.ApplyPendingBondEdit(ctx, token)
+
+		after := app.BondsKeeper.MustGetBond(ctx, token)
+		require.NotEqual(t, app.Codec().MustMarshalBinaryBare(before),
+			app.Codec().MustMarshalBinaryBare(after), tc.field)
+		require.Equal(t, app.Codec().MustMarshalBinaryBare(expected),
+			app.Codec().MustMarshalBinaryBare(after), tc.field)
+	}
+}
+
+func TestEditingABondDefersOrderTermsUntilBatchIsSettled(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Set bond to simulate creation
-	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
-
-	// Edit bond
-	msg := types.NewMsgEditBond(token, initName, initDescription, "10testtoken",
-		"10", "-5", initCreator, initSigners)
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+	before := app.BondsKeeper.MustGetBond(ctx, token)
+
+	// Edit fields that change the terms of orders and one that does not
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.Name = stringPtr("a new name")
+	msg.NetSellCap = coinPtr(sdk.NewInt64Coin(token, 10))
+	msg.DemurrageRate = decPtr(sdk.OneDec())
+	msg.MinReserve = coinsPtr(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)))
+	msg.FeeAddress = addressPtr(anotherAddress)
 	_, err := h(ctx, msg)
+	require.NoError(t, err)
 
-	require.Error(t, err)
+	// Only the name is edited until the current batch is settled
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, "a new name", bond.Name)
+	require.Equal(t, before.NetSellCap, bond.NetSellCap)
+	require.Equal(t, before.DemurrageRate, bond.DemurrageRate)
+	require.Equal(t, before.MinReserve, bond.MinReserve)
+	require.Equal(t, before.FeeAddress, bond.FeeAddress)
+	_, found := app.BondsKeeper.GetPendingBondEdit(ctx, token)
+	require.True(t, found)
+
+	// The other fields are edited once the batch is settled
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), bond.NetSellCap)
+	require.Equal(t, sdk.OneDec(), bond.DemurrageRate)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)), bond.MinReserve)
+	require.Equal(t, anotherAddress, bond.FeeAddress)
+	_, found = app.BondsKeeper.GetPendingBondEdit(ctx, token)
+	require.False(t, found)
+}
+
+func TestEditingASettledOrFailedBondFails(t *testing.T) {
+	for _, state := range []string{types.SettleState, types.FailedState} {
+		app, ctx := createTestApp(false)
+		h := bonds.NewHandler(app.BondsKeeper)
+
+		// Set bond to simulate creation
+		bond := newSimpleBond()
+		bond.State = state
+		app.BondsKeeper.SetBond(ctx, token, bond)
+
+		// Edit bond
+		_, err := h(ctx, newValidMsgEditBond())
+
+		require.True(t, types.ErrInvalidStateForAction.Is(err), state)
+		require.Equal(t, bond.Name, app.BondsKeeper.MustGetBond(ctx, token).Name)
+	}
 }
 
-func TestEditingABondWithNonFloatSanityMarginPercentageFails(t *testing.T) {
+func TestEditingABondWithNetSellCapInWrongDenomFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
@@ -237,14 +630,22 @@ func TestEditingABondWithNonFloatSanityMarginPercentageFails(t *testing.T) {
 	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
 
 	// Edit bond
-	msg := types.NewMsgEditBond(token, initName, initDescription, "10testtoken",
-		"20", "20t", initCreator, initSigners)
+	msg := newValidMsgEditBond()
+	msg.NetSellCap = coinPtr(sdk.NewInt64Coin(reserveToken, 10))
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
+	require.True(t, types.ErrNetSellCapDenomDoesNotMatchToken.Is(err))
 }
 
-func TestEditingABondCorrectlyPasses(t *testing.T) {
+func TestEditingABondWithNetSellCapPercentageAbove100Fails(t *testing.T) {
+	msg := newValidMsgEditBond()
+	msg.NetSellCapPercentage = decPtr(sdk.MustNewDecFromStr("100.1"))
+
+	require.Error(t, msg.ValidateBasic())
+}
+
+func TestEditingABondNetSellCapCorrectlyPasses(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
@@ -252,229 +653,321 @@ func TestEditingABondCorrectlyPasses(t *testing.T) {
 	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
 
 	// Edit bond
-	newName := "a new name"
-	newDescription := "a new description"
-	msg := types.NewMsgEditBond(token, newName, newDescription, "",
-		"0", "0", initCreator, initSigners)
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.NetSellCap = coinPtr(sdk.NewInt64Coin(token, 10))
+	msg.NetSellCapPercentage = decPtr(sdk.NewDec(5))
 	_, err := h(ctx, msg)
+	app.BondsKeeper.ApplyPendingBondEdit(ctx, token)
 
 	require.NoError(t, err)
 	bond, _ := app.BondsKeeper.GetBond(ctx, token)
-	require.Equal(t, newName, bond.Name)
-	require.Equal(t, newDescription, bond.Description)
-	require.Equal(t, sdk.Coins(nil), bond.OrderQuantityLimits)
-	require.Equal(t, sdk.ZeroDec(), bond.SanityRate)
-	require.Equal(t, sdk.ZeroDec(), bond.SanityMarginPercentage)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), bond.NetSellCap)
+	require.Equal(t, sdk.NewDec(5), bond.NetSellCapPercentage)
 }
 
-func TestBuyingANonExistingBondFails(t *testing.T) {
+func TestEditingABondMinReserveCorrectlyPasses(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Buy 1 token
-	_, err := h(ctx, newValidMsgBuy(1, 10))
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
 
-	require.Error(t, err)
+	// Edit bond
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.MinReserve = coinsPtr(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)))
+	msg.MinReservePercentage = decPtr(sdk.NewDec(5))
+	_, err := h(ctx, msg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	require.NoError(t, err)
+	bond, _ := app.BondsKeeper.GetBond(ctx, token)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)), bond.MinReserve)
+	require.Equal(t, sdk.NewDec(5), bond.MinReservePercentage)
 }
 
-func TestBuyingABondWithNonExistentToken(t *testing.T) {
+func TestEditingABondWithMinReserveNotInReserveTokensFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
 	// Create bond
 	h(ctx, newValidMsgCreateBond())
 
-	// Buy tokens of another bond
-	msg := newValidMsgBuy(amountLTMaxSupply, 0) // 0 max prices replaced below
-	msg.MaxPrices = sdk.Coins{sdk.NewInt64Coin(token2, 10)}
+	// Edit bond
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.MinReserve = coinsPtr(sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 100)))
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
-	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
-	require.True(t, currentSupply.Amount.IsZero())
+	require.True(t, types.ErrTokenIsNotAValidReserveToken.Is(err))
 }
 
-func TestBuyingABondWithMaxPriceBiggerThanBalanceFails(t *testing.T) {
+func TestEditingASwapperBondWithMinReservePercentageFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond
-	h(ctx, newValidMsgCreateBond())
-
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
-	require.Nil(t, err)
+	// Create swapper bond
+	h(ctx, newValidMsgCreateSwapperBond())
 
-	// Buy 10 tokens
-	_, err = h(ctx, newValidMsgBuy(10, 5000))
+	// Edit bond
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.MinReservePercentage = decPtr(sdk.NewDec(5))
+	_, err := h(ctx, msg)
 
 	require.Error(t, err)
-	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
-	require.True(t, currentSupply.Amount.IsZero())
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
 }
 
-func TestBuyingBondWithOrderQuantityLimitExceededFails(t *testing.T) {
+func TestEditingABondFeeAddressCorrectlyPasses(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond with order quantity limit
-	msg := newValidMsgCreateBond()
-	msg.OrderQuantityLimits = sdk.NewCoins(sdk.NewInt64Coin(token, 4))
-	h(ctx, msg)
-
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
-	require.Nil(t, err)
+	// Set bond to simulate creation
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
 
-	// Buy 10 tokens
-	_, err = h(ctx, newValidMsgBuy(10, 4000))
+	// Edit bond
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.FeeAddress = addressPtr(anotherAddress)
+	res, err := h(ctx, msg)
+	app.BondsKeeper.ApplyPendingBondEdit(ctx, token)
 
-	require.Error(t, err)
-	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
-	require.True(t, currentSupply.Amount.IsZero())
+	require.NoError(t, err)
+	bond, _ := app.BondsKeeper.GetBond(ctx, token)
+	require.Equal(t, anotherAddress, bond.FeeAddress)
+
+	// Only the edited field is included in the event
+	var attributes []string
+	for _, e := range res.Events {
+		if e.Type == types.EventTypeEditBond {
+			for _, a := range e.Attributes {
+				attributes = append(attributes, string(a.Key)+"="+string(a.Value))
+			}
+		}
+	}
+	require.Equal(t, []string{
+		"bond=" + token,
+		"edited_fields=[fee_address]",
+		"fee_address=" + anotherAddress.String(),
+	}, attributes)
 }
 
-func TestBuyingABondExceedingMaxSupplyFails(t *testing.T) {
+func TestEditingABondFeeAddressToModuleAccountFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond
-	h(ctx, newValidMsgCreateBond())
-
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 6000)})
-	require.Nil(t, err)
-
-	// Buy an amount greater than max supply
-	_, err = h(ctx, newValidMsgBuy(amountGTMaxSupply, 10))
+	// Set bond to simulate creation
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
 
-	require.Error(t, err)
-	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
-	require.True(t, currentSupply.Amount.IsZero())
+	// Edit bond, setting the fee address to the reserve account or to the
+	// bond's escrow account
+	for _, feeAddress := range []sdk.AccAddress{
+		app.SupplyKeeper.GetModuleAddress(types.BondsReserveAccount),
+		types.GetBondEscrowAddress(token),
+	} {
+		msg := types.NewMsgEditBond(token, initCreator, initSigners)
+		msg.FeeAddress = addressPtr(feeAddress)
+		_, err := h(ctx, msg)
+
+		require.Error(t, err)
+		require.True(t, types.ErrFeeAddressCannotBeModuleAccount.Is(err))
+	}
+	bond, _ := app.BondsKeeper.GetBond(ctx, token)
+	require.Equal(t, newSimpleBond().FeeAddress, bond.FeeAddress)
 }
 
-func TestBuyingABondExceedingMaxPriceFails(t *testing.T) {
+func TestEditingABondDemurrageRateAccruesIndex(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond
-	h(ctx, newValidMsgCreateBond())
+	// Set bond to simulate creation
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
 
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 6000)})
-	require.Nil(t, err)
+	// Set demurrage rate of 1% per block at height 100
+	ctx = ctx.WithBlockHeight(100)
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.DemurrageRate = decPtr(sdk.OneDec())
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+	app.BondsKeeper.ApplyPendingBondEdit(ctx, token)
 
-	// Buy an amount less than max supply but with low max prices
-	_, err = h(ctx, newValidMsgBuy(amountLTMaxSupply, 1))
+	bond, _ := app.BondsKeeper.GetBond(ctx, token)
+	require.Equal(t, sdk.OneDec(), bond.DemurrageRate)
+	require.Equal(t, sdk.OneDec(), bond.DemurrageIndex)
+	require.Equal(t, int64(100), bond.DemurrageHeight)
 
-	require.Error(t, err)
-	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
-	require.True(t, currentSupply.Amount.IsZero())
+	// Disable demurrage two blocks later; index decayed by 1% twice
+	ctx = ctx.WithBlockHeight(102)
+	msg.DemurrageRate = decPtr(sdk.ZeroDec())
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	app.BondsKeeper.ApplyPendingBondEdit(ctx, token)
+
+	bond, _ = app.BondsKeeper.GetBond(ctx, token)
+	require.True(t, bond.DemurrageRate.IsZero())
+	require.Equal(t, sdk.MustNewDecFromStr("0.9801"), bond.DemurrageIndex)
+	require.Equal(t, int64(102), bond.DemurrageHeight)
+	require.Equal(t, bond.DemurrageIndex, bond.GetDemurrageIndexAt(200))
 }
 
-func TestBuyingABondWithoutSufficientFundsFails(t *testing.T) {
+func TestEditingABondQuoteDenomCorrectlyPasses(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond
-	h(ctx, newValidMsgCreateBond())
+	// Set bond to simulate creation
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
 
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
-	require.Nil(t, err)
+	// Set quote denomination
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.QuoteDenom = stringPtr("usd")
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
 
-	// Buy 10 tokens
-	_, err = h(ctx, newValidMsgBuy(10, 4000))
+	bond, _ := app.BondsKeeper.GetBond(ctx, token)
+	require.Equal(t, "usd", bond.QuoteDenom)
 
-	require.Error(t, err)
-	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
-	require.True(t, currentSupply.Amount.IsZero())
+	// Clear quote denomination
+	msg.QuoteDenom = stringPtr("")
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+
+	bond, _ = app.BondsKeeper.GetBond(ctx, token)
+	require.Equal(t, "", bond.QuoteDenom)
 }
 
-func TestBuyingABondWithoutSufficientFundsDueToTxFeeFails(t *testing.T) {
+func TestSettingSanityRateIsLimitedPerStepAndWindow(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond
-	h(ctx, newValidMsgCreateBond())
+	// Create swapper bond (with sanity check disabled)
+	h(ctx, newValidMsgCreateSwapperBond())
+	setSanityRate := func(ctx sdk.Context, rate string) error {
+		msg := types.NewMsgSetSanityRate(token, sdk.MustNewDecFromStr(rate),
+			sdk.NewDec(10), initCreator, initSigners)
+		_, err := h(ctx, msg)
+		return err
+	}
 
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 5000)})
-	require.Nil(t, err)
+	// Enabling the sanity check is not limited
+	ctx = ctx.WithBlockHeight(100)
+	require.NoError(t, setSanityRate(ctx, "1"))
 
-	// Buy 10 tokens
-	_, err = h(ctx, newValidMsgBuy(10, 5000))
+	// A step of more than 5% fails
+	err := setSanityRate(ctx, "1.06")
+	require.Error(t, err)
+	require.True(t, types.ErrSanityRateChangeTooLarge.Is(err))
 
+	// Steps of at most 5% succeed until the rate is 20% away from the rate
+	// at the start of the window
+	for _, rate := range []string{"1.05", "1.1", "1.15", "1.2"} {
+		require.NoError(t, setSanityRate(ctx, rate))
+	}
+	err = setSanityRate(ctx, "1.25")
 	require.Error(t, err)
-	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
-	require.True(t, currentSupply.Amount.IsZero())
+	require.True(t, types.ErrSanityRateChangeTooLarge.Is(err))
+
+	// The same step succeeds in the next window
+	ctx = ctx.WithBlockHeight(100 + int64(types.DefaultSanityRateWindowBlocks))
+	require.NoError(t, setSanityRate(ctx, "1.25"))
+
+	bond, _ := app.BondsKeeper.GetBond(ctx, token)
+	require.Equal(t, sdk.MustNewDecFromStr("1.25"), bond.SanityRate)
+	require.Equal(t, sdk.NewDec(10), bond.SanityMarginPercentage)
+
+	// Editing the bond is subject to the same limits
+	editMsg := types.NewMsgEditBond(token, initCreator, initSigners)
+	editMsg.SanityRate = decPtr(sdk.NewDec(2))
+	editMsg.SanityMarginPercentage = decPtr(sdk.NewDec(10))
+	_, err = h(ctx, editMsg)
+	require.Error(t, err)
+	require.True(t, types.ErrSanityRateChangeTooLarge.Is(err))
 }
 
-func TestBuyingABondCorrectlyPasses(t *testing.T) {
+func TestSettingSanityRateEmitsEvent(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond
+	// Create swapper bond (with sanity check disabled)
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	msg := types.NewMsgSetSanityRate(token, sdk.OneDec(), sdk.NewDec(10),
+		initCreator, initSigners)
+	res, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	var attributes []string
+	for _, e := range res.Events {
+		if e.Type == types.EventTypeSetSanityRate {
+			for _, a := range e.Attributes {
+				attributes = append(attributes, string(a.Key)+"="+string(a.Value))
+			}
+		}
+	}
+	require.Contains(t, attributes, types.AttributeKeyOldSanityRate+"="+sdk.ZeroDec().String())
+	require.Contains(t, attributes, types.AttributeKeyNewSanityRate+"="+sdk.OneDec().String())
+	require.Contains(t, attributes, types.AttributeKeyNewSanityMarginPercentage+"="+sdk.NewDec(10).String())
+}
+
+func TestSettingSanityRateOfNonSwapperBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create power function bond
 	h(ctx, newValidMsgCreateBond())
 
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
-	require.Nil(t, err)
+	msg := types.NewMsgSetSanityRate(token, sdk.OneDec(), sdk.NewDec(10),
+		initCreator, initSigners)
+	_, err := h(ctx, msg)
 
-	// Buy 2 tokens
-	_, err = h(ctx, newValidMsgBuy(2, 4000))
-	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Error(t, err)
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
+}
 
-	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
-	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
-	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
-	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(3767), userBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
-	require.Equal(t, sdk.NewInt(232), reserveBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(2), currentSupply.Amount)
+func TestBuyingANonExistingBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Buy 1 token
+	_, err := h(ctx, newValidMsgBuy(1, 10))
+
+	require.Error(t, err)
 }
 
-func TestSellingANonExistingBondFails(t *testing.T) {
+func TestBuyingABondWithNonExistentToken(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Sell 10 tokens
-	_, err := h(ctx, newValidMsgSell(10))
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Buy tokens of another bond
+	msg := newValidMsgBuy(amountLTMaxSupply, 0) // 0 max prices replaced below
+	msg.MaxPrices = sdk.Coins{sdk.NewInt64Coin(token2, 10)}
+	_, err := h(ctx, msg)
 
 	require.Error(t, err)
-	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.True(t, currentSupply.Amount.IsZero())
 }
 
-func TestSellingABondWhichCannotBeSoldFails(t *testing.T) {
+func TestBuyingABondWithMaxPriceBiggerThanBalanceFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
 	// Create bond
-	createMsg := newValidMsgCreateBond()
-	createMsg.AllowSells = false
-	h(ctx, createMsg)
+	h(ctx, newValidMsgCreateBond())
 
 	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
 	require.Nil(t, err)
 
 	// Buy 10 tokens
-	h(ctx, newValidMsgBuy(10, 10000))
-	bonds.EndBlocker(ctx, app.BondsKeeper)
-
-	// Sell 10 tokens
-	bondPreSell := app.BondsKeeper.MustGetBond(ctx, token)
-	_, err = h(ctx, newValidMsgSell(10))
-	bondPostSell := app.BondsKeeper.MustGetBond(ctx, token)
+	_, err = h(ctx, newValidMsgBuy(10, 5000))
 
 	require.Error(t, err)
-	require.Equal(t, bondPostSell.CurrentSupply.Amount, bondPreSell.CurrentSupply.Amount)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.True(t, currentSupply.Amount.IsZero())
 }
 
-func TestSellBondExceedingOrderQuantityLimitFails(t *testing.T) {
+func TestBuyingBondWithOrderQuantityLimitExceededFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
@@ -484,23 +977,18 @@ func TestSellBondExceedingOrderQuantityLimitFails(t *testing.T) {
 	h(ctx, msg)
 
 	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
 	require.Nil(t, err)
 
 	// Buy 10 tokens
-	h(ctx, newValidMsgBuy(10, 10000))
-	bonds.EndBlocker(ctx, app.BondsKeeper)
-
-	// Sell 10 tokens
-	bondPreSell := app.BondsKeeper.MustGetBond(ctx, token)
-	_, err = h(ctx, newValidMsgSell(10))
-	bondPostSell := app.BondsKeeper.MustGetBond(ctx, token)
+	_, err = h(ctx, newValidMsgBuy(10, 4000))
 
 	require.Error(t, err)
-	require.Equal(t, bondPostSell.CurrentSupply.Amount, bondPreSell.CurrentSupply.Amount)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.True(t, currentSupply.Amount.IsZero())
 }
 
-func TestSellingABondWithAmountGreaterThanBalanceFails(t *testing.T) {
+func TestBuyingABondExceedingMaxSupplyFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
@@ -508,125 +996,2499 @@ func TestSellingABondWithAmountGreaterThanBalanceFails(t *testing.T) {
 	h(ctx, newValidMsgCreateBond())
 
 	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 6000)})
 	require.Nil(t, err)
 
-	// Buy 10 tokens
-	h(ctx, newValidMsgBuy(10, 10000))
-	bonds.EndBlocker(ctx, app.BondsKeeper)
-
-	// Sell 11 tokens
-	bondPreSell := app.BondsKeeper.MustGetBond(ctx, token)
-	_, err = h(ctx, newValidMsgSell(11))
-	bondPostSell := app.BondsKeeper.MustGetBond(ctx, token)
+	// Buy an amount greater than max supply
+	_, err = h(ctx, newValidMsgBuy(amountGTMaxSupply, 10))
 
-	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
 	require.Error(t, err)
-	require.Equal(t, bondPostSell.CurrentSupply.Amount, bondPreSell.CurrentSupply.Amount)
-	require.Equal(t, sdk.NewInt(10), userBalance.AmountOf(token))
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.True(t, currentSupply.Amount.IsZero())
 }
 
-func TestSellingABondWhichSellerDoesNotOwnFails(t *testing.T) {
+func TestDueBatchIsPerformedAtItsStoredPrices(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create first bond
-	h(ctx, newValidMsgCreateBond())
-
-	// Create second bond (different token)
-	bond2Msg := newValidMsgCreateBond()
-	bond2Msg.Token = token2
-	h(ctx, bond2Msg)
-
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	// Create bond and buy 10 tokens
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
 	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(10, 10000))
+	require.NoError(t, err)
 
-	// Buy 10 tokens
-	h(ctx, newValidMsgBuy(10, 10000))
+	// Store a buy price lower than the (12/3)*10^3 + 100*10 = 5000res that
+	// the batch's prices would be re-calculated as
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	batch.BuyPrices = sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 250)}
+	app.BondsKeeper.SetBatch(ctx, token, batch)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// Sell 11 of a different bond
-	msg := newValidMsgSell(0) // 0 amount replaced below
-	msg.Amount = sdk.NewInt64Coin(token2, 11)
-	_, err = h(ctx, msg)
+	// Buy was performed at the stored price
+	lastBatch := app.BondsKeeper.MustGetLastBatch(ctx, token)
+	require.Equal(t, batch.BuyPrices, lastBatch.BuyPrices)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 2500)),
+		app.BondsKeeper.GetReserveBalances(ctx, token))
+}
 
-	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	require.Error(t, err)
-	require.Equal(t, sdk.NewInt(10), userBalance.AmountOf(token))
-	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token2))
+func createBondAndBuyMaxSupply(t *testing.T, app *simapp.BondsApp, ctx sdk.Context,
+	h sdk.Handler, atMaxSupplyBehavior string) {
+	createMsg := newValidMsgCreateBond()
+	createMsg.MaxSupply = sdk.NewInt64Coin(token, 10)
+	createMsg.AtMaxSupplyBehavior = atMaxSupplyBehavior
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user and buy the whole max supply
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(10, 10000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.True(t, app.BondsKeeper.MustGetBond(ctx, token).HasReachedMaxSupply())
 }
 
-func TestSellingMoreTokensThanThereIsSupplyFails(t *testing.T) {
+func TestBuyingABondAfterBurnsAtMaxSupplyAllowingRebuys(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
+	createBondAndBuyMaxSupply(t, app, ctx, h, types.AtMaxSupplyAllowRebuys)
 
-	// Create bond
-	h(ctx, newValidMsgCreateBond())
+	// Buying is not possible while at max supply
+	_, err := h(ctx, newValidMsgBuy(1, 10000))
+	require.True(t, types.ErrCannotMintMoreThanMaxSupply.Is(err))
 
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
-	require.Nil(t, err)
+	// Buying is possible again once tokens are burned
+	_, err = h(ctx, newValidMsgSell(1))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	_, err = h(ctx, newValidMsgBuy(1, 10000))
+	require.NoError(t, err)
+}
 
-	// Buy 10 tokens
-	h(ctx, newValidMsgBuy(10, 10000))
+func TestBuyingABondClosedToBuysAtMaxSupplyFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	createBondAndBuyMaxSupply(t, app, ctx, h, types.AtMaxSupplyCloseToBuys)
+
+	// Bond was closed to buys upon reaching its max supply
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.True(t, bond.BuysClosed)
+	require.Equal(t, types.OpenState, bond.State)
+	var reachedEvents int
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == types.EventTypeMaxSupplyReached {
+			reachedEvents += 1
+		}
+	}
+	require.Equal(t, 1, reachedEvents)
+
+	// Buying is still not possible once tokens are burned
+	_, err := h(ctx, newValidMsgSell(1))
+	require.NoError(t, err)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
+	_, err = h(ctx, newValidMsgBuy(1, 10000))
+	require.True(t, types.ErrBondClosedToBuys.Is(err))
+}
 
-	// Sell an amount greater than the max supply
-	bondPreSell := app.BondsKeeper.MustGetBond(ctx, token)
-	_, err = h(ctx, newValidMsgSell(amountGTMaxSupply))
-	bondPostSell := app.BondsKeeper.MustGetBond(ctx, token)
+func TestBondAutoSettledAtMaxSupply(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createBondAndBuyMaxSupply(t, app, ctx, h, types.AtMaxSupplyAutoSettle)
 
-	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	require.Error(t, err)
-	require.Equal(t, sdk.NewInt(10), userBalance.AmountOf(token))
-	require.Equal(t, bondPreSell.CurrentSupply.Amount, bondPostSell.CurrentSupply.Amount)
+	// Bond was settled upon reaching its max supply
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.SettleState, bond.State)
+	require.False(t, bond.BuysClosed)
+
+	// Buying is not possible, but holders can withdraw their share
+	_, err := h(ctx, newValidMsgBuy(1, 10000))
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+	_, err = h(ctx, types.NewMsgWithdrawShare(userAddress, token))
+	require.NoError(t, err)
 }
 
-func TestSellingABondCorrectlyPasses(t *testing.T) {
+func TestBondFailsIfSoftCapNotReachedByRaiseDeadline(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond
-	h(ctx, newValidMsgCreateBond())
+	// Create bond with a soft cap of 10000res to be raised in two blocks
+	msg := newValidMsgCreateBond()
+	msg.SoftCap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000))
+	msg.RaiseDeadline = ctx.BlockHeight() + 2
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
 
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	// Buy 10 tokens, which raises only 5000res
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 6000)})
 	require.Nil(t, err)
-
-	// Buy 2 tokens
-	h(ctx, newValidMsgBuy(2, 4000))
+	_, err = h(ctx, newValidMsgBuy(10, 6000))
+	require.NoError(t, err)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+	balanceBefore := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken)
 
-	// Sell 2 tokens
-	msg := newValidMsgSell(2)
-	_, err = h(ctx, msg)
+	// Bond fails once the raise deadline is reached
+	ctx = ctx.WithBlockHeight(msg.RaiseDeadline)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.FailedState, bond.State)
+	require.False(t, bond.SoftCapReached)
 
-	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
-	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
-	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	// Buying is not possible, but the holder is refunded the full reserve
+	_, err = h(ctx, newValidMsgBuy(1, 10000))
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+	_, err = h(ctx, types.NewMsgWithdrawShare(userAddress, token))
 	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(3997), userBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token))
-	require.Equal(t, sdk.ZeroInt(), reserveBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(3), feeBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.ZeroInt(), currentSupply.Amount)
+	balanceAfter := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken)
+	require.Equal(t, sdk.NewInt(5000), balanceAfter.Sub(balanceBefore))
+	require.True(t, app.BondsKeeper.GetReserveBalances(ctx, token).IsZero())
 }
 
-func TestSwapBondDoesNotExistFails(t *testing.T) {
+func TestBondDoesNotFailIfSoftCapReachedByRaiseDeadline(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Swap tokens
-	_, err := h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 1))
-
+	// Create bond with a soft cap of 5000res to be raised in two blocks
+	msg := newValidMsgCreateBond()
+	msg.SoftCap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	msg.RaiseDeadline = ctx.BlockHeight() + 2
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Buy 10 tokens, which raises 5000res
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 6000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(10, 6000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.True(t, app.BondsKeeper.MustGetBond(ctx, token).SoftCapReached)
+
+	// Bond remains open once the raise deadline is reached
+	ctx = ctx.WithBlockHeight(msg.RaiseDeadline)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+
+	// Withdrawing a share is not possible
+	_, err = h(ctx, types.NewMsgWithdrawShare(userAddress, token))
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+}
+
+func TestCreateBondWithRaiseDeadlineThatHasPassedFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	msg := newValidMsgCreateBond()
+	msg.SoftCap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	msg.RaiseDeadline = ctx.BlockHeight()
+	_, err := h(ctx, msg)
+	require.True(t, types.ErrInvalidRaiseDeadline.Is(err))
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+}
+
+func TestBuyingABondExceedingMaxPriceFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 6000)})
+	require.Nil(t, err)
+
+	// Buy an amount less than max supply but with low max prices
+	_, err = h(ctx, newValidMsgBuy(amountLTMaxSupply, 1))
+
+	require.Error(t, err)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.True(t, currentSupply.Amount.IsZero())
+}
+
+func TestBuyingABondWithoutSufficientFundsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 10 tokens
+	_, err = h(ctx, newValidMsgBuy(10, 4000))
+
+	require.Error(t, err)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.True(t, currentSupply.Amount.IsZero())
+}
+
+func TestBuyingABondWithoutSufficientFundsDueToTxFeeFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 5000)})
+	require.Nil(t, err)
+
+	// Buy 10 tokens
+	_, err = h(ctx, newValidMsgBuy(10, 5000))
+
+	require.Error(t, err)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.True(t, currentSupply.Amount.IsZero())
+}
+
+func TestBuyingABondCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(3767), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(232), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(2), currentSupply.Amount)
+}
+
+type mockAttestationKeeper struct {
+	attested sdk.AccAddress
+}
+
+func (ak mockAttestationKeeper) HasValidAttestation(_ sdk.Context, _ string, address sdk.AccAddress) bool {
+	return address.Equals(ak.attested)
+}
+
+func TestBuyingABondRequiringAttestationWithoutAttestationFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	keeper := app.BondsKeeper
+	keeper.SetAttestationKeeper(mockAttestationKeeper{attested: anotherAddress})
+	h := bonds.NewHandler(keeper)
+
+	// Create bond requiring attestation
+	msg := newValidMsgCreateBond()
+	msg.RequireAttestation = true
+	h(ctx, msg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.Error(t, err)
+	require.True(t, types.ErrAttestationRequired.Is(err))
+	require.Empty(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys)
+}
+
+func TestBuyingABondRequiringAttestationWithAttestationPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	keeper := app.BondsKeeper
+	keeper.SetAttestationKeeper(mockAttestationKeeper{attested: userAddress})
+	h := bonds.NewHandler(keeper)
+
+	// Create bond requiring attestation
+	msg := newValidMsgCreateBond()
+	msg.RequireAttestation = true
+	h(ctx, msg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 1)
+}
+
+func TestBuyingABondRequiringAttestationWithNoOpAttestationKeeperPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond requiring attestation
+	msg := newValidMsgCreateBond()
+	msg.RequireAttestation = true
+	h(ctx, msg)
+	require.True(t, app.BondsKeeper.MustGetBond(ctx, token).RequireAttestation)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens (no-op attestation keeper considers everyone attested)
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 1)
+}
+
+func TestSellingABondRequiringAttestationWithoutAttestationFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	keeper := app.BondsKeeper
+	keeper.SetAttestationKeeper(mockAttestationKeeper{attested: anotherAddress})
+	h := bonds.NewHandler(keeper)
+
+	// Create bond requiring attestation
+	msg := newValidMsgCreateBond()
+	msg.RequireAttestation = true
+	h(ctx, msg)
+
+	// Give user bond tokens
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(token, 10)})
+	require.Nil(t, err)
+
+	// Sell 2 tokens
+	_, err = h(ctx, newValidMsgSell(2))
+	require.Error(t, err)
+	require.True(t, types.ErrAttestationRequired.Is(err))
+	require.Empty(t, app.BondsKeeper.MustGetBatch(ctx, token).Sells)
+}
+
+type mockReserveConverter struct {
+	bankKeeper bank.Keeper
+	derivative string
+	underlying string
+	rate       sdk.Dec
+}
+
+func (rc mockReserveConverter) GetConversionRate(_ sdk.Context, denom string) (string, sdk.Dec, bool) {
+	if denom != rc.derivative {
+		return "", sdk.Dec{}, false
+	}
+	return rc.underlying, rc.rate, true
+}
+
+func (rc mockReserveConverter) ConvertToUnderlying(ctx sdk.Context, address sdk.AccAddress, amount sdk.Coin) (sdk.Coin, error) {
+	underlying := sdk.NewCoin(rc.underlying, rc.rate.MulInt(amount.Amount).TruncateInt())
+	if _, err := rc.bankKeeper.SubtractCoins(ctx, address, sdk.Coins{amount}); err != nil {
+		return sdk.Coin{}, err
+	} else if _, err := rc.bankKeeper.AddCoins(ctx, address, sdk.Coins{underlying}); err != nil {
+		return sdk.Coin{}, err
+	}
+	return underlying, nil
+}
+
+func TestBuyingWithReserveDerivativeConvertsItToReserve(t *testing.T) {
+	app, ctx := createTestApp(false)
+	keeper := app.BondsKeeper
+	keeper.SetReserveConverter(mockReserveConverter{
+		bankKeeper: app.BankKeeper,
+		derivative: "st" + reserveToken,
+		underlying: reserveToken,
+		rate:       sdk.MustNewDecFromStr("1.5"),
+	})
+	h := bonds.NewHandler(keeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add derivative tokens to user
+	derivative := sdk.NewInt64Coin("st"+reserveToken, 3000)
+	err := addCoinsToUser(app, ctx, sdk.Coins{derivative})
+	require.Nil(t, err)
+
+	// Buy 2 tokens with max prices in the derivative token
+	msg := types.NewMsgBuy(userAddress, sdk.NewInt64Coin(token, 2), sdk.Coins{derivative})
+	res, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Max prices were converted to the reserve token and escrowed
+	expectedMaxPrices := sdk.Coins{sdk.NewInt64Coin(reserveToken, 4500)}
+	buys := app.BondsKeeper.MustGetBatch(ctx, token).Buys
+	require.Len(t, buys, 1)
+	require.Equal(t, expectedMaxPrices, buys[0].MaxPrices)
+	require.Equal(t, expectedMaxPrices, app.BondsKeeper.GetEscrowBalance(ctx, token))
+	require.True(t, app.BankKeeper.GetCoins(ctx, userAddress).IsZero())
+
+	// Conversion event was emitted
+	var found bool
+	for _, e := range res.Events {
+		if e.Type == types.EventTypeConvertReserve {
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func TestBuyingWithUnsupportedReserveDerivativeFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add derivative tokens to user
+	derivative := sdk.NewInt64Coin("st"+reserveToken, 3000)
+	err := addCoinsToUser(app, ctx, sdk.Coins{derivative})
+	require.Nil(t, err)
+
+	// Buy 2 tokens with max prices in the derivative token (no-op reserve
+	// converter does not support any derivatives)
+	msg := types.NewMsgBuy(userAddress, sdk.NewInt64Coin(token, 2), sdk.Coins{derivative})
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrReserveDenomsMismatch.Is(err))
+	require.Equal(t, sdk.Coins{derivative}, app.BankKeeper.GetCoins(ctx, userAddress))
+}
+
+func TestBuyingAndSellingIssuesOrderReceipts(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 4000)
+	res, err := h(ctx, buyMsg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Check that buy receipt was returned and stored
+	buyReceipt, found := app.BondsKeeper.GetOrderReceipt(ctx, string(res.Data))
+	require.True(t, found)
+	require.Equal(t, uint64(1), buyReceipt.OrderID)
+	require.Equal(t, types.AttributeValueBuyOrder, buyReceipt.OrderType)
+	require.Equal(t, token, buyReceipt.BondToken)
+	require.Equal(t, buyMsg.Buyer, buyReceipt.Address)
+	require.Equal(t, buyMsg.Amount, buyReceipt.Amount)
+	require.Equal(t, buyMsg.MaxPrices, buyReceipt.MaxPrices)
+	require.Equal(t, buyReceipt.GetReceiptHash(), buyReceipt.Receipt)
+
+	// Sell 2 tokens
+	sellMsg := newValidMsgSell(2)
+	res, err = h(ctx, sellMsg)
+	require.NoError(t, err)
+
+	// Check that sell receipt was returned and stored, with the next ID
+	sellReceipt, found := app.BondsKeeper.GetOrderReceipt(ctx, string(res.Data))
+	require.True(t, found)
+	require.Equal(t, uint64(2), sellReceipt.OrderID)
+	require.Equal(t, types.AttributeValueSellOrder, sellReceipt.OrderType)
+	require.Equal(t, sellMsg.Seller, sellReceipt.Address)
+	require.Equal(t, sellMsg.Amount, sellReceipt.Amount)
+	require.NotEqual(t, buyReceipt.Receipt, sellReceipt.Receipt)
+	require.Equal(t, uint64(3), app.BondsKeeper.GetNextOrderID(ctx))
+}
+
+func TestSellingANonExistingBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Sell 10 tokens
+	_, err := h(ctx, newValidMsgSell(10))
+
+	require.Error(t, err)
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+}
+
+func TestSellingABondWhichCannotBeSoldFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	createMsg := newValidMsgCreateBond()
+	createMsg.AllowSells = false
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+
+	// Buy 10 tokens
+	h(ctx, newValidMsgBuy(10, 10000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 10 tokens
+	bondPreSell := app.BondsKeeper.MustGetBond(ctx, token)
+	_, err = h(ctx, newValidMsgSell(10))
+	bondPostSell := app.BondsKeeper.MustGetBond(ctx, token)
+
+	require.Error(t, err)
+	require.Equal(t, bondPostSell.CurrentSupply.Amount, bondPreSell.CurrentSupply.Amount)
+}
+
+func TestSellBondExceedingOrderQuantityLimitFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with order quantity limit
+	msg := newValidMsgCreateBond()
+	msg.OrderQuantityLimits = sdk.NewCoins(sdk.NewInt64Coin(token, 4))
+	h(ctx, msg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+
+	// Buy 10 tokens
+	h(ctx, newValidMsgBuy(10, 10000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 10 tokens
+	bondPreSell := app.BondsKeeper.MustGetBond(ctx, token)
+	_, err = h(ctx, newValidMsgSell(10))
+	bondPostSell := app.BondsKeeper.MustGetBond(ctx, token)
+
+	require.Error(t, err)
+	require.Equal(t, bondPostSell.CurrentSupply.Amount, bondPreSell.CurrentSupply.Amount)
+}
+
+func TestSellingABondWithAmountGreaterThanBalanceFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+
+	// Buy 10 tokens
+	h(ctx, newValidMsgBuy(10, 10000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 11 tokens
+	bondPreSell := app.BondsKeeper.MustGetBond(ctx, token)
+	_, err = h(ctx, newValidMsgSell(11))
+	bondPostSell := app.BondsKeeper.MustGetBond(ctx, token)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Error(t, err)
+	require.Equal(t, bondPostSell.CurrentSupply.Amount, bondPreSell.CurrentSupply.Amount)
+	require.Equal(t, sdk.NewInt(10), userBalance.AmountOf(token))
+}
+
+func TestSellingABondWhichSellerDoesNotOwnFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create first bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Create second bond (different token)
+	bond2Msg := newValidMsgCreateBond()
+	bond2Msg.Token = token2
+	h(ctx, bond2Msg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+
+	// Buy 10 tokens
+	h(ctx, newValidMsgBuy(10, 10000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 11 of a different bond
+	msg := newValidMsgSell(0) // 0 amount replaced below
+	msg.Amount = sdk.NewInt64Coin(token2, 11)
+	_, err = h(ctx, msg)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Error(t, err)
+	require.Equal(t, sdk.NewInt(10), userBalance.AmountOf(token))
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token2))
+}
+
+func TestSellingMoreTokensThanThereIsSupplyFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+
+	// Buy 10 tokens
+	h(ctx, newValidMsgBuy(10, 10000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell an amount greater than the max supply
+	bondPreSell := app.BondsKeeper.MustGetBond(ctx, token)
+	_, err = h(ctx, newValidMsgSell(amountGTMaxSupply))
+	bondPostSell := app.BondsKeeper.MustGetBond(ctx, token)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Error(t, err)
+	require.Equal(t, sdk.NewInt(10), userBalance.AmountOf(token))
+	require.Equal(t, bondPreSell.CurrentSupply.Amount, bondPostSell.CurrentSupply.Amount)
+}
+
+func TestSellingABondCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 2 tokens
+	msg := newValidMsgSell(2)
+	_, err = h(ctx, msg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(3997), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token))
+	require.Equal(t, sdk.ZeroInt(), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(3), feeBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), currentSupply.Amount)
+}
+
+func TestSellingByValueWithReturnsExceedingMaxAmountFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Selling 2 tokens returns 230res (after fees), so 231res is not possible
+	res, err := h(ctx, newValidMsgSellByValue(231, 2))
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestSellingByValueCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Selling 1 token returns 126res (after fees), so only 1 token is sold
+	_, err = h(ctx, newValidMsgSellByValue(100, 2))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.Equal(t, sdk.NewInt(3893), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.OneInt(), userBalance.AmountOf(token))
+	require.Equal(t, sdk.OneInt(), currentSupply.Amount)
+}
+
+func TestSwapBondDoesNotExistFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Swap tokens
+	_, err := h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 1))
+
+	require.Error(t, err)
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+}
+
+func TestSwapOrderInvalidReserveDenomsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Perform swap (invalid instead of reserveToken)
+	_, err = h(ctx, newValidMsgSwap("invalid", reserveToken2, 10))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.AccountKeeper.GetAccount(ctx, userAddress).GetCoins()
+	require.Error(t, err)
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+
+	// Perform swap (invalid instead of reserveToken2)
+	_, err = h(ctx, newValidMsgSwap(reserveToken, "invalid", 10))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance = app.AccountKeeper.GetAccount(ctx, userAddress).GetCoins()
+	require.Error(t, err)
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+}
+
+func TestSwapOrderQuantityLimitExceededFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with order quantity limit
+	createMsg := newValidMsgCreateSwapperBond()
+	createMsg.OrderQuantityLimits = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 4))
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Perform swap
+	msg := types.NewMsgSwap(userAddress, token, sdk.NewInt64Coin(reserveToken, 5), reserveToken2)
+	_, err = h(ctx, msg)
+
+	userBalance := app.AccountKeeper.GetAccount(ctx, userAddress).GetCoins()
+	require.Error(t, err)
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+}
+
+func TestSwapInvalidAmount(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	// Add reserve tokens to user (but not enough)
+	nineReserveTokens := sdk.NewInt64Coin(reserveToken, 9)
+	tenReserveTokens := sdk.NewInt64Coin(reserveToken, 10)
+	err := addCoinsToUser(app, ctx, sdk.Coins{nineReserveTokens})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Perform swap
+	msg := types.NewMsgSwap(userAddress, token, tenReserveTokens, reserveToken2)
+	_, err = h(ctx, msg)
+
+	require.Error(t, err)
+}
+
+func TestSwapValidAmount(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Perform swap
+	_, err = h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 10))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(89990), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(90008), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(10009), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(9992), reserveBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken))
+}
+
+func TestSwapWeightedSwapperBond(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with weights res=0.5, rez=0.25, rec=0.25
+	_, err := h(ctx, newValidMsgCreateWeightedSwapperBond())
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+		sdk.NewInt64Coin(reserveToken3, 100000),
+	)
+	err = addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens, initialising the reserves
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+		sdk.NewInt64Coin(reserveToken3, 10000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Perform swap: 10res - 1res fee = 9res in, and since res has twice the
+	// weight of rez, 10000*(1-(10000/10009)^2) = 17.97... = 17rez out
+	_, err = h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 10))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.Equal(t, int64(89990), userBalance.AmountOf(reserveToken).Int64())
+	require.Equal(t, int64(90017), userBalance.AmountOf(reserveToken2).Int64())
+	require.Equal(t, int64(10009), reserveBalance.AmountOf(reserveToken).Int64())
+	require.Equal(t, int64(9983), reserveBalance.AmountOf(reserveToken2).Int64())
+	require.Equal(t, int64(10000), reserveBalance.AmountOf(reserveToken3).Int64())
+}
+
+func TestSwapStableSwapBond(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with amplification A=100
+	_, err := h(ctx, newValidMsgCreateStableSwapBond())
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000000),
+		sdk.NewInt64Coin(reserveToken2, 1000000),
+	)
+	err = addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens, initialising the reserves
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Perform swap: 1000res - 1res fee = 999res in, and close to the peg the
+	// stableswap invariant gives 998rez out (vs. 989rez for a swapper)
+	_, err = h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.Equal(t, int64(899000), userBalance.AmountOf(reserveToken).Int64())
+	require.Equal(t, int64(900998), userBalance.AmountOf(reserveToken2).Int64())
+	require.Equal(t, int64(100999), reserveBalance.AmountOf(reserveToken).Int64())
+	require.Equal(t, int64(99002), reserveBalance.AmountOf(reserveToken2).Int64())
+}
+
+func TestBuyingAPiecewiseBondAcrossSegments(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with a flat price of 10 until 100, then a price of x
+	_, err := h(ctx, newValidMsgCreatePiecewiseBond())
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000000)))
+	require.Nil(t, err)
+
+	// Buy 50 tokens within the first segment
+	_, err = h(ctx, newValidMsgBuy(50, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Reserve is 50*10
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.Equal(t, int64(500), reserveBalance.AmountOf(reserveToken).Int64())
+
+	// Buy 100 more tokens, crossing into the second segment
+	_, err = h(ctx, newValidMsgBuy(100, 10000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Reserve is 100*10 + (150^2 - 100^2)/2
+	reserveBalance = app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.Equal(t, int64(7250), reserveBalance.AmountOf(reserveToken).Int64())
+
+	// Current price is that of the second segment
+	bond := app.BondsKeeper.MustGetBond(ctx, initToken)
+	prices, err := bond.GetCurrentPricesPT(reserveBalance)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(150), prices.AmountOf(reserveToken))
+}
+
+func TestSwapValidAmountReversed(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Perform swap
+	_, err = h(ctx, newValidMsgSwap(reserveToken2, reserveToken, 10))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(90008), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(89990), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(9992), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(10009), reserveBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken2))
+}
+
+type mockOracleKeeper struct {
+	base  string
+	quote string
+	rate  sdk.Dec
+}
+
+func (ok mockOracleKeeper) GetExchangeRate(_ sdk.Context, base, quote string) (sdk.Dec, bool) {
+	if base != ok.base || quote != ok.quote {
+		return sdk.Dec{}, false
+	}
+	return ok.rate, true
+}
+
+// createSwapperBondWithSanityRate creates a swapper bond with a 1:1 reserve of
+// 10000 of each reserve token, a sanity rate of 1, and a sanity margin of 10%,
+// and gives the creator and the user 100000 of each reserve token.
+func createSwapperBondWithSanityRate(t *testing.T, app *simapp.BondsApp, ctx sdk.Context, h sdk.Handler) {
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	require.Nil(t, addCoinsToUser(app, ctx, coins))
+	_, err := app.BankKeeper.AddCoins(ctx, initCreator, coins)
+	require.Nil(t, err)
+
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	_, err = h(ctx, types.NewMsgSetSanityRate(token, sdk.OneDec(),
+		sdk.NewDec(10), initCreator, initSigners))
+	require.NoError(t, err)
+}
+
+func TestRebalanceSwapWithoutOracleRateFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createSwapperBondWithSanityRate(t, app, ctx, h)
+
+	from := sdk.NewInt64Coin(reserveToken, 2000)
+	msg := types.NewMsgRebalanceSwap(initCreator, token, from, reserveToken2, initSigners)
+	_, err := h(ctx, msg)
+
+	require.Error(t, err)
+	require.True(t, types.ErrOracleRateUnavailable.Is(err))
+}
+
+func TestRebalanceSwapWithOracleRateWithinSanityBandFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	keeper := app.BondsKeeper
+	keeper.SetOracleKeeper(mockOracleKeeper{
+		base:  reserveToken,
+		quote: reserveToken2,
+		rate:  sdk.MustNewDecFromStr("1.05"),
+	})
+	h := bonds.NewHandler(keeper)
+	createSwapperBondWithSanityRate(t, app, ctx, h)
+
+	from := sdk.NewInt64Coin(reserveToken, 2000)
+	msg := types.NewMsgRebalanceSwap(initCreator, token, from, reserveToken2, initSigners)
+	_, err := h(ctx, msg)
+
+	require.Error(t, err)
+	require.True(t, types.ErrOracleRateWithinSanityBand.Is(err))
+}
+
+func TestRebalanceSwapByNonSignersFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	keeper := app.BondsKeeper
+	keeper.SetOracleKeeper(mockOracleKeeper{
+		base:  reserveToken,
+		quote: reserveToken2,
+		rate:  sdk.MustNewDecFromStr("1.44"),
+	})
+	h := bonds.NewHandler(keeper)
+	createSwapperBondWithSanityRate(t, app, ctx, h)
+
+	from := sdk.NewInt64Coin(reserveToken, 2000)
+	signers := []sdk.AccAddress{userAddress}
+	msg := types.NewMsgRebalanceSwap(userAddress, token, from, reserveToken2, signers)
+	_, err := h(ctx, msg)
+
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInvalidAddress.Is(err))
+}
+
+func TestRebalanceSwapCanExceedSanityBandUpToOracleRate(t *testing.T) {
+	app, ctx := createTestApp(false)
+	keeper := app.BondsKeeper
+	oracleRate := sdk.MustNewDecFromStr("1.44")
+	keeper.SetOracleKeeper(mockOracleKeeper{
+		base:  reserveToken,
+		quote: reserveToken2,
+		rate:  oracleRate,
+	})
+	h := bonds.NewHandler(keeper)
+	createSwapperBondWithSanityRate(t, app, ctx, h)
+
+	// A normal swap that moves the rate to ~1.44 violates the sanity rate
+	from := sdk.NewInt64Coin(reserveToken, 2000)
+	_, err := h(ctx, types.NewMsgSwap(userAddress, token, from, reserveToken2))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, token)
+	require.Equal(t, sdk.NewInt(10000), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(10000), reserveBalance.AmountOf(reserveToken2))
+
+	// The same swap as a rebalance swap is checked around the oracle rate
+	msg := types.NewMsgRebalanceSwap(initCreator, token, from, reserveToken2, initSigners)
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	reserveBalance = app.BondsKeeper.GetReserveBalances(ctx, token)
+	require.Equal(t, sdk.NewInt(11998), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(8335), reserveBalance.AmountOf(reserveToken2))
+
+	// The oracle rate used is included in the fulfilment event
+	var found bool
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type != types.EventTypeOrderFulfill {
+			continue
+		}
+		for _, a := range e.Attributes {
+			if string(a.Key) == types.AttributeKeyOracleRate {
+				require.Equal(t, oracleRate.String(), string(a.Value))
+				found = true
+			}
+		}
+	}
+	require.True(t, found)
+
+	// A rebalance swap cannot move the rate beyond the margin around the
+	// oracle rate (i.e. above ~1.58)
+	from = sdk.NewInt64Coin(reserveToken, 1500)
+	msg = types.NewMsgRebalanceSwap(initCreator, token, from, reserveToken2, initSigners)
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	reserveBalance = app.BondsKeeper.GetReserveBalances(ctx, token)
+	require.Equal(t, sdk.NewInt(11998), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(8335), reserveBalance.AmountOf(reserveToken2))
+}
+
+func TestMakeOutcomePayment(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with 100k outcome payment
+	bondMsg := newValidMsgCreateBond()
+	bondMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
+	h(ctx, bondMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	require.Nil(t, err)
+
+	// Make outcome payment
+	_, err = h(ctx, newValidMsgMakeOutcomePayment())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Check that outcome payment is now in the bond reserve
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.NoError(t, err)
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(100000), reserveBalance.AmountOf(reserveToken))
+
+	// Check that the bond is now in SETTLE state
+	require.Equal(t, types.SettleState, app.BondsKeeper.MustGetBond(ctx, token).State)
+}
+
+func TestWithdrawShare(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Set bond current supply to 3 and state to SETTLE
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	bond.CurrentSupply = sdk.NewCoin(bond.Token, sdk.NewInt(3))
+	bond.State = types.SettleState
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Mint 3 bond tokens and send [2 to user 1] and [1 to user 2]
+	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount,
+		sdk.NewCoins(sdk.NewInt64Coin(token, 3)))
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.BondsMintBurnAccount,
+		userAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 2)))
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.BondsMintBurnAccount,
+		anotherAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 1)))
+	require.Nil(t, err)
+
+	// Simulate outcome payment by depositing (freshly minted) 100k into reserve
+	hundredK := sdk.NewCoins(sdk.NewCoin(reserveToken, sdk.NewInt(100000)))
+	err = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, hundredK)
+	require.Nil(t, err)
+	err = app.BondsKeeper.DepositReserveFromModule(
+		ctx, bond.Token, types.BondsMintBurnAccount, hundredK)
+	require.Nil(t, err)
+
+	// User 1 withdraws share
+	_, err = h(ctx, newValidMsgWithdrawShareFrom(userAddress))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// User 1 had 2 tokens out of the supply of 3 tokens, so user 1 gets 2/3
+	user1Balance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(66666), user1Balance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(33334), reserveBalance.AmountOf(reserveToken))
+
+	// Note: rounding is rounded to floor, so despite user 1 being owed 66666.67
+	// tokens, user 1 gets 66666 and not 66667 tokens. Then, since user 2 now owns
+	// the entire share of the bond tokens, they will get 100% of the remaining
+	// 33334 tokens, which is more than what was initially owed (33333.33).
+
+	// User 2 withdraws share
+	_, err = h(ctx, newValidMsgWithdrawShareFrom(anotherAddress))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// User 2 had 1 token out of the remaining supply of 1 token, so user 2 gets all remaining
+	user2Balance := app.BondsKeeper.BankKeeper.GetCoins(ctx, anotherAddress)
+	reserveBalance = app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(33334), user2Balance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), reserveBalance.AmountOf(reserveToken))
+}
+
+func TestAuthorizedTransferOfTransferableBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set transferable bond and give user bond tokens
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(token, 10)})
+	require.Nil(t, err)
+
+	msg := types.NewMsgAuthorizedTransfer(userAddress, anotherAddress,
+		sdk.NewInt64Coin(token, 10), "lost key recovery", initSigners)
+	_, err = h(ctx, msg)
+
+	require.Error(t, err)
+	require.True(t, types.ErrBondTokenIsTransferable.Is(err))
+}
+
+func TestAuthorizedTransferWithDifferentSignersFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set non-transferable bond and give user bond tokens
+	bond := newSimpleBond()
+	bond.NonTransferable = true
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(token, 10)})
+	require.Nil(t, err)
+
+	msg := types.NewMsgAuthorizedTransfer(userAddress, anotherAddress,
+		sdk.NewInt64Coin(token, 10), "lost key recovery",
+		[]sdk.AccAddress{anotherAddress})
+	_, err = h(ctx, msg)
+
+	require.Error(t, err)
+}
+
+func TestAuthorizedTransferExceedingBalanceFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set non-transferable bond and give user bond tokens
+	bond := newSimpleBond()
+	bond.NonTransferable = true
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(token, 10)})
+	require.Nil(t, err)
+
+	msg := types.NewMsgAuthorizedTransfer(userAddress, anotherAddress,
+		sdk.NewInt64Coin(token, 11), "lost key recovery", initSigners)
+	_, err = h(ctx, msg)
+
+	require.Error(t, err)
+}
+
+func TestAuthorizedTransferCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set non-transferable bond and give user bond tokens
+	bond := newSimpleBond()
+	bond.NonTransferable = true
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(token, 10)})
+	require.Nil(t, err)
+
+	msg := types.NewMsgAuthorizedTransfer(userAddress, anotherAddress,
+		sdk.NewInt64Coin(token, 10), "lost key recovery", initSigners)
+	res, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	anotherBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, anotherAddress)
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(10), anotherBalance.AmountOf(token))
+
+	// Transfer is recorded in the events
+	recorded := false
+	for _, e := range res.Events {
+		if e.Type == types.EventTypeAuthorizedTransfer {
+			recorded = true
+		}
+	}
+	require.True(t, recorded)
+}
+
+func TestSchedulingAParamChangeWithDifferentSignersFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	msg := newValidMsgScheduleParamChange(10)
+	msg.Signers = []sdk.AccAddress{anotherAddress}
+	_, err := h(ctx, msg)
+
+	require.Error(t, err)
+	_, found := app.BondsKeeper.GetScheduledParamChange(ctx, token)
+	require.False(t, found)
+}
+
+func TestSchedulingAParamChangeNotInTheFutureFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithBlockHeight(10)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	_, err := h(ctx, newValidMsgScheduleParamChange(10))
+
+	require.Error(t, err)
+	require.True(t, types.ErrInvalidEffectiveHeight.Is(err))
+}
+
+func TestSchedulingAParamChangeWithInvalidParamsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	msg := newValidMsgScheduleParamChange(10)
+	msg.FunctionParameters = functionParametersAugmented()
+	_, err := h(ctx, msg)
+
+	require.Error(t, err)
+	_, found := app.BondsKeeper.GetScheduledParamChange(ctx, token)
+	require.False(t, found)
+}
+
+func TestSchedulingAParamChangeForSwapperBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create swapper bond
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	_, err := h(ctx, newValidMsgScheduleParamChange(10))
+
+	require.Error(t, err)
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
+}
+
+func TestSchedulingASecondParamChangeFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and schedule change
+	h(ctx, newValidMsgCreateBond())
+	_, err := h(ctx, newValidMsgScheduleParamChange(10))
+	require.NoError(t, err)
+
+	_, err = h(ctx, newValidMsgScheduleParamChange(20))
+
+	require.Error(t, err)
+	require.True(t, types.ErrParamChangeAlreadyScheduled.Is(err))
+	change, found := app.BondsKeeper.GetScheduledParamChange(ctx, token)
+	require.True(t, found)
+	require.Equal(t, int64(10), change.EffectiveHeight)
+}
+
+func TestEndBlockerAppliesScheduledParamChangeAtEffectiveHeight(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and schedule change at height 5
+	h(ctx, newValidMsgCreateBond())
+	msg := newValidMsgScheduleParamChange(5)
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Change is not applied before height 5
+	bonds.EndBlocker(ctx.WithBlockHeight(4), app.BondsKeeper)
+	require.Equal(t, functionParametersPower(), app.BondsKeeper.MustGetBond(ctx, token).FunctionParameters)
+
+	// Change is applied (and removed) at height 5
+	bonds.EndBlocker(ctx.WithBlockHeight(5), app.BondsKeeper)
+	require.Equal(t, msg.FunctionParameters, app.BondsKeeper.MustGetBond(ctx, token).FunctionParameters)
+	_, found := app.BondsKeeper.GetScheduledParamChange(ctx, token)
+	require.False(t, found)
+}
+
+func TestEndBlockerInterpolatesScheduledParamChangeUntilEffectiveHeight(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithBlockHeight(0)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and schedule interpolated change from m:12,n:2,c:100 to
+	// m:10,n:2,c:50 at height 10
+	h(ctx, newValidMsgCreateBond())
+	msg := newValidMsgScheduleParamChange(10)
+	msg.Interpolate = true
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Parameters are 40% of the way to the new parameters at height 4
+	bonds.EndBlocker(ctx.WithBlockHeight(4), app.BondsKeeper)
+	expectedParams := types.FunctionParams{
+		types.NewFunctionParam("m", sdk.MustNewDecFromStr("11.2")),
+		types.NewFunctionParam("n", sdk.NewDec(2)),
+		types.NewFunctionParam("c", sdk.NewDec(80))}
+	require.Equal(t, expectedParams, app.BondsKeeper.MustGetBond(ctx, token).FunctionParameters)
+
+	// Change is applied (and removed) at height 10
+	bonds.EndBlocker(ctx.WithBlockHeight(10), app.BondsKeeper)
+	require.Equal(t, msg.FunctionParameters, app.BondsKeeper.MustGetBond(ctx, token).FunctionParameters)
+	_, found := app.BondsKeeper.GetScheduledParamChange(ctx, token)
+	require.False(t, found)
+}
+
+// newMsgScheduleParamChangeRaisingC returns a change to m:6,n:2,c:1000, which
+// is backed by the reserve at a supply of 0 but not at a supply of 10, at
+// which it requires (6/3)*10^3 + 1000*10 = 12000 rather than 5000
+func newMsgScheduleParamChangeRaisingC(effectiveHeight int64) types.MsgScheduleParamChange {
+	msg := newValidMsgScheduleParamChange(effectiveHeight)
+	msg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam("m", sdk.NewDec(6)),
+		types.NewFunctionParam("n", sdk.NewDec(2)),
+		types.NewFunctionParam("c", sdk.NewDec(1000))}
+	return msg
+}
+
+func buyTenTokens(t *testing.T, app *simapp.BondsApp, ctx sdk.Context, h sdk.Handler) {
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(10, 10000))
+	require.NoError(t, err)
+}
+
+func TestSchedulingAParamChangeNotBackedByReserveFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and buy 10 tokens
+	h(ctx, newValidMsgCreateBond())
+	buyTenTokens(t, app, ctx, h)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	_, err := h(ctx, newMsgScheduleParamChangeRaisingC(10))
+
+	require.True(t, types.ErrParamsNotBackedByReserve.Is(err))
+	_, found := app.BondsKeeper.GetScheduledParamChange(ctx, token)
+	require.False(t, found)
+}
+
+func TestEndBlockerSkipsScheduledParamChangeNoLongerBackedByReserve(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithBlockHeight(0)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and schedule change at height 5 while the supply is 0
+	h(ctx, newValidMsgCreateBond())
+	_, err := h(ctx, newMsgScheduleParamChangeRaisingC(5))
+	require.NoError(t, err)
+
+	// Buy 10 tokens, after which the change is no longer backed
+	buyTenTokens(t, app, ctx, h)
+	bonds.EndBlocker(ctx.WithBlockHeight(4), app.BondsKeeper)
+
+	// Change is removed at height 5 without being applied
+	bonds.EndBlocker(ctx.WithBlockHeight(5), app.BondsKeeper)
+	require.Equal(t, functionParametersPower(), app.BondsKeeper.MustGetBond(ctx, token).FunctionParameters)
+	_, found := app.BondsKeeper.GetScheduledParamChange(ctx, token)
+	require.False(t, found)
+
+	_, broken := keeper.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestEndBlockerSkipsInterpolatedParamsNotBackedByReserve(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithBlockHeight(0)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and schedule interpolated change at height 10 while the
+	// supply is 0
+	h(ctx, newValidMsgCreateBond())
+	msg := newMsgScheduleParamChangeRaisingC(10)
+	msg.Interpolate = true
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Buy 10 tokens, after which the parameters interpolated at height 4
+	// (m:9.6,n:2,c:460) require 7800 and are therefore not applied
+	buyTenTokens(t, app, ctx, h)
+	bonds.EndBlocker(ctx.WithBlockHeight(4), app.BondsKeeper)
+	require.Equal(t, functionParametersPower(), app.BondsKeeper.MustGetBond(ctx, token).FunctionParameters)
+
+	_, broken := keeper.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestSchedulingAnInterpolatedChangeOfPowerFunctionNFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	msg := newValidMsgScheduleParamChange(10)
+	msg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam("m", sdk.NewDec(10)),
+		types.NewFunctionParam("n", sdk.NewDec(3)),
+		types.NewFunctionParam("c", sdk.NewDec(50))}
+	msg.Interpolate = true
+	_, err := h(ctx, msg)
+
+	require.Error(t, err)
+	require.True(t, types.ErrCannotInterpolateFunctionParams.Is(err))
+	_, found := app.BondsKeeper.GetScheduledParamChange(ctx, token)
+	require.False(t, found)
+}
+
+func TestCancellingAScheduledParamChangeCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and schedule change at height 5
+	h(ctx, newValidMsgCreateBond())
+	_, err := h(ctx, newValidMsgScheduleParamChange(5))
+	require.NoError(t, err)
+
+	// Cancel change
+	cancelMsg := types.NewMsgCancelParamChange(token, initCreator, initSigners)
+	_, err = h(ctx, cancelMsg)
+	require.NoError(t, err)
+
+	// Change is not applied at height 5
+	bonds.EndBlocker(ctx.WithBlockHeight(5), app.BondsKeeper)
+	require.Equal(t, functionParametersPower(), app.BondsKeeper.MustGetBond(ctx, token).FunctionParameters)
+
+	// Cancelling again fails, since there is nothing to cancel
+	_, err = h(ctx, cancelMsg)
+	require.Error(t, err)
+	require.True(t, types.ErrNoParamChangeScheduled.Is(err))
+}
+
+func TestEndBlockerAppliesReachedMilestones(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with sells disabled and two milestones, the first of
+	// which releases a tranche of 50res and enables sells
+	createMsg := newValidMsgCreateBond()
+	createMsg.AllowSells = false
+	createMsg.Milestones = []types.Milestone{
+		types.NewMilestone(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 200)),
+			sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50)), false, sdk.ZeroDec(), true),
+		types.NewMilestone(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000)),
+			sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50)), false, sdk.ZeroDec(), false),
+	}
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens, for a reserve of 232res (>= 200res)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+	feeAddressBalance := app.BankKeeper.GetCoins(ctx, initFeeAddress).AmountOf(reserveToken)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Only the first milestone was reached and its changes were applied
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, uint64(1), bond.MilestonesReached)
+	require.True(t, bond.AllowSells)
+	require.Equal(t, sdk.NewInt(182), bond.CurrentReserve.AmountOf(reserveToken))
+	require.True(t, app.BankKeeper.GetCoins(ctx, initFeeAddress).AmountOf(reserveToken).GTE(
+		feeAddressBalance.AddRaw(50)))
+
+	// Milestone is not applied again in the next batch
+	h(ctx, newValidMsgBuy(1, 10000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, uint64(1), bond.MilestonesReached)
+}
+
+func TestBondEventsIncludeEventAttributes(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with event attributes
+	createMsg := newValidMsgCreateBond()
+	createMsg.EventAttributes = types.EventAttributes{
+		types.NewEventAttribute("project_id", "did:ixo:abc"),
+	}
+	res1, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy and perform the batch
+	res2, err := h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Every event with a bond attribute also has the event attributes
+	events := append(res1.Events, res2.Events...)
+	events = append(events, ctx.EventManager().Events()...)
+	checked := 0
+	for _, e := range events {
+		attributes := make(map[string]string)
+		for _, a := range e.Attributes {
+			attributes[string(a.Key)] = string(a.Value)
+		}
+		if _, ok := attributes[types.AttributeKeyBond]; ok {
+			require.Equal(t, "did:ixo:abc", attributes["project_id"])
+			checked += 1
+		}
+	}
+	require.True(t, checked >= 3) // create_bond, buy, and order_fulfill
+}
+
+func TestBuyOrderEventsIncludeCallbackPayload(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and add reserve tokens to user
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy with a callback payload and without one, and perform the batch
+	buyMsg := newValidMsgBuy(2, 10000)
+	buyMsg.CallbackPayload = "workflow-42"
+	res1, err := h(ctx, buyMsg)
+	require.NoError(t, err)
+	res2, err := h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Only the buy and order_fulfill events of the first order have a payload
+	events := append(res1.Events, res2.Events...)
+	events = append(events, ctx.EventManager().Events()...)
+	var withPayload, withoutPayload []string
+	for _, e := range events {
+		if e.Type != types.EventTypeBuy && e.Type != types.EventTypeOrderFulfill {
+			continue
+		}
+		attributes := make(map[string]string)
+		for _, a := range e.Attributes {
+			attributes[string(a.Key)] = string(a.Value)
+		}
+		if payload, ok := attributes[types.AttributeKeyCallbackPayload]; ok {
+			require.Equal(t, "workflow-42", payload)
+			withPayload = append(withPayload, e.Type)
+		} else {
+			withoutPayload = append(withoutPayload, e.Type)
+		}
+	}
+	expected := []string{types.EventTypeBuy, types.EventTypeOrderFulfill}
+	require.ElementsMatch(t, expected, withPayload)
+	require.ElementsMatch(t, expected, withoutPayload)
+
+	// The payload is kept in the last batch's buy order
+	lastBatch := app.BondsKeeper.MustGetLastBatch(ctx, token)
+	require.Equal(t, "workflow-42", lastBatch.Buys[0].CallbackPayload)
+	require.Equal(t, "", lastBatch.Buys[1].CallbackPayload)
+}
+
+func TestSellOrderEventsIncludeMemo(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond, add reserve tokens to user, and buy 4 tokens
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(4, 10000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell with a memo and perform the batch
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	sellMsg := newValidMsgSell(2)
+	sellMsg.Memo = "ref-0042"
+	res, err := h(ctx, sellMsg)
+	require.NoError(t, err)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Both the sell and the order_fulfill events include the memo
+	var withMemo []string
+	for _, e := range append(res.Events, ctx.EventManager().Events()...) {
+		for _, a := range e.Attributes {
+			if string(a.Key) == types.AttributeKeyMemo {
+				require.Equal(t, "ref-0042", string(a.Value))
+				withMemo = append(withMemo, e.Type)
+			}
+		}
+	}
+	expected := []string{types.EventTypeSell, types.EventTypeOrderFulfill}
+	require.ElementsMatch(t, expected, withMemo)
+
+	// The memo is kept in the last batch's sell order
+	lastBatch := app.BondsKeeper.MustGetLastBatch(ctx, token)
+	require.Equal(t, "ref-0042", lastBatch.Sells[0].Memo)
+}
+
+func TestCancelledSwapOrderEventIncludesMemo(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createSwapperBondWithSanityRate(t, app, ctx, h)
+
+	// Swap with a memo that violates the sanity rate and perform the batch
+	from := sdk.NewInt64Coin(reserveToken, 2000)
+	swapMsg := types.NewMsgSwap(userAddress, token, from, reserveToken2)
+	swapMsg.Memo = "ref-0043"
+	_, err := h(ctx, swapMsg)
+	require.NoError(t, err)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// The swap was cancelled and its order_cancel event includes the memo
+	var cancelEvents int
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type != types.EventTypeOrderCancel {
+			continue
+		}
+		attributes := make(map[string]string)
+		for _, a := range e.Attributes {
+			attributes[string(a.Key)] = string(a.Value)
+		}
+		require.Equal(t, types.AttributeValueSwapOrder, attributes[types.AttributeKeyOrderType])
+		require.Equal(t, "ref-0043", attributes[types.AttributeKeyMemo])
+		cancelEvents += 1
+	}
+	require.Equal(t, 1, cancelEvents)
+
+	// The memo is kept in the last batch's swap order and cancelled orders
+	lastBatch := app.BondsKeeper.MustGetLastBatch(ctx, token)
+	require.True(t, lastBatch.Swaps[0].IsCancelled())
+	require.Equal(t, "ref-0043", lastBatch.Swaps[0].Memo)
+}
+
+func TestCancelledOrdersOfAccountRefundedTogether(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createSwapperBondWithSanityRate(t, app, ctx, h)
+	balanceBefore := app.BankKeeper.GetCoins(ctx, userAddress)
+
+	// Two swaps that violate the sanity rate and perform the batch
+	from := sdk.NewInt64Coin(reserveToken, 2000)
+	_, err := h(ctx, types.NewMsgSwap(userAddress, token, from, reserveToken2))
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgSwap(userAddress, token, from, reserveToken2))
+	require.NoError(t, err)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Both swaps were cancelled and refunded by a single refund
+	var refundEvents int
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type != types.EventTypeRefund {
+			continue
+		}
+		attributes := make(map[string]string)
+		for _, a := range e.Attributes {
+			attributes[string(a.Key)] = string(a.Value)
+		}
+		require.Equal(t, userAddress.String(), attributes[types.AttributeKeyAddress])
+		require.Equal(t, "4000res", attributes[types.AttributeKeyAmount])
+		require.Equal(t, "2", attributes[types.AttributeKeyOrders])
+		refundEvents += 1
+	}
+	require.Equal(t, 1, refundEvents)
+	require.Equal(t, balanceBefore, app.BankKeeper.GetCoins(ctx, userAddress))
+
+	// Nothing is left pending once the batch has been settled
+	_, found := app.BondsKeeper.GetPendingRefunds(ctx, token)
+	require.False(t, found)
+}
+
+func TestEndBlockerAppliesMilestoneThetaDuringHatchPhase(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create augmented bond (theta=0.4) with a milestone reducing theta to 0.2
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.Milestones = []types.Milestone{
+		types.NewMilestone(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)),
+			sdk.NewCoins(), true, sdk.MustNewDecFromStr("0.2"), false),
+	}
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 20000 tokens, for a raise of 200res and a reserve of 120res
+	_, err = h(ctx, newValidMsgBuy(20000, 100000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Theta and R0 were updated, and the bond is still in the hatch phase
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, uint64(1), bond.MilestonesReached)
+	require.Equal(t, types.HatchState, bond.State)
+	require.Equal(t, sdk.MustNewDecFromStr("0.2"), bond.FunctionParameters.AsMap()["theta"])
+	require.Equal(t, sdk.NewDec(400), bond.FunctionParameters.AsMap()["R0"])
+}
+
+func TestCreateBondBurnsCreationFee(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set a creation fee of 10stake to be burned
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10))
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		fee, types.CreationFeeDestinationBurn,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+	err := mintCoinsToCreator(app, ctx, sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)})
+	require.Nil(t, err)
+
+	// Create bond
+	_, err = h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+
+	// Creation fee was charged to the creator and burned
+	require.Equal(t, sdk.NewInt(90), app.BankKeeper.GetCoins(ctx, initCreator).AmountOf(sdk.DefaultBondDenom))
+	require.Equal(t, sdk.NewInt(90), app.SupplyKeeper.GetSupply(ctx).GetTotal().AmountOf(sdk.DefaultBondDenom))
+}
+
+func TestCreateBondSendsCreationFeeToCommunityPool(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set a creation fee of 10stake to be sent to the community pool
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10))
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		fee, types.CreationFeeDestinationCommunityPool,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+	err := mintCoinsToCreator(app, ctx, sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)})
+	require.Nil(t, err)
+	communityPool := app.BondsKeeper.DistrKeeper.GetFeePoolCommunityCoins(ctx)
+
+	// Create bond
+	_, err = h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+
+	// Creation fee was charged to the creator and sent to the community pool
+	require.Equal(t, sdk.NewInt(90), app.BankKeeper.GetCoins(ctx, initCreator).AmountOf(sdk.DefaultBondDenom))
+	require.Equal(t, communityPool.Add(sdk.NewDecCoinsFromCoins(fee...)...),
+		app.BondsKeeper.DistrKeeper.GetFeePoolCommunityCoins(ctx))
+	require.Equal(t, sdk.NewInt(100), app.SupplyKeeper.GetSupply(ctx).GetTotal().AmountOf(sdk.DefaultBondDenom))
+}
+
+func TestCreateBondWithInsufficientCreationFeeFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set a creation fee of 10stake and give the creator only 5stake
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10))
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		fee, types.CreationFeeDestinationBurn,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+	err := mintCoinsToCreator(app, ctx, sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)})
+	require.Nil(t, err)
+
+	// Create bond
+	_, err = h(ctx, newValidMsgCreateBond())
+	require.Error(t, err)
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+}
+
+func TestCreateBondWithNameExceedingMaxNameLengthParamFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Lower the max name length to less than the length of initName
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		uint64(len(initName)-1), types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Create bond
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.Error(t, err)
+	require.True(t, types.ErrArgumentTooLong.Is(err))
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+}
+
+func TestCreateBondWithBatchBlocksOutsideBatchBlocksParamsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Raise the min batch blocks to more than the batch blocks of the bond
+	msg := newValidMsgCreateBond()
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		initBatchBlocks.Uint64()+1, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Create bond
+	_, err := h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrArgumentMustBeBetween.Is(err))
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+
+	// Lower the max batch blocks to less than the batch blocks of the bond
+	msg.BatchBlocks = sdk.NewUint(10)
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, 5,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Create bond
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrArgumentMustBeBetween.Is(err))
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+}
+
+func TestEditingABondWithDescriptionExceedingMaxDescriptionLengthParamFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set bond to simulate creation
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
+
+	// Lower the max description length to less than the new description
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, 5,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Edit bond
+	msg := types.NewMsgEditBond(token, initCreator, initSigners)
+	msg.Description = stringPtr("a longer description")
+	_, err := h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrArgumentTooLong.Is(err))
+	require.Equal(t, newSimpleBond().Description, app.BondsKeeper.MustGetBond(ctx, token).Description)
+}
+
+func TestSettingBondTranslationsCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set bond to simulate creation
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
+
+	// Set translations
+	translations := types.BondTranslations{
+		types.NewBondTranslation("fr", "Nom", "Description en français"),
+		types.NewBondTranslation("pt-BR", "Nome", "Descrição em português"),
+	}
+	msg := types.NewMsgSetBondTranslations(token, translations, initCreator, initSigners)
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, translations, app.BondsKeeper.MustGetBond(ctx, token).Translations)
+
+	// Setting an empty list removes all translations
+	msg = types.NewMsgSetBondTranslations(token, nil, initCreator, initSigners)
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	require.Empty(t, app.BondsKeeper.MustGetBond(ctx, token).Translations)
+}
+
+func TestSettingBondTranslationsWithWrongSignersFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set bond to simulate creation
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
+
+	// Set translations with a different list of signers
+	translations := types.BondTranslations{
+		types.NewBondTranslation("fr", "Nom", "Description"),
+	}
+	msg := types.NewMsgSetBondTranslations(token, translations,
+		anotherAddress, []sdk.AccAddress{anotherAddress})
+	_, err := h(ctx, msg)
 	require.Error(t, err)
-	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+	require.Empty(t, app.BondsKeeper.MustGetBond(ctx, token).Translations)
 }
 
-func TestSwapOrderInvalidReserveDenomsFails(t *testing.T) {
+func TestSettingBondTranslationsExceedingMaxNameLengthParamFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Set bond to simulate creation
+	app.BondsKeeper.SetBond(ctx, token, newSimpleBond())
+
+	// Lower the max name length to less than the translated name
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		2, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Set translations
+	translations := types.BondTranslations{
+		types.NewBondTranslation("fr", "Nom", "Description"),
+	}
+	msg := types.NewMsgSetBondTranslations(token, translations, initCreator, initSigners)
+	_, err := h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrArgumentTooLong.Is(err))
+}
+
+func TestSubmitBondProposalWithBondGovernanceDisabledFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with bond governance disabled (zero voting blocks)
+	h(ctx, newValidMsgCreateBond())
+
+	// Add bond tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(token, 10)})
+	require.Nil(t, err)
+
+	// Submit proposal
+	_, err = h(ctx, newValidMsgSubmitBondProposal(1))
+	require.Error(t, err)
+	require.True(t, types.ErrBondGovernanceDisabled.Is(err))
+	require.Empty(t, app.BondsKeeper.GetBondProposals(ctx))
+}
+
+func TestVoteBondProposalHoldsBondTokensUntilTally(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with a proposal voting period of 5 blocks
+	createMsg := newValidMsgCreateBond()
+	createMsg.ProposalVotingBlocks = 5
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Buy 10 tokens
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(10, 1000000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Submit proposal and vote yes
+	_, err = h(ctx, newValidMsgSubmitBondProposal(1))
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgVoteBondProposal(1, userAddress, types.VoteOptionYes))
+	require.NoError(t, err)
+
+	// Bond tokens are held by the module and cannot be used to vote again
+	require.True(t, app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).IsZero())
+	proposal, found := app.BondsKeeper.GetBondProposal(ctx, 1)
+	require.True(t, found)
+	require.Equal(t, sdk.NewInt(10), proposal.YesVotes)
+	_, err = h(ctx, types.NewMsgVoteBondProposal(1, userAddress, types.VoteOptionNo))
+	require.Error(t, err)
+	require.True(t, types.ErrAlreadyVotedOnBondProposal.Is(err))
+}
+
+func TestEndBlockerTalliesAndExecutesPassedFundingProposal(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with a proposal voting period of 5 blocks
+	createMsg := newValidMsgCreateBond()
+	createMsg.ProposalVotingBlocks = 5
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Buy 10 tokens
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(10, 1000000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	reserveBefore := app.BondsKeeper.MustGetBond(ctx, token).CurrentReserve
+
+	// Submit funding proposal for 100res and vote yes
+	_, err = h(ctx, newValidMsgSubmitBondProposal(100))
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgVoteBondProposal(1, userAddress, types.VoteOptionYes))
+	require.NoError(t, err)
+
+	// Proposal is not tallied before its voting period ends
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	proposal, _ := app.BondsKeeper.GetBondProposal(ctx, 1)
+	require.Equal(t, types.BondProposalStatusVoting, proposal.Status)
+
+	// Proposal passes and is executed at the end of its voting period
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 5)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	proposal, _ = app.BondsKeeper.GetBondProposal(ctx, 1)
+	require.Equal(t, types.BondProposalStatusPassed, proposal.Status)
+	require.Equal(t, sdk.NewInt(100), app.BankKeeper.GetCoins(ctx, anotherAddress).AmountOf(reserveToken))
+	require.Equal(t, reserveBefore.AmountOf(reserveToken).SubRaw(100),
+		app.BondsKeeper.MustGetBond(ctx, token).CurrentReserve.AmountOf(reserveToken))
+
+	// Bond tokens were returned to the voter
+	require.Equal(t, sdk.NewInt(10), app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token))
+	require.Empty(t, app.BondsKeeper.GetBondProposalVotes(ctx, 1))
+}
+
+func TestBuyingWhileOrderSubmissionHaltedFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Halt order submission
+	app.BondsKeeper.SetParams(ctx, types.NewParams(true, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Buy 2 tokens
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.Error(t, err)
+	require.Empty(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys)
+
+	// Resume order submission and buy 2 tokens
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 1)
+}
+
+func TestBuyingExceedingBuySpendCapFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 30000)})
+	require.Nil(t, err)
+
+	// Set a buy spend cap of 15000res per 10 blocks
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 15000)), 10,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Buy 2 tokens with max prices of 10000res
+	ctx = ctx.WithBlockHeight(1)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000)),
+		app.BondsKeeper.GetSpentInWindow(ctx, userAddress))
+
+	// Buying 2 more tokens with max prices of 10000res within the window fails
+	ctx = ctx.WithBlockHeight(10)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.Error(t, err)
+	require.True(t, types.ErrSpendCapExceeded.Is(err))
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 1)
+
+	// Buying 2 more tokens once the first buy is outside of the window passes
+	ctx = ctx.WithBlockHeight(11)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 2)
+}
+
+func TestBuySpendCapOnlyCountsAmountChargedByBuys(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 30000)})
+	require.Nil(t, err)
+
+	// Set a buy spend cap of 15000res per 10 blocks
+	params := app.BondsKeeper.GetParams(ctx)
+	params.BuySpendCap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 15000))
+	params.SpendCapWindowBlocks = 10
+	app.BondsKeeper.SetParams(ctx, params)
+
+	// Buy 2 tokens with max prices of 10000res, which are counted in full
+	// until the buy is performed
+	ctx = ctx.WithBlockHeight(1)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000)),
+		app.BondsKeeper.GetSpentInWindow(ctx, userAddress))
+
+	// Once the buy is performed, only the amount charged is counted
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	refund := app.BondsKeeper.MustGetLastBatch(ctx, token).Buys[0].Refund
+	require.False(t, refund.IsZero())
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000)).Sub(refund),
+		app.BondsKeeper.GetSpentInWindow(ctx, userAddress))
+
+	// So buying 2 more tokens with max prices of 10000res within the window
+	// no longer exceeds the cap
+	ctx = ctx.WithBlockHeight(2)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+}
+
+func TestSellingWhileOrderSubmissionHaltedFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Halt order submission
+	app.BondsKeeper.SetParams(ctx, types.NewParams(true, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Sell 2 tokens
+	_, err = h(ctx, newValidMsgSell(2))
+	require.Error(t, err)
+	require.Empty(t, app.BondsKeeper.MustGetBatch(ctx, token).Sells)
+}
+
+func TestSwapWhileOrderSubmissionHaltedFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
@@ -650,320 +3512,622 @@ func TestSwapOrderInvalidReserveDenomsFails(t *testing.T) {
 	h(ctx, buyMsg)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// Perform swap (invalid instead of reserveToken)
-	_, err = h(ctx, newValidMsgSwap("invalid", reserveToken2, 10))
-	bonds.EndBlocker(ctx, app.BondsKeeper)
+	// Halt order submission
+	app.BondsKeeper.SetParams(ctx, types.NewParams(true, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
 
-	userBalance := app.AccountKeeper.GetAccount(ctx, userAddress).GetCoins()
+	// Perform swap
+	_, err = h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 10))
 	require.Error(t, err)
+	require.Empty(t, app.BondsKeeper.MustGetBatch(ctx, token).Swaps)
+}
+
+func TestEndBlockerPerformsExistingOrdersWhileOrderSubmissionHalted(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens and then halt order submission before the batch ends
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	app.BondsKeeper.SetParams(ctx, types.NewParams(true, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Order was still performed and the remainder refunded
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(3767), userBalance.AmountOf(reserveToken))
 	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+}
 
-	// Perform swap (invalid instead of reserveToken2)
-	_, err = h(ctx, newValidMsgSwap(reserveToken, "invalid", 10))
+func TestDecrementRemainingBlocksCountAfterEndBlock(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	two := sdk.NewUint(2)
+	one := sdk.NewUint(1)
+
+	// Create bond
+	createMsg := newValidMsgCreateBond()
+	createMsg.BatchBlocks = two
+	h(ctx, createMsg)
+
+	require.Equal(t, two, app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, one, app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+}
+
+func TestEndBlockerDoesNotPerformOrdersBeforeASpecifiedNumberOfBlocks(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with batch blocks set to 2
+	createMsg := newValidMsgCreateBond()
+	createMsg.BatchBlocks = sdk.NewUint(2)
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 4 tokens
+	h(ctx, newValidMsgBuy(2, 10000))
+	h(ctx, newValidMsgBuy(2, 10000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	require.Equal(t, len(app.BondsKeeper.MustGetBatch(ctx, token).Buys), 2)
+}
+
+func TestEndBlockerPerformsOrdersAfterASpecifiedNumberOfBlocks(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	createMsg := newValidMsgCreateBond()
+	createMsg.BatchBlocks = sdk.NewUint(3)
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 4 tokens
+	h(ctx, newValidMsgBuy(2, 10000))
+	h(ctx, newValidMsgBuy(2, 10000))
+
+	// Run EndBlocker for N times, where N = BatchBlocks
+	batchBlocksInt := int(createMsg.BatchBlocks.Uint64())
+	for i := 0; i <= batchBlocksInt; i++ {
+		bonds.EndBlocker(ctx, app.BondsKeeper)
+	}
+
+	// Buys have been performed
+	require.Equal(t, 0, len(app.BondsKeeper.MustGetBatch(ctx, token).Buys))
+}
+
+type mockUpgradeKeeper struct {
+	plan upgrade.Plan
+}
+
+func (m mockUpgradeKeeper) GetUpgradePlan(_ sdk.Context) (upgrade.Plan, bool) {
+	return m.plan, true
+}
+
+func TestBeginBlockerSettlesPendingBatchBeforeUpgrade(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithBlockHeight(100)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	createMsg := newValidMsgCreateBond()
+	createMsg.BatchBlocks = sdk.NewUint(10)
+	_, err := h(ctx, createMsg)
+	require.Nil(t, err)
+
+	// Add reserve tokens to user and buy 2 tokens
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.Nil(t, err)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	userBalance = app.AccountKeeper.GetAccount(ctx, userAddress).GetCoins()
+	// Schedule upgrade within the bond's batch blocks
+	app.BondsKeeper.SetUpgradeKeeper(mockUpgradeKeeper{
+		upgrade.Plan{Name: "upgrade", Height: 105}})
+	h = bonds.NewHandler(app.BondsKeeper)
+
+	// In the next block, the pending buy is performed and new buys rejected
+	ctx = ctx.WithBlockHeight(101)
+	bonds.BeginBlocker(ctx, app.BondsKeeper)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
 	require.Error(t, err)
-	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.True(t, types.ErrOrdersFrozenForUpgrade.Is(err))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	require.Equal(t, 0, len(app.BondsKeeper.MustGetBatch(ctx, token).Buys))
+	require.Equal(t, int64(2), app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64())
 }
 
-func TestSwapOrderQuantityLimitExceededFails(t *testing.T) {
+func TestSimulateBatchMatchesEndBlockerWithoutCommitting(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
+	querier := bonds.NewQuerier(app.BondsKeeper)
 
-	// Create bond with order quantity limit
-	createMsg := newValidMsgCreateSwapperBond()
-	createMsg.OrderQuantityLimits = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 4))
+	// Create bond
+	createMsg := newValidMsgCreateBond()
+	createMsg.BatchBlocks = sdk.NewUint(3)
 	h(ctx, createMsg)
 
 	// Add reserve tokens to user
-	coins := sdk.NewCoins(
-		sdk.NewInt64Coin(reserveToken, 100000),
-		sdk.NewInt64Coin(reserveToken2, 100000),
-	)
-	err := addCoinsToUser(app, ctx, coins)
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
 	require.Nil(t, err)
 
-	// Buy 2 tokens
-	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
-	buyMsg.MaxPrices = sdk.NewCoins(
-		sdk.NewInt64Coin(reserveToken, 10000),
-		sdk.NewInt64Coin(reserveToken2, 10000),
-	)
-	h(ctx, buyMsg)
-	bonds.EndBlocker(ctx, app.BondsKeeper)
-
-	// Perform swap
-	msg := types.NewMsgSwap(userAddress, token, sdk.NewInt64Coin(reserveToken, 5), reserveToken2)
-	_, err = h(ctx, msg)
+	// Buy 4 tokens
+	h(ctx, newValidMsgBuy(2, 10000))
+	h(ctx, newValidMsgBuy(2, 10000))
 
-	userBalance := app.AccountKeeper.GetAccount(ctx, userAddress).GetCoins()
-	require.Error(t, err)
-	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	// Simulate batch
+	res, err := querier(ctx, []string{keeper.QuerySimulateBatch, token}, abci.RequestQuery{})
+	require.NoError(t, err)
+	var simulation types.QuerySimulateBatch
+	types.ModuleCdc.MustUnmarshalJSON(res, &simulation)
+	require.Equal(t, sdk.NewInt64Coin(token, 4), simulation.Result.TotalBought)
+	require.Equal(t, 2, len(simulation.Batch.Buys))
+	require.Equal(t, sdk.NewUint(3), simulation.BlocksRemaining)
+
+	// Nothing was committed
+	require.Equal(t, 2, len(app.BondsKeeper.MustGetBatch(ctx, token).Buys))
+	require.True(t, app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply.IsZero())
+	require.False(t, app.BondsKeeper.LastBatchResultExists(ctx, token))
+
+	// Simulated result matches the actual result once the batch is performed
+	for i := 0; i < int(createMsg.BatchBlocks.Uint64()); i++ {
+		bonds.EndBlocker(ctx, app.BondsKeeper)
+	}
+	result := app.BondsKeeper.MustGetLastBatchResult(ctx, token)
+	require.Equal(t, result.TotalBought, simulation.Result.TotalBought)
+	require.Equal(t, result.BuyPrices, simulation.Result.BuyPrices)
+	require.Equal(t, result.TotalRefunded.String(), simulation.Result.TotalRefunded.String())
 }
 
-func TestSwapInvalidAmount(t *testing.T) {
+func TestEndBlockerRecordsFeesCollectedByBond(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
 	// Create bond
-	h(ctx, newValidMsgCreateSwapperBond())
+	h(ctx, newValidMsgCreateBond())
 
-	// Add reserve tokens to user (but not enough)
-	nineReserveTokens := sdk.NewInt64Coin(reserveToken, 9)
-	tenReserveTokens := sdk.NewInt64Coin(reserveToken, 10)
-	err := addCoinsToUser(app, ctx, sdk.Coins{nineReserveTokens})
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
 	require.Nil(t, err)
 
-	// Buy 2 tokens
-	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
-	buyMsg.MaxPrices = sdk.NewCoins(
-		sdk.NewInt64Coin(reserveToken, 10000),
-		sdk.NewInt64Coin(reserveToken2, 10000),
-	)
-	h(ctx, buyMsg)
+	// Buy 4 tokens and perform batch
+	h(ctx, newValidMsgBuy(4, 10000))
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// Perform swap
-	msg := types.NewMsgSwap(userAddress, token, tenReserveTokens, reserveToken2)
-	_, err = h(ctx, msg)
-
-	require.Error(t, err)
+	// Fees collected by bond match the module-wide fees collected
+	fees := app.BondsKeeper.GetBondFeesCollected(ctx, token)
+	require.False(t, fees.IsZero())
+	require.Equal(t, app.BondsKeeper.GetModuleStats(ctx).TotalFeesCollected, fees)
+	require.Equal(t, fees, app.BankKeeper.GetCoins(ctx, initFeeAddress))
 }
 
-func TestSwapValidAmount(t *testing.T) {
+func TestEndBlockerRecordsBatchAccountingInHistory(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
 	// Create bond
-	h(ctx, newValidMsgCreateSwapperBond())
+	h(ctx, newValidMsgCreateBond())
 
 	// Add reserve tokens to user
-	coins := sdk.NewCoins(
-		sdk.NewInt64Coin(reserveToken, 100000),
-		sdk.NewInt64Coin(reserveToken2, 100000),
-	)
-	err := addCoinsToUser(app, ctx, coins)
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
 	require.Nil(t, err)
 
-	// Buy 2 tokens
-	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
-	buyMsg.MaxPrices = sdk.NewCoins(
-		sdk.NewInt64Coin(reserveToken, 10000),
-		sdk.NewInt64Coin(reserveToken2, 10000),
-	)
-	h(ctx, buyMsg)
-	bonds.EndBlocker(ctx, app.BondsKeeper)
-
-	// Perform swap
-	_, err = h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 10))
+	// Buy 4 tokens and perform batch
+	h(ctx, newValidMsgBuy(4, 10000))
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
-	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
-	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(89990), userBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(90008), userBalance.AmountOf(reserveToken2))
-	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
-	require.Equal(t, sdk.NewInt(10009), reserveBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(9992), reserveBalance.AmountOf(reserveToken2))
-	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken))
+	// The snapshot includes the batch's volumes and the fees collected
+	history := app.BondsKeeper.GetBondHistory(ctx, token)
+	require.Len(t, history, 1)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(token, 4)), history[0].Bought)
+	require.True(t, history[0].Sold.IsZero())
+	require.Equal(t, app.BondsKeeper.GetBondFeesCollected(ctx, token), history[0].FeesCollected)
+	require.False(t, history[0].Prices.Empty())
 }
 
-func TestSwapValidAmountReversed(t *testing.T) {
+func TestEndBlockerSavesLastBatchResult(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
 	// Create bond
-	h(ctx, newValidMsgCreateSwapperBond())
+	h(ctx, newValidMsgCreateBond())
 
 	// Add reserve tokens to user
-	coins := sdk.NewCoins(
-		sdk.NewInt64Coin(reserveToken, 100000),
-		sdk.NewInt64Coin(reserveToken2, 100000),
-	)
-	err := addCoinsToUser(app, ctx, coins)
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
 	require.Nil(t, err)
 
-	// Buy 2 tokens
-	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
-	buyMsg.MaxPrices = sdk.NewCoins(
-		sdk.NewInt64Coin(reserveToken, 10000),
-		sdk.NewInt64Coin(reserveToken2, 10000),
-	)
-	h(ctx, buyMsg)
-	bonds.EndBlocker(ctx, app.BondsKeeper)
+	// Buy 4 tokens
+	h(ctx, newValidMsgBuy(2, 10000))
+	h(ctx, newValidMsgBuy(2, 10000))
 
-	// Perform swap
-	_, err = h(ctx, newValidMsgSwap(reserveToken2, reserveToken, 10))
+	// No last batch result before batch is performed
+	require.False(t, app.BondsKeeper.LastBatchResultExists(ctx, token))
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
-	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
-	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(90008), userBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(89990), userBalance.AmountOf(reserveToken2))
-	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
-	require.Equal(t, sdk.NewInt(9992), reserveBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(10009), reserveBalance.AmountOf(reserveToken2))
-	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken2))
+	// Last batch result summarises performed orders
+	require.True(t, app.BondsKeeper.LastBatchResultExists(ctx, token))
+	result := app.BondsKeeper.MustGetLastBatchResult(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 4), result.TotalBought)
+	require.Equal(t, sdk.NewInt64Coin(token, 0), result.TotalSold)
+	require.Empty(t, result.CancelledOrders)
 }
 
-func TestMakeOutcomePayment(t *testing.T) {
+func TestEndBlockerDefersSellsExceedingNetSellCap(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond with 100k outcome payment
-	bondMsg := newValidMsgCreateBond()
-	bondMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
-	h(ctx, bondMsg)
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
 
 	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
 	require.Nil(t, err)
 
-	// Make outcome payment
-	_, err = h(ctx, newValidMsgMakeOutcomePayment())
+	// Buy 10 tokens
+	h(ctx, newValidMsgBuy(10, 1000000))
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// Check that outcome payment is now in the bond reserve
-	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	// Cap net sells to 2 tokens per batch, from the next batch onwards
+	editMsg := types.NewMsgEditBond(token, initCreator, initSigners)
+	editMsg.NetSellCap = coinPtr(sdk.NewInt64Coin(token, 2))
+	_, err = h(ctx, editMsg)
 	require.NoError(t, err)
-	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(100000), reserveBalance.AmountOf(reserveToken))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// Check that the bond is now in SETTLE state
-	require.Equal(t, types.SettleState, app.BondsKeeper.MustGetBond(ctx, token).State)
+	// Sell 5 tokens; only 2 are sold and the other 3 are deferred
+	_, err = h(ctx, newValidMsgSell(5))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.Equal(t, sdk.NewInt64Coin(token, 8), currentSupply)
+	require.Equal(t, sdk.NewInt64Coin(token, 3), batch.TotalSellAmount)
+	require.Len(t, batch.Sells, 1)
+
+	// Another 2 are sold in the next batch and the last one is deferred
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	batch = app.BondsKeeper.MustGetBatch(ctx, token)
+	currentSupply = app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.Equal(t, sdk.NewInt64Coin(token, 6), currentSupply)
+	require.Equal(t, sdk.NewInt64Coin(token, 1), batch.TotalSellAmount)
+
+	// The last token is sold in the third batch
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	batch = app.BondsKeeper.MustGetBatch(ctx, token)
+	currentSupply = app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.Equal(t, sdk.NewInt64Coin(token, 5), currentSupply)
+	require.Empty(t, batch.Sells)
 }
 
-func TestWithdrawShare(t *testing.T) {
+func TestEndBlockerDefersSellsBelowMinReserve(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
 	// Create bond
 	h(ctx, newValidMsgCreateBond())
 
-	// Set bond current supply to 3 and state to SETTLE
-	bond := app.BondsKeeper.MustGetBond(ctx, token)
-	bond.CurrentSupply = sdk.NewCoin(bond.Token, sdk.NewInt(3))
-	bond.State = types.SettleState
-	app.BondsKeeper.SetBond(ctx, token, bond)
-
-	// Mint 3 bond tokens and send [2 to user 1] and [1 to user 2]
-	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount,
-		sdk.NewCoins(sdk.NewInt64Coin(token, 3)))
-	require.Nil(t, err)
-	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.BondsMintBurnAccount,
-		userAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 2)))
-	require.Nil(t, err)
-	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.BondsMintBurnAccount,
-		anotherAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 1)))
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
 	require.Nil(t, err)
 
-	// Simulate outcome payment by depositing (freshly minted) 100k into reserve
-	hundredK := sdk.NewCoins(sdk.NewCoin(reserveToken, sdk.NewInt(100000)))
-	err = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, hundredK)
-	require.Nil(t, err)
-	err = app.BondsKeeper.DepositReserveFromModule(
-		ctx, bond.Token, types.BondsMintBurnAccount, hundredK)
-	require.Nil(t, err)
+	// Buy 10 tokens
+	// reserveAt(10) = (m/n+1)x^(n+1) + xc = (12/3)(10^(2+1)) + 10(100) = 5000
+	h(ctx, newValidMsgBuy(10, 1000000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// User 1 withdraws share
-	_, err = h(ctx, newValidMsgWithdrawShareFrom(userAddress))
+	// Set min reserve to 3000res, from the next batch onwards
+	editMsg := types.NewMsgEditBond(token, initCreator, initSigners)
+	editMsg.MinReserve = coinsPtr(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 3000)))
+	_, err = h(ctx, editMsg)
 	require.NoError(t, err)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// User 1 had 2 tokens out of the supply of 3 tokens, so user 1 gets 2/3
-	user1Balance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	// Sell 1 token and then 4 tokens; selling all 5 tokens would take the
+	// reserve to reserveAt(5) = 1000, so the second sell is deferred, and
+	// selling 1 token takes the reserve to reserveAt(9) = 3816
+	_, err = h(ctx, newValidMsgSell(1))
 	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(66666), user1Balance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(33334), reserveBalance.AmountOf(reserveToken))
+	_, err = h(ctx, newValidMsgSell(4))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// Note: rounding is rounded to floor, so despite user 1 being owed 66666.67
-	// tokens, user 1 gets 66666 and not 66667 tokens. Then, since user 2 now owns
-	// the entire share of the bond tokens, they will get 100% of the remaining
-	// 33334 tokens, which is more than what was initially owed (33333.33).
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 9), bond.CurrentSupply)
+	require.Equal(t, sdk.NewInt64Coin(reserveToken, 3816), bond.CurrentReserve[0])
+	require.Equal(t, sdk.NewInt64Coin(token, 4), batch.TotalSellAmount)
+	require.Len(t, batch.Sells, 1)
 
-	// User 2 withdraws share
-	_, err = h(ctx, newValidMsgWithdrawShareFrom(anotherAddress))
-	require.NoError(t, err)
+	// The sell keeps being deferred while it would breach the min reserve
 	bonds.EndBlocker(ctx, app.BondsKeeper)
+	batch = app.BondsKeeper.MustGetBatch(ctx, token)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 9), bond.CurrentSupply)
+	require.Equal(t, sdk.NewInt64Coin(token, 4), batch.TotalSellAmount)
 
-	// User 2 had 1 token out of the remaining supply of 1 token, so user 2 gets all remaining
-	user2Balance := app.BondsKeeper.BankKeeper.GetCoins(ctx, anotherAddress)
-	reserveBalance = app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	// The sell is performed in the batch after the one in which the min
+	// reserve is lowered
+	editMsg.MinReserve = coinsPtr(nil)
+	_, err = h(ctx, editMsg)
 	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(33334), user2Balance.AmountOf(reserveToken))
-	require.Equal(t, sdk.ZeroInt(), reserveBalance.AmountOf(reserveToken))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	batch = app.BondsKeeper.MustGetBatch(ctx, token)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 5), bond.CurrentSupply)
+	require.Empty(t, batch.Sells)
 }
 
-func TestDecrementRemainingBlocksCountAfterEndBlock(t *testing.T) {
+func setValueLockedCaps(app *simapp.BondsApp, ctx sdk.Context, maxTotal, maxBond sdk.Coins) {
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, maxTotal, maxBond,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+}
+
+func TestEndBlockerDefersBuysExceedingMaxBondValueLocked(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	two := sdk.NewUint(2)
-	one := sdk.NewUint(1)
+	// Cap each bond's reserve to 10000res
+	setValueLockedCaps(app, ctx, nil, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
 
-	// Create bond
-	createMsg := newValidMsgCreateBond()
-	createMsg.BatchBlocks = two
-	h(ctx, createMsg)
+	// Create bond and add reserve tokens to users
+	h(ctx, newValidMsgCreateBond())
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
 
-	require.Equal(t, two, app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+	// Buy 10 tokens (5000res) and another 10 tokens (29000res); the
+	// second buy is deferred since the reserve would exceed the cap
+	_, err = h(ctx, newValidMsgBuy(10, 1000000))
+	require.NoError(t, err)
+	secondBuy := newValidMsgBuy(10, 1000000)
+	secondBuy.Buyer = anotherAddress
+	_, err = h(ctx, secondBuy)
+	require.NoError(t, err)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
-	require.Equal(t, one, app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), bond.CurrentSupply)
+	require.Equal(t, sdk.NewInt(5000), bond.CurrentReserve.AmountOf(reserveToken))
+	require.Len(t, batch.Buys, 1)
+	require.Equal(t, anotherAddress, batch.Buys[0].Address)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), batch.TotalBuyAmount)
+
+	// The deferred buy's max prices remain in escrow
+	escrowBalance := app.BondsKeeper.GetEscrowBalance(ctx, token)
+	require.Equal(t, sdk.NewInt(1000000), escrowBalance.AmountOf(reserveToken))
+
+	// The buy keeps being deferred while it exceeds the cap
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), bond.CurrentSupply)
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 1)
+
+	// Once the cap is raised, the deferred buy is performed
+	setValueLockedCaps(app, ctx, nil, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 20), bond.CurrentSupply)
+	require.Equal(t, sdk.NewInt(34000), bond.CurrentReserve.AmountOf(reserveToken))
+	require.Empty(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys)
+	require.Equal(t, sdk.NewInt(10), app.BankKeeper.GetCoins(ctx, anotherAddress).AmountOf(token))
 }
 
-func TestEndBlockerDoesNotPerformOrdersBeforeASpecifiedNumberOfBlocks(t *testing.T) {
+func TestEndBlockerDefersLowestPriorityBuysFirst(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond with batch blocks set to 2
-	createMsg := newValidMsgCreateBond()
-	createMsg.BatchBlocks = sdk.NewUint(2)
-	h(ctx, createMsg)
+	// Cap each bond's reserve to 10000res
+	setValueLockedCaps(app, ctx, nil, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
 
-	// Add reserve tokens to user
+	// Create bond and add reserve tokens to users
+	h(ctx, newValidMsgCreateBond())
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000100)})
+	require.Nil(t, err)
+
+	// Buy 10 tokens, followed by another 10 tokens with a priority fee
+	_, err = h(ctx, newValidMsgBuy(10, 1000000))
+	require.NoError(t, err)
+	secondBuy := newValidMsgBuy(10, 1000000)
+	secondBuy.Buyer = anotherAddress
+	secondBuy.PriorityFee = sdk.Coins{sdk.NewInt64Coin(reserveToken, 100)}
+	_, err = h(ctx, secondBuy)
+	require.NoError(t, err)
+
+	// The priority fee is held in escrow along with the max prices
+	require.True(t, app.BankKeeper.GetCoins(ctx, initFeeAddress).IsZero())
+	require.True(t, app.BankKeeper.GetCoins(ctx, anotherAddress).IsZero())
+	escrowBalance := app.BondsKeeper.GetEscrowBalance(ctx, token)
+	require.Equal(t, sdk.NewInt(2000100), escrowBalance.AmountOf(reserveToken))
+
+	// The first buy is deferred since it has the lowest priority
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), bond.CurrentSupply)
+	require.Equal(t, sdk.NewInt(10), app.BankKeeper.GetCoins(ctx, anotherAddress).AmountOf(token))
+	require.Len(t, batch.Buys, 1)
+	require.Equal(t, userAddress, batch.Buys[0].Address)
+
+	// The priority fee was only paid once the buy was fulfilled
+	escrowBalance = app.BondsKeeper.GetEscrowBalance(ctx, token)
+	require.Equal(t, sdk.NewInt(1000000), escrowBalance.AmountOf(reserveToken))
+	feeBalance := app.BankKeeper.GetCoins(ctx, initFeeAddress).AmountOf(reserveToken)
+	require.Equal(t, feeBalance, app.BondsKeeper.GetBondFeesCollected(ctx, token).AmountOf(reserveToken))
+	require.True(t, feeBalance.GT(sdk.NewInt(100)))
+
+	// The priority ordering used is given by the buy_priority event
+	var found bool
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type != types.EventTypeBuyPriority {
+			continue
+		}
+		found = true
+		attributes := make(map[string]string)
+		for _, a := range e.Attributes {
+			attributes[string(a.Key)] = string(a.Value)
+		}
+		require.Equal(t, "["+anotherAddress.String()+","+userAddress.String()+"]",
+			attributes[types.AttributeKeyBuyers])
+		require.Equal(t, "[100,0]", attributes[types.AttributeKeyPriorities])
+	}
+	require.True(t, found)
+}
+
+func TestPriorityFeeOfCancelledBuyIsRefunded(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and add reserve tokens to users
+	h(ctx, newValidMsgCreateBond())
 	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
 	require.Nil(t, err)
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 300)})
+	require.Nil(t, err)
 
-	// Buy 4 tokens
-	h(ctx, newValidMsgBuy(2, 10000))
-	h(ctx, newValidMsgBuy(2, 10000))
-	bonds.EndBlocker(ctx, app.BondsKeeper)
+	// Buy 1 token with a priority fee and a max price of 200res
+	firstBuy := newValidMsgBuy(1, 200)
+	firstBuy.Buyer = anotherAddress
+	firstBuy.PriorityFee = sdk.Coins{sdk.NewInt64Coin(reserveToken, 100)}
+	_, err = h(ctx, firstBuy)
+	require.NoError(t, err)
+	require.True(t, app.BankKeeper.GetCoins(ctx, anotherAddress).IsZero())
 
-	require.Equal(t, len(app.BondsKeeper.MustGetBatch(ctx, token).Buys), 2)
+	// Buying 10 more tokens raises the price above 200res per token, which
+	// cancels the first buy and returns its max price and priority fee
+	_, err = h(ctx, newValidMsgBuy(10, 1000000))
+	require.NoError(t, err)
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.True(t, batch.Buys[0].IsCancelled())
+	require.Equal(t, sdk.NewInt(300), app.BankKeeper.GetCoins(ctx, anotherAddress).AmountOf(reserveToken))
+
+	// The priority fee is never paid to the fee address
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, sdk.NewInt(300), app.BankKeeper.GetCoins(ctx, anotherAddress).AmountOf(reserveToken))
+	feeBalance := app.BankKeeper.GetCoins(ctx, initFeeAddress).AmountOf(reserveToken)
+	require.True(t, feeBalance.LT(sdk.NewInt(100)))
+	require.Equal(t, feeBalance, app.BondsKeeper.GetBondFeesCollected(ctx, token).AmountOf(reserveToken))
+	require.True(t, app.BondsKeeper.GetEscrowBalance(ctx, token).IsZero())
 }
 
-func TestEndBlockerPerformsOrdersAfterASpecifiedNumberOfBlocks(t *testing.T) {
+func TestEndBlockerDefersBuysExceedingMaxTotalValueLocked(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond
-	createMsg := newValidMsgCreateBond()
-	createMsg.BatchBlocks = sdk.NewUint(3)
-	h(ctx, createMsg)
+	// Cap the total reserve of all bonds to 10000res
+	setValueLockedCaps(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)}, nil)
 
-	// Add reserve tokens to user
+	// Create two bonds and add reserve tokens to user
+	h(ctx, newValidMsgCreateBond())
+	bond2Msg := newValidMsgCreateBond()
+	bond2Msg.Token = token2
+	bond2Msg.MaxSupply = sdk.NewCoin(token2, initMaxSupply.Amount)
+	h(ctx, bond2Msg)
 	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
 	require.Nil(t, err)
 
-	// Buy 4 tokens
-	h(ctx, newValidMsgBuy(2, 10000))
-	h(ctx, newValidMsgBuy(2, 10000))
+	// Buy 10 tokens (5000res) of each bond; both fit within the cap
+	_, err = h(ctx, newValidMsgBuy(10, 10000))
+	require.NoError(t, err)
+	bond2Buy := newValidMsgBuy(10, 10000)
+	bond2Buy.Amount = sdk.NewInt64Coin(token2, 10)
+	_, err = h(ctx, bond2Buy)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	totalValueLocked := app.BondsKeeper.GetModuleStats(ctx).TotalValueLocked
+	require.Equal(t, sdk.NewInt(10000), totalValueLocked.AmountOf(reserveToken))
 
-	// Run EndBlocker for N times, where N = BatchBlocks
-	batchBlocksInt := int(createMsg.BatchBlocks.Uint64())
-	for i := 0; i <= batchBlocksInt; i++ {
-		bonds.EndBlocker(ctx, app.BondsKeeper)
-	}
+	// Any further buys are deferred, even though the bond's own reserve is
+	// well within the default (lack of) cap
+	_, err = h(ctx, newValidMsgBuy(1, 10000))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgBuy(1, 10000))
+	require.NoError(t, err)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// Buys have been performed
-	require.Equal(t, 0, len(app.BondsKeeper.MustGetBatch(ctx, token).Buys))
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), bond.CurrentSupply)
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 2)
+
+	var deferrals int
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == types.EventTypeOrderDefer {
+			deferrals++
+		}
+	}
+	require.Equal(t, 2, deferrals)
 }
 
 func TestEndBlockerAugmentedFunction(t *testing.T) {
@@ -1115,3 +4279,626 @@ func TestEndBlockerAugmentedFunctionSmallBuys(t *testing.T) {
 		ctx, bond.FeeAddress).AmountOf(reserveToken).Int64()
 	require.Equal(t, int64(9), feeAddressBalance)
 }
+
+func TestLargeChangeWithinAlertWindowEmitsAlertOncePerWindow(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Enable alerts for changes of more than 50% within 100 blocks
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, sdk.NewDec(50), 100,
+		types.DefaultMaxTotalValueLocked, types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Create bond and add reserve tokens to user
+	h(ctx, newValidMsgCreateBond())
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	supplyAlerts := func(ctx sdk.Context) (count int) {
+		for _, e := range ctx.EventManager().Events() {
+			if e.Type != types.EventTypeBondAlert {
+				continue
+			}
+			for _, a := range e.Attributes {
+				if string(a.Key) == types.AttributeKeyMetric &&
+					string(a.Value) == types.AlertMetricSupply {
+					count++
+				}
+			}
+		}
+		return count
+	}
+
+	// Buy 2 tokens; window starts with zero supply, so no supply alert
+	ctx = ctx.WithBlockHeight(10).WithEventManager(sdk.NewEventManager())
+	_, err = h(ctx, newValidMsgBuy(2, 100000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, 0, supplyAlerts(ctx))
+
+	// Start a new window with a supply of 2
+	ctx = ctx.WithBlockHeight(110).WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	window, found := app.BondsKeeper.GetAlertWindow(ctx, token)
+	require.True(t, found)
+	require.Equal(t, int64(110), window.StartHeight)
+
+	// Buy 1 token (supply +50%), which does not exceed the threshold
+	ctx = ctx.WithBlockHeight(111).WithEventManager(sdk.NewEventManager())
+	_, err = h(ctx, newValidMsgBuy(1, 100000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, 0, supplyAlerts(ctx))
+
+	// Buy 1 more token (supply +100%), which exceeds the threshold
+	ctx = ctx.WithBlockHeight(112).WithEventManager(sdk.NewEventManager())
+	_, err = h(ctx, newValidMsgBuy(1, 100000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, 1, supplyAlerts(ctx))
+
+	// Buy 1 more token, which is not alerted again within the same window
+	ctx = ctx.WithBlockHeight(113).WithEventManager(sdk.NewEventManager())
+	_, err = h(ctx, newValidMsgBuy(1, 100000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, 0, supplyAlerts(ctx))
+}
+
+func TestStressedBatchesLengthenBatchesAndThenDecay(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Enable stress mode for batches with a volume of more than 10% of the
+	// supply, lengthening batches by 5 blocks (up to 8) from the 2nd
+	// consecutive stressed batch
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, sdk.NewDec(10), 2, 5, 8,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+
+	// Create bond and add reserve tokens to user
+	h(ctx, newValidMsgCreateBond())
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	batchIntervalEvents := func(ctx sdk.Context) (count int) {
+		for _, e := range ctx.EventManager().Events() {
+			if e.Type == types.EventTypeBatchInterval {
+				count++
+			}
+		}
+		return count
+	}
+
+	// Settles the current batch (which ends after the effective batch blocks)
+	// with a buy of the amount, if positive
+	settleBatch := func(amount int64) sdk.Context {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		if amount > 0 {
+			_, err := h(ctx, newValidMsgBuy(amount, 100000))
+			require.NoError(t, err)
+		}
+		blocks := app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining.Uint64()
+		for i := uint64(0); i < blocks; i++ {
+			bonds.EndBlocker(ctx, app.BondsKeeper)
+		}
+		return ctx
+	}
+
+	// Buy 2 tokens; the supply was zero, so the batch is not stressed
+	ctx = settleBatch(2)
+	require.True(t, app.BondsKeeper.GetBatchStress(ctx, token).IsZero())
+
+	// Buy 2 tokens (100% of supply); first stressed batch
+	ctx = settleBatch(2)
+	require.Equal(t, types.NewBatchStress(1, 0), app.BondsKeeper.GetBatchStress(ctx, token))
+	require.Equal(t, 0, batchIntervalEvents(ctx))
+
+	// Buy 2 tokens (50% of supply); second stressed batch lengthens batches
+	ctx = settleBatch(2)
+	require.Equal(t, types.NewBatchStress(2, 5), app.BondsKeeper.GetBatchStress(ctx, token))
+	require.Equal(t, 1, batchIntervalEvents(ctx))
+	require.Equal(t, sdk.NewUint(6), app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+
+	// Buy 2 tokens (33% of supply); third stressed batch, capped at 8 blocks
+	ctx = settleBatch(2)
+	require.Equal(t, types.NewBatchStress(3, 8), app.BondsKeeper.GetBatchStress(ctx, token))
+	require.Equal(t, sdk.NewUint(9), app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+
+	// Empty batches are not stressed, so extra blocks decay back to zero
+	ctx = settleBatch(0)
+	require.Equal(t, types.NewBatchStress(0, 4), app.BondsKeeper.GetBatchStress(ctx, token))
+	require.Equal(t, 1, batchIntervalEvents(ctx))
+	ctx = settleBatch(0)
+	ctx = settleBatch(0)
+	ctx = settleBatch(0)
+	require.True(t, app.BondsKeeper.GetBatchStress(ctx, token).IsZero())
+	require.Equal(t, initBatchBlocks, app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+}
+
+func setFeeDustParams(app *simapp.BondsApp, ctx sdk.Context, denom string, sweepBlocks uint64) {
+	app.BondsKeeper.SetParams(ctx, types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked, types.DefaultFeeDustThreshold,
+		denom, sweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+}
+
+func TestSweepFeeDustSendsDustToCommunityPool(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Give the address dust in two denoms and a balance above the threshold
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 50),
+		sdk.NewInt64Coin(reserveToken2, 500),
+		sdk.NewInt64Coin("dust", 20),
+	)
+	_, err := app.BankKeeper.AddCoins(ctx, anotherAddress, coins)
+	require.Nil(t, err)
+	communityPool := app.BondsKeeper.DistrKeeper.GetFeePoolCommunityCoins(ctx)
+
+	// Sweep dust without a target denom
+	res, err := h(ctx, types.NewMsgSweepFeeDust(anotherAddress, ""))
+	require.NoError(t, err)
+
+	// All of the dust was sent to the community pool
+	dust := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50), sdk.NewInt64Coin("dust", 20))
+	require.Equal(t, coins.Sub(dust), app.BankKeeper.GetCoins(ctx, anotherAddress))
+	require.Equal(t, communityPool.Add(sdk.NewDecCoinsFromCoins(dust...)...),
+		app.BondsKeeper.DistrKeeper.GetFeePoolCommunityCoins(ctx))
+
+	var found bool
+	for _, e := range res.Events {
+		if e.Type == types.EventTypeSweepFeeDust {
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func TestSweepFeeDustSwapsDustThroughSwapperBond(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createSwapperBondWithSanityRate(t, app, ctx, h)
+
+	// Give the address dust in the swapper bond's reserve token and in a
+	// denom that cannot be swapped to the target denom
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 50),
+		sdk.NewInt64Coin("dust", 20),
+	)
+	_, err := app.BankKeeper.AddCoins(ctx, anotherAddress, coins)
+	require.Nil(t, err)
+	communityPool := app.BondsKeeper.DistrKeeper.GetFeePoolCommunityCoins(ctx)
+
+	// Sweep dust into the other reserve token
+	_, err = h(ctx, types.NewMsgSweepFeeDust(anotherAddress, reserveToken2))
+	require.NoError(t, err)
+
+	// The reserve token dust was added to the batch as a swap and the rest
+	// was sent to the community pool
+	swaps := app.BondsKeeper.MustGetBatch(ctx, token).Swaps
+	require.Len(t, swaps, 1)
+	require.Equal(t, anotherAddress, swaps[0].Address)
+	require.Equal(t, sdk.NewInt64Coin(reserveToken, 50), swaps[0].Amount)
+	require.Equal(t, reserveToken2, swaps[0].ToToken)
+	require.True(t, app.BankKeeper.GetCoins(ctx, anotherAddress).Empty())
+	require.Equal(t, communityPool.Add(sdk.NewDecCoinFromDec("dust", sdk.NewDec(20))),
+		app.BondsKeeper.DistrKeeper.GetFeePoolCommunityCoins(ctx))
+}
+
+func TestSweepFeeDustWithoutDustFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// A balance above the threshold and a balance in the target denom are
+	// not dust
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 500),
+		sdk.NewInt64Coin(reserveToken2, 50),
+	)
+	_, err := app.BankKeeper.AddCoins(ctx, anotherAddress, coins)
+	require.Nil(t, err)
+
+	_, err = h(ctx, types.NewMsgSweepFeeDust(anotherAddress, reserveToken2))
+	require.Error(t, err)
+	require.True(t, types.ErrNoFeeDustToSweep.Is(err))
+	require.Equal(t, coins, app.BankKeeper.GetCoins(ctx, anotherAddress))
+}
+
+func TestEndBlockerSweepsFeeDustPeriodically(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Sweep fee dust to the community pool every 2 blocks
+	setFeeDustParams(app, ctx, "", 2)
+
+	// Create bond and give its fee address dust
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	dust := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50))
+	_, err = app.BankKeeper.AddCoins(ctx, initFeeAddress, dust)
+	require.Nil(t, err)
+	communityPool := app.BondsKeeper.DistrKeeper.GetFeePoolCommunityCoins(ctx)
+
+	// No sweep is due at an odd height
+	bonds.EndBlocker(ctx.WithBlockHeight(3), app.BondsKeeper)
+	require.Equal(t, dust, app.BankKeeper.GetCoins(ctx, initFeeAddress))
+
+	// The fee address is swept at an even height
+	bonds.EndBlocker(ctx.WithBlockHeight(4), app.BondsKeeper)
+	require.True(t, app.BankKeeper.GetCoins(ctx, initFeeAddress).Empty())
+	require.Equal(t, communityPool.Add(sdk.NewDecCoinsFromCoins(dust...)...),
+		app.BondsKeeper.DistrKeeper.GetFeePoolCommunityCoins(ctx))
+}
+
+func TestChaosEndBlockerDoesNotHaltOnFailedSettlement(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create two bonds and add reserve tokens to user
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	createMsg2 := newValidMsgCreateBond()
+	createMsg2.Token = token2
+	createMsg2.MaxSupply = sdk.NewCoin(token2, initMaxSupply.Amount)
+	_, err = h(ctx, createMsg2)
+	require.NoError(t, err)
+	err = addCoinsToUser(app, ctx, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000)))
+	require.NoError(t, err)
+
+	// Buy from the first bond
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell to the first bond and buy from the second bond
+	_, err = h(ctx, newValidMsgSell(2))
+	require.NoError(t, err)
+	buyMsg2 := newValidMsgBuy(2, 10000)
+	buyMsg2.Amount.Denom = token2
+	_, err = h(ctx, buyMsg2)
+	require.NoError(t, err)
+
+	// Drain the reserve account without updating the first bond's reserve,
+	// so that the sell cannot be settled, given that the first bond's batch
+	// is settled before the second's
+	reserve := app.BondsKeeper.GetReserveBalances(ctx, token)
+	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, types.BondsReserveAccount, anotherAddress, reserve)
+	require.NoError(t, err)
+
+	// End block does not panic, and the second bond's batch still settles
+	require.NotPanics(t, func() {
+		bonds.EndBlocker(ctx, app.BondsKeeper)
+	})
+	userBalance := app.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, int64(2), userBalance.AmountOf(token2).Int64())
+
+	// The first bond's batch was restarted with its sell still pending
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Len(t, batch.Sells, 1)
+	require.Equal(t, bond.BatchBlocks, batch.BlocksRemaining)
+	require.Equal(t, int64(2), bond.CurrentSupply.Amount.Int64())
+
+	// Later end blocks, in which the batch is retried, do not panic either
+	require.NotPanics(t, func() {
+		bonds.EndBlocker(ctx, app.BondsKeeper)
+		bonds.EndBlocker(ctx, app.BondsKeeper)
+	})
+}
+
+func TestRegisteredRelayerNotifiedOfFills(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and add reserve tokens to user
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	err = addCoinsToUser(app, ctx, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000)))
+	require.NoError(t, err)
+
+	// Register another address for fill notifications
+	_, err = h(ctx, types.NewMsgRegisterNotifications(
+		anotherAddress, token, []string{types.NotificationTypeFills}))
+	require.NoError(t, err)
+
+	// Buy and settle the batch
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// The relayer was notified of the fill
+	expected := types.NewEvent(types.BondNotificationEvent{
+		Relayer:          anotherAddress,
+		Bond:             token,
+		NotificationType: types.NotificationTypeFills,
+		Fills:            1,
+		Cancellations:    0,
+	})
+	require.Contains(t, ctx.EventManager().Events(), expected)
+
+	// Once unregistered, the relayer is no longer notified
+	_, err = h(ctx, types.NewMsgUnregisterNotifications(anotherAddress, token))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	for _, e := range ctx.EventManager().Events() {
+		require.NotEqual(t, types.EventTypeBondNotification, e.Type)
+	}
+}
+
+const viaToken = "via"
+
+// createRoutedSwapBonds creates a power function bond (the via bond) with the
+// reserve token as its reserve, and a swapper function bond with the via
+// bond's token and the second reserve token as its reserves, such that swaps
+// between the reserve token and the second reserve token can be routed
+// through the via bond's token. The user ends up with 10 via bond tokens and
+// 2 swapper bond tokens.
+func createRoutedSwapBonds(t *testing.T, app *simapp.BondsApp, ctx sdk.Context, h sdk.Handler) {
+	err := addCoinsToUser(app, ctx, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	))
+	require.NoError(t, err)
+
+	// Create via bond and buy 20 via bond tokens
+	createViaMsg := newValidMsgCreateBond()
+	createViaMsg.Token = viaToken
+	createViaMsg.MaxSupply = sdk.NewInt64Coin(viaToken, 1000000)
+	_, err = h(ctx, createViaMsg)
+	require.NoError(t, err)
+	buyViaMsg := types.NewMsgBuy(userAddress, sdk.NewInt64Coin(viaToken, 20),
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50000)))
+	_, err = h(ctx, buyViaMsg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Create swapper bond and initialise it with 10 via bond tokens
+	createSwapperMsg := newValidMsgCreateSwapperBond()
+	createSwapperMsg.ReserveTokens = []string{viaToken, reserveToken2}
+	_, err = h(ctx, createSwapperMsg)
+	require.NoError(t, err)
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(viaToken, 10),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+}
+
+func newValidMsgRoutedSwap(from sdk.Coin, toToken string, minReturn int64) types.MsgSwap {
+	msg := types.NewMsgSwap(userAddress, token, from, toToken)
+	minReturnCoin := sdk.NewInt64Coin(toToken, minReturn)
+	msg.MinReturn = &minReturnCoin
+	return msg
+}
+
+func TestRoutedSwapBuyThenSwap(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createRoutedSwapBonds(t, app, ctx, h)
+	userBefore := app.BankKeeper.GetCoins(ctx, userAddress)
+	viaSupplyBefore := app.BondsKeeper.MustGetBond(ctx, viaToken).CurrentSupply
+
+	// Swap reserve tokens to the second reserve token via the via bond
+	from := sdk.NewInt64Coin(reserveToken, 20000)
+	_, err := h(ctx, newValidMsgRoutedSwap(from, reserveToken2, 1))
+	require.NoError(t, err)
+	require.Equal(t, viaToken, app.BondsKeeper.MustGetBatch(ctx, token).Swaps[0].Via)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// 3 via bond tokens were bought for 16985res (including fees), with the
+	// rest refunded, and swapped to 1666rez (2via after fees)
+	userAfter := app.BankKeeper.GetCoins(ctx, userAddress)
+	viaSupplyAfter := app.BondsKeeper.MustGetBond(ctx, viaToken).CurrentSupply
+	require.Equal(t, sdk.NewInt64Coin(viaToken, 3), viaSupplyAfter.Sub(viaSupplyBefore))
+	require.Equal(t, userBefore.AmountOf(viaToken), userAfter.AmountOf(viaToken))
+	require.Equal(t, sdk.NewInt(16985), userBefore.AmountOf(reserveToken).Sub(userAfter.AmountOf(reserveToken)))
+	require.Equal(t, sdk.NewInt(1666), userAfter.AmountOf(reserveToken2).Sub(userBefore.AmountOf(reserveToken2)))
+	require.True(t, app.BondsKeeper.GetEscrowBalance(ctx, token).IsZero())
+	require.True(t, app.BondsKeeper.GetEscrowBalance(ctx, viaToken).IsZero())
+
+	// A routed_swap_fulfill event was emitted for the swap
+	var routedEvents int
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == types.EventTypeRoutedSwapFulfill {
+			routedEvents += 1
+		}
+	}
+	require.Equal(t, 1, routedEvents)
+}
+
+func TestRoutedSwapSwapThenSell(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createRoutedSwapBonds(t, app, ctx, h)
+	userBefore := app.BankKeeper.GetCoins(ctx, userAddress)
+	viaSupplyBefore := app.BondsKeeper.MustGetBond(ctx, viaToken).CurrentSupply
+
+	// Swap the second reserve token to reserve tokens via the via bond
+	from := sdk.NewInt64Coin(reserveToken2, 3000)
+	_, err := h(ctx, newValidMsgRoutedSwap(from, reserveToken, 1))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// 3000rez were swapped to 2via, which were sold for 8854res (after fees)
+	userAfter := app.BankKeeper.GetCoins(ctx, userAddress)
+	viaSupplyAfter := app.BondsKeeper.MustGetBond(ctx, viaToken).CurrentSupply
+	require.Equal(t, sdk.NewInt64Coin(viaToken, 2), viaSupplyBefore.Sub(viaSupplyAfter))
+	require.Equal(t, userBefore.AmountOf(viaToken), userAfter.AmountOf(viaToken))
+	require.Equal(t, sdk.NewInt(3000), userBefore.AmountOf(reserveToken2).Sub(userAfter.AmountOf(reserveToken2)))
+	require.Equal(t, sdk.NewInt(8854), userAfter.AmountOf(reserveToken).Sub(userBefore.AmountOf(reserveToken)))
+	require.True(t, app.BondsKeeper.GetEscrowBalance(ctx, token).IsZero())
+}
+
+func TestRoutedSwapBelowMinReturnIsCancelledAtomically(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createRoutedSwapBonds(t, app, ctx, h)
+	userBefore := app.BankKeeper.GetCoins(ctx, userAddress)
+	viaBondBefore := app.BondsKeeper.MustGetBond(ctx, viaToken)
+	viaReserveBefore := app.BondsKeeper.GetReserveBalances(ctx, viaToken)
+	swapperReserveBefore := app.BondsKeeper.GetReserveBalances(ctx, token)
+
+	// Swap with a min return greater than the combined return (1666rez)
+	from := sdk.NewInt64Coin(reserveToken, 20000)
+	_, err := h(ctx, newValidMsgRoutedSwap(from, reserveToken2, 1667))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// The swap was cancelled and neither leg was kept
+	lastBatch := app.BondsKeeper.MustGetLastBatch(ctx, token)
+	require.True(t, lastBatch.Swaps[0].IsCancelled())
+	require.Contains(t, lastBatch.Swaps[0].CancelReason, types.ErrSwapReturnBelowMinReturn.Error())
+	require.Equal(t, userBefore, app.BankKeeper.GetCoins(ctx, userAddress))
+	require.Equal(t, viaBondBefore.CurrentSupply, app.BondsKeeper.MustGetBond(ctx, viaToken).CurrentSupply)
+	require.Equal(t, viaReserveBefore, app.BondsKeeper.GetReserveBalances(ctx, viaToken))
+	require.Equal(t, swapperReserveBefore, app.BondsKeeper.GetReserveBalances(ctx, token))
+}
+
+func TestRoutedSwapWithoutMinReturnFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createRoutedSwapBonds(t, app, ctx, h)
+
+	from := sdk.NewInt64Coin(reserveToken, 20000)
+	_, err := h(ctx, types.NewMsgSwap(userAddress, token, from, reserveToken2))
+	require.Error(t, err)
+	require.True(t, types.ErrArgumentCannotBeEmpty.Is(err))
+}
+
+func TestRoutedSwapThroughBondNotAllowingSellsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createRoutedSwapBonds(t, app, ctx, h)
+	viaBond := app.BondsKeeper.MustGetBond(ctx, viaToken)
+	viaBond.AllowSells = false
+	app.BondsKeeper.SetBond(ctx, viaToken, viaBond)
+
+	// Selling via bond tokens is not allowed, but buying them still is
+	from := sdk.NewInt64Coin(reserveToken2, 3000)
+	_, err := h(ctx, newValidMsgRoutedSwap(from, reserveToken, 1))
+	require.Error(t, err)
+	require.True(t, types.ErrBondDoesNotAllowSelling.Is(err))
+
+	from = sdk.NewInt64Coin(reserveToken, 20000)
+	_, err = h(ctx, newValidMsgRoutedSwap(from, reserveToken2, 1))
+	require.NoError(t, err)
+}
+
+func TestSwapBelowMinReturnIsCancelled(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createRoutedSwapBonds(t, app, ctx, h)
+	userBefore := app.BankKeeper.GetCoins(ctx, userAddress)
+
+	// Non-routed swap of 3000rez returns 2via, which is below the min return
+	from := sdk.NewInt64Coin(reserveToken2, 3000)
+	_, err := h(ctx, newValidMsgRoutedSwap(from, viaToken, 3))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	lastBatch := app.BondsKeeper.MustGetLastBatch(ctx, token)
+	require.Empty(t, lastBatch.Swaps[0].Via)
+	require.True(t, lastBatch.Swaps[0].IsCancelled())
+	require.Equal(t, userBefore, app.BankKeeper.GetCoins(ctx, userAddress))
+}
+
+func TestLedgerRecordsBuyAndSellFundFlows(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond and buy 10 tokens (reserve 5000res plus 0.1% fee of 5res)
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	require.NoError(t, addCoinsToUser(app, ctx, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 6000))))
+	_, err = h(ctx, newValidMsgBuy(10, 6000))
+	require.NoError(t, err)
+
+	// The max prices are escrowed until the batch is settled
+	ledger, found := app.BondsKeeper.GetLedger(ctx, token)
+	require.True(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 6000)),
+		ledger.Balance(types.LedgerAccountEscrow).Net())
+
+	// Settle the buy, then sell 5 tokens and settle the sell
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	_, err = h(ctx, newValidMsgSell(5))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	var kinds []string
+	for _, e := range app.BondsKeeper.GetLedgerEntries(ctx, token) {
+		kinds = append(kinds, e.Kind)
+	}
+	require.Equal(t, []string{
+		types.LedgerEntryEscrowIn, types.LedgerEntryMint, types.LedgerEntryReserveIn,
+		types.LedgerEntryFee, types.LedgerEntryRefund, types.LedgerEntryBurn,
+		types.LedgerEntryReserveOut, types.LedgerEntryFee,
+	}, kinds)
+
+	// The ledger's balances match the bond's reserve and (empty) escrow
+	ledger, _ = app.BondsKeeper.GetLedger(ctx, token)
+	require.True(t, ledger.IsBalanced())
+	require.Equal(t, app.BondsKeeper.GetReserveBalances(ctx, token),
+		ledger.Balance(types.LedgerAccountReserve).Net())
+	require.True(t, ledger.Balance(types.LedgerAccountEscrow).Net().IsZero())
+	_, broken := bonds.LedgerInvariant(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+
+	// Changing the reserve without recording it breaks the ledger invariant
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	bond.CurrentReserve = bond.CurrentReserve.Add(sdk.NewInt64Coin(reserveToken, 1))
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	_, broken = bonds.LedgerInvariant(app.BondsKeeper)(ctx)
+	require.True(t, broken)
+}
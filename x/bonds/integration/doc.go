@@ -0,0 +1,40 @@
+/*
+Package integration contains integration tests which boot the full app (with
+an in-memory database) and run scripted multi-block scenarios against it.
+
+Unlike the keeper and handler unit tests, the scenarios go through the whole
+ABCI flow, i.e. every block is started, signed transactions are delivered
+through the ante handler, and the block is ended and committed, so that the
+ordering of the EndBlock logic and the interactions with the bank and supply
+modules are covered.
+
+Scenarios are defined as JSON fixtures in the testdata directory. Each fixture
+lists the accounts (funded at genesis) and, for every block, the transactions
+delivered in the block and the expected state once the block is committed:
+
+	{
+	  "name": "...",
+	  "accounts": [{"name": "alice", "coins": "1000000res"}],
+	  "blocks": [
+	    {
+	      "txs": [
+	        {
+	          "signers": ["alice"],
+	          "msgs": [{"type": "bonds/MsgBuy", "value": {...}}],
+	          "error": "optional substring of the expected error"
+	        }
+	      ],
+	      "expect": {
+	        "balances": {"alice": "20000abc,999800res"},
+	        "bonds": {"abc": {"state": "OPEN", "supply": "20000abc", "reserve": "120res"}}
+	      }
+	    }
+	  ]
+	}
+
+Messages are in their amino JSON form, in which any {{name}} placeholder is
+replaced by the address of the named account before the message is decoded.
+Balances are checked exactly, i.e. an account's balance has to consist of the
+expected coins only.
+*/
+package integration
@@ -0,0 +1,261 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	simapp "github.com/ixoworld/bonds/app"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	chainID = "bonds-integration"
+
+	// blockTime is the time between consecutive blocks in the scenarios
+	blockTime = 5 * time.Second
+)
+
+// genesisTime is the time of the genesis block of every scenario
+var genesisTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type scenario struct {
+	Name     string    `json:"name"`
+	Accounts []account `json:"accounts"`
+	Blocks   []block   `json:"blocks"`
+}
+
+type account struct {
+	Name  string `json:"name"`
+	Coins string `json:"coins"`
+}
+
+type block struct {
+	Txs    []tx   `json:"txs"`
+	Expect expect `json:"expect"`
+}
+
+type tx struct {
+	Signers []string          `json:"signers"`
+	Msgs    []json.RawMessage `json:"msgs"`
+	Error   string            `json:"error"`
+}
+
+type expect struct {
+	Balances map[string]string     `json:"balances"`
+	Bonds    map[string]bondExpect `json:"bonds"`
+}
+
+type bondExpect struct {
+	State   string `json:"state"`
+	Supply  string `json:"supply"`
+	Reserve string `json:"reserve"`
+}
+
+// runner runs a scenario against a freshly booted app, keeping track of the
+// (deterministic) keys of the scenario's accounts
+type runner struct {
+	t    *testing.T
+	app  *simapp.BondsApp
+	cdc  *codec.Codec
+	keys map[string]crypto.PrivKey
+
+	// replacer replaces the {{name}} placeholders by the account addresses
+	replacer *strings.Replacer
+}
+
+func accountKey(name string) crypto.PrivKey {
+	return secp256k1.GenPrivKeySecp256k1([]byte(name))
+}
+
+func accountAddress(name string) sdk.AccAddress {
+	return sdk.AccAddress(accountKey(name).PubKey().Address())
+}
+
+func newRunner(t *testing.T, s scenario) *runner {
+	app := simapp.NewBondsApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, 0)
+	r := &runner{
+		t:    t,
+		app:  app,
+		cdc:  app.Codec(),
+		keys: make(map[string]crypto.PrivKey),
+	}
+
+	// Fund the scenario's accounts at genesis
+	var genAccounts authexported.GenesisAccounts
+	var placeholders []string
+	for _, a := range s.Accounts {
+		coins, err := sdk.ParseCoins(a.Coins)
+		require.NoError(t, err, "account %s", a.Name)
+
+		r.keys[a.Name] = accountKey(a.Name)
+		address := accountAddress(a.Name)
+		genAccounts = append(genAccounts, auth.NewBaseAccount(address, coins, nil, 0, 0))
+		placeholders = append(placeholders, "{{"+a.Name+"}}", address.String())
+	}
+	r.replacer = strings.NewReplacer(placeholders...)
+
+	genesisState := simapp.NewDefaultGenesisState()
+	genesisState[auth.ModuleName] = r.cdc.MustMarshalJSON(
+		auth.NewGenesisState(auth.DefaultParams(), genAccounts))
+	stateBytes, err := codec.MarshalJSONIndent(r.cdc, genesisState)
+	require.NoError(t, err)
+
+	app.InitChain(abci.RequestInitChain{
+		Time:          genesisTime,
+		ChainId:       chainID,
+		Validators:    []abci.ValidatorUpdate{},
+		AppStateBytes: stateBytes,
+	})
+	app.Commit()
+
+	return r
+}
+
+// decodeMsg decodes a message from its amino JSON form, after replacing any
+// account placeholders by the account addresses
+func (r *runner) decodeMsg(raw json.RawMessage) sdk.Msg {
+	var msg sdk.Msg
+	bz := []byte(r.replacer.Replace(string(raw)))
+	require.NoError(r.t, r.cdc.UnmarshalJSON(bz, &msg), "message %s", raw)
+	return msg
+}
+
+// deliverTx signs the transaction using the current account numbers and
+// sequences of its signers, and delivers it
+func (r *runner) deliverTx(ctx sdk.Context, tx tx) error {
+	var msgs []sdk.Msg
+	for _, raw := range tx.Msgs {
+		msgs = append(msgs, r.decodeMsg(raw))
+	}
+
+	var accNums, seqs []uint64
+	var privs []crypto.PrivKey
+	for _, name := range tx.Signers {
+		key, ok := r.keys[name]
+		require.True(r.t, ok, "unknown signer %s", name)
+
+		acc := r.app.AccountKeeper.GetAccount(ctx, accountAddress(name))
+		require.NotNil(r.t, acc, "signer %s has no account", name)
+		accNums = append(accNums, acc.GetAccountNumber())
+		seqs = append(seqs, acc.GetSequence())
+		privs = append(privs, key)
+	}
+
+	stdTx := helpers.GenTx(msgs, sdk.Coins{}, helpers.DefaultGenTxGas,
+		chainID, accNums, seqs, privs...)
+	_, _, err := r.app.Deliver(stdTx)
+	return err
+}
+
+// runBlock begins a block, delivers the block's transactions, and ends and
+// commits the block
+func (r *runner) runBlock(height int64, b block) {
+	header := abci.Header{
+		ChainID: chainID,
+		Height:  height,
+		Time:    genesisTime.Add(time.Duration(height) * blockTime),
+	}
+	r.app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := r.app.BaseApp.NewContext(false, header)
+	for i, tx := range b.Txs {
+		err := r.deliverTx(ctx, tx)
+		if tx.Error == "" {
+			require.NoError(r.t, err, "tx #%d", i)
+		} else {
+			require.Error(r.t, err, "tx #%d", i)
+			require.Contains(r.t, err.Error(), tx.Error, "tx #%d", i)
+		}
+	}
+
+	r.app.EndBlock(abci.RequestEndBlock{Height: height})
+	r.app.Commit()
+}
+
+// checkExpectations checks the state committed at the specified height
+func (r *runner) checkExpectations(height int64, e expect) {
+	ctx := r.app.BaseApp.NewContext(true, abci.Header{Height: height})
+
+	for name, expected := range e.Balances {
+		expectedCoins, err := sdk.ParseCoins(expected)
+		require.NoError(r.t, err, "balance of %s", name)
+
+		coins := r.app.BankKeeper.GetCoins(ctx, accountAddress(name))
+		require.Equal(r.t, expectedCoins.String(), coins.String(), "balance of %s", name)
+	}
+
+	for token, expected := range e.Bonds {
+		bond, found := r.app.BondsKeeper.GetBond(ctx, token)
+		require.True(r.t, found, "bond %s does not exist", token)
+
+		if expected.State != "" {
+			require.Equal(r.t, expected.State, bond.State, "state of %s", token)
+		}
+		if expected.Supply != "" {
+			supply, err := sdk.ParseCoin(expected.Supply)
+			require.NoError(r.t, err, "supply of %s", token)
+			require.Equal(r.t, supply.String(), bond.CurrentSupply.String(), "supply of %s", token)
+		}
+		if expected.Reserve != "" {
+			reserve, err := sdk.ParseCoins(expected.Reserve)
+			require.NoError(r.t, err, "reserve of %s", token)
+			require.Equal(r.t, reserve.String(), bond.CurrentReserve.String(), "reserve of %s", token)
+		}
+	}
+}
+
+// run runs the scenario's blocks one by one, stopping at the first block
+// whose transactions or expectations fail, since later blocks depend on it
+func (r *runner) run(s scenario) {
+	parent := r.t
+	defer func() { r.t = parent }()
+
+	for i, b := range s.Blocks {
+		height := r.app.LastBlockHeight() + 1
+		ok := parent.Run(fmt.Sprintf("block %d", i+1), func(t *testing.T) {
+			r.t = t
+			r.runBlock(height, b)
+			r.checkExpectations(height, b.Expect)
+		})
+		if !ok {
+			return
+		}
+	}
+}
+
+func loadScenario(t *testing.T, path string) scenario {
+	bz, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var s scenario
+	require.NoError(t, json.Unmarshal(bz, &s), path)
+	return s
+}
+
+func TestScenarios(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		s := loadScenario(t, path)
+		t.Run(s.Name, func(t *testing.T) {
+			newRunner(t, s).run(s)
+		})
+	}
+}
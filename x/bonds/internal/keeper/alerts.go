@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetAlertWindow returns the bond's current alert window, if any.
+func (k Keeper) GetAlertWindow(ctx sdk.Context, token string) (window types.AlertWindow, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetAlertWindowKey(token)) {
+		return types.AlertWindow{}, false
+	}
+
+	bz := store.Get(types.GetAlertWindowKey(token))
+	k.cdc.MustUnmarshalBinaryBare(bz, &window)
+
+	return window, true
+}
+
+func (k Keeper) SetAlertWindow(ctx sdk.Context, token string, window types.AlertWindow) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAlertWindowKey(token), k.cdc.MustMarshalBinaryBare(window))
+}
+
+// getAlertValues returns the bond's current spot price, reserve, and supply,
+// as an alert window starting at the current height. The spot price is empty
+// if it cannot be calculated (e.g. for a swapper bond without liquidity).
+func (k Keeper) getAlertValues(ctx sdk.Context, bond types.Bond) types.AlertWindow {
+	spotPrice, err := bond.GetCurrentPricesPT(bond.CurrentReserve)
+	if err != nil {
+		spotPrice = nil
+	}
+	return types.NewAlertWindow(ctx.BlockHeight(), spotPrice,
+		types.CoinsToDecCoins(bond.CurrentReserve...),
+		types.CoinsToDecCoins(bond.CurrentSupply))
+}
+
+// StartAlertWindowIfEnded starts a new alert window for the bond, recording
+// its current spot price, reserve, and supply, if the bond does not have an
+// alert window yet or if its current window has ended. Nothing is recorded
+// if alerts are disabled.
+func (k Keeper) StartAlertWindowIfEnded(ctx sdk.Context, token string) {
+	if !k.AlertChangePercentage(ctx).IsPositive() {
+		return
+	}
+
+	window, found := k.GetAlertWindow(ctx, token)
+	if !found || window.HasEnded(ctx.BlockHeight(), k.AlertWindowBlocks(ctx)) {
+		k.SetAlertWindow(ctx, token, k.getAlertValues(ctx, k.MustGetBond(ctx, token)))
+	}
+}
+
+// CheckBondAlerts emits an alert event for each of the bond's spot price,
+// reserve, and supply that has changed by more than the alert change
+// percentage since the start of the bond's current alert window. Each of
+// these is alerted at most once per window.
+func (k Keeper) CheckBondAlerts(ctx sdk.Context, token string) {
+	threshold := k.AlertChangePercentage(ctx)
+	if !threshold.IsPositive() {
+		return
+	}
+
+	window, found := k.GetAlertWindow(ctx, token)
+	if !found {
+		return
+	}
+
+	bond := k.MustGetBond(ctx, token)
+	current := k.getAlertValues(ctx, bond)
+	metrics := []struct {
+		name     string
+		from, to sdk.DecCoins
+	}{
+		{types.AlertMetricSpotPrice, window.SpotPrice, current.SpotPrice},
+		{types.AlertMetricReserve, window.Reserve, current.Reserve},
+		{types.AlertMetricSupply, window.Supply, current.Supply},
+	}
+
+	logger := k.Logger(ctx)
+	alerted := false
+	for _, m := range metrics {
+		if window.HasAlerted(m.name) {
+			continue
+		}
+
+		change := types.GetChangePercentage(m.from, m.to)
+		if change.LTE(threshold) {
+			continue
+		}
+		window.Alerted = append(window.Alerted, m.name)
+		alerted = true
+
+		logger.Info(fmt.Sprintf("bond %s %s changed by %s%% from %s to %s",
+			bond.Token, m.name, change, m.from, m.to))
+
+		ctx.EventManager().EmitEvent(
+			types.NewEvent(types.BondAlertEvent{
+				Bond:              bond.Token,
+				Metric:            m.name,
+				WindowStartHeight: window.StartHeight,
+				OldValue:          m.from,
+				NewValue:          m.to,
+				ChangePercentage:  change,
+			}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+		)
+	}
+
+	if alerted {
+		k.SetAlertWindow(ctx, token, window)
+	}
+}
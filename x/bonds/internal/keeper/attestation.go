@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+var _ types.AttestationKeeper = NoOpAttestationKeeper{}
+
+// NoOpAttestationKeeper is the default attestation keeper, which considers
+// every address to have a valid attestation for every bond. This means that
+// RequireAttestation has no effect unless an actual attestation keeper is set.
+type NoOpAttestationKeeper struct{}
+
+func (NoOpAttestationKeeper) HasValidAttestation(_ sdk.Context, _ string, _ sdk.AccAddress) bool {
+	return true
+}
+
+// HasValidAttestation returns true if the bond does not require an attestation
+// or if the address has a valid attestation for the bond.
+func (k Keeper) HasValidAttestation(ctx sdk.Context, bond types.Bond, address sdk.AccAddress) bool {
+	if !bond.RequireAttestation {
+		return true
+	}
+	return k.AttestationKeeper.HasValidAttestation(ctx, bond.Token, address)
+}
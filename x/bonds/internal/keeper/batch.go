@@ -5,6 +5,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"strings"
 )
 
 func (k Keeper) MustGetBatch(ctx sdk.Context, token string) types.Batch {
@@ -33,6 +34,19 @@ func (k Keeper) MustGetLastBatch(ctx sdk.Context, token string) types.Batch {
 	return batch
 }
 
+func (k Keeper) MustGetLastBatchResult(ctx sdk.Context, token string) types.BatchResult {
+	store := ctx.KVStore(k.storeKey)
+	if !k.LastBatchResultExists(ctx, token) {
+		panic(fmt.Sprintf("last batch result not found for %s\n", token))
+	}
+
+	bz := store.Get(types.GetLastBatchResultKey(token))
+	var result types.BatchResult
+	k.cdc.MustUnmarshalBinaryBare(bz, &result)
+
+	return result
+}
+
 func (k Keeper) BatchExists(ctx sdk.Context, token string) bool {
 	store := ctx.KVStore(k.storeKey)
 	return store.Has(types.GetBatchKey(token))
@@ -43,7 +57,18 @@ func (k Keeper) LastBatchExists(ctx sdk.Context, token string) bool {
 	return store.Has(types.GetLastBatchKey(token))
 }
 
+func (k Keeper) LastBatchResultExists(ctx sdk.Context, token string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetLastBatchResultKey(token))
+}
+
 func (k Keeper) SetBatch(ctx sdk.Context, token string, batch types.Batch) {
+	if k.BatchExists(ctx, token) {
+		k.updateStatsForBatch(ctx, k.MustGetBatch(ctx, token), batch)
+	} else {
+		k.updateStatsForBatch(ctx, types.Batch{}, batch)
+	}
+
 	store := ctx.KVStore(k.storeKey)
 	store.Set(types.GetBatchKey(token), k.cdc.MustMarshalBinaryBare(batch))
 }
@@ -53,6 +78,11 @@ func (k Keeper) SetLastBatch(ctx sdk.Context, token string, batch types.Batch) {
 	store.Set(types.GetLastBatchKey(token), k.cdc.MustMarshalBinaryBare(batch))
 }
 
+func (k Keeper) SetLastBatchResult(ctx sdk.Context, token string, result types.BatchResult) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetLastBatchResultKey(token), k.cdc.MustMarshalBinaryBare(result))
+}
+
 func (k Keeper) AddBuyOrder(ctx sdk.Context, token string, bo types.BuyOrder, buyPrices, sellPrices sdk.DecCoins) {
 	batch := k.MustGetBatch(ctx, token)
 	batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo.Amount)
@@ -88,53 +118,8 @@ func (k Keeper) AddSwapOrder(ctx sdk.Context, token string, so types.SwapOrder)
 
 func (k Keeper) GetBatchBuySellPrices(ctx sdk.Context, token string, batch types.Batch) (buyPricesPT, sellPricesPT sdk.DecCoins, err error) {
 	bond := k.MustGetBond(ctx, token)
-
-	buyAmountDec := batch.TotalBuyAmount.Amount.ToDec()
-	sellAmountDec := batch.TotalSellAmount.Amount.ToDec()
-
 	reserveBalances := k.GetReserveBalances(ctx, token)
-	currentPricesPT, err := bond.GetCurrentPricesPT(reserveBalances)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Get (amount of) matched and (actual) curve-calculated value for the remaining amount
-	// - The matched amount is the least of the buys and sells (i.e. greatest common amount)
-	// - The curved values are the prices/returns for the extra unmatched buys/sells
-	var matchedAmount sdk.Dec
-	var curvedValues sdk.DecCoins
-	if batch.EqualBuysAndSells() {
-		// Since equal, both prices are current prices
-		return currentPricesPT, currentPricesPT, nil
-	} else if batch.MoreBuysThanSells() {
-		matchedAmount = sellAmountDec // since sells < buys, greatest common amount is sells
-		extraBuys := batch.TotalBuyAmount.Sub(batch.TotalSellAmount)
-		curvedValues, err = bond.GetPricesToMint(extraBuys.Amount, reserveBalances) // buy prices
-		if err != nil {
-			return nil, nil, err
-		}
-	} else {
-		matchedAmount = buyAmountDec // since buys < sells, greatest common amount is buys
-		extraSells := batch.TotalSellAmount.Sub(batch.TotalBuyAmount)
-		curvedValues = bond.GetReturnsForBurn(extraSells.Amount, reserveBalances) // sell returns
-	}
-
-	// Get (actual) matched values
-	matchedValues := types.MultiplyDecCoinsByDec(currentPricesPT, matchedAmount)
-
-	// If buys > sells, totalValues is the total buy prices
-	// If sells > buys, totalValues is the total sell returns
-	totalValues := matchedValues.Add(curvedValues...)
-
-	// Calculate buy and sell prices per token
-	if batch.MoreBuysThanSells() {
-		buyPricesPT = types.DivideDecCoinsByDec(totalValues, buyAmountDec)
-		sellPricesPT = currentPricesPT
-	} else {
-		buyPricesPT = currentPricesPT
-		sellPricesPT = types.DivideDecCoinsByDec(totalValues, sellAmountDec)
-	}
-	return buyPricesPT, sellPricesPT, nil
+	return bond.GetBatchBuySellPrices(batch, reserveBalances)
 }
 
 func (k Keeper) GetUpdatedBatchPricesAfterBuy(ctx sdk.Context, token string, bo types.BuyOrder) (buyPrices, sellPrices sdk.DecCoins, err error) {
@@ -157,7 +142,7 @@ func (k Keeper) GetUpdatedBatchPricesAfterBuy(ctx sdk.Context, token string, bo
 	// the minimum buy of 1 token [101>100.5], so S0 is rounded to ceil; S0=101)
 	if bond.FunctionType == types.AugmentedFunction &&
 		bond.State == types.HatchState {
-		args := bond.FunctionParameters.AsMap()
+		args := bond.FunctionParamsMap()
 		if adjustedSupplyWithBuy.Amount.ToDec().GT(args["S0"].Ceil()) {
 			return nil, nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "Buy exceeds initial supply S0. Consider buying less tokens.")
 		}
@@ -197,62 +182,148 @@ func (k Keeper) GetUpdatedBatchPricesAfterSell(ctx sdk.Context, token string, so
 	return buyPrices, sellPrices, nil
 }
 
-func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrder, prices sdk.DecCoins) (err error) {
+func (k Keeper) GetTotalReturnsAfterSell(ctx sdk.Context, token string, so types.SellOrder) (totalReturns sdk.Coins, err error) {
 	bond := k.MustGetBond(ctx, token)
-	var extraEventAttributes []sdk.Attribute
+
+	// Get sell price as if sell order was added to batch
+	_, sellPrices, err := k.GetUpdatedBatchPricesAfterSell(ctx, token, so)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate returns in the same way as during PerformSellAtPrice
+	totalReturns, _, _ = getSellReturnsAtPrice(ctx, bond, so.Amount.Amount, sellPrices)
+	return totalReturns, nil
+}
+
+// GetSellAmountForReturns calculates the least amount of bond tokens, up to
+// maxAmount, that the seller needs to sell in the current batch to receive at
+// least the specified returns (after fees). Since total returns only increase
+// as the amount sold increases, the amount is found using a binary search.
+func (k Keeper) GetSellAmountForReturns(ctx sdk.Context, seller sdk.AccAddress, returns sdk.Coins, maxAmount sdk.Coin) (sdk.Coin, error) {
+	token := maxAmount.Denom
+	returnsForAmount := func(amount sdk.Int) (sdk.Coins, error) {
+		so := types.NewSellOrder(seller, sdk.NewCoin(token, amount))
+		return k.GetTotalReturnsAfterSell(ctx, token, so)
+	}
+
+	// Cannot burn more tokens than what exists
+	high := maxAmount.Amount
+	adjustedSupply := k.GetSupplyAdjustedForSell(ctx, token)
+	if adjustedSupply.Amount.LT(high) {
+		high = adjustedSupply.Amount
+	}
+	if !high.IsPositive() {
+		return sdk.Coin{}, sdkerrors.Wrap(types.ErrCannotBurnMoreThanSupply, maxAmount.String())
+	}
+
+	// Check that returns can be reached without exceeding the max amount
+	maxReturns, err := returnsForAmount(high)
+	if err != nil {
+		return sdk.Coin{}, err
+	} else if !maxReturns.IsAllGTE(returns) {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrMaxAmountInsufficientForReturns,
+			"selling %s%s returns %s", high, token, maxReturns)
+	}
+
+	// Binary search for least amount with returns >= requested returns
+	low := sdk.OneInt()
+	for low.LT(high) {
+		mid := low.Add(high).QuoRaw(2)
+		midReturns, err := returnsForAmount(mid)
+		if err != nil {
+			return sdk.Coin{}, err
+		}
+
+		if midReturns.IsAllGTE(returns) {
+			high = mid
+		} else {
+			low = mid.AddRaw(1)
+		}
+	}
+
+	return sdk.NewCoin(token, low), nil
+}
+
+// getBuyCostAtPrice returns the reserve prices (rounded up) and the fees that
+// are charged for buying the amount of bond tokens at the per-token prices.
+func getBuyCostAtPrice(bond types.Bond, amount sdk.Int, prices sdk.DecCoins) (reservePricesRounded, txFees sdk.Coins) {
+	reservePrices := types.MultiplyDecCoinsByInt(prices, amount)
+	reservePricesRounded = types.RoundReservePrices(reservePrices)
+	txFees = bond.GetOrderFees(types.AttributeValueBuyOrder, reservePrices).Total
+	return reservePricesRounded, txFees
+}
+
+// getSellReturnsAtPrice returns the returns (after fees and demurrage), the
+// fees, and the demurrage for selling the amount of bond tokens at the
+// per-token prices.
+func getSellReturnsAtPrice(ctx sdk.Context, bond types.Bond, amount sdk.Int,
+	prices sdk.DecCoins) (totalReturns, totalFees, demurrage sdk.Coins) {
+	reserveReturns := types.MultiplyDecCoinsByInt(prices, amount)
+	reserveReturnsRounded := types.RoundReserveReturns(reserveReturns)
+
+	totalFees = bond.GetOrderFees(types.AttributeValueSellOrder, reserveReturns).Total // calculate actual total fees
+	totalReturns = reserveReturnsRounded.Sub(totalFees)                                // calculate actual reserveReturns
+
+	// Deduct the part of the returns that has decayed due to demurrage
+	demurrage = bond.GetDemurrageCharges(
+		totalReturns, bond.GetDemurrageIndexAt(ctx.BlockHeight()))
+	return totalReturns.Sub(demurrage), totalFees, demurrage
+}
+
+// PerformBuyAtPrice performs the buy order by charging the prices (plus fees)
+// for the amount bought, and returns the refund, i.e. the rest of the max
+// prices that were locked for the order, which is sent back to the buyer.
+func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrder, prices sdk.DecCoins) (refund sdk.Coins, err error) {
+	bond := k.MustGetBond(ctx, token)
+	var chargedPricesReserve sdk.Int
+	var chargedPricesFunding sdk.Coins
+
+	reservePricesRounded, txFees := getBuyCostAtPrice(bond, bo.Amount.Amount, prices)
+	totalPrices := reservePricesRounded.Add(txFees...)
+
+	// Check that max prices not exceeded (before minting anything)
+	if exceeded := bo.DenomsExceedingMaxPrices(totalPrices); len(exceeded) > 0 {
+		return nil, sdkerrors.Wrapf(types.ErrMaxPriceExceeded, "Actual prices %s exceed max prices %s for %s",
+			totalPrices, bo.MaxPrices, strings.Join(exceeded, ","))
+	}
 
 	// Mint bond tokens
 	err = k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount,
 		sdk.Coins{bo.Amount})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Send bond tokens bought to buyer
 	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
 		types.BondsMintBurnAccount, bo.Address, sdk.Coins{bo.Amount})
 	if err != nil {
-		return err
-	}
-
-	reservePrices := types.MultiplyDecCoinsByInt(prices, bo.Amount.Amount)
-	reservePricesRounded := types.RoundReservePrices(reservePrices)
-	txFees := bond.GetTxFees(reservePrices)
-	totalPrices := reservePricesRounded.Add(txFees...)
-
-	if totalPrices.IsAnyGT(bo.MaxPrices) {
-		sdkerrors.Wrapf(types.ErrMaxPriceExceeded, "Actual prices %s exceed max prices %s", totalPrices, bo.MaxPrices)
+		return nil, err
 	}
+	k.RecordLedgerEntry(ctx, bond.Token, types.LedgerEntryMint,
+		types.LedgerAccountExternal, types.LedgerAccountSupply,
+		sdk.Coins{bo.Amount}, bo.Address)
 
 	// Add new reserve to reserve (reservePricesRounded should never be zero)
 	// TODO: investigate possibility of zero reservePricesRounded
 	if bond.FunctionType == types.AugmentedFunction &&
 		bond.State == types.HatchState {
-		args := bond.FunctionParameters.AsMap()
-		theta := args["theta"]
-
 		// Get current reserve
-		var currentReserve sdk.Int
-		if bond.CurrentReserve.Empty() {
-			currentReserve = sdk.ZeroInt()
-		} else {
-			// Reserve balances should all be equal given that we are always
-			// applying the same additions/subtractions to all reserve balances.
-			// Thus we can pick the first reserve balance as the global balance.
-			currentReserve = k.GetReserveBalances(ctx, token)[0].Amount
+		currentReserve, err := bond.GetCommonReserveBalance(bond.CurrentReserve)
+		if err != nil {
+			return nil, err
 		}
 
 		// Calculate expected new reserve (as fraction 1-theta of new total raise)
 		newSupply := bond.CurrentSupply.Add(bo.Amount).Amount
-		newTotalRaise := args["p0"].Mul(newSupply.ToDec())
-		newReserve := newTotalRaise.Mul(
-			sdk.OneDec().Sub(theta)).Ceil().TruncateInt()
+		newReserve := bond.HatchReserveAtSupply(newSupply)
 
 		// Calculate amount that should go into initial reserve
 		toInitialReserve := newReserve.Sub(currentReserve)
 		if reservePricesRounded[0].Amount.LT(toInitialReserve) {
 			// Reserve supplied by buyer is insufficient
-			return sdkerrors.Wrap(types.ErrInsufficientReserveToBuy, toInitialReserve.String())
+			return nil, sdkerrors.Wrap(types.ErrInsufficientReserveToBuy, toInitialReserve.String())
 		}
 		coinsToInitialReserve, _ := bond.GetNewReserveDecCoins(
 			toInitialReserve.ToDec()).TruncateDecimal()
@@ -261,48 +332,53 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 		coinsToFundingPool := reservePricesRounded.Sub(coinsToInitialReserve)
 
 		// Send reserve tokens to initial reserve
-		err = k.DepositReserveFromModule(ctx, bond.Token,
-			types.BatchesIntermediaryAccount, coinsToInitialReserve)
+		err = k.DepositReserveFromEscrow(ctx, bond.Token, coinsToInitialReserve)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		// Send reserve tokens to funding pool
-		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, bond.FeeAddress, coinsToFundingPool)
+		err = k.ReleaseEscrowedFunds(ctx, bond.Token,
+			bond.FeeAddress, coinsToFundingPool)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		extraEventAttributes = append(extraEventAttributes,
-			sdk.NewAttribute(types.AttributeKeyChargedPricesReserve, toInitialReserve.String()),
-			sdk.NewAttribute(types.AttributeKeyChargedPricesFunding, coinsToFundingPool.String()),
-		)
+		chargedPricesReserve = toInitialReserve
+		chargedPricesFunding = coinsToFundingPool
 	} else {
-		err = k.DepositReserveFromModule(
-			ctx, bond.Token, types.BatchesIntermediaryAccount, reservePricesRounded)
+		err = k.DepositReserveFromEscrow(ctx, bond.Token, reservePricesRounded)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	// Add charged fee to fee address
 	if !txFees.IsZero() {
-		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, bond.FeeAddress, txFees)
+		err = k.PayFeesFromEscrow(ctx, bond.Token, txFees)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		k.addFeesCollected(ctx, token, txFees)
+	}
+
+	// Pay the priority fee (if any) now that the buy has been fulfilled
+	if !bo.PriorityFee.IsZero() {
+		err = k.PayFeesFromEscrow(ctx, bond.Token, bo.PriorityFee)
+		if err != nil {
+			return nil, err
+		}
+		k.addFeesCollected(ctx, token, bo.PriorityFee)
 	}
 
 	// Add remainder to buyer address
 	returnToBuyer := bo.MaxPrices.Sub(totalPrices)
 	if !returnToBuyer.IsZero() {
-		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, bo.Address, returnToBuyer)
+		err = k.RefundEscrowedFunds(ctx, bond.Token, bo.Address, returnToBuyer)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		k.ReleaseBuySpend(ctx, bo.Address, bo.Height, returnToBuyer)
 	}
 
 	// Update supply (max supply exceeded check done during MsgBuy)
@@ -314,35 +390,28 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 	// Get new bond token balance
 	bondTokenBalance := k.BankKeeper.GetCoins(ctx, bo.Address).AmountOf(bond.Token)
 
-	event := sdk.NewEvent(
-		types.EventTypeOrderFulfill,
-		sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
-		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
-		sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
-		sdk.NewAttribute(types.AttributeKeyTokensMinted, bo.Amount.Amount.String()),
-		sdk.NewAttribute(types.AttributeKeyChargedPrices, reservePricesRounded.String()),
-		sdk.NewAttribute(types.AttributeKeyChargedFees, txFees.String()),
-		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, returnToBuyer.String()),
-		sdk.NewAttribute(types.AttributeKeyNewBondTokenBalance, bondTokenBalance.String()),
-	)
-	if len(extraEventAttributes) > 0 {
-		event = event.AppendAttributes(extraEventAttributes...)
-	}
-	ctx.EventManager().EmitEvent(event)
-
-	return nil
+	ctx.EventManager().EmitEvent(types.NewEvent(types.BuyOrderFulfillEvent{
+		Bond:                 bond.Token,
+		OrderType:            types.AttributeValueBuyOrder,
+		Address:              bo.Address,
+		TokensMinted:         bo.Amount.Amount,
+		ChargedPrices:        reservePricesRounded,
+		ChargedFees:          txFees,
+		ReturnedToAddress:    returnToBuyer,
+		NewBondTokenBalance:  bondTokenBalance,
+		ChargedPricesReserve: chargedPricesReserve,
+		ChargedPricesFunding: chargedPricesFunding,
+		CallbackPayload:      bo.CallbackPayload,
+		Memo:                 bo.Memo,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+	return returnToBuyer, nil
 }
 
 func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellOrder, prices sdk.DecCoins) (err error) {
 	bond := k.MustGetBond(ctx, token)
 
-	reserveReturns := types.MultiplyDecCoinsByInt(prices, so.Amount.Amount)
-	reserveReturnsRounded := types.RoundReserveReturns(reserveReturns)
-	txFees := bond.GetTxFees(reserveReturns)
-	exitFees := bond.GetExitFees(reserveReturns)
-
-	totalFees := types.AdjustFees(txFees.Add(exitFees...), reserveReturnsRounded) // calculate actual total fees
-	totalReturns := reserveReturnsRounded.Sub(totalFees)                          // calculate actual reserveReturns
+	totalReturns, totalFees, demurrage := getSellReturnsAtPrice(ctx, bond, so.Amount.Amount, prices)
 
 	// Send total returns to seller (totalReturns should never be zero)
 	// TODO: investigate possibility of zero totalReturns
@@ -353,7 +422,16 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellO
 
 	// Send total fee to fee address
 	if !totalFees.IsZero() {
-		err = k.WithdrawReserve(ctx, bond.Token, bond.FeeAddress, totalFees)
+		err = k.PayFeesFromReserve(ctx, bond.Token, totalFees)
+		if err != nil {
+			return err
+		}
+		k.addFeesCollected(ctx, token, totalFees)
+	}
+
+	// Send decayed returns to the funding pool (i.e. the fee address)
+	if !demurrage.IsZero() {
+		err = k.PayFeesFromReserve(ctx, bond.Token, demurrage)
 		if err != nil {
 			return err
 		}
@@ -368,21 +446,34 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellO
 	// Get new bond token balance
 	bondTokenBalance := k.BankKeeper.GetCoins(ctx, so.Address).AmountOf(bond.Token)
 
-	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		types.EventTypeOrderFulfill,
-		sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
-		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
-		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
-		sdk.NewAttribute(types.AttributeKeyTokensBurned, so.Amount.Amount.String()),
-		sdk.NewAttribute(types.AttributeKeyChargedFees, txFees.String()),
-		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, totalReturns.String()),
-		sdk.NewAttribute(types.AttributeKeyNewBondTokenBalance, bondTokenBalance.String()),
-	))
+	ctx.EventManager().EmitEvent(types.NewEvent(types.SellOrderFulfillEvent{
+		Bond:                bond.Token,
+		OrderType:           types.AttributeValueSellOrder,
+		Address:             so.Address,
+		TokensBurned:        so.Amount.Amount,
+		ChargedFees:         totalFees,
+		ReturnedToAddress:   totalReturns,
+		NewBondTokenBalance: bondTokenBalance,
+		ChargedDemurrage:    demurrage,
+		Memo:                so.Memo,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
 
 	return nil
 }
 
-func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder) (err error, ok bool) {
+func (k Keeper) PerformSwapOrder(ctx sdk.Context, token string, so types.SwapOrder) (err error, ok bool) {
+	if so.IsRouted() {
+		// Routed swaps are performed atomically, so nothing has been
+		// transferred if an error occurs and the swap can be cancelled
+		return k.performRoutedSwapOrder(ctx, token, so), true
+	}
+	_, err, ok = k.performSwapOrder(ctx, token, so)
+	return err, ok
+}
+
+// performSwapOrder performs the (non-routed) swap order and returns the
+// resultant tokens given to the swapper.
+func (k Keeper) performSwapOrder(ctx sdk.Context, token string, so types.SwapOrder) (returns sdk.Coins, err error, ok bool) {
 	bond := k.MustGetBond(ctx, token)
 
 	// WARNING: do not return ok=true if money has already been transferred when error occurs
@@ -391,71 +482,91 @@ func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder) (
 	reserveBalances := k.GetReserveBalances(ctx, token)
 	reserveReturns, txFee, err := bond.GetReturnsForSwap(so.Amount, so.ToToken, reserveBalances)
 	if err != nil {
-		return err, true
+		return nil, err, true
 	}
 	adjustedInput := so.Amount.Sub(txFee) // same as during GetReturnsForSwap
 
-	// Check if new rates violate sanity rate
+	// Check that the returns are not less than the min return (if any)
+	if !so.MinReturnMet(reserveReturns) {
+		return nil, sdkerrors.Wrapf(types.ErrSwapReturnBelowMinReturn,
+			"%s is less than %s", reserveReturns, so.MinReturn), true
+	}
+
+	// Check if new rates violate sanity rate. Rebalance swaps are instead
+	// checked against the sanity margin around the oracle rate.
 	newReserveBalances := reserveBalances.Add(adjustedInput).Sub(reserveReturns)
-	if bond.ReservesViolateSanityRate(newReserveBalances) {
-		return sdkerrors.Wrap(types.ErrValuesViolateSanityRate, newReserveBalances.String()), true
+	var oracleRate sdk.Dec
+	if so.IsRebalance() {
+		oracleRate = so.OracleRate
+		if bond.ReservesViolateOracleRate(oracleRate, newReserveBalances) {
+			return nil, sdkerrors.Wrapf(types.ErrValuesViolateSanityRate,
+				"%s (oracle rate %s)", newReserveBalances.String(), oracleRate), true
+		}
+	} else if bond.ReservesViolateSanityRate(newReserveBalances) {
+		return nil, sdkerrors.Wrap(types.ErrValuesViolateSanityRate, newReserveBalances.String()), true
 	}
 
 	// Give resultant tokens to swapper (reserveReturns should never be zero)
 	err = k.WithdrawReserve(ctx, bond.Token, so.Address, reserveReturns)
 	if err != nil {
-		return err, false
+		return nil, err, false
 	}
 
 	// Add fee-reduced coins to be swapped to reserve (adjustedInput should never be zero)
-	err = k.DepositReserveFromModule(
-		ctx, bond.Token, types.BatchesIntermediaryAccount, sdk.Coins{adjustedInput})
+	err = k.DepositReserveFromEscrow(ctx, bond.Token, sdk.Coins{adjustedInput})
 	if err != nil {
-		return err, false
+		return nil, err, false
 	}
 
 	// Add fee (taken from swapper) to fee address
 	if !txFee.IsZero() {
-		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, bond.FeeAddress, sdk.Coins{txFee})
+		err = k.PayFeesFromEscrow(ctx, bond.Token, sdk.Coins{txFee})
 		if err != nil {
-			return err, false
+			return nil, err, false
 		}
+		k.addFeesCollected(ctx, token, sdk.Coins{txFee})
 	}
 
 	logger := k.Logger(ctx)
-	logger.Info(fmt.Sprintf("performed swap order for %s to %s from %s",
-		so.Amount.String(), reserveReturns, so.Address.String()))
-
-	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		types.EventTypeOrderFulfill,
-		sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
-		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
-		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
-		sdk.NewAttribute(types.AttributeKeyTokensSwapped, adjustedInput.String()),
-		sdk.NewAttribute(types.AttributeKeyChargedFees, txFee.String()),
-		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, reserveReturns.String()),
-	))
+	if so.IsRebalance() {
+		logger.Info(fmt.Sprintf("performed rebalance swap order for %s to %s from %s using oracle rate %s",
+			so.Amount.String(), reserveReturns, so.Address.String(), oracleRate))
+	} else {
+		logger.Info(fmt.Sprintf("performed swap order for %s to %s from %s",
+			so.Amount.String(), reserveReturns, so.Address.String()))
+	}
 
-	return nil, true
+	ctx.EventManager().EmitEvent(types.NewEvent(types.SwapOrderFulfillEvent{
+		Bond:              bond.Token,
+		OrderType:         types.AttributeValueSwapOrder,
+		Address:           so.Address,
+		TokensSwapped:     adjustedInput,
+		ChargedFees:       txFee,
+		ReturnedToAddress: reserveReturns,
+		OracleRate:        oracleRate,
+		Memo:              so.Memo,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+	return reserveReturns, nil, true
 }
 
 func (k Keeper) PerformBuyOrders(ctx sdk.Context, token string) {
 	batch := k.MustGetBatch(ctx, token)
 
 	// Perform buys or return to buyer
-	for _, bo := range batch.Buys {
+	for i, bo := range batch.Buys {
 		if !bo.IsCancelled() {
-			err := k.PerformBuyAtPrice(ctx, token, bo, batch.BuyPrices)
+			refund, err := k.PerformBuyAtPrice(ctx, token, bo, batch.BuyPrices)
 			if err != nil {
 				// Panic here since all calculations should have been done
 				// correctly to prevent any errors during the buy
 				panic(err)
 			}
+			batch.Buys[i].Refund = refund
 		}
 	}
 
-	// Update batch with any new changes (shouldn't be any)
+	// Update batch with the refunds of the fulfilled buys
 	k.SetBatch(ctx, token, batch)
 }
 
@@ -480,13 +591,14 @@ func (k Keeper) PerformSellOrders(ctx sdk.Context, token string) {
 
 func (k Keeper) PerformSwapOrders(ctx sdk.Context, token string) {
 	logger := ctx.Logger()
+	bond := k.MustGetBond(ctx, token)
 	batch := k.MustGetBatch(ctx, token)
 
 	// Perform swaps
 	// TODO: implement swaps front-running prevention
 	for i, so := range batch.Swaps {
 		if !so.IsCancelled() {
-			err, ok := k.PerformSwap(ctx, token, so)
+			err, ok := k.PerformSwapOrder(ctx, token, so)
 			if err != nil {
 				if ok {
 					batch.Swaps[i].Cancelled = true
@@ -495,9 +607,17 @@ func (k Keeper) PerformSwapOrders(ctx sdk.Context, token string) {
 					logger.Info(fmt.Sprintf("cancelled swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
 					logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
 
+					ctx.EventManager().EmitEvent(types.NewEvent(types.OrderCancelEvent{
+						Bond:         token,
+						OrderType:    types.AttributeValueSwapOrder,
+						Address:      so.Address,
+						CancelReason: err.Error(),
+						Memo:         so.Memo,
+					}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
 					// Return from amount to swapper
-					err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-						types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
+					err := k.RefundEscrowedFunds(ctx, token,
+						so.Address, sdk.Coins{so.Amount})
 					if err != nil {
 						panic(err)
 					}
@@ -514,6 +634,111 @@ func (k Keeper) PerformSwapOrders(ctx sdk.Context, token string) {
 	k.SetBatch(ctx, token, batch)
 }
 
+// DeferSellsExceedingNetSellCap enforces the bond's net sell cap on the current
+// batch. If the batch's sells exceed its buys by more than the cap, the excess
+// is taken out of the sell orders pro-rata and returned as sell orders to be
+// added to the next batch. Since the reduced sells may raise the buy prices,
+// any buys that become unfulfillable are cancelled and the cap is re-applied.
+func (k Keeper) DeferSellsExceedingNetSellCap(ctx sdk.Context, token string) (deferred []types.SellOrder) {
+	for {
+		newlyDeferred := k.deferExcessSells(ctx, token)
+		if len(newlyDeferred) == 0 {
+			return deferred
+		}
+		deferred = append(deferred, newlyDeferred...)
+
+		if k.CancelUnfulfillableOrders(ctx, token) == 0 {
+			return deferred
+		}
+	}
+}
+
+func (k Keeper) deferExcessSells(ctx sdk.Context, token string) (deferred []types.SellOrder) {
+	bond := k.MustGetBond(ctx, token)
+	batch := k.MustGetBatch(ctx, token)
+
+	// Check if the net sell amount exceeds the cap (if any)
+	netSellCap, capped := bond.GetNetSellCap()
+	if !capped {
+		return nil
+	}
+	allowedSells := batch.TotalBuyAmount.Amount.Add(netSellCap)
+	totalSells := batch.TotalSellAmount.Amount
+	if totalSells.LTE(allowedSells) {
+		return nil
+	}
+	excess := totalSells.Sub(allowedSells)
+
+	// Defer the excess from each sell order pro-rata to its amount, with any
+	// remainder deferred one token at a time in order of arrival
+	weights := make([]sdk.Int, len(batch.Sells))
+	for i, so := range batch.Sells {
+		if so.IsCancelled() {
+			weights[i] = sdk.ZeroInt()
+		} else {
+			weights[i] = so.Amount.Amount
+		}
+	}
+	deferredAmounts := types.DistributeProRata(excess, weights)
+
+	// Reduce sell orders, dropping those that are deferred in their entirety
+	logger := k.Logger(ctx)
+	var remainingSells []types.SellOrder
+	for i, so := range batch.Sells {
+		if deferredAmounts[i].IsZero() {
+			remainingSells = append(remainingSells, so)
+			continue
+		}
+
+		deferredAmount := sdk.NewCoin(token, deferredAmounts[i])
+		deferredOrder := types.NewSellOrder(so.Address, deferredAmount)
+		deferredOrder.Memo = so.Memo
+		deferred = append(deferred, deferredOrder)
+		if deferredAmount.IsLT(so.Amount) {
+			so.Amount = so.Amount.Sub(deferredAmount)
+			remainingSells = append(remainingSells, so)
+		}
+
+		logger.Info(fmt.Sprintf("deferred sell order for %s from %s", deferredAmount.String(), so.Address.String()))
+
+		ctx.EventManager().EmitEvent(types.NewEvent(types.OrderDeferEvent{
+			Bond:           token,
+			OrderType:      types.AttributeValueSellOrder,
+			Address:        so.Address,
+			TokensDeferred: deferredAmount.Amount,
+			Memo:           so.Memo,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+	}
+	batch.Sells = remainingSells
+	batch.TotalSellAmount = batch.TotalSellAmount.Sub(sdk.NewCoin(token, excess))
+
+	// Update buy and sell prices given the reduced sells
+	buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
+	if err != nil {
+		panic(err)
+	}
+	batch.BuyPrices = buyPrices
+	batch.SellPrices = sellPrices
+
+	k.SetBatch(ctx, token, batch)
+	return deferred
+}
+
+// AddDeferredSellOrders adds sell orders deferred from a previous batch to the
+// current batch. The bond tokens being sold were already burned when the
+// orders were first placed, so there is nothing to collect from the sellers.
+func (k Keeper) AddDeferredSellOrders(ctx sdk.Context, token string, orders []types.SellOrder) {
+	for _, so := range orders {
+		buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterSell(ctx, token, so)
+		if err != nil {
+			// Panic here since deferred sells were already validated
+			// when they were first added to the previous batch
+			panic(err)
+		}
+		k.AddSellOrder(ctx, token, so, buyPrices, sellPrices)
+	}
+}
+
 func (k Keeper) PerformOrders(ctx sdk.Context, token string) {
 	k.PerformBuyOrders(ctx, token)
 	k.PerformSellOrders(ctx, token)
@@ -525,12 +750,13 @@ func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, token string,
 
 	reservePrices := types.MultiplyDecCoinsByInt(prices, bo.Amount.Amount)
 	reserveRounded := types.RoundReservePrices(reservePrices)
-	txFees := bond.GetTxFees(reservePrices)
+	txFees := bond.GetOrderFees(types.AttributeValueBuyOrder, reservePrices).Total
 	totalPrices := reserveRounded.Add(txFees...)
 
-	// Check that max prices not exceeded
-	if totalPrices.IsAnyGT(bo.MaxPrices) {
-		return sdkerrors.Wrapf(types.ErrMaxPriceExceeded, "Actual prices %s exceed max prices %s", totalPrices, bo.MaxPrices)
+	// Check that max prices not exceeded (each denom is an independent limit)
+	if exceeded := bo.DenomsExceedingMaxPrices(totalPrices); len(exceeded) > 0 {
+		return sdkerrors.Wrapf(types.ErrMaxPriceExceeded, "Actual prices %s exceed max prices %s for %s",
+			totalPrices, bo.MaxPrices, strings.Join(exceeded, ","))
 	}
 
 	return nil
@@ -538,6 +764,7 @@ func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, token string,
 
 func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelledOrders int) {
 	logger := k.Logger(ctx)
+	bond := k.MustGetBond(ctx, token)
 	batch := k.MustGetBatch(ctx, token)
 
 	// Cancel unfulfillable buys
@@ -554,20 +781,22 @@ func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelle
 				logger.Info(fmt.Sprintf("cancelled buy order for %s from %s", bo.Amount.String(), bo.Address.String()))
 				logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
 
-				ctx.EventManager().EmitEvent(sdk.NewEvent(
-					types.EventTypeOrderCancel,
-					sdk.NewAttribute(types.AttributeKeyBond, token),
-					sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
-					sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
-					sdk.NewAttribute(types.AttributeKeyCancelReason, bo.CancelReason),
-				))
-
-				// Return reserve to buyer
-				err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-					types.BatchesIntermediaryAccount, bo.Address, bo.MaxPrices)
+				ctx.EventManager().EmitEvent(types.NewEvent(types.OrderCancelEvent{
+					Bond:            token,
+					OrderType:       types.AttributeValueBuyOrder,
+					Address:         bo.Address,
+					CancelReason:    bo.CancelReason,
+					CallbackPayload: bo.CallbackPayload,
+					Memo:            bo.Memo,
+				}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+				// Return reserve (and any priority fee) to buyer
+				err := k.RefundEscrowedFunds(ctx, token,
+					bo.Address, bo.EscrowedFunds())
 				if err != nil {
 					panic(err)
 				}
+				k.ReleaseBuySpend(ctx, bo.Address, bo.Height, bo.MaxPrices)
 			}
 		}
 	}
@@ -600,3 +829,147 @@ func (k Keeper) CancelUnfulfillableOrders(ctx sdk.Context, token string) (cancel
 	k.SetBatch(ctx, token, batch)
 	return cancelledOrders
 }
+
+// SettleBatch performs the bond's current batch and saves it as the last
+// batch (along with its result), applies any milestones reached and any state
+// change caused by the new supply, and starts a new batch containing the buys
+// deferred by the value locked caps and the sells deferred by the net sell
+// cap. The batch's orders are performed at the batch's stored prices. If the
+// bond's current prices are specified, they are used as the spot price before
+// the batch instead of being computed. Refunds made during settlement are
+// accumulated per address and paid out at the end, in a single send per
+// address.
+func (k Keeper) SettleBatch(ctx sdk.Context, token string, currentPrices *BondPrices) {
+	// Start a new alert window before the batch changes the bond, if the
+	// previous window has ended
+	k.StartAlertWindowIfEnded(ctx, token)
+
+	// Accumulate any refunds until the batch has been settled
+	k.StartPendingRefunds(ctx, token)
+
+	bond := k.MustGetBond(ctx, token)
+	batch := k.MustGetBatch(ctx, token)
+
+	// Record the spot price and supply before the batch, against which the
+	// batch's price impact and volume are measured
+	if currentPrices == nil {
+		prices := BondPrices{}
+		prices.Prices, prices.Err = bond.GetCurrentPricesPT(bond.CurrentReserve)
+		currentPrices = &prices
+	}
+	spotPriceBefore := currentPrices.Prices
+	if currentPrices.Err != nil {
+		spotPriceBefore = nil
+	}
+	supplyBefore := bond.CurrentSupply.Amount
+
+	// Defer any buys exceeding the value locked caps to the next batch
+	deferredBuys := k.DeferBuysExceedingValueLockedCaps(ctx, bond.Token)
+
+	// Defer any sells exceeding the net sell cap to the next batch
+	deferredSells := k.DeferSellsExceedingNetSellCap(ctx, bond.Token)
+
+	// Defer any sells that would take the reserve below the min reserve
+	deferredSells = append(deferredSells, k.DeferSellsBelowMinReserve(ctx, bond.Token)...)
+
+	// Bring the demurrage index up to date before performing sells
+	k.AccrueDemurrage(ctx, bond.Token)
+
+	// Perform orders
+	k.PerformOrders(ctx, bond.Token)
+
+	// Get bond again just in case current supply was updated
+	// Get batch again just in case orders were cancelled
+	bond = k.MustGetBond(ctx, bond.Token)
+	batch = k.MustGetBatch(ctx, bond.Token)
+
+	// Mark the soft cap as reached by the new reserve, or fail the bond if
+	// its raise deadline has passed without reaching it
+	k.ApplySoftCap(ctx, bond.Token)
+
+	// Apply any milestones reached by the new reserve and get bond again
+	k.ApplyReachedMilestones(ctx, bond.Token)
+	bond = k.MustGetBond(ctx, bond.Token)
+
+	// For augmented, if hatch phase and newSupply >= S0, go to open phase
+	if bond.FunctionType == types.AugmentedFunction &&
+		bond.State == types.HatchState {
+		args := bond.FunctionParamsMap()
+		if bond.CurrentSupply.Amount.ToDec().GTE(args["S0"]) {
+			err := k.SetBondState(ctx, bond.Token, types.OpenState)
+			if err != nil {
+				panic(err)
+			}
+			bond = k.MustGetBond(ctx, bond.Token) // get bond again
+			bond.AllowSells = true                // enable sells
+			k.SetBond(ctx, bond.Token, bond)      // update bond
+		}
+	}
+
+	// Apply the bond's behaviour at max supply, if the new supply reached it
+	k.ApplyAtMaxSupplyBehavior(ctx, bond.Token)
+
+	// Lengthen (or shorten back) the bond's batches if the batch was stressed
+	// (or was not), which determines the length of the next batch
+	stressed := k.IsBatchStressed(ctx, bond, spotPriceBefore, supplyBefore,
+		batch.TotalBuyAmount.Amount, batch.TotalSellAmount.Amount)
+	k.UpdateBatchStress(ctx, bond, stressed)
+
+	// Save current batch as last batch (and its summarised result) and
+	// reset current batch
+	k.SetLastBatch(ctx, bond.Token, batch)
+	k.SetLastBatchResult(ctx, bond.Token, types.NewBatchResult(batch, ctx.BlockHeight()))
+	k.SetBatch(ctx, bond.Token, types.NewBatch(bond.Token, k.GetEffectiveBatchBlocks(ctx, bond)))
+
+	// Apply any pending edit of the bond's fees or sells now that the orders
+	// submitted under the previous terms have been performed
+	k.ApplyPendingBondEdit(ctx, bond.Token)
+
+	// Record the resulting supply and reserve in the bond's history
+	k.RecordBondSnapshot(ctx, bond.Token)
+
+	// Alert if the bond changed too much within the current alert window
+	k.CheckBondAlerts(ctx, bond.Token)
+
+	// Notify the relayers registered for notifications about the bond
+	k.NotifyRegisteredRelayers(ctx, bond.Token, batch)
+
+	// Add deferred buys and sells to the new batch
+	k.AddDeferredBuyOrders(ctx, bond.Token, deferredBuys)
+	k.AddDeferredSellOrders(ctx, bond.Token, deferredSells)
+
+	// Pay out the refunds accumulated during settlement
+	k.PayPendingRefunds(ctx, bond.Token)
+}
+
+// TrySettleBatch settles the bond's current batch like SettleBatch, but in a
+// cached context, so that none of the settlement's state changes are kept if
+// it panics (for example due to a reserve shortfall or a negative supply). In
+// that case the batch is restarted with its orders intact, so that settling
+// it is retried once the restarted batch is due, and an error is returned
+// instead of the panic being raised.
+func (k Keeper) TrySettleBatch(ctx sdk.Context, token string, currentPrices *BondPrices) (err error) {
+	cacheCtx, writeCache := ctx.CacheContext()
+	defer func() {
+		if r := recover(); r != nil {
+			err = sdkerrors.Wrapf(types.ErrBatchSettlementFailed, "%s: %v", token, r)
+			k.Logger(ctx).Error(err.Error())
+			k.restartBatch(ctx, token)
+		}
+	}()
+
+	k.SettleBatch(cacheCtx, token, currentPrices)
+
+	writeCache()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return nil
+}
+
+// restartBatch resets the blocks remaining of the bond's current batch to the
+// bond's effective batch blocks, so that the batch is due again after that
+// many blocks
+func (k Keeper) restartBatch(ctx sdk.Context, token string) {
+	batch := k.MustGetBatch(ctx, token)
+	batch.BlocksRemaining = k.GetEffectiveBatchBlocks(ctx, k.MustGetBond(ctx, token))
+	k.SetBatch(ctx, token, batch)
+}
@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetBatchStress returns the bond's batch stress, which is zero if none of
+// its recent batches were stressed.
+func (k Keeper) GetBatchStress(ctx sdk.Context, token string) (stress types.BatchStress) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetBatchStressKey(token))
+	if bz == nil {
+		return types.BatchStress{}
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &stress)
+	return stress
+}
+
+// SetBatchStress stores the bond's batch stress, or deletes it if it is zero.
+func (k Keeper) SetBatchStress(ctx sdk.Context, token string, stress types.BatchStress) {
+	store := ctx.KVStore(k.storeKey)
+	if stress.IsZero() {
+		store.Delete(types.GetBatchStressKey(token))
+		return
+	}
+	store.Set(types.GetBatchStressKey(token), k.cdc.MustMarshalBinaryBare(stress))
+}
+
+// GetEffectiveBatchBlocks returns the number of blocks of the bond's next
+// batch, i.e. its batch blocks lengthened by its batch stress (if any).
+func (k Keeper) GetEffectiveBatchBlocks(ctx sdk.Context, bond types.Bond) sdk.Uint {
+	return k.GetBatchStress(ctx, bond.Token).EffectiveBatchBlocks(bond.BatchBlocks)
+}
+
+// IsBatchStressed returns true if the price impact or volume of a batch
+// exceeds the stress thresholds, given the bond's spot price and supply before
+// the batch and the bond tokens bought and sold in the batch. A threshold of
+// zero is disabled.
+func (k Keeper) IsBatchStressed(ctx sdk.Context, bond types.Bond,
+	spotPriceBefore sdk.DecCoins, supplyBefore, bought, sold sdk.Int) bool {
+	priceImpactThreshold := k.StressPriceImpactPercentage(ctx)
+	if priceImpactThreshold.IsPositive() {
+		spotPrice, err := bond.GetCurrentPricesPT(bond.CurrentReserve)
+		if err == nil && types.GetChangePercentage(
+			spotPriceBefore, spotPrice).GT(priceImpactThreshold) {
+			return true
+		}
+	}
+
+	volumeThreshold := k.StressVolumePercentage(ctx)
+	if volumeThreshold.IsPositive() {
+		volume := types.GetVolumePercentage(bought, sold, supplyBefore)
+		if volume.GT(volumeThreshold) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UpdateBatchStress updates the bond's batch stress after one of its batches
+// was settled, given whether the batch was stressed, and emits an event if
+// the extra blocks by which the bond's batches are lengthened changed.
+func (k Keeper) UpdateBatchStress(ctx sdk.Context, bond types.Bond, stressed bool) {
+	stress := k.GetBatchStress(ctx, bond.Token)
+	if stress.IsZero() && !stressed {
+		return // nothing to update
+	}
+
+	updated := stress.Update(stressed, k.StressConsecutiveBatches(ctx),
+		k.StressExtraBlocks(ctx), k.StressMaxExtraBlocks(ctx))
+	k.SetBatchStress(ctx, bond.Token, updated)
+
+	if updated.ExtraBlocks == stress.ExtraBlocks {
+		return
+	}
+
+	effectiveBatchBlocks := updated.EffectiveBatchBlocks(bond.BatchBlocks)
+	k.Logger(ctx).Info(fmt.Sprintf("bond %s batches lengthened by %d blocks to %s blocks",
+		bond.Token, updated.ExtraBlocks, effectiveBatchBlocks))
+
+	ctx.EventManager().EmitEvent(
+		types.NewEvent(types.BatchIntervalEvent{
+			Bond:                 bond.Token,
+			BatchBlocks:          bond.BatchBlocks,
+			ExtraBlocks:          updated.ExtraBlocks,
+			EffectiveBatchBlocks: effectiveBatchBlocks,
+			StressedBatches:      updated.StressedBatches,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+	)
+}
@@ -49,6 +49,28 @@ func TestLastBatchExistsSetGet(t *testing.T) {
 	require.Equal(t, batchAdded, batchFetched)
 }
 
+func TestLastBatchResultExistsSetGet(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Last batch result doesn't exist yet
+	require.False(t, app.BondsKeeper.LastBatchResultExists(ctx, token))
+
+	// Add last batch result
+	resultAdded := types.NewBatchResult(getValidBatch(), ctx.BlockHeight())
+	app.BondsKeeper.SetLastBatchResult(ctx, token, resultAdded)
+
+	// Last batch result now exists (but this has nothing to do with batches)
+	require.True(t, app.BondsKeeper.LastBatchResultExists(ctx, token))
+	require.False(t, app.BondsKeeper.LastBatchExists(ctx, token))
+	require.False(t, app.BondsKeeper.BatchExists(ctx, token))
+
+	// Must get last batch result
+	resultFetched := app.BondsKeeper.MustGetLastBatchResult(ctx, token)
+
+	// Last batch result fetched is equal to added result
+	require.Equal(t, resultAdded, resultFetched)
+}
+
 func TestBatchAddBuyOrder(t *testing.T) {
 	app, ctx := createTestApp(false)
 
@@ -178,7 +200,8 @@ func TestGetBatchBuySellPrices(t *testing.T) {
 	batch.TotalSellAmount = batch.TotalSellAmount.Add(so.Amount)
 
 	// Calculate expected sell price
-	expectedReturns := bond.GetReturnsForBurn(so.Amount.Amount, reserveBalance)
+	expectedReturns, err := bond.GetReturnsForBurn(so.Amount.Amount, reserveBalance)
+	require.Nil(t, err)
 	require.NotNil(t, expectedReturns)
 	expectedSellPricesPerToken := types.DivideDecCoinsByDec(expectedReturns, fiveDec)
 
@@ -227,7 +250,7 @@ func TestGetBatchBuySellPrices(t *testing.T) {
 	batch.TotalSellAmount = batch.TotalSellAmount.Add(so1.Amount).Add(so2.Amount)
 
 	// Calculate expected sell price (for 5 [burn-price] + 5 [current-price] tokens)
-	expectedReturns1 := bond.GetReturnsForBurn(fiveTokens.Amount, reserveBalance)
+	expectedReturns1, err := bond.GetReturnsForBurn(fiveTokens.Amount, reserveBalance)
 	require.Nil(t, err)
 	require.NotNil(t, expectedReturns1)
 	expectedReturns2 := currentPrices.MulDec(fiveDec)
@@ -307,7 +330,8 @@ func TestGetUpdatedBatchPricesAfterSell(t *testing.T) {
 	so = types.NewSellOrder(sellerAddress, sellAmount)
 	buyPrices, sellPrices, err = app.BondsKeeper.GetUpdatedBatchPricesAfterSell(ctx, bond.Token, so)
 	expectedBuyPrices, _ := bond.GetCurrentPricesPT(nil)
-	expectedSellPrices := bond.GetReturnsForBurn(sellAmount.Amount, reserveBalance)
+	require.Nil(t, err)
+	expectedSellPrices, err := bond.GetReturnsForBurn(sellAmount.Amount, reserveBalance)
 	require.Nil(t, err)
 	require.Equal(t, expectedBuyPrices, buyPrices)
 	require.Equal(t, expectedSellPrices, sellPrices)
@@ -363,21 +387,21 @@ func TestPerformBuyAtPrice(t *testing.T) {
 		// Check expected prices
 		require.Equal(t, totalPrices.AmountOf(reserveToken), tc.expectedPrices)
 
-		// Add reserve tokens paid by buyer to module account address
-		moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
-		err := app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), tc.maxPrices)
+		// Add reserve tokens paid by buyer to escrow address
+		escrow := types.GetBondEscrowAddress(bond.Token)
+		err := app.BankKeeper.SetCoins(ctx, escrow, tc.maxPrices)
 		require.NoError(t, err)
 
 		// Previous values
 		prevSupplySDK := app.SupplyKeeper.GetSupply(ctx).GetTotal().AmountOf(bond.Token)
 		prevSupplyBonds := app.BondsKeeper.MustGetBond(ctx, bond.Token).CurrentSupply
-		prevModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
+		prevModuleAccBal := app.BankKeeper.GetCoins(ctx, escrow)
 		prevFeeAddrBal := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
 		prevBuyerBal := app.BankKeeper.GetCoins(ctx, buyerAddress)
 		prevReserveBal := app.BondsKeeper.GetReserveBalances(ctx, bond.Token)
 
 		// Perform buy
-		err = app.BondsKeeper.PerformBuyAtPrice(ctx, bond.Token, bo, buyPrices)
+		refund, err := app.BondsKeeper.PerformBuyAtPrice(ctx, bond.Token, bo, buyPrices)
 
 		// Check if buy is fulfillable (i.e. if maxPrices >= totalPrices)
 		if tc.fulfillable {
@@ -391,11 +415,12 @@ func TestPerformBuyAtPrice(t *testing.T) {
 		remainderForBuyer := tc.maxPrices.Sub(totalPrices)
 		tokensBought := sdk.NewCoin(bond.Token, tc.amount)
 		increaseInBuyerBal := sdk.Coins{tokensBought}.Add(remainderForBuyer...)
+		require.Equal(t, remainderForBuyer, refund)
 
 		// New values
 		newSupplySDK := app.SupplyKeeper.GetSupply(ctx).GetTotal().AmountOf(bond.Token)
 		newSupplyBonds := app.BondsKeeper.MustGetBond(ctx, bond.Token).CurrentSupply
-		newModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
+		newModuleAccBal := app.BankKeeper.GetCoins(ctx, escrow)
 		newFeeAddrBal := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
 		newBuyerBal := app.BankKeeper.GetCoins(ctx, buyerAddress)
 		newReserveBal := app.BondsKeeper.GetReserveBalances(ctx, bond.Token)
@@ -472,21 +497,21 @@ func TestPerformBuyAtPriceAugmentedFunction(t *testing.T) {
 		// Check expected prices
 		require.Equal(t, totalPrices.AmountOf(reserveToken), tc.expectedPrices)
 
-		// Add reserve tokens paid by buyer to module account address
-		moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
-		err := app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), tc.maxPrices)
+		// Add reserve tokens paid by buyer to escrow address
+		escrow := types.GetBondEscrowAddress(bond.Token)
+		err := app.BankKeeper.SetCoins(ctx, escrow, tc.maxPrices)
 		require.NoError(t, err)
 
 		// Previous values
 		prevSupplySDK := app.SupplyKeeper.GetSupply(ctx).GetTotal().AmountOf(bond.Token)
 		prevSupplyBonds := app.BondsKeeper.MustGetBond(ctx, bond.Token).CurrentSupply
-		prevModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
+		prevModuleAccBal := app.BankKeeper.GetCoins(ctx, escrow)
 		prevFeeAddrBal := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
 		prevBuyerBal := app.BankKeeper.GetCoins(ctx, buyerAddress)
 		prevReserveBal := app.BondsKeeper.GetReserveBalances(ctx, bond.Token)
 
 		// Perform buy
-		err = app.BondsKeeper.PerformBuyAtPrice(ctx, bond.Token, bo, buyPrices)
+		refund, err := app.BondsKeeper.PerformBuyAtPrice(ctx, bond.Token, bo, buyPrices)
 
 		// Check if buy is fulfillable (i.e. if maxPrices >= totalPrices)
 		if tc.fulfillable {
@@ -500,11 +525,12 @@ func TestPerformBuyAtPriceAugmentedFunction(t *testing.T) {
 		remainderForBuyer := tc.maxPrices.Sub(totalPrices)
 		tokensBought := sdk.NewCoin(bond.Token, tc.amount)
 		increaseInBuyerBal := sdk.Coins{tokensBought}.Add(remainderForBuyer...)
+		require.Equal(t, remainderForBuyer, refund)
 
 		// New values
 		newSupplySDK := app.SupplyKeeper.GetSupply(ctx).GetTotal().AmountOf(bond.Token)
 		newSupplyBonds := app.BondsKeeper.MustGetBond(ctx, bond.Token).CurrentSupply
-		newModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
+		newModuleAccBal := app.BankKeeper.GetCoins(ctx, escrow)
 		newFeeAddrBal := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
 		newBuyerBal := app.BankKeeper.GetCoins(ctx, buyerAddress)
 		newReserveBal := app.BondsKeeper.GetReserveBalances(ctx, bond.Token)
@@ -689,9 +715,9 @@ func TestPerformSwap(t *testing.T) {
 			ctx, bond.Token, types.BondsMintBurnAccount, startingReserves)
 		require.NoError(t, err)
 
-		// Add reserve tokens sent by swapper to module account address
-		moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
-		err = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), fromAmounts)
+		// Add reserve tokens sent by swapper to escrow address
+		escrow := types.GetBondEscrowAddress(bond.Token)
+		err = app.BankKeeper.SetCoins(ctx, escrow, fromAmounts)
 		require.NoError(t, err)
 
 		// Calculations
@@ -702,13 +728,13 @@ func TestPerformSwap(t *testing.T) {
 		totalOuts := sdk.Coins{types.RoundReserveReturn(sdk.NewDecCoinFromCoin(tc.outReserve).Sub(newOutReserveDec))} // out of reserves (i.e. returns)
 
 		// Previous values
-		prevModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
+		prevModuleAccBal := app.BankKeeper.GetCoins(ctx, escrow)
 		prevReserveBal := app.BondsKeeper.GetReserveBalances(ctx, bond.Token)
 		prevFeeAddrBal := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
 		prevSwapperBal := app.BankKeeper.GetCoins(ctx, swapperAddress)
 
 		// Perform swap
-		err, ok := app.BondsKeeper.PerformSwap(ctx, bond.Token, so)
+		err, ok := app.BondsKeeper.PerformSwapOrder(ctx, bond.Token, so)
 		require.True(t, ok)
 
 		// Check if error due to violated sanity rate
@@ -720,7 +746,7 @@ func TestPerformSwap(t *testing.T) {
 		}
 
 		// New values
-		newModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
+		newModuleAccBal := app.BankKeeper.GetCoins(ctx, escrow)
 		newReserveBal := app.BondsKeeper.GetReserveBalances(ctx, bond.Token)
 		newFeeAddrBal := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
 		newSwapperBal := app.BankKeeper.GetCoins(ctx, swapperAddress)
@@ -767,8 +793,8 @@ func TestPerformBuys(t *testing.T) {
 		}, // 12 * 100 = 1200 <= 2000
 	}
 
-	// Add reserve tokens paid by buyer to module account address
-	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	// Add reserve tokens paid by buyer to escrow address
+	escrow := types.GetBondEscrowAddress(bond.Token)
 
 	globalTotalPrices := sdk.NewCoins()
 	globalIncreaseInBuyerBal := sdk.NewCoins()
@@ -788,7 +814,7 @@ func TestPerformBuys(t *testing.T) {
 		globalTotalPrices = globalTotalPrices.Add(totalPrices...)
 
 		// Add coins paid by buyer
-		_, err := app.BankKeeper.AddCoins(ctx, moduleAcc.GetAddress(), tc.maxPrices)
+		_, err := app.BankKeeper.AddCoins(ctx, escrow, tc.maxPrices)
 		require.NoError(t, err)
 
 		// Calculate increase in buyer balance
@@ -805,7 +831,7 @@ func TestPerformBuys(t *testing.T) {
 	// New values
 	newSupplySDK := app.SupplyKeeper.GetSupply(ctx).GetTotal().AmountOf(bond.Token)
 	newSupplyBonds := app.BondsKeeper.MustGetBond(ctx, bond.Token).CurrentSupply
-	newModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
+	newModuleAccBal := app.BankKeeper.GetCoins(ctx, escrow)
 	newBuyerBal := app.BankKeeper.GetCoins(ctx, buyerAddress)
 
 	require.Equal(t, globalTokensBought.Amount, newSupplySDK)
@@ -929,7 +955,7 @@ func TestPerformSwaps(t *testing.T) {
 	globalReserveBal := initialReserves
 
 	// Previous values
-	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	escrow := types.GetBondEscrowAddress(bond.Token)
 
 	// Add swap orders
 	for _, tc := range testCases {
@@ -944,8 +970,8 @@ func TestPerformSwaps(t *testing.T) {
 		so := types.NewSwapOrder(swapperAddress, fromAmount, tc.toToken)
 		app.BondsKeeper.AddSwapOrder(ctx, token, so)
 
-		// Add reserve tokens sent by swapper to module account address
-		_, err = app.BankKeeper.AddCoins(ctx, moduleAcc.GetAddress(), fromAmounts)
+		// Add reserve tokens sent by swapper to escrow address
+		_, err = app.BankKeeper.AddCoins(ctx, escrow, fromAmounts)
 		require.NoError(t, err)
 
 		if tc.willGetCancelled {
@@ -966,7 +992,7 @@ func TestPerformSwaps(t *testing.T) {
 	app.BondsKeeper.PerformSwapOrders(ctx, token)
 
 	// New balances
-	newModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
+	newModuleAccBal := app.BankKeeper.GetCoins(ctx, escrow)
 	newReserveBal := app.BondsKeeper.GetReserveBalances(ctx, bond.Token)
 	newSwapperBal := app.BankKeeper.GetCoins(ctx, sellerAddress)
 
@@ -1033,6 +1059,32 @@ func TestCheckIfBuyOrderFulfillableAtPrice(t *testing.T) {
 	}
 }
 
+func TestCheckIfBuyOrderFulfillableAtPriceIdentifiesExceededDenom(t *testing.T) {
+	app, ctx := createTestApp(false)
+	bond := getValidBond()
+	bond.ReserveTokens = swapperReserves()
+	bond.TxFeePercentage = sdk.ZeroDec()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+
+	// Limit for reserveToken is sufficient but limit for reserveToken2 is not
+	buyPrices := sdk.DecCoins{
+		sdk.NewInt64DecCoin(reserveToken, 100),
+		sdk.NewInt64DecCoin(reserveToken2, 100),
+	}
+	maxPrices := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 999),
+	)
+	amount := sdk.NewCoin(bond.Token, sdk.NewInt(10))
+	bo := types.NewBuyOrder(buyerAddress, amount, maxPrices)
+
+	err := app.BondsKeeper.CheckIfBuyOrderFulfillableAtPrice(
+		ctx, bond.Token, bo, buyPrices)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "for "+reserveToken2)
+	require.NotContains(t, err.Error(), "for "+reserveToken)
+}
+
 func TestCancelUnfulfillableBuys(t *testing.T) {
 	app, ctx := createTestApp(false)
 	bond := getValidBond()
@@ -1075,10 +1127,10 @@ func TestCancelUnfulfillableBuys(t *testing.T) {
 		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices)
 		app.BondsKeeper.AddBuyOrder(ctx, bond.Token, bo, buyPrices, blankSellPrices)
 
-		// Add reserve tokens to module account address for return if cancel
-		moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
-		_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), tc.maxPrices)
-		require.Equal(t, tc.maxPrices, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+		// Add reserve tokens to escrow address for return if cancel
+		escrow := types.GetBondEscrowAddress(bond.Token)
+		_ = app.BankKeeper.SetCoins(ctx, escrow, tc.maxPrices)
+		require.Equal(t, tc.maxPrices, app.BankKeeper.GetCoins(ctx, escrow))
 
 		// Check that order added to batch and that it's not cancelled
 		batch := app.BondsKeeper.MustGetBatch(ctx, bond.Token)
@@ -1106,7 +1158,7 @@ func TestCancelUnfulfillableBuys(t *testing.T) {
 			require.Equal(t, buyPrices, batch.BuyPrices)
 
 			// Check that balances unchanged
-			require.Equal(t, tc.maxPrices, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+			require.Equal(t, tc.maxPrices, app.BankKeeper.GetCoins(ctx, escrow))
 			require.Equal(t, balanceBefore, app.BankKeeper.GetCoins(ctx, buyerAddress))
 		} else {
 			// Check that cancelled
@@ -1116,12 +1168,37 @@ func TestCancelUnfulfillableBuys(t *testing.T) {
 
 			// Check that reserve tokens returned to buyer
 			newBalance := balanceBefore.Add(tc.maxPrices...)
-			require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+			require.Empty(t, app.BankKeeper.GetCoins(ctx, escrow))
 			require.Equal(t, newBalance, app.BankKeeper.GetCoins(ctx, buyerAddress))
 		}
 	}
 }
 
+func TestCancelUnfulfillableBuysReleasesSpendOfCancelledBuys(t *testing.T) {
+	app, ctx := createTestApp(false)
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+
+	// Add a buy submitted at height 3 whose max prices were recorded against
+	// the buyer's spend cap, along with an earlier spend record of the buyer
+	buyPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 100)}
+	maxPrices := sdk.Coins{sdk.NewInt64Coin(reserveToken, 1100)}
+	earlierSpend := types.NewSpendRecord(1, sdk.Coins{sdk.NewInt64Coin(reserveToken, 500)})
+	app.BondsKeeper.SetSpendRecords(ctx, buyerAddress, types.SpendRecords{
+		earlierSpend, types.NewSpendRecord(3, maxPrices)})
+	bo := types.NewBuyOrder(buyerAddress, sdk.NewInt64Coin(bond.Token, 12), maxPrices)
+	bo.Height = 3
+	app.BondsKeeper.AddBuyOrder(ctx, bond.Token, bo, buyPrices, sdk.NewDecCoinsFromCoins())
+	_ = app.BankKeeper.SetCoins(ctx, types.GetBondEscrowAddress(bond.Token), maxPrices)
+
+	// The buy is cancelled, since (12 * 100) = 1200 > 1100, and its max
+	// prices are no longer counted towards the buyer's spend cap
+	require.Equal(t, 1, app.BondsKeeper.CancelUnfulfillableBuys(ctx, bond.Token))
+	require.Equal(t, types.SpendRecords{earlierSpend},
+		app.BondsKeeper.GetSpendRecords(ctx, buyerAddress))
+}
+
 func TestCancelUnfulfillableOrders(t *testing.T) {
 	app, ctx := createTestApp(false)
 	bond := getValidBond()
@@ -1165,10 +1242,10 @@ func TestCancelUnfulfillableOrders(t *testing.T) {
 		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices)
 		app.BondsKeeper.AddBuyOrder(ctx, bond.Token, bo, buyPrices, blankSellPrices)
 
-		// Add reserve tokens to module account address for return if cancel
-		moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
-		_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), tc.maxPrices)
-		require.Equal(t, tc.maxPrices, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+		// Add reserve tokens to escrow address for return if cancel
+		escrow := types.GetBondEscrowAddress(bond.Token)
+		_ = app.BankKeeper.SetCoins(ctx, escrow, tc.maxPrices)
+		require.Equal(t, tc.maxPrices, app.BankKeeper.GetCoins(ctx, escrow))
 
 		// Check that order added to batch and that it's not cancelled
 		batch := app.BondsKeeper.MustGetBatch(ctx, bond.Token)
@@ -1196,7 +1273,7 @@ func TestCancelUnfulfillableOrders(t *testing.T) {
 			require.Equal(t, buyPrices, batch.BuyPrices)
 
 			// Check that balances unchanged
-			require.Equal(t, tc.maxPrices, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+			require.Equal(t, tc.maxPrices, app.BankKeeper.GetCoins(ctx, escrow))
 			require.Equal(t, balanceBefore, app.BankKeeper.GetCoins(ctx, buyerAddress))
 		} else {
 			// Check that cancelled
@@ -1206,7 +1283,7 @@ func TestCancelUnfulfillableOrders(t *testing.T) {
 
 			// Check that reserve tokens returned to buyer
 			newBalance := balanceBefore.Add(tc.maxPrices...)
-			require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+			require.Empty(t, app.BankKeeper.GetCoins(ctx, escrow))
 			require.Equal(t, newBalance, app.BankKeeper.GetCoins(ctx, buyerAddress))
 		}
 	}
@@ -0,0 +1,254 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetNextBondProposalID returns the ID that will be assigned to the next bond
+// proposal. IDs are unique across all bonds and start from 1.
+func (k Keeper) GetNextBondProposalID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.NextBondProposalIDKey) {
+		return 1
+	}
+
+	bz := store.Get(types.NextBondProposalIDKey)
+	var proposalID uint64
+	k.cdc.MustUnmarshalBinaryBare(bz, &proposalID)
+
+	return proposalID
+}
+
+func (k Keeper) SetNextBondProposalID(ctx sdk.Context, proposalID uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.NextBondProposalIDKey, k.cdc.MustMarshalBinaryBare(proposalID))
+}
+
+func (k Keeper) GetBondProposalIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.BondProposalsKeyPrefix)
+}
+
+func (k Keeper) GetBondProposal(ctx sdk.Context, proposalID uint64) (proposal types.BondProposal, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetBondProposalKey(proposalID)) {
+		return types.BondProposal{}, false
+	}
+
+	bz := store.Get(types.GetBondProposalKey(proposalID))
+	k.cdc.MustUnmarshalBinaryBare(bz, &proposal)
+
+	return proposal, true
+}
+
+func (k Keeper) GetBondProposals(ctx sdk.Context) (proposals []types.BondProposal) {
+	iterator := k.GetBondProposalIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var proposal types.BondProposal
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &proposal)
+		proposals = append(proposals, proposal)
+	}
+	return proposals
+}
+
+func (k Keeper) GetBondProposalsByBond(ctx sdk.Context, token string) (proposals []types.BondProposal) {
+	for _, proposal := range k.GetBondProposals(ctx) {
+		if proposal.BondToken == token {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals
+}
+
+func (k Keeper) SetBondProposal(ctx sdk.Context, proposal types.BondProposal) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetBondProposalKey(proposal.ProposalID),
+		k.cdc.MustMarshalBinaryBare(proposal))
+}
+
+// SubmitBondProposal assigns the next bond proposal ID to a new proposal,
+// stores the proposal, and increments the next bond proposal ID.
+func (k Keeper) SubmitBondProposal(ctx sdk.Context, bond types.Bond,
+	proposer sdk.AccAddress, title, description, proposalType string,
+	fundingRecipient sdk.AccAddress, fundingAmount sdk.Coins) types.BondProposal {
+	proposalID := k.GetNextBondProposalID(ctx)
+	votingEndHeight := ctx.BlockHeight() + int64(bond.ProposalVotingBlocks)
+	proposal := types.NewBondProposal(proposalID, bond.Token, proposer, title,
+		description, proposalType, fundingRecipient, fundingAmount, votingEndHeight)
+
+	k.SetBondProposal(ctx, proposal)
+	k.SetNextBondProposalID(ctx, proposalID+1)
+
+	return proposal
+}
+
+func (k Keeper) GetBondProposalVote(ctx sdk.Context, proposalID uint64,
+	voter sdk.AccAddress) (vote types.BondProposalVote, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetBondProposalVoteKey(proposalID, voter)) {
+		return types.BondProposalVote{}, false
+	}
+
+	bz := store.Get(types.GetBondProposalVoteKey(proposalID, voter))
+	k.cdc.MustUnmarshalBinaryBare(bz, &vote)
+
+	return vote, true
+}
+
+func (k Keeper) GetBondProposalVotes(ctx sdk.Context, proposalID uint64) (votes []types.BondProposalVote) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetBondProposalVotesKey(proposalID))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var vote types.BondProposalVote
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+func (k Keeper) GetAllBondProposalVotes(ctx sdk.Context) (votes []types.BondProposalVote) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.BondProposalVotesPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var vote types.BondProposalVote
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+func (k Keeper) SetBondProposalVote(ctx sdk.Context, vote types.BondProposalVote) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetBondProposalVoteKey(vote.ProposalID, vote.Voter),
+		k.cdc.MustMarshalBinaryBare(vote))
+}
+
+func (k Keeper) DeleteBondProposalVote(ctx sdk.Context, proposalID uint64, voter sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetBondProposalVoteKey(proposalID, voter))
+}
+
+// VoteOnBondProposal casts a vote on a bond proposal with a voting power equal
+// to the voter's bond token balance. The bond tokens are sent to the bond
+// proposals account and are only returned once the proposal is tallied.
+func (k Keeper) VoteOnBondProposal(ctx sdk.Context, proposal types.BondProposal,
+	voter sdk.AccAddress, option string) (types.BondProposalVote, error) {
+
+	power := k.BankKeeper.GetCoins(ctx, voter).AmountOf(proposal.BondToken)
+	if !power.IsPositive() {
+		return types.BondProposalVote{}, types.ErrNoBondTokensOwned
+	}
+
+	err := k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, voter,
+		types.BondProposalsAccount, sdk.Coins{sdk.NewCoin(proposal.BondToken, power)})
+	if err != nil {
+		return types.BondProposalVote{}, err
+	}
+
+	vote := types.NewBondProposalVote(proposal.ProposalID, voter, option, power)
+	k.SetBondProposalVote(ctx, vote)
+
+	if option == types.VoteOptionYes {
+		proposal.YesVotes = proposal.YesVotes.Add(power)
+	} else {
+		proposal.NoVotes = proposal.NoVotes.Add(power)
+	}
+	k.SetBondProposal(ctx, proposal)
+
+	return vote, nil
+}
+
+// TallyDueBondProposals tallies every bond proposal whose voting period ends
+// at the current height, executes the passed funding proposals, and returns
+// the bond tokens of every vote cast on the tallied proposals to the voters.
+func (k Keeper) TallyDueBondProposals(ctx sdk.Context) {
+	// Collect due proposals first, since the store cannot be written to while
+	// it is being iterated over
+	var dueProposals []types.BondProposal
+	for _, proposal := range k.GetBondProposals(ctx) {
+		if proposal.IsDue(ctx.BlockHeight()) {
+			dueProposals = append(dueProposals, proposal)
+		}
+	}
+
+	quorum := k.BondProposalQuorum(ctx)
+	for _, proposal := range dueProposals {
+		proposal.Status = k.tallyBondProposal(ctx, proposal, quorum)
+		k.SetBondProposal(ctx, proposal)
+
+		// Return bond tokens to voters
+		for _, vote := range k.GetBondProposalVotes(ctx, proposal.ProposalID) {
+			err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+				types.BondProposalsAccount, vote.Voter,
+				sdk.Coins{sdk.NewCoin(proposal.BondToken, vote.Power)})
+			if err != nil {
+				// Should never happen, since the bond proposals account
+				// holds the bond tokens of every vote that was cast
+				panic(err)
+			}
+			k.DeleteBondProposalVote(ctx, proposal.ProposalID, vote.Voter)
+		}
+
+		// Get bond for its event attributes (empty if the bond does not exist)
+		bond, _ := k.GetBond(ctx, proposal.BondToken)
+
+		logger := k.Logger(ctx)
+		logger.Info(fmt.Sprintf("tallied bond proposal %d for %s with %s yes votes and %s no votes: %s",
+			proposal.ProposalID, proposal.BondToken, proposal.YesVotes,
+			proposal.NoVotes, proposal.Status))
+
+		ctx.EventManager().EmitEvent(types.NewEvent(types.TallyBondProposalEvent{
+			Bond:           proposal.BondToken,
+			ProposalID:     proposal.ProposalID,
+			YesVotes:       proposal.YesVotes,
+			NoVotes:        proposal.NoVotes,
+			ProposalStatus: proposal.Status,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+	}
+}
+
+// tallyBondProposal returns the resulting status of a bond proposal, and
+// executes the proposal if it passed and is a funding proposal. A funding
+// proposal can only be executed while the bond is open and its reserve
+// covers the funding amount, otherwise the proposal is marked as failed.
+func (k Keeper) tallyBondProposal(ctx sdk.Context,
+	proposal types.BondProposal, quorum sdk.Dec) string {
+	bond, found := k.GetBond(ctx, proposal.BondToken)
+	if !found {
+		return types.BondProposalStatusFailed
+	} else if !proposal.Passes(bond.CurrentSupply.Amount, quorum) {
+		return types.BondProposalStatusRejected
+	} else if proposal.ProposalType != types.BondProposalTypeFunding {
+		return types.BondProposalStatusPassed
+	}
+
+	if err := bond.CheckStateAllows(types.ActionWithdrawFunds); err != nil {
+		k.logBondProposalFailure(ctx, proposal, err)
+		return types.BondProposalStatusFailed
+	} else if !proposal.FundingAmount.IsAllLTE(bond.CurrentReserve) {
+		k.logBondProposalFailure(ctx, proposal, sdkerrors.Wrapf(
+			sdkerrors.ErrInsufficientFunds, "%s exceeds reserve %s",
+			proposal.FundingAmount, bond.CurrentReserve))
+		return types.BondProposalStatusFailed
+	}
+
+	err := k.WithdrawReserve(ctx, bond.Token,
+		proposal.FundingRecipient, proposal.FundingAmount)
+	if err != nil {
+		k.logBondProposalFailure(ctx, proposal, err)
+		return types.BondProposalStatusFailed
+	}
+	return types.BondProposalStatusPassed
+}
+
+func (k Keeper) logBondProposalFailure(ctx sdk.Context, proposal types.BondProposal, err error) {
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond proposal %d for %s passed but could not be executed: %s",
+		proposal.ProposalID, proposal.BondToken, err.Error()))
+}
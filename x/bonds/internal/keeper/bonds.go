@@ -3,6 +3,7 @@ package keeper
 import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 )
 
@@ -18,7 +19,7 @@ func (k Keeper) GetBond(ctx sdk.Context, token string) (bond types.Bond, found b
 	}
 	bz := store.Get(types.GetBondKey(token))
 	k.cdc.MustUnmarshalBinaryBare(bz, &bond)
-	return bond, true
+	return bond.WithFunctionParamsCache(k.functionParamsCache), true
 }
 
 func (k Keeper) MustGetBond(ctx sdk.Context, token string) types.Bond {
@@ -39,7 +40,13 @@ func (k Keeper) MustGetBondByKey(ctx sdk.Context, key []byte) types.Bond {
 	var bond types.Bond
 	k.cdc.MustUnmarshalBinaryBare(bz, &bond)
 
-	return bond
+	return bond.WithFunctionParamsCache(k.functionParamsCache)
+}
+
+// InvalidateFunctionParamsCache removes the cached parsed function parameters
+// of the bond with the specified token, if any.
+func (k Keeper) InvalidateFunctionParamsCache(token string) {
+	k.functionParamsCache.Invalidate(token)
 }
 
 func (k Keeper) BondExists(ctx sdk.Context, token string) bool {
@@ -47,12 +54,58 @@ func (k Keeper) BondExists(ctx sdk.Context, token string) bool {
 	return store.Has(types.GetBondKey(token))
 }
 
+// CheckFeeAddress checks that the fee address of the bond with the specified
+// token is neither one of the bonds module accounts nor the escrow account of
+// any bond (including the bond itself), so that fees never get mixed up with
+// the reserve or with the funds of pending orders.
+func (k Keeper) CheckFeeAddress(ctx sdk.Context, token string, feeAddress sdk.AccAddress) error {
+	if err := types.CheckFeeAddress(feeAddress); err != nil {
+		return err
+	} else if feeAddress.Equals(types.GetBondEscrowAddress(token)) {
+		return sdkerrors.Wrapf(types.ErrFeeAddressCannotBeModuleAccount, "escrow account of %s", token)
+	}
+
+	iterator := k.GetBondIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		bond := k.MustGetBondByKey(ctx, iterator.Key())
+		if feeAddress.Equals(types.GetBondEscrowAddress(bond.Token)) {
+			return sdkerrors.Wrapf(types.ErrFeeAddressCannotBeModuleAccount, "escrow account of %s", bond.Token)
+		}
+	}
+	return nil
+}
+
+func (k Keeper) IsNonTransferable(ctx sdk.Context, token string) bool {
+	bond, found := k.GetBond(ctx, token)
+	return found && bond.NonTransferable
+}
+
 func (k Keeper) SetBond(ctx sdk.Context, token string, bond types.Bond) {
+	oldBond, exists := k.GetBond(ctx, token)
+	k.updateStatsForBond(ctx, oldBond, exists, bond)
+
+	// Only update search index if the name or description changed
+	if !exists || oldBond.Name != bond.Name || oldBond.Description != bond.Description {
+		k.setBondSearchIndexEntry(ctx, token, bond)
+	}
+
 	store := ctx.KVStore(k.storeKey)
 	store.Set(types.GetBondKey(token), k.cdc.MustMarshalBinaryBare(bond))
 }
 
 func (k Keeper) DepositReserve(ctx sdk.Context, token string, from sdk.AccAddress, amount sdk.Coins) error {
+	err := k.depositReserve(ctx, token, from, amount)
+	if err != nil {
+		return err
+	}
+
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryReserveIn,
+		types.LedgerAccountReserve, types.LedgerAccountExternal, amount, from)
+	return nil
+}
+
+func (k Keeper) depositReserve(ctx sdk.Context, token string, from sdk.AccAddress, amount sdk.Coins) error {
 	// Send tokens to bonds reserve account
 	err := k.SupplyKeeper.SendCoinsFromAccountToModule(
 		ctx, from, types.BondsReserveAccount, amount)
@@ -79,11 +132,41 @@ func (k Keeper) DepositReserveFromModule(ctx sdk.Context, token string,
 	// Update bond reserve
 	k.setReserveBalances(ctx, token,
 		k.MustGetBond(ctx, token).CurrentReserve.Add(amount...))
+
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryReserveIn,
+		types.LedgerAccountReserve, types.LedgerAccountExternal, amount,
+		k.SupplyKeeper.GetModuleAddress(fromModule))
 	return nil
 }
 
 func (k Keeper) WithdrawReserve(ctx sdk.Context, token string,
 	to sdk.AccAddress, amount sdk.Coins) error {
+	err := k.withdrawReserve(ctx, token, to, amount)
+	if err != nil {
+		return err
+	}
+
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryReserveOut,
+		types.LedgerAccountExternal, types.LedgerAccountReserve, amount, to)
+	return nil
+}
+
+// PayFeesFromReserve sends fees (or other funds owed to the bond's fee
+// address, such as demurrage) from the bond's reserve to its fee address.
+func (k Keeper) PayFeesFromReserve(ctx sdk.Context, token string, amount sdk.Coins) error {
+	feeAddress := k.MustGetBond(ctx, token).FeeAddress
+	err := k.withdrawReserve(ctx, token, feeAddress, amount)
+	if err != nil {
+		return err
+	}
+
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryFee,
+		types.LedgerAccountExternal, types.LedgerAccountReserve, amount, feeAddress)
+	return nil
+}
+
+func (k Keeper) withdrawReserve(ctx sdk.Context, token string,
+	to sdk.AccAddress, amount sdk.Coins) error {
 
 	// Send tokens from bonds reserve account
 	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(
@@ -131,19 +214,24 @@ func (k Keeper) SetCurrentSupply(ctx sdk.Context, token string, currentSupply sd
 	k.SetBond(ctx, token, bond)
 }
 
-func (k Keeper) SetBondState(ctx sdk.Context, token string, newState string) {
+// SetBondState moves the bond to the new state, returning an error if the
+// bond cannot move from its current state to the new state.
+func (k Keeper) SetBondState(ctx sdk.Context, token string, newState string) error {
 	bond := k.MustGetBond(ctx, token)
 	previousState := bond.State
+	if err := types.CheckBondStateTransition(previousState, newState); err != nil {
+		return err
+	}
 	bond.State = newState
 	k.SetBond(ctx, token, bond)
 
 	logger := k.Logger(ctx)
 	logger.Info(fmt.Sprintf("updated state for %s from %s to %s", bond.Token, previousState, newState))
 
-	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		types.EventTypeStateChange,
-		sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
-		sdk.NewAttribute(types.AttributeKeyOldState, previousState),
-		sdk.NewAttribute(types.AttributeKeyNewState, newState),
-	))
+	ctx.EventManager().EmitEvent(types.NewEvent(types.StateChangeEvent{
+		Bond:     bond.Token,
+		OldState: previousState,
+		NewState: newState,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+	return nil
 }
@@ -32,10 +32,10 @@ func TestBondExistsSetGet(t *testing.T) {
 	// Option 2: must get bond
 	bondFetched3 := app.BondsKeeper.MustGetBondByKey(ctx, types.GetBondKey(token))
 
-	// Batch fetched is equal to added batch
-	require.EqualValues(t, bondAdded, bondFetched1)
-	require.EqualValues(t, bondAdded, bondFetched2)
-	require.EqualValues(t, bondAdded, bondFetched3)
+	// Bond fetched is equal to added bond (ignoring the keeper's cache)
+	require.EqualValues(t, bondAdded, bondFetched1.WithFunctionParamsCache(nil))
+	require.EqualValues(t, bondAdded, bondFetched2.WithFunctionParamsCache(nil))
+	require.EqualValues(t, bondAdded, bondFetched3.WithFunctionParamsCache(nil))
 	require.True(t, found)
 }
 
@@ -303,19 +303,22 @@ func TestSetBondState(t *testing.T) {
 	// State is initially "initState"
 	require.Equal(t, initState, app.BondsKeeper.MustGetBond(ctx, token).State)
 
+	// Cannot move to a state that is not a bond state
+	err := app.BondsKeeper.SetBondState(ctx, token, "some_other_state")
+	require.Error(t, err)
+	require.True(t, types.ErrInvalidBondState.Is(err))
+	require.Equal(t, initState, app.BondsKeeper.MustGetBond(ctx, token).State)
+
 	// Change state
-	newState := "some_other_state"
-	app.BondsKeeper.SetBondState(ctx, token, newState)
+	require.Nil(t, app.BondsKeeper.SetBondState(ctx, token, types.SettleState))
 
 	// Check that state changed
 	stateFetched := app.BondsKeeper.MustGetBond(ctx, token).State
-	require.Equal(t, newState, stateFetched)
+	require.Equal(t, types.SettleState, stateFetched)
 
-	// Change supply again
-	newState = "yet another state"
-	app.BondsKeeper.SetBondState(ctx, token, newState)
-
-	// Check that supply changed
-	stateFetched = app.BondsKeeper.MustGetBond(ctx, token).State
-	require.Equal(t, newState, stateFetched)
+	// Cannot move back from the final SETTLE state
+	err = app.BondsKeeper.SetBondState(ctx, token, types.OpenState)
+	require.Error(t, err)
+	require.True(t, types.ErrInvalidBondState.Is(err))
+	require.Equal(t, types.SettleState, app.BondsKeeper.MustGetBond(ctx, token).State)
 }
@@ -35,6 +35,8 @@ var (
 	initSanityRate             = sdk.MustNewDecFromStr(blankSanityRate)
 	initSanityMarginPercentage = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
 	initAllowSell              = true
+	initNonTransferable        = false
+	initRequireAttestation     = false
 	initSigners                = []sdk.AccAddress{initCreator}
 	initBatchBlocks            = sdk.NewUint(10)
 	initOutcomePayment         = sdk.Coins(nil)
@@ -132,7 +134,8 @@ func getValidPowerFunctionBond() types.Bond {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initNonTransferable, initRequireAttestation, initSigners,
+		initBatchBlocks, initOutcomePayment, nil, 0, nil, initState)
 }
 
 func getValidAugmentedFunctionBond() types.Bond {
@@ -143,7 +146,8 @@ func getValidAugmentedFunctionBond() types.Bond {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initNonTransferable, initRequireAttestation, initSigners,
+		initBatchBlocks, initOutcomePayment, nil, 0, nil, initState)
 }
 
 func getValidSwapperBond() types.Bond {
@@ -154,7 +158,8 @@ func getValidSwapperBond() types.Bond {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initNonTransferable, initRequireAttestation, initSigners,
+		initBatchBlocks, initOutcomePayment, nil, 0, nil, initState)
 }
 
 func getValidBond() types.Bond {
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+var _ types.ReserveConverter = NoOpReserveConverter{}
+
+// NoOpReserveConverter is the default reserve converter, which does not
+// support any derivative tokens. This means that reserve contributions can
+// only be made in the reserve tokens themselves unless an actual reserve
+// converter is set.
+type NoOpReserveConverter struct{}
+
+func (NoOpReserveConverter) GetConversionRate(_ sdk.Context, _ string) (string, sdk.Dec, bool) {
+	return "", sdk.Dec{}, false
+}
+
+func (NoOpReserveConverter) ConvertToUnderlying(_ sdk.Context, _ sdk.AccAddress, amount sdk.Coin) (sdk.Coin, error) {
+	return sdk.Coin{}, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, amount.Denom)
+}
+
+// ConvertReserveContribution converts any amounts in the contribution that are
+// not in one of the bond's reserve tokens, but in a derivative of one of them
+// supported by the reserve converter, into the underlying reserve token. The
+// conversion is performed on behalf of the address, which ends up holding the
+// underlying tokens instead of the derivative tokens. The contribution in
+// terms of the underlying reserve tokens is returned. Amounts that are not
+// convertible are returned unchanged.
+func (k Keeper) ConvertReserveContribution(ctx sdk.Context, bond types.Bond,
+	address sdk.AccAddress, contribution sdk.Coins) (converted sdk.Coins, err error) {
+
+	logger := k.Logger(ctx)
+	for _, c := range contribution {
+		if bond.IsReserveToken(c.Denom) {
+			converted = converted.Add(c)
+			continue
+		}
+
+		underlyingDenom, _, found := k.ReserveConverter.GetConversionRate(ctx, c.Denom)
+		if !found || !bond.IsReserveToken(underlyingDenom) {
+			converted = converted.Add(c)
+			continue
+		}
+
+		underlying, err := k.ReserveConverter.ConvertToUnderlying(ctx, address, c)
+		if err != nil {
+			return nil, err
+		} else if underlying.Denom != underlyingDenom {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins,
+				"converted %s to %s; expected %s", c, underlying, underlyingDenom)
+		}
+		converted = converted.Add(underlying)
+
+		logger.Info(fmt.Sprintf("converted %s to %s for %s",
+			c, underlying, address))
+
+		ctx.EventManager().EmitEvent(
+			types.NewEvent(types.ConvertReserveEvent{
+				Bond:       bond.Token,
+				Address:    address,
+				Derivative: c,
+				Underlying: underlying,
+			}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+		)
+	}
+	return converted, nil
+}
@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// ChargeBondCreationFee charges the bond creation fee (if any) to the creator
+// of a new bond, and either burns the fee or sends it to the community pool,
+// depending on the creation fee destination. The charged fee is returned.
+func (k Keeper) ChargeBondCreationFee(ctx sdk.Context, creator sdk.AccAddress) (sdk.Coins, error) {
+	fee := k.BondCreationFee(ctx)
+	if fee.IsZero() {
+		return sdk.Coins{}, nil
+	}
+
+	switch k.CreationFeeDestination(ctx) {
+	case types.CreationFeeDestinationCommunityPool:
+		err := k.DistrKeeper.FundCommunityPool(ctx, fee, creator)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		// Send fee to be burned from creator (enforces fee <= balance)
+		err := k.SupplyKeeper.SendCoinsFromAccountToModule(
+			ctx, creator, types.BondsMintBurnAccount, fee)
+		if err != nil {
+			return nil, err
+		}
+
+		// Burn fee
+		err = k.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount, fee)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fee, nil
+}
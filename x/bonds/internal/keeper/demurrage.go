@@ -0,0 +1,20 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AccrueDemurrage brings the bond's demurrage index up to date, decaying it by
+// the bond's demurrage rate for every block since it was last updated. This
+// has no effect for bonds without demurrage.
+func (k Keeper) AccrueDemurrage(ctx sdk.Context, token string) {
+	bond := k.MustGetBond(ctx, token)
+	if !bond.GetDemurrageRate().IsPositive() ||
+		bond.DemurrageHeight >= ctx.BlockHeight() {
+		return
+	}
+
+	bond.DemurrageIndex = bond.GetDemurrageIndexAt(ctx.BlockHeight())
+	bond.DemurrageHeight = ctx.BlockHeight()
+	k.SetBond(ctx, token, bond)
+}
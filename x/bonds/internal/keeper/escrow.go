@@ -0,0 +1,136 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetEscrowBalance returns the funds held by the escrow account of the bond.
+func (k Keeper) GetEscrowBalance(ctx sdk.Context, token string) sdk.Coins {
+	return k.BankKeeper.GetCoins(ctx, types.GetBondEscrowAddress(token))
+}
+
+// GetExpectedEscrowBalance returns the funds that the escrow account of the
+// bond is expected to hold, i.e. the max prices and priority fee of every
+// pending buy and the amount of every pending swap in the bond's current batch.
+func (k Keeper) GetExpectedEscrowBalance(ctx sdk.Context, token string) sdk.Coins {
+	expected := sdk.Coins{}
+	batch := k.MustGetBatch(ctx, token)
+	for _, bo := range batch.Buys {
+		if !bo.IsCancelled() {
+			expected = expected.Add(bo.EscrowedFunds()...)
+		}
+	}
+	for _, so := range batch.Swaps {
+		if !so.IsCancelled() {
+			expected = expected.Add(so.Amount)
+		}
+	}
+	return expected
+}
+
+// EscrowOrderFunds takes the funds of a pending order of the bond from the
+// sender and holds them in the bond's escrow account.
+func (k Keeper) EscrowOrderFunds(ctx sdk.Context, token string,
+	from sdk.AccAddress, amount sdk.Coins) error {
+	err := k.BankKeeper.SendCoins(
+		ctx, from, types.GetBondEscrowAddress(token), amount)
+	if err != nil {
+		return err
+	}
+
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryEscrowIn,
+		types.LedgerAccountEscrow, types.LedgerAccountExternal, amount, from)
+	return nil
+}
+
+// ReleaseEscrowedFunds sends funds held by the bond's escrow account to the
+// recipient, e.g. to pay a hatch buy's funding to the fee address.
+func (k Keeper) ReleaseEscrowedFunds(ctx sdk.Context, token string,
+	to sdk.AccAddress, amount sdk.Coins) error {
+	err := k.releaseEscrowedFunds(ctx, token, to, amount)
+	if err != nil {
+		return err
+	}
+
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryEscrowOut,
+		types.LedgerAccountExternal, types.LedgerAccountEscrow, amount, to)
+	return nil
+}
+
+// PayFeesFromEscrow sends fees held by the bond's escrow account to the bond's
+// fee address.
+func (k Keeper) PayFeesFromEscrow(ctx sdk.Context, token string, amount sdk.Coins) error {
+	feeAddress := k.MustGetBond(ctx, token).FeeAddress
+	err := k.releaseEscrowedFunds(ctx, token, feeAddress, amount)
+	if err != nil {
+		return err
+	}
+
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryFee,
+		types.LedgerAccountExternal, types.LedgerAccountEscrow, amount, feeAddress)
+	return nil
+}
+
+func (k Keeper) releaseEscrowedFunds(ctx sdk.Context, token string,
+	to sdk.AccAddress, amount sdk.Coins) error {
+	return k.BankKeeper.SendCoins(
+		ctx, types.GetBondEscrowAddress(token), to, amount)
+}
+
+// DepositReserveFromEscrow adds funds held by the bond's escrow account to the
+// bond's reserve.
+func (k Keeper) DepositReserveFromEscrow(ctx sdk.Context, token string,
+	amount sdk.Coins) error {
+	escrow := types.GetBondEscrowAddress(token)
+	err := k.depositReserve(ctx, token, escrow, amount)
+	if err != nil {
+		return err
+	}
+
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryReserveIn,
+		types.LedgerAccountReserve, types.LedgerAccountEscrow, amount, nil)
+	return nil
+}
+
+// MigrateEscrowedFunds moves the funds of the pending orders of every bond
+// from the batches intermediary account, which used to hold the funds of the
+// pending orders of all bonds, to the bond's own escrow account. Only the
+// shortfall of each escrow account is moved, so this has no effect once the
+// funds have been migrated.
+func (k Keeper) MigrateEscrowedFunds(ctx sdk.Context) {
+	intermediary := k.SupplyKeeper.GetModuleAddress(types.BatchesIntermediaryAccount)
+
+	iterator := k.GetBondIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		bond := k.MustGetBondByKey(ctx, iterator.Key())
+		expected := k.GetExpectedEscrowBalance(ctx, bond.Token)
+		balance := k.GetEscrowBalance(ctx, bond.Token)
+		available := k.BankKeeper.GetCoins(ctx, intermediary)
+
+		// Move as much of the shortfall as the intermediary account holds
+		shortfall := sdk.Coins{}
+		for _, c := range expected {
+			missing := c.Amount.Sub(balance.AmountOf(c.Denom))
+			if missing.GT(available.AmountOf(c.Denom)) {
+				missing = available.AmountOf(c.Denom)
+			}
+			if missing.IsPositive() {
+				shortfall = shortfall.Add(sdk.NewCoin(c.Denom, missing))
+			}
+		}
+		if shortfall.IsZero() {
+			continue
+		}
+
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount,
+			types.GetBondEscrowAddress(bond.Token), shortfall)
+		if err != nil {
+			panic(err)
+		}
+		k.RecordLedgerEntry(ctx, bond.Token, types.LedgerEntryEscrowIn,
+			types.LedgerAccountEscrow, types.LedgerAccountExternal, shortfall, intermediary)
+	}
+}
@@ -0,0 +1,73 @@
+package keeper_test
+
+import (
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEscrowIsPerBond(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add two bonds, each with an empty batch
+	otherToken := "othertoken"
+	otherBond := getValidBond()
+	otherBond.Token = otherToken
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.SetBond(ctx, otherToken, otherBond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	app.BondsKeeper.SetBatch(ctx, otherToken, types.NewBatch(otherToken, batchBlocks))
+	require.NotEqual(t,
+		types.GetBondEscrowAddress(token), types.GetBondEscrowAddress(otherToken))
+
+	// Escrow the max prices of a buy order for the first bond
+	_, err := app.BankKeeper.AddCoins(ctx, buyerAddress, maxPrices)
+	require.Nil(t, err)
+	err = app.BondsKeeper.EscrowOrderFunds(ctx, token, buyerAddress, maxPrices)
+	require.Nil(t, err)
+	app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+
+	// Only the escrow of the first bond holds the funds
+	require.Equal(t, maxPrices, app.BondsKeeper.GetEscrowBalance(ctx, token))
+	require.True(t, app.BondsKeeper.GetEscrowBalance(ctx, otherToken).IsZero())
+	require.Equal(t, maxPrices, app.BondsKeeper.GetExpectedEscrowBalance(ctx, token))
+	_, broken := keeper.EscrowInvariant(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+
+	// The other bond cannot release funds escrowed for the first bond
+	err = app.BondsKeeper.ReleaseEscrowedFunds(ctx, otherToken, buyerAddress, maxPrices)
+	require.Error(t, err)
+
+	// Releasing the first bond's funds without cancelling the order breaks
+	// the escrow invariant
+	err = app.BondsKeeper.ReleaseEscrowedFunds(ctx, token, buyerAddress, maxPrices)
+	require.Nil(t, err)
+	_, broken = keeper.EscrowInvariant(app.BondsKeeper)(ctx)
+	require.True(t, broken)
+}
+
+func TestMigrateEscrowedFunds(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add bond and batch with a pending buy order, with max prices held by
+	// the batches intermediary account, as before per-bond escrow
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, maxPrices)
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, types.BondsMintBurnAccount, types.BatchesIntermediaryAccount, maxPrices)
+	require.Nil(t, err)
+
+	// Funds are moved to the bond's escrow
+	app.BondsKeeper.MigrateEscrowedFunds(ctx)
+	intermediary := app.SupplyKeeper.GetModuleAddress(types.BatchesIntermediaryAccount)
+	require.True(t, app.BankKeeper.GetCoins(ctx, intermediary).IsZero())
+	require.Equal(t, maxPrices, app.BondsKeeper.GetEscrowBalance(ctx, token))
+
+	// Migrating again has no effect
+	app.BondsKeeper.MigrateEscrowedFunds(ctx)
+	require.Equal(t, maxPrices, app.BondsKeeper.GetEscrowBalance(ctx, token))
+}
@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetFeeDust returns the address' spendable balances that are below the fee
+// dust threshold, excluding the target denomination and any non-transferable
+// bond tokens, which cannot be swapped or sent to the community pool.
+func (k Keeper) GetFeeDust(ctx sdk.Context, address sdk.AccAddress, targetDenom string) sdk.Coins {
+	account := k.accountKeeper.GetAccount(ctx, address)
+	if account == nil {
+		return sdk.Coins{}
+	}
+
+	threshold := k.FeeDustThreshold(ctx)
+	dust := sdk.Coins{}
+	for _, c := range account.SpendableCoins(ctx.BlockHeader().Time) {
+		if c.Denom == targetDenom || !c.IsPositive() || c.Amount.GTE(threshold) {
+			continue
+		} else if k.IsNonTransferable(ctx, c.Denom) {
+			continue
+		}
+		dust = dust.Add(c)
+	}
+	return dust
+}
+
+// getDustSwapperBond returns the first open swapper bond that swaps between
+// the dust denomination and the target denomination, if any.
+func (k Keeper) getDustSwapperBond(ctx sdk.Context, dustDenom, targetDenom string) (bond types.Bond, found bool) {
+	reserve := sdk.NewCoins(
+		sdk.NewCoin(dustDenom, sdk.OneInt()),
+		sdk.NewCoin(targetDenom, sdk.OneInt()))
+
+	iterator := k.GetBondIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		bond := k.MustGetBondByKey(ctx, iterator.Key())
+		if types.IsSwapperFunctionType(bond.FunctionType) &&
+			bond.State == types.OpenState && bond.ReserveDenomsEqualTo(reserve) {
+			return bond, true
+		}
+	}
+	return types.Bond{}, false
+}
+
+// SweepFeeDust consolidates the address' fee dust. Each dust balance is
+// submitted as a swap to the target denomination through an open swapper bond
+// if the target denomination is not blank and such a bond exists. Any dust
+// that is not swapped is sent to the community pool. The caller module is
+// empty if the sweep is from a MsgSweepFeeDust.
+func (k Keeper) SweepFeeDust(ctx sdk.Context, address sdk.AccAddress, targetDenom,
+	callerModule string) (swapped, sentToCommunityPool sdk.Coins, err error) {
+
+	dust := k.GetFeeDust(ctx, address, targetDenom)
+	if dust.Empty() {
+		return nil, nil, types.ErrNoFeeDustToSweep
+	}
+
+	swapped = sdk.Coins{}
+	sentToCommunityPool = sdk.Coins{}
+	for _, d := range dust {
+		if targetDenom != "" {
+			if bond, found := k.getDustSwapperBond(ctx, d.Denom, targetDenom); found {
+				// Submit the swap in a cache context so that a failed swap
+				// (for example due to order quantity limits) leaves no trace
+				cacheCtx, writeCache := ctx.CacheContext()
+				msg := types.NewMsgSwap(address, bond.Token, d, targetDenom)
+				if _, err := k.SubmitSwap(cacheCtx, msg, callerModule); err == nil {
+					writeCache()
+					ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+					swapped = swapped.Add(d)
+					continue
+				}
+			}
+		}
+		sentToCommunityPool = sentToCommunityPool.Add(d)
+	}
+
+	if !sentToCommunityPool.Empty() {
+		err = k.DistrKeeper.FundCommunityPool(ctx, sentToCommunityPool, address)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("swept fee dust of %s; swapped %s to %s and sent %s to community pool",
+		address.String(), swapped, targetDenom, sentToCommunityPool))
+
+	ctx.EventManager().EmitEvent(types.NewEvent(types.SweepFeeDustEvent{
+		Address:             address,
+		TargetDenom:         targetDenom,
+		Swapped:             swapped,
+		SentToCommunityPool: sentToCommunityPool,
+		CallerModule:        callerModule,
+	}))
+
+	return swapped, sentToCommunityPool, nil
+}
+
+// SweepFeeDustOfFeeAddresses sweeps the fee dust of every bond's fee address
+// into the fee dust denomination, every fee dust sweep blocks blocks. A failed
+// sweep of one fee address does not affect the sweeps of the others.
+func (k Keeper) SweepFeeDustOfFeeAddresses(ctx sdk.Context) {
+	blocks := k.FeeDustSweepBlocks(ctx)
+	if blocks == 0 || uint64(ctx.BlockHeight())%blocks != 0 {
+		return
+	}
+
+	// Collect the unique fee addresses in the order of the bonds' tokens
+	var feeAddresses []sdk.AccAddress
+	seen := make(map[string]bool)
+	iterator := k.GetBondIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		bond := k.MustGetBondByKey(ctx, iterator.Key())
+		if !seen[bond.FeeAddress.String()] {
+			seen[bond.FeeAddress.String()] = true
+			feeAddresses = append(feeAddresses, bond.FeeAddress)
+		}
+	}
+	iterator.Close()
+
+	targetDenom := k.FeeDustDenom(ctx)
+	for _, address := range feeAddresses {
+		cacheCtx, writeCache := ctx.CacheContext()
+		_, _, err := k.SweepFeeDust(cacheCtx, address, targetDenom, types.ModuleName)
+		if err != nil {
+			continue
+		}
+		writeCache()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+}
@@ -0,0 +1,97 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetBondHistory returns the bond's most recent snapshots, from oldest to
+// newest, or an empty history if none were recorded yet.
+func (k Keeper) GetBondHistory(ctx sdk.Context, token string) types.BondHistory {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetBondHistoryKey(token)) {
+		return types.BondHistory{}
+	}
+
+	bz := store.Get(types.GetBondHistoryKey(token))
+	var history types.BondHistory
+	k.cdc.MustUnmarshalBinaryBare(bz, &history)
+
+	return history
+}
+
+// GetBondHistories returns the histories of all bonds, along with their tokens.
+func (k Keeper) GetBondHistories(ctx sdk.Context) (histories []types.BondHistoryEntry) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.BondHistoriesKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var history types.BondHistory
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &history)
+		token := string(iterator.Key()[len(types.BondHistoriesKeyPrefix):])
+		histories = append(histories, types.NewBondHistoryEntry(token, history))
+	}
+	return histories
+}
+
+func (k Keeper) SetBondHistory(ctx sdk.Context, token string, history types.BondHistory) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetBondHistoryKey(token), k.cdc.MustMarshalBinaryBare(history))
+}
+
+// RecordBondSnapshot adds a snapshot of the bond's current supply, reserve,
+// prices, and total fees collected to the bond's history, along with the
+// volumes of the bond's last batch if it was performed at the current height.
+// The prices are left out if they cannot be calculated (e.g. for a swapper
+// bond without any liquidity).
+func (k Keeper) RecordBondSnapshot(ctx sdk.Context, token string) {
+	bond := k.MustGetBond(ctx, token)
+	prices, err := bond.GetCurrentPricesPT(k.GetReserveBalances(ctx, token))
+	if err != nil {
+		prices = nil
+	}
+
+	snapshot := types.NewBondSnapshot(bond, prices, ctx.BlockHeight())
+	snapshot.FeesCollected = k.GetBondFeesCollected(ctx, token)
+	if k.LastBatchResultExists(ctx, token) {
+		result := k.MustGetLastBatchResult(ctx, token)
+		if result.Height == ctx.BlockHeight() {
+			snapshot = snapshot.WithBatchResult(result)
+		}
+	}
+
+	history := k.GetBondHistory(ctx, token)
+	history = history.Add(snapshot)
+	k.SetBondHistory(ctx, token, history)
+}
+
+// GetBondAtHeight reconstructs the bond's supply, reserve, and prices at the
+// last batch boundary at or before the height, from the bond's history. Since
+// the history is kept in the current state, this does not require the state
+// at the height itself, which pruned nodes do not keep. For snapshots that
+// were recorded without prices, the prices are calculated from the snapshot's
+// supply and reserve using the bond's current function.
+func (k Keeper) GetBondAtHeight(ctx sdk.Context, token string, height int64) (types.QueryBondAtHeight, error) {
+	bond, found := k.GetBond(ctx, token)
+	if !found {
+		return types.QueryBondAtHeight{}, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	}
+
+	snapshot, found := k.GetBondHistory(ctx, token).AtHeight(height)
+	if !found {
+		return types.QueryBondAtHeight{}, sdkerrors.Wrapf(types.ErrBondHistoryNotAvailable,
+			"%s has no history at or before height %d", token, height)
+	}
+
+	if snapshot.Prices.Empty() {
+		bond.CurrentSupply = snapshot.Supply
+		bond.CurrentReserve = snapshot.Reserve
+		prices, err := bond.GetCurrentPricesPT(snapshot.Reserve)
+		if err == nil {
+			snapshot.Prices = prices
+		}
+	}
+
+	return types.NewQueryBondAtHeight(token, height, snapshot), nil
+}
@@ -16,6 +16,10 @@ func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
 		SupplyInvariant(k))
 	ir.RegisterRoute(types.ModuleName, "bonds-reserve",
 		ReserveInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-escrow",
+		EscrowInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-ledger",
+		LedgerInvariant(k))
 }
 
 // AllInvariants runs all invariants of the bonds module.
@@ -25,7 +29,15 @@ func AllInvariants(k Keeper) sdk.Invariant {
 		if stop {
 			return res, stop
 		}
-		return ReserveInvariant(k)(ctx)
+		res, stop = ReserveInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = EscrowInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return LedgerInvariant(k)(ctx)
 	}
 }
 
@@ -88,7 +100,7 @@ func ReserveInvariant(k Keeper) sdk.Invariant {
 			denom := bond.Token
 
 			if bond.FunctionType == types.AugmentedFunction ||
-				bond.FunctionType == types.SwapperFunction {
+				types.IsSwapperFunctionType(bond.FunctionType) {
 				continue // Check does not apply to augmented/swapper functions
 			}
 
@@ -113,3 +125,80 @@ func ReserveInvariant(k Keeper) sdk.Invariant {
 			"%d Bonds reserve invariants broken\n%s", count, msg)), broken
 	}
 }
+
+func EscrowInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		iterator := k.GetBondIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			bond := k.MustGetBondByKey(ctx, iterator.Key())
+			denom := bond.Token
+
+			// The escrow can hold more than expected, since anyone can send
+			// funds to its address, but never less
+			expected := k.GetExpectedEscrowBalance(ctx, denom)
+			actual := k.GetEscrowBalance(ctx, denom)
+			if !actual.IsAllGTE(expected) {
+				count++
+				msg += fmt.Sprintf("%s escrow invariance:\n"+
+					"\texpected %s escrow: %s\n"+
+					"\tactual %s escrow: %s\n",
+					denom, denom, expected.String(),
+					denom, actual.String())
+			}
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "escrow", fmt.Sprintf(
+			"%d Bonds escrow invariants broken\n%s", count, msg)), broken
+	}
+}
+
+func LedgerInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		for _, ledger := range k.GetLedgers(ctx) {
+			denom := ledger.Bond
+
+			if !ledger.IsBalanced() {
+				count++
+				msg += fmt.Sprintf("%s ledger invariance:\n"+
+					"\t%s ledger debits do not equal its credits\n",
+					denom, denom)
+			}
+
+			// The reserve is only ever changed by recorded movements of funds
+			ledgerReserve := ledger.Balance(types.LedgerAccountReserve).Net()
+			actualReserve := k.GetReserveBalances(ctx, denom)
+			if !ledgerReserve.IsAllGTE(actualReserve) || !actualReserve.IsAllGTE(ledgerReserve) {
+				count++
+				msg += fmt.Sprintf("%s ledger reserve invariance:\n"+
+					"\tledger %s reserve: %s\n"+
+					"\tactual %s reserve: %s\n",
+					denom, denom, ledgerReserve.String(),
+					denom, actualReserve.String())
+			}
+
+			// The escrow can hold more than recorded, since anyone can send
+			// funds to its address, but never less
+			ledgerEscrow := ledger.Balance(types.LedgerAccountEscrow).Net()
+			actualEscrow := k.GetEscrowBalance(ctx, denom)
+			if !actualEscrow.IsAllGTE(ledgerEscrow) {
+				count++
+				msg += fmt.Sprintf("%s ledger escrow invariance:\n"+
+					"\tledger %s escrow: %s\n"+
+					"\tactual %s escrow: %s\n",
+					denom, denom, ledgerEscrow.String(),
+					denom, actualEscrow.String())
+			}
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "ledger", fmt.Sprintf(
+			"%d Bonds ledger invariants broken\n%s", count, msg)), broken
+	}
+}
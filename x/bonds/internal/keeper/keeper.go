@@ -6,6 +6,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
@@ -13,35 +15,95 @@ import (
 )
 
 type Keeper struct {
-	BankKeeper    bank.Keeper
-	SupplyKeeper  supply.Keeper
-	accountKeeper auth.AccountKeeper
-	StakingKeeper staking.Keeper
+	BankKeeper        bank.Keeper
+	SupplyKeeper      supply.Keeper
+	accountKeeper     auth.AccountKeeper
+	StakingKeeper     staking.Keeper
+	DistrKeeper       distribution.Keeper
+	AttestationKeeper types.AttestationKeeper
+	ReserveConverter  types.ReserveConverter
+	OracleKeeper      types.OracleKeeper
+	UpgradeKeeper     types.UpgradeKeeper
 
-	storeKey sdk.StoreKey
+	storeKey   sdk.StoreKey
+	paramSpace params.Subspace
+
+	// functionParamsCache is used by all bonds read by the keeper to avoid
+	// re-parsing the function parameters on every price calculation. It is
+	// shared by all copies of the keeper, since the keeper is passed around
+	// by value.
+	functionParamsCache *types.FunctionParamsCache
 
 	cdc *codec.Codec
 }
 
 func NewKeeper(bankKeeper bank.Keeper, supplyKeeper supply.Keeper,
 	accountKeeper auth.AccountKeeper, stakingKeeper staking.Keeper,
-	storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
+	distrKeeper distribution.Keeper, storeKey sdk.StoreKey,
+	paramSpace params.Subspace, cdc *codec.Codec) Keeper {
 
 	// ensure batches module account is set
 	if addr := supplyKeeper.GetModuleAddress(types.BatchesIntermediaryAccount); addr == nil {
 		panic(fmt.Sprintf("%s module account has not been set", types.BatchesIntermediaryAccount))
 	}
 
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
 	return Keeper{
-		BankKeeper:    bankKeeper,
-		SupplyKeeper:  supplyKeeper,
-		accountKeeper: accountKeeper,
-		StakingKeeper: stakingKeeper,
-		storeKey:      storeKey,
-		cdc:           cdc,
+		BankKeeper:        bankKeeper,
+		SupplyKeeper:      supplyKeeper,
+		accountKeeper:     accountKeeper,
+		StakingKeeper:     stakingKeeper,
+		DistrKeeper:       distrKeeper,
+		AttestationKeeper: NoOpAttestationKeeper{},
+		ReserveConverter:  NoOpReserveConverter{},
+		OracleKeeper:      NoOpOracleKeeper{},
+		UpgradeKeeper:     NoOpUpgradeKeeper{},
+		storeKey:          storeKey,
+		paramSpace:        paramSpace,
+		functionParamsCache: types.NewFunctionParamsCache(
+			types.DefaultFunctionParamsCacheSize),
+		cdc: cdc,
 	}
 }
 
+// SetAttestationKeeper sets the attestation keeper consulted for bonds that
+// require an attestation. It must be called before the keeper is passed to
+// the module, since the keeper is passed around by value.
+func (k *Keeper) SetAttestationKeeper(ak types.AttestationKeeper) *Keeper {
+	k.AttestationKeeper = ak
+	return k
+}
+
+// SetReserveConverter sets the reserve converter used to convert derivative
+// tokens contributed to bond reserves into their underlying tokens. It must be
+// called before the keeper is passed to the module, since the keeper is passed
+// around by value.
+func (k *Keeper) SetReserveConverter(rc types.ReserveConverter) *Keeper {
+	k.ReserveConverter = rc
+	return k
+}
+
+// SetOracleKeeper sets the oracle keeper that provides the market exchange
+// rates consulted by rebalance swaps. It must be called before the keeper is
+// passed to the module, since the keeper is passed around by value.
+func (k *Keeper) SetOracleKeeper(ok types.OracleKeeper) *Keeper {
+	k.OracleKeeper = ok
+	return k
+}
+
+// SetUpgradeKeeper sets the upgrade keeper that provides the scheduled
+// software upgrade, before which bond batches are settled. It must be called
+// before the keeper is passed to the module, since the keeper is passed
+// around by value.
+func (k *Keeper) SetUpgradeKeeper(uk types.UpgradeKeeper) *Keeper {
+	k.UpgradeKeeper = uk
+	return k
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
@@ -0,0 +1,139 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetLedger(ctx sdk.Context, token string) (ledger types.BondLedger, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetLedgerKey(token)) {
+		return types.BondLedger{}, false
+	}
+
+	bz := store.Get(types.GetLedgerKey(token))
+	k.cdc.MustUnmarshalBinaryBare(bz, &ledger)
+
+	return ledger, true
+}
+
+func (k Keeper) GetLedgers(ctx sdk.Context) (ledgers []types.BondLedger) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.LedgersKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var ledger types.BondLedger
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &ledger)
+		ledgers = append(ledgers, ledger)
+	}
+	return ledgers
+}
+
+func (k Keeper) SetLedger(ctx sdk.Context, ledger types.BondLedger) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetLedgerKey(ledger.Bond), k.cdc.MustMarshalBinaryBare(ledger))
+}
+
+func (k Keeper) SetLedgerEntry(ctx sdk.Context, entry types.LedgerEntry) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetLedgerEntryKey(entry.Bond, entry.Sequence),
+		k.cdc.MustMarshalBinaryBare(entry))
+	store.Set(types.GetLedgerEntryHeightKey(entry.Height, entry.Bond, entry.Sequence),
+		[]byte{})
+}
+
+// PruneLedgerEntries deletes the ledger entries recorded more than the ledger
+// entry retention blocks ago, unless the retention blocks are zero, in which
+// case ledger entries are kept forever. The ledgers' balances are unaffected.
+func (k Keeper) PruneLedgerEntries(ctx sdk.Context) {
+	retentionBlocks := k.LedgerEntryRetentionBlocks(ctx)
+	if retentionBlocks == 0 || ctx.BlockHeight() <= int64(retentionBlocks) {
+		return
+	}
+	cutoff := ctx.BlockHeight() - int64(retentionBlocks)
+
+	// Collect the keys of the entries to prune first, since the store cannot
+	// be written to while it is being iterated over
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.LedgerHeightsKeyPrefix, types.GetLedgerHeightKey(cutoff+1))
+	var heightKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		heightKeys = append(heightKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	prefixLength := len(types.GetLedgerHeightKey(0))
+	for _, key := range heightKeys {
+		entryKey := append([]byte{}, types.LedgerEntriesKeyPrefix...)
+		store.Delete(append(entryKey, key[prefixLength:]...))
+		store.Delete(key)
+	}
+}
+
+// GetLedgerEntries returns the bond's ledger entries, from oldest to newest.
+func (k Keeper) GetLedgerEntries(ctx sdk.Context, token string) (entries []types.LedgerEntry) {
+	return k.getLedgerEntries(ctx, types.GetBondLedgerEntriesKey(token), 0, 0)
+}
+
+// GetLedgerEntriesPage returns the page of the bond's ledger entries with the
+// specified limit, from oldest to newest. Pages start from 1.
+func (k Keeper) GetLedgerEntriesPage(ctx sdk.Context, token string,
+	page, limit int) (entries []types.LedgerEntry) {
+	return k.getLedgerEntries(ctx, types.GetBondLedgerEntriesKey(token),
+		uint64((page-1)*limit), limit)
+}
+
+func (k Keeper) GetAllLedgerEntries(ctx sdk.Context) (entries []types.LedgerEntry) {
+	return k.getLedgerEntries(ctx, types.LedgerEntriesKeyPrefix, 0, 0)
+}
+
+// getLedgerEntries returns up to the limit of the entries under the prefix
+// (or all of them if the limit is zero), skipping the specified number of
+// entries first.
+func (k Keeper) getLedgerEntries(ctx sdk.Context, prefix []byte,
+	skip uint64, limit int) (entries []types.LedgerEntry) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		if skip > 0 {
+			skip--
+			continue
+		} else if limit > 0 && len(entries) == limit {
+			break
+		}
+		var entry types.LedgerEntry
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &entry)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// OpenLedger starts recording the movements of funds of the bond in its
+// ledger. Bonds created before ledgers were introduced do not have a ledger,
+// since their earlier movements of funds were never recorded.
+func (k Keeper) OpenLedger(ctx sdk.Context, token string) {
+	if _, found := k.GetLedger(ctx, token); !found {
+		k.SetLedger(ctx, types.NewBondLedger(token))
+	}
+}
+
+// RecordLedgerEntry records a movement of the bond's funds from the credit
+// account to the debit account in the bond's ledger, and updates the ledger's
+// running balances. Nothing is recorded if the amount is zero or if the bond
+// does not have a ledger.
+func (k Keeper) RecordLedgerEntry(ctx sdk.Context, token, kind, debit,
+	credit string, amount sdk.Coins, address sdk.AccAddress) {
+	if amount.IsZero() {
+		return
+	}
+	ledger, found := k.GetLedger(ctx, token)
+	if !found {
+		return
+	}
+
+	entry := types.NewLedgerEntry(token, ledger.NextSequence,
+		ctx.BlockHeight(), kind, debit, credit, amount, address)
+	k.SetLedgerEntry(ctx, entry)
+	k.SetLedger(ctx, ledger.Record(entry))
+}
@@ -0,0 +1,48 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneLedgerEntriesDeletesEntriesNoLongerRetained(t *testing.T) {
+	app, ctx := createTestApp(false)
+	params := app.BondsKeeper.GetParams(ctx)
+	params.LedgerEntryRetentionBlocks = 10
+	app.BondsKeeper.SetParams(ctx, params)
+	app.BondsKeeper.OpenLedger(ctx, token)
+
+	// Record entries at heights 1 and 5
+	amount := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	app.BondsKeeper.RecordLedgerEntry(ctx.WithBlockHeight(1), token,
+		types.LedgerEntryEscrowIn, types.LedgerAccountEscrow,
+		types.LedgerAccountExternal, amount, buyerAddress)
+	app.BondsKeeper.RecordLedgerEntry(ctx.WithBlockHeight(5), token,
+		types.LedgerEntryReserveIn, types.LedgerAccountReserve,
+		types.LedgerAccountEscrow, amount, buyerAddress)
+
+	// Both entries are retained until height 11
+	app.BondsKeeper.PruneLedgerEntries(ctx.WithBlockHeight(10))
+	require.Len(t, app.BondsKeeper.GetLedgerEntries(ctx, token), 2)
+
+	// Only the entry recorded at height 1 is pruned at height 11
+	app.BondsKeeper.PruneLedgerEntries(ctx.WithBlockHeight(11))
+	entries := app.BondsKeeper.GetLedgerEntries(ctx, token)
+	require.Len(t, entries, 1)
+	require.Equal(t, uint64(1), entries[0].Sequence)
+
+	// Pruning does not affect the ledger's balances or free up sequences
+	ledger, _ := app.BondsKeeper.GetLedger(ctx, token)
+	require.Equal(t, uint64(2), ledger.NextSequence)
+	require.Equal(t, amount, ledger.Balance(types.LedgerAccountReserve).Net())
+	require.True(t, ledger.Balance(types.LedgerAccountEscrow).Net().IsZero())
+
+	// A retention of zero keeps entries forever
+	params.LedgerEntryRetentionBlocks = 0
+	app.BondsKeeper.SetParams(ctx, params)
+	app.BondsKeeper.PruneLedgerEntries(ctx.WithBlockHeight(1000))
+	require.Len(t, app.BondsKeeper.GetLedgerEntries(ctx, token), 1)
+}
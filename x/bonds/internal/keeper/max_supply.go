@@ -0,0 +1,45 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// ApplyAtMaxSupplyBehavior applies the bond's at max supply behaviour if the
+// bond's supply is exactly its max supply, i.e. closes the bond to buys for
+// good or settles the bond. Bonds that allow rebuys are left as they are.
+func (k Keeper) ApplyAtMaxSupplyBehavior(ctx sdk.Context, token string) {
+	bond := k.MustGetBond(ctx, token)
+	if !bond.HasReachedMaxSupply() {
+		return
+	}
+
+	switch bond.AtMaxSupplyBehavior {
+	case types.AtMaxSupplyCloseToBuys:
+		if bond.BuysClosed {
+			return
+		}
+		bond.BuysClosed = true
+		k.SetBond(ctx, token, bond)
+	case types.AtMaxSupplyAutoSettle:
+		if types.CheckBondStateTransition(bond.State, types.SettleState) != nil {
+			return
+		}
+		if err := k.SetBondState(ctx, token, types.SettleState); err != nil {
+			panic(err)
+		}
+	default:
+		return
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s reached its max supply %s; applied %s",
+		bond.Token, bond.MaxSupply.String(), bond.AtMaxSupplyBehavior))
+
+	ctx.EventManager().EmitEvent(types.NewEvent(types.MaxSupplyReachedEvent{
+		Bond:                bond.Token,
+		MaxSupply:           bond.MaxSupply,
+		AtMaxSupplyBehavior: bond.AtMaxSupplyBehavior,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+}
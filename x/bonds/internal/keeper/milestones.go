@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// ApplyReachedMilestones applies the changes of every milestone of the bond
+// that has not yet been reached and whose reserve threshold is met by the
+// bond's current reserve, in the order in which the milestones were declared.
+// Milestones are never un-reached, even if the reserve later drops below them.
+func (k Keeper) ApplyReachedMilestones(ctx sdk.Context, token string) {
+	bond := k.MustGetBond(ctx, token)
+	for bond.MilestonesReached < uint64(len(bond.Milestones)) {
+		index := bond.MilestonesReached
+		milestone := bond.Milestones[index]
+		if !milestone.IsReached(bond.CurrentReserve) {
+			break
+		}
+
+		// Release funding tranche to the fee address
+		if !milestone.FundingTranche.Empty() {
+			err := k.WithdrawReserve(ctx, token, bond.FeeAddress, milestone.FundingTranche)
+			if err != nil {
+				// Should never happen, since milestone validation ensures
+				// that the tranche does not exceed the reached threshold
+				panic(err)
+			}
+			bond = k.MustGetBond(ctx, token) // get bond again (reserve changed)
+		}
+
+		// Update theta (and the dependent R0 and V0), which is only relevant
+		// while an augmented bond is still in its hatch phase
+		if milestone.UpdateTheta && bond.FunctionType == types.AugmentedFunction &&
+			bond.State == types.HatchState {
+			bond.FunctionParameters = withAugmentedTheta(
+				bond.FunctionParameters, milestone.Theta)
+			k.InvalidateFunctionParamsCache(token)
+		}
+
+		// Enable sells
+		if milestone.EnableSells {
+			bond.AllowSells = true
+		}
+
+		bond.MilestonesReached += 1
+		k.SetBond(ctx, token, bond)
+
+		logger := k.Logger(ctx)
+		logger.Info(fmt.Sprintf("bond %s reached milestone %d with reserve %s",
+			token, index, bond.CurrentReserve.String()))
+
+		ctx.EventManager().EmitEvent(types.NewEvent(types.MilestoneReachedEvent{
+			Bond:               token,
+			Milestone:          index,
+			ReserveThreshold:   milestone.ReserveThreshold,
+			FundingTranche:     milestone.FundingTranche,
+			FunctionParameters: bond.FunctionParameters,
+			AllowSells:         bond.AllowSells,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+	}
+}
+
+// withAugmentedTheta returns a copy of the augmented function parameters with
+// theta set to the new theta, and with R0 and V0 recomputed accordingly.
+func withAugmentedTheta(fps types.FunctionParams, theta sdk.Dec) types.FunctionParams {
+	paramsMap := fps.AsMap()
+	paramsMap["theta"] = theta
+	paramsMap["R0"] = paramsMap["d0"].Mul(sdk.OneDec().Sub(theta))
+	paramsMap["V0"] = types.Invariant(paramsMap["R0"], paramsMap["S0"],
+		paramsMap["kappa"].TruncateInt64())
+
+	updated := make(types.FunctionParams, len(fps))
+	for i, fp := range fps {
+		updated[i] = types.NewFunctionParam(fp.Param, paramsMap[fp.Param])
+	}
+	return updated
+}
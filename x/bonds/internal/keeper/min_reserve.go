@@ -0,0 +1,89 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// sellsBreachMinReserve returns true if performing the batch's buys and sells
+// would take the bond's reserve below the bond's min reserve in any of the
+// reserve tokens, given the bond's supply along the curve after the batch.
+func (k Keeper) sellsBreachMinReserve(ctx sdk.Context, bond types.Bond, batch types.Batch) bool {
+	supply := bond.CurrentSupply.Amount.
+		Add(batch.TotalBuyAmount.Amount).Sub(batch.TotalSellAmount.Amount)
+	minReserve, floored := bond.GetMinReserve(supply)
+	if !floored {
+		return false
+	}
+
+	reserve := k.GetReserveBalances(ctx, bond.Token).Add(batch.GetBuysReserve()...)
+	sellsReserve := batch.GetSellsReserve()
+	for _, r := range minReserve {
+		if reserve.AmountOf(r.Denom).Sub(sellsReserve.AmountOf(r.Denom)).LT(r.Amount) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeferSellsBelowMinReserve enforces the bond's min reserve on the current
+// batch. While the batch's sells would take the reserve below the min reserve,
+// sell orders are taken out of the batch, starting from the most recent one,
+// and returned as sell orders to be added to the next batch. Since the reduced
+// sells may raise the buy prices, any buys that become unfulfillable are
+// cancelled and the min reserve is re-applied.
+func (k Keeper) DeferSellsBelowMinReserve(ctx sdk.Context, token string) (deferred []types.SellOrder) {
+	for {
+		newlyDeferred := k.deferSellsBelowMinReserve(ctx, token)
+		if len(newlyDeferred) == 0 {
+			return deferred
+		}
+		deferred = append(deferred, newlyDeferred...)
+
+		if k.CancelUnfulfillableOrders(ctx, token) == 0 {
+			return deferred
+		}
+	}
+}
+
+func (k Keeper) deferSellsBelowMinReserve(ctx sdk.Context, token string) (deferred []types.SellOrder) {
+	logger := k.Logger(ctx)
+	bond := k.MustGetBond(ctx, token)
+	batch := k.MustGetBatch(ctx, token)
+	for i := len(batch.Sells) - 1; i >= 0; i-- {
+		if !k.sellsBreachMinReserve(ctx, bond, batch) {
+			break
+		}
+
+		so := batch.Sells[i]
+		if so.IsCancelled() {
+			continue
+		}
+
+		// Take the sell out of the batch and update the batch prices, keeping
+		// the deferred sells in order of arrival
+		batch.Sells = append(batch.Sells[:i], batch.Sells[i+1:]...)
+		batch.TotalSellAmount = batch.TotalSellAmount.Sub(so.Amount)
+		buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
+		if err != nil {
+			panic(err)
+		}
+		batch.BuyPrices = buyPrices
+		batch.SellPrices = sellPrices
+		deferred = append([]types.SellOrder{so}, deferred...)
+
+		logger.Info(fmt.Sprintf("deferred sell order for %s from %s", so.Amount.String(), so.Address.String()))
+
+		ctx.EventManager().EmitEvent(types.NewEvent(types.OrderDeferEvent{
+			Bond:           token,
+			OrderType:      types.AttributeValueSellOrder,
+			Address:        so.Address,
+			TokensDeferred: so.Amount.Amount,
+			Memo:           so.Memo,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+	}
+
+	k.SetBatch(ctx, token, batch)
+	return deferred
+}
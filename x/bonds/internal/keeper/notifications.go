@@ -0,0 +1,169 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetNotificationRegistration(ctx sdk.Context, token string,
+	relayer sdk.AccAddress) (registration types.NotificationRegistration, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetNotificationRegistrationKey(token, relayer)) {
+		return types.NotificationRegistration{}, false
+	}
+
+	bz := store.Get(types.GetNotificationRegistrationKey(token, relayer))
+	k.cdc.MustUnmarshalBinaryBare(bz, &registration)
+
+	return registration, true
+}
+
+func (k Keeper) GetBondNotificationRegistrations(ctx sdk.Context, token string) (registrations []types.NotificationRegistration) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetBondNotificationsKey(token))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var registration types.NotificationRegistration
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &registration)
+		registrations = append(registrations, registration)
+	}
+	return registrations
+}
+
+func (k Keeper) GetAllNotificationRegistrations(ctx sdk.Context) (registrations []types.NotificationRegistration) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.NotificationsKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var registration types.NotificationRegistration
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &registration)
+		registrations = append(registrations, registration)
+	}
+	return registrations
+}
+
+func (k Keeper) SetNotificationRegistration(ctx sdk.Context, registration types.NotificationRegistration) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetNotificationRegistrationKey(registration.BondToken, registration.Relayer),
+		k.cdc.MustMarshalBinaryBare(registration))
+}
+
+func (k Keeper) DeleteNotificationRegistration(ctx sdk.Context, token string, relayer sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetNotificationRegistrationKey(token, relayer))
+}
+
+// RegisterNotifications registers the relayer for notifications of the
+// specified types about the bond. A relayer that is already registered for the
+// bond only has its notification types replaced. Otherwise, the notification
+// deposit is taken from the relayer and held until it unregisters.
+func (k Keeper) RegisterNotifications(ctx sdk.Context, token string,
+	relayer sdk.AccAddress, notificationTypes []string) (types.NotificationRegistration, error) {
+
+	if !k.BondExists(ctx, token) {
+		return types.NotificationRegistration{}, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	}
+
+	registration, found := k.GetNotificationRegistration(ctx, token, relayer)
+	if found {
+		registration.NotificationTypes = notificationTypes
+		k.SetNotificationRegistration(ctx, registration)
+		return registration, nil
+	}
+
+	maxRelays := k.MaxBondNotificationRelays(ctx)
+	if uint64(len(k.GetBondNotificationRegistrations(ctx, token))) >= maxRelays {
+		return types.NotificationRegistration{}, sdkerrors.Wrapf(
+			types.ErrTooManyNotificationRegistrations, "%s has %d", token, maxRelays)
+	}
+
+	deposit := k.NotificationDeposit(ctx)
+	if !deposit.IsZero() {
+		err := k.SupplyKeeper.SendCoinsFromAccountToModule(
+			ctx, relayer, types.NotificationDepositsAccount, deposit)
+		if err != nil {
+			return types.NotificationRegistration{}, err
+		}
+	}
+
+	registration = types.NewNotificationRegistration(
+		token, relayer, notificationTypes, deposit, ctx.BlockHeight())
+	k.SetNotificationRegistration(ctx, registration)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("registered %s for %s notifications", relayer, token))
+
+	return registration, nil
+}
+
+// UnregisterNotifications removes the relayer's registration for notifications
+// about the bond and returns the deposit taken when it registered.
+func (k Keeper) UnregisterNotifications(ctx sdk.Context, token string,
+	relayer sdk.AccAddress) (types.NotificationRegistration, error) {
+
+	registration, found := k.GetNotificationRegistration(ctx, token, relayer)
+	if !found {
+		return types.NotificationRegistration{}, sdkerrors.Wrapf(
+			types.ErrNotificationRegistrationDoesNotExist, "%s for %s", relayer, token)
+	}
+
+	if !registration.Deposit.IsZero() {
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(
+			ctx, types.NotificationDepositsAccount, relayer, registration.Deposit)
+		if err != nil {
+			return types.NotificationRegistration{}, err
+		}
+	}
+	k.DeleteNotificationRegistration(ctx, token, relayer)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("unregistered %s from %s notifications", relayer, token))
+
+	return registration, nil
+}
+
+// NotifyRegisteredRelayers emits a compact notification event for each relayer
+// registered for the bond, for each of the notification types that the
+// relayer registered for and that apply to the settled batch, i.e. fills if
+// any of the batch's orders were fulfilled and settlements if the batch had
+// any orders at all.
+func (k Keeper) NotifyRegisteredRelayers(ctx sdk.Context, token string, batch types.Batch) {
+	var fills, cancellations uint64
+	count := func(cancelled bool) {
+		if cancelled {
+			cancellations++
+		} else {
+			fills++
+		}
+	}
+	for _, bo := range batch.Buys {
+		count(bo.IsCancelled())
+	}
+	for _, so := range batch.Sells {
+		count(so.IsCancelled())
+	}
+	for _, so := range batch.Swaps {
+		count(so.IsCancelled())
+	}
+
+	if fills+cancellations == 0 {
+		return
+	}
+
+	for _, registration := range k.GetBondNotificationRegistrations(ctx, token) {
+		for _, notificationType := range registration.NotificationTypes {
+			if notificationType == types.NotificationTypeFills && fills == 0 {
+				continue
+			}
+			ctx.EventManager().EmitEvent(types.NewEvent(types.BondNotificationEvent{
+				Relayer:          registration.Relayer,
+				Bond:             token,
+				NotificationType: notificationType,
+				Fills:            fills,
+				Cancellations:    cancellations,
+			}))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRegisterAndUnregisterNotifications(t *testing.T) {
+	app, ctx := createTestApp(false)
+	relayer := baseOrderAddress
+	fills := []string{types.NotificationTypeFills}
+
+	// Set a notification deposit and give the relayer enough to pay it
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	params := app.BondsKeeper.GetParams(ctx)
+	params.NotificationDeposit = deposit
+	app.BondsKeeper.SetParams(ctx, params)
+	_, err := app.BankKeeper.AddCoins(ctx, relayer, deposit)
+	require.Nil(t, err)
+
+	// Cannot register for a bond that does not exist
+	_, err = app.BondsKeeper.RegisterNotifications(ctx, token, relayer, fills)
+	require.True(t, types.ErrBondDoesNotExist.Is(err))
+
+	// Registering takes the deposit
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	registration, err := app.BondsKeeper.RegisterNotifications(ctx, token, relayer, fills)
+	require.Nil(t, err)
+	require.Equal(t, deposit, registration.Deposit)
+	require.True(t, app.BankKeeper.GetCoins(ctx, relayer).IsZero())
+	stuck, err := app.BondsKeeper.GetStuckFunds(ctx, types.NotificationDepositsAccount)
+	require.Nil(t, err)
+	require.True(t, stuck.IsZero())
+
+	// Registering again only replaces the types, without a second deposit
+	allTypes := []string{types.NotificationTypeFills, types.NotificationTypeSettlements}
+	registration, err = app.BondsKeeper.RegisterNotifications(ctx, token, relayer, allTypes)
+	require.Nil(t, err)
+	require.Equal(t, allTypes, registration.NotificationTypes)
+	require.Equal(t, deposit, registration.Deposit)
+	require.Len(t, app.BondsKeeper.GetBondNotificationRegistrations(ctx, token), 1)
+
+	// Unregistering returns the deposit
+	_, err = app.BondsKeeper.UnregisterNotifications(ctx, token, relayer)
+	require.Nil(t, err)
+	require.Equal(t, deposit, app.BankKeeper.GetCoins(ctx, relayer))
+	require.Empty(t, app.BondsKeeper.GetBondNotificationRegistrations(ctx, token))
+
+	// Cannot unregister twice
+	_, err = app.BondsKeeper.UnregisterNotifications(ctx, token, relayer)
+	require.True(t, types.ErrNotificationRegistrationDoesNotExist.Is(err))
+}
+
+func TestRegisterNotificationsMaxRelays(t *testing.T) {
+	app, ctx := createTestApp(false)
+	fills := []string{types.NotificationTypeFills}
+
+	params := app.BondsKeeper.GetParams(ctx)
+	params.MaxBondNotificationRelays = 1
+	app.BondsKeeper.SetParams(ctx, params)
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+
+	_, err := app.BondsKeeper.RegisterNotifications(ctx, token, buyerAddress, fills)
+	require.Nil(t, err)
+
+	// A second relayer cannot register, but the first can still update
+	_, err = app.BondsKeeper.RegisterNotifications(ctx, token, sellerAddress, fills)
+	require.True(t, types.ErrTooManyNotificationRegistrations.Is(err))
+	_, err = app.BondsKeeper.RegisterNotifications(ctx, token, buyerAddress,
+		[]string{types.NotificationTypeSettlements})
+	require.Nil(t, err)
+}
+
+func TestNotifyRegisteredRelayers(t *testing.T) {
+	app, ctx := createTestApp(false)
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+
+	_, err := app.BondsKeeper.RegisterNotifications(ctx, token, buyerAddress,
+		[]string{types.NotificationTypeFills})
+	require.Nil(t, err)
+	_, err = app.BondsKeeper.RegisterNotifications(ctx, token, sellerAddress,
+		[]string{types.NotificationTypeSettlements})
+	require.Nil(t, err)
+
+	notifications := func(batch types.Batch) (events sdk.Events) {
+		ctx := ctx.WithEventManager(sdk.NewEventManager())
+		app.BondsKeeper.NotifyRegisteredRelayers(ctx, token, batch)
+		return ctx.EventManager().Events()
+	}
+
+	// No notifications for a batch without orders
+	batch := getValidBatch()
+	require.Empty(t, notifications(batch))
+
+	// Only settlement notifications if all orders were cancelled
+	cancelled := getValidBuyOrder()
+	cancelled.Cancelled = true
+	batch.Buys = []types.BuyOrder{cancelled}
+	require.Equal(t, sdk.Events{
+		types.NewEvent(types.BondNotificationEvent{
+			Relayer:          sellerAddress,
+			Bond:             token,
+			NotificationType: types.NotificationTypeSettlements,
+			Fills:            0,
+			Cancellations:    1,
+		}),
+	}, notifications(batch))
+
+	// Both types of notifications if any order was filled
+	batch.Sells = []types.SellOrder{getValidSellOrder()}
+	require.Len(t, notifications(batch), 2)
+}
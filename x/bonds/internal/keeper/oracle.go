@@ -0,0 +1,73 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+var _ types.OracleKeeper = NoOpOracleKeeper{}
+
+// NoOpOracleKeeper is the default oracle keeper, which does not have any
+// exchange rates. This means that rebalance swaps are rejected unless an
+// actual oracle keeper is set.
+type NoOpOracleKeeper struct{}
+
+func (NoOpOracleKeeper) GetExchangeRate(_ sdk.Context, _, _ string) (sdk.Dec, bool) {
+	return sdk.Dec{}, false
+}
+
+// GetRebalanceOracleRate returns the oracle's market rate between the swapper
+// bond's reserve tokens (i.e. t1 per t2, same as the bond's sanity rate), as
+// long as the rate confirms that the market rate has moved outside of the
+// bond's sanity band. Otherwise, a rebalance swap is not needed, since normal
+// swaps can move the reserves anywhere within the sanity band.
+func (k Keeper) GetRebalanceOracleRate(ctx sdk.Context, bond types.Bond) (sdk.Dec, error) {
+	rate, found := k.OracleKeeper.GetExchangeRate(ctx,
+		bond.ReserveTokens[0], bond.ReserveTokens[1])
+	if !found || rate.IsNil() || !rate.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrOracleRateUnavailable,
+			"%s per %s", bond.ReserveTokens[0], bond.ReserveTokens[1])
+	}
+
+	// A zero sanity rate means that the sanity check is disabled
+	if bond.SanityRate.IsZero() {
+		return sdk.Dec{}, sdkerrors.Wrap(types.ErrOracleRateWithinSanityBand,
+			"sanity check is disabled")
+	}
+
+	minRate, maxRate := bond.GetSanityRateBand()
+	if rate.GTE(minRate) && rate.LTE(maxRate) {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrOracleRateWithinSanityBand,
+			"%s is within [%s, %s]", rate, minRate, maxRate)
+	}
+
+	return rate, nil
+}
+
+// GetQuoteValue converts amounts in the bond's reserve tokens into a single
+// amount in the bond's quote denomination, using the oracle's market rate of
+// the quote denomination per unit of each reserve token. Amounts that are
+// already in the quote denomination are not converted.
+func (k Keeper) GetQuoteValue(ctx sdk.Context, bond types.Bond, amounts sdk.DecCoins) (sdk.DecCoin, error) {
+	if bond.QuoteDenom == "" {
+		return sdk.DecCoin{}, sdkerrors.Wrap(types.ErrBondHasNoQuoteDenom, bond.Token)
+	}
+
+	total := sdk.ZeroDec()
+	for _, a := range amounts {
+		if a.Denom == bond.QuoteDenom {
+			total = total.Add(a.Amount)
+			continue
+		}
+
+		rate, found := k.OracleKeeper.GetExchangeRate(ctx, bond.QuoteDenom, a.Denom)
+		if !found || rate.IsNil() || rate.IsNegative() {
+			return sdk.DecCoin{}, sdkerrors.Wrapf(types.ErrOracleRateUnavailable,
+				"%s per %s", bond.QuoteDenom, a.Denom)
+		}
+		total = total.Add(a.Amount.Mul(rate))
+	}
+
+	return sdk.NewDecCoinFromDec(bond.QuoteDenom, total), nil
+}
@@ -0,0 +1,430 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// PerformBuy submits a buy on behalf of the buyer, for use by other modules
+// (e.g. a payroll module that buys a community bond for its members) without
+// requiring a MsgBuy signed by the buyer. The buy goes through the same checks
+// and batch as a MsgBuy, and the caller module is recorded in the buy event.
+func (k Keeper) PerformBuy(ctx sdk.Context, callerModule string,
+	buyer sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) (types.OrderReceipt, error) {
+	if strings.TrimSpace(callerModule) == "" {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrArgumentCannotBeEmpty, "caller module")
+	}
+
+	msg := types.NewMsgBuy(buyer, amount, maxPrices)
+	if err := msg.ValidateBasic(); err != nil {
+		return types.OrderReceipt{}, err
+	}
+	return k.SubmitBuy(ctx, msg, callerModule)
+}
+
+// PerformSell submits a sell on behalf of the seller, for use by other
+// modules, in the same way as PerformBuy.
+func (k Keeper) PerformSell(ctx sdk.Context, callerModule string,
+	seller sdk.AccAddress, amount sdk.Coin) (types.OrderReceipt, error) {
+	if strings.TrimSpace(callerModule) == "" {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrArgumentCannotBeEmpty, "caller module")
+	}
+
+	msg := types.NewMsgSell(seller, amount)
+	if err := msg.ValidateBasic(); err != nil {
+		return types.OrderReceipt{}, err
+	}
+	return k.SubmitSell(ctx, msg, callerModule)
+}
+
+// PerformSwap submits a swap on behalf of the swapper, for use by other
+// modules, in the same way as PerformBuy.
+func (k Keeper) PerformSwap(ctx sdk.Context, callerModule string,
+	swapper sdk.AccAddress, bondToken string, from sdk.Coin, toToken string) (types.OrderReceipt, error) {
+	if strings.TrimSpace(callerModule) == "" {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrArgumentCannotBeEmpty, "caller module")
+	}
+
+	msg := types.NewMsgSwap(swapper, bondToken, from, toToken)
+	if err := msg.ValidateBasic(); err != nil {
+		return types.OrderReceipt{}, err
+	}
+	return k.SubmitSwap(ctx, msg, callerModule)
+}
+
+// CheckOrderAllowed returns the error that the order (a MsgBuy, MsgSell, or
+// MsgSwap) would be rejected with if it were submitted now, or nil if it would
+// be accepted. The order is submitted to a cached context whose state changes
+// and events are discarded, so that every check of an actual submission is
+// applied without affecting the state.
+func (k Keeper) CheckOrderAllowed(ctx sdk.Context, msg sdk.Msg) error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	cacheCtx, _ := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+
+	var err error
+	switch msg := msg.(type) {
+	case types.MsgBuy:
+		_, err = k.SubmitBuy(cacheCtx, msg, "")
+	case types.MsgSell:
+		_, err = k.SubmitSell(cacheCtx, msg, "")
+	case types.MsgSwap:
+		_, err = k.SubmitSwap(cacheCtx, msg, "")
+	default:
+		err = sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized order type: %T", msg)
+	}
+	return err
+}
+
+// SubmitBuy checks the buy against the bond and module state and adds it to
+// the bond's batch, or initialises the reserves if the bond is a swapper bond
+// without any supply. The caller module is empty if the buy is from a MsgBuy.
+func (k Keeper) SubmitBuy(ctx sdk.Context, msg types.MsgBuy, callerModule string) (types.OrderReceipt, error) {
+
+	// Check that order submission has not been halted module-wide
+	if k.OrderSubmissionHalted(ctx) {
+		return types.OrderReceipt{}, types.ErrOrderSubmissionHalted
+	}
+
+	token := msg.Amount.Denom
+	bond, found := k.GetBond(ctx, token)
+	if !found {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	}
+
+	// Check that orders are not frozen for a scheduled upgrade
+	if k.OrdersFrozenForUpgrade(ctx, bond) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrOrdersFrozenForUpgrade, bond.Token)
+	}
+
+	// Check that the buyer has a valid attestation, if the bond requires one
+	if !k.HasValidAttestation(ctx, bond, msg.Buyer) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrAttestationRequired, msg.Buyer.String())
+	}
+
+	// Check current state is HATCH/OPEN
+	if err := bond.CheckStateAllows(types.ActionBuy); err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// Check that the bond was not closed to buys upon reaching its max supply
+	if bond.BuysClosed {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrBondClosedToBuys, bond.Token)
+	}
+
+	// Convert any max prices in derivatives of the reserve tokens (e.g. liquid
+	// staking derivatives) into the underlying reserve tokens, so that the rest
+	// of the buy only deals with the reserve tokens themselves
+	maxPrices, err := k.ConvertReserveContribution(ctx, bond, msg.Buyer, msg.MaxPrices)
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+	msg.MaxPrices = maxPrices
+
+	// Check max prices, order quantity limits
+	if !bond.ReserveDenomsEqualTo(msg.MaxPrices) {
+		return types.OrderReceipt{}, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.MaxPrices.String(), strings.Join(bond.ReserveTokens, ","))
+	} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+	}
+
+	// Check that the priority fee (if any) is in the bond's reserve tokens
+	for _, c := range msg.PriorityFee {
+		if !bond.IsReserveToken(c.Denom) {
+			return types.OrderReceipt{}, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "priority fee %s does not match reserve; expected: %s", msg.PriorityFee.String(), strings.Join(bond.ReserveTokens, ","))
+		}
+	}
+
+	// Check the amount committed to the buy (i.e. the max prices) against the
+	// buyer's spend cap and record it, if spend caps are enabled. Whatever is
+	// returned to the buyer is released once the buy is performed or cancelled
+	if err := k.RecordBuySpend(ctx, msg.Buyer, msg.MaxPrices); err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// For the swapper, the first buy is the initialisation of the reserves
+	// The max prices are used as the actual prices and one token is minted
+	// The amount of token serves to define the price of adding more liquidity
+	if bond.CurrentSupply.IsZero() && types.IsSwapperFunctionType(bond.FunctionType) {
+		return k.performFirstSwapperFunctionBuy(ctx, bond, msg, callerModule)
+	}
+
+	// Create order
+	order := types.NewBuyOrder(msg.Buyer, msg.Amount, msg.MaxPrices)
+	order.Height = ctx.BlockHeight()
+	order.CallbackPayload = msg.CallbackPayload
+	order.Memo = msg.Memo
+	order.PriorityFee = msg.PriorityFee
+
+	// Take max that buyer is willing to pay, along with the priority fee (if
+	// any), which is only paid if the buy is fulfilled (enforces balance)
+	err = k.EscrowOrderFunds(ctx, token, msg.Buyer, order.EscrowedFunds())
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// Get buy price and check if can add buy order to batch
+	buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterBuy(ctx, token, order)
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// Add buy order to batch
+	k.AddBuyOrder(ctx, token, order, buyPrices, sellPrices)
+
+	// Issue order receipt
+	receipt := k.IssueBuyOrderReceipt(ctx, msg.Buyer, msg.Amount, msg.MaxPrices)
+
+	// Cancel unfulfillable orders
+	k.CancelUnfulfillableOrders(ctx, token)
+
+	ctx.EventManager().EmitEvent(types.NewEvent(types.BuyEvent{
+		Bond:            msg.Amount.Denom,
+		Amount:          msg.Amount.Amount,
+		MaxPrices:       msg.MaxPrices,
+		OrderID:         receipt.OrderID,
+		OrderReceipt:    receipt.Receipt,
+		CallbackPayload: msg.CallbackPayload,
+		Memo:            msg.Memo,
+		CallerModule:    callerModule,
+		PriorityFee:     msg.PriorityFee,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+	return receipt, nil
+}
+
+func (k Keeper) performFirstSwapperFunctionBuy(ctx sdk.Context, bond types.Bond,
+	msg types.MsgBuy, callerModule string) (types.OrderReceipt, error) {
+
+	// TODO: investigate effect that a high amount has on future buyers' ability to buy.
+
+	// Check if initial liquidity violates sanity rate
+	if bond.ReservesViolateSanityRate(msg.MaxPrices) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrValuesViolateSanityRate, msg.MaxPrices.String())
+	}
+
+	// Use max prices as the amount to send to the liquidity pool (i.e. price)
+	err := k.DepositReserve(ctx, bond.Token, msg.Buyer, msg.MaxPrices)
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// Mint bond tokens
+	err = k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount,
+		sdk.Coins{msg.Amount})
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// Send bond tokens to buyer
+	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BondsMintBurnAccount, msg.Buyer, sdk.Coins{msg.Amount})
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+	k.RecordLedgerEntry(ctx, bond.Token, types.LedgerEntryMint,
+		types.LedgerAccountExternal, types.LedgerAccountSupply,
+		sdk.Coins{msg.Amount}, msg.Buyer)
+
+	// Update supply
+	k.SetCurrentSupply(ctx, bond.Token, bond.CurrentSupply.Add(msg.Amount))
+
+	// Issue order receipt
+	receipt := k.IssueBuyOrderReceipt(ctx, msg.Buyer, msg.Amount, msg.MaxPrices)
+
+	ctx.EventManager().EmitEvent(types.NewEvent(types.InitSwapperEvent{
+		Bond:            msg.Amount.Denom,
+		Amount:          msg.Amount.Amount,
+		ChargedPrices:   msg.MaxPrices,
+		OrderID:         receipt.OrderID,
+		OrderReceipt:    receipt.Receipt,
+		CallbackPayload: msg.CallbackPayload,
+		Memo:            msg.Memo,
+		CallerModule:    callerModule,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+	return receipt, nil
+}
+
+// SubmitSell checks the sell against the bond and module state, burns the
+// bond tokens being sold, and adds the sell to the bond's batch. The caller
+// module is empty if the sell is from a MsgSell.
+func (k Keeper) SubmitSell(ctx sdk.Context, msg types.MsgSell, callerModule string) (types.OrderReceipt, error) {
+
+	// Check that order submission has not been halted module-wide
+	if k.OrderSubmissionHalted(ctx) {
+		return types.OrderReceipt{}, types.ErrOrderSubmissionHalted
+	}
+
+	token := msg.Amount.Denom
+	bond, found := k.GetBond(ctx, token)
+	if !found {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	}
+
+	// Check that orders are not frozen for a scheduled upgrade
+	if k.OrdersFrozenForUpgrade(ctx, bond) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrOrdersFrozenForUpgrade, bond.Token)
+	}
+
+	// Check that the seller has a valid attestation, if the bond requires one
+	if !k.HasValidAttestation(ctx, bond, msg.Seller) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrAttestationRequired, msg.Seller.String())
+	}
+
+	// Check sells allowed, current state is OPEN, and order limits not exceeded
+	if !bond.AllowSells {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, token)
+	} else if err := bond.CheckStateAllows(types.ActionSell); err != nil {
+		return types.OrderReceipt{}, err
+	} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+	}
+
+	// Send coins to be burned from seller (enforces sellAmount <= balance)
+	err := k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Seller,
+		types.BondsMintBurnAccount, sdk.Coins{msg.Amount})
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// Burn bond tokens to be sold
+	err = k.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount,
+		sdk.Coins{msg.Amount})
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryBurn,
+		types.LedgerAccountSupply, types.LedgerAccountExternal,
+		sdk.Coins{msg.Amount}, msg.Seller)
+
+	// Create order
+	order := types.NewSellOrder(msg.Seller, msg.Amount)
+	order.Memo = msg.Memo
+
+	// Get sell price and check if can add sell order to batch
+	buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterSell(ctx, token, order)
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// Add sell order to batch
+	k.AddSellOrder(ctx, token, order, buyPrices, sellPrices)
+
+	// Issue order receipt
+	receipt := k.IssueSellOrderReceipt(ctx, msg.Seller, msg.Amount)
+
+	//// Cancel unfulfillable orders (Note: no need)
+	//k.CancelUnfulfillableOrders(ctx, token)
+
+	ctx.EventManager().EmitEvent(types.NewEvent(types.SellEvent{
+		Bond:         msg.Amount.Denom,
+		Amount:       msg.Amount.Amount,
+		OrderID:      receipt.OrderID,
+		OrderReceipt: receipt.Receipt,
+		Memo:         msg.Memo,
+		CallerModule: callerModule,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+	return receipt, nil
+}
+
+// SubmitSwap checks the swap against the bond and module state and adds it to
+// the bond's batch. The caller module is empty if the swap is from a MsgSwap.
+func (k Keeper) SubmitSwap(ctx sdk.Context, msg types.MsgSwap, callerModule string) (types.OrderReceipt, error) {
+
+	// Check that order submission has not been halted module-wide
+	if k.OrderSubmissionHalted(ctx) {
+		return types.OrderReceipt{}, types.ErrOrderSubmissionHalted
+	}
+
+	bond, found := k.GetBond(ctx, msg.BondToken)
+	if !found {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Check that orders are not frozen for a scheduled upgrade
+	if k.OrdersFrozenForUpgrade(ctx, bond) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrOrdersFrozenForUpgrade, bond.Token)
+	}
+
+	// Check that the swapper has a valid attestation, if the bond requires one
+	if !k.HasValidAttestation(ctx, bond, msg.Swapper) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrAttestationRequired, msg.Swapper.String())
+	}
+
+	// Confirm that function type is a swapper function and state is OPEN
+	if !types.IsSwapperFunctionType(bond.FunctionType) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	} else if err := bond.CheckStateAllows(types.ActionSwap); err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// Check that from and to use reserve token names, or else that the swap
+	// can be routed through a reserve token that is another bond's token
+	via, err := k.GetSwapVia(ctx, bond, msg.From.Denom, msg.ToToken)
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// A routed swap crosses two curves, so a min return has to be specified
+	// and the swapper needs a valid attestation for the via bond as well
+	if via != "" {
+		if msg.MinReturn == nil {
+			return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrArgumentCannotBeEmpty, "MinReturn")
+		} else if !k.HasValidAttestation(ctx, k.MustGetBond(ctx, via), msg.Swapper) {
+			return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrAttestationRequired, msg.Swapper.String())
+		}
+	}
+
+	// Check if order quantity limit exceeded
+	if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.From}) {
+		return types.OrderReceipt{}, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.From.String())
+	}
+
+	// Take coins to be swapped from swapper (enforces swapAmount <= balance)
+	err = k.EscrowOrderFunds(ctx, msg.BondToken, msg.Swapper, sdk.Coins{msg.From})
+	if err != nil {
+		return types.OrderReceipt{}, err
+	}
+
+	// Create order
+	order := types.NewSwapOrder(msg.Swapper, msg.From, msg.ToToken)
+	order.Memo = msg.Memo
+	order.Via = via
+	order.MinReturn = msg.MinReturn
+
+	// Add swap order to batch
+	k.AddSwapOrder(ctx, msg.BondToken, order)
+
+	// Issue order receipt
+	receipt := k.IssueSwapOrderReceipt(ctx, msg.BondToken, msg.Swapper, msg.From, msg.ToToken)
+
+	//// Cancel unfulfillable orders (Note: no need)
+	//k.CancelUnfulfillableOrders(ctx, token)
+
+	var minReturn sdk.Coin
+	if msg.MinReturn != nil {
+		minReturn = *msg.MinReturn
+	}
+	ctx.EventManager().EmitEvent(types.NewEvent(types.SwapEvent{
+		Bond:          msg.BondToken,
+		Amount:        msg.From.Amount,
+		SwapFromToken: msg.From.Denom,
+		SwapToToken:   msg.ToToken,
+		Via:           via,
+		MinReturn:     minReturn,
+		OrderID:       receipt.OrderID,
+		OrderReceipt:  receipt.Receipt,
+		Memo:          msg.Memo,
+		CallerModule:  callerModule,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+	return receipt, nil
+}
@@ -0,0 +1,135 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+const callerModule = "payroll"
+
+func findEventAttribute(events sdk.Events, eventType, key string) (string, bool) {
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		for _, a := range e.Attributes {
+			if string(a.Key) == key {
+				return string(a.Value), true
+			}
+		}
+	}
+	return "", false
+}
+
+func TestPerformBuyAddsBuyOrderAttributedToCallerModule(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000))
+	_, err := app.BankKeeper.AddCoins(ctx, buyerAddress, maxPrices)
+	require.Nil(t, err)
+
+	receipt, err := app.BondsKeeper.PerformBuy(ctx, callerModule,
+		buyerAddress, buyAmount, maxPrices)
+	require.Nil(t, err)
+	require.NotEmpty(t, receipt.Receipt)
+
+	// Buy was added to the batch, with the max prices escrowed
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Len(t, batch.Buys, 1)
+	require.Equal(t, buyerAddress, batch.Buys[0].Address)
+	require.True(t, app.BankKeeper.GetCoins(ctx, buyerAddress).IsZero())
+	require.Equal(t, maxPrices, app.BondsKeeper.GetEscrowBalance(ctx, token))
+
+	// Buy event records the caller module
+	value, found := findEventAttribute(ctx.EventManager().Events(),
+		types.EventTypeBuy, "caller_module")
+	require.True(t, found)
+	require.Equal(t, callerModule, value)
+}
+
+func TestPerformBuyGoesThroughSameChecksAsMsgBuy(t *testing.T) {
+	app, ctx := createTestApp(false)
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000))
+
+	// Caller module is required
+	_, err := app.BondsKeeper.PerformBuy(ctx, "", buyerAddress, buyAmount, maxPrices)
+	require.Error(t, err)
+	require.True(t, types.ErrArgumentCannotBeEmpty.Is(err))
+
+	// Buy is validated as a MsgBuy would be
+	_, err = app.BondsKeeper.PerformBuy(ctx, callerModule, buyerAddress,
+		sdk.NewInt64Coin(token, 0), maxPrices)
+	require.Error(t, err)
+	require.True(t, types.ErrArgumentMustBePositive.Is(err))
+
+	// Bond must exist
+	_, err = app.BondsKeeper.PerformBuy(ctx, callerModule, buyerAddress, buyAmount, maxPrices)
+	require.Error(t, err)
+	require.True(t, types.ErrBondDoesNotExist.Is(err))
+
+	// Order submission must not be halted
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	params := app.BondsKeeper.GetParams(ctx)
+	params.OrderSubmissionHalted = true
+	app.BondsKeeper.SetParams(ctx, params)
+	_, err = app.BondsKeeper.PerformBuy(ctx, callerModule, buyerAddress, buyAmount, maxPrices)
+	require.Error(t, err)
+	require.True(t, types.ErrOrderSubmissionHalted.Is(err))
+}
+
+func TestPerformSellAddsSellOrderAttributedToCallerModule(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bond := getValidBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(token, 10)
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	// Deposit the reserve backing the supply (i.e. 12*10^3/3 + 100*10)
+	reserve := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	_, err := app.BankKeeper.AddCoins(ctx, buyerAddress, reserve)
+	require.Nil(t, err)
+	err = app.BondsKeeper.DepositReserve(ctx, token, buyerAddress, reserve)
+	require.Nil(t, err)
+
+	// Mint bond tokens to the seller
+	bondTokens := sdk.NewCoins(sdk.NewInt64Coin(token, 10))
+	err = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, bondTokens)
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BondsMintBurnAccount, sellerAddress, bondTokens)
+	require.Nil(t, err)
+
+	receipt, err := app.BondsKeeper.PerformSell(ctx, callerModule, sellerAddress, sellAmount)
+	require.Nil(t, err)
+	require.NotEmpty(t, receipt.Receipt)
+
+	// Sell was added to the batch, with the bond tokens burned
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Len(t, batch.Sells, 1)
+	require.Equal(t, int64(9), app.BankKeeper.GetCoins(ctx, sellerAddress).AmountOf(token).Int64())
+
+	// Sell event records the caller module
+	value, found := findEventAttribute(ctx.EventManager().Events(),
+		types.EventTypeSell, "caller_module")
+	require.True(t, found)
+	require.Equal(t, callerModule, value)
+}
+
+func TestPerformSwapRequiresSwapperBond(t *testing.T) {
+	app, ctx := createTestApp(false)
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	_, err := app.BondsKeeper.PerformSwap(ctx, callerModule,
+		swapperAddress, token, swapFrom, swapTo)
+	require.Error(t, err)
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
+}
@@ -0,0 +1,201 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+func (k Keeper) OrderSubmissionHalted(ctx sdk.Context) bool {
+	var halted bool
+	k.paramSpace.Get(ctx, types.KeyOrderSubmissionHalted, &halted)
+	return halted
+}
+
+func (k Keeper) BondProposalQuorum(ctx sdk.Context) sdk.Dec {
+	var quorum sdk.Dec
+	k.paramSpace.Get(ctx, types.KeyBondProposalQuorum, &quorum)
+	return quorum
+}
+
+func (k Keeper) BondCreationFee(ctx sdk.Context) sdk.Coins {
+	var fee sdk.Coins
+	k.paramSpace.Get(ctx, types.KeyBondCreationFee, &fee)
+	return fee
+}
+
+func (k Keeper) CreationFeeDestination(ctx sdk.Context) string {
+	var destination string
+	k.paramSpace.Get(ctx, types.KeyCreationFeeDestination, &destination)
+	return destination
+}
+
+func (k Keeper) MaxNameLength(ctx sdk.Context) uint64 {
+	var maxLength uint64
+	k.paramSpace.Get(ctx, types.KeyMaxNameLength, &maxLength)
+	return maxLength
+}
+
+func (k Keeper) MaxDescriptionLength(ctx sdk.Context) uint64 {
+	var maxLength uint64
+	k.paramSpace.Get(ctx, types.KeyMaxDescriptionLength, &maxLength)
+	return maxLength
+}
+
+func (k Keeper) BuySpendCap(ctx sdk.Context) sdk.Coins {
+	var spendCap sdk.Coins
+	k.paramSpace.Get(ctx, types.KeyBuySpendCap, &spendCap)
+	return spendCap
+}
+
+func (k Keeper) SpendCapWindowBlocks(ctx sdk.Context) uint64 {
+	var windowBlocks uint64
+	k.paramSpace.Get(ctx, types.KeySpendCapWindowBlocks, &windowBlocks)
+	return windowBlocks
+}
+
+func (k Keeper) MaxSanityRateStepPercentage(ctx sdk.Context) sdk.Dec {
+	var percentage sdk.Dec
+	k.paramSpace.Get(ctx, types.KeyMaxSanityRateStepPercentage, &percentage)
+	return percentage
+}
+
+func (k Keeper) MaxSanityRateWindowPercentage(ctx sdk.Context) sdk.Dec {
+	var percentage sdk.Dec
+	k.paramSpace.Get(ctx, types.KeyMaxSanityRateWindowPercentage, &percentage)
+	return percentage
+}
+
+func (k Keeper) SanityRateWindowBlocks(ctx sdk.Context) uint64 {
+	var windowBlocks uint64
+	k.paramSpace.Get(ctx, types.KeySanityRateWindowBlocks, &windowBlocks)
+	return windowBlocks
+}
+
+func (k Keeper) MaxPreMinePercentage(ctx sdk.Context) sdk.Dec {
+	var percentage sdk.Dec
+	k.paramSpace.Get(ctx, types.KeyMaxPreMinePercentage, &percentage)
+	return percentage
+}
+
+func (k Keeper) PreMineVestingBlocks(ctx sdk.Context) uint64 {
+	var vestingBlocks uint64
+	k.paramSpace.Get(ctx, types.KeyPreMineVestingBlocks, &vestingBlocks)
+	return vestingBlocks
+}
+
+func (k Keeper) AlertChangePercentage(ctx sdk.Context) sdk.Dec {
+	var percentage sdk.Dec
+	k.paramSpace.Get(ctx, types.KeyAlertChangePercentage, &percentage)
+	return percentage
+}
+
+func (k Keeper) AlertWindowBlocks(ctx sdk.Context) uint64 {
+	var windowBlocks uint64
+	k.paramSpace.Get(ctx, types.KeyAlertWindowBlocks, &windowBlocks)
+	return windowBlocks
+}
+
+func (k Keeper) MaxTotalValueLocked(ctx sdk.Context) sdk.Coins {
+	var maxValueLocked sdk.Coins
+	k.paramSpace.Get(ctx, types.KeyMaxTotalValueLocked, &maxValueLocked)
+	return maxValueLocked
+}
+
+func (k Keeper) MaxBondValueLocked(ctx sdk.Context) sdk.Coins {
+	var maxValueLocked sdk.Coins
+	k.paramSpace.Get(ctx, types.KeyMaxBondValueLocked, &maxValueLocked)
+	return maxValueLocked
+}
+
+func (k Keeper) FeeDustThreshold(ctx sdk.Context) sdk.Int {
+	var threshold sdk.Int
+	k.paramSpace.Get(ctx, types.KeyFeeDustThreshold, &threshold)
+	return threshold
+}
+
+func (k Keeper) FeeDustDenom(ctx sdk.Context) string {
+	var denom string
+	k.paramSpace.Get(ctx, types.KeyFeeDustDenom, &denom)
+	return denom
+}
+
+func (k Keeper) FeeDustSweepBlocks(ctx sdk.Context) uint64 {
+	var sweepBlocks uint64
+	k.paramSpace.Get(ctx, types.KeyFeeDustSweepBlocks, &sweepBlocks)
+	return sweepBlocks
+}
+
+func (k Keeper) NotificationDeposit(ctx sdk.Context) sdk.Coins {
+	var deposit sdk.Coins
+	k.paramSpace.Get(ctx, types.KeyNotificationDeposit, &deposit)
+	return deposit
+}
+
+func (k Keeper) MaxBondNotificationRelays(ctx sdk.Context) uint64 {
+	var maxRelays uint64
+	k.paramSpace.Get(ctx, types.KeyMaxBondNotificationRelays, &maxRelays)
+	return maxRelays
+}
+
+func (k Keeper) StressPriceImpactPercentage(ctx sdk.Context) sdk.Dec {
+	var percentage sdk.Dec
+	k.paramSpace.Get(ctx, types.KeyStressPriceImpactPercentage, &percentage)
+	return percentage
+}
+
+func (k Keeper) StressVolumePercentage(ctx sdk.Context) sdk.Dec {
+	var percentage sdk.Dec
+	k.paramSpace.Get(ctx, types.KeyStressVolumePercentage, &percentage)
+	return percentage
+}
+
+func (k Keeper) StressConsecutiveBatches(ctx sdk.Context) uint64 {
+	var batches uint64
+	k.paramSpace.Get(ctx, types.KeyStressConsecutiveBatches, &batches)
+	return batches
+}
+
+func (k Keeper) StressExtraBlocks(ctx sdk.Context) uint64 {
+	var extraBlocks uint64
+	k.paramSpace.Get(ctx, types.KeyStressExtraBlocks, &extraBlocks)
+	return extraBlocks
+}
+
+func (k Keeper) StressMaxExtraBlocks(ctx sdk.Context) uint64 {
+	var maxExtraBlocks uint64
+	k.paramSpace.Get(ctx, types.KeyStressMaxExtraBlocks, &maxExtraBlocks)
+	return maxExtraBlocks
+}
+
+func (k Keeper) MinBatchBlocks(ctx sdk.Context) uint64 {
+	var minBatchBlocks uint64
+	k.paramSpace.Get(ctx, types.KeyMinBatchBlocks, &minBatchBlocks)
+	return minBatchBlocks
+}
+
+func (k Keeper) MaxBatchBlocks(ctx sdk.Context) uint64 {
+	var maxBatchBlocks uint64
+	k.paramSpace.Get(ctx, types.KeyMaxBatchBlocks, &maxBatchBlocks)
+	return maxBatchBlocks
+}
+
+func (k Keeper) OrderReceiptRetentionBlocks(ctx sdk.Context) uint64 {
+	var retentionBlocks uint64
+	k.paramSpace.Get(ctx, types.KeyOrderReceiptRetentionBlocks, &retentionBlocks)
+	return retentionBlocks
+}
+
+func (k Keeper) LedgerEntryRetentionBlocks(ctx sdk.Context) uint64 {
+	var retentionBlocks uint64
+	k.paramSpace.Get(ctx, types.KeyLedgerEntryRetentionBlocks, &retentionBlocks)
+	return retentionBlocks
+}
@@ -0,0 +1,62 @@
+package keeper_test
+
+import (
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParamsInitiallyDefault(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	require.Equal(t, types.DefaultParams(), app.BondsKeeper.GetParams(ctx))
+	require.False(t, app.BondsKeeper.OrderSubmissionHalted(ctx))
+}
+
+func TestParamsSetGet(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	params := types.NewParams(true, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks)
+	app.BondsKeeper.SetParams(ctx, params)
+	require.Equal(t, params, app.BondsKeeper.GetParams(ctx))
+	require.True(t, app.BondsKeeper.OrderSubmissionHalted(ctx))
+
+	params = types.NewParams(false, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks)
+	app.BondsKeeper.SetParams(ctx, params)
+	require.Equal(t, params, app.BondsKeeper.GetParams(ctx))
+	require.False(t, app.BondsKeeper.OrderSubmissionHalted(ctx))
+}
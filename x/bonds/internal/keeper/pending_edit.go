@@ -0,0 +1,114 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"strconv"
+)
+
+func (k Keeper) GetPendingBondEdit(ctx sdk.Context, token string) (edit types.PendingBondEdit, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetPendingBondEditKey(token)) {
+		return types.PendingBondEdit{}, false
+	}
+
+	bz := store.Get(types.GetPendingBondEditKey(token))
+	k.cdc.MustUnmarshalBinaryBare(bz, &edit)
+
+	return edit, true
+}
+
+func (k Keeper) GetPendingBondEdits(ctx sdk.Context) (edits []types.PendingBondEdit) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.PendingEditsKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var edit types.PendingBondEdit
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &edit)
+		edits = append(edits, edit)
+	}
+	return edits
+}
+
+func (k Keeper) SetPendingBondEdit(ctx sdk.Context, edit types.PendingBondEdit) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingBondEditKey(edit.BondToken), k.cdc.MustMarshalBinaryBare(edit))
+}
+
+func (k Keeper) DeletePendingBondEdit(ctx sdk.Context, token string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingBondEditKey(token))
+}
+
+// AddPendingBondEdit merges the edit into the bond's pending edit (if any), so
+// that it is applied once the bond's current batch is settled. An error is
+// returned if the merged edit could not be applied to the bond.
+func (k Keeper) AddPendingBondEdit(ctx sdk.Context, edit types.PendingBondEdit) error {
+	bond, found := k.GetBond(ctx, edit.BondToken)
+	if !found {
+		return sdkerrors.Wrap(types.ErrBondDoesNotExist, edit.BondToken)
+	}
+
+	if pending, found := k.GetPendingBondEdit(ctx, edit.BondToken); found {
+		edit = pending.Merge(edit)
+	}
+	if _, err := edit.ApplyTo(bond, ctx.BlockHeight()); err != nil {
+		return err
+	}
+
+	k.SetPendingBondEdit(ctx, edit)
+	return nil
+}
+
+// ApplyPendingBondEdit applies the bond's pending edit (if any) and deletes
+// it. The bond is left unchanged if the edit can no longer be applied to it.
+func (k Keeper) ApplyPendingBondEdit(ctx sdk.Context, token string) {
+	edit, found := k.GetPendingBondEdit(ctx, token)
+	if !found {
+		return
+	}
+	k.DeletePendingBondEdit(ctx, token)
+
+	bond, err := edit.ApplyTo(k.MustGetBond(ctx, token), ctx.BlockHeight())
+	if err != nil {
+		k.Logger(ctx).Info(fmt.Sprintf("skipped pending edit of %s: %s", token, err.Error()))
+		return
+	}
+	k.SetBond(ctx, token, bond)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("applied pending edit of %s", token))
+
+	event := types.ApplyBondEditEvent{Bond: token}
+	if edit.TxFeePercentage != nil {
+		event.TxFeePercentage = edit.TxFeePercentage.String()
+	}
+	if edit.ExitFeePercentage != nil {
+		event.ExitFeePercentage = edit.ExitFeePercentage.String()
+	}
+	if edit.AllowSells != nil {
+		event.AllowSells = strconv.FormatBool(*edit.AllowSells)
+	}
+	if edit.NetSellCap != nil {
+		event.NetSellCap = edit.NetSellCap.String()
+	}
+	if edit.NetSellCapPercentage != nil {
+		event.NetSellCapPercentage = edit.NetSellCapPercentage.String()
+	}
+	if edit.DemurrageRate != nil {
+		event.DemurrageRate = edit.DemurrageRate.String()
+	}
+	if edit.EditsMinReserve {
+		event.MinReserve = edit.MinReserve.String()
+	}
+	if edit.MinReservePercentage != nil {
+		event.MinReservePercentage = edit.MinReservePercentage.String()
+	}
+	if edit.FeeAddress != nil {
+		event.FeeAddress = edit.FeeAddress.String()
+	}
+	ctx.EventManager().EmitEvent(types.NewEvent(event).
+		AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+}
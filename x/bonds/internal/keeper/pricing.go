@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"runtime"
+	"sync"
+)
+
+// PricingWorkers is the number of goroutines used by GetBondsCurrentPrices to
+// compute current prices in parallel. It only affects performance and never
+// the results, which are always returned in the order of the tokens.
+var PricingWorkers = runtime.NumCPU()
+
+// BondPrices are the current prices computed for a bond, or the error
+// encountered while computing them.
+type BondPrices struct {
+	Prices sdk.DecCoins
+	Err    error
+}
+
+// GetBondsCurrentPrices computes the current prices of the bonds with the
+// specified tokens, against their current reserves. Since the prices of
+// different bonds are independent, they are computed in parallel.
+//
+// All state is read before any computation begins and nothing is written, so
+// the context (store, gas meter) is only ever accessed from the calling
+// goroutine and the results are deterministic regardless of scheduling.
+func (k Keeper) GetBondsCurrentPrices(ctx sdk.Context, tokens []string) []BondPrices {
+	bonds := make([]types.Bond, len(tokens))
+	for i, token := range tokens {
+		bonds[i] = k.MustGetBond(ctx, token)
+	}
+
+	results := make([]BondPrices, len(tokens))
+	panics := make([]interface{}, len(tokens))
+
+	workers := PricingWorkers
+	if workers > len(tokens) {
+		workers = len(tokens)
+	}
+
+	indices := make(chan int, len(tokens))
+	for i := range tokens {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i], panics[i] = getCurrentPricesRecovered(bonds[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A panic in a worker goroutine cannot be recovered by the caller, so it
+	// is returned as the error of the bond that caused it instead
+	for i, p := range panics {
+		if p != nil {
+			results[i] = BondPrices{Err: sdkerrors.Wrapf(
+				types.ErrBatchSettlementFailed, "pricing panicked: %v", p)}
+		}
+	}
+	return results
+}
+
+func getCurrentPricesRecovered(bond types.Bond) (result BondPrices, panicked interface{}) {
+	defer func() {
+		panicked = recover()
+	}()
+	result.Prices, result.Err = bond.GetCurrentPricesPT(bond.CurrentReserve)
+	return result, nil
+}
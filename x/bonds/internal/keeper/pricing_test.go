@@ -0,0 +1,70 @@
+package keeper_test
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetBondsCurrentPricesMatchesSequentialPrices(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add bonds with a varying supply
+	var tokens []string
+	for i := 1; i <= 20; i++ {
+		bondToken := fmt.Sprintf("token%d", i)
+		tokens = append(tokens, bondToken)
+
+		bond := getValidBond()
+		bond.Token = bondToken
+		bond.CurrentSupply = sdk.NewInt64Coin(bondToken, int64(i))
+		bond.MaxSupply = sdk.NewInt64Coin(bondToken, 10000)
+		app.BondsKeeper.SetBond(ctx, bondToken, bond)
+	}
+
+	defer func(workers int) { keeper.PricingWorkers = workers }(keeper.PricingWorkers)
+	for _, workers := range []int{1, 4, 64} {
+		keeper.PricingWorkers = workers
+		results := app.BondsKeeper.GetBondsCurrentPrices(ctx, tokens)
+
+		// Results are in the order of the tokens and equal to sequential prices
+		require.Len(t, results, len(tokens))
+		for i, token := range tokens {
+			bond := app.BondsKeeper.MustGetBond(ctx, token)
+			prices, err := bond.GetCurrentPricesPT(bond.CurrentReserve)
+			require.Nil(t, err)
+			require.Nil(t, results[i].Err)
+			require.Equal(t, prices, results[i].Prices)
+		}
+	}
+}
+
+func TestGetBondsCurrentPricesReturnsPerBondErrors(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Power function bond, for which prices can be computed
+	bond1 := getValidBond()
+	app.BondsKeeper.SetBond(ctx, bond1.Token, bond1)
+
+	// Swapper function bond with zero supply, for which prices cannot be computed
+	bond2 := getValidSwapperBond()
+	bond2.Token = token2
+	bond2.CurrentSupply = sdk.NewCoin(token2, sdk.ZeroInt())
+	app.BondsKeeper.SetBond(ctx, bond2.Token, bond2)
+
+	results := app.BondsKeeper.GetBondsCurrentPrices(ctx, []string{bond1.Token, bond2.Token})
+
+	require.Len(t, results, 2)
+	require.Nil(t, results[0].Err)
+	require.NotNil(t, results[0].Prices)
+	require.Error(t, results[1].Err)
+}
+
+func TestGetBondsCurrentPricesNoTokens(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	results := app.BondsKeeper.GetBondsCurrentPrices(ctx, nil)
+	require.Empty(t, results)
+}
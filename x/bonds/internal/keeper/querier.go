@@ -8,19 +8,47 @@ import (
 	"github.com/ixoworld/bonds/x/bonds/client"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"strconv"
+	"strings"
 )
 
 const (
-	QueryBonds          = "bonds"
-	QueryBond           = "bond"
-	QueryBatch          = "batch"
-	QueryLastBatch      = "last_batch"
-	QueryCurrentPrice   = "current_price"
-	QueryCurrentReserve = "current_reserve"
-	QueryCustomPrice    = "custom_price"
-	QueryBuyPrice       = "buy_price"
-	QuerySellReturn     = "sell_return"
-	QuerySwapReturn     = "swap_return"
+	QueryBonds                     = "bonds"
+	QueryBond                      = "bond"
+	QueryBondAdmin                 = "bond_admin"
+	QuerySearchBonds               = "search_bonds"
+	QueryBatch                     = "batch"
+	QueryBatchOrders               = "batch_orders"
+	QueryLastBatch                 = "last_batch"
+	QueryLastBatchResult           = "last_batch_result"
+	QueryEffectiveBatchBlocks      = "effective_batch_blocks"
+	QueryBatchAuction              = "batch_auction"
+	QuerySimulateBatch             = "simulate_batch"
+	QuerySupplyHistory             = "supply_history"
+	QueryReserveHistory            = "reserve_history"
+	QueryEffectiveAPR              = "effective_apr"
+	QueryBondAtHeight              = "bond_at_height"
+	QueryExport                    = "export"
+	QueryLedger                    = "ledger"
+	QueryLedgerExport              = "ledger_export"
+	QueryCurrentPrice              = "current_price"
+	QueryQuotePrice                = "quote_price"
+	QueryCurrentReserve            = "current_reserve"
+	QueryCustomPrice               = "custom_price"
+	QueryBuyPrice                  = "buy_price"
+	QuerySellReturn                = "sell_return"
+	QuerySwapReturn                = "swap_return"
+	QueryFeePreview                = "fee_preview"
+	QueryPriceImpact               = "price_impact"
+	QuerySanityCheck               = "sanity_check"
+	QueryIsTradeAllowed            = "is_trade_allowed"
+	QueryOrderByReceipt            = "order_by_receipt"
+	QueryScheduledChange           = "scheduled_param_change"
+	QueryBondProposals             = "bond_proposals"
+	QueryBondProposal              = "bond_proposal"
+	QueryModuleStats               = "module_stats"
+	QueryNotificationRegistrations = "notification_registrations"
+	QueryParams                    = "params"
 )
 
 // NewQuerier is the module level router for state queries
@@ -31,12 +59,42 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryBonds(ctx, keeper)
 		case QueryBond:
 			return queryBond(ctx, path[1:], keeper)
+		case QueryBondAdmin:
+			return queryBondAdmin(ctx, path[1:], keeper)
+		case QuerySearchBonds:
+			return querySearchBonds(ctx, path[1:], keeper)
 		case QueryBatch:
 			return queryBatch(ctx, path[1:], keeper)
+		case QueryBatchOrders:
+			return queryBatchOrders(ctx, path[1:], req, keeper)
 		case QueryLastBatch:
 			return queryLastBatch(ctx, path[1:], keeper)
+		case QueryLastBatchResult:
+			return queryLastBatchResult(ctx, path[1:], keeper)
+		case QueryEffectiveBatchBlocks:
+			return queryEffectiveBatchBlocks(ctx, path[1:], keeper)
+		case QueryBatchAuction:
+			return queryBatchAuction(ctx, path[1:], keeper)
+		case QuerySimulateBatch:
+			return querySimulateBatch(ctx, path[1:], keeper)
+		case QuerySupplyHistory:
+			return querySupplyHistory(ctx, path[1:], keeper)
+		case QueryReserveHistory:
+			return queryReserveHistory(ctx, path[1:], keeper)
+		case QueryBondAtHeight:
+			return queryBondAtHeight(ctx, path[1:], keeper)
+		case QueryExport:
+			return queryExport(ctx, path[1:], keeper)
+		case QueryLedger:
+			return queryLedger(ctx, path[1:], req, keeper)
+		case QueryLedgerExport:
+			return queryLedgerExport(ctx, path[1:], keeper)
+		case QueryEffectiveAPR:
+			return queryEffectiveAPR(ctx, path[1:], keeper)
 		case QueryCurrentPrice:
 			return queryCurrentPrice(ctx, path[1:], keeper)
+		case QueryQuotePrice:
+			return queryQuotePrice(ctx, path[1:], keeper)
 		case QueryCurrentReserve:
 			return queryCurrentReserve(ctx, path[1:], keeper)
 		case QueryCustomPrice:
@@ -47,6 +105,28 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return querySellReturn(ctx, path[1:], keeper)
 		case QuerySwapReturn:
 			return querySwapReturn(ctx, path[1:], keeper)
+		case QueryFeePreview:
+			return queryFeePreview(ctx, path[1:], keeper)
+		case QueryPriceImpact:
+			return queryPriceImpact(ctx, path[1:], keeper)
+		case QuerySanityCheck:
+			return querySanityCheck(ctx, path[1:], keeper)
+		case QueryIsTradeAllowed:
+			return queryIsTradeAllowed(ctx, path[1:], req, keeper)
+		case QueryOrderByReceipt:
+			return queryOrderByReceipt(ctx, path[1:], keeper)
+		case QueryScheduledChange:
+			return queryScheduledParamChange(ctx, path[1:], keeper)
+		case QueryBondProposals:
+			return queryBondProposals(ctx, path[1:], keeper)
+		case QueryBondProposal:
+			return queryBondProposal(ctx, path[1:], keeper)
+		case QueryNotificationRegistrations:
+			return queryNotificationRegistrations(ctx, path[1:], keeper)
+		case QueryModuleStats:
+			return queryModuleStats(ctx, keeper)
+		case QueryParams:
+			return queryParams(ctx, keeper)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown bonds query endpoint")
 		}
@@ -64,6 +144,20 @@ func zeroReserveTokensIfEmpty(reserveCoins sdk.Coins, bond types.Bond) sdk.Coins
 	return reserveCoins
 }
 
+// getQuoteValueIfAvailable returns the amounts converted into the bond's quote
+// denomination, or nil if the bond has no quote denomination or the oracle has
+// no rate, so that such queries still return the reserve-denominated amounts.
+func getQuoteValueIfAvailable(ctx sdk.Context, keeper Keeper, bond types.Bond, amounts sdk.Coins) sdk.DecCoins {
+	if bond.QuoteDenom == "" {
+		return nil
+	}
+	quoteValue, err := keeper.GetQuoteValue(ctx, bond, types.CoinsToDecCoins(amounts...))
+	if err != nil {
+		return nil
+	}
+	return sdk.DecCoins{quoteValue}
+}
+
 func zeroReserveTokensIfEmptyDec(reserveCoins sdk.DecCoins, bond types.Bond) sdk.DecCoins {
 	if reserveCoins.IsZero() {
 		zeroes := bond.GetNewReserveDecCoins(sdk.OneDec())
@@ -100,7 +194,81 @@ func queryBond(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err e
 		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
 	}
 
-	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, bond)
+	result := types.NewQueryBond(bond, keeper.GetReserveBalances(ctx, bondToken),
+		keeper.MustGetBatch(ctx, bondToken).BlocksRemaining, ctx.BlockHeight())
+	result.SpotPrices = zeroReserveTokensIfEmptyDec(result.SpotPrices, bond)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryBondAdmin(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	var scheduledChange *types.ScheduledParamChange
+	if change, found := keeper.GetScheduledParamChange(ctx, bondToken); found {
+		scheduledChange = &change
+	}
+
+	// The funding pool is the fee address's balance of the reserve tokens
+	feeAddressBalance := keeper.BankKeeper.GetCoins(ctx, bond.FeeAddress)
+	fundingPoolBalance := sdk.Coins{}
+	for _, r := range bond.ReserveTokens {
+		if amount := feeAddressBalance.AmountOf(r); amount.IsPositive() {
+			fundingPoolBalance = fundingPoolBalance.Add(sdk.NewCoin(r, amount))
+		}
+	}
+
+	admin := types.QueryBondAdmin{
+		Bond:                  bond,
+		Signers:               bond.Signers,
+		SignerThreshold:       bond.SignerThreshold,
+		ScheduledParamChange:  scheduledChange,
+		FeesCollected:         keeper.GetBondFeesCollected(ctx, bondToken),
+		FundingPoolBalance:    fundingPoolBalance,
+		OrderSubmissionHalted: keeper.OrderSubmissionHalted(ctx),
+		PendingBatch:          types.NewBatchSummary(keeper.MustGetBatch(ctx, bondToken)),
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, admin)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func querySearchBonds(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "search limit and query are required")
+	}
+
+	limit, err2 := strconv.Atoi(path[0])
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err2.Error())
+	} else if limit < 1 || limit > types.MaxBondSearchLimit {
+		return nil, sdkerrors.Wrapf(types.ErrArgumentMustBeBetween,
+			"limit must be between 1 and %d", types.MaxBondSearchLimit)
+	}
+
+	// The query itself can contain slashes, so the rest of the path is joined
+	query := strings.Join(path[1:], "/")
+	if strings.TrimSpace(query) == "" {
+		return nil, sdkerrors.Wrap(types.ErrArgumentCannotBeEmpty, "query")
+	}
+
+	bondsList := types.QueryBonds(keeper.SearchBonds(ctx, query, limit))
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, bondsList)
 	if err2 != nil {
 		panic("could not marshal result to JSON")
 	}
@@ -125,6 +293,53 @@ func queryBatch(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err
 	return bz, nil
 }
 
+func queryBatchOrders(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	var params types.QueryBatchOrdersParams
+	if err2 := keeper.cdc.UnmarshalJSON(req.Data, &params); err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err2.Error())
+	} else if err2 := params.Validate(); err2 != nil {
+		return nil, err2
+	}
+
+	if !keeper.BatchExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "batch for '%s' does not exist", bondToken)
+	}
+
+	orders := types.NewQueryBatchOrders(keeper.MustGetBatch(ctx, bondToken), params)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, orders)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryIsTradeAllowed(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	var params types.QueryIsTradeAllowedParams
+	if err2 := keeper.cdc.UnmarshalJSON(req.Data, &params); err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err2.Error())
+	}
+
+	msg, err2 := params.Msg(bondToken)
+	if err2 != nil {
+		return nil, err2
+	}
+
+	result := types.NewQueryIsTradeAllowed(keeper.CheckOrderAllowed(ctx, msg))
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 func queryLastBatch(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
 	bondToken := path[0]
 
@@ -142,6 +357,283 @@ func queryLastBatch(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 	return bz, nil
 }
 
+func queryLastBatchResult(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	if !keeper.LastBatchResultExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "last batch result for '%s' does not exist", bondToken)
+	}
+
+	result := keeper.MustGetLastBatchResult(ctx, bondToken)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryEffectiveBatchBlocks(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	result := types.NewQueryEffectiveBatchBlocks(bond, keeper.GetBatchStress(ctx, bondToken))
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryBatchAuction(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+	batch := keeper.MustGetBatch(ctx, bondToken)
+
+	// Re-calculate the batch prices against the latest reserve, as is done
+	// before the batch is performed, to get the indicative clearing prices
+	clearingPrices, _, err := bond.GetBatchBuySellPrices(batch, bond.CurrentReserve)
+	if err != nil {
+		return nil, err
+	}
+	clearingPrices = zeroReserveTokensIfEmptyDec(clearingPrices, bond)
+
+	// Supply available to the batch is limited by the max supply and, for an
+	// augmented bond in its hatch phase, by the initial supply S0 (rounded to
+	// ceil, as is done when adding buys to the batch)
+	maxSupply := bond.MaxSupply.Amount
+	if bond.FunctionType == types.AugmentedFunction &&
+		bond.State == types.HatchState {
+		S0 := bond.FunctionParamsMap()["S0"].Ceil().TruncateInt()
+		maxSupply = sdk.MinInt(maxSupply, S0)
+	}
+	available := sdk.NewCoin(bondToken, sdk.ZeroInt())
+	if bond.CurrentSupply.Amount.LT(maxSupply) {
+		available = sdk.NewCoin(bondToken, maxSupply.Sub(bond.CurrentSupply.Amount))
+	}
+
+	auction := types.NewQueryBatchAuction(batch, clearingPrices, available)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, auction)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func querySimulateBatch(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	// A batch that would panic at the end of the block (i.e. a stuck batch)
+	// results in an error instead of crashing the query
+	defer func() {
+		if r := recover(); r != nil {
+			res, err = nil, sdkerrors.Wrapf(sdkerrors.ErrPanic, "settling batch: %v", r)
+		}
+	}()
+
+	// Settle the batch in a cache context, which is never written, so that
+	// none of the changes are committed
+	cacheCtx, _ := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+
+	batch := keeper.MustGetBatch(cacheCtx, bondToken)
+	feesBefore := keeper.GetModuleStats(cacheCtx).TotalFeesCollected
+
+	keeper.SettleBatch(cacheCtx, bondToken, nil)
+
+	feesAfter := keeper.GetModuleStats(cacheCtx).TotalFeesCollected
+
+	simulation := types.QuerySimulateBatch{
+		Batch:           keeper.MustGetLastBatch(cacheCtx, bondToken),
+		Result:          keeper.MustGetLastBatchResult(cacheCtx, bondToken),
+		TotalFees:       feesAfter.Sub(feesBefore),
+		BlocksRemaining: batch.BlocksRemaining,
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, simulation)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func querySupplyHistory(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	history := types.NewQuerySupplyHistory(keeper.GetBondHistory(ctx, bondToken))
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, history)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryReserveHistory(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	history := types.NewQueryReserveHistory(keeper.GetBondHistory(ctx, bondToken))
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, history)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryBondAtHeight(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+	heightStr := path[1]
+
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil || height < 0 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid height '%s'", heightStr)
+	}
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	bondAtHeight, err := keeper.GetBondAtHeight(ctx, bondToken, height)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, bondAtHeight)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryExport(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+	format := path[1]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	export, err := types.NewQueryBondExport(
+		bondToken, format, keeper.GetBondHistory(ctx, bondToken))
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, export)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryLedger(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	var params types.QueryLedgerParams
+	if err2 := keeper.cdc.UnmarshalJSON(req.Data, &params); err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err2.Error())
+	} else if err2 := params.Validate(); err2 != nil {
+		return nil, err2
+	}
+
+	ledger, found := keeper.GetLedger(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "ledger for '%s' does not exist", bondToken)
+	}
+
+	entries := keeper.GetLedgerEntriesPage(ctx, bondToken, params.Page, params.Limit)
+	if entries == nil {
+		entries = []types.LedgerEntry{}
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, types.QueryLedger{
+		Ledger:  ledger,
+		Page:    params.Page,
+		Limit:   params.Limit,
+		Total:   ledger.NextSequence,
+		Entries: entries,
+	})
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryLedgerExport(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+	format := path[1]
+
+	if _, found := keeper.GetLedger(ctx, bondToken); !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "ledger for '%s' does not exist", bondToken)
+	}
+
+	export, err := types.NewQueryLedgerExport(
+		bondToken, format, keeper.GetLedgerEntries(ctx, bondToken))
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, export)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryEffectiveAPR(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	apr, ok := types.NewQueryEffectiveAPR(keeper.GetBondHistory(ctx, bondToken))
+	if !ok {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest,
+			"bond '%s' does not have enough history to calculate its APR", bondToken)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, apr)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 func queryCurrentPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
 	bondToken := path[0]
 
@@ -165,6 +657,38 @@ func queryCurrentPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byt
 	return bz, nil
 }
 
+func queryQuotePrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	reserveBalances := keeper.GetReserveBalances(ctx, bondToken)
+	reservePrices, err := bond.GetCurrentPricesPT(reserveBalances)
+	if err != nil {
+		return nil, err
+	}
+
+	quotePrice, err := keeper.GetQuoteValue(ctx, bond, reservePrices)
+	if err != nil {
+		return nil, err
+	}
+
+	result := types.QueryQuotePrice{
+		Prices:     zeroReserveTokensIfEmptyDec(reservePrices, bond),
+		QuotePrice: quotePrice,
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 func queryCurrentReserve(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
 	bondToken := path[0]
 
@@ -236,7 +760,7 @@ func queryBuyPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, e
 		return nil, err
 	}
 	reservePricesRounded := types.RoundReservePrices(reservePrices)
-	txFee := bond.GetTxFees(reservePrices)
+	txFee := bond.GetOrderFees(types.AttributeValueBuyOrder, reservePrices).Total
 
 	var result types.QueryBuyPrice
 	result.AdjustedSupply = adjustedSupply
@@ -244,6 +768,8 @@ func queryBuyPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, e
 	result.TxFees = zeroReserveTokensIfEmpty(txFee, bond)
 	result.TotalPrices = zeroReserveTokensIfEmpty(reservePricesRounded.Add(txFee...), bond)
 	result.TotalFees = zeroReserveTokensIfEmpty(txFee, bond)
+	result.QuoteTotalPrices = getQuoteValueIfAvailable(ctx, keeper, bond,
+		reservePricesRounded.Add(txFee...))
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
 	if err2 != nil {
@@ -278,20 +804,30 @@ func querySellReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 	}
 
 	reserveBalances := keeper.GetReserveBalances(ctx, bondToken)
-	reserveReturns := bond.GetReturnsForBurn(bondCoin.Amount, reserveBalances)
+	reserveReturns, err := bond.GetReturnsForBurn(bondCoin.Amount, reserveBalances)
+	if err != nil {
+		return nil, err
+	}
 	reserveReturnsRounded := types.RoundReserveReturns(reserveReturns)
 
-	txFees := bond.GetTxFees(reserveReturns)
-	exitFees := bond.GetExitFees(reserveReturns)
-	totalFees := types.AdjustFees(txFees.Add(exitFees...), reserveReturnsRounded)
+	fees := bond.GetOrderFees(types.AttributeValueSellOrder, reserveReturns)
+	txFees := fees.AmountOf(types.TxFeeName)
+	exitFees := fees.AmountOf(types.ExitFeeName)
+	totalFees := fees.Total
+	totalReturns := reserveReturnsRounded.Sub(totalFees)
+	demurrage := bond.GetDemurrageCharges(
+		totalReturns, bond.GetDemurrageIndexAt(ctx.BlockHeight()))
 
 	var result types.QuerySellReturn
 	result.AdjustedSupply = adjustedSupply
 	result.Returns = zeroReserveTokensIfEmpty(reserveReturnsRounded, bond)
 	result.TxFees = zeroReserveTokensIfEmpty(txFees, bond)
 	result.ExitFees = zeroReserveTokensIfEmpty(exitFees, bond)
-	result.TotalReturns = zeroReserveTokensIfEmpty(reserveReturnsRounded.Sub(totalFees), bond)
+	result.TotalReturns = zeroReserveTokensIfEmpty(totalReturns.Sub(demurrage), bond)
 	result.TotalFees = zeroReserveTokensIfEmpty(totalFees, bond)
+	result.Demurrage = zeroReserveTokensIfEmpty(demurrage, bond)
+	result.QuoteTotalReturns = getQuoteValueIfAvailable(ctx, keeper, bond,
+		totalReturns.Sub(demurrage))
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
 	if err2 != nil {
@@ -338,3 +874,349 @@ func querySwapReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 
 	return bz, nil
 }
+
+func queryFeePreview(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "bond token and reserve amounts are required")
+	}
+	bondToken := path[0]
+	reserveAmounts := path[1]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	reserveDecCoins, err2 := sdk.ParseDecCoins(reserveAmounts)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+	}
+	for _, r := range reserveDecCoins {
+		if !bond.IsReserveToken(r.Denom) {
+			return nil, sdkerrors.Wrap(types.ErrTokenIsNotAValidReserveToken, r.Denom)
+		}
+	}
+
+	result := types.NewQueryFeePreview(bond, reserveDecCoins)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryPriceImpact(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	if len(path) < 3 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "bond token, order type and amount are required")
+	}
+	bondToken := path[0]
+	orderType := path[1]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, bondToken)
+	}
+
+	// The hypothetical order is added to the current batch, so that it is
+	// netted against the existing orders in the same way as a real order
+	batch := keeper.MustGetBatch(ctx, bondToken)
+	tradeBatch := batch
+	switch orderType {
+	case types.AttributeValueBuyOrder:
+		bondCoin, err2 := client.ParseTwoPartCoin(path[2], bondToken)
+		if err2 != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+		}
+
+		// Max supply cannot be less than supply (max supply >= supply)
+		adjustedSupply := keeper.GetSupplyAdjustedForBuy(ctx, bondToken)
+		if bond.MaxSupply.IsLT(adjustedSupply.Add(bondCoin)) {
+			return nil, sdkerrors.Wrap(types.ErrCannotMintMoreThanMaxSupply, bond.MaxSupply.String())
+		}
+
+		tradeBatch.TotalBuyAmount = batch.TotalBuyAmount.Add(bondCoin)
+	case types.AttributeValueSellOrder:
+		bondCoin, err2 := client.ParseTwoPartCoin(path[2], bondToken)
+		if err2 != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+		}
+
+		if !bond.AllowSells {
+			return nil, sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, bond.Name)
+		}
+
+		// Cannot burn more tokens than what exists
+		adjustedSupply := keeper.GetSupplyAdjustedForSell(ctx, bondToken)
+		if adjustedSupply.IsLT(bondCoin) {
+			return nil, sdkerrors.Wrap(types.ErrCannotBurnMoreThanSupply, adjustedSupply.String())
+		}
+
+		tradeBatch.TotalSellAmount = batch.TotalSellAmount.Add(bondCoin)
+	case types.AttributeValueSwapOrder:
+		if len(path) < 5 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "from token, from amount and to token are required")
+		}
+		fromToken := path[2]
+		fromAmount := path[3]
+		toToken := path[4]
+
+		fromCoin, err2 := client.ParseTwoPartCoin(fromAmount, fromToken)
+		if err2 != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+		}
+
+		// Check that the swap on its own is possible, since otherwise it would
+		// simply be skipped and the query would report no price impact
+		reserveBalances := keeper.GetReserveBalances(ctx, bondToken)
+		_, _, err = bond.GetReturnsForSwap(fromCoin, toToken, reserveBalances)
+		if err != nil {
+			return nil, err
+		}
+
+		swapOrder := types.NewSwapOrder(sdk.AccAddress{}, fromCoin, toToken)
+		tradeBatch.Swaps = append(append([]types.SwapOrder{}, batch.Swaps...), swapOrder)
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized order type '%s'", orderType)
+	}
+
+	// The prices before and after the trade are the prices after the batch is
+	// performed without and with the hypothetical order respectively
+	reserveBalances := keeper.GetReserveBalances(ctx, bondToken)
+	preTradePrices, err := bond.GetCurrentPricesAfterBatch(batch, reserveBalances)
+	if err != nil {
+		return nil, err
+	}
+	postTradePrices, err := bond.GetCurrentPricesAfterBatch(tradeBatch, reserveBalances)
+	if err != nil {
+		return nil, err
+	}
+
+	result := types.NewQueryPriceImpact(
+		zeroReserveTokensIfEmptyDec(preTradePrices, bond),
+		zeroReserveTokensIfEmptyDec(postTradePrices, bond))
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func querySanityCheck(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	if len(path) < 3 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "bond token, order type and amount are required")
+	}
+	bondToken := path[0]
+	orderType := path[1]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, bondToken)
+	} else if bond.FunctionType != types.SwapperFunction {
+		return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	}
+
+	// The new reserves are the reserves after the order on its own, in the
+	// same way as they are calculated when the order is performed
+	reserveBalances := keeper.GetReserveBalances(ctx, bondToken)
+	var newReserves sdk.Coins
+	switch orderType {
+	case types.AttributeValueBuyOrder:
+		bondCoin, err2 := client.ParseTwoPartCoin(path[2], bondToken)
+		if err2 != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+		}
+
+		if bond.CurrentSupply.IsZero() {
+			// The first buy provides the initial liquidity (the max prices)
+			if len(path) < 4 {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "max prices are required for the first buy")
+			}
+			maxPrices, err2 := sdk.ParseCoins(path[3])
+			if err2 != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+			} else if !bond.ReserveDenomsEqualTo(maxPrices) {
+				return nil, sdkerrors.Wrap(types.ErrReserveDenomsMismatch, maxPrices.String())
+			}
+			newReserves = maxPrices
+		} else {
+			reservePrices, err := bond.GetPricesToMint(bondCoin.Amount, reserveBalances)
+			if err != nil {
+				return nil, err
+			}
+			newReserves = reserveBalances.Add(types.RoundReservePrices(reservePrices)...)
+		}
+	case types.AttributeValueSellOrder:
+		bondCoin, err2 := client.ParseTwoPartCoin(path[2], bondToken)
+		if err2 != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+		}
+
+		// Cannot burn more tokens than what exists
+		adjustedSupply := keeper.GetSupplyAdjustedForSell(ctx, bondToken)
+		if adjustedSupply.IsLT(bondCoin) {
+			return nil, sdkerrors.Wrap(types.ErrCannotBurnMoreThanSupply, adjustedSupply.String())
+		}
+
+		reserveReturns, err := bond.GetReturnsForBurn(bondCoin.Amount, reserveBalances)
+		if err != nil {
+			return nil, err
+		}
+		newReserves = reserveBalances.Sub(types.RoundReserveReturns(reserveReturns))
+	case types.AttributeValueSwapOrder:
+		if len(path) < 5 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "from token, from amount and to token are required")
+		}
+		fromToken := path[2]
+		fromAmount := path[3]
+		toToken := path[4]
+
+		fromCoin, err2 := client.ParseTwoPartCoin(fromAmount, fromToken)
+		if err2 != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+		}
+
+		reserveReturns, txFee, err := bond.GetReturnsForSwap(fromCoin, toToken, reserveBalances)
+		if err != nil {
+			return nil, err
+		}
+		newReserves = reserveBalances.Add(fromCoin.Sub(txFee)).Sub(reserveReturns)
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized order type '%s'", orderType)
+	}
+
+	// The exchange rate is undefined if the second reserve would be emptied
+	if !newReserves.AmountOf(bond.ReserveTokens[1]).IsPositive() {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest,
+			"resulting %s reserve would be empty", bond.ReserveTokens[1])
+	}
+
+	result := types.NewQuerySanityCheck(bond, newReserves)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryOrderByReceipt(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	receipt := path[0]
+
+	if !types.IsValidReceipt(receipt) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "'%s' is not a valid order receipt", receipt)
+	}
+
+	orderReceipt, found := keeper.GetOrderReceipt(ctx, receipt)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "order with receipt '%s' does not exist", receipt)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, orderReceipt)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryScheduledParamChange(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	change, found := keeper.GetScheduledParamChange(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "scheduled param change for '%s' does not exist", bondToken)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, change)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryBondProposals(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	proposals := keeper.GetBondProposalsByBond(ctx, bondToken)
+	if proposals == nil {
+		proposals = []types.BondProposal{}
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, proposals)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryNotificationRegistrations(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	registrations := keeper.GetBondNotificationRegistrations(ctx, bondToken)
+	if registrations == nil {
+		registrations = []types.NotificationRegistration{}
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, registrations)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryBondProposal(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	proposalID, err := strconv.ParseUint(path[0], 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "'%s' is not a valid proposal ID", path[0])
+	}
+
+	proposal, found := keeper.GetBondProposal(ctx, proposalID)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond proposal '%d' does not exist", proposalID)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, proposal)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryModuleStats(ctx sdk.Context, keeper Keeper) (res []byte, err error) {
+	stats := keeper.GetModuleStats(ctx)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, stats)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryParams(ctx sdk.Context, keeper Keeper) (res []byte, err error) {
+	params := keeper.GetParams(ctx)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, params)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
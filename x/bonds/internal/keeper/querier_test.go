@@ -2,10 +2,12 @@ package keeper_test
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"strings"
 	"testing"
 )
 
@@ -45,27 +47,76 @@ func TestQueryBonds(t *testing.T) {
 	require.Equal(t, queryResult, types.QueryBonds{token})
 }
 
+func TestQuerySearchBonds(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryBonds
+
+	// Add bond
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Matching query (case-insensitive) returns the bond
+	res, err := querier(ctx, []string{keeper.QuerySearchBonds, "10", "TEST TOKEN"}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, types.QueryBonds{token}, queryResult)
+
+	// Non-matching query returns no bonds
+	res, err = querier(ctx, []string{keeper.QuerySearchBonds, "10", "abc"}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	queryResult = nil
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult, 0)
+
+	// Error if limit is invalid or out of range
+	_, err = querier(ctx, []string{keeper.QuerySearchBonds, "abc", "test"}, req)
+	require.Error(t, err)
+	_, err = querier(ctx, []string{keeper.QuerySearchBonds, "0", "test"}, req)
+	require.Error(t, err)
+	_, err = querier(ctx, []string{keeper.QuerySearchBonds, "101", "test"}, req)
+	require.Error(t, err)
+
+	// Error if query is missing or empty
+	_, err = querier(ctx, []string{keeper.QuerySearchBonds, "10"}, req)
+	require.Error(t, err)
+	_, err = querier(ctx, []string{keeper.QuerySearchBonds, "10", " "}, req)
+	require.Error(t, err)
+}
+
 func TestQueryBond(t *testing.T) {
 	app, ctx := createTestApp(false)
 	querier := keeper.NewQuerier(app.BondsKeeper)
 	req := abci.RequestQuery{}
-	var queryResult types.Bond
+	var queryResult types.QueryBond
 
 	// Initially error since no bond
 	res, err := querier(ctx, []string{keeper.QueryBond, token}, req)
 	require.Error(t, err)
 	require.Nil(t, res)
 
-	// Add bond
+	// Add bond with a supply of 10 and its batch
 	bond := getValidBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(token, 10)
 	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
 
 	// No error because of new bond
 	res, err = querier(ctx, []string{keeper.QueryBond, token}, req)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
-	require.Equal(t, queryResult, bond)
+	require.Equal(t, queryResult.Bond, bond)
+
+	// Price is 12*10^2+100 = 1300 and reserve is 12*10^3/3+100*10 = 5000
+	require.Equal(t, sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, 1300)), queryResult.SpotPrices)
+	require.Equal(t, sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, 5000)), queryResult.ImpliedReserve)
+	require.Equal(t, sdk.NewDec(5000).QuoInt64(13000), queryResult.ReserveRatio)
+	require.Equal(t, bond.State, queryResult.Phase)
+	require.Equal(t, ctx.BlockHeight()+int64(batchBlocks.Uint64()), queryResult.NextBatchHeight)
 }
 
 func TestQueryBatch(t *testing.T) {
@@ -114,6 +165,376 @@ func TestQueryLastBatch(t *testing.T) {
 	require.Equal(t, queryResult, batch)
 }
 
+func TestQueryLastBatchResult(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.BatchResult
+
+	// Initially error since no last batch result
+	res, err := querier(ctx, []string{keeper.QueryLastBatchResult, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add last batch result
+	result := types.NewBatchResult(getValidBatch(), ctx.BlockHeight())
+	app.BondsKeeper.SetLastBatchResult(ctx, token, result)
+
+	// No error because of new last batch result
+	res, err = querier(ctx, []string{keeper.QueryLastBatchResult, token}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, queryResult, result)
+}
+
+func TestQueryBatchAuction(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryBatchAuction
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryBatchAuction, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond with supply 10 and reserve 5000
+	// reserveAt(10) = (m/n+1)x^(n+1) + xc = (12/3)(10^(2+1)) + 10(100) = 5000
+	bond := getValidBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 10)
+	bond.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Add batch with pending buys for 3 and 7 tokens, and a cancelled buy
+	batch := getValidBatch()
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
+	cancelled := types.NewBuyOrder(baseOrderAddress, sdk.NewInt64Coin(token, 5), maxPrices)
+	cancelled.Cancelled = true
+	batch.Buys = []types.BuyOrder{
+		types.NewBuyOrder(baseOrderAddress, sdk.NewInt64Coin(token, 3), maxPrices),
+		cancelled,
+		types.NewBuyOrder(baseOrderAddress, sdk.NewInt64Coin(token, 7), maxPrices),
+	}
+	batch.TotalBuyAmount = sdk.NewInt64Coin(token, 10)
+	app.BondsKeeper.SetBatch(ctx, token, batch)
+
+	// Calculate clearing price and oversubscription manually
+	// price = (reserveAt(10+10) - reserveAt(10)) / 10 = (34000 - 5000) / 10 = 2900
+	// ratio = 10 / (maxSupply - 10) = 10 / 9990
+	manualClearingPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 2900)}
+	manualAvailable := sdk.NewCoin(token, initMaxSupply.Amount.SubRaw(10))
+	manualRatio := sdk.NewDec(10).QuoInt(manualAvailable.Amount)
+
+	// Check that bids are anonymized and sorted, and that the rest is correct
+	res, err = querier(ctx, []string{keeper.QueryBatchAuction, token}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, []sdk.Coin{sdk.NewInt64Coin(token, 7), sdk.NewInt64Coin(token, 3)}, queryResult.Bids)
+	require.Equal(t, manualClearingPrices, queryResult.ClearingPrices)
+	require.Equal(t, batch.TotalBuyAmount, queryResult.TotalBidAmount)
+	require.Equal(t, manualAvailable, queryResult.AvailableSupply)
+	require.Equal(t, manualRatio, queryResult.OversubscriptionRatio)
+}
+
+func TestQueryBatchOrders(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	var queryResult types.QueryBatchOrders
+
+	newReq := func(params types.QueryBatchOrdersParams) abci.RequestQuery {
+		return abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(params)}
+	}
+	req := newReq(types.NewQueryBatchOrdersParams(1, 2, "", nil, ""))
+
+	// Initially error since no batch
+	res, err := querier(ctx, []string{keeper.QueryBatchOrders, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add batch with two buys (one cancelled), a sell, and a swap
+	batch := getValidBatch()
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
+	cancelled := types.NewBuyOrder(buyerAddress, sdk.NewInt64Coin(token, 5), maxPrices)
+	cancelled.Cancelled = true
+	batch.Buys = []types.BuyOrder{
+		types.NewBuyOrder(buyerAddress, sdk.NewInt64Coin(token, 3), maxPrices),
+		cancelled,
+	}
+	batch.Sells = []types.SellOrder{
+		types.NewSellOrder(sellerAddress, sdk.NewInt64Coin(token, 2)),
+	}
+	batch.Swaps = []types.SwapOrder{
+		types.NewSwapOrder(swapperAddress, sdk.NewInt64Coin(reserveToken, 4), reserveToken2),
+	}
+	app.BondsKeeper.SetBatch(ctx, token, batch)
+
+	// Second page of two orders has the sell and the swap
+	req = newReq(types.NewQueryBatchOrdersParams(2, 2, "", nil, ""))
+	res, err = querier(ctx, []string{keeper.QueryBatchOrders, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, uint64(4), queryResult.Total)
+	require.Len(t, queryResult.Orders, 2)
+	require.Equal(t, types.AttributeValueSellOrder, queryResult.Orders[0].Side)
+	require.Equal(t, types.AttributeValueSwapOrder, queryResult.Orders[1].Side)
+	require.Equal(t, reserveToken2, queryResult.Orders[1].ToToken)
+
+	// Filtering by account, side, and status
+	req = newReq(types.NewQueryBatchOrdersParams(1, 10,
+		types.AttributeValueBuyOrder, buyerAddress, types.BatchOrderStatusCancelled))
+	res, err = querier(ctx, []string{keeper.QueryBatchOrders, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, uint64(1), queryResult.Total)
+	require.Equal(t, uint64(1), queryResult.Orders[0].Index)
+	require.Equal(t, cancelled.Amount, queryResult.Orders[0].Amount)
+	require.Equal(t, maxPrices, queryResult.Orders[0].MaxPrices)
+
+	// Invalid params give errors
+	for _, params := range []types.QueryBatchOrdersParams{
+		types.NewQueryBatchOrdersParams(0, 10, "", nil, ""),
+		types.NewQueryBatchOrdersParams(1, types.MaxBatchOrdersLimit+1, "", nil, ""),
+		types.NewQueryBatchOrdersParams(1, 10, "bid", nil, ""),
+		types.NewQueryBatchOrdersParams(1, 10, "", nil, "fulfilled"),
+	} {
+		_, err = querier(ctx, []string{keeper.QueryBatchOrders, token}, newReq(params))
+		require.Error(t, err)
+	}
+}
+
+func TestQuerySupplyAndReserveHistory(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var supplyResult types.QuerySupplyHistory
+	var reserveResult types.QueryReserveHistory
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QuerySupplyHistory, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+	res, err = querier(ctx, []string{keeper.QueryReserveHistory, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond and record two snapshots with different supply and reserve
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.RecordBondSnapshot(ctx, token)
+
+	bond.CurrentSupply = sdk.NewInt64Coin(token, 10)
+	bond.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	app.BondsKeeper.RecordBondSnapshot(ctx, token)
+
+	// Supply history lists both snapshots from oldest to newest
+	res, err = querier(ctx, []string{keeper.QuerySupplyHistory, token}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &supplyResult)
+	require.Len(t, supplyResult, 2)
+	require.Equal(t, sdk.NewInt64Coin(token, 0), supplyResult[0].Supply)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), supplyResult[1].Supply)
+	require.Equal(t, ctx.BlockHeight(), supplyResult[1].Height)
+
+	// Reserve history lists both snapshots from oldest to newest
+	res, err = querier(ctx, []string{keeper.QueryReserveHistory, token}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &reserveResult)
+	require.Len(t, reserveResult, 2)
+	require.True(t, reserveResult[0].Reserve.IsZero())
+	require.Equal(t, bond.CurrentReserve, reserveResult[1].Reserve)
+	require.Equal(t, ctx.BlockHeight(), reserveResult[1].Height)
+}
+
+func TestQueryBondAtHeight(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	path := func(height string) []string {
+		return []string{keeper.QueryBondAtHeight, token, height}
+	}
+
+	// Initially error since no bond
+	_, err := querier(ctx, path("1"), req)
+	require.Error(t, err)
+
+	// Add bond and record snapshots at heights 10 and 15
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	ctx = ctx.WithBlockHeight(10)
+	app.BondsKeeper.RecordBondSnapshot(ctx, token)
+	firstPrices, err := bond.GetCurrentPricesPT(nil)
+	require.NoError(t, err)
+
+	bond.CurrentSupply = sdk.NewInt64Coin(token, 10)
+	bond.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	ctx = ctx.WithBlockHeight(15)
+	app.BondsKeeper.RecordBondSnapshot(ctx, token)
+	secondPrices, err := bond.GetCurrentPricesPT(nil)
+	require.NoError(t, err)
+
+	// Error for invalid heights and heights before the oldest snapshot
+	for _, height := range []string{"abc", "-1", "9"} {
+		_, err = querier(ctx, path(height), req)
+		require.Error(t, err)
+	}
+
+	// Each height gives the last snapshot at or before the height
+	testCases := []struct {
+		height         string
+		snapshotHeight int64
+		supply         sdk.Coin
+		prices         sdk.DecCoins
+	}{
+		{"10", 10, sdk.NewInt64Coin(token, 0), firstPrices},
+		{"14", 10, sdk.NewInt64Coin(token, 0), firstPrices},
+		{"15", 15, sdk.NewInt64Coin(token, 10), secondPrices},
+		{"1000", 15, sdk.NewInt64Coin(token, 10), secondPrices},
+	}
+	for _, tc := range testCases {
+		res, err := querier(ctx, path(tc.height), req)
+		require.NoError(t, err)
+
+		var result types.QueryBondAtHeight
+		types.ModuleCdc.MustUnmarshalJSON(res, &result)
+		require.Equal(t, token, result.Bond)
+		require.Equal(t, tc.snapshotHeight, result.SnapshotHeight)
+		require.Equal(t, tc.supply, result.Supply)
+		require.Equal(t, tc.prices, result.Prices)
+	}
+
+	// Prices of snapshots recorded without prices are reconstructed from the
+	// snapshot's supply using the bond's function
+	history := app.BondsKeeper.GetBondHistory(ctx, token)
+	history[1].Prices = nil
+	app.BondsKeeper.SetBondHistory(ctx, token, history)
+	result, err := app.BondsKeeper.GetBondAtHeight(ctx, token, 15)
+	require.NoError(t, err)
+	require.Equal(t, secondPrices, result.Prices)
+}
+
+func TestQueryExport(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+
+	// Initially error since no bond
+	_, err := querier(ctx, []string{keeper.QueryExport, token, types.ExportFormatCSV}, req)
+	require.Error(t, err)
+
+	// Add bond and record a snapshot
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.RecordBondSnapshot(ctx, token)
+
+	// Error for unsupported formats
+	_, err = querier(ctx, []string{keeper.QueryExport, token, "xlsx"}, req)
+	require.Error(t, err)
+
+	// CSV has the header and one row for the snapshot
+	res, err := querier(ctx, []string{keeper.QueryExport, token, types.ExportFormatCSV}, req)
+	require.NoError(t, err)
+
+	var result types.QueryBondExport
+	types.ModuleCdc.MustUnmarshalJSON(res, &result)
+	require.Equal(t, token, result.Bond)
+	expected, err := types.NewBondAccountingCSV(token, app.BondsKeeper.GetBondHistory(ctx, token))
+	require.NoError(t, err)
+	require.Equal(t, expected, result.Content)
+	require.Len(t, strings.Split(strings.TrimSpace(result.Content), "\n"), 2)
+}
+
+func TestQueryLedger(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(
+		types.NewQueryLedgerParams(2, 2))}
+
+	// Initially error since no ledger
+	_, err := querier(ctx, []string{keeper.QueryLedger, token}, req)
+	require.Error(t, err)
+
+	// Add bond with a ledger and escrow funds three times
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.OpenLedger(ctx, token)
+	amount := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	_, err = app.BankKeeper.AddCoins(ctx, buyerAddress, amount.Add(amount...).Add(amount...))
+	require.Nil(t, err)
+	for i := 0; i < 3; i++ {
+		require.Nil(t, app.BondsKeeper.EscrowOrderFunds(ctx, token, buyerAddress, amount))
+	}
+
+	// Second page of two entries has the third entry
+	res, err := querier(ctx, []string{keeper.QueryLedger, token}, req)
+	require.NoError(t, err)
+	var result types.QueryLedger
+	types.ModuleCdc.MustUnmarshalJSON(res, &result)
+	require.Equal(t, uint64(3), result.Total)
+	require.Len(t, result.Entries, 1)
+	require.Equal(t, uint64(2), result.Entries[0].Sequence)
+	require.Equal(t, types.LedgerEntryEscrowIn, result.Entries[0].Kind)
+	require.Equal(t, buyerAddress, result.Entries[0].Address)
+	require.Equal(t, amount.Add(amount...).Add(amount...),
+		result.Ledger.Balance(types.LedgerAccountEscrow).Net())
+
+	// Error for limits above the max
+	req.Data = types.ModuleCdc.MustMarshalJSON(
+		types.NewQueryLedgerParams(1, types.MaxLedgerEntriesLimit+1))
+	_, err = querier(ctx, []string{keeper.QueryLedger, token}, req)
+	require.Error(t, err)
+
+	// CSV export has the header and one row per entry
+	res, err = querier(ctx, []string{keeper.QueryLedgerExport, token, types.ExportFormatCSV}, req)
+	require.NoError(t, err)
+	var export types.QueryBondExport
+	types.ModuleCdc.MustUnmarshalJSON(res, &export)
+	require.Len(t, strings.Split(strings.TrimSpace(export.Content), "\n"), 4)
+}
+
+func TestQueryEffectiveAPR(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var result types.QueryEffectiveAPR
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryEffectiveAPR, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond with a supply and record a snapshot
+	bond := getValidBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(token, 100)
+	bond.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000))
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.RecordBondSnapshot(ctx, token)
+
+	// Error since a single snapshot is not enough history
+	res, err = querier(ctx, []string{keeper.QueryEffectiveAPR, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Reserve per token grows by 5% over a quarter of a year
+	bond.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1050))
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + types.BlocksPerYear/4)
+	app.BondsKeeper.RecordBondSnapshot(ctx, token)
+
+	res, err = querier(ctx, []string{keeper.QueryEffectiveAPR, token}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &result)
+	require.Equal(t, ctx.BlockHeight(), result.ToHeight)
+	require.Len(t, result.APRPercentages, 1)
+	require.Equal(t, reserveToken, result.APRPercentages[0].Denom)
+	require.Equal(t, sdk.NewDec(20), result.APRPercentages[0].Percentage)
+}
+
 func TestQueryCurrentPrice(t *testing.T) {
 	app, ctx := createTestApp(false)
 	querier := keeper.NewQuerier(app.BondsKeeper)
@@ -262,6 +683,100 @@ func TestQueryCurrentPriceForSwapper(t *testing.T) {
 	require.Equal(t, queryResult, manualPrices)
 }
 
+type mockOracleKeeper struct {
+	base  string
+	quote string
+	rate  sdk.Dec
+}
+
+func (ok mockOracleKeeper) GetExchangeRate(_ sdk.Context, base, quote string) (sdk.Dec, bool) {
+	if base != ok.base || quote != ok.quote {
+		return sdk.Dec{}, false
+	}
+	return ok.rate, true
+}
+
+func TestQueryQuotePrice(t *testing.T) {
+	app, ctx := createTestApp(false)
+	app.BondsKeeper.SetOracleKeeper(mockOracleKeeper{
+		base: "usd", quote: reserveToken, rate: sdk.MustNewDecFromStr("0.5")})
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryQuotePrice
+
+	// Add bond with a current supply of 10
+	bond := getValidBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 10)
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Error since the bond has no quote denomination
+	res, err := querier(ctx, []string{keeper.QueryQuotePrice, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Error since the oracle has no rate for the quote denomination
+	bond.QuoteDenom = "eur"
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	res, err = querier(ctx, []string{keeper.QueryQuotePrice, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Set quote denomination with an available rate
+	bond.QuoteDenom = "usd"
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Calculate current price manually
+	// y = mx^n + c = 12(10^2) + 100 = 1200 + 100 = 1300
+	// quote price = 1300 * 0.5 = 650
+	manualPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 1300)}
+	manualQuotePrice := sdk.NewInt64DecCoin("usd", 650)
+
+	// Check that prices are correct
+	res, err = querier(ctx, []string{keeper.QueryQuotePrice, token}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, manualPrices, queryResult.Prices)
+	require.Equal(t, manualQuotePrice, queryResult.QuotePrice)
+}
+
+func TestQueryBuyPriceIncludesQuoteTotalPrices(t *testing.T) {
+	app, ctx := createTestApp(false)
+	app.BondsKeeper.SetOracleKeeper(mockOracleKeeper{
+		base: "usd", quote: reserveToken, rate: sdk.MustNewDecFromStr("0.5")})
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryBuyPrice
+
+	// Add bond and batch
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	// No quote total prices since the bond has no quote denomination
+	res, err := querier(ctx,
+		[]string{keeper.QueryBuyPrice, token, "10"}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Nil(t, queryResult.QuoteTotalPrices)
+
+	// Set quote denomination
+	bond.QuoteDenom = "usd"
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Calculate quote total prices manually
+	// price = 5000, tx fee = 0.1% of 5000 = 5, total = 5005
+	// quote total = 5005 * 0.5 = 2502.5
+	manualQuoteTotalPrices := sdk.DecCoins{
+		sdk.NewDecCoinFromDec("usd", sdk.MustNewDecFromStr("2502.5"))}
+
+	res, err = querier(ctx,
+		[]string{keeper.QueryBuyPrice, token, "10"}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, manualQuoteTotalPrices, queryResult.QuoteTotalPrices)
+}
+
 func TestQueryCurrentReserve(t *testing.T) {
 	app, ctx := createTestApp(false)
 	querier := keeper.NewQuerier(app.BondsKeeper)
@@ -492,7 +1007,8 @@ func TestQuerySellPrice(t *testing.T) {
 	bond, _ = app.BondsKeeper.GetBond(ctx, token)
 	sellAmount := sdk.NewInt(10)
 	reserveBalances := app.BondsKeeper.GetReserveBalances(ctx, token)
-	sellReturns := bond.GetReturnsForBurn(buyAmount, reserveBalances)
+	sellReturns, err := bond.GetReturnsForBurn(buyAmount, reserveBalances)
+	require.Nil(t, err)
 	txFees := bond.GetTxFees(sellReturns)
 	exitFees := bond.GetExitFees(sellReturns)
 	totalFees := txFees.Add(exitFees...)
@@ -581,3 +1097,565 @@ func TestQuerySwapReturn(t *testing.T) {
 	require.Equal(t, queryResult.TotalReturns, manualSwapReturns)
 	require.Equal(t, queryResult.TotalFees, sdk.Coins{txFee})
 }
+
+func TestQueryFeePreview(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var result types.QueryFeePreview
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryFeePreview, token, "1000.5" + reserveToken}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond with 0.1% tx and exit fees
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Error since not a reserve token of the bond
+	res, err = querier(ctx, []string{keeper.QueryFeePreview, token, "1000.5" + reserveToken2}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Fees of 1.0005res are each rounded up to 2res
+	res, err = querier(ctx, []string{keeper.QueryFeePreview, token, "1000.5" + reserveToken}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &result)
+
+	exactFee := sdk.NewDecCoins(sdk.NewDecCoinFromDec(reserveToken, sdk.MustNewDecFromStr("1.0005")))
+	fee := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 2))
+	require.Equal(t, exactFee, result.ExactTxFees)
+	require.Equal(t, exactFee, result.ExactExitFees)
+	require.Equal(t, fee, result.TxFees)
+	require.Equal(t, fee, result.ExitFees)
+	require.Equal(t, fee, result.BuyTotalFees)
+	require.Equal(t, fee.Add(fee...), result.SellTotalFees)
+	require.Equal(t, fee, result.SwapTotalFees)
+}
+
+func TestQueryPriceImpact(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryPriceImpact
+
+	// Initially error since no bond
+	dummyAmount := sdk.OneInt().String()
+	res, err := querier(ctx, []string{keeper.QueryPriceImpact,
+		token, types.AttributeValueBuyOrder, dummyAmount}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond and batch
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	// Calculate prices manually
+	// price(0) = mx^n + c = 12(0^2) + 100 = 100
+	// price(10) = mx^n + c = 12(10^2) + 100 = 1300
+	// impact = (1300 - 100) / 100 = 1200%
+	manualPreTradePrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 100)}
+	manualPostTradePrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 1300)}
+	manualImpacts := []types.PriceImpact{{Denom: reserveToken, Percentage: sdk.NewDec(1200)}}
+
+	// Check that prices and impact are correct
+	res, err = querier(ctx, []string{keeper.QueryPriceImpact,
+		token, types.AttributeValueBuyOrder, "10"}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, manualPreTradePrices, queryResult.PreTradePrices)
+	require.Equal(t, manualPostTradePrices, queryResult.PostTradePrices)
+	require.Equal(t, manualImpacts, queryResult.ImpactPercentages)
+}
+
+func TestQueryPriceImpactNetsOrdersInBatch(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryPriceImpact
+
+	// Add bond with supply 10 and reserve 5000
+	// reserveAt(10) = (m/n+1)x^(n+1) + xc = (12/3)(10^(2+1)) + 10(100) = 5000
+	bond := getValidBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 10)
+	bond.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Add batch with a pending buy for 10 tokens
+	batch := getValidBatch()
+	batch.TotalBuyAmount = sdk.NewInt64Coin(bond.Token, 10)
+	app.BondsKeeper.SetBatch(ctx, token, batch)
+
+	// Calculate prices manually (sell of 5 is netted against the buy of 10)
+	// price(10+10) = mx^n + c = 12(20^2) + 100 = 4900
+	// price(10+10-5) = mx^n + c = 12(15^2) + 100 = 2800
+	manualPreTradePrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 4900)}
+	manualPostTradePrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 2800)}
+	manualImpact := sdk.NewDec(2800 - 4900).QuoInt64(4900).MulInt64(100)
+
+	// Check that prices and impact are correct
+	res, err := querier(ctx, []string{keeper.QueryPriceImpact,
+		token, types.AttributeValueSellOrder, "5"}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, manualPreTradePrices, queryResult.PreTradePrices)
+	require.Equal(t, manualPostTradePrices, queryResult.PostTradePrices)
+	require.Len(t, queryResult.ImpactPercentages, 1)
+	require.Equal(t, manualImpact, queryResult.ImpactPercentages[0].Percentage)
+	require.True(t, queryResult.ImpactPercentages[0].Percentage.IsNegative())
+}
+
+func TestQueryPriceImpactInvalidOrdersGiveError(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+
+	// Add bond and batch
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	// Error since unrecognized order type
+	_, err := querier(ctx, []string{keeper.QueryPriceImpact,
+		token, "foo", "10"}, req)
+	require.Error(t, err)
+
+	// Error since buy exceeds max supply
+	aboveMaxSupply := bond.MaxSupply.Amount.AddRaw(1).String()
+	_, err = querier(ctx, []string{keeper.QueryPriceImpact,
+		token, types.AttributeValueBuyOrder, aboveMaxSupply}, req)
+	require.Error(t, err)
+	require.True(t, types.ErrCannotMintMoreThanMaxSupply.Is(err))
+
+	// Error since sell exceeds supply
+	_, err = querier(ctx, []string{keeper.QueryPriceImpact,
+		token, types.AttributeValueSellOrder, "10"}, req)
+	require.Error(t, err)
+	require.True(t, types.ErrCannotBurnMoreThanSupply.Is(err))
+
+	// Error since swap not available for power function
+	_, err = querier(ctx, []string{keeper.QueryPriceImpact, token,
+		types.AttributeValueSwapOrder, reserveToken, "10", reserveToken2}, req)
+	require.Error(t, err)
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
+}
+
+func TestQueryPriceImpactForSwap(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryPriceImpact
+
+	// Add swapper bond with current supply 2, and batch
+	bond := getValidSwapperBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 2)
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	// Send 200res,300rez to reserve
+	newReserve := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300),
+	)
+	_ = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, newReserve)
+	_ = app.BondsKeeper.DepositReserveFromModule(
+		ctx, bond.Token, types.BondsMintBurnAccount, newReserve)
+
+	// Get prices before and after swap directly (swap of 100res gives 99rez
+	// and 1res is charged as a fee; refer to TestQuerySwapReturn)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	preTradePrices, _ := bond.GetCurrentPricesPT(newReserve)
+	postTradePrices, _ := bond.GetCurrentPricesPT(sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 299),
+		sdk.NewInt64Coin(reserveToken2, 201),
+	))
+
+	// Check that prices are correct and that price in res went up and rez down
+	res, err := querier(ctx, []string{keeper.QueryPriceImpact, token,
+		types.AttributeValueSwapOrder, reserveToken, "100", reserveToken2}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, preTradePrices, queryResult.PreTradePrices)
+	require.Equal(t, postTradePrices, queryResult.PostTradePrices)
+	require.Len(t, queryResult.ImpactPercentages, 2)
+	require.Equal(t, reserveToken, queryResult.ImpactPercentages[0].Denom)
+	require.True(t, queryResult.ImpactPercentages[0].Percentage.IsPositive())
+	require.Equal(t, reserveToken2, queryResult.ImpactPercentages[1].Denom)
+	require.True(t, queryResult.ImpactPercentages[1].Percentage.IsNegative())
+}
+
+func TestQuerySanityCheckForSwap(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QuerySanityCheck
+
+	// Add swapper bond with current supply 2, sanity rate 1 +-20%, and batch
+	bond := getValidSwapperBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 2)
+	bond.SanityRate = sdk.OneDec()
+	bond.SanityMarginPercentage = sdk.NewDec(20)
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	// Send 200res,300rez to reserve
+	newReserve := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300),
+	)
+	_ = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, newReserve)
+	_ = app.BondsKeeper.DepositReserveFromModule(
+		ctx, bond.Token, types.BondsMintBurnAccount, newReserve)
+
+	// Swap of 100res gives 99rez and 1res is charged as a fee (refer to
+	// TestQuerySwapReturn), so the new rate is 299/201, which is above 1.2
+	expectedReserves := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 299),
+		sdk.NewInt64Coin(reserveToken2, 201),
+	)
+	expectedRate := sdk.NewDec(299).Quo(sdk.NewDec(201))
+
+	res, err := querier(ctx, []string{keeper.QuerySanityCheck, token,
+		types.AttributeValueSwapOrder, reserveToken, "100", reserveToken2}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, expectedReserves, queryResult.NewReserves)
+	require.Equal(t, expectedRate, queryResult.ExchangeRate)
+	require.Equal(t, sdk.MustNewDecFromStr("0.8"), queryResult.MinRate)
+	require.Equal(t, sdk.MustNewDecFromStr("1.2"), queryResult.MaxRate)
+	require.True(t, queryResult.ViolatesSanityRate)
+	require.Equal(t, expectedRate.Sub(sdk.MustNewDecFromStr("1.2")), queryResult.DistanceFromBand)
+
+	// With a sanity margin of 50%, the new rate is within 0.5 to 1.5
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	bond.SanityMarginPercentage = sdk.NewDec(50)
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	res, err = querier(ctx, []string{keeper.QuerySanityCheck, token,
+		types.AttributeValueSwapOrder, reserveToken, "100", reserveToken2}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.False(t, queryResult.ViolatesSanityRate)
+	require.True(t, queryResult.DistanceFromBand.IsZero())
+}
+
+func TestQuerySanityCheckForNonSwapperBondGivesError(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+
+	_, err := querier(ctx, []string{keeper.QuerySanityCheck, token,
+		types.AttributeValueBuyOrder, "10"}, req)
+	require.Error(t, err)
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
+}
+
+func TestQueryIsTradeAllowed(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	var queryResult types.QueryIsTradeAllowed
+
+	newReq := func(params types.QueryIsTradeAllowedParams) abci.RequestQuery {
+		return abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(params)}
+	}
+	buyMaxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000))
+	buyReq := newReq(types.NewQueryIsTradeAllowedParams(
+		types.AttributeValueBuyOrder, buyerAddress, buyAmount, buyMaxPrices, ""))
+
+	// Not allowed since the bond does not exist
+	res, err := querier(ctx, []string{keeper.QueryIsTradeAllowed, token}, buyReq)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.False(t, queryResult.Allowed)
+	require.Equal(t, types.DefaultCodespace, queryResult.Codespace)
+	require.Equal(t, types.ErrBondDoesNotExist.ABCICode(), queryResult.Code)
+
+	// Add bond and batch
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	// Not allowed since the buyer cannot afford the max prices
+	res, err = querier(ctx, []string{keeper.QueryIsTradeAllowed, token}, buyReq)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.False(t, queryResult.Allowed)
+	require.Equal(t, sdkerrors.ErrInsufficientFunds.ABCICode(), queryResult.Code)
+	require.NotEmpty(t, queryResult.Error)
+
+	// Allowed once the buyer has the max prices
+	_, err = app.BankKeeper.AddCoins(ctx, buyerAddress, buyMaxPrices)
+	require.NoError(t, err)
+	res, err = querier(ctx, []string{keeper.QueryIsTradeAllowed, token}, buyReq)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, types.NewQueryIsTradeAllowed(nil), queryResult)
+
+	// The check did not change the state
+	require.Empty(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys)
+	require.Equal(t, buyMaxPrices, app.BankKeeper.GetCoins(ctx, buyerAddress))
+
+	// Not allowed since the order fails its basic validation
+	res, err = querier(ctx, []string{keeper.QueryIsTradeAllowed, token},
+		newReq(types.NewQueryIsTradeAllowedParams(
+			types.AttributeValueBuyOrder, buyerAddress, buyAmount, nil, "")))
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.False(t, queryResult.Allowed)
+
+	// Not allowed since the bond does not allow sells
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	bond.AllowSells = false
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	res, err = querier(ctx, []string{keeper.QueryIsTradeAllowed, token},
+		newReq(types.NewQueryIsTradeAllowedParams(
+			types.AttributeValueSellOrder, sellerAddress, sellAmount, nil, "")))
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.False(t, queryResult.Allowed)
+	require.Equal(t, types.ErrBondDoesNotAllowSelling.ABCICode(), queryResult.Code)
+
+	// Not allowed since order submission is halted
+	params := app.BondsKeeper.GetParams(ctx)
+	params.OrderSubmissionHalted = true
+	app.BondsKeeper.SetParams(ctx, params)
+	res, err = querier(ctx, []string{keeper.QueryIsTradeAllowed, token}, buyReq)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.False(t, queryResult.Allowed)
+	require.Equal(t, types.ErrOrderSubmissionHalted.ABCICode(), queryResult.Code)
+
+	// Error if the side is invalid or the amount is not in the bond token
+	for _, params := range []types.QueryIsTradeAllowedParams{
+		types.NewQueryIsTradeAllowedParams("bid", buyerAddress, buyAmount, buyMaxPrices, ""),
+		types.NewQueryIsTradeAllowedParams(types.AttributeValueSellOrder,
+			sellerAddress, sdk.NewInt64Coin(reserveToken, 1), nil, ""),
+	} {
+		_, err = querier(ctx, []string{keeper.QueryIsTradeAllowed, token}, newReq(params))
+		require.Error(t, err)
+	}
+}
+
+func TestQueryOrderByReceipt(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.OrderReceipt
+
+	// Error since not a valid receipt
+	res, err := querier(ctx, []string{keeper.QueryOrderByReceipt, "abc"}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Error since no order with the receipt
+	receipt := types.NewSellOrderReceipt(1, sellerAddress, sellAmount, 1)
+	res, err = querier(ctx, []string{keeper.QueryOrderByReceipt, receipt.Receipt}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Issue order receipt
+	receipt = app.BondsKeeper.IssueSellOrderReceipt(ctx, sellerAddress, sellAmount)
+
+	// Check that order receipt is returned
+	res, err = querier(ctx, []string{keeper.QueryOrderByReceipt, receipt.Receipt}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, receipt, queryResult)
+}
+
+func TestQueryScheduledParamChange(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.ScheduledParamChange
+
+	// Add bond
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+
+	// Error since no change scheduled
+	res, err := querier(ctx, []string{keeper.QueryScheduledChange, bond.Token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Schedule change
+	change := types.NewScheduledParamChange(
+		bond.Token, bond.FunctionParameters, 100, ctx.BlockHeight())
+	app.BondsKeeper.SetScheduledParamChange(ctx, change)
+
+	// Check that scheduled change is returned
+	res, err = querier(ctx, []string{keeper.QueryScheduledChange, bond.Token}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, change, queryResult)
+}
+
+func TestQueryBondAdmin(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryBondAdmin
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryBondAdmin, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond and batch with one pending and one cancelled buy
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	batch := getValidBatch()
+	batch.Buys = []types.BuyOrder{
+		types.NewBuyOrder(bond.FeeAddress, sdk.NewInt64Coin(token, 10), nil),
+		types.NewBuyOrder(bond.FeeAddress, sdk.NewInt64Coin(token, 20), nil),
+	}
+	batch.Buys[1].Cancelled = true
+	app.BondsKeeper.SetBatch(ctx, bond.Token, batch)
+
+	// Add reserve and non-reserve tokens to fee address
+	err = app.BankKeeper.SetCoins(ctx, bond.FeeAddress, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100), sdk.NewInt64Coin("other", 50)))
+	require.Nil(t, err)
+
+	// Query without scheduled change
+	res, err = querier(ctx, []string{keeper.QueryBondAdmin, bond.Token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, bond.Token, queryResult.Bond.Token)
+	require.Equal(t, bond.Signers, queryResult.Signers)
+	require.Equal(t, bond.SignerThreshold, queryResult.SignerThreshold)
+	require.Nil(t, queryResult.ScheduledParamChange)
+	require.True(t, queryResult.FeesCollected.IsZero())
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)),
+		queryResult.FundingPoolBalance)
+	require.False(t, queryResult.OrderSubmissionHalted)
+	require.Equal(t, uint64(1), queryResult.PendingBatch.BuyOrders)
+	require.Equal(t, batch.BlocksRemaining, queryResult.PendingBatch.BlocksRemaining)
+
+	// Schedule change and query again
+	change := types.NewScheduledParamChange(
+		bond.Token, bond.FunctionParameters, 100, ctx.BlockHeight())
+	app.BondsKeeper.SetScheduledParamChange(ctx, change)
+	res, err = querier(ctx, []string{keeper.QueryBondAdmin, bond.Token}, req)
+	require.NoError(t, err)
+	queryResult = types.QueryBondAdmin{}
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.NotNil(t, queryResult.ScheduledParamChange)
+	require.Equal(t, change, *queryResult.ScheduledParamChange)
+}
+
+func TestQueryModuleStats(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.ModuleStats
+
+	// Add bond
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+
+	// Query module stats
+	res, err := querier(ctx, []string{keeper.QueryModuleStats}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, uint64(1), queryResult.TotalBonds())
+	require.Equal(t, uint64(1), queryResult.BondCountOf(bond.FunctionType))
+}
+
+func TestQueryEffectiveBatchBlocks(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryEffectiveBatchBlocks
+
+	// Error if bond does not exist
+	_, err := querier(ctx, []string{keeper.QueryEffectiveBatchBlocks, token}, req)
+	require.Error(t, err)
+
+	// Add bond
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+
+	// Initially no extra blocks
+	res, err := querier(ctx, []string{keeper.QueryEffectiveBatchBlocks, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, bond.BatchBlocks, queryResult.EffectiveBatchBlocks)
+	require.Equal(t, uint64(0), queryResult.ExtraBlocks)
+
+	// Extra blocks lengthen the effective batch blocks
+	app.BondsKeeper.SetBatchStress(ctx, token, types.NewBatchStress(3, 20))
+	res, err = querier(ctx, []string{keeper.QueryEffectiveBatchBlocks, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, types.NewQueryEffectiveBatchBlocks(
+		bond, types.NewBatchStress(3, 20)), queryResult)
+	require.Equal(t, bond.BatchBlocks.Add(sdk.NewUint(20)), queryResult.EffectiveBatchBlocks)
+}
+
+func TestQueryParams(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.Params
+
+	// Initially default params
+	res, err := querier(ctx, []string{keeper.QueryParams}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, types.DefaultParams(), queryResult)
+
+	// Params reflect changes
+	app.BondsKeeper.SetParams(ctx, types.NewParams(true, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks))
+	res, err = querier(ctx, []string{keeper.QueryParams}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, types.NewParams(true, types.DefaultBondProposalQuorum,
+		types.DefaultBondCreationFee, types.DefaultCreationFeeDestination,
+		types.DefaultMaxNameLength, types.DefaultMaxDescriptionLength,
+		types.DefaultBuySpendCap, types.DefaultSpendCapWindowBlocks,
+		types.DefaultMaxSanityRateStepPercentage, types.DefaultMaxSanityRateWindowPercentage,
+		types.DefaultSanityRateWindowBlocks, types.DefaultMaxPreMinePercentage,
+		types.DefaultPreMineVestingBlocks, types.DefaultAlertChangePercentage,
+		types.DefaultAlertWindowBlocks, types.DefaultMaxTotalValueLocked,
+		types.DefaultMaxBondValueLocked,
+		types.DefaultFeeDustThreshold, types.DefaultFeeDustDenom,
+		types.DefaultFeeDustSweepBlocks,
+		types.DefaultNotificationDeposit, types.DefaultMaxBondNotificationRelays,
+		types.DefaultStressPriceImpactPercentage, types.DefaultStressVolumePercentage,
+		types.DefaultStressConsecutiveBatches, types.DefaultStressExtraBlocks,
+		types.DefaultStressMaxExtraBlocks,
+		types.DefaultMinBatchBlocks, types.DefaultMaxBatchBlocks,
+		types.DefaultOrderReceiptRetentionBlocks,
+		types.DefaultLedgerEntryRetentionBlocks), queryResult)
+}
@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"bytes"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// RebuildIndexes regenerates the module's derived data from the bonds and
+// batches in state: the bond search index and the module stats are rebuilt
+// from scratch, and a bond history is seeded with a snapshot of the bond's
+// current state for each bond that has none. This is intended for chains
+// upgrading to a version that introduces any of these stores, which can call
+// it from the upgrade's handler instead of migrating the derived data exactly.
+//
+// The total fees collected cannot be fully rebuilt, since fees were not
+// recorded per bond by earlier versions, so the stored total is only replaced
+// if it is less than the sum of the fees recorded per bond.
+func (k Keeper) RebuildIndexes(ctx sdk.Context) types.RebuildIndexesReport {
+	store := ctx.KVStore(k.storeKey)
+	report := types.RebuildIndexesReport{StatsBefore: k.GetModuleStats(ctx)}
+
+	// Remove the existing search index entries, remembering them so that
+	// changed entries can be reported
+	oldEntries := make(map[string][]byte)
+	iterator := k.GetBondSearchIndexIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		oldEntries[string(iterator.Key())] = iterator.Value()
+	}
+	iterator.Close()
+	for key := range oldEntries {
+		store.Delete([]byte(key))
+	}
+
+	stats := types.NewModuleStats()
+	feesCollected := sdk.Coins{}
+
+	var bonds []types.Bond
+	iterator = k.GetBondIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		bonds = append(bonds, k.MustGetBondByKey(ctx, iterator.Key()))
+	}
+	iterator.Close()
+
+	for _, bond := range bonds {
+		report.Bonds += 1
+
+		// Search index
+		k.setBondSearchIndexEntry(ctx, bond.Token, bond)
+		key := types.GetBondSearchIndexKey(bond.Token)
+		newEntry := store.Get(key)
+		if newEntry != nil {
+			report.SearchIndexEntries += 1
+		}
+		if !bytes.Equal(oldEntries[string(key)], newEntry) {
+			report.SearchIndexChanges += 1
+		}
+		delete(oldEntries, string(key))
+
+		// Module stats
+		stats = stats.AddBond(bond.FunctionType)
+		stats.TotalValueLocked = stats.TotalValueLocked.Add(bond.CurrentReserve...)
+		if k.BatchExists(ctx, bond.Token) && k.MustGetBatch(ctx, bond.Token).HasOrders() {
+			stats.ActiveBatches += 1
+		}
+		feesCollected = feesCollected.Add(k.GetBondFeesCollected(ctx, bond.Token)...)
+
+		// Bond histories
+		if len(k.GetBondHistory(ctx, bond.Token)) == 0 {
+			k.RecordBondSnapshot(ctx, bond.Token)
+			report.SeededBondHistories = append(report.SeededBondHistories, bond.Token)
+		}
+	}
+
+	// Entries left over belong to bonds that no longer exist
+	report.SearchIndexChanges += uint64(len(oldEntries))
+
+	stats.TotalFeesCollected = feesCollected
+	if report.StatsBefore.TotalFeesCollected.IsAllGTE(feesCollected) {
+		stats.TotalFeesCollected = report.StatsBefore.TotalFeesCollected
+	}
+	k.SetModuleStats(ctx, stats)
+	report.StatsAfter = stats
+
+	return report
+}
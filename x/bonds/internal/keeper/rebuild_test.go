@@ -0,0 +1,41 @@
+package keeper_test
+
+import (
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRebuildIndexes(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add bonds
+	powerBond := getValidPowerFunctionBond()
+	app.BondsKeeper.SetBond(ctx, powerBond.Token, powerBond)
+	swapperBond := getValidSwapperBond()
+	swapperBond.Token = token2
+	app.BondsKeeper.SetBond(ctx, swapperBond.Token, swapperBond)
+	expectedStats := app.BondsKeeper.GetModuleStats(ctx)
+
+	// Lose the module stats, as if the store was introduced by an upgrade
+	app.BondsKeeper.SetModuleStats(ctx, types.NewModuleStats())
+
+	// Rebuild restores the stats, keeps the search index, and seeds histories
+	report := app.BondsKeeper.RebuildIndexes(ctx)
+	require.True(t, report.HasChanges())
+	require.Equal(t, uint64(2), report.Bonds)
+	require.Equal(t, uint64(2), report.SearchIndexEntries)
+	require.Equal(t, uint64(0), report.SearchIndexChanges)
+	require.Equal(t, uint64(0), report.StatsBefore.TotalBonds())
+	require.Equal(t, expectedStats, report.StatsAfter)
+	require.Equal(t, expectedStats, app.BondsKeeper.GetModuleStats(ctx))
+	require.ElementsMatch(t, []string{powerBond.Token, swapperBond.Token},
+		report.SeededBondHistories)
+	require.Len(t, app.BondsKeeper.GetBondHistory(ctx, powerBond.Token), 1)
+	require.Len(t, app.BondsKeeper.SearchBonds(ctx, powerBond.Name, 10), 2)
+
+	// Rebuilding again changes nothing
+	report = app.BondsKeeper.RebuildIndexes(ctx)
+	require.False(t, report.HasChanges())
+	require.Len(t, app.BondsKeeper.GetBondHistory(ctx, powerBond.Token), 1)
+}
@@ -0,0 +1,125 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetNextOrderID returns the ID that will be assigned to the next order. IDs
+// are unique across all bonds and start from 1.
+func (k Keeper) GetNextOrderID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.NextOrderIDKey) {
+		return 1
+	}
+
+	bz := store.Get(types.NextOrderIDKey)
+	var orderID uint64
+	k.cdc.MustUnmarshalBinaryBare(bz, &orderID)
+
+	return orderID
+}
+
+func (k Keeper) SetNextOrderID(ctx sdk.Context, orderID uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.NextOrderIDKey, k.cdc.MustMarshalBinaryBare(orderID))
+}
+
+func (k Keeper) GetOrderReceipt(ctx sdk.Context, receipt string) (orderReceipt types.OrderReceipt, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetOrderReceiptKey(receipt)) {
+		return types.OrderReceipt{}, false
+	}
+
+	bz := store.Get(types.GetOrderReceiptKey(receipt))
+	k.cdc.MustUnmarshalBinaryBare(bz, &orderReceipt)
+
+	return orderReceipt, true
+}
+
+// GetOrderReceipts returns all of the stored order receipts.
+func (k Keeper) GetOrderReceipts(ctx sdk.Context) (orderReceipts []types.OrderReceipt) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.OrderReceiptsKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var orderReceipt types.OrderReceipt
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &orderReceipt)
+		orderReceipts = append(orderReceipts, orderReceipt)
+	}
+	return orderReceipts
+}
+
+// SetOrderReceipt stores the order receipt, indexing it by the height at which
+// it was issued so that it can be pruned once it is no longer retained.
+func (k Keeper) SetOrderReceipt(ctx sdk.Context, orderReceipt types.OrderReceipt) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetOrderReceiptKey(orderReceipt.Receipt),
+		k.cdc.MustMarshalBinaryBare(orderReceipt))
+	store.Set(types.GetOrderReceiptHeightKey(orderReceipt.Height, orderReceipt.Receipt),
+		[]byte{})
+}
+
+// PruneOrderReceipts deletes the order receipts issued more than the order
+// receipt retention blocks ago, unless the retention blocks are zero, in which
+// case order receipts are kept forever.
+func (k Keeper) PruneOrderReceipts(ctx sdk.Context) {
+	retentionBlocks := k.OrderReceiptRetentionBlocks(ctx)
+	if retentionBlocks == 0 || ctx.BlockHeight() <= int64(retentionBlocks) {
+		return
+	}
+	cutoff := ctx.BlockHeight() - int64(retentionBlocks)
+
+	// Collect the keys of the receipts to prune first, since the store cannot
+	// be written to while it is being iterated over
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.ReceiptHeightsKeyPrefix, types.GetReceiptHeightKey(cutoff+1))
+	var heightKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		heightKeys = append(heightKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	prefixLength := len(types.GetReceiptHeightKey(0))
+	for _, key := range heightKeys {
+		store.Delete(types.GetOrderReceiptKey(string(key[prefixLength:])))
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) IssueBuyOrderReceipt(ctx sdk.Context, address sdk.AccAddress,
+	amount sdk.Coin, maxPrices sdk.Coins) types.OrderReceipt {
+	return k.issueOrderReceipt(ctx, func(orderID uint64) types.OrderReceipt {
+		return types.NewBuyOrderReceipt(
+			orderID, address, amount, maxPrices, ctx.BlockHeight())
+	})
+}
+
+func (k Keeper) IssueSellOrderReceipt(ctx sdk.Context, address sdk.AccAddress,
+	amount sdk.Coin) types.OrderReceipt {
+	return k.issueOrderReceipt(ctx, func(orderID uint64) types.OrderReceipt {
+		return types.NewSellOrderReceipt(
+			orderID, address, amount, ctx.BlockHeight())
+	})
+}
+
+func (k Keeper) IssueSwapOrderReceipt(ctx sdk.Context, bondToken string,
+	address sdk.AccAddress, from sdk.Coin, toToken string) types.OrderReceipt {
+	return k.issueOrderReceipt(ctx, func(orderID uint64) types.OrderReceipt {
+		return types.NewSwapOrderReceipt(
+			orderID, bondToken, address, from, toToken, ctx.BlockHeight())
+	})
+}
+
+// issueOrderReceipt assigns the next order ID to a new order receipt, stores
+// the receipt, and increments the next order ID.
+func (k Keeper) issueOrderReceipt(ctx sdk.Context,
+	newReceipt func(orderID uint64) types.OrderReceipt) types.OrderReceipt {
+	orderID := k.GetNextOrderID(ctx)
+	orderReceipt := newReceipt(orderID)
+
+	k.SetOrderReceipt(ctx, orderReceipt)
+	k.SetNextOrderID(ctx, orderID+1)
+
+	return orderReceipt
+}
@@ -0,0 +1,76 @@
+package keeper_test
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIssueOrderReceiptIncrementsOrderID(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Initially next order ID is 1
+	require.Equal(t, uint64(1), app.BondsKeeper.GetNextOrderID(ctx))
+
+	// Issue receipts for a buy, sell and swap order
+	buyReceipt := app.BondsKeeper.IssueBuyOrderReceipt(
+		ctx, buyerAddress, buyAmount, maxPrices)
+	sellReceipt := app.BondsKeeper.IssueSellOrderReceipt(
+		ctx, sellerAddress, sellAmount)
+	swapReceipt := app.BondsKeeper.IssueSwapOrderReceipt(
+		ctx, token, swapperAddress, swapFrom, swapTo)
+
+	// Check that IDs were assigned in order and next order ID incremented
+	require.Equal(t, uint64(1), buyReceipt.OrderID)
+	require.Equal(t, uint64(2), sellReceipt.OrderID)
+	require.Equal(t, uint64(3), swapReceipt.OrderID)
+	require.Equal(t, uint64(4), app.BondsKeeper.GetNextOrderID(ctx))
+
+	// Check that receipts were stored
+	for _, receipt := range []string{
+		buyReceipt.Receipt, sellReceipt.Receipt, swapReceipt.Receipt} {
+		stored, found := app.BondsKeeper.GetOrderReceipt(ctx, receipt)
+		require.True(t, found)
+		require.Equal(t, receipt, stored.Receipt)
+	}
+}
+
+func TestGetOrderReceiptNotFound(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	_, found := app.BondsKeeper.GetOrderReceipt(ctx, "dummy")
+	require.False(t, found)
+}
+
+func TestPruneOrderReceiptsDeletesReceiptsNoLongerRetained(t *testing.T) {
+	app, ctx := createTestApp(false)
+	params := app.BondsKeeper.GetParams(ctx)
+	params.OrderReceiptRetentionBlocks = 10
+	app.BondsKeeper.SetParams(ctx, params)
+
+	// Issue receipts at heights 1 and 5
+	oldReceipt := app.BondsKeeper.IssueBuyOrderReceipt(
+		ctx.WithBlockHeight(1), buyerAddress, buyAmount, maxPrices)
+	newReceipt := app.BondsKeeper.IssueSellOrderReceipt(
+		ctx.WithBlockHeight(5), sellerAddress, sellAmount)
+
+	// Both receipts are retained until height 11
+	app.BondsKeeper.PruneOrderReceipts(ctx.WithBlockHeight(10))
+	require.Len(t, app.BondsKeeper.GetOrderReceipts(ctx), 2)
+
+	// Only the receipt issued at height 1 is pruned at height 11
+	app.BondsKeeper.PruneOrderReceipts(ctx.WithBlockHeight(11))
+	_, found := app.BondsKeeper.GetOrderReceipt(ctx, oldReceipt.Receipt)
+	require.False(t, found)
+	_, found = app.BondsKeeper.GetOrderReceipt(ctx, newReceipt.Receipt)
+	require.True(t, found)
+
+	// Pruning does not free up order IDs
+	require.Equal(t, uint64(3), app.BondsKeeper.GetNextOrderID(ctx))
+
+	// A retention of zero keeps receipts forever
+	params.OrderReceiptRetentionBlocks = 0
+	app.BondsKeeper.SetParams(ctx, params)
+	app.BondsKeeper.PruneOrderReceipts(ctx.WithBlockHeight(1000))
+	_, found = app.BondsKeeper.GetOrderReceipt(ctx, newReceipt.Receipt)
+	require.True(t, found)
+}
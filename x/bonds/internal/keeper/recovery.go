@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetExpectedModuleAccountBalance returns the balance that the bonds module
+// account is expected to hold according to the current bond and batch
+// accounting. The reserve account holds the current reserve of every bond,
+// the bond proposals account holds the bond tokens of every vote cast on a
+// bond proposal that is yet to be tallied, the bond vesting account holds the
+// pre-mined bond tokens of every vesting schedule that are yet to be released,
+// the notification deposits account holds the deposit of every notification
+// registration, and the mint/burn and batches
+// intermediary accounts hold nothing, since any tokens sent to the former are
+// immediately burned or sent out, and the funds of pending orders are held by
+// each bond's own escrow account instead of the latter.
+func (k Keeper) GetExpectedModuleAccountBalance(ctx sdk.Context, moduleAccount string) (expected sdk.Coins, err error) {
+	expected = sdk.Coins{}
+	switch moduleAccount {
+	case types.BondsMintBurnAccount:
+		return expected, nil
+	case types.BondsReserveAccount:
+		iterator := k.GetBondIterator(ctx)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			bond := k.MustGetBondByKey(ctx, iterator.Key())
+			expected = expected.Add(bond.CurrentReserve...)
+		}
+		return expected, nil
+	case types.BatchesIntermediaryAccount:
+		return expected, nil
+	case types.BondProposalsAccount:
+		for _, vote := range k.GetAllBondProposalVotes(ctx) {
+			proposal, found := k.GetBondProposal(ctx, vote.ProposalID)
+			if !found {
+				continue
+			}
+			expected = expected.Add(sdk.NewCoin(proposal.BondToken, vote.Power))
+		}
+		return expected, nil
+	case types.BondVestingAccount:
+		for _, schedule := range k.GetVestingSchedules(ctx) {
+			expected = expected.Add(schedule.Locked())
+		}
+		return expected, nil
+	case types.NotificationDepositsAccount:
+		for _, registration := range k.GetAllNotificationRegistrations(ctx) {
+			expected = expected.Add(registration.Deposit...)
+		}
+		return expected, nil
+	default:
+		return nil, sdkerrors.Wrap(types.ErrUnknownBondsModuleAccount, moduleAccount)
+	}
+}
+
+// GetStuckFunds returns the funds held by the bonds module account in excess
+// of its expected balance, i.e. funds not accounted for by any bond or order.
+func (k Keeper) GetStuckFunds(ctx sdk.Context, moduleAccount string) (sdk.Coins, error) {
+	expected, err := k.GetExpectedModuleAccountBalance(ctx, moduleAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	address := k.SupplyKeeper.GetModuleAddress(moduleAccount)
+	balance := k.BankKeeper.GetCoins(ctx, address)
+
+	// Only the excess (if any) of each denom is considered to be stuck, so any
+	// denom of which the account holds less than expected is simply ignored
+	stuck := sdk.Coins{}
+	for _, c := range balance {
+		excess := c.Amount.Sub(expected.AmountOf(c.Denom))
+		if excess.IsPositive() {
+			stuck = stuck.Add(sdk.NewCoin(c.Denom, excess))
+		}
+	}
+	return stuck, nil
+}
+
+// ClaimStuckFunds sends an amount of stuck funds held by the bonds module
+// account to the recipient. The amount cannot exceed the stuck funds, so that
+// funds belonging to bonds or pending orders can never be claimed.
+func (k Keeper) ClaimStuckFunds(ctx sdk.Context, moduleAccount string,
+	recipient sdk.AccAddress, amount sdk.Coins) (stuck sdk.Coins, err error) {
+
+	stuck, err = k.GetStuckFunds(ctx, moduleAccount)
+	if err != nil {
+		return nil, err
+	} else if !amount.IsAllLTE(stuck) {
+		return nil, sdkerrors.Wrapf(types.ErrClaimExceedsStuckFunds,
+			"%s exceeds %s", amount, stuck)
+	}
+
+	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, moduleAccount, recipient, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return stuck, nil
+}
@@ -0,0 +1,118 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetStuckFundsReserveAccount(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add bond with a reserve deposited to the reserve account
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	reserve := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, reserve)
+	require.Nil(t, err)
+	err = app.BondsKeeper.DepositReserveFromModule(
+		ctx, token, types.BondsMintBurnAccount, reserve)
+	require.Nil(t, err)
+
+	// No stuck funds since the reserve belongs to the bond
+	stuck, err := app.BondsKeeper.GetStuckFunds(ctx, types.BondsReserveAccount)
+	require.Nil(t, err)
+	require.True(t, stuck.IsZero())
+
+	// Send extra funds directly to the reserve account
+	extra := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	err = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, extra)
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, types.BondsMintBurnAccount, types.BondsReserveAccount, extra)
+	require.Nil(t, err)
+
+	// Extra funds are now stuck
+	stuck, err = app.BondsKeeper.GetStuckFunds(ctx, types.BondsReserveAccount)
+	require.Nil(t, err)
+	require.Equal(t, extra, stuck)
+}
+
+func TestGetStuckFundsBatchesIntermediaryAccount(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add bond and batch with a pending buy order, with max prices escrowed
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+	_, err := app.BankKeeper.AddCoins(ctx, types.GetBondEscrowAddress(token), maxPrices)
+	require.Nil(t, err)
+
+	// No stuck funds since the funds of the buy order are held by the escrow
+	stuck, err := app.BondsKeeper.GetStuckFunds(ctx, types.BatchesIntermediaryAccount)
+	require.Nil(t, err)
+	require.True(t, stuck.IsZero())
+
+	// Send funds to the batches intermediary account
+	extra := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	err = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, extra)
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, types.BondsMintBurnAccount, types.BatchesIntermediaryAccount, extra)
+	require.Nil(t, err)
+
+	// Extra funds are now stuck
+	stuck, err = app.BondsKeeper.GetStuckFunds(ctx, types.BatchesIntermediaryAccount)
+	require.Nil(t, err)
+	require.Equal(t, extra, stuck)
+}
+
+func TestGetStuckFundsUnknownModuleAccount(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	_, err := app.BondsKeeper.GetStuckFunds(ctx, "not_a_bonds_account")
+	require.Error(t, err)
+	require.True(t, types.ErrUnknownBondsModuleAccount.Is(err))
+}
+
+func TestClaimStuckFunds(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add funds to the mint/burn account, which should always be empty
+	stuckFunds := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, stuckFunds)
+	require.Nil(t, err)
+
+	// Claim part of the stuck funds
+	claim := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 4))
+	stuck, err := app.BondsKeeper.ClaimStuckFunds(
+		ctx, types.BondsMintBurnAccount, buyerAddress, claim)
+	require.Nil(t, err)
+	require.Equal(t, stuckFunds, stuck)
+	require.Equal(t, claim, app.BankKeeper.GetCoins(ctx, buyerAddress))
+
+	// Remaining stuck funds reduced by the claim
+	stuck, err = app.BondsKeeper.GetStuckFunds(ctx, types.BondsMintBurnAccount)
+	require.Nil(t, err)
+	require.Equal(t, stuckFunds.Sub(claim), stuck)
+}
+
+func TestClaimStuckFundsFailsIfClaimExceedsStuckFunds(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add bond with a reserve deposited to the reserve account
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	reserve := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, reserve)
+	require.Nil(t, err)
+	err = app.BondsKeeper.DepositReserveFromModule(
+		ctx, token, types.BondsMintBurnAccount, reserve)
+	require.Nil(t, err)
+
+	// Reserve cannot be claimed since it belongs to the bond
+	_, err = app.BondsKeeper.ClaimStuckFunds(
+		ctx, types.BondsReserveAccount, buyerAddress, reserve)
+	require.Error(t, err)
+	require.True(t, types.ErrClaimExceedsStuckFunds.Is(err))
+	require.True(t, app.BankKeeper.GetCoins(ctx, buyerAddress).IsZero())
+}
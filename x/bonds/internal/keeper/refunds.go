@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetPendingRefunds returns the refunds accumulated so far by the settlement
+// of the bond's batch, if the batch is being settled.
+func (k Keeper) GetPendingRefunds(ctx sdk.Context, token string) (refunds types.PendingRefunds, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetPendingRefundsKey(token)) {
+		return types.PendingRefunds{}, false
+	}
+
+	bz := store.Get(types.GetPendingRefundsKey(token))
+	k.cdc.MustUnmarshalBinaryBare(bz, &refunds)
+
+	return refunds, true
+}
+
+func (k Keeper) SetPendingRefunds(ctx sdk.Context, token string, refunds types.PendingRefunds) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingRefundsKey(token), k.cdc.MustMarshalBinaryBare(refunds))
+}
+
+func (k Keeper) deletePendingRefunds(ctx sdk.Context, token string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingRefundsKey(token))
+}
+
+// StartPendingRefunds starts accumulating the refunds made from the bond's
+// escrow account, which are then only paid out by PayPendingRefunds.
+func (k Keeper) StartPendingRefunds(ctx sdk.Context, token string) {
+	k.SetPendingRefunds(ctx, token, types.NewPendingRefunds(token))
+}
+
+// RefundEscrowedFunds refunds funds held by the bond's escrow account to the
+// address of a cancelled or partially filled order. While refunds are being
+// accumulated (i.e. while the bond's batch is being settled) the refund is
+// added to the address' pending refund; otherwise it is sent immediately.
+func (k Keeper) RefundEscrowedFunds(ctx sdk.Context, token string,
+	to sdk.AccAddress, amount sdk.Coins) error {
+	refunds, found := k.GetPendingRefunds(ctx, token)
+	if !found {
+		return k.sendRefund(ctx, token, to, amount)
+	}
+	k.SetPendingRefunds(ctx, token, refunds.Add(to, amount))
+	return nil
+}
+
+func (k Keeper) sendRefund(ctx sdk.Context, token string,
+	to sdk.AccAddress, amount sdk.Coins) error {
+	err := k.releaseEscrowedFunds(ctx, token, to, amount)
+	if err != nil {
+		return err
+	}
+
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryRefund,
+		types.LedgerAccountExternal, types.LedgerAccountEscrow, amount, to)
+	return nil
+}
+
+// PayPendingRefunds stops accumulating refunds for the bond and sends each
+// address its accumulated refund in a single send, in the order in which the
+// addresses were first refunded, emitting a refund event for each.
+func (k Keeper) PayPendingRefunds(ctx sdk.Context, token string) {
+	refunds, found := k.GetPendingRefunds(ctx, token)
+	if !found {
+		return
+	}
+	k.deletePendingRefunds(ctx, token)
+
+	bond := k.MustGetBond(ctx, token)
+	for _, r := range refunds.Refunds {
+		err := k.sendRefund(ctx, token, r.Address, r.Amount)
+		if err != nil {
+			panic(err)
+		}
+
+		ctx.EventManager().EmitEvent(types.NewEvent(types.RefundEvent{
+			Bond:    token,
+			Address: r.Address,
+			Amount:  r.Amount,
+			Orders:  r.Orders,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+	}
+}
@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetReservation returns the creator that reserved the bond token in the
+// current block, if any.
+func (k Keeper) GetReservation(ctx sdk.Context, token string) (creator sdk.AccAddress, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetReservationKey(token)) {
+		return nil, false
+	}
+	return store.Get(types.GetReservationKey(token)), true
+}
+
+// ReserveBondToken reserves the bond token for the creator until the end of
+// the current block, so that no other bond creation can claim it meanwhile.
+func (k Keeper) ReserveBondToken(ctx sdk.Context, token string, creator sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetReservationKey(token), creator.Bytes())
+}
+
+// ClearReservations deletes all of the bond token reservations.
+func (k Keeper) ClearReservations(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ReservationsKeyPrefix)
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
@@ -0,0 +1,237 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// MigrateReserveToken replaces one of the bond's reserve tokens by a new
+// denomination, converting the bond's reserve at the specified rate (i.e. new
+// tokens per old token). Trading is frozen for the duration of the migration
+// by settling the bond's current batch beforehand, so that no pending order
+// refers to the old denomination. The old reserve tokens are burned and the
+// converted amount of new reserve tokens is minted in their place.
+//
+// Every amount specified by the bond in the old denomination (order quantity
+// limits, outcome payment, milestones and the funding amounts of bond
+// proposals still in their voting period) is converted at the same rate, and
+// the function parameters and sanity rate are scaled accordingly, so that the
+// bond's prices remain unchanged in terms of value.
+func (k Keeper) MigrateReserveToken(ctx sdk.Context, token, fromDenom,
+	toDenom string, rate sdk.Dec) (oldReserve, newReserve sdk.Coins, err error) {
+
+	bond, found := k.GetBond(ctx, token)
+	if !found {
+		return nil, nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	}
+
+	// Check that the old denomination is a reserve token and that the new one
+	// is neither a reserve token nor the bond token
+	reserveIndex := -1
+	for i, r := range bond.ReserveTokens {
+		if r == fromDenom {
+			reserveIndex = i
+		} else if r == toDenom {
+			return nil, nil, sdkerrors.Wrapf(types.ErrInvalidReserveMigration,
+				"%s is already a reserve token", toDenom)
+		}
+	}
+	if reserveIndex == -1 {
+		return nil, nil, sdkerrors.Wrap(types.ErrTokenIsNotAValidReserveToken, fromDenom)
+	} else if toDenom == bond.Token {
+		return nil, nil, sdkerrors.Wrap(types.ErrBondTokenCannotAlsoBeReserveToken, toDenom)
+	}
+
+	// A non-swapper function prices every reserve token equally, so one of
+	// multiple reserve tokens cannot be re-priced on its own
+	if !rate.Equal(sdk.OneDec()) {
+		if !types.IsSwapperFunctionType(bond.FunctionType) && len(bond.ReserveTokens) > 1 {
+			return nil, nil, sdkerrors.Wrap(types.ErrInvalidReserveMigration,
+				"rate must be 1 for a bond with multiple reserve tokens")
+		} else if _, found := k.GetScheduledParamChange(ctx, token); found {
+			return nil, nil, sdkerrors.Wrap(types.ErrParamChangeAlreadyScheduled,
+				"rate must be 1 for a bond with a scheduled parameter change")
+		}
+	}
+
+	// Settle the current batch, so that its orders are performed in the old
+	// denomination, and get bond again (reserve and state changed)
+	k.SettleBatch(ctx, token, nil)
+	bond = k.MustGetBond(ctx, token)
+
+	// Convert every amount specified in the old denomination
+	oldReserve = bond.CurrentReserve
+	newReserve, err = convertDenom(oldReserve, fromDenom, toDenom, rate)
+	if err != nil {
+		return nil, nil, err
+	}
+	bond.OrderQuantityLimits, err = convertDenom(
+		bond.OrderQuantityLimits, fromDenom, toDenom, rate)
+	if err != nil {
+		return nil, nil, err
+	}
+	bond.OutcomePayment, err = convertDenom(
+		bond.OutcomePayment, fromDenom, toDenom, rate)
+	if err != nil {
+		return nil, nil, err
+	}
+	bond.SoftCap, err = convertDenom(
+		bond.SoftCap, fromDenom, toDenom, rate)
+	if err != nil {
+		return nil, nil, err
+	}
+	milestones := make([]types.Milestone, len(bond.Milestones))
+	for i, m := range bond.Milestones {
+		m.ReserveThreshold, err = convertDenom(m.ReserveThreshold, fromDenom, toDenom, rate)
+		if err != nil {
+			return nil, nil, err
+		}
+		m.FundingTranche, err = convertDenom(m.FundingTranche, fromDenom, toDenom, rate)
+		if err != nil {
+			return nil, nil, err
+		}
+		milestones[i] = m
+	}
+	bond.Milestones = milestones
+
+	// Burn the old reserve tokens and mint the new ones in their place
+	burned := sdk.NewCoins(sdk.NewCoin(fromDenom, oldReserve.AmountOf(fromDenom)))
+	minted := sdk.NewCoins(sdk.NewCoin(toDenom, newReserve.AmountOf(toDenom)))
+	if !burned.IsZero() {
+		err = k.SupplyKeeper.SendCoinsFromModuleToModule(ctx,
+			types.BondsReserveAccount, types.BondsMintBurnAccount, burned)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = k.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount, burned)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, minted)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = k.SupplyKeeper.SendCoinsFromModuleToModule(ctx,
+			types.BondsMintBurnAccount, types.BondsReserveAccount, minted)
+		if err != nil {
+			return nil, nil, err
+		}
+		k.RecordLedgerEntry(ctx, bond.Token, types.LedgerEntryBurn,
+			types.LedgerAccountSupply, types.LedgerAccountReserve, burned, nil)
+		k.RecordLedgerEntry(ctx, bond.Token, types.LedgerEntryMint,
+			types.LedgerAccountReserve, types.LedgerAccountSupply, minted, nil)
+	}
+
+	// Replace the reserve token, keeping its position, since the order of
+	// the reserve tokens matters (e.g. for the swapper sanity rate)
+	reserveTokens := make([]string, len(bond.ReserveTokens))
+	copy(reserveTokens, bond.ReserveTokens)
+	reserveTokens[reserveIndex] = toDenom
+	bond.ReserveTokens = reserveTokens
+	bond.CurrentReserve = newReserve
+
+	// Scale the function parameters (of each curve segment, for a piecewise
+	// function), or the swapper sanity rate (which is the rate of the first
+	// reserve token per second reserve token)
+	if bond.FunctionType == types.SwapperFunction {
+		if reserveIndex == 0 {
+			bond.SanityRate = bond.SanityRate.Mul(rate)
+		} else {
+			bond.SanityRate = bond.SanityRate.Quo(rate)
+		}
+	} else if bond.FunctionType == types.PiecewiseFunction {
+		segments := make([]types.CurveSegment, len(bond.CurveSegments))
+		for i, s := range bond.CurveSegments {
+			segments[i] = types.NewCurveSegment(s.SupplyThreshold, s.FunctionType,
+				withScaledReserve(s.FunctionType, s.FunctionParameters, rate))
+		}
+		bond.CurveSegments = segments
+	} else {
+		bond.FunctionParameters = withScaledReserve(
+			bond.FunctionType, bond.FunctionParameters, rate)
+	}
+	k.SetBond(ctx, token, bond)
+	k.InvalidateFunctionParamsCache(token)
+
+	// Convert the funding amounts of bond proposals still in their voting
+	// period, so that they can still be executed once tallied
+	for _, proposal := range k.GetBondProposalsByBond(ctx, token) {
+		if !proposal.IsVoting() {
+			continue
+		}
+		proposal.FundingAmount, err = convertDenom(
+			proposal.FundingAmount, fromDenom, toDenom, rate)
+		if err != nil {
+			return nil, nil, err
+		}
+		k.SetBondProposal(ctx, proposal)
+	}
+
+	// Re-calculate the prices of the new batch, which might already contain
+	// sells deferred by the net sell cap, in terms of the new denomination
+	batch := k.MustGetBatch(ctx, token)
+	if batch.HasOrders() {
+		buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
+		if err == nil {
+			batch.BuyPrices = buyPrices
+			batch.SellPrices = sellPrices
+			k.SetBatch(ctx, token, batch)
+		}
+	}
+
+	return oldReserve, newReserve, nil
+}
+
+// convertDenom returns a copy of the coins with the amount in the old
+// denomination (if any) replaced by the same amount multiplied by the rate
+// (rounded down) in the new denomination. An amount that would be converted
+// to zero results in an error, since it would otherwise be lost.
+func convertDenom(coins sdk.Coins, fromDenom, toDenom string, rate sdk.Dec) (sdk.Coins, error) {
+	amount := coins.AmountOf(fromDenom)
+	if amount.IsZero() {
+		return coins, nil
+	}
+
+	converted := amount.ToDec().Mul(rate).TruncateInt()
+	if !converted.IsPositive() {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidReserveMigration,
+			"%s%s converts to zero at rate %s", amount, fromDenom, rate)
+	}
+
+	return coins.Sub(sdk.Coins{sdk.NewCoin(fromDenom, amount)}).Add(
+		sdk.NewCoin(toDenom, converted)), nil
+}
+
+// withScaledReserve returns a copy of the function parameters scaled such
+// that the reserve and prices of the function are multiplied by the rate.
+func withScaledReserve(functionType string, fps types.FunctionParams, rate sdk.Dec) types.FunctionParams {
+	paramsMap := fps.AsMap()
+	switch functionType {
+	case types.PowerFunction:
+		paramsMap["m"] = paramsMap["m"].Mul(rate)
+		paramsMap["c"] = paramsMap["c"].Mul(rate)
+	case types.SigmoidFunction:
+		paramsMap["a"] = paramsMap["a"].Mul(rate)
+	case types.ExponentialFunction, types.LogarithmicFunction:
+		paramsMap["a"] = paramsMap["a"].Mul(rate)
+	case types.BancorFunction:
+		paramsMap["p0"] = paramsMap["p0"].Mul(rate)
+	case types.PolynomialFunction:
+		for p := range paramsMap {
+			paramsMap[p] = paramsMap[p].Mul(rate)
+		}
+	case types.AugmentedFunction:
+		paramsMap["d0"] = paramsMap["d0"].Mul(rate)
+		paramsMap["p0"] = paramsMap["p0"].Mul(rate)
+		paramsMap["R0"] = paramsMap["d0"].Mul(sdk.OneDec().Sub(paramsMap["theta"]))
+		paramsMap["V0"] = types.Invariant(paramsMap["R0"], paramsMap["S0"],
+			paramsMap["kappa"].TruncateInt64())
+	}
+
+	updated := make(types.FunctionParams, len(fps))
+	for i, fp := range fps {
+		updated[i] = types.NewFunctionParam(fp.Param, paramsMap[fp.Param])
+	}
+	return updated
+}
@@ -0,0 +1,279 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetSwapVia returns the token of the bond (the via bond) through which a swap
+// from the from token to the to token has to be routed in the swapper bond, or
+// an empty string if both tokens are reserve tokens of the swapper bond. A
+// swap can be routed through a reserve token of the swapper bond that is the
+// token of a bond with a single reserve token:
+//   - if the from token is the via bond's reserve token, the via bond's token
+//     is first bought with the from tokens and then swapped to the to token
+//   - if the to token is the via bond's reserve token, the from tokens are
+//     first swapped to the via bond's token, which is then sold
+func (k Keeper) GetSwapVia(ctx sdk.Context, bond types.Bond, fromToken, toToken string) (string, error) {
+	fromIsReserve := bond.IsReserveToken(fromToken)
+	toIsReserve := bond.IsReserveToken(toToken)
+	if fromIsReserve && toIsReserve {
+		return "", nil
+	} else if fromIsReserve == toIsReserve {
+		return "", sdkerrors.Wrapf(types.ErrReserveDenomsMismatch,
+			"%s,%s do not match reserve; expected: %s", fromToken, toToken, bond.ReserveTokens)
+	}
+
+	// The via bond's token is the swapper bond's other reserve token
+	var via, viaReserve string
+	if fromIsReserve {
+		via, viaReserve = otherReserveToken(bond, fromToken), toToken
+	} else {
+		via, viaReserve = otherReserveToken(bond, toToken), fromToken
+	}
+
+	viaBond, found := k.GetBond(ctx, via)
+	if !found || len(viaBond.ReserveTokens) != 1 || viaBond.ReserveTokens[0] != viaReserve {
+		return "", sdkerrors.Wrapf(types.ErrReserveDenomsMismatch,
+			"%s,%s do not match reserve and cannot be routed; expected: %s", fromToken, toToken, bond.ReserveTokens)
+	}
+
+	// Check that the via bond allows the buy or sell leg of the swap
+	if k.OrdersFrozenForUpgrade(ctx, viaBond) {
+		return "", sdkerrors.Wrap(types.ErrOrdersFrozenForUpgrade, viaBond.Token)
+	} else if viaBond.State != types.OpenState {
+		return "", sdkerrors.Wrapf(types.ErrInvalidStateForAction, "%s is %s", viaBond.Token, viaBond.State)
+	} else if !fromIsReserve && viaBond.BuysClosed {
+		return "", sdkerrors.Wrap(types.ErrBondClosedToBuys, viaBond.Token)
+	} else if fromIsReserve && !viaBond.AllowSells {
+		return "", sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, viaBond.Token)
+	}
+
+	return via, nil
+}
+
+func otherReserveToken(bond types.Bond, token string) string {
+	for _, r := range bond.ReserveTokens {
+		if r != token {
+			return r
+		}
+	}
+	return ""
+}
+
+// performRoutedSwapOrder performs both legs of the routed swap order, i.e. the
+// buy (sell) of the via bond's token at its current price along the curve and
+// the swap from (to) the via bond's token in the swapper bond. The legs are
+// performed atomically, so if either leg fails or the final returns are less
+// than the order's min return, none of the swap's state changes are kept and
+// the from tokens remain in the swapper bond's escrow.
+func (k Keeper) performRoutedSwapOrder(ctx sdk.Context, token string, so types.SwapOrder) error {
+	cacheCtx, writeCache := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+	viaBond := k.MustGetBond(cacheCtx, so.Via)
+
+	var viaTokens sdk.Coin
+	var returns sdk.Coins
+	var err error
+	if viaBond.ReserveTokens[0] == so.Amount.Denom {
+		viaTokens, returns, err = k.performBuyThenSwap(cacheCtx, token, viaBond, so)
+	} else {
+		viaTokens, returns, err = k.performSwapThenSell(cacheCtx, token, viaBond, so)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Check that the combined returns are not less than the min return
+	if !so.MinReturnMet(returns) {
+		return sdkerrors.Wrapf(types.ErrSwapReturnBelowMinReturn,
+			"%s is less than %s", returns, so.MinReturn)
+	}
+
+	writeCache()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("performed swap order for %s to %s via %s from %s",
+		so.Amount.String(), returns, viaTokens, so.Address.String()))
+
+	var minReturn sdk.Coin
+	if so.MinReturn != nil {
+		minReturn = *so.MinReturn
+	}
+	bond := k.MustGetBond(ctx, token)
+	ctx.EventManager().EmitEvent(types.NewEvent(types.RoutedSwapFulfillEvent{
+		Bond:              token,
+		Address:           so.Address,
+		Via:               so.Via,
+		TokensSwapped:     so.Amount,
+		ViaTokens:         viaTokens,
+		ReturnedToAddress: returns,
+		MinReturn:         minReturn,
+		Memo:              so.Memo,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+	return nil
+}
+
+// performBuyThenSwap buys as many of the via bond's tokens as the from tokens
+// held in the swapper bond's escrow can pay for, refunding the rest, and swaps
+// the tokens bought to the to token.
+func (k Keeper) performBuyThenSwap(ctx sdk.Context, token string,
+	viaBond types.Bond, so types.SwapOrder) (viaTokens sdk.Coin, returns sdk.Coins, err error) {
+
+	amount, prices, err := k.getBuyAmountForFunds(ctx, viaBond, so.Amount)
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+
+	// Move the from tokens to the via bond's escrow and buy from the via bond
+	err = k.BankKeeper.SendCoins(ctx, types.GetBondEscrowAddress(token),
+		types.GetBondEscrowAddress(viaBond.Token), sdk.Coins{so.Amount})
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryEscrowOut,
+		types.LedgerAccountExternal, types.LedgerAccountEscrow,
+		sdk.Coins{so.Amount}, types.GetBondEscrowAddress(viaBond.Token))
+	k.RecordLedgerEntry(ctx, viaBond.Token, types.LedgerEntryEscrowIn,
+		types.LedgerAccountEscrow, types.LedgerAccountExternal,
+		sdk.Coins{so.Amount}, types.GetBondEscrowAddress(token))
+	bo := types.NewBuyOrder(so.Address, amount, sdk.Coins{so.Amount})
+	bo.Memo = so.Memo
+	_, err = k.PerformBuyAtPrice(ctx, viaBond.Token, bo, prices)
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+
+	// Swap the via bond's tokens bought to the to token
+	err = k.EscrowOrderFunds(ctx, token, so.Address, sdk.Coins{amount})
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+	leg := types.NewSwapOrder(so.Address, amount, so.ToToken)
+	leg.Memo = so.Memo
+	returns, err, _ = k.performSwapOrder(ctx, token, leg)
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+
+	return amount, returns, nil
+}
+
+// performSwapThenSell swaps the from tokens held in the swapper bond's escrow
+// to the via bond's token and sells the resultant tokens to the via bond.
+func (k Keeper) performSwapThenSell(ctx sdk.Context, token string,
+	viaBond types.Bond, so types.SwapOrder) (viaTokens sdk.Coin, returns sdk.Coins, err error) {
+
+	leg := types.NewSwapOrder(so.Address, so.Amount, viaBond.Token)
+	leg.Memo = so.Memo
+	swapReturns, err, _ := k.performSwapOrder(ctx, token, leg)
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+	viaTokens = sdk.NewCoin(viaBond.Token, swapReturns.AmountOf(viaBond.Token))
+
+	// Cannot burn more tokens than what exists
+	if k.GetSupplyAdjustedForSell(ctx, viaBond.Token).IsLT(viaTokens) {
+		return sdk.Coin{}, nil, sdkerrors.Wrap(types.ErrCannotBurnMoreThanSupply, viaTokens.String())
+	}
+
+	// Burn the via bond's tokens and sell them at the current price
+	err = k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, so.Address,
+		types.BondsMintBurnAccount, sdk.Coins{viaTokens})
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+	err = k.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount, sdk.Coins{viaTokens})
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+	k.RecordLedgerEntry(ctx, viaBond.Token, types.LedgerEntryBurn,
+		types.LedgerAccountSupply, types.LedgerAccountExternal,
+		sdk.Coins{viaTokens}, so.Address)
+
+	reserveReturns, err := viaBond.GetReturnsForBurn(
+		viaTokens.Amount, k.GetReserveBalances(ctx, viaBond.Token))
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+	prices := reserveReturns.QuoDec(viaTokens.Amount.ToDec())
+	returns, _, _ = getSellReturnsAtPrice(ctx, viaBond, viaTokens.Amount, prices)
+
+	sellOrder := types.NewSellOrder(so.Address, viaTokens)
+	sellOrder.Memo = so.Memo
+	err = k.PerformSellAtPrice(ctx, viaBond.Token, sellOrder, prices)
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+
+	return viaTokens, returns, nil
+}
+
+// getBuyAmountForFunds calculates the greatest amount of the bond's tokens
+// that can be bought at the bond's current price along the curve with the
+// funds (including fees), together with the per-token prices of the buy.
+// Since the cost only increases as the amount bought increases, the amount is
+// found by doubling it until the funds are exceeded and then using a binary
+// search.
+func (k Keeper) getBuyAmountForFunds(ctx sdk.Context, bond types.Bond,
+	funds sdk.Coin) (amount sdk.Coin, prices sdk.DecCoins, err error) {
+
+	reserveBalances := k.GetReserveBalances(ctx, bond.Token)
+	pricesFor := func(n sdk.Int) (sdk.DecCoins, bool, error) {
+		reservePrices, err := bond.GetPricesToMint(n, reserveBalances)
+		if err != nil {
+			return nil, false, err
+		}
+		prices := reservePrices.QuoDec(n.ToDec())
+		reservePricesRounded, txFees := getBuyCostAtPrice(bond, n, prices)
+		return prices, reservePricesRounded.Add(txFees...).IsAllLTE(sdk.Coins{funds}), nil
+	}
+
+	// Max supply cannot be less than supply (max supply >= supply)
+	maxAmount := bond.MaxSupply.Amount.Sub(k.GetSupplyAdjustedForBuy(ctx, bond.Token).Amount)
+	if !maxAmount.IsPositive() {
+		return sdk.Coin{}, nil, sdkerrors.Wrap(types.ErrCannotMintMoreThanMaxSupply, bond.MaxSupply.String())
+	}
+
+	// Double the amount until the funds are exceeded or max supply is reached
+	low, high := sdk.ZeroInt(), sdk.OneInt()
+	for high.LTE(maxAmount) {
+		_, affordable, err := pricesFor(high)
+		if err != nil {
+			return sdk.Coin{}, nil, err
+		} else if !affordable {
+			break
+		}
+		low, high = high, high.MulRaw(2)
+	}
+	if high.GT(maxAmount) {
+		high = maxAmount.AddRaw(1)
+	}
+
+	// Binary search for greatest affordable amount in [low, high)
+	for low.AddRaw(1).LT(high) {
+		mid := low.Add(high).QuoRaw(2)
+		_, affordable, err := pricesFor(mid)
+		if err != nil {
+			return sdk.Coin{}, nil, err
+		} else if affordable {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	if low.IsZero() {
+		return sdk.Coin{}, nil, sdkerrors.Wrapf(types.ErrSwapAmountTooSmallToGiveAnyReturn,
+			"%s - %s", funds.Denom, bond.Token)
+	}
+	prices, _, err = pricesFor(low)
+	if err != nil {
+		return sdk.Coin{}, nil, err
+	}
+	return sdk.NewCoin(bond.Token, low), prices, nil
+}
@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetSanityRateWindow returns the bond's current sanity rate window, if any.
+func (k Keeper) GetSanityRateWindow(ctx sdk.Context, token string) (window types.SanityRateWindow, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetSanityRateWindowKey(token)) {
+		return types.SanityRateWindow{}, false
+	}
+
+	bz := store.Get(types.GetSanityRateWindowKey(token))
+	k.cdc.MustUnmarshalBinaryBare(bz, &window)
+
+	return window, true
+}
+
+// GetAllSanityRateWindows returns the current sanity rate window of each bond,
+// along with the bond's token.
+func (k Keeper) GetAllSanityRateWindows(ctx sdk.Context) (windows []types.SanityRateWindowEntry) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.SanityWindowsKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var window types.SanityRateWindow
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &window)
+		token := string(iterator.Key()[len(types.SanityWindowsKeyPrefix):])
+		windows = append(windows, types.NewSanityRateWindowEntry(token, window))
+	}
+	return windows
+}
+
+func (k Keeper) SetSanityRateWindow(ctx sdk.Context, token string, window types.SanityRateWindow) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetSanityRateWindowKey(token), k.cdc.MustMarshalBinaryBare(window))
+}
+
+// CheckSanityRateChange checks that changing the bond's sanity rate and sanity
+// margin percentage to the specified values changes each of them by no more
+// than the max step percentage relative to their current values, and by no
+// more than the max window percentage relative to their values at the start of
+// the current sanity rate window. If the change is allowed, the window is
+// stored, starting a new window if the previous one has ended.
+func (k Keeper) CheckSanityRateChange(ctx sdk.Context, bond types.Bond,
+	sanityRate, sanityMarginPercentage sdk.Dec) error {
+
+	maxStep := k.MaxSanityRateStepPercentage(ctx)
+	if err := types.CheckSanityValueChange("sanity rate",
+		bond.SanityRate, sanityRate, maxStep); err != nil {
+		return err
+	} else if err := types.CheckSanityValueChange("sanity margin percentage",
+		bond.SanityMarginPercentage, sanityMarginPercentage, maxStep); err != nil {
+		return err
+	}
+
+	// A new window starts if the previous one has ended or if the sanity check
+	// was disabled (i.e. the sanity rate was zero) at the start of the window
+	window, found := k.GetSanityRateWindow(ctx, bond.Token)
+	if !found || window.SanityRate.IsZero() ||
+		window.HasEnded(ctx.BlockHeight(), k.SanityRateWindowBlocks(ctx)) {
+		window = types.NewSanityRateWindow(ctx.BlockHeight(),
+			bond.SanityRate, bond.SanityMarginPercentage)
+	}
+
+	maxWindow := k.MaxSanityRateWindowPercentage(ctx)
+	if err := types.CheckSanityValueChange("sanity rate",
+		window.SanityRate, sanityRate, maxWindow); err != nil {
+		return err
+	} else if err := types.CheckSanityValueChange("sanity margin percentage",
+		window.SanityMarginPercentage, sanityMarginPercentage, maxWindow); err != nil {
+		return err
+	}
+
+	k.SetSanityRateWindow(ctx, bond.Token, window)
+	return nil
+}
@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetScheduledParamChangeIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.ScheduledChangesKeyPrefix)
+}
+
+func (k Keeper) GetScheduledParamChange(ctx sdk.Context, token string) (change types.ScheduledParamChange, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetScheduledChangeKey(token)) {
+		return types.ScheduledParamChange{}, false
+	}
+
+	bz := store.Get(types.GetScheduledChangeKey(token))
+	k.cdc.MustUnmarshalBinaryBare(bz, &change)
+
+	return change, true
+}
+
+func (k Keeper) GetScheduledParamChanges(ctx sdk.Context) (changes []types.ScheduledParamChange) {
+	iterator := k.GetScheduledParamChangeIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var change types.ScheduledParamChange
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &change)
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func (k Keeper) SetScheduledParamChange(ctx sdk.Context, change types.ScheduledParamChange) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetScheduledChangeKey(change.BondToken),
+		k.cdc.MustMarshalBinaryBare(change))
+}
+
+func (k Keeper) DeleteScheduledParamChange(ctx sdk.Context, token string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetScheduledChangeKey(token))
+}
+
+// ApplyDueScheduledParamChanges sets the new function parameters of every
+// bond with a scheduled parameter change that is due at the current height,
+// and deletes the applied changes. Bonds with an interpolated change that is
+// not yet due are instead given the parameters interpolated at the height.
+func (k Keeper) ApplyDueScheduledParamChanges(ctx sdk.Context) {
+	// Collect due changes first, since the store cannot be written to while
+	// it is being iterated over
+	var dueChanges, interpolatedChanges []types.ScheduledParamChange
+	for _, change := range k.GetScheduledParamChanges(ctx) {
+		if change.IsDue(ctx.BlockHeight()) {
+			dueChanges = append(dueChanges, change)
+		} else if change.Interpolate {
+			interpolatedChanges = append(interpolatedChanges, change)
+		}
+	}
+
+	for _, change := range interpolatedChanges {
+		k.applyInterpolatedParamChange(ctx, change)
+	}
+
+	for _, change := range dueChanges {
+		k.DeleteScheduledParamChange(ctx, change.BondToken)
+
+		bond, found := k.GetBond(ctx, change.BondToken)
+		if !found {
+			continue
+		}
+
+		// Drop the change if the reserve no longer covers the new parameters,
+		// e.g. since the supply grew after the change was scheduled
+		if err := types.CheckParamsBackedByReserve(bond, change.FunctionParameters); err != nil {
+			k.Logger(ctx).Info(fmt.Sprintf("skipped scheduled function parameters [%s] for %s: %s",
+				change.FunctionParameters.String(), bond.Token, err.Error()))
+			continue
+		}
+
+		oldParams := bond.FunctionParameters
+		bond.FunctionParameters = change.FunctionParameters
+		k.SetBond(ctx, bond.Token, bond)
+		k.InvalidateFunctionParamsCache(bond.Token)
+
+		logger := k.Logger(ctx)
+		logger.Info(fmt.Sprintf("applied scheduled function parameters change for %s from [%s] to [%s]",
+			bond.Token, oldParams.String(), change.FunctionParameters.String()))
+
+		ctx.EventManager().EmitEvent(types.NewEvent(types.ApplyParamChangeEvent{
+			Bond:              bond.Token,
+			EffectiveHeight:   change.EffectiveHeight,
+			OldFunctionParams: oldParams,
+			NewFunctionParams: change.FunctionParameters,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+	}
+}
+
+// applyInterpolatedParamChange sets the bond's function parameters to those
+// interpolated by the change at the current height. The bond keeps its current
+// parameters if the interpolated parameters are not valid for the bond (e.g.
+// if a sigmoid function's c passes through zero), so that the bond's function
+// can always be evaluated, or if the bond's reserve does not cover them.
+func (k Keeper) applyInterpolatedParamChange(ctx sdk.Context, change types.ScheduledParamChange) {
+	bond, found := k.GetBond(ctx, change.BondToken)
+	if !found {
+		return
+	}
+
+	params := change.ParamsAtHeight(ctx.BlockHeight())
+	if err := types.ValidateFunctionParamsForBond(bond, params); err != nil {
+		k.Logger(ctx).Info(fmt.Sprintf("skipped interpolated function parameters [%s] for %s: %s",
+			params.String(), bond.Token, err.Error()))
+		return
+	} else if err := types.CheckParamsBackedByReserve(bond, params); err != nil {
+		k.Logger(ctx).Info(fmt.Sprintf("skipped interpolated function parameters [%s] for %s: %s",
+			params.String(), bond.Token, err.Error()))
+		return
+	}
+
+	bond.FunctionParameters = params
+	k.SetBond(ctx, bond.Token, bond)
+	k.InvalidateFunctionParamsCache(bond.Token)
+}
@@ -0,0 +1,42 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetBondSearchIndexIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.BondSearchIndexKeyPrefix)
+}
+
+func (k Keeper) setBondSearchIndexEntry(ctx sdk.Context, token string, bond types.Bond) {
+	entry := types.NewBondSearchIndexEntry(bond.Name, bond.Description)
+
+	// A bond with a blank name and description cannot match any (non-empty)
+	// query, and its entry would marshal to no bytes at all, so it is removed
+	store := ctx.KVStore(k.storeKey)
+	if entry.Name == "" && entry.Description == "" {
+		store.Delete(types.GetBondSearchIndexKey(token))
+		return
+	}
+	store.Set(types.GetBondSearchIndexKey(token), k.cdc.MustMarshalBinaryBare(entry))
+}
+
+// SearchBonds returns the tokens of (at most limit) bonds whose name or
+// description contains the query, ignoring case. Bonds are returned in the
+// order of their tokens.
+func (k Keeper) SearchBonds(ctx sdk.Context, query string, limit int) (tokens []string) {
+	iterator := k.GetBondSearchIndexIterator(ctx)
+	defer iterator.Close()
+
+	for ; iterator.Valid() && len(tokens) < limit; iterator.Next() {
+		var entry types.BondSearchIndexEntry
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &entry)
+		if entry.Matches(query) {
+			token := string(iterator.Key()[len(types.BondSearchIndexKeyPrefix):])
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
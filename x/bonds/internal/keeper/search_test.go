@@ -0,0 +1,66 @@
+package keeper_test
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSearchBondsMatchesNameAndDescription(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	bond1 := getValidBond()
+	bond1.Name = "Solar Farm Bond"
+	bond1.Description = "Funds a community solar project"
+	app.BondsKeeper.SetBond(ctx, bond1.Token, bond1)
+
+	bond2 := getValidBond()
+	bond2.Token = token2
+	bond2.Name = "Wind Bond"
+	bond2.Description = "Funds offshore WIND turbines"
+	app.BondsKeeper.SetBond(ctx, bond2.Token, bond2)
+
+	// Case-insensitive match on name
+	require.Equal(t, []string{bond1.Token},
+		app.BondsKeeper.SearchBonds(ctx, "solar farm", 10))
+
+	// Case-insensitive match on description
+	require.Equal(t, []string{bond2.Token},
+		app.BondsKeeper.SearchBonds(ctx, "Offshore", 10))
+
+	// Match on both bonds (in order of token)
+	require.Equal(t, []string{bond1.Token, bond2.Token},
+		app.BondsKeeper.SearchBonds(ctx, "FUNDS", 10))
+
+	// No matches
+	require.Empty(t, app.BondsKeeper.SearchBonds(ctx, "hydro", 10))
+}
+
+func TestSearchBondsRespectsLimit(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	bond1 := getValidBond()
+	app.BondsKeeper.SetBond(ctx, bond1.Token, bond1)
+
+	bond2 := getValidBond()
+	bond2.Token = token2
+	app.BondsKeeper.SetBond(ctx, bond2.Token, bond2)
+
+	require.Len(t, app.BondsKeeper.SearchBonds(ctx, bond1.Name, 10), 2)
+	require.Len(t, app.BondsKeeper.SearchBonds(ctx, bond1.Name, 1), 1)
+}
+
+func TestSearchBondsReflectsEditedName(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	bond := getValidBond()
+	bond.Name = "Old Name"
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	require.Equal(t, []string{bond.Token},
+		app.BondsKeeper.SearchBonds(ctx, "old", 10))
+
+	bond.Name = "New Name"
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	require.Empty(t, app.BondsKeeper.SearchBonds(ctx, "old", 10))
+	require.Equal(t, []string{bond.Token},
+		app.BondsKeeper.SearchBonds(ctx, "new", 10))
+}
@@ -0,0 +1,135 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	simapp "github.com/ixoworld/bonds/app"
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// The tests in this file deliberately drive state to the branches of batch
+// settlement that panic, to check that settling a due batch never panics and
+// that a failed settlement leaves no trace other than the restarted batch.
+// They can be run on their own using `make test-chaos`.
+
+func setUpChaosBond(t *testing.T, app *simapp.BondsApp, ctx sdk.Context,
+	bond types.Bond, reserve sdk.Coins) {
+	bond.TxFeePercentage = sdk.ZeroDec()
+	bond.ExitFeePercentage = sdk.ZeroDec()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	app.BondsKeeper.SetBatch(ctx, bond.Token, types.NewBatch(bond.Token, sdk.ZeroUint()))
+
+	if !reserve.IsZero() {
+		err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, reserve)
+		require.NoError(t, err)
+		err = app.BondsKeeper.DepositReserveFromModule(
+			ctx, bond.Token, types.BondsMintBurnAccount, reserve)
+		require.NoError(t, err)
+	}
+}
+
+func requireSettlementFailedCleanly(t *testing.T, app *simapp.BondsApp,
+	ctx sdk.Context, token string) {
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	bondBefore := app.BondsKeeper.MustGetBond(ctx, token)
+	batchBefore := app.BondsKeeper.MustGetBatch(ctx, token)
+	reserveBefore := app.BondsKeeper.GetReserveBalances(ctx, token)
+
+	var err error
+	require.NotPanics(t, func() {
+		err = app.BondsKeeper.TrySettleBatch(ctx, token, nil)
+	})
+	require.Error(t, err)
+	require.True(t, types.ErrBatchSettlementFailed.Is(err))
+
+	// Nothing changed except for the batch having been restarted
+	batchBefore.BlocksRemaining = bondBefore.BatchBlocks
+	require.Equal(t, bondBefore, app.BondsKeeper.MustGetBond(ctx, token))
+	require.Equal(t, batchBefore, app.BondsKeeper.MustGetBatch(ctx, token))
+	require.Equal(t, reserveBefore, app.BondsKeeper.GetReserveBalances(ctx, token))
+	require.False(t, app.BondsKeeper.LastBatchExists(ctx, token))
+	require.False(t, app.BondsKeeper.LastBatchResultExists(ctx, token))
+	require.Empty(t, ctx.EventManager().Events())
+}
+
+func TestChaosSettleBatchNegativeSupply(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Bond with a reserve but no supply, with a sell order that would take
+	// the supply negative after the returns have been paid to the seller
+	reserve := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	setUpChaosBond(t, app, ctx, getValidBond(), reserve)
+	sellPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 10)}
+	so := types.NewSellOrder(sellerAddress, sdk.NewInt64Coin(token, 10))
+	app.BondsKeeper.AddSellOrder(ctx, token, so, nil, sellPrices)
+
+	requireSettlementFailedCleanly(t, app, ctx, token)
+
+	// The returns paid before the panic were not kept
+	require.True(t, app.BankKeeper.GetCoins(ctx, sellerAddress).IsZero())
+}
+
+func TestChaosSettleBatchReserveShortfall(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Bond with a supply but an empty reserve, so the sell returns cannot
+	// be withdrawn from the reserve
+	bond := getValidBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(token, 10)
+	setUpChaosBond(t, app, ctx, bond, nil)
+	sellPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 10)}
+	so := types.NewSellOrder(sellerAddress, sdk.NewInt64Coin(token, 10))
+	app.BondsKeeper.AddSellOrder(ctx, token, so, nil, sellPrices)
+
+	requireSettlementFailedCleanly(t, app, ctx, token)
+}
+
+func TestChaosSettleBatchUnrecognizedFunctionType(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Bond with a function type that is not recognized, as if the store was
+	// written by a different version of the module
+	bond := getValidBond()
+	bond.FunctionType = "unrecognized_function"
+	setUpChaosBond(t, app, ctx, bond, nil)
+	app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+
+	// Pricing returns an error instead of panicking
+	var results []keeper.BondPrices
+	require.NotPanics(t, func() {
+		results = app.BondsKeeper.GetBondsCurrentPrices(ctx, []string{token})
+	})
+	require.True(t, types.ErrBatchSettlementFailed.Is(results[0].Err))
+
+	requireSettlementFailedCleanly(t, app, ctx, token)
+}
+
+func TestChaosSettleBatchSucceedsAfterFailure(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Sell that fails due to a reserve shortfall
+	bond := getValidBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(token, 10)
+	setUpChaosBond(t, app, ctx, bond, nil)
+	sellPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 10)}
+	so := types.NewSellOrder(sellerAddress, sdk.NewInt64Coin(token, 10))
+	app.BondsKeeper.AddSellOrder(ctx, token, so, nil, sellPrices)
+	require.Error(t, app.BondsKeeper.TrySettleBatch(ctx, token, nil))
+
+	// Once the shortfall is resolved, the restarted batch settles
+	reserve := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, reserve)
+	require.NoError(t, err)
+	err = app.BondsKeeper.DepositReserveFromModule(
+		ctx, token, types.BondsMintBurnAccount, reserve)
+	require.NoError(t, err)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, app.BondsKeeper.TrySettleBatch(ctx, token, nil))
+	require.Equal(t, reserve, app.BankKeeper.GetCoins(ctx, sellerAddress))
+	require.True(t, app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply.IsZero())
+	require.True(t, app.BondsKeeper.LastBatchResultExists(ctx, token))
+	require.NotEmpty(t, ctx.EventManager().Events())
+}
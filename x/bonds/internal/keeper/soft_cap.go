@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// ApplySoftCap marks the bond's soft cap as reached once the bond's reserve
+// first meets it, or moves the bond to its FAILED state if the soft cap has
+// not been reached by the bond's raise deadline. This is checked whenever one
+// of the bond's batches is settled, so a bond fails at the first batch settled
+// at or after its raise deadline. A soft cap that was reached is never
+// un-reached, even if the reserve later drops below it.
+func (k Keeper) ApplySoftCap(ctx sdk.Context, token string) {
+	bond := k.MustGetBond(ctx, token)
+	if !bond.HasSoftCap() || bond.SoftCapReached {
+		return
+	} else if types.CheckBondStateTransition(bond.State, types.FailedState) != nil {
+		return
+	}
+
+	logger := k.Logger(ctx)
+	if bond.IsSoftCapMet(bond.CurrentReserve) {
+		bond.SoftCapReached = true
+		k.SetBond(ctx, token, bond)
+
+		logger.Info(fmt.Sprintf("bond %s reached its soft cap %s",
+			token, bond.SoftCap.String()))
+
+		ctx.EventManager().EmitEvent(types.NewEvent(types.SoftCapReachedEvent{
+			Bond:          token,
+			SoftCap:       bond.SoftCap,
+			RaiseDeadline: bond.RaiseDeadline,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+		return
+	} else if ctx.BlockHeight() < bond.RaiseDeadline {
+		return
+	}
+
+	if err := k.SetBondState(ctx, token, types.FailedState); err != nil {
+		panic(err)
+	}
+
+	logger.Info(fmt.Sprintf("bond %s did not reach its soft cap %s by height %d",
+		token, bond.SoftCap.String(), bond.RaiseDeadline))
+
+	ctx.EventManager().EmitEvent(types.NewEvent(types.RaiseFailedEvent{
+		Bond:          token,
+		SoftCap:       bond.SoftCap,
+		RaiseDeadline: bond.RaiseDeadline,
+		Reserve:       bond.CurrentReserve,
+	}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+}
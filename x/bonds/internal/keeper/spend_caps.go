@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetSpendRecords returns the address's spend records, from oldest to newest,
+// including any records that are no longer within the spend cap window.
+func (k Keeper) GetSpendRecords(ctx sdk.Context, address sdk.AccAddress) types.SpendRecords {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetSpendRecordsKey(address)) {
+		return types.SpendRecords{}
+	}
+
+	bz := store.Get(types.GetSpendRecordsKey(address))
+	var records types.SpendRecords
+	k.cdc.MustUnmarshalBinaryBare(bz, &records)
+
+	return records
+}
+
+// SetSpendRecords stores the address's spend records, or deletes them if
+// there are none, so that addresses that stop buying do not use up storage.
+func (k Keeper) SetSpendRecords(ctx sdk.Context, address sdk.AccAddress, records types.SpendRecords) {
+	store := ctx.KVStore(k.storeKey)
+	if len(records) == 0 {
+		store.Delete(types.GetSpendRecordsKey(address))
+		return
+	}
+	store.Set(types.GetSpendRecordsKey(address), k.cdc.MustMarshalBinaryBare(records))
+}
+
+// GetSpentInWindow returns the total amount that the address committed to
+// buys within the current spend cap window.
+func (k Keeper) GetSpentInWindow(ctx sdk.Context, address sdk.AccAddress) sdk.Coins {
+	records := k.GetSpendRecords(ctx, address).Prune(
+		ctx.BlockHeight(), k.SpendCapWindowBlocks(ctx))
+	return records.Total()
+}
+
+// RecordBuySpend checks that committing the amount to a buy does not make the
+// address exceed the buy spend cap within the current spend cap window and, if
+// it does not, records the amount. Nothing is checked or recorded if the buy
+// spend cap is empty (i.e. spend caps are disabled).
+func (k Keeper) RecordBuySpend(ctx sdk.Context, address sdk.AccAddress, amount sdk.Coins) error {
+	spendCap := k.BuySpendCap(ctx)
+	if spendCap.Empty() {
+		return nil
+	}
+
+	records := k.GetSpendRecords(ctx, address).Prune(
+		ctx.BlockHeight(), k.SpendCapWindowBlocks(ctx))
+	if err := types.CheckSpendCap(spendCap, records.Total(), amount); err != nil {
+		return err
+	}
+
+	records = append(records, types.NewSpendRecord(ctx.BlockHeight(), amount))
+	k.SetSpendRecords(ctx, address, records)
+	return nil
+}
+
+// ReleaseBuySpend releases the amount returned to the address from the spend
+// record of its buy submitted at the specified height, so that the address
+// is only counted as having spent the amount that the buy actually charged.
+// Nothing is released if the buy's record no longer exists.
+func (k Keeper) ReleaseBuySpend(ctx sdk.Context, address sdk.AccAddress, height int64, amount sdk.Coins) {
+	records := k.GetSpendRecords(ctx, address)
+	if len(records) == 0 {
+		return
+	}
+	k.SetSpendRecords(ctx, address, records.Release(height, amount))
+}
+
+// GetAllSpendRecords returns the spend records of every address, along with
+// the address.
+func (k Keeper) GetAllSpendRecords(ctx sdk.Context) (entries []types.SpendRecordsEntry) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.SpendRecordsKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var records types.SpendRecords
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &records)
+		address := sdk.AccAddress(iterator.Key()[len(types.SpendRecordsKeyPrefix):])
+		entries = append(entries, types.NewSpendRecordsEntry(address, records))
+	}
+	return entries
+}
@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetModuleStats(ctx sdk.Context) types.ModuleStats {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.ModuleStatsKey) {
+		return types.NewModuleStats()
+	}
+
+	bz := store.Get(types.ModuleStatsKey)
+	var stats types.ModuleStats
+	k.cdc.MustUnmarshalBinaryBare(bz, &stats)
+
+	return stats
+}
+
+func (k Keeper) SetModuleStats(ctx sdk.Context, stats types.ModuleStats) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.ModuleStatsKey, k.cdc.MustMarshalBinaryBare(stats))
+}
+
+func (k Keeper) updateStatsForBond(ctx sdk.Context, oldBond types.Bond, exists bool, newBond types.Bond) {
+	stats := k.GetModuleStats(ctx)
+
+	// Count bond if it is a new bond
+	if !exists {
+		stats = stats.AddBond(newBond.FunctionType)
+	}
+
+	// Replace old reserve with new reserve in total value locked
+	stats.TotalValueLocked = stats.TotalValueLocked.
+		Add(newBond.CurrentReserve...).Sub(oldBond.CurrentReserve)
+
+	k.SetModuleStats(ctx, stats)
+}
+
+func (k Keeper) updateStatsForBatch(ctx sdk.Context, oldBatch types.Batch, newBatch types.Batch) {
+	if oldBatch.HasOrders() == newBatch.HasOrders() {
+		return
+	}
+
+	stats := k.GetModuleStats(ctx)
+	if newBatch.HasOrders() {
+		stats.ActiveBatches += 1
+	} else {
+		stats.ActiveBatches -= 1
+	}
+	k.SetModuleStats(ctx, stats)
+}
+
+// GetBondFeesCollected returns the total fees collected by the bond over its
+// lifetime, which are sent to the bond's fee address as they are charged.
+func (k Keeper) GetBondFeesCollected(ctx sdk.Context, token string) sdk.Coins {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetBondFeesKey(token)) {
+		return sdk.Coins{}
+	}
+
+	bz := store.Get(types.GetBondFeesKey(token))
+	var fees sdk.Coins
+	k.cdc.MustUnmarshalBinaryBare(bz, &fees)
+
+	return fees
+}
+
+// GetAllBondFeesCollected returns the total fees collected by each bond, along
+// with the bond's token.
+func (k Keeper) GetAllBondFeesCollected(ctx sdk.Context) (fees []types.BondFeesEntry) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.BondFeesKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var bondFees sdk.Coins
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &bondFees)
+		token := string(iterator.Key()[len(types.BondFeesKeyPrefix):])
+		fees = append(fees, types.NewBondFeesEntry(token, bondFees))
+	}
+	return fees
+}
+
+func (k Keeper) SetBondFeesCollected(ctx sdk.Context, token string, fees sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetBondFeesKey(token), k.cdc.MustMarshalBinaryBare(fees))
+}
+
+func (k Keeper) addFeesCollected(ctx sdk.Context, token string, fees sdk.Coins) {
+	if fees.IsZero() {
+		return
+	}
+
+	stats := k.GetModuleStats(ctx)
+	stats.TotalFeesCollected = stats.TotalFeesCollected.Add(fees...)
+	k.SetModuleStats(ctx, stats)
+
+	k.SetBondFeesCollected(ctx, token,
+		k.GetBondFeesCollected(ctx, token).Add(fees...))
+}
@@ -0,0 +1,83 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestModuleStatsInitiallyEmpty(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	stats := app.BondsKeeper.GetModuleStats(ctx)
+	require.Equal(t, types.NewModuleStats(), stats)
+	require.Equal(t, uint64(0), stats.TotalBonds())
+}
+
+func TestModuleStatsCountsBonds(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add power function bond
+	powerBond := getValidPowerFunctionBond()
+	app.BondsKeeper.SetBond(ctx, powerBond.Token, powerBond)
+
+	// Setting the same bond again does not increase the count
+	app.BondsKeeper.SetBond(ctx, powerBond.Token, powerBond)
+
+	// Add swapper function bond
+	swapperBond := getValidSwapperBond()
+	swapperBond.Token = token2
+	app.BondsKeeper.SetBond(ctx, swapperBond.Token, swapperBond)
+
+	stats := app.BondsKeeper.GetModuleStats(ctx)
+	require.Equal(t, uint64(2), stats.TotalBonds())
+	require.Equal(t, uint64(1), stats.BondCountOf(types.PowerFunction))
+	require.Equal(t, uint64(1), stats.BondCountOf(types.SwapperFunction))
+	require.Equal(t, uint64(0), stats.BondCountOf(types.SigmoidFunction))
+}
+
+func TestModuleStatsTracksTotalValueLocked(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add bond
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Add tokens to an account
+	amount, err := sdk.ParseCoins("12res1,34res2")
+	require.Nil(t, err)
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	err = app.BankKeeper.SetCoins(ctx, address, amount)
+	require.Nil(t, err)
+
+	// Deposit reserve
+	err = app.BondsKeeper.DepositReserve(ctx, token, address, amount)
+	require.Nil(t, err)
+	require.Equal(t, amount, app.BondsKeeper.GetModuleStats(ctx).TotalValueLocked)
+
+	// Withdraw part of reserve
+	withdrawal, err := sdk.ParseCoins("2res1,4res2")
+	require.Nil(t, err)
+	err = app.BondsKeeper.WithdrawReserve(ctx, token, address, withdrawal)
+	require.Nil(t, err)
+	require.Equal(t, amount.Sub(withdrawal), app.BondsKeeper.GetModuleStats(ctx).TotalValueLocked)
+}
+
+func TestModuleStatsTracksActiveBatches(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add batch without orders
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	require.Equal(t, uint64(0), app.BondsKeeper.GetModuleStats(ctx).ActiveBatches)
+
+	// Add orders to batch
+	app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+	app.BondsKeeper.AddSellOrder(ctx, token, getValidSellOrder(), buyPrices, sellPrices)
+	require.Equal(t, uint64(1), app.BondsKeeper.GetModuleStats(ctx).ActiveBatches)
+
+	// Reset batch
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	require.Equal(t, uint64(0), app.BondsKeeper.GetModuleStats(ctx).ActiveBatches)
+}
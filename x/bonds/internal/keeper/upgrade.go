@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+var _ types.UpgradeKeeper = NoOpUpgradeKeeper{}
+
+// NoOpUpgradeKeeper is the default upgrade keeper, which never has an upgrade
+// plan. This means that batches are never settled early for an upgrade unless
+// an actual upgrade keeper is set.
+type NoOpUpgradeKeeper struct{}
+
+func (NoOpUpgradeKeeper) GetUpgradePlan(_ sdk.Context) (upgrade.Plan, bool) {
+	return upgrade.Plan{}, false
+}
+
+// BlocksUntilUpgrade returns the number of blocks from the current block to
+// the height of the scheduled upgrade, at which the chain halts, if any. An
+// upgrade scheduled by time is ignored, since its height is not known.
+func (k Keeper) BlocksUntilUpgrade(ctx sdk.Context) (blocks int64, found bool) {
+	plan, found := k.UpgradeKeeper.GetUpgradePlan(ctx)
+	if !found || plan.Height <= ctx.BlockHeight() {
+		return 0, false
+	}
+	return plan.Height - ctx.BlockHeight(), true
+}
+
+// OrdersFrozenForUpgrade returns true if a batch of the bond started in the
+// current block would not be performed before the height of the scheduled
+// upgrade (i.e. if the upgrade is within the bond's batch blocks), in which
+// case new orders are rejected until the upgrade.
+func (k Keeper) OrdersFrozenForUpgrade(ctx sdk.Context, bond types.Bond) bool {
+	blocks, found := k.BlocksUntilUpgrade(ctx)
+	return found && sdk.NewUint(uint64(blocks)).LT(bond.BatchBlocks)
+}
+
+// SettleBatchesBeforeUpgrade makes the pending batch of every bond whose
+// orders are frozen for the scheduled upgrade due at the end of the current
+// block, so that no escrowed order funds are left pending across the upgrade.
+func (k Keeper) SettleBatchesBeforeUpgrade(ctx sdk.Context) {
+	if _, found := k.BlocksUntilUpgrade(ctx); !found {
+		return
+	}
+
+	iterator := k.GetBondIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		bond := k.MustGetBondByKey(ctx, iterator.Key())
+		if !k.OrdersFrozenForUpgrade(ctx, bond) {
+			continue
+		}
+
+		batch := k.MustGetBatch(ctx, bond.Token)
+		if !batch.HasOrders() || batch.BlocksRemaining.LTE(sdk.OneUint()) {
+			continue
+		}
+
+		batch.BlocksRemaining = sdk.OneUint()
+		k.SetBatch(ctx, bond.Token, batch)
+		k.Logger(ctx).Info(fmt.Sprintf(
+			"settling batch of %s early for the scheduled upgrade", bond.Token))
+	}
+}
@@ -0,0 +1,75 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+type mockUpgradeKeeper struct {
+	plan *upgrade.Plan
+}
+
+func (m mockUpgradeKeeper) GetUpgradePlan(_ sdk.Context) (upgrade.Plan, bool) {
+	if m.plan == nil {
+		return upgrade.Plan{}, false
+	}
+	return *m.plan, true
+}
+
+func TestOrdersFrozenForUpgrade(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithBlockHeight(100)
+	bond := getValidBond() // batch blocks: 10
+
+	testCases := []struct {
+		plan     *upgrade.Plan
+		expected bool
+	}{
+		{nil, false},
+		{&upgrade.Plan{Name: "a", Height: 101}, true},
+		{&upgrade.Plan{Name: "a", Height: 109}, true},
+		{&upgrade.Plan{Name: "a", Height: 110}, false},
+		{&upgrade.Plan{Name: "a", Height: 200}, false},
+		{&upgrade.Plan{Name: "a", Height: 100}, false}, // upgrade is due now
+		{&upgrade.Plan{Name: "a", Time: ctx.BlockTime()}, false},
+	}
+	for _, tc := range testCases {
+		app.BondsKeeper.SetUpgradeKeeper(mockUpgradeKeeper{tc.plan})
+		require.Equal(t, tc.expected, app.BondsKeeper.OrdersFrozenForUpgrade(ctx, bond))
+	}
+}
+
+func TestSettleBatchesBeforeUpgrade(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithBlockHeight(100)
+
+	// Add two bonds, one of which has a pending buy
+	otherBond := getValidBond()
+	otherBond.Token = token2
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	app.BondsKeeper.SetBond(ctx, token2, otherBond)
+	app.BondsKeeper.SetBatch(ctx, token, types.NewBatch(token, initBatchBlocks))
+	app.BondsKeeper.SetBatch(ctx, token2, types.NewBatch(token2, initBatchBlocks))
+	app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+
+	// Upgrade outside of the batch blocks; batches left as they are
+	plan := upgrade.Plan{Name: "a", Height: 120}
+	app.BondsKeeper.SetUpgradeKeeper(mockUpgradeKeeper{&plan})
+	app.BondsKeeper.SettleBatchesBeforeUpgrade(ctx)
+	require.Equal(t, initBatchBlocks, app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+
+	// Upgrade within the batch blocks; only the batch with orders is made due
+	plan.Height = 105
+	app.BondsKeeper.SettleBatchesBeforeUpgrade(ctx)
+	require.Equal(t, sdk.OneUint(), app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+	require.Equal(t, initBatchBlocks, app.BondsKeeper.MustGetBatch(ctx, token2).BlocksRemaining)
+
+	// New orders are rejected until the upgrade
+	_, err := app.BondsKeeper.PerformBuy(ctx, callerModule, buyerAddress, buyAmount, maxPrices)
+	require.Error(t, err)
+	require.True(t, types.ErrOrdersFrozenForUpgrade.Is(err))
+}
@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// buysExceedValueLockedCaps returns true if performing the batch's buys would
+// take the bond's reserve above the max bond value locked, or the total
+// reserve of all bonds above the max total value locked. Sells in the batch
+// are not taken into account, so the resulting reserve is never underestimated.
+func (k Keeper) buysExceedValueLockedCaps(ctx sdk.Context, bond types.Bond, batch types.Batch) bool {
+	buysReserve := batch.GetBuysReserve()
+
+	bondValueLocked := bond.CurrentReserve.Add(buysReserve...)
+	if len(types.DenomsExceedingValueLockedCap(bondValueLocked, k.MaxBondValueLocked(ctx))) > 0 {
+		return true
+	}
+
+	totalValueLocked := k.GetModuleStats(ctx).TotalValueLocked.Add(buysReserve...)
+	return len(types.DenomsExceedingValueLockedCap(totalValueLocked, k.MaxTotalValueLocked(ctx))) > 0
+}
+
+// DeferBuysExceedingValueLockedCaps enforces the max bond and max total value
+// locked on the current batch. While the batch's buys would take the reserve
+// above either cap, buy orders are taken out of the batch, starting from the
+// one with the lowest priority (see types.RankBuysByPriority), and returned as
+// buy orders to be added to the next batch, in their original order of
+// arrival. The deferred orders' max prices remain in escrow until they are
+// performed.
+func (k Keeper) DeferBuysExceedingValueLockedCaps(ctx sdk.Context, token string) (deferred []types.BuyOrder) {
+	if k.MaxBondValueLocked(ctx).Empty() && k.MaxTotalValueLocked(ctx).Empty() {
+		return nil
+	}
+
+	bond := k.MustGetBond(ctx, token)
+	batch := k.MustGetBatch(ctx, token)
+	if !k.buysExceedValueLockedCaps(ctx, bond, batch) {
+		return nil
+	}
+
+	// The batch is constrained, so the buys are performed in order of priority
+	logger := k.Logger(ctx)
+	buys := batch.Buys
+	ranking := types.RankBuysByPriority(buys)
+	priorityEvent := types.BuyPriorityEvent{Bond: token}
+	for _, i := range ranking {
+		priorityEvent.Buyers = append(priorityEvent.Buyers, buys[i].Address)
+		priorityEvent.Priorities = append(priorityEvent.Priorities, buys[i].Priority().String())
+	}
+	ctx.EventManager().EmitEvent(types.NewEvent(priorityEvent).
+		AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+	isDeferred := make(map[int]bool)
+	for j := len(ranking) - 1; j >= 0; j-- {
+		if !k.buysExceedValueLockedCaps(ctx, bond, batch) {
+			break
+		}
+
+		// Take the buy out of the batch and update the batch prices
+		bo := buys[ranking[j]]
+		isDeferred[ranking[j]] = true
+		batch.Buys = nil
+		for i, other := range buys {
+			if !isDeferred[i] {
+				batch.Buys = append(batch.Buys, other)
+			}
+		}
+		batch.TotalBuyAmount = batch.TotalBuyAmount.Sub(bo.Amount)
+		buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
+		if err != nil {
+			panic(err)
+		}
+		batch.BuyPrices = buyPrices
+		batch.SellPrices = sellPrices
+
+		logger.Info(fmt.Sprintf("deferred buy order for %s from %s", bo.Amount.String(), bo.Address.String()))
+
+		ctx.EventManager().EmitEvent(types.NewEvent(types.OrderDeferEvent{
+			Bond:           token,
+			OrderType:      types.AttributeValueBuyOrder,
+			Address:        bo.Address,
+			TokensDeferred: bo.Amount.Amount,
+			Memo:           bo.Memo,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+	}
+
+	// Keep the deferred buys in order of arrival
+	for i, bo := range buys {
+		if isDeferred[i] {
+			deferred = append(deferred, bo)
+		}
+	}
+
+	k.SetBatch(ctx, token, batch)
+	return deferred
+}
+
+// AddDeferredBuyOrders adds buy orders deferred from a previous batch to the
+// current batch. The buyers' max prices are still in escrow from when the
+// orders were first placed. Any order that can no longer be added to the batch
+// (e.g. since it would now cross the end of the hatch phase) is cancelled and
+// its max prices are returned to the buyer.
+func (k Keeper) AddDeferredBuyOrders(ctx sdk.Context, token string, orders []types.BuyOrder) {
+	logger := k.Logger(ctx)
+	bond := k.MustGetBond(ctx, token)
+	for _, bo := range orders {
+		buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterBuy(ctx, token, bo)
+		if err == nil {
+			k.AddBuyOrder(ctx, token, bo, buyPrices, sellPrices)
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("cancelled deferred buy order for %s from %s", bo.Amount.String(), bo.Address.String()))
+		logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
+
+		ctx.EventManager().EmitEvent(types.NewEvent(types.OrderCancelEvent{
+			Bond:            token,
+			OrderType:       types.AttributeValueBuyOrder,
+			Address:         bo.Address,
+			CancelReason:    err.Error(),
+			CallbackPayload: bo.CallbackPayload,
+			Memo:            bo.Memo,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...))
+
+		err = k.RefundEscrowedFunds(ctx, token, bo.Address, bo.EscrowedFunds())
+		if err != nil {
+			panic(err)
+		}
+		k.ReleaseBuySpend(ctx, bo.Address, bo.Height, bo.MaxPrices)
+	}
+}
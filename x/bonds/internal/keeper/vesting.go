@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetVestingScheduleIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.VestingKeyPrefix)
+}
+
+func (k Keeper) GetVestingSchedule(ctx sdk.Context, token string) (schedule types.VestingSchedule, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(types.GetVestingScheduleKey(token)) {
+		return types.VestingSchedule{}, false
+	}
+
+	bz := store.Get(types.GetVestingScheduleKey(token))
+	k.cdc.MustUnmarshalBinaryBare(bz, &schedule)
+
+	return schedule, true
+}
+
+func (k Keeper) GetVestingSchedules(ctx sdk.Context) (schedules []types.VestingSchedule) {
+	iterator := k.GetVestingScheduleIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var schedule types.VestingSchedule
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &schedule)
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
+
+func (k Keeper) SetVestingSchedule(ctx sdk.Context, schedule types.VestingSchedule) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetVestingScheduleKey(schedule.BondToken),
+		k.cdc.MustMarshalBinaryBare(schedule))
+}
+
+func (k Keeper) DeleteVestingSchedule(ctx sdk.Context, token string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetVestingScheduleKey(token))
+}
+
+// PreMine mints the amount of the bond's tokens, adding it to the bond's
+// current supply, and locks it in the bond vesting account under a vesting
+// schedule that releases it to the recipient over the pre-mine vesting period.
+// The recipient pays the reserve for the amount along the curve (rounded up),
+// so that the pre-mined tokens are backed by the reserve like any other
+// tokens and selling them never takes reserve paid in by buyers.
+func (k Keeper) PreMine(ctx sdk.Context, token string,
+	recipient sdk.AccAddress, amount sdk.Coin) error {
+
+	bond := k.MustGetBond(ctx, token)
+	prices, err := bond.GetPricesToMint(amount.Amount, k.GetReserveBalances(ctx, token))
+	if err != nil {
+		return err
+	}
+	err = k.DepositReserve(ctx, token, recipient, types.RoundReservePrices(prices))
+	if err != nil {
+		return err
+	}
+
+	err = k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount,
+		sdk.Coins{amount})
+	if err != nil {
+		return err
+	}
+
+	err = k.SupplyKeeper.SendCoinsFromModuleToModule(ctx,
+		types.BondsMintBurnAccount, types.BondVestingAccount, sdk.Coins{amount})
+	if err != nil {
+		return err
+	}
+	k.RecordLedgerEntry(ctx, token, types.LedgerEntryMint,
+		types.LedgerAccountExternal, types.LedgerAccountSupply,
+		sdk.Coins{amount}, k.SupplyKeeper.GetModuleAddress(types.BondVestingAccount))
+
+	k.SetCurrentSupply(ctx, token,
+		k.MustGetBond(ctx, token).CurrentSupply.Add(amount))
+
+	endHeight := ctx.BlockHeight() + int64(k.PreMineVestingBlocks(ctx))
+	k.SetVestingSchedule(ctx, types.NewVestingSchedule(
+		token, recipient, amount, ctx.BlockHeight(), endHeight))
+
+	return nil
+}
+
+// ReleaseVestedTokens sends the tokens that have vested since they were last
+// released to the recipient of each vesting schedule, and deletes the vesting
+// schedules that have been released in full.
+func (k Keeper) ReleaseVestedTokens(ctx sdk.Context) {
+	for _, schedule := range k.GetVestingSchedules(ctx) {
+		releasable := schedule.ReleasableAt(ctx.BlockHeight())
+		if releasable.IsPositive() {
+			err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+				types.BondVestingAccount, schedule.Recipient, sdk.Coins{releasable})
+			if err != nil {
+				panic(err)
+			}
+			schedule.Released = schedule.Released.Add(releasable)
+
+			logger := k.Logger(ctx)
+			logger.Info(fmt.Sprintf("released %s vested to %s",
+				releasable, schedule.Recipient))
+
+			bond := k.MustGetBond(ctx, schedule.BondToken)
+			ctx.EventManager().EmitEvent(
+				types.NewEvent(types.ReleaseVestedEvent{
+					Bond:      schedule.BondToken,
+					Recipient: schedule.Recipient,
+					Amount:    releasable,
+				}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+			)
+		}
+
+		if schedule.Locked().IsZero() {
+			k.DeleteVestingSchedule(ctx, schedule.BondToken)
+		} else if releasable.IsPositive() {
+			k.SetVestingSchedule(ctx, schedule)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Metrics of a bond that are monitored for alerts
+const (
+	AlertMetricSpotPrice = "spot_price"
+	AlertMetricReserve   = "reserve"
+	AlertMetricSupply    = "supply"
+)
+
+// AlertWindow records a bond's spot price, reserve, and supply at the start of
+// the current alert window, against which changes in these values are
+// measured, along with the metrics for which an alert was already emitted
+// within the window, so that each metric is alerted at most once per window.
+type AlertWindow struct {
+	StartHeight int64        `json:"start_height" yaml:"start_height"`
+	SpotPrice   sdk.DecCoins `json:"spot_price" yaml:"spot_price"`
+	Reserve     sdk.DecCoins `json:"reserve" yaml:"reserve"`
+	Supply      sdk.DecCoins `json:"supply" yaml:"supply"`
+	Alerted     []string     `json:"alerted" yaml:"alerted"`
+}
+
+func NewAlertWindow(startHeight int64, spotPrice, reserve, supply sdk.DecCoins) AlertWindow {
+	return AlertWindow{
+		StartHeight: startHeight,
+		SpotPrice:   spotPrice,
+		Reserve:     reserve,
+		Supply:      supply,
+		Alerted:     nil,
+	}
+}
+
+// HasEnded returns true if the window of the specified number of blocks has
+// ended at the specified height.
+func (w AlertWindow) HasEnded(height int64, windowBlocks uint64) bool {
+	return height >= w.StartHeight+int64(windowBlocks)
+}
+
+// HasAlerted returns true if an alert was already emitted for the metric
+// within the window.
+func (w AlertWindow) HasAlerted(metric string) bool {
+	for _, m := range w.Alerted {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// CoinsToDecCoins converts coins to dec coins, keeping any zero amounts.
+func CoinsToDecCoins(coins ...sdk.Coin) sdk.DecCoins {
+	decCoins := make(sdk.DecCoins, len(coins))
+	for i, c := range coins {
+		decCoins[i] = sdk.NewDecCoinFromCoin(c)
+	}
+	return decCoins
+}
+
+// GetChangePercentage returns the largest change, in any denomination, from
+// one set of values to another, as a percentage of the original value. Zero
+// original values are ignored, since a change from zero cannot be expressed
+// as a percentage.
+func GetChangePercentage(from, to sdk.DecCoins) sdk.Dec {
+	largest := sdk.ZeroDec()
+	for _, f := range from {
+		if !f.Amount.IsPositive() {
+			continue
+		}
+		change := to.AmountOf(f.Denom).Sub(f.Amount).Abs().Quo(f.Amount).MulInt64(100)
+		if change.GT(largest) {
+			largest = change
+		}
+	}
+	return largest
+}
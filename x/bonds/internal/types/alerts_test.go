@@ -0,0 +1,46 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetChangePercentage(t *testing.T) {
+	testCases := []struct {
+		from     sdk.DecCoins
+		to       sdk.DecCoins
+		expected sdk.Dec
+	}{
+		{sdk.DecCoins{sdk.NewInt64DecCoin("res", 100)},
+			sdk.DecCoins{sdk.NewInt64DecCoin("res", 100)}, sdk.ZeroDec()},
+		{sdk.DecCoins{sdk.NewInt64DecCoin("res", 100)},
+			sdk.DecCoins{sdk.NewInt64DecCoin("res", 150)}, sdk.NewDec(50)},
+		{sdk.DecCoins{sdk.NewInt64DecCoin("res", 100)},
+			sdk.DecCoins{sdk.NewInt64DecCoin("res", 25)}, sdk.NewDec(75)},
+		{sdk.DecCoins{sdk.NewInt64DecCoin("res", 100)},
+			nil, sdk.NewDec(100)},
+		{sdk.DecCoins{sdk.NewInt64DecCoin("res", 100), sdk.NewInt64DecCoin("rez", 100)},
+			sdk.DecCoins{sdk.NewInt64DecCoin("res", 110), sdk.NewInt64DecCoin("rez", 300)}, sdk.NewDec(200)},
+		{sdk.DecCoins{sdk.NewInt64DecCoin("res", 0)},
+			sdk.DecCoins{sdk.NewInt64DecCoin("res", 1000)}, sdk.ZeroDec()},
+		{nil, sdk.DecCoins{sdk.NewInt64DecCoin("res", 1000)}, sdk.ZeroDec()},
+	}
+	for _, tc := range testCases {
+		actual := GetChangePercentage(tc.from, tc.to)
+		require.True(t, tc.expected.Equal(actual),
+			tc.from.String()+"->"+tc.to.String()+": "+actual.String())
+	}
+}
+
+func TestAlertWindow(t *testing.T) {
+	window := NewAlertWindow(100, nil, nil, nil)
+
+	require.False(t, window.HasEnded(109, 10))
+	require.True(t, window.HasEnded(110, 10))
+
+	require.False(t, window.HasAlerted(AlertMetricSupply))
+	window.Alerted = append(window.Alerted, AlertMetricSupply)
+	require.True(t, window.HasAlerted(AlertMetricSupply))
+	require.False(t, window.HasAlerted(AlertMetricReserve))
+}
@@ -6,6 +6,11 @@ import (
 
 // Inspired by work from BlockScience:
 // https://github.com/BlockScience/cadCAD-Tutorials/tree/master/00-Reference-Mechanisms
+//
+// All of the functions below are computed using sdk.Dec arithmetic only. Roots
+// are found using sdk.Dec.ApproxRoot, which performs a bounded number of
+// Newton iterations on integers, so every node computes identical results
+// regardless of its architecture. Floating point numbers must not be used here.
 
 // value function for a given state (R,S)
 func Invariant(R, S sdk.Dec, kappa int64) sdk.Dec {
@@ -0,0 +1,121 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The Bancor function keeps a constant reserve ratio cw (the connector weight)
+// between the reserve and the market cap, i.e. reserve = cw * supply * price.
+// Given the price p0 at the supply s0, its price is p0*(x/s0)^(1/cw-1) and its
+// reserve is cw*p0*s0*(x/s0)^(1/cw). Both are computed using sdk.Dec arithmetic
+// only, so every node computes identical results regardless of its
+// architecture.
+
+// ApproxPower returns base^exponent for the non-negative base and exponent.
+// The integer part n of the exponent is evaluated by repeated multiplication
+// and the fractional part f as e^(f*ln(base)), or as 1/e^(f*ln(1/base)) if the
+// base is less than 1, so the result is deterministic.
+func ApproxPower(base, exponent sdk.Dec) (sdk.Dec, error) {
+	if base.IsNegative() {
+		return sdk.Dec{}, sdkerrors.Wrap(ErrArgumentCannotBeNegative, "base")
+	} else if exponent.IsNegative() {
+		return sdk.Dec{}, sdkerrors.Wrap(ErrArgumentCannotBeNegative, "exponent")
+	} else if exponent.IsZero() {
+		return sdk.OneDec(), nil
+	} else if base.IsZero() {
+		return sdk.ZeroDec(), nil
+	}
+
+	n := exponent.TruncateInt()
+	f := exponent.Sub(n.ToDec())
+	result := base.Power(n.Uint64())
+	if f.IsZero() {
+		return result, nil
+	}
+
+	if base.GTE(sdk.OneDec()) {
+		ln, err := ApproxLn(base)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		exp, err := ApproxExp(f.Mul(ln))
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		return result.Mul(exp), nil
+	}
+
+	ln, err := ApproxLn(sdk.OneDec().Quo(base))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	exp, err := ApproxExp(f.Mul(ln))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return result.Quo(exp), nil
+}
+
+// bancorFunctionPrice returns the Bancor function's price p0*(x/s0)^(1/cw-1)
+// at the supply x
+func bancorFunctionPrice(p0, s0, cw, x sdk.Dec) (sdk.Dec, error) {
+	power, err := ApproxPower(x.Quo(s0), sdk.OneDec().Quo(cw).Sub(sdk.OneDec()))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return p0.Mul(power), nil
+}
+
+// bancorFunctionReserve returns the Bancor function's reserve at the supply x,
+// i.e. the integral of the price from 0 to x, cw*p0*s0*(x/s0)^(1/cw)
+func bancorFunctionReserve(p0, s0, cw, x sdk.Dec) (sdk.Dec, error) {
+	power, err := ApproxPower(x.Quo(s0), sdk.OneDec().Quo(cw))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return cw.Mul(p0).Mul(s0).Mul(power), nil
+}
+
+// CheckBancorMaxSupply returns an error if the Bancor function with the
+// parameters cannot be evaluated up to the max supply, i.e. if (x/s0)^(1/cw)
+// at the max supply x exceeds e^MaxExponentialExponent.
+func CheckBancorMaxSupply(paramsMap map[string]sdk.Dec, maxSupply sdk.Int) error {
+	ratio := maxSupply.ToDec().Quo(paramsMap["s0"])
+	if ratio.LTE(sdk.OneDec()) {
+		return nil
+	}
+
+	ln, err := ApproxLn(ratio)
+	if err != nil {
+		return err
+	} else if ln.Quo(paramsMap["cw"]).GT(MaxExponentialExponent) {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"ln(max supply %s / FunctionParams:s0) divided by FunctionParams:cw cannot exceed %s",
+			maxSupply, MaxExponentialExponent)
+	}
+	return nil
+}
+
+func bancorParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Bancor exception 1: p0 != 0, otherwise the price is always zero
+	// Bancor exception 2: s0 != 0, otherwise we run into divisions by zero
+	for _, p := range []string{"p0", "s0"} {
+		val, ok := paramsMap[p]
+		if !ok {
+			panic("did not find parameter " + p + " for bancor function")
+		} else if !val.IsPositive() {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:"+p)
+		}
+	}
+
+	// Bancor exception 3: 0 < cw <= 1, since the reserve cannot exceed the
+	// market cap and a zero cw would result in divisions by zero
+	val, ok := paramsMap["cw"]
+	if !ok {
+		panic("did not find parameter cw for bancor function")
+	} else if !val.IsPositive() || val.GT(sdk.OneDec()) {
+		return sdkerrors.Wrap(ErrArgumentMustBeBetween, "FunctionParams:cw must be between 0 (exclusive) and 1")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestApproxPower(t *testing.T) {
+	// Expected values are base^exponent to 18 decimal places (rounded)
+	testCases := []struct {
+		base     string
+		exponent string
+		expected string
+	}{
+		{"0", "1.5", "0"},
+		{"0", "0", "1"},
+		{"7", "0", "1"},
+		{"2", "3", "8"},
+		{"2", "1.5", "2.828427124746190098"},
+		{"2", "2.5", "5.656854249492380195"},
+		{"0.5", "1.5", "0.353553390593273762"},
+		{"10", "0.3", "1.995262314968879601"},
+		{"1000", "2.25", "5623413.251903490803949510"},
+		{"0.001", "0.5", "0.031622776601683793"},
+		{"1.000001", "3.7", "1.000003700004995003"},
+	}
+
+	// Results are expected to be within a relative error of 1e-15
+	tolerance := sdk.NewDecWithPrec(1, 15)
+	for _, tc := range testCases {
+		actual, err := ApproxPower(
+			sdk.MustNewDecFromStr(tc.base), sdk.MustNewDecFromStr(tc.exponent))
+		require.Nil(t, err)
+
+		expected := sdk.MustNewDecFromStr(tc.expected)
+		require.True(t, actual.Sub(expected).Abs().LTE(tolerance.Mul(expected).Add(tolerance)),
+			"%s^%s: expected %s, got %s", tc.base, tc.exponent, expected, actual)
+	}
+}
+
+func TestApproxPowerNegativeArgumentsGiveError(t *testing.T) {
+	_, err := ApproxPower(sdk.NewDec(-2), sdk.NewDec(2))
+	require.Error(t, err)
+	_, err = ApproxPower(sdk.NewDec(2), sdk.NewDec(-2))
+	require.Error(t, err)
+}
+
+func TestBancorFunctionKeepsConstantReserveRatio(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionType = BancorFunction
+	bond.FunctionParameters = FunctionParams{
+		NewFunctionParam("p0", sdk.MustNewDecFromStr("0.25")),
+		NewFunctionParam("s0", sdk.NewDec(1000)),
+		NewFunctionParam("cw", sdk.MustNewDecFromStr("0.3"))}
+	cw := sdk.MustNewDecFromStr("0.3")
+
+	// The reserve is always cw times the market cap (supply times price)
+	tolerance := sdk.NewDecWithPrec(1, 12)
+	for _, supply := range []int64{1, 500, 1000, 1234, 5000} {
+		x := sdk.NewInt(supply)
+		prices, err := bond.GetPricesAtSupply(x)
+		require.Nil(t, err)
+		reserve := bond.ReserveAtSupply(x)
+		expected := cw.Mul(prices[0].Amount).MulInt(x)
+		require.True(t, reserve.Sub(expected).Abs().LTE(tolerance.Mul(expected)),
+			"supply %d: expected %s, got %s", supply, expected, reserve)
+	}
+
+	// The price at s0 is p0
+	prices, err := bond.GetPricesAtSupply(sdk.NewInt(1000))
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("0.25"), prices[0].Amount)
+}
+
+func TestCheckBancorMaxSupply(t *testing.T) {
+	paramsMap := functionParametersBancor().AsMap() // s0=1000, cw=0.5
+
+	// ln(x/1000)/0.5 <= 100 for x up to 1000*e^50 (about 5.18e24)
+	require.Nil(t, CheckBancorMaxSupply(paramsMap, sdk.NewInt(1)))
+	require.Nil(t, CheckBancorMaxSupply(paramsMap, sdk.NewIntWithDecimal(5, 24)))
+	require.Error(t, CheckBancorMaxSupply(paramsMap, sdk.NewIntWithDecimal(6, 24)))
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"sort"
 )
 
 type Batch struct {
@@ -20,6 +21,10 @@ func (b Batch) MoreBuysThanSells() bool { return b.TotalSellAmount.IsLT(b.TotalB
 func (b Batch) MoreSellsThanBuys() bool { return b.TotalBuyAmount.IsLT(b.TotalSellAmount) }
 func (b Batch) EqualBuysAndSells() bool { return b.TotalBuyAmount.IsEqual(b.TotalSellAmount) }
 
+func (b Batch) HasOrders() bool {
+	return len(b.Buys) > 0 || len(b.Sells) > 0 || len(b.Swaps) > 0
+}
+
 func NewBatch(token string, blocks sdk.Uint) Batch {
 	return Batch{
 		Token:           token,
@@ -29,11 +34,14 @@ func NewBatch(token string, blocks sdk.Uint) Batch {
 	}
 }
 
+// BaseOrder optionally holds a memo specified by the order's owner (e.g. an
+// internal reference number), which is echoed in the order's events.
 type BaseOrder struct {
 	Address      sdk.AccAddress `json:"address" yaml:"address"`
 	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
 	Cancelled    bool           `json:"cancelled" yaml:"cancelled"`
 	CancelReason string         `json:"cancel_reason" yaml:"cancel_reason"`
+	Memo         string         `json:"memo,omitempty" yaml:"memo,omitempty"`
 }
 
 func NewBaseOrder(address sdk.AccAddress, amount sdk.Coin) BaseOrder {
@@ -49,9 +57,26 @@ func (bo BaseOrder) IsCancelled() bool {
 	return bo.Cancelled == true
 }
 
+// BuyOrder is a buy of an amount of bond tokens, for which the max prices are
+// locked until the batch is performed. A fulfilled buy is always charged the
+// batch's buy prices (plus fees), regardless of its max prices, and the rest
+// of the max prices (the refund) is returned to the buyer.
+//
+// The buyer can optionally pay a priority fee, which determines the buy's
+// priority whenever a batch's buys cannot all be performed. The priority fee
+// is locked along with the max prices, and is only paid to the bond's fee
+// address if the buy is fulfilled, being returned to the buyer otherwise.
+//
+// The height at which the buy was submitted identifies the buyer's spend
+// record of the buy (if spend caps are enabled), from which the part of the
+// max prices returned to the buyer is released.
 type BuyOrder struct {
 	BaseOrder
-	MaxPrices sdk.Coins `json:"max_prices" yaml:"max_prices"`
+	Height          int64     `json:"height,omitempty" yaml:"height,omitempty"`
+	MaxPrices       sdk.Coins `json:"max_prices" yaml:"max_prices"`
+	Refund          sdk.Coins `json:"refund" yaml:"refund"`
+	CallbackPayload string    `json:"callback_payload,omitempty" yaml:"callback_payload,omitempty"`
+	PriorityFee     sdk.Coins `json:"priority_fee,omitempty" yaml:"priority_fee,omitempty"`
 }
 
 func NewBuyOrder(address sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) BuyOrder {
@@ -61,6 +86,52 @@ func NewBuyOrder(address sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) B
 	}
 }
 
+// EscrowedFunds returns the funds locked for the buy order until its batch is
+// performed, i.e. its max prices and its priority fee.
+func (bo BuyOrder) EscrowedFunds() sdk.Coins {
+	return bo.MaxPrices.Add(bo.PriorityFee...)
+}
+
+// Priority returns the sum of the amounts of the buy order's priority fee.
+// Since a priority fee can only be paid in the bond's reserve tokens, which
+// are always charged equal amounts per bond token bought, the amounts of the
+// different reserve tokens are treated as having equal value.
+func (bo BuyOrder) Priority() sdk.Int {
+	priority := sdk.ZeroInt()
+	for _, c := range bo.PriorityFee {
+		priority = priority.Add(c.Amount)
+	}
+	return priority
+}
+
+// RankBuysByPriority returns the indices of the (non-cancelled) buys, ordered
+// from the highest to the lowest priority. Ties are broken by order of arrival
+// in the batch, so the ranking is deterministic and, for buys without priority
+// fees, is simply the order of arrival.
+func RankBuysByPriority(buys []BuyOrder) (ranking []int) {
+	for i, bo := range buys {
+		if !bo.IsCancelled() {
+			ranking = append(ranking, i)
+		}
+	}
+	sort.SliceStable(ranking, func(a, b int) bool {
+		return buys[ranking[a]].Priority().GT(buys[ranking[b]].Priority())
+	})
+	return ranking
+}
+
+// DenomsExceedingMaxPrices returns the denoms of the prices for which the buy
+// order's max price is exceeded. Each denom in the max prices is treated as an
+// independent limit, and a denom missing from the max prices has a limit of 0.
+func (bo BuyOrder) DenomsExceedingMaxPrices(prices sdk.Coins) (denoms []string) {
+	for _, p := range prices {
+		if p.Amount.GT(bo.MaxPrices.AmountOf(p.Denom)) {
+			denoms = append(denoms, p.Denom)
+		}
+	}
+	return denoms
+}
+
 type SellOrder struct {
 	BaseOrder
 }
@@ -73,12 +144,117 @@ func NewSellOrder(address sdk.AccAddress, amount sdk.Coin) SellOrder {
 
 type SwapOrder struct {
 	BaseOrder
-	ToToken string `json:"to_token" yaml:"to_token"`
+	ToToken    string    `json:"to_token" yaml:"to_token"`
+	OracleRate sdk.Dec   `json:"oracle_rate,omitempty" yaml:"oracle_rate,omitempty"`
+	Via        string    `json:"via,omitempty" yaml:"via,omitempty"`
+	MinReturn  *sdk.Coin `json:"min_return,omitempty" yaml:"min_return,omitempty"`
 }
 
 func NewSwapOrder(address sdk.AccAddress, from sdk.Coin, toToken string) SwapOrder {
 	return SwapOrder{
-		BaseOrder: NewBaseOrder(address, from),
-		ToToken:   toToken,
+		BaseOrder:  NewBaseOrder(address, from),
+		ToToken:    toToken,
+		OracleRate: sdk.ZeroDec(),
 	}
 }
+
+// NewRebalanceSwapOrder returns a swap order that is checked against the
+// sanity margin around the oracle rate instead of around the bond's sanity
+// rate, allowing the swap to move the reserves outside of the sanity band.
+func NewRebalanceSwapOrder(address sdk.AccAddress, from sdk.Coin,
+	toToken string, oracleRate sdk.Dec) SwapOrder {
+	so := NewSwapOrder(address, from, toToken)
+	so.OracleRate = oracleRate
+	return so
+}
+
+// IsRebalance returns true if the swap order is a rebalance swap order, i.e.
+// if it has an oracle rate. Normal swap orders have no (or a zero) oracle rate.
+func (so SwapOrder) IsRebalance() bool {
+	return !so.OracleRate.IsNil() && so.OracleRate.IsPositive()
+}
+
+// IsRouted returns true if the swap order is routed through the token of
+// another bond (the via bond) that is one of the swapper bond's reserve
+// tokens, i.e. if one of the from and to tokens is a reserve token of the via
+// bond rather than of the swapper bond itself.
+func (so SwapOrder) IsRouted() bool {
+	return so.Via != ""
+}
+
+// MinReturnMet returns true if the returns of the swap are not less than the
+// swap order's min return, if it has one.
+func (so SwapOrder) MinReturnMet(returns sdk.Coins) bool {
+	return so.MinReturn == nil || returns.AmountOf(so.MinReturn.Denom).GTE(so.MinReturn.Amount)
+}
+
+type CancelledOrder struct {
+	OrderType    string         `json:"order_type" yaml:"order_type"`
+	Address      sdk.AccAddress `json:"address" yaml:"address"`
+	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
+	CancelReason string         `json:"cancel_reason" yaml:"cancel_reason"`
+	Memo         string         `json:"memo,omitempty" yaml:"memo,omitempty"`
+}
+
+func NewCancelledOrder(orderType string, order BaseOrder) CancelledOrder {
+	return CancelledOrder{
+		OrderType:    orderType,
+		Address:      order.Address,
+		Amount:       order.Amount,
+		CancelReason: order.CancelReason,
+		Memo:         order.Memo,
+	}
+}
+
+// BatchResult is a summary of an executed batch, kept so that the outcome of
+// the last batch of a bond can be queried after the batch has been reset.
+type BatchResult struct {
+	Token           string           `json:"token" yaml:"token"`
+	Height          int64            `json:"height" yaml:"height"`
+	BuyPrices       sdk.DecCoins     `json:"buy_prices" yaml:"buy_prices"`
+	SellPrices      sdk.DecCoins     `json:"sell_prices" yaml:"sell_prices"`
+	TotalBought     sdk.Coin         `json:"total_bought" yaml:"total_bought"`
+	TotalSold       sdk.Coin         `json:"total_sold" yaml:"total_sold"`
+	TotalSwapped    sdk.Coins        `json:"total_swapped" yaml:"total_swapped"`
+	TotalRefunded   sdk.Coins        `json:"total_refunded" yaml:"total_refunded"`
+	CancelledOrders []CancelledOrder `json:"cancelled_orders" yaml:"cancelled_orders"`
+}
+
+func NewBatchResult(batch Batch, height int64) BatchResult {
+	result := BatchResult{
+		Token:       batch.Token,
+		Height:      height,
+		BuyPrices:   batch.BuyPrices,
+		SellPrices:  batch.SellPrices,
+		TotalBought: sdk.NewInt64Coin(batch.Token, 0),
+		TotalSold:   sdk.NewInt64Coin(batch.Token, 0),
+	}
+
+	for _, bo := range batch.Buys {
+		if bo.IsCancelled() {
+			result.CancelledOrders = append(result.CancelledOrders,
+				NewCancelledOrder(AttributeValueBuyOrder, bo.BaseOrder))
+		} else {
+			result.TotalBought = result.TotalBought.Add(bo.Amount)
+			result.TotalRefunded = result.TotalRefunded.Add(bo.Refund...)
+		}
+	}
+	for _, so := range batch.Sells {
+		if so.IsCancelled() {
+			result.CancelledOrders = append(result.CancelledOrders,
+				NewCancelledOrder(AttributeValueSellOrder, so.BaseOrder))
+		} else {
+			result.TotalSold = result.TotalSold.Add(so.Amount)
+		}
+	}
+	for _, so := range batch.Swaps {
+		if so.IsCancelled() {
+			result.CancelledOrders = append(result.CancelledOrders,
+				NewCancelledOrder(AttributeValueSwapOrder, so.BaseOrder))
+		} else {
+			result.TotalSwapped = result.TotalSwapped.Add(so.Amount)
+		}
+	}
+
+	return result
+}
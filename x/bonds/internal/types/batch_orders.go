@@ -0,0 +1,152 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// DefaultBatchOrdersLimit is the number of orders per page returned by a
+	// batch orders query if no limit is specified.
+	DefaultBatchOrdersLimit = 50
+	// MaxBatchOrdersLimit is the maximum number of orders per page returned by
+	// a batch orders query.
+	MaxBatchOrdersLimit = 100
+
+	BatchOrderStatusPending   = "pending"
+	BatchOrderStatusCancelled = "cancelled"
+)
+
+// QueryBatchOrdersParams are the pagination and filters of a batch orders
+// query. Pages start from 1. Empty filters match every order.
+type QueryBatchOrdersParams struct {
+	Page    int            `json:"page" yaml:"page"`
+	Limit   int            `json:"limit" yaml:"limit"`
+	Side    string         `json:"side" yaml:"side"`
+	Account sdk.AccAddress `json:"account" yaml:"account"`
+	Status  string         `json:"status" yaml:"status"`
+}
+
+func NewQueryBatchOrdersParams(page, limit int, side string,
+	account sdk.AccAddress, status string) QueryBatchOrdersParams {
+	return QueryBatchOrdersParams{
+		Page:    page,
+		Limit:   limit,
+		Side:    side,
+		Account: account,
+		Status:  status,
+	}
+}
+
+func (p QueryBatchOrdersParams) Validate() error {
+	if p.Page < 1 {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "page")
+	} else if p.Limit < 1 || p.Limit > MaxBatchOrdersLimit {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"limit must be between 1 and %d", MaxBatchOrdersLimit)
+	}
+
+	switch p.Side {
+	case "", AttributeValueBuyOrder, AttributeValueSellOrder, AttributeValueSwapOrder:
+	default:
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest,
+			"side must be one of %s, %s or %s", AttributeValueBuyOrder,
+			AttributeValueSellOrder, AttributeValueSwapOrder)
+	}
+
+	switch p.Status {
+	case "", BatchOrderStatusPending, BatchOrderStatusCancelled:
+	default:
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest,
+			"status must be one of %s or %s", BatchOrderStatusPending,
+			BatchOrderStatusCancelled)
+	}
+
+	return nil
+}
+
+// BatchOrder is a buy, sell, or swap order in a batch, identified by its side
+// and its index among the orders of that side in the batch. The max prices are
+// only set for buys and the to token is only set for swaps.
+type BatchOrder struct {
+	Side         string         `json:"side" yaml:"side"`
+	Index        uint64         `json:"index" yaml:"index"`
+	Address      sdk.AccAddress `json:"address" yaml:"address"`
+	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
+	Status       string         `json:"status" yaml:"status"`
+	CancelReason string         `json:"cancel_reason" yaml:"cancel_reason"`
+	MaxPrices    sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	ToToken      string         `json:"to_token" yaml:"to_token"`
+}
+
+func newBatchOrder(side string, index int, order BaseOrder) BatchOrder {
+	status := BatchOrderStatusPending
+	if order.IsCancelled() {
+		status = BatchOrderStatusCancelled
+	}
+	return BatchOrder{
+		Side:         side,
+		Index:        uint64(index),
+		Address:      order.Address,
+		Amount:       order.Amount,
+		Status:       status,
+		CancelReason: order.CancelReason,
+	}
+}
+
+// QueryBatchOrders is a page of the orders in a batch that match the filters
+// of the query. The total is the number of matching orders across all pages.
+type QueryBatchOrders struct {
+	Page   int          `json:"page" yaml:"page"`
+	Limit  int          `json:"limit" yaml:"limit"`
+	Total  uint64       `json:"total" yaml:"total"`
+	Orders []BatchOrder `json:"orders" yaml:"orders"`
+}
+
+// NewQueryBatchOrders lists the buys, then the sells, then the swaps in the
+// batch (each in the order in which they were added to the batch) that match
+// the filters, and returns the requested page of that list.
+func NewQueryBatchOrders(batch Batch, params QueryBatchOrdersParams) QueryBatchOrders {
+	var matching []BatchOrder
+	for i, bo := range batch.Buys {
+		order := newBatchOrder(AttributeValueBuyOrder, i, bo.BaseOrder)
+		order.MaxPrices = bo.MaxPrices
+		matching = appendIfMatches(matching, order, params)
+	}
+	for i, so := range batch.Sells {
+		order := newBatchOrder(AttributeValueSellOrder, i, so.BaseOrder)
+		matching = appendIfMatches(matching, order, params)
+	}
+	for i, so := range batch.Swaps {
+		order := newBatchOrder(AttributeValueSwapOrder, i, so.BaseOrder)
+		order.ToToken = so.ToToken
+		matching = appendIfMatches(matching, order, params)
+	}
+
+	start := (params.Page - 1) * params.Limit
+	end := start + params.Limit
+	if start > len(matching) {
+		start = len(matching)
+	}
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	return QueryBatchOrders{
+		Page:   params.Page,
+		Limit:  params.Limit,
+		Total:  uint64(len(matching)),
+		Orders: append([]BatchOrder{}, matching[start:end]...),
+	}
+}
+
+func appendIfMatches(orders []BatchOrder, order BatchOrder, params QueryBatchOrdersParams) []BatchOrder {
+	if params.Side != "" && order.Side != params.Side {
+		return orders
+	} else if !params.Account.Empty() && !order.Address.Equals(params.Account) {
+		return orders
+	} else if params.Status != "" && order.Status != params.Status {
+		return orders
+	}
+	return append(orders, order)
+}
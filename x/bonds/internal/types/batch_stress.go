@@ -0,0 +1,65 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BatchStress records the number of consecutive stressed batches of a bond,
+// i.e. batches whose price impact or volume exceeded the stress thresholds,
+// and the extra blocks by which the bond's batches are currently lengthened
+// as a result. The extra blocks are added while batches remain stressed and
+// decay back to zero once they are not.
+type BatchStress struct {
+	StressedBatches uint64 `json:"stressed_batches" yaml:"stressed_batches"`
+	ExtraBlocks     uint64 `json:"extra_blocks" yaml:"extra_blocks"`
+}
+
+func NewBatchStress(stressedBatches, extraBlocks uint64) BatchStress {
+	return BatchStress{
+		StressedBatches: stressedBatches,
+		ExtraBlocks:     extraBlocks,
+	}
+}
+
+// Update returns the batch stress after a batch that was (or was not)
+// stressed. Once the specified number of consecutive batches are stressed,
+// each further stressed batch lengthens the bond's batches by the extra
+// blocks, up to the max extra blocks. A batch that is not stressed resets
+// the count of stressed batches and halves the extra blocks (rounding down).
+func (s BatchStress) Update(stressed bool, consecutiveBatches, extraBlocks,
+	maxExtraBlocks uint64) BatchStress {
+	if !stressed {
+		return NewBatchStress(0, s.ExtraBlocks/2)
+	}
+
+	s.StressedBatches += 1
+	if s.StressedBatches >= consecutiveBatches {
+		s.ExtraBlocks += extraBlocks
+		if s.ExtraBlocks > maxExtraBlocks {
+			s.ExtraBlocks = maxExtraBlocks
+		}
+	}
+	return s
+}
+
+// IsZero returns true if no batches are stressed and batches are not
+// lengthened, in which case the batch stress does not need to be stored.
+func (s BatchStress) IsZero() bool {
+	return s.StressedBatches == 0 && s.ExtraBlocks == 0
+}
+
+// EffectiveBatchBlocks returns the number of blocks of the bond's batches
+// given the batch stress, i.e. the bond's batch blocks plus the extra blocks.
+func (s BatchStress) EffectiveBatchBlocks(batchBlocks sdk.Uint) sdk.Uint {
+	return batchBlocks.Add(sdk.NewUint(s.ExtraBlocks))
+}
+
+// GetVolumePercentage returns the bond tokens bought and sold in a batch as a
+// percentage of the bond's supply before the batch. Zero is returned if the
+// supply was zero, since the volume cannot be expressed as a percentage.
+func GetVolumePercentage(bought, sold, supply sdk.Int) sdk.Dec {
+	if !supply.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	return bought.Add(sold).ToDec().Quo(supply.ToDec()).MulInt64(100)
+}
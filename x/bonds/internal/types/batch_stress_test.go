@@ -0,0 +1,59 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBatchStressUpdate(t *testing.T) {
+	stress := BatchStress{}
+
+	// First stressed batch does not lengthen batches (2 consecutive required)
+	stress = stress.Update(true, 2, 10, 25)
+	require.Equal(t, NewBatchStress(1, 0), stress)
+
+	// Second and further stressed batches lengthen batches up to the max
+	stress = stress.Update(true, 2, 10, 25)
+	require.Equal(t, NewBatchStress(2, 10), stress)
+	stress = stress.Update(true, 2, 10, 25)
+	require.Equal(t, NewBatchStress(3, 20), stress)
+	stress = stress.Update(true, 2, 10, 25)
+	require.Equal(t, NewBatchStress(4, 25), stress)
+
+	// Batches that are not stressed reset the count and halve the extra blocks
+	stress = stress.Update(false, 2, 10, 25)
+	require.Equal(t, NewBatchStress(0, 12), stress)
+	stress = stress.Update(false, 2, 10, 25)
+	require.Equal(t, NewBatchStress(0, 6), stress)
+	stress = stress.Update(false, 2, 10, 25)
+	stress = stress.Update(false, 2, 10, 25)
+	stress = stress.Update(false, 2, 10, 25)
+	require.Equal(t, NewBatchStress(0, 0), stress)
+	require.True(t, stress.IsZero())
+}
+
+func TestBatchStressEffectiveBatchBlocks(t *testing.T) {
+	require.Equal(t, sdk.NewUint(5), BatchStress{}.EffectiveBatchBlocks(sdk.NewUint(5)))
+	require.Equal(t, sdk.NewUint(15), NewBatchStress(2, 10).EffectiveBatchBlocks(sdk.NewUint(5)))
+}
+
+func TestGetVolumePercentage(t *testing.T) {
+	testCases := []struct {
+		bought   int64
+		sold     int64
+		supply   int64
+		expected sdk.Dec
+	}{
+		{0, 0, 100, sdk.ZeroDec()},
+		{10, 0, 100, sdk.NewDec(10)},
+		{10, 15, 100, sdk.NewDec(25)},
+		{300, 0, 100, sdk.NewDec(300)},
+		{10, 0, 0, sdk.ZeroDec()},
+	}
+	for _, tc := range testCases {
+		actual := GetVolumePercentage(sdk.NewInt(tc.bought),
+			sdk.NewInt(tc.sold), sdk.NewInt(tc.supply))
+		require.True(t, tc.expected.Equal(actual), actual.String())
+	}
+}
@@ -90,6 +90,73 @@ func TestNewBuyOrderDefaultValues(t *testing.T) {
 	require.Equal(t, maxPrices, order.MaxPrices)
 }
 
+func TestBuyOrderDenomsExceedingMaxPrices(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin("token1", 1000)
+	maxPrices := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100),
+		sdk.NewInt64Coin(reserveToken2, 200),
+	)
+	order := NewBuyOrder(address, amount, maxPrices)
+
+	testCases := []struct {
+		prices         sdk.Coins
+		expectedDenoms []string
+	}{
+		{sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 100),
+			sdk.NewInt64Coin(reserveToken2, 200),
+		), nil},
+		{sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 101),
+			sdk.NewInt64Coin(reserveToken2, 200),
+		), []string{reserveToken}},
+		{sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 100),
+			sdk.NewInt64Coin(reserveToken2, 201),
+		), []string{reserveToken2}},
+		{sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 101),
+			sdk.NewInt64Coin(reserveToken2, 201),
+		), []string{reserveToken, reserveToken2}},
+		{sdk.NewCoins(
+			sdk.NewInt64Coin("othertoken", 1),
+		), []string{"othertoken"}},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expectedDenoms, order.DenomsExceedingMaxPrices(tc.prices))
+	}
+}
+
+func TestRankBuysByPriority(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin("token1", 1000)
+	newBuyOrder := func(priorityFee sdk.Coins) BuyOrder {
+		order := NewBuyOrder(address, amount, nil)
+		order.PriorityFee = priorityFee
+		return order
+	}
+	cancelled := newBuyOrder(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)))
+	cancelled.Cancelled = true
+
+	buys := []BuyOrder{
+		newBuyOrder(nil),
+		newBuyOrder(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5))),
+		cancelled,
+		newBuyOrder(nil),
+		newBuyOrder(sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 2),
+			sdk.NewInt64Coin(reserveToken2, 3),
+		)),
+		newBuyOrder(sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 7))),
+	}
+
+	// Highest priority first, ties (5 and 2+3, no fees) broken by arrival
+	require.Equal(t, sdk.NewInt(5), buys[4].Priority())
+	require.Equal(t, []int{5, 1, 4, 0, 3}, RankBuysByPriority(buys))
+	require.Empty(t, RankBuysByPriority(nil))
+}
+
 func TestNewSellOrderDefaultValues(t *testing.T) {
 	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	amount := sdk.NewInt64Coin("token", 1000)
@@ -113,3 +180,51 @@ func TestNewSwapOrderDefaultValues(t *testing.T) {
 	require.False(t, order.Cancelled)
 	require.Empty(t, order.CancelReason)
 }
+
+func TestNewBatchResult(t *testing.T) {
+	token := "token"
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin("res", 1000))
+
+	batch := NewBatch(token, sdk.OneUint())
+	batch.BuyPrices = sdk.NewDecCoins(sdk.NewInt64DecCoin("res", 10))
+	batch.SellPrices = sdk.NewDecCoins(sdk.NewInt64DecCoin("res", 9))
+	batch.Buys = []BuyOrder{
+		NewBuyOrder(address, sdk.NewInt64Coin(token, 10), maxPrices),
+		NewBuyOrder(address, sdk.NewInt64Coin(token, 20), maxPrices),
+	}
+	batch.Sells = []SellOrder{
+		NewSellOrder(address, sdk.NewInt64Coin(token, 5)),
+	}
+	batch.Swaps = []SwapOrder{
+		NewSwapOrder(address, sdk.NewInt64Coin("res", 100), "res2"),
+		NewSwapOrder(address, sdk.NewInt64Coin("res", 200), "res2"),
+	}
+
+	// Set refund of first buy (the second buy's refund is not counted)
+	batch.Buys[0].Refund = sdk.NewCoins(sdk.NewInt64Coin("res", 900))
+	batch.Buys[1].Refund = sdk.NewCoins(sdk.NewInt64Coin("res", 800))
+
+	// Cancel second buy and first swap
+	batch.Buys[1].Cancelled = true
+	batch.Buys[1].CancelReason = "buy reason"
+	batch.Swaps[0].Cancelled = true
+	batch.Swaps[0].CancelReason = "swap reason"
+
+	result := NewBatchResult(batch, 100)
+
+	require.Equal(t, token, result.Token)
+	require.Equal(t, int64(100), result.Height)
+	require.Equal(t, batch.BuyPrices, result.BuyPrices)
+	require.Equal(t, batch.SellPrices, result.SellPrices)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), result.TotalBought)
+	require.Equal(t, sdk.NewInt64Coin(token, 5), result.TotalSold)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("res", 200)), result.TotalSwapped)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("res", 900)), result.TotalRefunded)
+	require.Equal(t, []CancelledOrder{
+		NewCancelledOrder(AttributeValueBuyOrder, batch.Buys[1].BaseOrder),
+		NewCancelledOrder(AttributeValueSwapOrder, batch.Swaps[0].BaseOrder),
+	}, result.CancelledOrders)
+	require.Equal(t, "buy reason", result.CancelledOrders[0].CancelReason)
+	require.Equal(t, "swap reason", result.CancelledOrders[1].CancelReason)
+}
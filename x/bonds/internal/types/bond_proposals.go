@@ -0,0 +1,161 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"strings"
+)
+
+const (
+	BondProposalTypeText    = "text"
+	BondProposalTypeFunding = "funding"
+
+	BondProposalStatusVoting   = "VOTING"
+	BondProposalStatusPassed   = "PASSED"
+	BondProposalStatusRejected = "REJECTED"
+	BondProposalStatusFailed   = "FAILED"
+
+	VoteOptionYes = "yes"
+	VoteOptionNo  = "no"
+)
+
+// BondProposal is a proposal submitted to the holders of a bond's tokens, who
+// vote on it with a voting power equal to their bond token balance. A text
+// proposal has no effect other than recording the outcome of the vote, while
+// a funding proposal withdraws the funding amount from the bond's reserve and
+// sends it to the funding recipient if it passes. A funding proposal that
+// passes but cannot be executed (e.g. if the reserve is insufficient by then)
+// is marked as failed.
+type BondProposal struct {
+	ProposalID       uint64         `json:"proposal_id" yaml:"proposal_id"`
+	BondToken        string         `json:"bond_token" yaml:"bond_token"`
+	Proposer         sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	Title            string         `json:"title" yaml:"title"`
+	Description      string         `json:"description" yaml:"description"`
+	ProposalType     string         `json:"proposal_type" yaml:"proposal_type"`
+	FundingRecipient sdk.AccAddress `json:"funding_recipient" yaml:"funding_recipient"`
+	FundingAmount    sdk.Coins      `json:"funding_amount" yaml:"funding_amount"`
+	VotingEndHeight  int64          `json:"voting_end_height" yaml:"voting_end_height"`
+	Status           string         `json:"status" yaml:"status"`
+	YesVotes         sdk.Int        `json:"yes_votes" yaml:"yes_votes"`
+	NoVotes          sdk.Int        `json:"no_votes" yaml:"no_votes"`
+}
+
+func NewBondProposal(proposalID uint64, bondToken string, proposer sdk.AccAddress,
+	title, description, proposalType string, fundingRecipient sdk.AccAddress,
+	fundingAmount sdk.Coins, votingEndHeight int64) BondProposal {
+	return BondProposal{
+		ProposalID:       proposalID,
+		BondToken:        bondToken,
+		Proposer:         proposer,
+		Title:            title,
+		Description:      description,
+		ProposalType:     proposalType,
+		FundingRecipient: fundingRecipient,
+		FundingAmount:    fundingAmount,
+		VotingEndHeight:  votingEndHeight,
+		Status:           BondProposalStatusVoting,
+		YesVotes:         sdk.ZeroInt(),
+		NoVotes:          sdk.ZeroInt(),
+	}
+}
+
+// IsVoting returns true if the proposal is still in its voting period.
+func (p BondProposal) IsVoting() bool {
+	return p.Status == BondProposalStatusVoting
+}
+
+// IsDue returns true if the proposal should be tallied at the specified height.
+func (p BondProposal) IsDue(height int64) bool {
+	return p.IsVoting() && height >= p.VotingEndHeight
+}
+
+// Passes returns true if the votes cast make up at least the quorum (a
+// percentage) of the supply and the majority of the votes cast are yes votes.
+func (p BondProposal) Passes(supply sdk.Int, quorum sdk.Dec) bool {
+	totalVotes := p.YesVotes.Add(p.NoVotes)
+	minVotes := supply.ToDec().Mul(quorum).QuoInt64(100)
+	return totalVotes.ToDec().GTE(minVotes) && p.YesVotes.GT(p.NoVotes)
+}
+
+// BondProposalVote is a vote cast on a bond proposal. The voter's bond tokens
+// (i.e. the voting power) are held by the bonds module until the proposal is
+// tallied, so that the same tokens cannot be used to vote more than once.
+type BondProposalVote struct {
+	ProposalID uint64         `json:"proposal_id" yaml:"proposal_id"`
+	Voter      sdk.AccAddress `json:"voter" yaml:"voter"`
+	Option     string         `json:"option" yaml:"option"`
+	Power      sdk.Int        `json:"power" yaml:"power"`
+}
+
+func NewBondProposalVote(proposalID uint64, voter sdk.AccAddress,
+	option string, power sdk.Int) BondProposalVote {
+	return BondProposalVote{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Option:     option,
+		Power:      power,
+	}
+}
+
+func (v BondProposalVote) String() string {
+	return fmt.Sprintf("%s voted %s on proposal %d with %s",
+		v.Voter, v.Option, v.ProposalID, v.Power)
+}
+
+// ValidateBondProposalContent checks that the content of a bond proposal is
+// valid. Only a funding proposal has (and requires) a recipient and amount.
+func ValidateBondProposalContent(title, description, proposalType string,
+	fundingRecipient sdk.AccAddress, fundingAmount sdk.Coins) error {
+	if strings.TrimSpace(title) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Title")
+	} else if strings.TrimSpace(description) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Description")
+	}
+
+	switch proposalType {
+	case BondProposalTypeText:
+		if !fundingRecipient.Empty() || !fundingAmount.Empty() {
+			return sdkerrors.Wrap(ErrInvalidBondProposalType,
+				"text proposal cannot have a funding recipient or amount")
+		}
+	case BondProposalTypeFunding:
+		if fundingRecipient.Empty() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "FundingRecipient")
+		} else if !fundingAmount.IsValid() || fundingAmount.Empty() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, fundingAmount.String())
+		}
+	default:
+		return sdkerrors.Wrap(ErrInvalidBondProposalType, proposalType)
+	}
+	return nil
+}
+
+// CheckVoteOption returns an error if the vote option is not yes or no.
+func CheckVoteOption(option string) error {
+	if option != VoteOptionYes && option != VoteOptionNo {
+		return sdkerrors.Wrap(ErrInvalidVoteOption, option)
+	}
+	return nil
+}
+
+// CheckBondAllowsBondProposal checks that the bond allows bond proposals and
+// that the funding amount (if any) only consists of the bond's reserve tokens.
+func CheckBondAllowsBondProposal(bond Bond, fundingAmount sdk.Coins) error {
+	if bond.ProposalVotingBlocks == 0 {
+		return sdkerrors.Wrap(ErrBondGovernanceDisabled, bond.Token)
+	}
+
+	reserveDenoms := make(map[string]bool)
+	for _, r := range bond.ReserveTokens {
+		reserveDenoms[r] = true
+	}
+	for _, c := range fundingAmount {
+		if !reserveDenoms[c.Denom] {
+			return sdkerrors.Wrapf(ErrTokenIsNotAValidReserveToken,
+				"%s is not a reserve token of %s", c.Denom, bond.Token)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// A bond's state follows a simple state machine. An augmented bond starts in
+// the HATCH state and every other bond starts in the OPEN state. A bond in the
+// HATCH state moves to the OPEN state once its initial raise is complete, and
+// a bond in either state can be settled (SETTLE) or can fail (FAILED) if it
+// misses its raise. SETTLE and FAILED are final, since holders then withdraw
+// their share of the reserve, so no state can be reached from either of them.
+//
+// The actions that can be performed on a bond depend on its state only, and
+// are listed in bondStateActions. Any other checks (e.g. whether the bond
+// allows sells at all) are made separately.
+
+const (
+	ActionBuy            = "buy"
+	ActionSell           = "sell"
+	ActionSwap           = "swap"
+	ActionOutcomePayment = "outcome_payment"
+	ActionWithdrawShare  = "withdraw_share"
+	ActionWithdrawFunds  = "withdraw_funds"
+)
+
+var bondStateTransitions = map[string][]string{
+	HatchState:  {OpenState, SettleState, FailedState},
+	OpenState:   {SettleState, FailedState},
+	SettleState: {},
+	FailedState: {},
+}
+
+var bondStateActions = map[string][]string{
+	HatchState:  {ActionBuy},
+	OpenState:   {ActionBuy, ActionSell, ActionSwap, ActionOutcomePayment, ActionWithdrawFunds},
+	SettleState: {ActionWithdrawShare},
+	FailedState: {ActionWithdrawShare},
+}
+
+// IsValidBondState returns true if the state is one of the bond states.
+func IsValidBondState(state string) bool {
+	_, ok := bondStateTransitions[state]
+	return ok
+}
+
+// IsFinalBondState returns true if no other state can be reached from the
+// state, i.e. if the bond has been settled or has failed.
+func IsFinalBondState(state string) bool {
+	return IsValidBondState(state) && len(bondStateTransitions[state]) == 0
+}
+
+// CheckBondStateTransition returns an error if a bond in the from state cannot
+// move to the to state.
+func CheckBondStateTransition(from, to string) error {
+	if !IsValidBondState(to) {
+		return sdkerrors.Wrapf(ErrInvalidBondState, "%s is not a bond state", to)
+	}
+	for _, s := range bondStateTransitions[from] {
+		if s == to {
+			return nil
+		}
+	}
+	return sdkerrors.Wrapf(ErrInvalidBondState, "cannot move from %s to %s", from, to)
+}
+
+// CheckStateAllows returns an error if the action cannot be performed on the
+// bond in its current state.
+func (bond Bond) CheckStateAllows(action string) error {
+	for _, a := range bondStateActions[bond.State] {
+		if a == action {
+			return nil
+		}
+	}
+	return sdkerrors.Wrapf(ErrInvalidStateForAction, "%s is not allowed in state %s", action, bond.State)
+}
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCheckBondStateTransition(t *testing.T) {
+	testCases := []struct {
+		from    string
+		to      string
+		isValid bool
+	}{
+		{HatchState, OpenState, true},
+		{HatchState, SettleState, true},
+		{HatchState, FailedState, true},
+		{OpenState, SettleState, true},
+		{OpenState, FailedState, true},
+		{OpenState, HatchState, false},
+		{OpenState, OpenState, false},
+		{SettleState, OpenState, false},
+		{SettleState, FailedState, false},
+		{FailedState, SettleState, false},
+		{OpenState, "some_other_state", false},
+		{"some_other_state", OpenState, false},
+	}
+	for _, tc := range testCases {
+		err := CheckBondStateTransition(tc.from, tc.to)
+		if tc.isValid {
+			require.Nil(t, err, "%s to %s", tc.from, tc.to)
+		} else {
+			require.Error(t, err, "%s to %s", tc.from, tc.to)
+			require.True(t, ErrInvalidBondState.Is(err))
+		}
+	}
+}
+
+func TestIsFinalBondState(t *testing.T) {
+	require.False(t, IsFinalBondState(HatchState))
+	require.False(t, IsFinalBondState(OpenState))
+	require.True(t, IsFinalBondState(SettleState))
+	require.True(t, IsFinalBondState(FailedState))
+	require.False(t, IsFinalBondState("some_other_state"))
+}
+
+func TestBondCheckStateAllows(t *testing.T) {
+	testCases := []struct {
+		state   string
+		allowed []string
+	}{
+		{HatchState, []string{ActionBuy}},
+		{OpenState, []string{ActionBuy, ActionSell, ActionSwap,
+			ActionOutcomePayment, ActionWithdrawFunds}},
+		{SettleState, []string{ActionWithdrawShare}},
+		{FailedState, []string{ActionWithdrawShare}},
+		{"some_other_state", nil},
+	}
+	actions := []string{ActionBuy, ActionSell, ActionSwap,
+		ActionOutcomePayment, ActionWithdrawShare, ActionWithdrawFunds}
+	for _, tc := range testCases {
+		bond := Bond{State: tc.state}
+		for _, action := range actions {
+			allowed := false
+			for _, a := range tc.allowed {
+				allowed = allowed || a == action
+			}
+			err := bond.CheckStateAllows(action)
+			if allowed {
+				require.Nil(t, err, "%s in %s", action, tc.state)
+			} else {
+				require.Error(t, err, "%s in %s", action, tc.state)
+				require.True(t, ErrInvalidStateForAction.Is(err))
+			}
+		}
+	}
+}
+
+func TestValidateGenesisRejectsInvalidBondState(t *testing.T) {
+	genesis := DefaultGenesisState()
+	genesis.Bonds = []Bond{{Token: "abc", State: OpenState}}
+	require.Nil(t, ValidateGenesis(genesis))
+
+	genesis.Bonds = []Bond{{Token: "abc", State: "some_other_state"}}
+	require.Error(t, ValidateGenesis(genesis))
+}
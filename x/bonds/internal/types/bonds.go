@@ -5,20 +5,28 @@ import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"math/big"
 	"sort"
 )
 
 const (
-	PowerFunction     = "power_function"
-	SigmoidFunction   = "sigmoid_function"
-	SwapperFunction   = "swapper_function"
-	AugmentedFunction = "augmented_function"
+	PowerFunction       = "power_function"
+	SigmoidFunction     = "sigmoid_function"
+	SwapperFunction     = "swapper_function"
+	AugmentedFunction   = "augmented_function"
+	ExponentialFunction = "exponential_function"
+	LogarithmicFunction = "logarithmic_function"
+	PolynomialFunction  = "polynomial_function"
+	BancorFunction      = "bancor_function"
+	PiecewiseFunction   = "piecewise_function"
+
+	WeightedSwapperFunction = "weighted_swapper_function"
+	StableSwapFunction      = "stable_swap_function"
 
 	HatchState  = "HATCH"
 	OpenState   = "OPEN"
 	SettleState = "SETTLE"
-
-	DoNotModifyField = "[do-not-modify]"
+	FailedState = "FAILED"
 
 	AnyNumberOfReserveTokens = -1
 )
@@ -27,30 +35,68 @@ type FunctionParamRestrictions func(paramsMap map[string]sdk.Dec) error
 
 var (
 	RequiredParamsForFunctionType = map[string][]string{
-		PowerFunction:     {"m", "n", "c"},
-		SigmoidFunction:   {"a", "b", "c"},
-		SwapperFunction:   nil,
-		AugmentedFunction: {"d0", "p0", "theta", "kappa"},
+		PowerFunction:       {"m", "n", "c"},
+		SigmoidFunction:     {"a", "b", "c"},
+		SwapperFunction:     nil,
+		AugmentedFunction:   {"d0", "p0", "theta", "kappa"},
+		ExponentialFunction: {"a", "b"},
+		LogarithmicFunction: {"a", "b"},
+		PolynomialFunction:  nil, // variable, see PolynomialCoefficients
+		BancorFunction:      {"p0", "s0", "cw"},
+		PiecewiseFunction:   nil, // see CurveSegment
+
+		WeightedSwapperFunction: nil, // variable, see WeightedSwapperWeights
+		StableSwapFunction:      {"A"},
 	}
 
 	NoOfReserveTokensForFunctionType = map[string]int{
-		PowerFunction:     AnyNumberOfReserveTokens,
-		SigmoidFunction:   AnyNumberOfReserveTokens,
-		SwapperFunction:   2,
-		AugmentedFunction: AnyNumberOfReserveTokens,
+		PowerFunction:       AnyNumberOfReserveTokens,
+		SigmoidFunction:     AnyNumberOfReserveTokens,
+		SwapperFunction:     2,
+		AugmentedFunction:   AnyNumberOfReserveTokens,
+		ExponentialFunction: AnyNumberOfReserveTokens,
+		LogarithmicFunction: AnyNumberOfReserveTokens,
+		PolynomialFunction:  AnyNumberOfReserveTokens,
+		BancorFunction:      AnyNumberOfReserveTokens,
+		PiecewiseFunction:   AnyNumberOfReserveTokens,
+
+		WeightedSwapperFunction: AnyNumberOfReserveTokens, // see CheckWeightedSwapperReserveTokens
+		StableSwapFunction:      2,
+	}
+
+	// IntegerParamsForFunctionType lists the parameters of each function
+	// type that must be integers, which is checked against their exact values
+	IntegerParamsForFunctionType = map[string][]string{
+		PowerFunction:      {"n"},
+		AugmentedFunction:  {"d0", "kappa"},
+		StableSwapFunction: {"A"},
 	}
 
 	ExtraParameterRestrictions = map[string]FunctionParamRestrictions{
-		PowerFunction:     powerParameterRestrictions,
-		SigmoidFunction:   sigmoidParameterRestrictions,
-		SwapperFunction:   nil,
-		AugmentedFunction: augmentedParameterRestrictions,
+		PowerFunction:       powerParameterRestrictions,
+		SigmoidFunction:     sigmoidParameterRestrictions,
+		SwapperFunction:     nil,
+		AugmentedFunction:   augmentedParameterRestrictions,
+		ExponentialFunction: exponentialParameterRestrictions,
+		LogarithmicFunction: logarithmicParameterRestrictions,
+		PolynomialFunction:  polynomialParameterRestrictions,
+		BancorFunction:      bancorParameterRestrictions,
+		PiecewiseFunction:   nil,
+
+		WeightedSwapperFunction: weightedSwapperParameterRestrictions,
+		StableSwapFunction:      stableSwapParameterRestrictions,
 	}
 )
 
+// FunctionParam is a named parameter of a bond's function. Parameters that
+// cannot be represented exactly as an sdk.Dec (e.g. 1/3) can optionally also
+// hold their exact value as a fraction, in which case Value is the fraction
+// truncated to sdk.Precision decimal places. The fraction is used instead of
+// Value wherever the function can be evaluated exactly (see GetPricesAtSupply).
 type FunctionParam struct {
-	Param string  `json:"param" yaml:"param"`
-	Value sdk.Dec `json:"value" yaml:"value"`
+	Param    string    `json:"param" yaml:"param"`
+	Value    sdk.Dec   `json:"value" yaml:"value"`
+	Rational *Rational `json:"rational,omitempty" yaml:"rational,omitempty"`
 }
 
 func NewFunctionParam(param string, value sdk.Dec) FunctionParam {
@@ -60,31 +106,112 @@ func NewFunctionParam(param string, value sdk.Dec) FunctionParam {
 	}
 }
 
+// NewRationalFunctionParam returns a function parameter with the exact value
+// of the fraction, and with the fraction truncated to an sdk.Dec as its Value
+func NewRationalFunctionParam(param string, value Rational) FunctionParam {
+	return FunctionParam{
+		Param:    param,
+		Value:    value.Dec(),
+		Rational: &value,
+	}
+}
+
+// ExactValue returns the parameter's exact value as a fraction, which is
+// either its rational value (if any) or its Value, since every sdk.Dec is
+// itself an exact fraction.
+func (fp FunctionParam) ExactValue() Rational {
+	if fp.Rational != nil {
+		return *fp.Rational
+	}
+	return NewRationalFromDec(fp.Value)
+}
+
+// validateRational checks that the parameter's rational value, if any, is
+// valid and that its Value is the rational value truncated to an sdk.Dec.
+func (fp FunctionParam) validateRational() error {
+	if fp.Rational == nil {
+		return nil
+	} else if err := fp.Rational.Validate(); err != nil {
+		return sdkerrors.Wrap(err, fp.Param)
+	} else if !fp.Value.Equal(fp.Rational.Dec()) {
+		return sdkerrors.Wrapf(ErrInvalidFunctionParameter,
+			"%s value does not match rational value %s", fp.Param, fp.Rational)
+	}
+	return nil
+}
+
 type FunctionParams []FunctionParam
 
 func (fps FunctionParams) Validate(functionType string) error {
+	// Check the hard caps on the number of params and param name lengths
+	if len(fps) > MaxFunctionParams {
+		return sdkerrors.Wrapf(ErrIncorrectNumberOfFunctionParameters, "cannot have more than %d", MaxFunctionParams)
+	}
+	for _, fp := range fps {
+		if len(fp.Param) > MaxFunctionParamNameLength {
+			return sdkerrors.Wrapf(ErrArgumentTooLong, "function parameter name %s", fp.Param)
+		} else if fp.Value.IsNil() {
+			return sdkerrors.Wrap(ErrFunctionParameterMissingOrNonFloat, fp.Param)
+		}
+	}
+
 	// Come up with list of expected parameters
 	expectedParams, err := GetRequiredParamsForFunctionType(functionType)
 	if err != nil {
 		return err
 	}
 
+	// The polynomial function's parameters are its coefficients c0 to cN, so
+	// the expected parameters depend on the number of parameters
+	if functionType == PolynomialFunction {
+		if len(fps) == 0 {
+			return sdkerrors.Wrap(ErrIncorrectNumberOfFunctionParameters, "expected at least 1")
+		}
+		expectedParams = PolynomialCoefficients(len(fps))
+	}
+
+	// Similarly, the weighted swapper function's parameters are the weights
+	// w0 to wN of its reserve tokens
+	if functionType == WeightedSwapperFunction {
+		if len(fps) == 0 {
+			return sdkerrors.Wrap(ErrIncorrectNumberOfFunctionParameters, "expected at least 1")
+		}
+		expectedParams = WeightedSwapperWeights(len(fps))
+	}
+
 	// Check that number of params is as expected
 	if len(fps) != len(expectedParams) {
 		return sdkerrors.Wrapf(ErrIncorrectNumberOfFunctionParameters, "expected %d", len(expectedParams))
 	}
 
-	// Check that params match and all values are non-negative
+	// Check that any rational values are valid and match the Dec values
+	for _, fp := range fps {
+		if err := fp.validateRational(); err != nil {
+			return err
+		}
+	}
+
+	// Check that params match and all values are non-negative. The exact
+	// values are checked, since a tiny negative fraction truncates to zero.
 	paramsMap := fps.AsMap()
+	exactMap := fps.AsExactMap()
 	for _, p := range expectedParams {
-		val, ok := paramsMap[p]
+		_, ok := paramsMap[p]
 		if !ok {
 			return sdkerrors.Wrap(ErrFunctionParameterMissingOrNonFloat, p)
-		} else if val.IsNegative() {
+		} else if exactMap[p].IsNegative() {
 			return sdkerrors.Wrap(ErrArgumentCannotBeNegative, p)
 		}
 	}
 
+	// Check that params required to be integers are exactly integers, since
+	// a fraction can be within sdk.Precision decimal places of an integer
+	for _, p := range IntegerParamsForFunctionType[functionType] {
+		if !exactMap[p].IsInteger() {
+			return sdkerrors.Wrapf(ErrArgumentMustBeInteger, "FunctionParams:%s", p)
+		}
+	}
+
 	// Get extra function parameter restrictions
 	extraRestrictions, err := GetExceptionsForFunctionType(functionType)
 	if err != nil {
@@ -116,6 +243,42 @@ func (fps FunctionParams) AsMap() (paramsMap map[string]sdk.Dec) {
 	return paramsMap
 }
 
+// AsExactMap returns the exact value of each of the parameters (see
+// FunctionParam.ExactValue)
+func (fps FunctionParams) AsExactMap() (exactMap map[string]Rational) {
+	exactMap = make(map[string]Rational)
+	for _, fp := range fps {
+		exactMap[fp.Param] = fp.ExactValue()
+	}
+	return exactMap
+}
+
+// HasRational returns true if any of the parameters has a rational value
+func (fps FunctionParams) HasRational() bool {
+	for _, fp := range fps {
+		if fp.Rational != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (fps FunctionParams) Equal(fps2 FunctionParams) bool {
+	if len(fps) != len(fps2) {
+		return false
+	}
+	for i := range fps {
+		if fps[i].Param != fps2[i].Param || !fps[i].Value.Equal(fps2[i].Value) {
+			return false
+		} else if (fps[i].Rational == nil) != (fps2[i].Rational == nil) {
+			return false
+		} else if fps[i].Rational != nil && !fps[i].Rational.Equal(*fps2[i].Rational) {
+			return false
+		}
+	}
+	return true
+}
+
 func powerParameterRestrictions(paramsMap map[string]sdk.Dec) error {
 	// Power exception 1: n must be an integer, otherwise x^n loop does not work
 	val, ok := paramsMap["n"]
@@ -198,20 +361,54 @@ type Bond struct {
 	CurrentSupply          sdk.Coin         `json:"current_supply" yaml:"current_supply"`
 	CurrentReserve         sdk.Coins        `json:"current_reserve" yaml:"current_reserve"`
 	AllowSells             bool             `json:"allow_sells" yaml:"allow_sells"`
+	NonTransferable        bool             `json:"non_transferable" yaml:"non_transferable"`
+	RequireAttestation     bool             `json:"require_attestation" yaml:"require_attestation"`
 	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
 	BatchBlocks            sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
 	OutcomePayment         sdk.Coins        `json:"outcome_payment" yaml:"outcome_payment"`
 	State                  string           `json:"state" yaml:"state"`
+	NetSellCap             sdk.Coin         `json:"net_sell_cap" yaml:"net_sell_cap"`
+	NetSellCapPercentage   sdk.Dec          `json:"net_sell_cap_percentage" yaml:"net_sell_cap_percentage"`
+	CurveVersion           uint64           `json:"curve_version" yaml:"curve_version"`
+	Milestones             []Milestone      `json:"milestones" yaml:"milestones"`
+	MilestonesReached      uint64           `json:"milestones_reached" yaml:"milestones_reached"`
+	ProposalVotingBlocks   uint64           `json:"proposal_voting_blocks" yaml:"proposal_voting_blocks"`
+	EventAttributes        EventAttributes  `json:"event_attributes" yaml:"event_attributes"`
+	Translations           BondTranslations `json:"translations" yaml:"translations"`
+	DemurrageRate          sdk.Dec          `json:"demurrage_rate" yaml:"demurrage_rate"`
+	DemurrageIndex         sdk.Dec          `json:"demurrage_index" yaml:"demurrage_index"`
+	DemurrageHeight        int64            `json:"demurrage_height" yaml:"demurrage_height"`
+	AtMaxSupplyBehavior    string           `json:"at_max_supply_behavior" yaml:"at_max_supply_behavior"`
+	BuysClosed             bool             `json:"buys_closed" yaml:"buys_closed"`
+	QuoteDenom             string           `json:"quote_denom" yaml:"quote_denom"`
+	MinReserve             sdk.Coins        `json:"min_reserve" yaml:"min_reserve"`
+	MinReservePercentage   sdk.Dec          `json:"min_reserve_percentage" yaml:"min_reserve_percentage"`
+	SignerThreshold        SignerThreshold  `json:"signer_threshold" yaml:"signer_threshold"`
+	CurveSegments          []CurveSegment   `json:"curve_segments" yaml:"curve_segments"`
+	SoftCap                sdk.Coins        `json:"soft_cap" yaml:"soft_cap"`
+	RaiseDeadline          int64            `json:"raise_deadline" yaml:"raise_deadline"`
+	SoftCapReached         bool             `json:"soft_cap_reached" yaml:"soft_cap_reached"`
+
+	// paramsCache is not part of the bond's state, but is set by the keeper
+	// when the bond is read so that its function parameters are only parsed
+	// when they change (see FunctionParamsMap)
+	paramsCache *FunctionParamsCache
 }
 
 func NewBond(token, name, description string, creator sdk.AccAddress,
 	functionType string, functionParameters FunctionParams, reserveTokens []string,
 	txFeePercentage, exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress,
 	maxSupply sdk.Coin, orderQuantityLimits sdk.Coins, sanityRate,
-	sanityMarginPercentage sdk.Dec, allowSells bool, signers []sdk.AccAddress,
-	batchBlocks sdk.Uint, outcomePayment sdk.Coins, state string) Bond {
-
-	// Ensure tokens and coins are sorted
+	sanityMarginPercentage sdk.Dec, allowSells, nonTransferable,
+	requireAttestation bool, signers []sdk.AccAddress, batchBlocks sdk.Uint,
+	outcomePayment sdk.Coins, milestones []Milestone, proposalVotingBlocks uint64,
+	eventAttributes EventAttributes, state string) Bond {
+
+	// Ensure tokens and coins are sorted, keeping any weighted swapper
+	// weights with their reserve tokens
+	if functionType == WeightedSwapperFunction {
+		functionParameters = sortedWeightedSwapperWeights(functionParameters, reserveTokens)
+	}
 	sort.Strings(reserveTokens)
 	orderQuantityLimits = orderQuantityLimits.Sort()
 
@@ -233,10 +430,27 @@ func NewBond(token, name, description string, creator sdk.AccAddress,
 		CurrentSupply:          sdk.NewCoin(token, sdk.ZeroInt()),
 		CurrentReserve:         nil,
 		AllowSells:             allowSells,
+		NonTransferable:        nonTransferable,
+		RequireAttestation:     requireAttestation,
 		Signers:                signers,
 		BatchBlocks:            batchBlocks,
 		OutcomePayment:         outcomePayment,
 		State:                  state,
+		NetSellCap:             sdk.NewCoin(token, sdk.ZeroInt()),
+		NetSellCapPercentage:   sdk.ZeroDec(),
+		CurveVersion:           LatestCurveVersion,
+		Milestones:             milestones,
+		MilestonesReached:      0,
+		ProposalVotingBlocks:   proposalVotingBlocks,
+		EventAttributes:        eventAttributes,
+		DemurrageRate:          sdk.ZeroDec(),
+		DemurrageIndex:         sdk.OneDec(),
+		DemurrageHeight:        0,
+		AtMaxSupplyBehavior:    AtMaxSupplyAllowRebuys,
+		BuysClosed:             false,
+		QuoteDenom:             "",
+		MinReserve:             nil,
+		MinReservePercentage:   sdk.ZeroDec(),
 	}
 }
 
@@ -248,15 +462,56 @@ func (bond Bond) GetNewReserveDecCoins(amount sdk.Dec) (coins sdk.DecCoins) {
 	return coins
 }
 
+// WithFunctionParamsCache returns the bond with the cache used to look up its
+// parsed function parameters set.
+func (bond Bond) WithFunctionParamsCache(cache *FunctionParamsCache) Bond {
+	bond.paramsCache = cache
+	return bond
+}
+
+// FunctionParamsMap returns the bond's function parameters as a map, using a
+// cached copy if the bond has a cache and the parameters have already been
+// parsed. The returned map may be shared and must not be modified.
+func (bond Bond) FunctionParamsMap() map[string]sdk.Dec {
+	if bond.paramsCache == nil {
+		return bond.FunctionParameters.AsMap()
+	}
+	return bond.paramsCache.Get(bond.Token, bond.FunctionParameters)
+}
+
+// GetCurveVersion returns the version of the curve engine used to evaluate
+// the bond's function. Bonds created before curve versioning was introduced
+// have no version set and are evaluated using the original curve engine.
+func (bond Bond) GetCurveVersion() uint64 {
+	if bond.CurveVersion == 0 {
+		return CurveVersion1
+	}
+	return bond.CurveVersion
+}
+
 func (bond Bond) GetPricesAtSupply(supply sdk.Int) (result sdk.DecCoins, err error) {
 	if supply.IsNegative() {
 		panic(fmt.Sprintf("negative supply for bond %s", bond.Token))
 	}
 
-	args := bond.FunctionParameters.AsMap()
+	switch bond.GetCurveVersion() {
+	case CurveVersion1:
+		return bond.getPricesAtSupplyV1(supply)
+	default:
+		panic(fmt.Sprintf("unrecognized curve version %d for bond %s",
+			bond.CurveVersion, bond.Token))
+	}
+}
+
+func (bond Bond) getPricesAtSupplyV1(supply sdk.Int) (result sdk.DecCoins, err error) {
+	args := bond.FunctionParamsMap()
 	x := supply.ToDec()
 	switch bond.FunctionType {
 	case PowerFunction:
+		if bond.FunctionParameters.HasRational() {
+			result = bond.GetNewReserveDecCoins(bond.exactPowerFunctionPrice(supply))
+			break
+		}
 		m := args["m"]
 		n64 := args["n"].TruncateInt64() // enforced by powerParameterRestrictions
 		c := args["c"]
@@ -274,6 +529,31 @@ func (bond Bond) GetPricesAtSupply(supply sdk.Int) (result sdk.DecCoins, err err
 		}
 		result = bond.GetNewReserveDecCoins(
 			a.Mul(temp1.Quo(temp3).Add(sdk.OneDec())))
+	case ExponentialFunction:
+		price, err := exponentialFunctionPrice(args["a"], args["b"], x)
+		if err != nil {
+			return nil, err
+		}
+		result = bond.GetNewReserveDecCoins(price)
+	case LogarithmicFunction:
+		price, err := logarithmicFunctionPrice(args["a"], args["b"], x)
+		if err != nil {
+			return nil, err
+		}
+		result = bond.GetNewReserveDecCoins(price)
+	case PolynomialFunction:
+		result = bond.GetNewReserveDecCoins(polynomialFunctionPrice(args, x))
+	case BancorFunction:
+		price, err := bancorFunctionPrice(args["p0"], args["s0"], args["cw"], x)
+		if err != nil {
+			return nil, err
+		}
+		result = bond.GetNewReserveDecCoins(price)
+	case PiecewiseFunction:
+		result, err = bond.piecewisePricesAtSupply(supply)
+		if err != nil {
+			return nil, err
+		}
 	case AugmentedFunction:
 		// Note: during the hatch phase, this function returns the hatch price
 		// p0 even if the supply argument is greater than the initial supply S0
@@ -294,6 +574,10 @@ func (bond Bond) GetPricesAtSupply(supply sdk.Int) (result sdk.DecCoins, err err
 			panic("unrecognized bond state")
 		}
 	case SwapperFunction:
+		fallthrough
+	case WeightedSwapperFunction:
+		fallthrough
+	case StableSwapFunction:
 		return nil, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
 	default:
 		panic("unrecognized function type")
@@ -313,9 +597,23 @@ func (bond Bond) GetCurrentPricesPT(reserveBalances sdk.Coins) (sdk.DecCoins, er
 		fallthrough
 	case SigmoidFunction:
 		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		fallthrough
+	case PolynomialFunction:
+		fallthrough
+	case BancorFunction:
+		fallthrough
+	case PiecewiseFunction:
+		fallthrough
 	case AugmentedFunction:
 		return bond.GetPricesAtSupply(bond.CurrentSupply.Amount)
 	case SwapperFunction:
+		fallthrough
+	case WeightedSwapperFunction:
+		fallthrough
+	case StableSwapFunction:
 		return bond.GetPricesToMint(sdk.OneInt(), reserveBalances)
 	default:
 		panic("unrecognized function type")
@@ -327,10 +625,24 @@ func (bond Bond) ReserveAtSupply(supply sdk.Int) (result sdk.Dec) {
 		panic(fmt.Sprintf("negative supply for bond %s", bond.Token))
 	}
 
-	args := bond.FunctionParameters.AsMap()
+	switch bond.GetCurveVersion() {
+	case CurveVersion1:
+		return bond.reserveAtSupplyV1(supply)
+	default:
+		panic(fmt.Sprintf("unrecognized curve version %d for bond %s",
+			bond.CurveVersion, bond.Token))
+	}
+}
+
+func (bond Bond) reserveAtSupplyV1(supply sdk.Int) (result sdk.Dec) {
+	args := bond.FunctionParamsMap()
 	x := supply.ToDec()
 	switch bond.FunctionType {
 	case PowerFunction:
+		if bond.FunctionParameters.HasRational() {
+			result = bond.exactPowerFunctionReserve(supply)
+			break
+		}
 		m := args["m"]
 		n, n64 := args["n"], args["n"].TruncateInt64() // enforced by powerParameterRestrictions
 		c := args["c"]
@@ -356,11 +668,37 @@ func (bond Bond) ReserveAtSupply(supply sdk.Int) (result sdk.Dec) {
 		constant := a.Mul(approx)
 
 		result = temp5.Sub(constant)
+	case ExponentialFunction:
+		var err error
+		result, err = exponentialFunctionReserve(args["a"], args["b"], x)
+		if err != nil {
+			panic(err) // x is bounded by the max supply, checked at creation
+		}
+	case LogarithmicFunction:
+		var err error
+		result, err = logarithmicFunctionReserve(args["a"], args["b"], x)
+		if err != nil {
+			panic(err) // cannot happen, since x and b are not negative
+		}
+	case PolynomialFunction:
+		result = PolynomialCurveIntegral(args, x)
+	case BancorFunction:
+		var err error
+		result, err = bancorFunctionReserve(args["p0"], args["s0"], args["cw"], x)
+		if err != nil {
+			panic(err) // x is bounded by the max supply, checked at creation
+		}
+	case PiecewiseFunction:
+		result = bond.piecewiseReserveAtSupply(supply)
 	case AugmentedFunction:
 		kappa := args["kappa"].TruncateInt64()
 		V0 := args["V0"]
 		result = Reserve(x, kappa, V0)
 	case SwapperFunction:
+		fallthrough
+	case WeightedSwapperFunction:
+		fallthrough
+	case StableSwapFunction:
 		panic("invalid function for function type")
 	default:
 		panic("unrecognized function type")
@@ -374,6 +712,43 @@ func (bond Bond) ReserveAtSupply(supply sdk.Int) (result sdk.Dec) {
 	return result
 }
 
+// exactPowerFunctionPrice returns the power function's price m*x^n+c at the
+// supply x, evaluated exactly using the exact values of the function
+// parameters and truncated to an sdk.Dec only at the end. Other function types
+// involve square roots or fractional powers that cannot be evaluated exactly,
+// so they are always evaluated using the parameters' truncated Dec values.
+func (bond Bond) exactPowerFunctionPrice(supply sdk.Int) sdk.Dec {
+	args := bond.FunctionParameters.AsExactMap()
+	n := args["n"].Numerator.BigInt() // integer, enforced by Validate
+	xn := sdk.NewIntFromBigInt(new(big.Int).Exp(supply.BigInt(), n, nil))
+	return args["m"].MulInt(xn).Add(args["c"]).Dec()
+}
+
+// exactPowerFunctionReserve returns the power function's reserve
+// m*x^(n+1)/(n+1)+c*x at the supply x, evaluated exactly like the price
+func (bond Bond) exactPowerFunctionReserve(supply sdk.Int) sdk.Dec {
+	args := bond.FunctionParameters.AsExactMap()
+	n1 := args["n"].Numerator.AddRaw(1) // integer, enforced by Validate
+	xn1 := sdk.NewIntFromBigInt(new(big.Int).Exp(supply.BigInt(), n1.BigInt(), nil))
+	return args["m"].MulInt(xn1).QuoInt(n1).Add(args["c"].MulInt(supply)).Dec()
+}
+
+// HatchReserveAtSupply returns the reserve that an augmented bond in its
+// hatch phase is expected to hold at the supply, i.e. the fraction 1-theta of
+// the total raised at the hatch price p0, rounded up. It is evaluated exactly
+// if any of the function parameters has a rational value (e.g. theta of 1/3).
+func (bond Bond) HatchReserveAtSupply(supply sdk.Int) sdk.Int {
+	if bond.FunctionParameters.HasRational() {
+		args := bond.FunctionParameters.AsExactMap()
+		one := NewRational(sdk.OneInt(), sdk.OneInt())
+		return args["p0"].MulInt(supply).Mul(one.Sub(args["theta"])).Ceil()
+	}
+
+	args := bond.FunctionParamsMap()
+	totalRaise := args["p0"].Mul(supply.ToDec())
+	return totalRaise.Mul(sdk.OneDec().Sub(args["theta"])).Ceil().TruncateInt()
+}
+
 func (bond Bond) GetReserveDeltaForLiquidityDelta(mintOrBurn sdk.Int, reserveBalances sdk.Coins) sdk.DecCoins {
 	if mintOrBurn.IsNegative() {
 		panic(fmt.Sprintf("negative liquidity delta for bond %s", bond.Token))
@@ -386,24 +761,35 @@ func (bond Bond) GetReserveDeltaForLiquidityDelta(mintOrBurn sdk.Int, reserveBal
 		fallthrough
 	case SigmoidFunction:
 		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		fallthrough
+	case PolynomialFunction:
+		fallthrough
+	case BancorFunction:
+		fallthrough
+	case PiecewiseFunction:
+		fallthrough
 	case AugmentedFunction:
 		panic("invalid function for function type")
 	case SwapperFunction:
-		resToken1 := bond.ReserveTokens[0]
-		resToken2 := bond.ReserveTokens[1]
-		resBalance1 := reserveBalances.AmountOf(resToken1).ToDec()
-		resBalance2 := reserveBalances.AmountOf(resToken2).ToDec()
-
+		fallthrough
+	case WeightedSwapperFunction:
+		fallthrough
+	case StableSwapFunction:
 		// Using Uniswap formulae: x' = (1+-α)x = x +- Δx, where α = Δx/x
-		// Where x is any of the two reserve balances or the current supply
+		// Where x is any of the reserve balances or the current supply
 		// and x' is any of the updated reserve balances or the updated supply
 		// By making Δx subject of the formula: Δx = αx
 		alpha := mintOrBurn.ToDec().Quo(bond.CurrentSupply.Amount.ToDec())
 
-		result := sdk.DecCoins{
-			sdk.NewDecCoinFromDec(resToken1, alpha.Mul(resBalance1)),
-			sdk.NewDecCoinFromDec(resToken2, alpha.Mul(resBalance2)),
+		result := make(sdk.DecCoins, len(bond.ReserveTokens))
+		for i, r := range bond.ReserveTokens {
+			resBalance := reserveBalances.AmountOf(r).ToDec()
+			result[i] = sdk.NewDecCoinFromDec(r, alpha.Mul(resBalance))
 		}
+		result = result.Sort()
 		if result.IsAnyNegative() {
 			panic(fmt.Sprintf("negative reserve delta result for bond %s", bond.Token))
 		}
@@ -413,6 +799,32 @@ func (bond Bond) GetReserveDeltaForLiquidityDelta(mintOrBurn sdk.Int, reserveBal
 	}
 }
 
+// Reserve balances of curve-based bonds are expected to be exactly equal, so
+// no difference is tolerated by default when extracting the common balance.
+var CommonReserveBalanceTolerance = sdk.ZeroInt()
+
+// GetCommonReserveBalance returns the single balance shared by all of the
+// bond's reserve tokens. Reserve balances should all be equal given that we
+// are always applying the same additions/subtractions to all of them, so an
+// error is returned if any balance differs from the first by more than
+// CommonReserveBalanceTolerance, rather than pricing based on one of them.
+// An empty reserve has a common balance of zero.
+func (bond Bond) GetCommonReserveBalance(reserveBalances sdk.Coins) (sdk.Int, error) {
+	if reserveBalances.Empty() {
+		return sdk.ZeroInt(), nil
+	}
+
+	commonReserveBalance := reserveBalances.AmountOf(bond.ReserveTokens[0])
+	for _, r := range bond.ReserveTokens[1:] {
+		balance := reserveBalances.AmountOf(r)
+		if balance.Sub(commonReserveBalance).GT(CommonReserveBalanceTolerance) ||
+			commonReserveBalance.Sub(balance).GT(CommonReserveBalanceTolerance) {
+			return sdk.Int{}, sdkerrors.Wrap(ErrReserveBalancesNotEqual, reserveBalances.String())
+		}
+	}
+	return commonReserveBalance, nil
+}
+
 func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
 	if mint.IsNegative() {
 		panic(fmt.Sprintf("negative mint amount for bond %s", bond.Token))
@@ -422,7 +834,7 @@ func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.D
 
 	// If hatch phase for augmented function, use fixed p0 price
 	if bond.FunctionType == AugmentedFunction && bond.State == HatchState {
-		args := bond.FunctionParameters.AsMap()
+		args := bond.FunctionParamsMap()
 		if bond.State == HatchState {
 			price := args["p0"].Mul(mint.ToDec())
 			return bond.GetNewReserveDecCoins(price), nil
@@ -434,18 +846,23 @@ func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.D
 		fallthrough
 	case SigmoidFunction:
 		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		fallthrough
+	case PolynomialFunction:
+		fallthrough
+	case BancorFunction:
+		fallthrough
+	case PiecewiseFunction:
+		fallthrough
 	case AugmentedFunction:
-		var priceToMint sdk.Dec
 		result := bond.ReserveAtSupply(bond.CurrentSupply.Amount.Add(mint))
-		if reserveBalances.Empty() {
-			priceToMint = result
-		} else {
-			// Reserve balances should all be equal given that we are always
-			// applying the same additions/subtractions to all reserve balances.
-			// Thus we can pick the first reserve balance as the global balance.
-			commonReserveBalance := reserveBalances[0].Amount.ToDec()
-			priceToMint = result.Sub(commonReserveBalance)
+		commonReserveBalance, err := bond.GetCommonReserveBalance(reserveBalances)
+		if err != nil {
+			return nil, err
 		}
+		priceToMint := result.Sub(commonReserveBalance.ToDec())
 		if priceToMint.IsNegative() {
 			// Negative priceToMint means that the previous buyer overpaid
 			// to the point that the price for this buyer is covered. However,
@@ -454,6 +871,10 @@ func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.D
 		}
 		return bond.GetNewReserveDecCoins(priceToMint), nil
 	case SwapperFunction:
+		fallthrough
+	case WeightedSwapperFunction:
+		fallthrough
+	case StableSwapFunction:
 		if bond.CurrentSupply.Amount.IsZero() {
 			return nil, sdkerrors.Wrap(ErrFunctionRequiresNonZeroCurrentSupply, bond.CurrentSupply.Amount.String())
 		}
@@ -464,7 +885,7 @@ func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.D
 	// Note: fees have to be added to these prices to get actual prices
 }
 
-func (bond Bond) GetReturnsForBurn(burn sdk.Int, reserveBalances sdk.Coins) sdk.DecCoins {
+func (bond Bond) GetReturnsForBurn(burn sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
 	if burn.IsNegative() {
 		panic(fmt.Sprintf("negative burn amount for bond %s", bond.Token))
 	} else if reserveBalances.IsAnyNegative() {
@@ -476,28 +897,37 @@ func (bond Bond) GetReturnsForBurn(burn sdk.Int, reserveBalances sdk.Coins) sdk.
 		fallthrough
 	case SigmoidFunction:
 		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		fallthrough
+	case PolynomialFunction:
+		fallthrough
+	case BancorFunction:
+		fallthrough
+	case PiecewiseFunction:
+		fallthrough
 	case AugmentedFunction:
 		result := bond.ReserveAtSupply(bond.CurrentSupply.Amount.Sub(burn))
-
-		var reserveBalance sdk.Dec
-		if reserveBalances.Empty() {
-			reserveBalance = sdk.ZeroDec()
-		} else {
-			// Reserve balances should all be equal given that we are always
-			// applying the same additions/subtractions to all reserve balances.
-			// Thus we can pick the first reserve balance as the global balance.
-			reserveBalance = reserveBalances[0].Amount.ToDec()
+		commonReserveBalance, err := bond.GetCommonReserveBalance(reserveBalances)
+		if err != nil {
+			return nil, err
 		}
+		reserveBalance := commonReserveBalance.ToDec()
 
 		if result.GT(reserveBalance) {
 			panic("not enough reserve available for burn")
 		} else {
 			returnForBurn := reserveBalance.Sub(result)
-			return bond.GetNewReserveDecCoins(returnForBurn)
+			return bond.GetNewReserveDecCoins(returnForBurn), nil
 			// TODO: investigate possibility of negative returnForBurn
 		}
 	case SwapperFunction:
-		return bond.GetReserveDeltaForLiquidityDelta(burn, reserveBalances)
+		fallthrough
+	case WeightedSwapperFunction:
+		fallthrough
+	case StableSwapFunction:
+		return bond.GetReserveDeltaForLiquidityDelta(burn, reserveBalances), nil
 	default:
 		panic("unrecognized function type")
 	}
@@ -516,13 +946,27 @@ func (bond Bond) GetReturnsForSwap(from sdk.Coin, toToken string, reserveBalance
 		fallthrough
 	case SigmoidFunction:
 		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		fallthrough
+	case PolynomialFunction:
+		fallthrough
+	case BancorFunction:
+		fallthrough
+	case PiecewiseFunction:
+		fallthrough
 	case AugmentedFunction:
 		return nil, sdk.Coin{}, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
 	case SwapperFunction:
+		fallthrough
+	case WeightedSwapperFunction:
+		fallthrough
+	case StableSwapFunction:
 		// Check that from and to are reserve tokens
-		if from.Denom != bond.ReserveTokens[0] && from.Denom != bond.ReserveTokens[1] {
+		if !bond.IsReserveToken(from.Denom) {
 			return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, from.Denom)
-		} else if toToken != bond.ReserveTokens[0] && toToken != bond.ReserveTokens[1] {
+		} else if !bond.IsReserveToken(toToken) {
 			return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, toToken)
 		}
 
@@ -531,7 +975,9 @@ func (bond Bond) GetReturnsForSwap(from sdk.Coin, toToken string, reserveBalance
 		outRes := reserveBalances.AmountOf(toToken)
 
 		// Calculate fee to get the adjusted input amount
-		txFee = bond.GetTxFee(sdk.NewDecCoinFromCoin(from))
+		fees := bond.GetOrderFees(AttributeValueSwapOrder,
+			sdk.DecCoins{sdk.NewDecCoinFromCoin(from)})
+		txFee = sdk.NewCoin(from.Denom, fees.Total.AmountOf(from.Denom))
 		inAmt = inAmt.Sub(txFee.Amount) // adjusted input
 
 		// Check that at least 1 token is going in
@@ -539,11 +985,22 @@ func (bond Bond) GetReturnsForSwap(from sdk.Coin, toToken string, reserveBalance
 			return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
 		}
 
-		// Calculate output amount using Uniswap formula: Δy = (Δx*y)/(x+Δx)
+		// Calculate output amount using Uniswap formula: Δy = (Δx*y)/(x+Δx),
+		// or its generalisation to weighted reserve tokens, or the stableswap
+		// invariant for reserve tokens of like value
 		outAmt := inAmt.Mul(outRes).Quo(inRes.Add(inAmt))
+		if bond.FunctionType == WeightedSwapperFunction {
+			outAmt, err = weightedSwapReturn(inAmt, inRes, outRes,
+				bond.weightOfReserveToken(from.Denom), bond.weightOfReserveToken(toToken))
+			if err != nil {
+				return nil, sdk.Coin{}, err
+			}
+		} else if bond.FunctionType == StableSwapFunction {
+			outAmt = stableSwapReturn(inAmt, inRes, outRes, bond.FunctionParamsMap()["A"])
+		}
 
 		// Check that not giving out all of the available outRes or nothing at all
-		if outAmt.Equal(outRes) {
+		if outAmt.GTE(outRes) {
 			return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountCausesReserveDepletion, "%s - %s", from.Denom, toToken)
 		} else if outAmt.IsZero() {
 			return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
@@ -557,9 +1014,104 @@ func (bond Bond) GetReturnsForSwap(from sdk.Coin, toToken string, reserveBalance
 	}
 }
 
+// GetBatchBuySellPrices returns the per-token buy and sell prices of a batch
+// given the reserve balances. Buys and sells are matched at the current price,
+// and any unmatched buys (sells) are priced along the curve.
+func (bond Bond) GetBatchBuySellPrices(batch Batch, reserveBalances sdk.Coins) (buyPricesPT, sellPricesPT sdk.DecCoins, err error) {
+	buyAmountDec := batch.TotalBuyAmount.Amount.ToDec()
+	sellAmountDec := batch.TotalSellAmount.Amount.ToDec()
+
+	currentPricesPT, err := bond.GetCurrentPricesPT(reserveBalances)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get (amount of) matched and (actual) curve-calculated value for the remaining amount
+	// - The matched amount is the least of the buys and sells (i.e. greatest common amount)
+	// - The curved values are the prices/returns for the extra unmatched buys/sells
+	var matchedAmount sdk.Dec
+	var curvedValues sdk.DecCoins
+	if batch.EqualBuysAndSells() {
+		// Since equal, both prices are current prices
+		return currentPricesPT, currentPricesPT, nil
+	} else if batch.MoreBuysThanSells() {
+		matchedAmount = sellAmountDec // since sells < buys, greatest common amount is sells
+		extraBuys := batch.TotalBuyAmount.Sub(batch.TotalSellAmount)
+		curvedValues, err = bond.GetPricesToMint(extraBuys.Amount, reserveBalances) // buy prices
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		matchedAmount = buyAmountDec // since buys < sells, greatest common amount is buys
+		extraSells := batch.TotalSellAmount.Sub(batch.TotalBuyAmount)
+		curvedValues, err = bond.GetReturnsForBurn(extraSells.Amount, reserveBalances) // sell returns
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Get (actual) matched values
+	matchedValues := MultiplyDecCoinsByDec(currentPricesPT, matchedAmount)
+
+	// If buys > sells, totalValues is the total buy prices
+	// If sells > buys, totalValues is the total sell returns
+	totalValues := matchedValues.Add(curvedValues...)
+
+	// Calculate buy and sell prices per token
+	if batch.MoreBuysThanSells() {
+		buyPricesPT = DivideDecCoinsByDec(totalValues, buyAmountDec)
+		sellPricesPT = currentPricesPT
+	} else {
+		buyPricesPT = currentPricesPT
+		sellPricesPT = DivideDecCoinsByDec(totalValues, sellAmountDec)
+	}
+	return buyPricesPT, sellPricesPT, nil
+}
+
+// GetCurrentPricesAfterBatch returns the per-token current prices that the bond
+// would have after the batch is performed, given the reserve balances. Buys and
+// sells are performed at the batch buy and sell prices, followed by the swaps
+// in the order that they were submitted, as is done at the end of a batch.
+func (bond Bond) GetCurrentPricesAfterBatch(batch Batch, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
+	buyPricesPT, sellPricesPT, err := bond.GetBatchBuySellPrices(batch, reserveBalances)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buys add their (rounded) prices to the reserve and sells take their
+	// (rounded) returns from the reserve, so only the net amount affects it
+	buyPrices := RoundReservePrices(MultiplyDecCoinsByInt(
+		buyPricesPT, batch.TotalBuyAmount.Amount))
+	sellReturns := RoundReserveReturns(MultiplyDecCoinsByInt(
+		sellPricesPT, batch.TotalSellAmount.Amount))
+	reserveBalances = reserveBalances.Add(buyPrices...).Sub(sellReturns)
+
+	bond.CurrentSupply = bond.CurrentSupply.
+		Add(batch.TotalBuyAmount).Sub(batch.TotalSellAmount)
+	bond.CurrentReserve = reserveBalances
+
+	// Swaps that would fail are skipped, since these would be cancelled
+	for _, so := range batch.Swaps {
+		if so.IsCancelled() {
+			continue
+		}
+		returns, txFee, err := bond.GetReturnsForSwap(so.Amount, so.ToToken, reserveBalances)
+		if err != nil {
+			continue
+		}
+		newReserveBalances := reserveBalances.Add(so.Amount.Sub(txFee)).Sub(returns)
+		if bond.ReservesViolateSanityRate(newReserveBalances) {
+			continue
+		}
+		reserveBalances = newReserveBalances
+		bond.CurrentReserve = reserveBalances
+	}
+
+	return bond.GetCurrentPricesPT(reserveBalances)
+}
+
 func (bond Bond) GetFee(reserveAmount sdk.DecCoin, percentage sdk.Dec) sdk.Coin {
-	feeAmount := percentage.QuoInt64(100).Mul(reserveAmount.Amount)
-	return RoundFee(sdk.NewDecCoinFromDec(reserveAmount.Denom, feeAmount))
+	return GetPercentageFee(reserveAmount, percentage)
 }
 
 func (bond Bond) GetTxFee(reserveAmount sdk.DecCoin) sdk.Coin {
@@ -571,10 +1123,7 @@ func (bond Bond) GetExitFee(reserveAmount sdk.DecCoin) sdk.Coin {
 }
 
 func (bond Bond) GetFees(reserveAmounts sdk.DecCoins, percentage sdk.Dec) (fees sdk.Coins) {
-	for _, r := range reserveAmounts {
-		fees = fees.Add(bond.GetFee(r, percentage))
-	}
-	return fees
+	return GetPercentageFees(reserveAmounts, percentage)
 }
 
 //noinspection GoNilness
@@ -616,10 +1165,41 @@ func (bond Bond) ReserveDenomsEqualTo(coins sdk.Coins) bool {
 	return true
 }
 
+func (bond Bond) IsReserveToken(denom string) bool {
+	for _, d := range bond.ReserveTokens {
+		if d == denom {
+			return true
+		}
+	}
+	return false
+}
+
 func (bond Bond) AnyOrderQuantityLimitsExceeded(amounts sdk.Coins) bool {
 	return amounts.IsAnyGT(bond.OrderQuantityLimits)
 }
 
+// GetNetSellCap returns the maximum net amount of bond tokens (i.e. sells minus
+// buys) that can be sold in a single batch, and whether a cap is set at all.
+// A zero cap or zero cap percentage means that the respective cap is not set.
+// If both are set, the lesser of the two caps applies.
+func (bond Bond) GetNetSellCap() (netSellCap sdk.Int, capped bool) {
+	if bond.NetSellCap.IsPositive() {
+		netSellCap = bond.NetSellCap.Amount
+		capped = true
+	}
+
+	if bond.NetSellCapPercentage.IsPositive() {
+		percentageCap := NewPercentage(bond.NetSellCapPercentage).AsFraction().MulInt(
+			bond.CurrentSupply.Amount).TruncateInt()
+		if !capped || percentageCap.LT(netSellCap) {
+			netSellCap = percentageCap
+			capped = true
+		}
+	}
+
+	return netSellCap, capped
+}
+
 func (bond Bond) ReservesViolateSanityRate(newReserves sdk.Coins) bool {
 
 	if bond.SanityRate.IsZero() {
@@ -627,23 +1207,44 @@ func (bond Bond) ReservesViolateSanityRate(newReserves sdk.Coins) bool {
 	}
 
 	// Get new rate from new balances
-	resToken1 := bond.ReserveTokens[0]
-	resToken2 := bond.ReserveTokens[1]
-	resBalance1 := newReserves.AmountOf(resToken1).ToDec()
-	resBalance2 := newReserves.AmountOf(resToken2).ToDec()
-	exchangeRate := resBalance1.Quo(resBalance2)
+	exchangeRate := bond.GetReservesExchangeRate(newReserves)
 
 	// Get max and min acceptable rates
-	sanityMarginDecimal := bond.SanityMarginPercentage.Quo(sdk.NewDec(100))
+	minRate, maxRate := bond.GetSanityRateBand()
+
+	return exchangeRate.LT(minRate) || exchangeRate.GT(maxRate)
+}
+
+// ReservesViolateOracleRate is the same as ReservesViolateSanityRate, except
+// that the band allowed by the sanity margin percentage is around the oracle
+// rate rather than around the bond's sanity rate.
+func (bond Bond) ReservesViolateOracleRate(oracleRate sdk.Dec, newReserves sdk.Coins) bool {
+	bond.SanityRate = oracleRate
+	return bond.ReservesViolateSanityRate(newReserves)
+}
+
+// GetReservesExchangeRate returns the rate of the first reserve token per
+// second reserve token implied by the reserve balances (i.e. t1 per t2).
+func (bond Bond) GetReservesExchangeRate(reserves sdk.Coins) sdk.Dec {
+	resBalance1 := reserves.AmountOf(bond.ReserveTokens[0]).ToDec()
+	resBalance2 := reserves.AmountOf(bond.ReserveTokens[1]).ToDec()
+	return resBalance1.Quo(resBalance2)
+}
+
+// GetSanityRateBand returns the min and max exchange rates allowed by the
+// sanity rate and sanity margin percentage, with the min rate being no less
+// than zero.
+func (bond Bond) GetSanityRateBand() (minRate, maxRate sdk.Dec) {
+	sanityMarginDecimal := NewPercentage(bond.SanityMarginPercentage).AsFraction()
 	upperPercentage := sdk.OneDec().Add(sanityMarginDecimal)
 	lowerPercentage := sdk.OneDec().Sub(sanityMarginDecimal)
-	maxRate := bond.SanityRate.Mul(upperPercentage)
-	minRate := bond.SanityRate.Mul(lowerPercentage)
+	maxRate = bond.SanityRate.Mul(upperPercentage)
+	minRate = bond.SanityRate.Mul(lowerPercentage)
 
 	// If min rate is negative, change to zero
 	if minRate.IsNegative() {
 		minRate = sdk.ZeroDec()
 	}
 
-	return exchangeRate.LT(minRate) || exchangeRate.GT(maxRate)
+	return minRate, maxRate
 }
@@ -74,6 +74,96 @@ func TestExtraParameterRestrictions_Sigmoid(t *testing.T) {
 	}
 }
 
+func TestExtraParameterRestrictions_Exponential(t *testing.T) {
+	paramRestrictions := ExtraParameterRestrictions[ExponentialFunction]
+
+	testCases := []struct {
+		a           string
+		b           string
+		expectError bool
+	}{
+		{"10", "0.001", false}, // positive values allowed for both
+		{"0", "0.001", true},   // zero not allowed for a
+		{"10", "0", true},      // zero not allowed for b
+	}
+
+	for _, tc := range testCases {
+		aDec := sdk.MustNewDecFromStr(tc.a)
+		bDec := sdk.MustNewDecFromStr(tc.b)
+		err := paramRestrictions(FunctionParams{
+			NewFunctionParam("a", aDec),
+			NewFunctionParam("b", bDec),
+		}.AsMap())
+
+		if tc.expectError {
+			require.Error(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
+func TestExtraParameterRestrictions_Logarithmic(t *testing.T) {
+	paramRestrictions := ExtraParameterRestrictions[LogarithmicFunction]
+
+	testCases := []struct {
+		a           string
+		b           string
+		expectError bool
+	}{
+		{"10", "0.001", false}, // positive values allowed for both
+		{"0", "0.001", true},   // zero not allowed for a
+		{"10", "0", true},      // zero not allowed for b
+	}
+
+	for _, tc := range testCases {
+		aDec := sdk.MustNewDecFromStr(tc.a)
+		bDec := sdk.MustNewDecFromStr(tc.b)
+		err := paramRestrictions(FunctionParams{
+			NewFunctionParam("a", aDec),
+			NewFunctionParam("b", bDec),
+		}.AsMap())
+
+		if tc.expectError {
+			require.Error(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
+func TestExtraParameterRestrictions_Bancor(t *testing.T) {
+	paramRestrictions := ExtraParameterRestrictions[BancorFunction]
+
+	testCases := []struct {
+		p0          string
+		s0          string
+		cw          string
+		expectError bool
+	}{
+		{"1", "1000", "0.5", false}, // positive values allowed for all
+		{"1", "1000", "1", false},   // cw of 1 allowed
+		{"0", "1000", "0.5", true},  // zero not allowed for p0
+		{"1", "0", "0.5", true},     // zero not allowed for s0
+		{"1", "1000", "0", true},    // zero not allowed for cw
+		{"1", "1000", "1.1", true},  // cw greater than 1 not allowed
+	}
+
+	for _, tc := range testCases {
+		err := paramRestrictions(FunctionParams{
+			NewFunctionParam("p0", sdk.MustNewDecFromStr(tc.p0)),
+			NewFunctionParam("s0", sdk.MustNewDecFromStr(tc.s0)),
+			NewFunctionParam("cw", sdk.MustNewDecFromStr(tc.cw)),
+		}.AsMap())
+
+		if tc.expectError {
+			require.Error(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
 func TestExtraParameterRestrictions_Augmented(t *testing.T) {
 	paramRestrictions := ExtraParameterRestrictions[AugmentedFunction]
 
@@ -166,13 +256,33 @@ func TestNewBondDefaultValuesAndSorting(t *testing.T) {
 		PowerFunction, functionParametersPower(), customReserveTokens,
 		initTxFeePercentage, initExitFeePercentage, initFeeAddress, initMaxSupply,
 		customOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initNonTransferable, initRequireAttestation, initSigners,
+		initBatchBlocks, initOutcomePayment, nil, 0, nil, initState)
 
 	expectedCurrentSupply := sdk.NewInt64Coin(bond.Token, 0)
 
 	require.Equal(t, expectedCurrentSupply, bond.CurrentSupply)
 	require.Equal(t, sortedReserveTokens, bond.ReserveTokens)
 	require.Equal(t, sortedOrderQuantityLimits, bond.OrderQuantityLimits)
+	require.Equal(t, LatestCurveVersion, bond.CurveVersion)
+}
+
+func TestGetCurveVersionDefaultsToCurveVersion1(t *testing.T) {
+	bond := getValidBond()
+
+	bond.CurveVersion = 0
+	require.Equal(t, CurveVersion1, bond.GetCurveVersion())
+
+	bond.CurveVersion = LatestCurveVersion
+	require.Equal(t, LatestCurveVersion, bond.GetCurveVersion())
+}
+
+func TestUnrecognizedCurveVersionPanics(t *testing.T) {
+	bond := getValidBond()
+	bond.CurveVersion = LatestCurveVersion + 1
+
+	require.Panics(t, func() { _, _ = bond.GetPricesAtSupply(sdk.NewInt(100)) })
+	require.Panics(t, func() { bond.ReserveAtSupply(sdk.NewInt(100)) })
 }
 
 func TestGetNewReserveCoinReturnPasses(t *testing.T) {
@@ -223,6 +333,26 @@ func TestGetPricesAtSupply(t *testing.T) {
 		// Sigmoid
 		{SigmoidFunction, functionParametersSigmoid(), multitokenReserve(),
 			sdk.NewInt(1000), OpenState, "5.999998484887893066", true},
+		// Exponential
+		{ExponentialFunction, functionParametersExponential(), multitokenReserve(),
+			sdk.NewInt(0), OpenState, "2", true},
+		{ExponentialFunction, functionParametersExponential(), multitokenReserve(),
+			sdk.NewInt(1000), OpenState, "5.436563656918090470", true},
+		// Logarithmic
+		{LogarithmicFunction, functionParametersLogarithmic(), multitokenReserve(),
+			sdk.NewInt(0), OpenState, "0", true},
+		{LogarithmicFunction, functionParametersLogarithmic(), multitokenReserve(),
+			sdk.NewInt(1000), OpenState, "6.931471805599453090", true},
+		// Polynomial
+		{PolynomialFunction, functionParametersPolynomial(), multitokenReserve(),
+			sdk.NewInt(0), OpenState, "100", true},
+		{PolynomialFunction, functionParametersPolynomial(), multitokenReserve(),
+			sdk.NewInt(1000), OpenState, "2600", true},
+		// Bancor
+		{BancorFunction, functionParametersBancor(), multitokenReserve(),
+			sdk.NewInt(0), OpenState, "0", true},
+		{BancorFunction, functionParametersBancor(), multitokenReserve(),
+			sdk.NewInt(2000), OpenState, "2", true},
 		// Augmented
 		{AugmentedFunction, functionParametersAugmentedFull(), multitokenReserve(),
 			sdk.NewInt(0), HatchState, "0.01", true},
@@ -264,6 +394,27 @@ func TestGetPricesAtSupply(t *testing.T) {
 	}
 }
 
+func TestPricesKeepFullDecPrecision(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionParameters = FunctionParams{
+		NewFunctionParam("m", sdk.MustNewDecFromStr("0.000000000001")),
+		NewFunctionParam("n", sdk.NewDec(1)),
+		NewFunctionParam("c", sdk.ZeroDec())}
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 1)
+
+	// Price per token is not truncated to fewer than sdk.Precision decimals
+	prices, err := bond.GetCurrentPricesPT(nil)
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("0.000000000001"), prices.AmountOf(reserveToken))
+
+	// Amounts charged are only rounded (up) when converted to whole units
+	mintPrices, err := bond.GetPricesToMint(sdk.OneInt(), nil)
+	require.Nil(t, err)
+	require.True(t, mintPrices.AmountOf(reserveToken).IsPositive())
+	require.True(t, mintPrices.AmountOf(reserveToken).LT(sdk.OneDec()))
+	require.Equal(t, sdk.OneInt(), RoundReservePrices(mintPrices).AmountOf(reserveToken))
+}
+
 func TestGetCurrentPrices(t *testing.T) {
 	bond := getValidBond()
 	// TODO: add more test cases
@@ -340,6 +491,26 @@ func TestReserveAtSupply(t *testing.T) {
 			"13043817825332782212.764456919596679543"},
 		{SigmoidFunction, functionParametersSigmoidHuge(), maxInt64,
 			"170141183460469231685570443531610226691.0"},
+		// Exponential
+		{ExponentialFunction, functionParametersExponential(), sdk.NewInt(0),
+			"0"},
+		{ExponentialFunction, functionParametersExponential(), sdk.NewInt(1000),
+			"3436.563656918090470000"},
+		// Logarithmic
+		{LogarithmicFunction, functionParametersLogarithmic(), sdk.NewInt(0),
+			"0"},
+		{LogarithmicFunction, functionParametersLogarithmic(), sdk.NewInt(1000),
+			"3862.943611198906180000"},
+		// Polynomial
+		{PolynomialFunction, functionParametersPolynomial(), sdk.NewInt(0),
+			"0"},
+		{PolynomialFunction, functionParametersPolynomial(), sdk.NewInt(1000),
+			"1016666.666666666666666666"},
+		// Bancor
+		{BancorFunction, functionParametersBancor(), sdk.NewInt(0),
+			"0"},
+		{BancorFunction, functionParametersBancor(), sdk.NewInt(2000),
+			"2000"},
 		// Augmented
 		{AugmentedFunction, functionParametersAugmentedFull(), sdk.NewInt(1),
 			"0.0000000000024"},
@@ -385,6 +556,60 @@ func TestGetReserveDeltaForLiquidityDelta(t *testing.T) {
 	}
 }
 
+func TestGetCommonReserveBalance(t *testing.T) {
+	bond := getValidBond()
+	bond.ReserveTokens = multitokenReserve()
+
+	testCases := []struct {
+		reserveBalances sdk.Coins
+		expectedBalance sdk.Int
+		fails           bool
+	}{
+		{nil, sdk.ZeroInt(), false},
+		{sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 10),
+			sdk.NewInt64Coin(reserveToken2, 10),
+		), sdk.NewInt(10), false},
+		{sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 10),
+			sdk.NewInt64Coin(reserveToken2, 11),
+		), sdk.Int{}, true},
+		{sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 11),
+			sdk.NewInt64Coin(reserveToken2, 10),
+		), sdk.Int{}, true},
+		{sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken2, 10),
+		), sdk.Int{}, true},
+	}
+	for _, tc := range testCases {
+		actualResult, err := bond.GetCommonReserveBalance(tc.reserveBalances)
+		if tc.fails {
+			require.Error(t, err)
+		} else {
+			require.Nil(t, err)
+			require.Equal(t, tc.expectedBalance, actualResult)
+		}
+	}
+}
+
+func TestGetPricesAndReturnsFailForUnequalReserveBalances(t *testing.T) {
+	bond := getValidBond()
+	bond.ReserveTokens = multitokenReserve()
+	bond.CurrentSupply = sdk.NewCoin(bond.Token, sdk.NewInt(10))
+
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 9999),
+	)
+
+	_, err := bond.GetPricesToMint(sdk.NewInt(1), reserveBalances)
+	require.Error(t, err)
+
+	_, err = bond.GetReturnsForBurn(sdk.NewInt(1), reserveBalances)
+	require.Error(t, err)
+}
+
 func TestGetPricesToMint(t *testing.T) {
 	bond := getValidBond()
 	// TODO: add more test cases
@@ -523,7 +748,8 @@ func TestGetReturnsForBurn(t *testing.T) {
 		bond.ReserveTokens = tc.reserveTokens
 		bond.CurrentSupply = sdk.NewCoin(bond.Token, tc.currentSupply)
 
-		actualResult := bond.GetReturnsForBurn(tc.amount, tc.reserveBalances)
+		actualResult, err := bond.GetReturnsForBurn(tc.amount, tc.reserveBalances)
+		require.Nil(t, err)
 		expectedDec := sdk.MustNewDecFromStr(tc.expectedReturn)
 		expectedResult := newDecMultitokenReserveFromDec(expectedDec)
 		require.Equal(t, expectedResult, actualResult)
@@ -792,6 +1018,35 @@ func TestAnyOrderQuantityLimitsExceeded(t *testing.T) {
 	}
 }
 
+func TestGetNetSellCap(t *testing.T) {
+	bond := getValidBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 1000)
+
+	testCases := []struct {
+		netSellCap           int64
+		netSellCapPercentage string
+		expectedCap          int64
+		expectedCapped       bool
+	}{
+		{0, "0", 0, false},     // no cap
+		{50, "0", 50, true},    // absolute cap only
+		{0, "10", 100, true},   // 10% of 1000
+		{0, "0.05", 0, true},   // 0.05% of 1000 rounded down
+		{50, "10", 50, true},   // absolute cap is lesser
+		{150, "10", 100, true}, // percentage cap is lesser
+	}
+	for _, tc := range testCases {
+		bond.NetSellCap = sdk.NewInt64Coin(bond.Token, tc.netSellCap)
+		bond.NetSellCapPercentage = sdk.MustNewDecFromStr(tc.netSellCapPercentage)
+
+		netSellCap, capped := bond.GetNetSellCap()
+		require.Equal(t, tc.expectedCapped, capped)
+		if capped {
+			require.Equal(t, tc.expectedCap, netSellCap.Int64())
+		}
+	}
+}
+
 func TestReservesViolateSanityRateReturnsFalseWhenSanityRateIsZero(t *testing.T) {
 	bond := getValidBond()
 
@@ -836,3 +1091,89 @@ func TestReservesViolateSanityRateReturnsFalseWhenSanityRateIsZero(t *testing.T)
 		require.Equal(t, tc.violates, actualResult)
 	}
 }
+
+func TestFunctionParamsValidateRationalValues(t *testing.T) {
+	third := NewRational(sdk.NewInt(1), sdk.NewInt(3))
+	tiny := NewRational(sdk.NewInt(-1), sdk.NewInt(1e18).MulRaw(10))
+	nearlyTwo := NewRational(sdk.NewInt(2e18).MulRaw(10).AddRaw(1), sdk.NewInt(1e18).MulRaw(10))
+
+	testCases := []struct {
+		m           FunctionParam
+		n           FunctionParam
+		expectError bool
+	}{
+		// Rational m
+		{NewRationalFunctionParam("m", third), NewFunctionParam("n", sdk.NewDec(2)), false},
+		// Rational integer n
+		{NewFunctionParam("m", sdk.NewDec(12)), NewRationalFunctionParam("n",
+			NewRational(sdk.NewInt(4), sdk.NewInt(2))), false},
+		// Value does not match the rational value
+		{FunctionParam{"m", sdk.NewDec(1), &third}, NewFunctionParam("n", sdk.NewDec(2)), true},
+		// Rational value is not reduced
+		{FunctionParam{"m", third.Dec(), &Rational{sdk.NewInt(2), sdk.NewInt(6)}},
+			NewFunctionParam("n", sdk.NewDec(2)), true},
+		// Negative m that truncates to a Dec of zero
+		{NewRationalFunctionParam("m", tiny), NewFunctionParam("n", sdk.NewDec(2)), true},
+		// Non-integer n that truncates to an integer Dec
+		{NewFunctionParam("m", sdk.NewDec(12)), NewRationalFunctionParam("n", nearlyTwo), true},
+	}
+	for i, tc := range testCases {
+		fps := FunctionParams{tc.m, tc.n, NewFunctionParam("c", sdk.NewDec(100))}
+		err := fps.Validate(PowerFunction)
+		if tc.expectError {
+			require.Error(t, err, "test case #%d", i)
+		} else {
+			require.Nil(t, err, "test case #%d", i)
+		}
+	}
+}
+
+func TestFunctionParamsEqualComparesRationalValues(t *testing.T) {
+	third := NewRational(sdk.NewInt(1), sdk.NewInt(3))
+	rational := FunctionParams{NewRationalFunctionParam("m", third)}
+	dec := FunctionParams{NewFunctionParam("m", third.Dec())}
+
+	require.True(t, rational.Equal(FunctionParams{NewRationalFunctionParam("m", third)}))
+	require.False(t, rational.Equal(dec))
+	require.False(t, dec.Equal(rational))
+}
+
+func TestPowerFunctionWithRationalParamsIsExact(t *testing.T) {
+	bond := getValidPowerFunctionBond()
+	third := NewRational(sdk.NewInt(1), sdk.NewInt(3))
+	supply := sdk.NewInt(3)
+
+	// Using the truncated Dec value of 1/3, precision is lost
+	bond.FunctionParameters = FunctionParams{
+		NewFunctionParam("m", third.Dec()),
+		NewFunctionParam("n", sdk.NewDec(2)),
+		NewFunctionParam("c", sdk.ZeroDec())}
+	prices, err := bond.GetPricesAtSupply(supply)
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("2.999999999999999997"), prices[0].Amount)
+
+	// Using the rational value, the price (1/3)*3^2 and the reserve
+	// (1/3)*3^3/3 are evaluated exactly
+	bond.FunctionParameters[0] = NewRationalFunctionParam("m", third)
+	prices, err = bond.GetPricesAtSupply(supply)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(3), prices[0].Amount)
+	require.Equal(t, sdk.NewDec(3), bond.ReserveAtSupply(supply))
+}
+
+func TestHatchReserveAtSupplyWithRationalTheta(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionType = AugmentedFunction
+	third := NewRational(sdk.NewInt(1), sdk.NewInt(3))
+	supply := sdk.NewInt(3)
+
+	// Using the truncated Dec value of 1/3, 3*3*(1-theta) rounds up to 7
+	bond.FunctionParameters = FunctionParams{
+		NewFunctionParam("p0", sdk.NewDec(3)),
+		NewFunctionParam("theta", third.Dec())}
+	require.Equal(t, sdk.NewInt(7), bond.HatchReserveAtSupply(supply))
+
+	// Using the rational value, 3*3*(2/3) is exactly 6
+	bond.FunctionParameters[1] = NewRationalFunctionParam("theta", third)
+	require.Equal(t, sdk.NewInt(6), bond.HatchReserveAtSupply(supply))
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"container/list"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Maximum number of bonds for which parsed function parameters are cached
+const DefaultFunctionParamsCacheSize = 1000
+
+type functionParamsCacheEntry struct {
+	token     string
+	params    FunctionParams
+	paramsMap map[string]sdk.Dec
+}
+
+// FunctionParamsCache is an LRU cache of parsed function parameter maps keyed
+// by bond token. An entry is only used if the parameters that it was parsed
+// from are equal to the bond's current parameters, so that changes to a bond's
+// parameters (e.g. the augmented function's transition to the open state)
+// never result in stale values being used.
+type FunctionParamsCache struct {
+	mtx      sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+func NewFunctionParamsCache(capacity int) *FunctionParamsCache {
+	return &FunctionParamsCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the parsed parameter map for the bond token, parsing and caching
+// it if not already cached or if the cached entry was parsed from parameters
+// other than fps. The returned map is shared and must not be modified.
+func (c *FunctionParamsCache) Get(token string, fps FunctionParams) map[string]sdk.Dec {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if elem, ok := c.entries[token]; ok {
+		entry := elem.Value.(*functionParamsCacheEntry)
+		if entry.params.Equal(fps) {
+			c.order.MoveToFront(elem)
+			return entry.paramsMap
+		}
+		c.order.Remove(elem)
+		delete(c.entries, token)
+	}
+
+	entry := &functionParamsCacheEntry{
+		token:     token,
+		params:    append(FunctionParams{}, fps...),
+		paramsMap: fps.AsMap(),
+	}
+	c.entries[token] = c.order.PushFront(entry)
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*functionParamsCacheEntry).token)
+	}
+
+	return entry.paramsMap
+}
+
+// Invalidate removes the cached parameter map for the bond token, if any.
+func (c *FunctionParamsCache) Invalidate(token string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if elem, ok := c.entries[token]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, token)
+	}
+}
+
+// Len returns the number of bonds for which parameters are cached.
+func (c *FunctionParamsCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.order.Len()
+}
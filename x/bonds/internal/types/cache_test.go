@@ -0,0 +1,116 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionParamsCacheReturnsParsedParams(t *testing.T) {
+	cache := NewFunctionParamsCache(DefaultFunctionParamsCacheSize)
+	fps := functionParametersPower()
+
+	paramsMap := cache.Get(token, fps)
+	require.Equal(t, fps.AsMap(), paramsMap)
+	require.Equal(t, 1, cache.Len())
+
+	// Second call returns the same (cached) params
+	paramsMap = cache.Get(token, fps)
+	require.Equal(t, fps.AsMap(), paramsMap)
+	require.Equal(t, 1, cache.Len())
+}
+
+func TestFunctionParamsCacheReparsesChangedParams(t *testing.T) {
+	cache := NewFunctionParamsCache(DefaultFunctionParamsCacheSize)
+	fps := functionParametersPower()
+	_ = cache.Get(token, fps)
+
+	// Changing the params (even in-place) should not return stale values
+	fps[0].Value = fps[0].Value.Add(sdk.OneDec())
+	paramsMap := cache.Get(token, fps)
+	require.Equal(t, fps.AsMap(), paramsMap)
+	require.Equal(t, 1, cache.Len())
+
+	// Replacing the params altogether should not return stale values
+	fps = functionParametersSigmoid()
+	paramsMap = cache.Get(token, fps)
+	require.Equal(t, fps.AsMap(), paramsMap)
+	require.Equal(t, 1, cache.Len())
+}
+
+func TestFunctionParamsCacheInvalidate(t *testing.T) {
+	cache := NewFunctionParamsCache(DefaultFunctionParamsCacheSize)
+	_ = cache.Get(token, functionParametersPower())
+	require.Equal(t, 1, cache.Len())
+
+	cache.Invalidate(token)
+	require.Equal(t, 0, cache.Len())
+
+	// Invalidating a token that is not cached has no effect
+	cache.Invalidate(token)
+	require.Equal(t, 0, cache.Len())
+}
+
+func TestFunctionParamsCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewFunctionParamsCache(2)
+	fps := functionParametersPower()
+
+	_ = cache.Get("token1", fps)
+	_ = cache.Get("token2", fps)
+	_ = cache.Get("token1", fps) // token1 is now the most recently used
+	_ = cache.Get("token3", fps) // evicts token2
+	require.Equal(t, 2, cache.Len())
+
+	_, found1 := cache.entries["token1"]
+	_, found2 := cache.entries["token2"]
+	_, found3 := cache.entries["token3"]
+	require.True(t, found1)
+	require.False(t, found2)
+	require.True(t, found3)
+}
+
+func TestFunctionParamsMapUsesBondCache(t *testing.T) {
+	bond := getValidBond()
+	require.Equal(t, bond.FunctionParameters.AsMap(), bond.FunctionParamsMap())
+
+	cache := NewFunctionParamsCache(DefaultFunctionParamsCacheSize)
+	bond = bond.WithFunctionParamsCache(cache)
+	require.Equal(t, bond.FunctionParameters.AsMap(), bond.FunctionParamsMap())
+	require.Equal(t, 1, cache.Len())
+}
+
+func BenchmarkFunctionParamsAsMap(b *testing.B) {
+	fps := functionParametersAugmentedFull()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = fps.AsMap()
+	}
+}
+
+func BenchmarkFunctionParamsCacheGet(b *testing.B) {
+	cache := NewFunctionParamsCache(DefaultFunctionParamsCacheSize)
+	fps := functionParametersAugmentedFull()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = cache.Get(token, fps)
+	}
+}
+
+func BenchmarkGetPricesToMint(b *testing.B) {
+	bond := getValidBond().WithFunctionParamsCache(
+		NewFunctionParamsCache(DefaultFunctionParamsCacheSize))
+	bond.ReserveTokens = multitokenReserve()
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 100)
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = bond.GetPricesToMint(sdk.NewInt(10), reserveBalances)
+	}
+}
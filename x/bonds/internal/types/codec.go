@@ -21,11 +21,31 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(&BuyOrder{}, "bonds/BuyOrder", nil)
 	cdc.RegisterConcrete(&SellOrder{}, "bonds/SellOrder", nil)
 	cdc.RegisterConcrete(&SwapOrder{}, "bonds/SwapOrder", nil)
+	cdc.RegisterConcrete(&CancelledOrder{}, "bonds/CancelledOrder", nil)
+	cdc.RegisterConcrete(&BatchResult{}, "bonds/BatchResult", nil)
+	cdc.RegisterConcrete(&BondCount{}, "bonds/BondCount", nil)
+	cdc.RegisterConcrete(&ModuleStats{}, "bonds/ModuleStats", nil)
 	cdc.RegisterConcrete(MsgCreateBond{}, "bonds/MsgCreateBond", nil)
 	cdc.RegisterConcrete(MsgEditBond{}, "bonds/MsgEditBond", nil)
 	cdc.RegisterConcrete(MsgBuy{}, "bonds/MsgBuy", nil)
 	cdc.RegisterConcrete(MsgSell{}, "bonds/MsgSell", nil)
+	cdc.RegisterConcrete(MsgSellByValue{}, "bonds/MsgSellByValue", nil)
 	cdc.RegisterConcrete(MsgSwap{}, "bonds/MsgSwap", nil)
 	cdc.RegisterConcrete(MsgMakeOutcomePayment{}, "bonds/MsgMakeOutcomePayment", nil)
 	cdc.RegisterConcrete(MsgWithdrawShare{}, "bonds/MsgWithdrawShare", nil)
+	cdc.RegisterConcrete(MsgAuthorizedTransfer{}, "bonds/MsgAuthorizedTransfer", nil)
+	cdc.RegisterConcrete(MsgScheduleParamChange{}, "bonds/MsgScheduleParamChange", nil)
+	cdc.RegisterConcrete(MsgCancelParamChange{}, "bonds/MsgCancelParamChange", nil)
+	cdc.RegisterConcrete(MsgSubmitBondProposal{}, "bonds/MsgSubmitBondProposal", nil)
+	cdc.RegisterConcrete(MsgVoteBondProposal{}, "bonds/MsgVoteBondProposal", nil)
+	cdc.RegisterConcrete(MsgSetBondTranslations{}, "bonds/MsgSetBondTranslations", nil)
+	cdc.RegisterConcrete(MsgSetSanityRate{}, "bonds/MsgSetSanityRate", nil)
+	cdc.RegisterConcrete(MsgRebalanceSwap{}, "bonds/MsgRebalanceSwap", nil)
+	cdc.RegisterConcrete(MsgSweepFeeDust{}, "bonds/MsgSweepFeeDust", nil)
+	cdc.RegisterConcrete(MsgRegisterNotifications{}, "bonds/MsgRegisterNotifications", nil)
+	cdc.RegisterConcrete(MsgUnregisterNotifications{}, "bonds/MsgUnregisterNotifications", nil)
+	cdc.RegisterConcrete(ClaimStuckFundsProposal{}, "bonds/ClaimStuckFundsProposal", nil)
+	cdc.RegisterConcrete(MigrateCurveVersionProposal{}, "bonds/MigrateCurveVersionProposal", nil)
+	cdc.RegisterConcrete(MigrateReserveTokenProposal{}, "bonds/MigrateReserveTokenProposal", nil)
+	registerDevCodec(cdc)
 }
@@ -26,6 +26,8 @@ var (
 	initSanityRate             = sdk.MustNewDecFromStr(blankSanityRate)
 	initSanityMarginPercentage = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
 	initAllowSell              = true
+	initNonTransferable        = false
+	initRequireAttestation     = false
 	initSigners                = []sdk.AccAddress{initCreator}
 	initBatchBlocks            = sdk.NewUint(10)
 	initOutcomePayment         = sdk.Coins(nil)
@@ -72,6 +74,44 @@ func functionParametersAugmentedFull() FunctionParams {
 	return append(base, extras...)
 }
 
+func functionParametersExponential() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("a", sdk.NewDec(2)),
+		NewFunctionParam("b", sdk.MustNewDecFromStr("0.001"))}
+}
+
+func functionParametersLogarithmic() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("a", sdk.NewDec(10)),
+		NewFunctionParam("b", sdk.MustNewDecFromStr("0.001"))}
+}
+
+func functionParametersPolynomial() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("c0", sdk.NewDec(100)),
+		NewFunctionParam("c1", sdk.MustNewDecFromStr("0.5")),
+		NewFunctionParam("c2", sdk.MustNewDecFromStr("0.002"))}
+}
+
+func functionParametersBancor() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("p0", sdk.OneDec()),
+		NewFunctionParam("s0", sdk.NewDec(1000)),
+		NewFunctionParam("cw", sdk.MustNewDecFromStr("0.5"))}
+}
+
+func functionParametersWeightedSwapper() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("w0", sdk.MustNewDecFromStr("0.5")),
+		NewFunctionParam("w1", sdk.MustNewDecFromStr("0.25")),
+		NewFunctionParam("w2", sdk.MustNewDecFromStr("0.25"))}
+}
+
+func functionParametersStableSwap() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("A", sdk.NewDec(100))}
+}
+
 func functionParametersPowerHuge() FunctionParams {
 	return FunctionParams{
 		NewFunctionParam("m", sdk.NewDec(1)),
@@ -90,6 +130,10 @@ func powerReserves() []string     { return []string{reserveToken} }
 func multitokenReserve() []string { return []string{reserveToken, reserveToken2} }
 func swapperReserves() []string   { return []string{reserveToken, reserveToken2} }
 
+func weightedSwapperReserves() []string {
+	return []string{reserveToken, reserveToken2, reserveToken3}
+}
+
 func getValidPowerFunctionBond() Bond {
 	functionType := PowerFunction
 	functionParams := functionParametersPower()
@@ -98,7 +142,8 @@ func getValidPowerFunctionBond() Bond {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initNonTransferable, initRequireAttestation, initSigners,
+		initBatchBlocks, initOutcomePayment, nil, 0, nil, initState)
 }
 
 func getValidBond() Bond {
@@ -131,7 +176,9 @@ func newValidMsgCreateBond() MsgCreateBond {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment)
+		initAllowSell, initNonTransferable, initRequireAttestation, initSigners,
+		initBatchBlocks, initOutcomePayment, nil, 0, nil, nil,
+		AtMaxSupplyAllowRebuys)
 }
 
 func newValidMsgCreateSwapperBond() MsgCreateBond {
@@ -142,16 +189,19 @@ func newValidMsgCreateSwapperBond() MsgCreateBond {
 	return validMsg
 }
 
-func newEmptyStringsMsgEditBond() MsgEditBond {
-	return NewMsgEditBond(initToken, "", "", "", "", "",
-		initCreator, initSigners)
-}
-
 func newValidMsgEditBond() MsgEditBond {
-	return NewMsgEditBond(initToken, "newName", "newDescription", "", "0", "0",
-		initCreator, initSigners)
+	msg := NewMsgEditBond(initToken, initCreator, initSigners)
+	msg.Name = stringPtr("newName")
+	msg.Description = stringPtr("newDescription")
+	return msg
 }
 
+func stringPtr(s string) *string                  { return &s }
+func decPtr(d sdk.Dec) *sdk.Dec                   { return &d }
+func boolPtr(b bool) *bool                        { return &b }
+func coinsPtr(c sdk.Coins) *sdk.Coins             { return &c }
+func addressPtr(a sdk.AccAddress) *sdk.AccAddress { return &a }
+
 func newValidMsgBuy() MsgBuy {
 	buyer := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	amount, _ := sdk.ParseCoin("10" + initToken)
@@ -165,8 +215,49 @@ func newValidMsgSell() MsgSell {
 	return NewMsgSell(seller, amount)
 }
 
+func newValidMsgSellByValue() MsgSellByValue {
+	seller := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	returns := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	maxAmount := sdk.NewInt64Coin(initToken, 10)
+	return NewMsgSellByValue(seller, returns, maxAmount)
+}
+
 func newValidMsgSwap() MsgSwap {
 	swapper := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	from := sdk.NewInt64Coin(reserveToken, 10)
 	return NewMsgSwap(swapper, initToken, from, reserveToken2)
 }
+
+func newValidMsgAuthorizedTransfer() MsgAuthorizedTransfer {
+	from := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	to := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin(initToken, 10)
+	return NewMsgAuthorizedTransfer(from, to, amount, "lost key recovery", initSigners)
+}
+
+func newValidMsgScheduleParamChange() MsgScheduleParamChange {
+	return NewMsgScheduleParamChange(initToken, functionParametersPower(),
+		100, false, initCreator, initSigners)
+}
+
+func newValidMsgCancelParamChange() MsgCancelParamChange {
+	return NewMsgCancelParamChange(initToken, initCreator, initSigners)
+}
+
+func newValidMsgSubmitBondProposal() MsgSubmitBondProposal {
+	fundingAmount := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	return NewMsgSubmitBondProposal(initToken, initCreator, "title",
+		"description", BondProposalTypeFunding, initFeeAddress, fundingAmount)
+}
+
+func newValidMsgVoteBondProposal() MsgVoteBondProposal {
+	return NewMsgVoteBondProposal(1, initCreator, VoteOptionYes)
+}
+
+func newValidMsgSetBondTranslations() MsgSetBondTranslations {
+	translations := BondTranslations{
+		NewBondTranslation("fr", "Nom", "Description"),
+		NewBondTranslation("pt-BR", "Nome", "Descrição"),
+	}
+	return NewMsgSetBondTranslations(initToken, translations, initCreator, initSigners)
+}
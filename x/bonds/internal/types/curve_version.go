@@ -0,0 +1,18 @@
+package types
+
+// Curve engine versions. Whenever a fix to the curve math would change the
+// prices or reserves of existing bonds, a new version is introduced instead of
+// modifying the existing evaluation path, so that bonds created under an older
+// version keep their prices until explicitly migrated (through governance).
+const (
+	// CurveVersion1 is the original curve engine
+	CurveVersion1 uint64 = 1
+
+	// LatestCurveVersion is the version under which new bonds are created
+	LatestCurveVersion = CurveVersion1
+)
+
+// IsValidCurveVersion returns true if the version is a known curve version.
+func IsValidCurveVersion(version uint64) bool {
+	return version >= CurveVersion1 && version <= LatestCurveVersion
+}
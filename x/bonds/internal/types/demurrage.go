@@ -0,0 +1,86 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ParseDemurrageRate parses a demurrage rate, i.e. the percentage of the
+// redemption value of a bond's tokens that decays every block, from a decimal
+// string. Unlike other percentages, the rate is not limited to a number of
+// decimal places, since meaningful per-block rates are very small, but it has
+// to be less than 100 so that the redemption value never decays completely.
+func ParseDemurrageRate(str string) (sdk.Dec, error) {
+	rate, err := sdk.NewDecFromStr(str)
+	if err != nil {
+		return sdk.Dec{}, sdkerrors.Wrap(ErrArgumentMissingOrNonFloat, str)
+	} else if err := ValidateDemurrageRate(rate); err != nil {
+		return sdk.Dec{}, err
+	}
+	return rate, nil
+}
+
+// ValidateDemurrageRate checks that the demurrage rate is at least 0 and less
+// than 100, as described for ParseDemurrageRate.
+func ValidateDemurrageRate(rate sdk.Dec) error {
+	if rate.IsNil() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "demurrage rate")
+	} else if rate.IsNegative() || rate.GTE(MaxPercentage) {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"demurrage rate %s must be at least 0 and less than %s", rate, MaxPercentage)
+	}
+	return nil
+}
+
+// GetDemurrageRate returns the bond's demurrage rate. Bonds created before
+// demurrage was introduced have no rate set and have no demurrage.
+func (bond Bond) GetDemurrageRate() sdk.Dec {
+	if bond.DemurrageRate.IsNil() {
+		return sdk.ZeroDec()
+	}
+	return bond.DemurrageRate
+}
+
+// GetDemurrageIndex returns the bond's demurrage index as of the last time
+// that it was updated. Bonds created before demurrage was introduced have no
+// index set, which is equivalent to an index of one. Since amino stores an
+// unset index as zero, which is never a valid index, zero is treated as unset.
+func (bond Bond) GetDemurrageIndex() sdk.Dec {
+	if bond.DemurrageIndex.IsNil() || bond.DemurrageIndex.IsZero() {
+		return sdk.OneDec()
+	}
+	return bond.DemurrageIndex
+}
+
+// GetDemurrageIndexAt returns the bond's demurrage index at the specified
+// block height, i.e. the fraction of the redemption value of the bond's tokens
+// that has not decayed. The index decays by the demurrage rate every block
+// since the index was last updated.
+func (bond Bond) GetDemurrageIndexAt(height int64) sdk.Dec {
+	index := bond.GetDemurrageIndex()
+	rate := bond.GetDemurrageRate()
+	if !rate.IsPositive() || height <= bond.DemurrageHeight {
+		return index
+	}
+
+	blocks := uint64(height - bond.DemurrageHeight)
+	decay := sdk.OneDec().Sub(NewPercentage(rate).AsFraction())
+	return index.Mul(decay.Power(blocks))
+}
+
+// GetDemurrageCharges returns the part of the reserve returns that has decayed
+// according to the demurrage index. Charges are rounded down so that the
+// account gets charged less.
+func (bond Bond) GetDemurrageCharges(reserveReturns sdk.Coins, index sdk.Dec) (charges sdk.Coins) {
+	decayed := sdk.OneDec().Sub(index)
+	if !decayed.IsPositive() {
+		return nil
+	}
+	for _, r := range reserveReturns {
+		charge := decayed.MulInt(r.Amount).TruncateInt()
+		if charge.IsPositive() {
+			charges = charges.Add(sdk.NewCoin(r.Denom, charge))
+		}
+	}
+	return charges
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseDemurrageRate(t *testing.T) {
+	testCases := []struct {
+		rate    string
+		isValid bool
+	}{
+		{"0", true},
+		{"0.000000000001", true},
+		{"99.9", true},
+		{"100", false},
+		{"-0.1", false},
+		{"abc", false},
+	}
+	for _, tc := range testCases {
+		_, err := ParseDemurrageRate(tc.rate)
+		if tc.isValid {
+			require.Nil(t, err, tc.rate)
+		} else {
+			require.NotNil(t, err, tc.rate)
+		}
+	}
+}
+
+func TestGetDemurrageIndexAt(t *testing.T) {
+	bond := getValidBond()
+	bond.DemurrageRate = sdk.NewDec(10)
+	bond.DemurrageHeight = 5
+
+	require.Equal(t, sdk.OneDec(), bond.GetDemurrageIndexAt(5))
+	require.Equal(t, sdk.MustNewDecFromStr("0.9"), bond.GetDemurrageIndexAt(6))
+	require.Equal(t, sdk.MustNewDecFromStr("0.729"), bond.GetDemurrageIndexAt(8))
+
+	// Bonds without demurrage do not decay
+	bond.DemurrageRate = sdk.Dec{}
+	bond.DemurrageIndex = sdk.Dec{}
+	require.Equal(t, sdk.OneDec(), bond.GetDemurrageIndexAt(100))
+}
+
+func TestGetDemurrageCharges(t *testing.T) {
+	bond := getValidBond()
+	returns := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1005))
+
+	// Decayed part is rounded down
+	charges := bond.GetDemurrageCharges(returns, sdk.MustNewDecFromStr("0.9"))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)), charges)
+
+	require.True(t, bond.GetDemurrageCharges(returns, sdk.OneDec()).IsZero())
+}
@@ -0,0 +1,120 @@
+//go:build testing
+// +build testing
+
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"strings"
+)
+
+// The developer messages below are only compiled into builds with the testing
+// build tag, so that local devnets and end-to-end test suites can fund
+// accounts with reserve tokens and fast-forward batches. Builds without the
+// tag (e.g. mainnet builds) cannot decode or handle these messages, so any
+// transaction containing them is rejected.
+
+const (
+	TypeMsgDevMintReserve  = "dev_mint_reserve"
+	TypeMsgDevAdvanceBatch = "dev_advance_batch"
+
+	// DevMessagesEnabled indicates whether the developer messages are
+	// compiled into this build
+	DevMessagesEnabled = true
+)
+
+func registerDevCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgDevMintReserve{}, "bonds/MsgDevMintReserve", nil)
+	cdc.RegisterConcrete(MsgDevAdvanceBatch{}, "bonds/MsgDevAdvanceBatch", nil)
+}
+
+// MsgDevMintReserve mints an amount of the bond's reserve tokens to the
+// sender, as a faucet that the sender can then use to buy from the bond.
+type MsgDevMintReserve struct {
+	Sender    sdk.AccAddress `json:"sender" yaml:"sender"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	Amount    sdk.Coins      `json:"amount" yaml:"amount"`
+}
+
+func NewMsgDevMintReserve(sender sdk.AccAddress, bondToken string, amount sdk.Coins) MsgDevMintReserve {
+	return MsgDevMintReserve{
+		Sender:    sender,
+		BondToken: bondToken,
+		Amount:    amount,
+	}
+}
+
+func (msg MsgDevMintReserve) ValidateBasic() error {
+	// Check if empty
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Sender")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Validate bond token
+	if err := CheckCoinDenom(msg.BondToken); err != nil {
+		return err
+	}
+
+	// Validate amount
+	if !isValidCoins(msg.Amount) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	} else if !msg.Amount.IsAllPositive() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+	}
+
+	return nil
+}
+
+func (msg MsgDevMintReserve) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDevMintReserve) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+func (msg MsgDevMintReserve) Route() string { return RouterKey }
+
+func (msg MsgDevMintReserve) Type() string { return TypeMsgDevMintReserve }
+
+// MsgDevAdvanceBatch makes the bond's current batch due at the end of the
+// current block, regardless of its blocks remaining.
+type MsgDevAdvanceBatch struct {
+	Sender    sdk.AccAddress `json:"sender" yaml:"sender"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+}
+
+func NewMsgDevAdvanceBatch(sender sdk.AccAddress, bondToken string) MsgDevAdvanceBatch {
+	return MsgDevAdvanceBatch{
+		Sender:    sender,
+		BondToken: bondToken,
+	}
+}
+
+func (msg MsgDevAdvanceBatch) ValidateBasic() error {
+	// Check if empty
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Sender")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Validate bond token
+	return CheckCoinDenom(msg.BondToken)
+}
+
+func (msg MsgDevAdvanceBatch) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDevAdvanceBatch) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+func (msg MsgDevAdvanceBatch) Route() string { return RouterKey }
+
+func (msg MsgDevAdvanceBatch) Type() string { return TypeMsgDevAdvanceBatch }
@@ -0,0 +1,15 @@
+//go:build !testing
+// +build !testing
+
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// DevMessagesEnabled indicates whether the developer messages (see
+// dev_msgs.go) are compiled into this build, which requires the testing
+// build tag
+const DevMessagesEnabled = false
+
+func registerDevCodec(*codec.Codec) {}
@@ -48,4 +48,60 @@ var (
 	ErrInvalidFunctionParameter             = sdkerrors.Register(ModuleName, 337, "invalid function parameter")
 	ErrArgumentMissingOrNonUInteger         = sdkerrors.Register(ModuleName, 338, "argument is missing or is not an unsigned integer")
 	ErrArgumentMissingOrNonBoolean          = sdkerrors.Register(ModuleName, 339, "argument is missing or is not true or false")
+	ErrMaxAmountInsufficientForReturns      = sdkerrors.Register(ModuleName, 340, "max amount of tokens to sell is insufficient for the requested returns")
+	ErrDuplicateFunctionParameter           = sdkerrors.Register(ModuleName, 341, "cannot have duplicate function parameters")
+	ErrFeeAddressCannotBeModuleAccount      = sdkerrors.Register(ModuleName, 342, "fee address cannot be a bonds module account")
+	ErrDuplicateSigner                      = sdkerrors.Register(ModuleName, 343, "cannot have duplicate signers")
+	ErrNetSellCapDenomDoesNotMatchToken     = sdkerrors.Register(ModuleName, 344, "net sell cap denom does not match token denom")
+	ErrBondTokenIsNonTransferable           = sdkerrors.Register(ModuleName, 345, "bond token is non-transferable")
+	ErrBondTokenIsTransferable              = sdkerrors.Register(ModuleName, 346, "bond token is transferable and does not require an authorized transfer")
+	ErrReserveBalancesNotEqual              = sdkerrors.Register(ModuleName, 347, "reserve balances are not all equal")
+	ErrOrderSubmissionHalted                = sdkerrors.Register(ModuleName, 348, "order submission is halted for all bonds")
+	ErrUnknownBondsModuleAccount            = sdkerrors.Register(ModuleName, 349, "not a bonds module account")
+	ErrClaimExceedsStuckFunds               = sdkerrors.Register(ModuleName, 350, "amount exceeds the funds not accounted for by bonds and orders")
+	ErrUnrecognizedCurveVersion             = sdkerrors.Register(ModuleName, 351, "unrecognized curve version")
+	ErrCurveVersionNotNewer                 = sdkerrors.Register(ModuleName, 352, "curve version is not newer than the bond's current curve version")
+	ErrTooManyDecimalPlaces                 = sdkerrors.Register(ModuleName, 353, "too many decimal places")
+	ErrAttestationRequired                  = sdkerrors.Register(ModuleName, 354, "address does not have a valid attestation for the bond")
+	ErrInvalidEffectiveHeight               = sdkerrors.Register(ModuleName, 355, "effective height is not in the future")
+	ErrParamChangeAlreadyScheduled          = sdkerrors.Register(ModuleName, 356, "bond already has a scheduled parameter change")
+	ErrNoParamChangeScheduled               = sdkerrors.Register(ModuleName, 357, "bond does not have a scheduled parameter change")
+	ErrInvalidMilestone                     = sdkerrors.Register(ModuleName, 358, "invalid milestone")
+	ErrBondGovernanceDisabled               = sdkerrors.Register(ModuleName, 359, "bond does not allow bond proposals")
+	ErrBondProposalDoesNotExist             = sdkerrors.Register(ModuleName, 360, "bond proposal does not exist")
+	ErrBondProposalVotingEnded              = sdkerrors.Register(ModuleName, 361, "bond proposal is no longer in its voting period")
+	ErrAlreadyVotedOnBondProposal           = sdkerrors.Register(ModuleName, 362, "address already voted on the bond proposal")
+	ErrInvalidBondProposalType              = sdkerrors.Register(ModuleName, 363, "invalid bond proposal type")
+	ErrInvalidVoteOption                    = sdkerrors.Register(ModuleName, 364, "invalid vote option")
+	ErrInvalidAnchorPoint                   = sdkerrors.Register(ModuleName, 365, "invalid anchor point")
+	ErrInvalidEventAttribute                = sdkerrors.Register(ModuleName, 366, "invalid event attribute")
+	ErrArgumentTooLong                      = sdkerrors.Register(ModuleName, 367, "argument is too long")
+	ErrInvalidBondTranslation               = sdkerrors.Register(ModuleName, 368, "invalid bond translation")
+	ErrSpendCapExceeded                     = sdkerrors.Register(ModuleName, 369, "address spend cap exceeded")
+	ErrInvalidReserveMigration              = sdkerrors.Register(ModuleName, 370, "invalid reserve token migration")
+	ErrSanityRateChangeTooLarge             = sdkerrors.Register(ModuleName, 371, "sanity rate change is too large")
+	ErrPreMineTooLarge                      = sdkerrors.Register(ModuleName, 372, "pre-mine is too large")
+	ErrOracleRateUnavailable                = sdkerrors.Register(ModuleName, 373, "oracle exchange rate is unavailable")
+	ErrOracleRateWithinSanityBand           = sdkerrors.Register(ModuleName, 374, "oracle exchange rate is within the sanity band")
+	ErrInvalidAtMaxSupplyBehavior           = sdkerrors.Register(ModuleName, 375, "invalid at max supply behavior")
+	ErrBondClosedToBuys                     = sdkerrors.Register(ModuleName, 376, "bond is closed to buys")
+	ErrBondTokenReserved                    = sdkerrors.Register(ModuleName, 377, "bond token is already reserved by another creation in this block")
+	ErrOrdersFrozenForUpgrade               = sdkerrors.Register(ModuleName, 378, "orders are frozen until the scheduled upgrade")
+	ErrBondHasNoQuoteDenom                  = sdkerrors.Register(ModuleName, 379, "bond does not have a quote denomination")
+	ErrNoFeeDustToSweep                     = sdkerrors.Register(ModuleName, 380, "no fee dust to sweep")
+	ErrBatchSettlementFailed                = sdkerrors.Register(ModuleName, 381, "batch settlement failed")
+	ErrInvalidNotificationType              = sdkerrors.Register(ModuleName, 382, "invalid notification type")
+	ErrNotificationRegistrationDoesNotExist = sdkerrors.Register(ModuleName, 383, "notification registration does not exist")
+	ErrTooManyNotificationRegistrations     = sdkerrors.Register(ModuleName, 384, "bond has the maximum number of notification registrations")
+	ErrBondHistoryNotAvailable              = sdkerrors.Register(ModuleName, 385, "bond history not available")
+	ErrUnsupportedExportFormat              = sdkerrors.Register(ModuleName, 386, "unsupported export format")
+	ErrSwapReturnBelowMinReturn             = sdkerrors.Register(ModuleName, 387, "swap return is below the min return")
+	ErrTooManySigners                       = sdkerrors.Register(ModuleName, 388, "too many signers")
+	ErrInvalidSignerThreshold               = sdkerrors.Register(ModuleName, 389, "invalid signer threshold")
+	ErrInvalidCurveSegment                  = sdkerrors.Register(ModuleName, 390, "invalid curve segment")
+	ErrCannotInterpolateFunctionParams      = sdkerrors.Register(ModuleName, 391, "function parameters cannot be interpolated")
+	ErrInvalidRaiseDeadline                 = sdkerrors.Register(ModuleName, 392, "invalid raise deadline")
+	ErrInvalidBondState                     = sdkerrors.Register(ModuleName, 393, "invalid bond state")
+	ErrInvalidOrderReceipt                  = sdkerrors.Register(ModuleName, 394, "invalid order receipt")
+	ErrParamsNotBackedByReserve             = sdkerrors.Register(ModuleName, 395, "function parameters are not backed by the reserve")
 )
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	MaxEventAttributes           = 5
+	MaxEventAttributeValueLength = 128
+)
+
+// Event attribute keys are restricted to lowercase alphanumeric characters and
+// underscores so that they can be used as-is in indexer and event queries.
+var reEventAttributeKey = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// EventAttribute is a static key/value pair registered by a bond's creator,
+// which is attached to every event that the bond emits. This allows indexers
+// to partition the bonds module's events (e.g. by project ID or DID) without
+// having to maintain a mapping from bonds to projects off-chain.
+type EventAttribute struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+func NewEventAttribute(key, value string) EventAttribute {
+	return EventAttribute{
+		Key:   key,
+		Value: value,
+	}
+}
+
+func (ea EventAttribute) String() string {
+	return fmt.Sprintf("%s:%s", ea.Key, ea.Value)
+}
+
+type EventAttributes []EventAttribute
+
+// Validate checks that there are at most MaxEventAttributes attributes, that
+// the keys are valid and unique, and that the values are not empty or longer
+// than MaxEventAttributeValueLength. Keys cannot be the same as the bond
+// attribute key, which is already attached to every event emitted by a bond.
+func (eas EventAttributes) Validate() error {
+	if len(eas) > MaxEventAttributes {
+		return sdkerrors.Wrapf(ErrInvalidEventAttribute,
+			"cannot have more than %d event attributes", MaxEventAttributes)
+	}
+
+	keys := make(map[string]bool)
+	for _, ea := range eas {
+		if !reEventAttributeKey.MatchString(ea.Key) || ea.Key == AttributeKeyBond {
+			return sdkerrors.Wrapf(ErrInvalidEventAttribute, "invalid key %s", ea.Key)
+		} else if keys[ea.Key] {
+			return sdkerrors.Wrapf(ErrInvalidEventAttribute, "duplicate key %s", ea.Key)
+		} else if strings.TrimSpace(ea.Value) == "" {
+			return sdkerrors.Wrapf(ErrInvalidEventAttribute, "empty value for key %s", ea.Key)
+		} else if len(ea.Value) > MaxEventAttributeValueLength {
+			return sdkerrors.Wrapf(ErrInvalidEventAttribute,
+				"value for key %s is longer than %d characters", ea.Key, MaxEventAttributeValueLength)
+		}
+		keys[ea.Key] = true
+	}
+	return nil
+}
+
+// AsSDKAttributes returns the event attributes as attributes that can be
+// appended to an sdk.Event.
+func (eas EventAttributes) AsSDKAttributes() []sdk.Attribute {
+	attributes := make([]sdk.Attribute, len(eas))
+	for i, ea := range eas {
+		attributes[i] = sdk.NewAttribute(ea.Key, ea.Value)
+	}
+	return attributes
+}
+
+func (eas EventAttributes) String() string {
+	strs := make([]string, len(eas))
+	for i, ea := range eas {
+		strs[i] = ea.String()
+	}
+	return strings.Join(strs, ",")
+}
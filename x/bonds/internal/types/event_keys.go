@@ -0,0 +1,131 @@
+// Code generated by gen_event_keys.go; DO NOT EDIT.
+
+package types
+
+const (
+	AttributeKeyAddress                   = "address"
+	AttributeKeyAllowSells                = "allow_sells"
+	AttributeKeyAmount                    = "amount"
+	AttributeKeyAtMaxSupplyBehavior       = "at_max_supply_behavior"
+	AttributeKeyBatchBlocks               = "batch_blocks"
+	AttributeKeyBond                      = "bond"
+	AttributeKeyBuyers                    = "buyers"
+	AttributeKeyCallbackPayload           = "callback_payload"
+	AttributeKeyCallerModule              = "caller_module"
+	AttributeKeyCancelReason              = "cancel_reason"
+	AttributeKeyCancellations             = "cancellations"
+	AttributeKeyChangePercentage          = "change_percentage"
+	AttributeKeyChargedDemurrage          = "charged_demurrage"
+	AttributeKeyChargedFees               = "charged_fees"
+	AttributeKeyChargedPrices             = "charged_prices"
+	AttributeKeyChargedPricesFunding      = "charged_prices_of_which_funding"
+	AttributeKeyChargedPricesReserve      = "charged_prices_of_which_reserve"
+	AttributeKeyCreationFee               = "creation_fee"
+	AttributeKeyCurveVersion              = "curve_version"
+	AttributeKeyDemurrageRate             = "demurrage_rate"
+	AttributeKeyDeposit                   = "deposit"
+	AttributeKeyDerivative                = "derivative"
+	AttributeKeyDescription               = "description"
+	AttributeKeyEditedFields              = "edited_fields"
+	AttributeKeyEffectiveBatchBlocks      = "effective_batch_blocks"
+	AttributeKeyEffectiveHeight           = "effective_height"
+	AttributeKeyExitFeePercentage         = "exit_fee_percentage"
+	AttributeKeyExtraBlocks               = "extra_blocks"
+	AttributeKeyFeeAddress                = "fee_address"
+	AttributeKeyFills                     = "fills"
+	AttributeKeyFromAddress               = "from_address"
+	AttributeKeyFromDenom                 = "from_denom"
+	AttributeKeyFunctionParameters        = "function_parameters"
+	AttributeKeyFunctionType              = "function_type"
+	AttributeKeyFundingAmount             = "funding_amount"
+	AttributeKeyFundingRecipient          = "funding_recipient"
+	AttributeKeyFundingTranche            = "funding_tranche"
+	AttributeKeyInterpolate               = "interpolate"
+	AttributeKeyLocales                   = "locales"
+	AttributeKeyMaxPrices                 = "max_prices"
+	AttributeKeyMaxSupply                 = "max_supply"
+	AttributeKeyMemo                      = "memo"
+	AttributeKeyMetric                    = "metric"
+	AttributeKeyMilestone                 = "milestone"
+	AttributeKeyMinReserve                = "min_reserve"
+	AttributeKeyMinReservePercentage      = "min_reserve_percentage"
+	AttributeKeyMinReturn                 = "min_return"
+	AttributeKeyModuleAccount             = "module_account"
+	AttributeKeyName                      = "name"
+	AttributeKeyNetSellCap                = "net_sell_cap"
+	AttributeKeyNetSellCapPercentage      = "net_sell_cap_percentage"
+	AttributeKeyNewBondTokenBalance       = "new_bond_token_balance"
+	AttributeKeyNewCurveVersion           = "new_curve_version"
+	AttributeKeyNewFunctionParams         = "new_function_parameters"
+	AttributeKeyNewReserve                = "new_reserve"
+	AttributeKeyNewSanityMarginPercentage = "new_sanity_margin_percentage"
+	AttributeKeyNewSanityRate             = "new_sanity_rate"
+	AttributeKeyNewState                  = "new_state"
+	AttributeKeyNewValue                  = "new_value"
+	AttributeKeyNoVotes                   = "no_votes"
+	AttributeKeyNonTransferable           = "non_transferable"
+	AttributeKeyNotificationType          = "notification_type"
+	AttributeKeyNotificationTypes         = "notification_types"
+	AttributeKeyOldCurveVersion           = "old_curve_version"
+	AttributeKeyOldFunctionParams         = "old_function_parameters"
+	AttributeKeyOldReserve                = "old_reserve"
+	AttributeKeyOldSanityMarginPercentage = "old_sanity_margin_percentage"
+	AttributeKeyOldSanityRate             = "old_sanity_rate"
+	AttributeKeyOldState                  = "old_state"
+	AttributeKeyOldValue                  = "old_value"
+	AttributeKeyOracleRate                = "oracle_rate"
+	AttributeKeyOrderID                   = "order_id"
+	AttributeKeyOrderQuantityLimits       = "order_quantity_limits"
+	AttributeKeyOrderReceipt              = "order_receipt"
+	AttributeKeyOrderType                 = "order_type"
+	AttributeKeyOrders                    = "orders"
+	AttributeKeyOutcomePayment            = "outcome_payment"
+	AttributeKeyPreMine                   = "pre_mine"
+	AttributeKeyPriorities                = "priorities"
+	AttributeKeyPriorityFee               = "priority_fee"
+	AttributeKeyProposalID                = "proposal_id"
+	AttributeKeyProposalStatus            = "proposal_status"
+	AttributeKeyProposalType              = "proposal_type"
+	AttributeKeyProposalVotingBlocks      = "proposal_voting_blocks"
+	AttributeKeyQuoteDenom                = "quote_denom"
+	AttributeKeyRaiseDeadline             = "raise_deadline"
+	AttributeKeyRate                      = "rate"
+	AttributeKeyReason                    = "reason"
+	AttributeKeyRecipient                 = "recipient"
+	AttributeKeyRefunded                  = "refunded"
+	AttributeKeyRelayer                   = "relayer"
+	AttributeKeyRequireAttestation        = "require_attestation"
+	AttributeKeyReserve                   = "reserve"
+	AttributeKeyReserveThreshold          = "reserve_threshold"
+	AttributeKeyReserveTokens             = "reserve_tokens"
+	AttributeKeyReturnedToAddress         = "returned_to_address"
+	AttributeKeySanityMarginPercentage    = "sanity_margin_percentage"
+	AttributeKeySanityRate                = "sanity_rate"
+	AttributeKeySentToCommunityPool       = "sent_to_community_pool"
+	AttributeKeySignerThreshold           = "signer_threshold"
+	AttributeKeySigners                   = "signers"
+	AttributeKeySoftCap                   = "soft_cap"
+	AttributeKeyState                     = "state"
+	AttributeKeyStressedBatches           = "stressed_batches"
+	AttributeKeyStuckFunds                = "stuck_funds"
+	AttributeKeySwapFromToken             = "from_token"
+	AttributeKeySwapToToken               = "to_token"
+	AttributeKeySwapped                   = "swapped"
+	AttributeKeyTargetDenom               = "target_denom"
+	AttributeKeyToAddress                 = "to_address"
+	AttributeKeyToDenom                   = "to_denom"
+	AttributeKeyTokensBurned              = "tokens_burned"
+	AttributeKeyTokensDeferred            = "tokens_deferred"
+	AttributeKeyTokensMinted              = "tokens_minted"
+	AttributeKeyTokensSwapped             = "tokens_swapped"
+	AttributeKeyTxFeePercentage           = "tx_fee_percentage"
+	AttributeKeyUnderlying                = "underlying"
+	AttributeKeyVia                       = "via"
+	AttributeKeyViaTokens                 = "via_tokens"
+	AttributeKeyVoteOption                = "vote_option"
+	AttributeKeyVoter                     = "voter"
+	AttributeKeyVotingEndHeight           = "voting_end_height"
+	AttributeKeyVotingPower               = "voting_power"
+	AttributeKeyWindowStartHeight         = "window_start_height"
+	AttributeKeyYesVotes                  = "yes_votes"
+)
@@ -1,53 +1,48 @@
 package types
 
 const (
-	EventTypeCreateBond         = "create_bond"
-	EventTypeEditBond           = "edit_bond"
-	EventTypeInitSwapper        = "init_swapper"
-	EventTypeBuy                = "buy"
-	EventTypeSell               = "sell"
-	EventTypeSwap               = "swap"
-	EventTypeMakeOutcomePayment = "make_outcome_payment"
-	EventTypeWithdrawShare      = "withdraw_share"
-	EventTypeOrderCancel        = "order_cancel"
-	EventTypeOrderFulfill       = "order_fulfill"
-	EventTypeStateChange        = "state_change"
-
-	AttributeKeyBond                   = "bond"
-	AttributeKeyName                   = "name"
-	AttributeKeyDescription            = "description"
-	AttributeKeyFunctionType           = "function_type"
-	AttributeKeyFunctionParameters     = "function_parameters"
-	AttributeKeyReserveTokens          = "reserve_tokens"
-	AttributeKeyTxFeePercentage        = "tx_fee_percentage"
-	AttributeKeyExitFeePercentage      = "exit_fee_percentage"
-	AttributeKeyFeeAddress             = "fee_address"
-	AttributeKeyMaxSupply              = "max_supply"
-	AttributeKeyOrderQuantityLimits    = "order_quantity_limits"
-	AttributeKeySanityRate             = "sanity_rate"
-	AttributeKeySanityMarginPercentage = "sanity_margin_percentage"
-	AttributeKeyAllowSells             = "allow_sells"
-	AttributeKeySigners                = "signers"
-	AttributeKeyBatchBlocks            = "batch_blocks"
-	AttributeKeyOutcomePayment         = "outcome_payment"
-	AttributeKeyState                  = "state"
-	AttributeKeyMaxPrices              = "max_prices"
-	AttributeKeySwapFromToken          = "from_token"
-	AttributeKeySwapToToken            = "to_token"
-	AttributeKeyOrderType              = "order_type"
-	AttributeKeyAddress                = "address"
-	AttributeKeyCancelReason           = "cancel_reason"
-	AttributeKeyTokensMinted           = "tokens_minted"
-	AttributeKeyTokensBurned           = "tokens_burned"
-	AttributeKeyTokensSwapped          = "tokens_swapped"
-	AttributeKeyChargedPrices          = "charged_prices"
-	AttributeKeyChargedPricesReserve   = "charged_prices_of_which_reserve"
-	AttributeKeyChargedPricesFunding   = "charged_prices_of_which_funding"
-	AttributeKeyChargedFees            = "charged_fees"
-	AttributeKeyReturnedToAddress      = "returned_to_address"
-	AttributeKeyNewBondTokenBalance    = "new_bond_token_balance"
-	AttributeKeyOldState               = "old_state"
-	AttributeKeyNewState               = "new_state"
+	EventTypeCreateBond              = "create_bond"
+	EventTypeEditBond                = "edit_bond"
+	EventTypeInitSwapper             = "init_swapper"
+	EventTypeBuy                     = "buy"
+	EventTypeSell                    = "sell"
+	EventTypeSwap                    = "swap"
+	EventTypeMakeOutcomePayment      = "make_outcome_payment"
+	EventTypeWithdrawShare           = "withdraw_share"
+	EventTypeAuthorizedTransfer      = "authorized_transfer"
+	EventTypeOrderCancel             = "order_cancel"
+	EventTypeOrderFulfill            = "order_fulfill"
+	EventTypeOrderDefer              = "order_defer"
+	EventTypeStateChange             = "state_change"
+	EventTypeClaimStuckFunds         = "claim_stuck_funds"
+	EventTypeMigrateCurve            = "migrate_curve"
+	EventTypeScheduleChange          = "schedule_param_change"
+	EventTypeCancelChange            = "cancel_param_change"
+	EventTypeApplyChange             = "apply_param_change"
+	EventTypeMilestoneReached        = "milestone_reached"
+	EventTypeSubmitProposal          = "submit_bond_proposal"
+	EventTypeVoteProposal            = "vote_bond_proposal"
+	EventTypeTallyProposal           = "tally_bond_proposal"
+	EventTypeSetTranslations         = "set_bond_translations"
+	EventTypeMigrateReserve          = "migrate_reserve_token"
+	EventTypeSetSanityRate           = "set_sanity_rate"
+	EventTypeReleaseVested           = "release_vested"
+	EventTypeConvertReserve          = "convert_reserve"
+	EventTypeBondAlert               = "bond_alert"
+	EventTypeRebalanceSwap           = "rebalance_swap"
+	EventTypeRefund                  = "refund"
+	EventTypeMaxSupplyReached        = "max_supply_reached"
+	EventTypeSweepFeeDust            = "sweep_fee_dust"
+	EventTypeRegisterNotifications   = "register_notifications"
+	EventTypeUnregisterNotifications = "unregister_notifications"
+	EventTypeBondNotification        = "bond_notification"
+	EventTypeBuyPriority             = "buy_priority"
+	EventTypeRoutedSwapFulfill       = "routed_swap_fulfill"
+	EventTypeBatchInterval           = "batch_interval"
+	EventTypeSoftCapReached          = "soft_cap_reached"
+	EventTypeRaiseFailed             = "raise_failed"
+	EventTypeEditBondProposal        = "edit_bond_proposal"
+	EventTypeApplyBondEdit           = "apply_bond_edit"
 
 	AttributeValueBuyOrder  = "buy"
 	AttributeValueSellOrder = "sell"
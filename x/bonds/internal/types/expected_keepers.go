@@ -0,0 +1,53 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+// AttestationKeeper is consulted when buying or selling tokens of bonds that
+// require an attestation (e.g. KYC), to check whether the address has a valid
+// attestation for the bond. It is meant to be implemented outside of the bonds
+// module, for example by an identity or credentials module.
+type AttestationKeeper interface {
+	HasValidAttestation(ctx sdk.Context, bondToken string, address sdk.AccAddress) bool
+}
+
+// ReserveConverter converts derivative tokens (e.g. liquid-staking derivatives
+// of a staked token) into the underlying tokens that they represent, so that
+// buyers can contribute to a bond's reserve using derivative tokens while the
+// reserve itself is kept in the underlying tokens. It is meant to be
+// implemented outside of the bonds module, for example by a liquid staking
+// module that values the derivative tokens using its conversion rate.
+type ReserveConverter interface {
+	// GetConversionRate returns the underlying denomination of the derivative
+	// denomination and the amount of underlying tokens that one derivative
+	// token is worth, or false if the denomination is not a supported
+	// derivative.
+	GetConversionRate(ctx sdk.Context, derivativeDenom string) (underlyingDenom string, rate sdk.Dec, found bool)
+
+	// ConvertToUnderlying exchanges the derivative tokens held by the address
+	// for the equivalent amount of underlying tokens at the current conversion
+	// rate, and returns the amount of underlying tokens that the address
+	// received in exchange.
+	ConvertToUnderlying(ctx sdk.Context, address sdk.AccAddress, amount sdk.Coin) (sdk.Coin, error)
+}
+
+// OracleKeeper provides market exchange rates between tokens, which are used
+// to confirm that the market rate between a swapper bond's reserve tokens has
+// moved before allowing a rebalance swap to move the bond's reserves outside
+// of the bond's sanity band. It is meant to be implemented outside of the
+// bonds module, for example by a price oracle module.
+type OracleKeeper interface {
+	// GetExchangeRate returns the market rate of the base denomination per
+	// unit of the quote denomination, or false if the oracle does not have a
+	// rate for the pair of denominations.
+	GetExchangeRate(ctx sdk.Context, baseDenom, quoteDenom string) (rate sdk.Dec, found bool)
+}
+
+// UpgradeKeeper provides the software upgrade plan of the chain (if any), so
+// that bond batches can be settled before the chain halts for the upgrade. It
+// is implemented by the upgrade module's keeper.
+type UpgradeKeeper interface {
+	GetUpgradePlan(ctx sdk.Context) (plan upgrade.Plan, havePlan bool)
+}
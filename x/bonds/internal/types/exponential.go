@@ -0,0 +1,95 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The exponential function's price a*e^(b*x) and reserve (a/b)*(e^(b*x)-1)
+// are computed using sdk.Dec arithmetic only, so every node computes identical
+// results regardless of its architecture.
+
+// MaxExponentialExponent is the greatest exponent b*x for which e^(b*x) can be
+// evaluated. An exponential bond's b multiplied by its max supply cannot exceed
+// it, so that prices and reserves stay well within the range of an sdk.Dec.
+var MaxExponentialExponent = sdk.NewDec(100)
+
+var (
+	// eulerNumber is e truncated to sdk.Precision decimal places
+	eulerNumber = sdk.MustNewDecFromStr("2.718281828459045235")
+
+	// expTaylorTerms is the max number of terms of the Taylor series of e^f
+	// (for 0 <= f < 1) that are summed, which is more than enough for the
+	// terms to become zero at sdk.Precision decimal places
+	expTaylorTerms = 40
+)
+
+// ApproxExp returns e^y for the non-negative y, up to MaxExponentialExponent.
+// The integer part n of y is evaluated as e^n by repeated multiplication and
+// the fractional part f by summing the Taylor series of e^f, which converges
+// quickly since f < 1, so the result is deterministic.
+func ApproxExp(y sdk.Dec) (sdk.Dec, error) {
+	if y.IsNegative() {
+		return sdk.Dec{}, sdkerrors.Wrap(ErrArgumentCannotBeNegative, "exponent")
+	} else if y.GT(MaxExponentialExponent) {
+		return sdk.Dec{}, sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"exponent %s must be between 0 and %s", y, MaxExponentialExponent)
+	}
+
+	n := y.TruncateInt()
+	f := y.Sub(n.ToDec())
+
+	sum, term := sdk.OneDec(), sdk.OneDec()
+	for i := int64(1); i <= int64(expTaylorTerms) && !term.IsZero(); i++ {
+		term = term.Mul(f).QuoInt64(i)
+		sum = sum.Add(term)
+	}
+
+	return eulerNumber.Power(n.Uint64()).Mul(sum), nil
+}
+
+// exponentialFunctionPrice returns the exponential function's price a*e^(b*x)
+// at the supply x
+func exponentialFunctionPrice(a, b, x sdk.Dec) (sdk.Dec, error) {
+	exp, err := ApproxExp(b.Mul(x))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return a.Mul(exp), nil
+}
+
+// exponentialFunctionReserve returns the exponential function's reserve at
+// the supply x, i.e. the integral of the price from 0 to x, (a/b)*(e^(b*x)-1)
+func exponentialFunctionReserve(a, b, x sdk.Dec) (sdk.Dec, error) {
+	exp, err := ApproxExp(b.Mul(x))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return a.Mul(exp.Sub(sdk.OneDec())).Quo(b), nil
+}
+
+// CheckExponentialMaxSupply returns an error if the exponential function with
+// the parameters cannot be evaluated up to the max supply, i.e. if b multiplied
+// by the max supply exceeds MaxExponentialExponent.
+func CheckExponentialMaxSupply(paramsMap map[string]sdk.Dec, maxSupply sdk.Int) error {
+	if paramsMap["b"].MulInt(maxSupply).GT(MaxExponentialExponent) {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"FunctionParams:b multiplied by max supply %s cannot exceed %s",
+			maxSupply, MaxExponentialExponent)
+	}
+	return nil
+}
+
+func exponentialParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Exponential exception 1: a != 0, otherwise the price is always zero
+	// Exponential exception 2: b != 0, otherwise we run into divisions by zero
+	for _, p := range []string{"a", "b"} {
+		val, ok := paramsMap[p]
+		if !ok {
+			panic("did not find parameter " + p + " for exponential function")
+		} else if !val.IsPositive() {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:"+p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestApproxExp(t *testing.T) {
+	// Expected values are e^y to 18 decimal places (rounded)
+	testCases := []struct {
+		y        string
+		expected string
+	}{
+		{"0", "1"},
+		{"0.5", "1.648721270700128147"},
+		{"1", "2.718281828459045235"},
+		{"2.5", "12.182493960703473438"},
+		{"10.123456789", "24920.767949009910435041"},
+		{"50", "5184705528587072464087.453322933485384827"},
+		{"100", "26881171418161354484126255515800135873611118.773741922415191609"},
+	}
+
+	// Results are expected to be within a relative error of 1e-15
+	tolerance := sdk.NewDecWithPrec(1, 15)
+	for _, tc := range testCases {
+		actual, err := ApproxExp(sdk.MustNewDecFromStr(tc.y))
+		require.Nil(t, err)
+
+		expected := sdk.MustNewDecFromStr(tc.expected)
+		relativeError := actual.Sub(expected).Abs().Quo(expected)
+		require.True(t, relativeError.LTE(tolerance),
+			"e^%s: expected %s, got %s", tc.y, expected, actual)
+	}
+}
+
+func TestApproxExpIsExactForIntegerExponents(t *testing.T) {
+	actual, err := ApproxExp(sdk.NewDec(2))
+	require.Nil(t, err)
+	require.Equal(t, eulerNumber.Mul(eulerNumber), actual)
+}
+
+func TestApproxExpOutOfRangeGivesError(t *testing.T) {
+	_, err := ApproxExp(sdk.NewDec(-1))
+	require.Error(t, err)
+
+	_, err = ApproxExp(MaxExponentialExponent.Add(sdk.SmallestDec()))
+	require.Error(t, err)
+}
+
+func TestCheckExponentialMaxSupply(t *testing.T) {
+	paramsMap := functionParametersExponential().AsMap() // b=0.001
+
+	require.Nil(t, CheckExponentialMaxSupply(paramsMap, sdk.NewInt(100000)))
+	require.Error(t, CheckExponentialMaxSupply(paramsMap, sdk.NewInt(100001)))
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"bytes"
+	"encoding/csv"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"sort"
+	"strconv"
+)
+
+// ExportFormatCSV is the only format in which a bond's accounting can
+// currently be exported. CSV files can be opened directly in spreadsheet
+// software such as Excel.
+const ExportFormatCSV = "csv"
+
+// QueryBondExport is a bond's accounting exported in the specified format.
+type QueryBondExport struct {
+	Bond    string `json:"bond" yaml:"bond"`
+	Format  string `json:"format" yaml:"format"`
+	Content string `json:"content" yaml:"content"`
+}
+
+// NewBondAccountingCSV exports the bond's batch-by-batch accounting from its
+// history as CSV, with one row per snapshot, from oldest to newest. Amounts in
+// multiple denominations (e.g. the reserve) are given in one column per
+// denomination, so that each cell holds a single number. The fees of a batch
+// are the fees collected by the bond since the previous snapshot, and are left
+// blank for the oldest snapshot in the history.
+func NewBondAccountingCSV(token string, history BondHistory) (string, error) {
+	reserveDenoms := make(map[string]bool)
+	feeDenoms := make(map[string]bool)
+	for _, s := range history {
+		for _, c := range s.Reserve {
+			reserveDenoms[c.Denom] = true
+		}
+		for _, c := range s.Prices {
+			reserveDenoms[c.Denom] = true
+		}
+		for _, c := range s.Swapped {
+			reserveDenoms[c.Denom] = true
+		}
+		for _, c := range s.FeesCollected {
+			feeDenoms[c.Denom] = true
+		}
+	}
+	reserve := sortedKeys(reserveDenoms)
+	fees := sortedKeys(feeDenoms)
+
+	header := []string{"height", "supply_" + token}
+	for _, group := range []string{"reserve", "price", "swapped"} {
+		for _, denom := range reserve {
+			header = append(header, group+"_"+denom)
+		}
+	}
+	header = append(header, "bought_"+token, "sold_"+token)
+	for _, group := range []string{"fees", "fees_collected"} {
+		for _, denom := range fees {
+			header = append(header, group+"_"+denom)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+
+	for i, s := range history {
+		row := []string{strconv.FormatInt(s.Height, 10), s.Supply.Amount.String()}
+		for _, denom := range reserve {
+			row = append(row, s.Reserve.AmountOf(denom).String())
+		}
+		for _, denom := range reserve {
+			if s.Prices.Empty() {
+				row = append(row, "")
+			} else {
+				row = append(row, s.Prices.AmountOf(denom).String())
+			}
+		}
+		for _, denom := range reserve {
+			row = append(row, s.Swapped.AmountOf(denom).String())
+		}
+		row = append(row, s.Bought.AmountOf(token).String(), s.Sold.AmountOf(token).String())
+		for _, denom := range fees {
+			if i == 0 {
+				row = append(row, "")
+			} else {
+				delta := s.FeesCollected.AmountOf(denom).Sub(
+					history[i-1].FeesCollected.AmountOf(denom))
+				row = append(row, delta.String())
+			}
+		}
+		for _, denom := range fees {
+			row = append(row, s.FeesCollected.AmountOf(denom).String())
+		}
+		if err := w.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// NewQueryBondExport exports the bond's accounting from its history in the
+// specified format, which must be ExportFormatCSV.
+func NewQueryBondExport(token, format string, history BondHistory) (QueryBondExport, error) {
+	if format != ExportFormatCSV {
+		return QueryBondExport{}, sdkerrors.Wrap(ErrUnsupportedExportFormat, format)
+	}
+
+	content, err := NewBondAccountingCSV(token, history)
+	if err != nil {
+		return QueryBondExport{}, err
+	}
+
+	return QueryBondExport{
+		Bond:    token,
+		Format:  format,
+		Content: content,
+	}, nil
+}
+
+func sortedKeys(m map[string]bool) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewBondAccountingCSV(t *testing.T) {
+	history := BondHistory{
+		{
+			Height:        10,
+			Supply:        sdk.NewInt64Coin("abc", 0),
+			FeesCollected: sdk.Coins{},
+		},
+		{
+			Height:        20,
+			Supply:        sdk.NewInt64Coin("abc", 10),
+			Reserve:       sdk.NewCoins(sdk.NewInt64Coin("res", 100)),
+			Prices:        sdk.NewDecCoins(sdk.NewInt64DecCoin("res", 20)),
+			Bought:        sdk.NewCoins(sdk.NewInt64Coin("abc", 10)),
+			FeesCollected: sdk.NewCoins(sdk.NewInt64Coin("res", 5)),
+		},
+		{
+			Height:        30,
+			Supply:        sdk.NewInt64Coin("abc", 8),
+			Reserve:       sdk.NewCoins(sdk.NewInt64Coin("res", 64)),
+			Prices:        sdk.NewDecCoins(sdk.NewInt64DecCoin("res", 16)),
+			Sold:          sdk.NewCoins(sdk.NewInt64Coin("abc", 2)),
+			FeesCollected: sdk.NewCoins(sdk.NewInt64Coin("res", 7)),
+		},
+	}
+
+	// Blank prices for the snapshot without prices and blank fees for the
+	// oldest snapshot, whose previous snapshot is unknown
+	expected := "" +
+		"height,supply_abc,reserve_res,price_res,swapped_res,bought_abc,sold_abc,fees_res,fees_collected_res\n" +
+		"10,0,0,,0,0,0,,0\n" +
+		"20,10,100,20.000000000000000000,0,10,0,5,5\n" +
+		"30,8,64,16.000000000000000000,0,0,2,2,7\n"
+	csv, err := NewBondAccountingCSV("abc", history)
+	require.NoError(t, err)
+	require.Equal(t, expected, csv)
+
+	// Only the header for an empty history
+	csv, err = NewBondAccountingCSV("abc", BondHistory{})
+	require.NoError(t, err)
+	require.Equal(t, "height,supply_abc,bought_abc,sold_abc\n", csv)
+}
+
+func TestNewQueryBondExportUnsupportedFormat(t *testing.T) {
+	_, err := NewQueryBondExport("abc", "xlsx", BondHistory{})
+	require.Error(t, err)
+	require.True(t, ErrUnsupportedExportFormat.Is(err))
+
+	export, err := NewQueryBondExport("abc", ExportFormatCSV, BondHistory{})
+	require.NoError(t, err)
+	require.Equal(t, ExportFormatCSV, export.Format)
+}
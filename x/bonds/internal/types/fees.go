@@ -0,0 +1,177 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Names of the fees charged by a bond's fee chain
+const (
+	TxFeeName   = "tx_fee"
+	ExitFeeName = "exit_fee"
+)
+
+// FeeOrder holds the details of an order that the fee decorators need to
+// calculate the fees charged on it. The reserve amounts are the (unrounded)
+// prices of a buy, the (unrounded) returns of a sell, or the from amount of a
+// swap, and the order type is one of the buy, sell, or swap order types.
+type FeeOrder struct {
+	OrderType      string
+	ReserveAmounts sdk.DecCoins
+}
+
+func NewFeeOrder(orderType string, reserveAmounts sdk.DecCoins) FeeOrder {
+	return FeeOrder{
+		OrderType:      orderType,
+		ReserveAmounts: reserveAmounts,
+	}
+}
+
+// Fee is a fee charged on an order by one of the decorators of a fee chain.
+type Fee struct {
+	Name   string
+	Amount sdk.Coins
+}
+
+// Fees are the fees charged on an order by a fee chain. The itemised fees are
+// the fees charged by each decorator, in the order in which they were charged,
+// and the total is the sum of the itemised fees, unless a decorator adjusted
+// the total (e.g. capped it) after these were charged.
+type Fees struct {
+	Itemised []Fee
+	Total    sdk.Coins
+}
+
+// Add returns the fees with the named fee added to them.
+func (fees Fees) Add(name string, amount sdk.Coins) Fees {
+	return Fees{
+		Itemised: append(append([]Fee{}, fees.Itemised...), Fee{Name: name, Amount: amount}),
+		Total:    fees.Total.Add(amount...),
+	}
+}
+
+// AmountOf returns the amount of the named fee, or nil if it was not charged.
+func (fees Fees) AmountOf(name string) (amount sdk.Coins) {
+	for _, f := range fees.Itemised {
+		if f.Name == name {
+			amount = amount.Add(f.Amount...)
+		}
+	}
+	return amount
+}
+
+// FeeDecorator is a single step of a fee chain, which is given the fees
+// charged on the order by the previous decorators in the chain, and returns
+// these with its own fee added to them or with any other adjustment applied.
+type FeeDecorator interface {
+	ApplyFees(order FeeOrder, fees Fees) Fees
+}
+
+// FeeChain is an ordered chain of fee decorators, which together calculate the
+// fees charged on an order. New kinds of fees (or adjustments to fees) should
+// be added to a bond's fee chain as decorators, rather than as special cases
+// in the code that performs orders.
+type FeeChain []FeeDecorator
+
+func NewFeeChain(decorators ...FeeDecorator) FeeChain {
+	return decorators
+}
+
+// GetFees applies each decorator of the chain to the order in turn.
+func (chain FeeChain) GetFees(order FeeOrder) (fees Fees) {
+	for _, d := range chain {
+		fees = d.ApplyFees(order, fees)
+	}
+	return fees
+}
+
+// PercentageFeeDecorator charges a percentage of the reserve amounts of each
+// order of one of the specified order types. Fees are rounded up per denom.
+type PercentageFeeDecorator struct {
+	Name       string
+	Percentage sdk.Dec
+	OrderTypes []string
+}
+
+var _ FeeDecorator = PercentageFeeDecorator{}
+
+func NewPercentageFeeDecorator(name string, percentage sdk.Dec,
+	orderTypes ...string) PercentageFeeDecorator {
+	return PercentageFeeDecorator{
+		Name:       name,
+		Percentage: percentage,
+		OrderTypes: orderTypes,
+	}
+}
+
+func (d PercentageFeeDecorator) ApplyFees(order FeeOrder, fees Fees) Fees {
+	for _, orderType := range d.OrderTypes {
+		if orderType == order.OrderType {
+			return fees.Add(d.Name, GetPercentageFees(order.ReserveAmounts, d.Percentage))
+		}
+	}
+	return fees
+}
+
+// SellFeesCapDecorator caps the total fees charged on a sell order at the
+// sell's rounded returns, since the seller cannot be charged more than what
+// the sell returns. The itemised fees are left as they were charged.
+type SellFeesCapDecorator struct{}
+
+var _ FeeDecorator = SellFeesCapDecorator{}
+
+func (SellFeesCapDecorator) ApplyFees(order FeeOrder, fees Fees) Fees {
+	if order.OrderType != AttributeValueSellOrder {
+		return fees
+	}
+	maxFees := RoundReserveReturns(order.ReserveAmounts)
+	fees.Total = AdjustFees(fees.Total, maxFees)
+	return fees
+}
+
+// GetExactPercentageFee returns the percentage of the reserve amount, before
+// it is rounded up to the fee that is actually charged.
+func GetExactPercentageFee(reserveAmount sdk.DecCoin, percentage sdk.Dec) sdk.DecCoin {
+	feeAmount := NewPercentage(percentage).AsFraction().Mul(reserveAmount.Amount)
+	return sdk.NewDecCoinFromDec(reserveAmount.Denom, feeAmount)
+}
+
+// GetExactPercentageFees returns the percentage of each of the reserve
+// amounts, before these are rounded up.
+func GetExactPercentageFees(reserveAmounts sdk.DecCoins, percentage sdk.Dec) (fees sdk.DecCoins) {
+	for _, r := range reserveAmounts {
+		fees = fees.Add(GetExactPercentageFee(r, percentage))
+	}
+	return fees
+}
+
+// GetPercentageFee returns the percentage of the reserve amount, rounded up.
+func GetPercentageFee(reserveAmount sdk.DecCoin, percentage sdk.Dec) sdk.Coin {
+	return RoundFee(GetExactPercentageFee(reserveAmount, percentage))
+}
+
+// GetPercentageFees returns the percentage of each of the reserve amounts,
+// rounded up.
+func GetPercentageFees(reserveAmounts sdk.DecCoins, percentage sdk.Dec) (fees sdk.Coins) {
+	for _, r := range reserveAmounts {
+		fees = fees.Add(GetPercentageFee(r, percentage))
+	}
+	return fees
+}
+
+// GetFeeChain returns the bond's fee chain, which charges the bond's tx fee on
+// all orders and the bond's exit fee on sells, in that order.
+func (bond Bond) GetFeeChain() FeeChain {
+	return NewFeeChain(
+		NewPercentageFeeDecorator(TxFeeName, bond.TxFeePercentage,
+			AttributeValueBuyOrder, AttributeValueSellOrder, AttributeValueSwapOrder),
+		NewPercentageFeeDecorator(ExitFeeName, bond.ExitFeePercentage,
+			AttributeValueSellOrder),
+		SellFeesCapDecorator{},
+	)
+}
+
+// GetOrderFees returns the fees charged on an order of the specified type with
+// the specified reserve amounts by the bond's fee chain.
+func (bond Bond) GetOrderFees(orderType string, reserveAmounts sdk.DecCoins) Fees {
+	return bond.GetFeeChain().GetFees(NewFeeOrder(orderType, reserveAmounts))
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// halvingFeeDecorator halves the total fees, as a discount would
+type halvingFeeDecorator struct{}
+
+func (halvingFeeDecorator) ApplyFees(_ FeeOrder, fees Fees) Fees {
+	var halved sdk.Coins
+	for _, f := range fees.Total {
+		halved = halved.Add(sdk.NewCoin(f.Denom, f.Amount.QuoRaw(2)))
+	}
+	fees.Total = halved
+	return fees
+}
+
+func TestBondFeeChainChargesOnlyTxFeeOnBuysAndSwaps(t *testing.T) {
+	bond := Bond{}
+	bond.TxFeePercentage = sdk.MustNewDecFromStr("0.1")
+	bond.ExitFeePercentage = sdk.MustNewDecFromStr("0.2")
+	reserveAmounts := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 10000)}
+
+	for _, orderType := range []string{AttributeValueBuyOrder, AttributeValueSwapOrder} {
+		fees := bond.GetOrderFees(orderType, reserveAmounts)
+		require.Equal(t, bond.GetTxFees(reserveAmounts), fees.Total)
+		require.Equal(t, bond.GetTxFees(reserveAmounts), fees.AmountOf(TxFeeName))
+		require.True(t, fees.AmountOf(ExitFeeName).IsZero())
+	}
+}
+
+func TestBondFeeChainChargesTxAndExitFeesOnSells(t *testing.T) {
+	bond := Bond{}
+	bond.TxFeePercentage = sdk.MustNewDecFromStr("0.1")
+	bond.ExitFeePercentage = sdk.MustNewDecFromStr("0.2")
+	reserveAmounts := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 10000)}
+
+	fees := bond.GetOrderFees(AttributeValueSellOrder, reserveAmounts)
+	txFees := bond.GetTxFees(reserveAmounts)
+	exitFees := bond.GetExitFees(reserveAmounts)
+	require.Equal(t, txFees, fees.AmountOf(TxFeeName))
+	require.Equal(t, exitFees, fees.AmountOf(ExitFeeName))
+	require.Equal(t, txFees.Add(exitFees...), fees.Total)
+	require.Equal(t, []string{TxFeeName, ExitFeeName},
+		[]string{fees.Itemised[0].Name, fees.Itemised[1].Name})
+}
+
+func TestBondFeeChainCapsSellFeesAtReturns(t *testing.T) {
+	bond := Bond{}
+	bond.TxFeePercentage = sdk.MustNewDecFromStr("0.1")
+	bond.ExitFeePercentage = sdk.MustNewDecFromStr("0.1")
+
+	// Each fee is rounded up to 1, but the returns are rounded down to 1
+	reserveAmounts := sdk.DecCoins{sdk.NewDecCoinFromDec(
+		reserveToken, sdk.MustNewDecFromStr("1.5"))}
+	fees := bond.GetOrderFees(AttributeValueSellOrder, reserveAmounts)
+
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1)), fees.AmountOf(TxFeeName))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1)), fees.AmountOf(ExitFeeName))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1)), fees.Total)
+}
+
+func TestFeeChainAppliesDecoratorsInOrder(t *testing.T) {
+	txFee := NewPercentageFeeDecorator(TxFeeName, sdk.NewDec(10), AttributeValueBuyOrder)
+	order := NewFeeOrder(AttributeValueBuyOrder,
+		sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 1000)})
+
+	// Discount applied after the fee is charged halves the fee
+	fees := NewFeeChain(txFee, halvingFeeDecorator{}).GetFees(order)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)), fees.AmountOf(TxFeeName))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50)), fees.Total)
+
+	// Discount applied before the fee is charged has nothing to discount
+	fees = NewFeeChain(halvingFeeDecorator{}, txFee).GetFees(order)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)), fees.Total)
+}
+
+func TestPercentageFeeDecoratorIgnoresOtherOrderTypes(t *testing.T) {
+	exitFee := NewPercentageFeeDecorator(ExitFeeName, sdk.NewDec(10), AttributeValueSellOrder)
+	order := NewFeeOrder(AttributeValueBuyOrder,
+		sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 1000)})
+
+	fees := exitFee.ApplyFees(order, Fees{})
+	require.Empty(t, fees.Itemised)
+	require.True(t, fees.Total.IsZero())
+}
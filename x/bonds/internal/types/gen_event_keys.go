@@ -0,0 +1,98 @@
+//go:build ignore
+// +build ignore
+
+// This program generates event_keys.go from the typed events defined in
+// typed_events.go. It can be invoked by running go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	inputFile  = "typed_events.go"
+	outputFile = "event_keys.go"
+)
+
+func main() {
+	f, err := parser.ParseFile(token.NewFileSet(), inputFile, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Collect the attribute key of every field tagged with one, checking that
+	// a field name always maps to the same key and vice versa, so that every
+	// generated constant is unambiguous
+	keys := make(map[string]string)
+	names := make(map[string]string)
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		for _, field := range st.Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				log.Fatal(err)
+			}
+			key := strings.Split(reflect.StructTag(tag).Get("attr"), ",")[0]
+			if key == "" {
+				continue
+			}
+			for _, ident := range field.Names {
+				name := ident.Name
+				if k, ok := keys[name]; ok && k != key {
+					log.Fatalf("%s.%s: field %s has keys %s and %s",
+						spec.Name.Name, name, name, k, key)
+				} else if n, ok := names[key]; ok && n != name {
+					log.Fatalf("%s.%s: key %s has field names %s and %s",
+						spec.Name.Name, name, key, n, name)
+				}
+				keys[name] = key
+				names[key] = name
+			}
+		}
+		return false
+	})
+
+	sorted := make([]string, 0, len(keys))
+	for name := range keys {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen_event_keys.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package types\n\n")
+	fmt.Fprintf(&buf, "const (\n")
+	for _, name := range sorted {
+		fmt.Fprintf(&buf, "\tAttributeKey%s = %q\n", name, keys[name])
+	}
+	fmt.Fprintf(&buf, ")\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(outputFile, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,25 +1,144 @@
 package types
 
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
 type GenesisState struct {
-	Bonds   []Bond  `json:"bonds" yaml:"bonds"`
-	Batches []Batch `json:"batches" yaml:"batches"`
+	Bonds                     []Bond                     `json:"bonds" yaml:"bonds"`
+	Batches                   []Batch                    `json:"batches" yaml:"batches"`
+	ScheduledParamChanges     []ScheduledParamChange     `json:"scheduled_param_changes" yaml:"scheduled_param_changes"`
+	BondProposals             []BondProposal             `json:"bond_proposals" yaml:"bond_proposals"`
+	BondProposalVotes         []BondProposalVote         `json:"bond_proposal_votes" yaml:"bond_proposal_votes"`
+	VestingSchedules          []VestingSchedule          `json:"vesting_schedules" yaml:"vesting_schedules"`
+	NotificationRegistrations []NotificationRegistration `json:"notification_registrations" yaml:"notification_registrations"`
+	Ledgers                   []BondLedger               `json:"ledgers" yaml:"ledgers"`
+	LedgerEntries             []LedgerEntry              `json:"ledger_entries" yaml:"ledger_entries"`
+	PendingBondEdits          []PendingBondEdit          `json:"pending_bond_edits" yaml:"pending_bond_edits"`
+	NextOrderID               uint64                     `json:"next_order_id" yaml:"next_order_id"`
+	OrderReceipts             []OrderReceipt             `json:"order_receipts" yaml:"order_receipts"`
+	BondHistories             []BondHistoryEntry         `json:"bond_histories" yaml:"bond_histories"`
+	BondFeesCollected         []BondFeesEntry            `json:"bond_fees_collected" yaml:"bond_fees_collected"`
+	TotalFeesCollected        sdk.Coins                  `json:"total_fees_collected" yaml:"total_fees_collected"`
+	SpendRecords              []SpendRecordsEntry        `json:"spend_records" yaml:"spend_records"`
+	SanityRateWindows         []SanityRateWindowEntry    `json:"sanity_rate_windows" yaml:"sanity_rate_windows"`
+	Params                    Params                     `json:"params" yaml:"params"`
 }
 
-func NewGenesisState(bonds []Bond, batches []Batch) GenesisState {
+func NewGenesisState(bonds []Bond, batches []Batch,
+	scheduledParamChanges []ScheduledParamChange, bondProposals []BondProposal,
+	bondProposalVotes []BondProposalVote, vestingSchedules []VestingSchedule,
+	notificationRegistrations []NotificationRegistration, ledgers []BondLedger,
+	ledgerEntries []LedgerEntry, pendingBondEdits []PendingBondEdit,
+	nextOrderID uint64, orderReceipts []OrderReceipt,
+	bondHistories []BondHistoryEntry, bondFeesCollected []BondFeesEntry,
+	totalFeesCollected sdk.Coins, spendRecords []SpendRecordsEntry,
+	sanityRateWindows []SanityRateWindowEntry, params Params) GenesisState {
 	return GenesisState{
-		Bonds:   bonds,
-		Batches: batches,
+		Bonds:                     bonds,
+		Batches:                   batches,
+		ScheduledParamChanges:     scheduledParamChanges,
+		BondProposals:             bondProposals,
+		BondProposalVotes:         bondProposalVotes,
+		VestingSchedules:          vestingSchedules,
+		NotificationRegistrations: notificationRegistrations,
+		Ledgers:                   ledgers,
+		LedgerEntries:             ledgerEntries,
+		PendingBondEdits:          pendingBondEdits,
+		NextOrderID:               nextOrderID,
+		OrderReceipts:             orderReceipts,
+		BondHistories:             bondHistories,
+		BondFeesCollected:         bondFeesCollected,
+		TotalFeesCollected:        totalFeesCollected,
+		SpendRecords:              spendRecords,
+		SanityRateWindows:         sanityRateWindows,
+		Params:                    params,
 	}
 }
 
-//noinspection GoUnusedParameter
 func ValidateGenesis(data GenesisState) error {
-	return nil
+	for _, b := range data.Bonds {
+		if !IsValidBondState(b.State) {
+			return sdkerrors.Wrapf(ErrInvalidBondState,
+				"bond %s has invalid state %s", b.Token, b.State)
+		}
+	}
+	for _, h := range data.BondHistories {
+		if len(h.Snapshots) > MaxBondHistoryLength {
+			return fmt.Errorf("history of bond %s has %d snapshots, more than the max of %d",
+				h.BondToken, len(h.Snapshots), MaxBondHistoryLength)
+		}
+	}
+	bondFeesCollected := sdk.Coins{}
+	for _, f := range data.BondFeesCollected {
+		if !f.Fees.IsValid() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins,
+				"fees collected by bond %s are invalid: %s", f.BondToken, f.Fees)
+		}
+		bondFeesCollected = bondFeesCollected.Add(f.Fees...)
+	}
+	if !data.TotalFeesCollected.IsValid() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins,
+			"total fees collected are invalid: %s", data.TotalFeesCollected)
+	} else if !data.TotalFeesCollected.IsAllGTE(bondFeesCollected) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins,
+			"total fees collected %s are less than the fees collected by bonds %s",
+			data.TotalFeesCollected, bondFeesCollected)
+	}
+	for _, r := range data.OrderReceipts {
+		if r.Receipt != r.GetReceiptHash() {
+			return sdkerrors.Wrapf(ErrInvalidOrderReceipt,
+				"order receipt %s does not match its order details", r.Receipt)
+		} else if r.OrderID >= data.NextOrderID && data.NextOrderID != 0 {
+			return sdkerrors.Wrapf(ErrInvalidOrderReceipt,
+				"order ID %d of receipt %s is not less than the next order ID %d",
+				r.OrderID, r.Receipt, data.NextOrderID)
+		}
+	}
+	for _, e := range data.SpendRecords {
+		if e.Address.Empty() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "spend records address is empty")
+		}
+		for i, r := range e.Records {
+			if !r.Amount.IsValid() {
+				return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins,
+					"spend record of %s is invalid: %s", e.Address, r.Amount)
+			} else if i > 0 && r.Height < e.Records[i-1].Height {
+				return fmt.Errorf("spend records of %s are not ordered by height", e.Address)
+			}
+		}
+	}
+	for _, w := range data.SanityRateWindows {
+		if err := w.Window.Validate(); err != nil {
+			return fmt.Errorf("sanity rate window of bond %s is invalid: %s",
+				w.BondToken, err)
+		}
+	}
+	return data.Params.Validate()
 }
 
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Bonds:   nil,
-		Batches: nil,
+		Bonds:                     nil,
+		Batches:                   nil,
+		ScheduledParamChanges:     nil,
+		BondProposals:             nil,
+		BondProposalVotes:         nil,
+		VestingSchedules:          nil,
+		NotificationRegistrations: nil,
+		Ledgers:                   nil,
+		LedgerEntries:             nil,
+		PendingBondEdits:          nil,
+		NextOrderID:               1,
+		OrderReceipts:             nil,
+		BondHistories:             nil,
+		BondFeesCollected:         nil,
+		TotalFeesCollected:        nil,
+		SpendRecords:              nil,
+		SanityRateWindows:         nil,
+		Params:                    DefaultParams(),
 	}
 }
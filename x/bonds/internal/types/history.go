@@ -0,0 +1,88 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxBondHistoryLength is the number of snapshots kept in a bond's history.
+// Once the history is full, recording a snapshot drops the oldest snapshot.
+const MaxBondHistoryLength = 100
+
+// BondSnapshot records a bond's supply, reserve, and current prices at the
+// height at which one of its batches was performed, along with the volumes of
+// that batch and the total fees collected by the bond up to the height.
+// Snapshots recorded before prices, volumes, and fees were recorded have none.
+type BondSnapshot struct {
+	Height        int64        `json:"height" yaml:"height"`
+	Supply        sdk.Coin     `json:"supply" yaml:"supply"`
+	Reserve       sdk.Coins    `json:"reserve" yaml:"reserve"`
+	Prices        sdk.DecCoins `json:"prices,omitempty" yaml:"prices,omitempty"`
+	Bought        sdk.Coins    `json:"bought,omitempty" yaml:"bought,omitempty"`
+	Sold          sdk.Coins    `json:"sold,omitempty" yaml:"sold,omitempty"`
+	Swapped       sdk.Coins    `json:"swapped,omitempty" yaml:"swapped,omitempty"`
+	FeesCollected sdk.Coins    `json:"fees_collected,omitempty" yaml:"fees_collected,omitempty"`
+}
+
+func NewBondSnapshot(bond Bond, prices sdk.DecCoins, height int64) BondSnapshot {
+	return BondSnapshot{
+		Height:  height,
+		Supply:  bond.CurrentSupply,
+		Reserve: bond.CurrentReserve,
+		Prices:  prices,
+	}
+}
+
+// WithBatchResult returns the snapshot with the volumes of the batch (i.e. the
+// bond tokens bought and sold, and reserve tokens swapped) set.
+func (s BondSnapshot) WithBatchResult(result BatchResult) BondSnapshot {
+	s.Bought = sdk.NewCoins(result.TotalBought)
+	s.Sold = sdk.NewCoins(result.TotalSold)
+	s.Swapped = result.TotalSwapped
+	return s
+}
+
+func (s BondSnapshot) String() string {
+	return fmt.Sprintf("%d: supply %s, reserve %s, prices %s",
+		s.Height, s.Supply, s.Reserve, s.Prices)
+}
+
+// BondHistory is a ring buffer of a bond's most recent snapshots, from oldest
+// to newest.
+type BondHistory []BondSnapshot
+
+// Add returns the history with the snapshot appended, dropping the oldest
+// snapshots if the history would otherwise exceed MaxBondHistoryLength.
+func (h BondHistory) Add(snapshot BondSnapshot) BondHistory {
+	h = append(h, snapshot)
+	if len(h) > MaxBondHistoryLength {
+		h = h[len(h)-MaxBondHistoryLength:]
+	}
+	return h
+}
+
+// AtHeight returns the most recent snapshot recorded at or before the height,
+// i.e. the snapshot of the last batch boundary at the height. False is
+// returned if the height is before the oldest snapshot in the history.
+func (h BondHistory) AtHeight(height int64) (BondSnapshot, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		if h[i].Height <= height {
+			return h[i], true
+		}
+	}
+	return BondSnapshot{}, false
+}
+
+// BondHistoryEntry is a bond's history along with the bond's token, as
+// included in the genesis state.
+type BondHistoryEntry struct {
+	BondToken string      `json:"bond_token" yaml:"bond_token"`
+	Snapshots BondHistory `json:"snapshots" yaml:"snapshots"`
+}
+
+func NewBondHistoryEntry(bondToken string, snapshots BondHistory) BondHistoryEntry {
+	return BondHistoryEntry{
+		BondToken: bondToken,
+		Snapshots: snapshots,
+	}
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBondHistoryAddDropsOldestSnapshotsWhenFull(t *testing.T) {
+	history := BondHistory{}
+	for height := int64(1); height <= MaxBondHistoryLength+5; height++ {
+		history = history.Add(BondSnapshot{
+			Height:  height,
+			Supply:  sdk.NewInt64Coin("abc", height),
+			Reserve: sdk.NewCoins(sdk.NewInt64Coin("res", height*10)),
+		})
+	}
+
+	require.Len(t, history, MaxBondHistoryLength)
+	require.Equal(t, int64(6), history[0].Height)
+	require.Equal(t, int64(MaxBondHistoryLength+5), history[len(history)-1].Height)
+}
+
+func TestBondHistoryAtHeight(t *testing.T) {
+	history := BondHistory{}
+	for _, height := range []int64{10, 20, 30} {
+		history = history.Add(BondSnapshot{
+			Height: height,
+			Supply: sdk.NewInt64Coin("abc", height),
+		})
+	}
+
+	testCases := []struct {
+		height         int64
+		found          bool
+		snapshotHeight int64
+	}{
+		{9, false, 0},
+		{10, true, 10},
+		{19, true, 10},
+		{20, true, 20},
+		{100, true, 30},
+	}
+	for _, tc := range testCases {
+		snapshot, found := history.AtHeight(tc.height)
+		require.Equal(t, tc.found, found)
+		require.Equal(t, tc.snapshotHeight, snapshot.Height)
+	}
+
+	_, found := BondHistory{}.AtHeight(10)
+	require.False(t, found)
+}
+
+func TestValidateGenesisRejectsOverlongBondHistory(t *testing.T) {
+	snapshots := make(BondHistory, MaxBondHistoryLength)
+	genesis := DefaultGenesisState()
+	genesis.BondHistories = []BondHistoryEntry{NewBondHistoryEntry(initToken, snapshots)}
+	require.Nil(t, ValidateGenesis(genesis))
+
+	snapshots = append(snapshots, BondSnapshot{})
+	genesis.BondHistories = []BondHistoryEntry{NewBondHistoryEntry(initToken, snapshots)}
+	require.Error(t, ValidateGenesis(genesis))
+}
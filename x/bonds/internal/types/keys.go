@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of this module
 	ModuleName = "bonds"
@@ -16,6 +20,19 @@ const (
 	// BondsReserveAccount the root string for the bonds reserve account address
 	BondsReserveAccount = "bonds_reserve_account"
 
+	// BondProposalsAccount the root string for the bond proposals account
+	// address, which holds the bond tokens of votes cast on bond proposals
+	BondProposalsAccount = "bond_proposals_account"
+
+	// BondVestingAccount the root string for the bond vesting account address,
+	// which holds the pre-mined bond tokens that have not vested yet
+	BondVestingAccount = "bond_vesting_account"
+
+	// NotificationDepositsAccount the root string for the notification
+	// deposits account address, which holds the deposits of the relayers
+	// registered for bond notifications
+	NotificationDepositsAccount = "notification_deposits_account"
+
 	// QuerierRoute is the querier route for this module's store.
 	QuerierRoute = ModuleName
 
@@ -28,10 +45,58 @@ const (
 // - Bonds: 0x00<bond_token_bytes>
 // - Batches: 0x01<bond_token_bytes>
 // - Last batches: 0x02<bond_token_bytes>
+// - Last batch results: 0x03<bond_token_bytes>
+// - Module stats: 0x04
+// - Bond search index: 0x05<bond_token_bytes>
+// - Next order ID: 0x06
+// - Order receipts: 0x07<receipt_bytes>
+// - Scheduled param changes: 0x08<bond_token_bytes>
+// - Bond proposals: 0x09<proposal_id_bytes>
+// - Bond proposal votes: 0x0A<proposal_id_bytes><voter_address_bytes>
+// - Next bond proposal ID: 0x0B
+// - Bond histories: 0x0C<bond_token_bytes>
+// - Spend records: 0x0D<address_bytes>
+// - Bond fees collected: 0x0E<bond_token_bytes>
+// - Sanity rate windows: 0x0F<bond_token_bytes>
+// - Vesting schedules: 0x10<bond_token_bytes>
+// - Alert windows: 0x11<bond_token_bytes>
+// - Pending refunds: 0x12<bond_token_bytes>
+// - Bond token reservations: 0x13<bond_token_bytes>
+// - Notification registrations: 0x14<bond_token_bytes>/<relayer_address_bytes>
+// - Batch stresses: 0x15<bond_token_bytes>
+// - Ledgers: 0x16<bond_token_bytes>
+// - Ledger entries: 0x17<bond_token_bytes>/<sequence_bytes>
+// - Pending bond edits: 0x18<bond_token_bytes>
+// - Order receipts by height: 0x19<height_bytes><receipt_bytes>
+// - Ledger entries by height: 0x1A<height_bytes><bond_token_bytes>/<sequence_bytes>
 var (
-	BondsKeyPrefix       = []byte{0x00} // key for bonds
-	BatchesKeyPrefix     = []byte{0x01} // key for batches
-	LastBatchesKeyPrefix = []byte{0x02} // key for last batches
+	BondsKeyPrefix            = []byte{0x00} // key for bonds
+	BatchesKeyPrefix          = []byte{0x01} // key for batches
+	LastBatchesKeyPrefix      = []byte{0x02} // key for last batches
+	LastBatchResultsKeyPrefix = []byte{0x03} // key for last batch results
+	ModuleStatsKey            = []byte{0x04} // key for module stats
+	BondSearchIndexKeyPrefix  = []byte{0x05} // key for bond search index
+	NextOrderIDKey            = []byte{0x06} // key for next order ID
+	OrderReceiptsKeyPrefix    = []byte{0x07} // key for order receipts
+	ScheduledChangesKeyPrefix = []byte{0x08} // key for scheduled param changes
+	BondProposalsKeyPrefix    = []byte{0x09} // key for bond proposals
+	BondProposalVotesPrefix   = []byte{0x0A} // key for bond proposal votes
+	NextBondProposalIDKey     = []byte{0x0B} // key for next bond proposal ID
+	BondHistoriesKeyPrefix    = []byte{0x0C} // key for bond histories
+	SpendRecordsKeyPrefix     = []byte{0x0D} // key for spend records
+	BondFeesKeyPrefix         = []byte{0x0E} // key for bond fees collected
+	SanityWindowsKeyPrefix    = []byte{0x0F} // key for sanity rate windows
+	VestingKeyPrefix          = []byte{0x10} // key for vesting schedules
+	AlertWindowsKeyPrefix     = []byte{0x11} // key for alert windows
+	PendingRefundsKeyPrefix   = []byte{0x12} // key for pending refunds
+	ReservationsKeyPrefix     = []byte{0x13} // key for bond token reservations
+	NotificationsKeyPrefix    = []byte{0x14} // key for notification registrations
+	BatchStressesKeyPrefix    = []byte{0x15} // key for batch stresses
+	LedgersKeyPrefix          = []byte{0x16} // key for ledgers
+	LedgerEntriesKeyPrefix    = []byte{0x17} // key for ledger entries
+	PendingEditsKeyPrefix     = []byte{0x18} // key for pending bond edits
+	ReceiptHeightsKeyPrefix   = []byte{0x19} // key for order receipts by height
+	LedgerHeightsKeyPrefix    = []byte{0x1A} // key for ledger entries by height
 )
 
 func GetBondKey(token string) []byte {
@@ -45,3 +110,121 @@ func GetBatchKey(token string) []byte {
 func GetLastBatchKey(token string) []byte {
 	return append(LastBatchesKeyPrefix, []byte(token)...)
 }
+
+func GetLastBatchResultKey(token string) []byte {
+	return append(LastBatchResultsKeyPrefix, []byte(token)...)
+}
+
+func GetBondSearchIndexKey(token string) []byte {
+	return append(BondSearchIndexKeyPrefix, []byte(token)...)
+}
+
+func GetOrderReceiptKey(receipt string) []byte {
+	return append(OrderReceiptsKeyPrefix, []byte(receipt)...)
+}
+
+// GetReceiptHeightKey returns the prefix of the keys of the order receipts
+// issued at the height. Heights are big-endian encoded, so that receipts are
+// iterated over from the oldest to the newest.
+func GetReceiptHeightKey(height int64) []byte {
+	return append(ReceiptHeightsKeyPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+func GetOrderReceiptHeightKey(height int64, receipt string) []byte {
+	return append(GetReceiptHeightKey(height), []byte(receipt)...)
+}
+
+func GetScheduledChangeKey(token string) []byte {
+	return append(ScheduledChangesKeyPrefix, []byte(token)...)
+}
+
+func GetBondHistoryKey(token string) []byte {
+	return append(BondHistoriesKeyPrefix, []byte(token)...)
+}
+
+func GetSpendRecordsKey(address sdk.AccAddress) []byte {
+	return append(SpendRecordsKeyPrefix, address.Bytes()...)
+}
+
+func GetBondFeesKey(token string) []byte {
+	return append(BondFeesKeyPrefix, []byte(token)...)
+}
+
+func GetSanityRateWindowKey(token string) []byte {
+	return append(SanityWindowsKeyPrefix, []byte(token)...)
+}
+
+func GetVestingScheduleKey(token string) []byte {
+	return append(VestingKeyPrefix, []byte(token)...)
+}
+
+func GetAlertWindowKey(token string) []byte {
+	return append(AlertWindowsKeyPrefix, []byte(token)...)
+}
+
+func GetPendingRefundsKey(token string) []byte {
+	return append(PendingRefundsKeyPrefix, []byte(token)...)
+}
+
+func GetReservationKey(token string) []byte {
+	return append(ReservationsKeyPrefix, []byte(token)...)
+}
+
+// GetBondNotificationsKey returns the prefix of the keys of the notification
+// registrations for the bond. The token is terminated by a slash (which cannot
+// appear in a denomination), so that the registrations for one bond are never
+// iterated over together with those for a bond whose token it is a prefix of.
+func GetBondNotificationsKey(token string) []byte {
+	return append(NotificationsKeyPrefix, []byte(token+"/")...)
+}
+
+func GetNotificationRegistrationKey(token string, relayer sdk.AccAddress) []byte {
+	return append(GetBondNotificationsKey(token), relayer.Bytes()...)
+}
+
+func GetBondProposalKey(proposalID uint64) []byte {
+	return append(BondProposalsKeyPrefix, sdk.Uint64ToBigEndian(proposalID)...)
+}
+
+func GetBondProposalVotesKey(proposalID uint64) []byte {
+	return append(BondProposalVotesPrefix, sdk.Uint64ToBigEndian(proposalID)...)
+}
+
+func GetBondProposalVoteKey(proposalID uint64, voter sdk.AccAddress) []byte {
+	return append(GetBondProposalVotesKey(proposalID), voter.Bytes()...)
+}
+
+func GetBatchStressKey(token string) []byte {
+	return append(BatchStressesKeyPrefix, []byte(token)...)
+}
+
+func GetLedgerKey(token string) []byte {
+	return append(LedgersKeyPrefix, []byte(token)...)
+}
+
+// GetBondLedgerEntriesKey returns the prefix of the keys of the bond's ledger
+// entries, with the token terminated by a slash for the same reason as in
+// GetBondNotificationsKey.
+func GetBondLedgerEntriesKey(token string) []byte {
+	return append(LedgerEntriesKeyPrefix, []byte(token+"/")...)
+}
+
+func GetLedgerEntryKey(token string, sequence uint64) []byte {
+	return append(GetBondLedgerEntriesKey(token), sdk.Uint64ToBigEndian(sequence)...)
+}
+
+// GetLedgerHeightKey returns the prefix of the keys of the ledger entries
+// recorded at the height, which are followed by the entry's key without its
+// prefix, so that entries are iterated over from the oldest to the newest.
+func GetLedgerHeightKey(height int64) []byte {
+	return append(LedgerHeightsKeyPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+func GetLedgerEntryHeightKey(height int64, token string, sequence uint64) []byte {
+	return append(GetLedgerHeightKey(height),
+		GetLedgerEntryKey(token, sequence)[len(LedgerEntriesKeyPrefix):]...)
+}
+
+func GetPendingBondEditKey(token string) []byte {
+	return append(PendingEditsKeyPrefix, []byte(token)...)
+}
@@ -0,0 +1,240 @@
+package types
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// A bond's ledger records every movement of funds caused by the module on the
+// bond's behalf as a double-entry record: the funds are debited to the account
+// that they move into and credited to the account that they move out of, so
+// the total debits of a ledger always equal its total credits. The reserve and
+// escrow accounts are the bond's reserve and escrow, the supply account is the
+// bond token's supply (from which tokens are minted and into which they are
+// burned) and the external account is anything outside of the module, such as
+// the address of a buyer, a seller, or the fee address.
+
+const (
+	LedgerAccountExternal = "external"
+	LedgerAccountEscrow   = "escrow"
+	LedgerAccountReserve  = "reserve"
+	LedgerAccountSupply   = "supply"
+
+	LedgerEntryEscrowIn   = "escrow_in"
+	LedgerEntryEscrowOut  = "escrow_out"
+	LedgerEntryReserveIn  = "reserve_in"
+	LedgerEntryReserveOut = "reserve_out"
+	LedgerEntryFee        = "fee"
+	LedgerEntryRefund     = "refund"
+	LedgerEntryMint       = "mint"
+	LedgerEntryBurn       = "burn"
+
+	// DefaultLedgerEntriesLimit is the number of entries per page returned by
+	// a ledger query if no limit is specified.
+	DefaultLedgerEntriesLimit = 50
+	// MaxLedgerEntriesLimit is the maximum number of entries per page returned
+	// by a ledger query.
+	MaxLedgerEntriesLimit = 100
+)
+
+// LedgerEntry is a movement of funds of a bond from the credit account to the
+// debit account. The address is the address outside of the module that the
+// funds came from or went to, if either account is the external account.
+type LedgerEntry struct {
+	Bond     string         `json:"bond" yaml:"bond"`
+	Sequence uint64         `json:"sequence" yaml:"sequence"`
+	Height   int64          `json:"height" yaml:"height"`
+	Kind     string         `json:"kind" yaml:"kind"`
+	Debit    string         `json:"debit" yaml:"debit"`
+	Credit   string         `json:"credit" yaml:"credit"`
+	Amount   sdk.Coins      `json:"amount" yaml:"amount"`
+	Address  sdk.AccAddress `json:"address" yaml:"address"`
+}
+
+func NewLedgerEntry(token string, sequence uint64, height int64, kind, debit,
+	credit string, amount sdk.Coins, address sdk.AccAddress) LedgerEntry {
+	return LedgerEntry{
+		Bond:     token,
+		Sequence: sequence,
+		Height:   height,
+		Kind:     kind,
+		Debit:    debit,
+		Credit:   credit,
+		Amount:   amount,
+		Address:  address,
+	}
+}
+
+// LedgerBalance is the running total of the funds debited to and credited
+// from an account of a bond's ledger.
+type LedgerBalance struct {
+	Account string    `json:"account" yaml:"account"`
+	Debits  sdk.Coins `json:"debits" yaml:"debits"`
+	Credits sdk.Coins `json:"credits" yaml:"credits"`
+}
+
+// Net returns the funds held by the account, i.e. its debits minus its
+// credits, ignoring any denominations of which more was credited than debited
+// (e.g. the bond token in the supply account, since tokens are minted from it).
+func (b LedgerBalance) Net() sdk.Coins {
+	net := sdk.Coins{}
+	for _, d := range b.Debits {
+		amount := d.Amount.Sub(b.Credits.AmountOf(d.Denom))
+		if amount.IsPositive() {
+			net = net.Add(sdk.NewCoin(d.Denom, amount))
+		}
+	}
+	return net
+}
+
+// BondLedger is the summary of a bond's ledger, i.e. the sequence number of
+// its next entry and the running balance of each of its accounts, in the order
+// in which the accounts were first used.
+type BondLedger struct {
+	Bond         string          `json:"bond" yaml:"bond"`
+	NextSequence uint64          `json:"next_sequence" yaml:"next_sequence"`
+	Balances     []LedgerBalance `json:"balances" yaml:"balances"`
+}
+
+func NewBondLedger(token string) BondLedger {
+	return BondLedger{
+		Bond:         token,
+		NextSequence: 0,
+		Balances:     nil,
+	}
+}
+
+// Balance returns the running balance of the account.
+func (l BondLedger) Balance(account string) LedgerBalance {
+	for _, b := range l.Balances {
+		if b.Account == account {
+			return b
+		}
+	}
+	return LedgerBalance{Account: account, Debits: sdk.Coins{}, Credits: sdk.Coins{}}
+}
+
+// Record returns the ledger with the entry applied to the running balances of
+// its debit and credit accounts and with its next sequence number incremented.
+func (l BondLedger) Record(entry LedgerEntry) BondLedger {
+	balances := make([]LedgerBalance, len(l.Balances))
+	copy(balances, l.Balances)
+	l.Balances = balances
+
+	l.setBalance(l.Balance(entry.Debit), entry.Amount, nil)
+	l.setBalance(l.Balance(entry.Credit), nil, entry.Amount)
+	l.NextSequence = entry.Sequence + 1
+	return l
+}
+
+func (l *BondLedger) setBalance(b LedgerBalance, debits, credits sdk.Coins) {
+	b.Debits = b.Debits.Add(debits...)
+	b.Credits = b.Credits.Add(credits...)
+	for i := range l.Balances {
+		if l.Balances[i].Account == b.Account {
+			l.Balances[i] = b
+			return
+		}
+	}
+	l.Balances = append(l.Balances, b)
+}
+
+// IsBalanced returns true if the total debits of the ledger's accounts equal
+// their total credits, as is always the case for a double-entry ledger.
+func (l BondLedger) IsBalanced() bool {
+	debits, credits := sdk.Coins{}, sdk.Coins{}
+	for _, b := range l.Balances {
+		debits = debits.Add(b.Debits...)
+		credits = credits.Add(b.Credits...)
+	}
+	return debits.IsEqual(credits)
+}
+
+// QueryLedgerParams are the pagination of a ledger query. Pages start from 1.
+type QueryLedgerParams struct {
+	Page  int `json:"page" yaml:"page"`
+	Limit int `json:"limit" yaml:"limit"`
+}
+
+func NewQueryLedgerParams(page, limit int) QueryLedgerParams {
+	return QueryLedgerParams{
+		Page:  page,
+		Limit: limit,
+	}
+}
+
+func (p QueryLedgerParams) Validate() error {
+	if p.Page < 1 {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "page")
+	} else if p.Limit < 1 || p.Limit > MaxLedgerEntriesLimit {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"limit must be between 1 and %d", MaxLedgerEntriesLimit)
+	}
+	return nil
+}
+
+// QueryLedger is a bond's ledger summary together with a page of its entries,
+// from oldest to newest. The total is the number of entries across all pages.
+type QueryLedger struct {
+	Ledger  BondLedger    `json:"ledger" yaml:"ledger"`
+	Page    int           `json:"page" yaml:"page"`
+	Limit   int           `json:"limit" yaml:"limit"`
+	Total   uint64        `json:"total" yaml:"total"`
+	Entries []LedgerEntry `json:"entries" yaml:"entries"`
+}
+
+// NewBondLedgerCSV exports the bond's ledger entries as CSV, with one row per
+// entry, from oldest to newest. The amount of an entry is given in a single
+// column, as a comma-separated list of coins.
+func NewBondLedgerCSV(entries []LedgerEntry) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := []string{"sequence", "height", "kind", "debit", "credit", "amount", "address"}
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+
+	for _, e := range entries {
+		address := ""
+		if !e.Address.Empty() {
+			address = e.Address.String()
+		}
+		row := []string{
+			strconv.FormatUint(e.Sequence, 10),
+			strconv.FormatInt(e.Height, 10),
+			e.Kind, e.Debit, e.Credit, e.Amount.String(), address,
+		}
+		if err := w.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// NewQueryLedgerExport exports the bond's ledger entries in the specified
+// format, which must be ExportFormatCSV.
+func NewQueryLedgerExport(token, format string, entries []LedgerEntry) (QueryBondExport, error) {
+	if format != ExportFormatCSV {
+		return QueryBondExport{}, sdkerrors.Wrap(ErrUnsupportedExportFormat, format)
+	}
+
+	content, err := NewBondLedgerCSV(entries)
+	if err != nil {
+		return QueryBondExport{}, err
+	}
+
+	return QueryBondExport{
+		Bond:    token,
+		Format:  format,
+		Content: content,
+	}, nil
+}
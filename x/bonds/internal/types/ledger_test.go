@@ -0,0 +1,61 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBondLedgerRecord(t *testing.T) {
+	escrowed := sdk.NewCoins(sdk.NewInt64Coin("res", 100))
+	deposited := sdk.NewCoins(sdk.NewInt64Coin("res", 90))
+	minted := sdk.NewCoins(sdk.NewInt64Coin("abc", 10))
+
+	ledger := NewBondLedger("abc")
+	require.True(t, ledger.IsBalanced())
+
+	ledger = ledger.Record(NewLedgerEntry("abc", 0, 1, LedgerEntryEscrowIn,
+		LedgerAccountEscrow, LedgerAccountExternal, escrowed, nil))
+	ledger = ledger.Record(NewLedgerEntry("abc", 1, 2, LedgerEntryReserveIn,
+		LedgerAccountReserve, LedgerAccountEscrow, deposited, nil))
+	ledger = ledger.Record(NewLedgerEntry("abc", 2, 2, LedgerEntryMint,
+		LedgerAccountExternal, LedgerAccountSupply, minted, nil))
+
+	// Every entry is debited to one account and credited from another
+	require.True(t, ledger.IsBalanced())
+	require.Equal(t, uint64(3), ledger.NextSequence)
+	require.Equal(t, deposited, ledger.Balance(LedgerAccountReserve).Net())
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("res", 10)),
+		ledger.Balance(LedgerAccountEscrow).Net())
+
+	// More bond tokens were credited from the supply account than debited
+	require.True(t, ledger.Balance(LedgerAccountSupply).Net().IsZero())
+	require.Equal(t, minted, ledger.Balance(LedgerAccountSupply).Credits)
+
+	// Unused accounts have empty balances
+	require.True(t, ledger.Balance("unused").Net().IsZero())
+}
+
+func TestNewBondLedgerCSV(t *testing.T) {
+	address := sdk.AccAddress("address1")
+	entries := []LedgerEntry{
+		NewLedgerEntry("abc", 0, 10, LedgerEntryEscrowIn, LedgerAccountEscrow,
+			LedgerAccountExternal, sdk.NewCoins(
+				sdk.NewInt64Coin("res", 100), sdk.NewInt64Coin("rez", 50)), address),
+		NewLedgerEntry("abc", 1, 20, LedgerEntryReserveIn, LedgerAccountReserve,
+			LedgerAccountEscrow, sdk.NewCoins(sdk.NewInt64Coin("res", 90)), nil),
+	}
+
+	// Coins in multiple denominations are quoted, since they contain commas
+	expected := "" +
+		"sequence,height,kind,debit,credit,amount,address\n" +
+		"0,10,escrow_in,escrow,external,\"100res,50rez\"," + address.String() + "\n" +
+		"1,20,reserve_in,reserve,escrow,90res,\n"
+
+	csv, err := NewBondLedgerCSV(entries)
+	require.Nil(t, err)
+	require.Equal(t, expected, csv)
+
+	_, err = NewQueryLedgerExport("abc", "xlsx", entries)
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Hard caps on the size of bond fields, which prevent unbounded strings and
+// parameter lists from bloating the state and breaking user interfaces. The
+// bond name and description length limits can be lowered further through the
+// MaxNameLength and MaxDescriptionLength module parameters.
+const (
+	MaxFunctionParams          = 8
+	MaxFunctionParamNameLength = 16
+	MaxBondNameLength          = 128
+	MaxBondDescriptionLength   = 1024
+	MaxCallbackPayloadLength   = 256
+	MaxOrderMemoLength         = 256
+)
+
+// CheckNameLength checks that the bond name does not exceed the specified
+// maximum length (in bytes).
+func CheckNameLength(name string, maxLength uint64) error {
+	if uint64(len(name)) > maxLength {
+		return sdkerrors.Wrapf(ErrArgumentTooLong,
+			"Name is longer than %d characters", maxLength)
+	}
+	return nil
+}
+
+// CheckBatchBlocks checks that the number of blocks of a bond's batches is
+// within the specified bounds (inclusive).
+func CheckBatchBlocks(batchBlocks sdk.Uint, minBatchBlocks, maxBatchBlocks uint64) error {
+	if batchBlocks.LT(sdk.NewUint(minBatchBlocks)) || batchBlocks.GT(sdk.NewUint(maxBatchBlocks)) {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"BatchBlocks must be between %d and %d", minBatchBlocks, maxBatchBlocks)
+	}
+	return nil
+}
+
+// CheckDescriptionLength checks that the bond description does not exceed the
+// specified maximum length (in bytes).
+func CheckDescriptionLength(description string, maxLength uint64) error {
+	if uint64(len(description)) > maxLength {
+		return sdkerrors.Wrapf(ErrArgumentTooLong,
+			"Description is longer than %d characters", maxLength)
+	}
+	return nil
+}
+
+// CheckOrderMemoLength checks that the order memo does not exceed the maximum
+// order memo length (in bytes).
+func CheckOrderMemoLength(memo string) error {
+	if len(memo) > MaxOrderMemoLength {
+		return sdkerrors.Wrapf(ErrArgumentTooLong,
+			"Memo is longer than %d characters", MaxOrderMemoLength)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The logarithmic function's price a*ln(1+b*x) and reserve
+// (a/b)*((1+b*x)*ln(1+b*x)-b*x) are computed using sdk.Dec arithmetic only, so
+// every node computes identical results regardless of its architecture.
+
+var (
+	// ln2 is ln(2) truncated to sdk.Precision decimal places
+	ln2 = sdk.MustNewDecFromStr("0.693147180559945309")
+
+	// lnSeriesTerms is the max number of terms of the series of ln(m) (for
+	// 1 <= m < 2) that are summed, which is more than enough for the terms to
+	// become zero at sdk.Precision decimal places
+	lnSeriesTerms = 40
+)
+
+// ApproxLn returns ln(y) for y >= 1. The argument is first reduced to m in
+// [1, 2) by halving it k times, so that ln(y) = k*ln(2) + ln(m), and ln(m) is
+// evaluated by summing the series 2*(z + z^3/3 + z^5/5 + ...) with
+// z = (m-1)/(m+1) <= 1/3, which converges quickly, so the result is
+// deterministic.
+func ApproxLn(y sdk.Dec) (sdk.Dec, error) {
+	if y.LT(sdk.OneDec()) {
+		return sdk.Dec{}, sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"logarithm argument %s must be at least 1", y)
+	}
+
+	two := sdk.NewDec(2)
+	k := int64(0)
+	m := y
+	for m.GTE(two) {
+		m = m.Quo(two)
+		k++
+	}
+
+	z := m.Sub(sdk.OneDec()).Quo(m.Add(sdk.OneDec()))
+	z2 := z.Mul(z)
+	sum, power := sdk.ZeroDec(), z
+	for i := int64(0); i < int64(lnSeriesTerms) && !power.IsZero(); i++ {
+		sum = sum.Add(power.QuoInt64(2*i + 1))
+		power = power.Mul(z2)
+	}
+
+	return ln2.MulInt64(k).Add(sum.MulInt64(2)), nil
+}
+
+// logarithmicFunctionPrice returns the logarithmic function's price
+// a*ln(1+b*x) at the supply x
+func logarithmicFunctionPrice(a, b, x sdk.Dec) (sdk.Dec, error) {
+	ln, err := ApproxLn(sdk.OneDec().Add(b.Mul(x)))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return a.Mul(ln), nil
+}
+
+// logarithmicFunctionReserve returns the logarithmic function's reserve at the
+// supply x, i.e. the integral of the price from 0 to x,
+// (a/b)*((1+b*x)*ln(1+b*x)-b*x)
+func logarithmicFunctionReserve(a, b, x sdk.Dec) (sdk.Dec, error) {
+	bx := b.Mul(x)
+	ln, err := ApproxLn(sdk.OneDec().Add(bx))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return a.Mul(sdk.OneDec().Add(bx).Mul(ln).Sub(bx)).Quo(b), nil
+}
+
+func logarithmicParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Logarithmic exception 1: a != 0, otherwise the price is always zero
+	// Logarithmic exception 2: b != 0, otherwise we run into divisions by zero
+	for _, p := range []string{"a", "b"} {
+		val, ok := paramsMap[p]
+		if !ok {
+			panic("did not find parameter " + p + " for logarithmic function")
+		} else if !val.IsPositive() {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:"+p)
+		}
+	}
+	return nil
+}
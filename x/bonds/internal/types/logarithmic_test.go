@@ -0,0 +1,63 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestApproxLn(t *testing.T) {
+	// Expected values are ln(y) to 18 decimal places (rounded)
+	testCases := []struct {
+		y        string
+		expected string
+	}{
+		{"1", "0"},
+		{"1.5", "0.405465108108164382"},
+		{"1.999999", "0.693146680559820309"},
+		{"2", "0.693147180559945309"},
+		{"10", "2.302585092994045684"},
+		{"1001", "6.908754779315220585"},
+		{"123456789.123", "18.631401767164318042"},
+	}
+
+	// Results are expected to be within an absolute error of 1e-15
+	tolerance := sdk.NewDecWithPrec(1, 15)
+	for _, tc := range testCases {
+		actual, err := ApproxLn(sdk.MustNewDecFromStr(tc.y))
+		require.Nil(t, err)
+
+		expected := sdk.MustNewDecFromStr(tc.expected)
+		require.True(t, actual.Sub(expected).Abs().LTE(tolerance),
+			"ln(%s): expected %s, got %s", tc.y, expected, actual)
+	}
+}
+
+func TestApproxLnOfPowerOfTwoIsExact(t *testing.T) {
+	actual, err := ApproxLn(sdk.NewDec(1024))
+	require.Nil(t, err)
+	require.Equal(t, ln2.MulInt64(10), actual)
+}
+
+func TestApproxLnOutOfRangeGivesError(t *testing.T) {
+	_, err := ApproxLn(sdk.MustNewDecFromStr("0.999999999999999999"))
+	require.Error(t, err)
+}
+
+func TestLogarithmicFunctionReserveIsIntegralOfPrice(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionType = LogarithmicFunction
+	bond.FunctionParameters = functionParametersLogarithmic()
+
+	// The reserve added by the 1001st token is between the prices before and
+	// after it, since the price is increasing
+	reserveDelta := bond.ReserveAtSupply(sdk.NewInt(1001)).Sub(
+		bond.ReserveAtSupply(sdk.NewInt(1000)))
+	priceBefore, err := bond.GetPricesAtSupply(sdk.NewInt(1000))
+	require.Nil(t, err)
+	priceAfter, err := bond.GetPricesAtSupply(sdk.NewInt(1001))
+	require.Nil(t, err)
+
+	require.True(t, reserveDelta.GT(priceBefore[0].Amount))
+	require.True(t, reserveDelta.LT(priceAfter[0].Amount))
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Behaviours of a bond once its supply reaches exactly its max supply
+const (
+	// AtMaxSupplyAllowRebuys leaves the bond as it is, so buys are only
+	// rejected while they would exceed the max supply, and are possible again
+	// once tokens are burned (e.g. by sells)
+	AtMaxSupplyAllowRebuys = "allow_rebuys"
+
+	// AtMaxSupplyCloseToBuys closes the bond to buys for good, even if tokens
+	// are burned afterwards
+	AtMaxSupplyCloseToBuys = "close_to_buys"
+
+	// AtMaxSupplyAutoSettle moves the bond to its SETTLE state, in which the
+	// bond's holders can withdraw their share of the bond's reserve
+	AtMaxSupplyAutoSettle = "auto_settle"
+)
+
+func CheckAtMaxSupplyBehavior(behavior string) error {
+	switch behavior {
+	case AtMaxSupplyAllowRebuys, AtMaxSupplyCloseToBuys, AtMaxSupplyAutoSettle:
+		return nil
+	default:
+		return sdkerrors.Wrap(ErrInvalidAtMaxSupplyBehavior, behavior)
+	}
+}
+
+// HasReachedMaxSupply returns true if the bond's supply is exactly its max
+// supply.
+func (bond Bond) HasReachedMaxSupply() bool {
+	return bond.CurrentSupply.Amount.Equal(bond.MaxSupply.Amount)
+}
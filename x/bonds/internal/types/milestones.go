@@ -0,0 +1,101 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Milestone is a reserve threshold of a bond, pre-declared at bond creation,
+// at which a set of changes is automatically applied to the bond. Milestones
+// are reached in order, so each milestone's threshold must be greater than the
+// previous milestone's threshold. When a milestone is reached:
+//
+// The funding tranche (if any) is released from the reserve to the bond's fee
+// address. The augmented function's theta is set to the milestone's theta (if
+// UpdateTheta is set and the bond is still in its hatch phase), reducing the
+// fraction of the raise that goes to the fee address from then on. Sells are
+// enabled (if EnableSells is set).
+type Milestone struct {
+	ReserveThreshold sdk.Coins `json:"reserve_threshold" yaml:"reserve_threshold"`
+	FundingTranche   sdk.Coins `json:"funding_tranche" yaml:"funding_tranche"`
+	UpdateTheta      bool      `json:"update_theta" yaml:"update_theta"`
+	Theta            sdk.Dec   `json:"theta" yaml:"theta"`
+	EnableSells      bool      `json:"enable_sells" yaml:"enable_sells"`
+}
+
+func NewMilestone(reserveThreshold, fundingTranche sdk.Coins,
+	updateTheta bool, theta sdk.Dec, enableSells bool) Milestone {
+	return Milestone{
+		ReserveThreshold: reserveThreshold,
+		FundingTranche:   fundingTranche,
+		UpdateTheta:      updateTheta,
+		Theta:            theta,
+		EnableSells:      enableSells,
+	}
+}
+
+// IsReached returns true if the reserve meets the milestone's threshold.
+func (m Milestone) IsReached(reserve sdk.Coins) bool {
+	return reserve.IsAllGTE(m.ReserveThreshold)
+}
+
+// ValidateMilestones checks that the milestones of a bond with the specified
+// reserve tokens, function type, and function parameters are valid.
+func ValidateMilestones(milestones []Milestone, reserveTokens []string,
+	functionType string, functionParameters FunctionParams) error {
+
+	reserveDenoms := make(map[string]bool)
+	for _, r := range reserveTokens {
+		reserveDenoms[r] = true
+	}
+
+	var previousThreshold sdk.Coins
+	var previousTheta sdk.Dec
+	if functionType == AugmentedFunction {
+		previousTheta = functionParameters.AsMap()["theta"]
+	}
+
+	for i, m := range milestones {
+		// Check threshold and tranche are valid amounts of reserve tokens
+		if !isValidCoins(m.ReserveThreshold) || m.ReserveThreshold.Empty() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins,
+				"milestone %d reserve threshold is invalid", i)
+		} else if !isValidCoins(m.FundingTranche) {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins,
+				"milestone %d funding tranche is invalid", i)
+		}
+		for _, c := range m.ReserveThreshold.Add(m.FundingTranche...) {
+			if !reserveDenoms[c.Denom] {
+				return sdkerrors.Wrapf(ErrReserveDenomsMismatch,
+					"milestone %d denom %s is not a reserve token", i, c.Denom)
+			}
+		}
+
+		// Check that the tranche does not exceed the threshold, so that the
+		// reserve can always afford the tranche once the threshold is met
+		if !m.FundingTranche.IsAllLTE(m.ReserveThreshold) {
+			return sdkerrors.Wrapf(ErrInvalidMilestone,
+				"milestone %d funding tranche exceeds its reserve threshold", i)
+		}
+
+		// Check that thresholds are increasing (in every reserve token of the
+		// previous threshold), since milestones are reached in order
+		if i > 0 && !m.ReserveThreshold.IsAllGT(previousThreshold) {
+			return sdkerrors.Wrapf(ErrInvalidMilestone,
+				"milestone %d reserve threshold is not greater than the previous one", i)
+		}
+		previousThreshold = m.ReserveThreshold
+
+		// Check that theta (if updated) is reduced and is not negative
+		if m.UpdateTheta {
+			if functionType != AugmentedFunction {
+				return sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, functionType)
+			} else if m.Theta.IsNil() || m.Theta.IsNegative() || m.Theta.GTE(previousTheta) {
+				return sdkerrors.Wrapf(ErrInvalidMilestone,
+					"milestone %d theta must be at least 0 and less than %s", i, previousTheta)
+			}
+			previousTheta = m.Theta
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetMinReservePercentage returns the bond's min reserve percentage. Bonds
+// created before the min reserve was introduced have no percentage set and
+// have no min reserve.
+func (bond Bond) GetMinReservePercentage() sdk.Dec {
+	if bond.MinReservePercentage.IsNil() {
+		return sdk.ZeroDec()
+	}
+	return bond.MinReservePercentage
+}
+
+// GetMinReserve returns the reserve balance below which the bond's sells are
+// deferred, given the bond's supply along the curve once the sells have been
+// performed. For each reserve token, the greater of the absolute min reserve
+// and the min reserve percentage of the reserve implied by the curve at that
+// supply applies. The percentage is ignored for swapper bonds, since their
+// reserve is not implied by their supply.
+func (bond Bond) GetMinReserve(curveSupply sdk.Int) (minReserve sdk.Coins, floored bool) {
+	impliedMinReserve := sdk.ZeroInt()
+	percentage := bond.GetMinReservePercentage()
+	if percentage.IsPositive() && !IsSwapperFunctionType(bond.FunctionType) {
+		impliedMinReserve = NewPercentage(percentage).AsFraction().Mul(
+			bond.ReserveAtSupply(curveSupply)).Ceil().TruncateInt()
+	}
+
+	minReserve = sdk.Coins{}
+	for _, r := range bond.ReserveTokens {
+		amount := bond.MinReserve.AmountOf(r)
+		if impliedMinReserve.GT(amount) {
+			amount = impliedMinReserve
+		}
+		if amount.IsPositive() {
+			minReserve = minReserve.Add(sdk.NewCoin(r, amount))
+		}
+	}
+
+	return minReserve, !minReserve.Empty()
+}
+
+// GetSellsReserve returns the reserve that the batch's sells (excluding any
+// cancelled sells) take out of the bond's reserve at the batch's sell prices,
+// including any fees and demurrage charged on the returns.
+func (b Batch) GetSellsReserve() sdk.Coins {
+	reserve := sdk.Coins{}
+	for _, so := range b.Sells {
+		if !so.IsCancelled() {
+			reserveReturns := MultiplyDecCoinsByInt(b.SellPrices, so.Amount.Amount)
+			reserve = reserve.Add(RoundReserveReturns(reserveReturns)...)
+		}
+	}
+	return reserve
+}
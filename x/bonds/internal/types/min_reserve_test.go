@@ -0,0 +1,74 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetMinReserve(t *testing.T) {
+	bond := getValidBond()
+
+	// Reserve implied by the curve at a supply of 10 is 5000res
+	curveSupply := sdk.NewInt(10)
+
+	testCases := []struct {
+		minReserve           string
+		minReservePercentage string
+		expectedMinReserve   string
+		expectedFloored      bool
+	}{
+		{"", "0", "", false},             // no min reserve
+		{"300res", "0", "300res", true},  // absolute min reserve only
+		{"", "10", "500res", true},       // 10% of 5000
+		{"", "0.01", "1res", true},       // 0.01% of 5000 rounded up
+		{"300res", "10", "500res", true}, // percentage is greater
+		{"800res", "10", "800res", true}, // absolute min reserve is greater
+		{"300rez", "0", "", false},       // not a reserve token
+	}
+	for _, tc := range testCases {
+		bond.MinReserve, _ = sdk.ParseCoins(tc.minReserve)
+		bond.MinReservePercentage = sdk.MustNewDecFromStr(tc.minReservePercentage)
+
+		minReserve, floored := bond.GetMinReserve(curveSupply)
+		require.Equal(t, tc.expectedFloored, floored)
+		require.Equal(t, tc.expectedMinReserve, minReserve.String())
+	}
+}
+
+func TestGetMinReserveIgnoresPercentageForSwapper(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionType = SwapperFunction
+	bond.ReserveTokens = swapperReserves()
+	bond.MinReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 300))
+	bond.MinReservePercentage = sdk.NewDec(10)
+
+	minReserve, floored := bond.GetMinReserve(sdk.NewInt(10))
+	require.True(t, floored)
+	require.Equal(t, bond.MinReserve, minReserve)
+}
+
+func TestGetMinReserveOfBondCreatedBeforeMinReserve(t *testing.T) {
+	bond := getValidBond()
+	bond.MinReserve = nil
+	bond.MinReservePercentage = sdk.Dec{}
+
+	_, floored := bond.GetMinReserve(sdk.NewInt(10))
+	require.False(t, floored)
+}
+
+func TestBatchGetSellsReserveExcludesCancelledSells(t *testing.T) {
+	batch := NewBatch(initToken, sdk.OneUint())
+	batch.SellPrices = sdk.NewDecCoins(sdk.NewDecCoinFromDec("res", sdk.MustNewDecFromStr("1.5")))
+
+	cancelled := NewSellOrder(initCreator, sdk.NewInt64Coin(initToken, 100))
+	cancelled.Cancelled = true
+	batch.Sells = []SellOrder{
+		NewSellOrder(initCreator, sdk.NewInt64Coin(initToken, 3)),
+		cancelled,
+		NewSellOrder(initCreator, sdk.NewInt64Coin(initToken, 5)),
+	}
+
+	// 1.5*3 rounded down (4) plus 1.5*5 rounded down (7)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("res", 11)), batch.GetSellsReserve())
+}
@@ -7,13 +7,25 @@ import (
 )
 
 const (
-	TypeMsgCreateBond         = "create_bond"
-	TypeMsgEditBond           = "edit_bond"
-	TypeMsgBuy                = "buy"
-	TypeMsgSell               = "sell"
-	TypeMsgSwap               = "swap"
-	TypeMsgMakeOutcomePayment = "make_outcome_payment"
-	TypeMsgWithdrawShare      = "withdraw_share"
+	TypeMsgCreateBond              = "create_bond"
+	TypeMsgEditBond                = "edit_bond"
+	TypeMsgBuy                     = "buy"
+	TypeMsgSell                    = "sell"
+	TypeMsgSellByValue             = "sell_by_value"
+	TypeMsgSwap                    = "swap"
+	TypeMsgMakeOutcomePayment      = "make_outcome_payment"
+	TypeMsgWithdrawShare           = "withdraw_share"
+	TypeMsgAuthorizedTransfer      = "authorized_transfer"
+	TypeMsgScheduleParamChange     = "schedule_param_change"
+	TypeMsgCancelParamChange       = "cancel_param_change"
+	TypeMsgSubmitBondProposal      = "submit_bond_proposal"
+	TypeMsgVoteBondProposal        = "vote_bond_proposal"
+	TypeMsgSetBondTranslations     = "set_bond_translations"
+	TypeMsgSetSanityRate           = "set_sanity_rate"
+	TypeMsgRebalanceSwap           = "rebalance_swap"
+	TypeMsgSweepFeeDust            = "sweep_fee_dust"
+	TypeMsgRegisterNotifications   = "register_notifications"
+	TypeMsgUnregisterNotifications = "unregister_notifications"
 )
 
 type MsgCreateBond struct {
@@ -32,17 +44,31 @@ type MsgCreateBond struct {
 	SanityRate             sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
 	SanityMarginPercentage sdk.Dec          `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
 	AllowSells             bool             `json:"allow_sells" yaml:"allow_sells"`
+	NonTransferable        bool             `json:"non_transferable" yaml:"non_transferable"`
+	RequireAttestation     bool             `json:"require_attestation" yaml:"require_attestation"`
 	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
 	BatchBlocks            sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
 	OutcomePayment         sdk.Coins        `json:"outcome_payment" yaml:"outcome_payment"`
+	Milestones             []Milestone      `json:"milestones" yaml:"milestones"`
+	ProposalVotingBlocks   uint64           `json:"proposal_voting_blocks" yaml:"proposal_voting_blocks"`
+	EventAttributes        EventAttributes  `json:"event_attributes" yaml:"event_attributes"`
+	PreMine                sdk.Coins        `json:"pre_mine" yaml:"pre_mine"`
+	AtMaxSupplyBehavior    string           `json:"at_max_supply_behavior" yaml:"at_max_supply_behavior"`
+	SignerThreshold        *SignerThreshold `json:"signer_threshold,omitempty" yaml:"signer_threshold,omitempty"`
+	CurveSegments          []CurveSegment   `json:"curve_segments,omitempty" yaml:"curve_segments,omitempty"`
+	SoftCap                sdk.Coins        `json:"soft_cap,omitempty" yaml:"soft_cap,omitempty"`
+	RaiseDeadline          int64            `json:"raise_deadline,omitempty" yaml:"raise_deadline,omitempty"`
 }
 
 func NewMsgCreateBond(token, name, description string, creator sdk.AccAddress,
 	functionType string, functionParameters FunctionParams, reserveTokens []string,
 	txFeePercentage, exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress, maxSupply sdk.Coin,
 	orderQuantityLimits sdk.Coins, sanityRate, sanityMarginPercentage sdk.Dec,
-	allowSell bool, signers []sdk.AccAddress, batchBlocks sdk.Uint,
-	outcomePayment sdk.Coins) MsgCreateBond {
+	allowSell, nonTransferable, requireAttestation bool,
+	signers []sdk.AccAddress, batchBlocks sdk.Uint,
+	outcomePayment sdk.Coins, milestones []Milestone,
+	proposalVotingBlocks uint64, eventAttributes EventAttributes,
+	preMine sdk.Coins, atMaxSupplyBehavior string) MsgCreateBond {
 	return MsgCreateBond{
 		Token:                  token,
 		Name:                   name,
@@ -59,9 +85,16 @@ func NewMsgCreateBond(token, name, description string, creator sdk.AccAddress,
 		SanityRate:             sanityRate,
 		SanityMarginPercentage: sanityMarginPercentage,
 		AllowSells:             allowSell,
+		NonTransferable:        nonTransferable,
+		RequireAttestation:     requireAttestation,
 		Signers:                signers,
 		BatchBlocks:            batchBlocks,
 		OutcomePayment:         outcomePayment,
+		Milestones:             milestones,
+		ProposalVotingBlocks:   proposalVotingBlocks,
+		EventAttributes:        eventAttributes,
+		PreMine:                preMine,
+		AtMaxSupplyBehavior:    atMaxSupplyBehavior,
 	}
 }
 
@@ -84,6 +117,13 @@ func (msg MsgCreateBond) ValidateBasic() error {
 	}
 	// Note: FunctionParameters can be empty
 
+	// Check that name and description are not too long
+	if err := CheckNameLength(msg.Name, MaxBondNameLength); err != nil {
+		return err
+	} else if err := CheckDescriptionLength(msg.Description, MaxBondDescriptionLength); err != nil {
+		return err
+	}
+
 	// Check that bond token is a valid token name
 	err := CheckCoinDenom(msg.Token)
 	if err != nil {
@@ -102,13 +142,37 @@ func (msg MsgCreateBond) ValidateBasic() error {
 		return err
 	}
 
+	// Check that a weighted swapper function has one weight per reserve token
+	if msg.FunctionType == WeightedSwapperFunction {
+		err = CheckWeightedSwapperReserveTokens(msg.FunctionParameters, msg.ReserveTokens)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Validate fee address and signers
+	if err = CheckFeeAddress(msg.FeeAddress); err != nil {
+		return err
+	} else if err = CheckSigners(msg.Signers); err != nil {
+		return err
+	}
+
+	// Validate signer threshold (nil means all signers must sign)
+	if msg.SignerThreshold != nil {
+		if err = msg.SignerThreshold.Validate(len(msg.Signers)); err != nil {
+			return err
+		}
+	}
+
 	// Validate coins
-	if !msg.MaxSupply.IsValid() {
+	if !isValidCoin(msg.MaxSupply) {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "max supply is invalid")
-	} else if !msg.OrderQuantityLimits.IsValid() {
+	} else if !isValidCoins(msg.OrderQuantityLimits) {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "order quantity limits are invalid")
-	} else if !msg.OutcomePayment.IsValid() {
+	} else if !isValidCoins(msg.OutcomePayment) {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "outcome payment is invalid")
+	} else if !isValidCoins(msg.PreMine) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "pre-mine is invalid")
 	}
 
 	// Check that max supply denom matches token denom
@@ -116,29 +180,105 @@ func (msg MsgCreateBond) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrMaxSupplyDenomDoesNotMatchTokenDenom, msg.Token)
 	}
 
-	// Check that Sanity values not negative
-	if msg.SanityRate.IsNegative() {
+	// Check that exponential function can be evaluated up to the max supply
+	if msg.FunctionType == ExponentialFunction {
+		err = CheckExponentialMaxSupply(msg.FunctionParameters.AsMap(), msg.MaxSupply.Amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check that polynomial function can be evaluated up to the max supply
+	if msg.FunctionType == PolynomialFunction {
+		err = CheckPolynomialMaxSupply(msg.FunctionParameters.AsMap(), msg.MaxSupply.Amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check that Bancor function can be evaluated up to the max supply
+	if msg.FunctionType == BancorFunction {
+		err = CheckBancorMaxSupply(msg.FunctionParameters.AsMap(), msg.MaxSupply.Amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check that a piecewise function has valid curve segments, and that no
+	// other function type has any curve segments
+	err = ValidateCurveSegments(msg.CurveSegments, msg.FunctionType, msg.MaxSupply.Amount)
+	if err != nil {
+		return err
+	}
+
+	// Check that pre-mine (if any) is in the bond token and within max supply
+	if !msg.PreMine.Empty() {
+		if len(msg.PreMine) != 1 || msg.PreMine[0].Denom != msg.Token {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins,
+				"pre-mine %s must be in the bond token %s", msg.PreMine, msg.Token)
+		} else if msg.PreMine[0].Amount.GT(msg.MaxSupply.Amount) {
+			return sdkerrors.Wrapf(ErrPreMineTooLarge,
+				"%s exceeds max supply %s", msg.PreMine, msg.MaxSupply)
+		}
+	}
+
+	// Check that Sanity values not negative and margin is a valid percentage
+	if msg.SanityRate.IsNil() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityRate")
+	} else if msg.SanityRate.IsNegative() {
 		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "SanityRate")
-	} else if msg.SanityMarginPercentage.IsNegative() {
-		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "SanityMarginPercentage")
+	} else if err = NewPercentage(msg.SanityMarginPercentage).Validate(); err != nil {
+		return sdkerrors.Wrap(err, "SanityMarginPercentage")
+	}
+
+	// Sanity rates are rates between two reserve tokens, so they are not
+	// available for weighted swapper functions, nor for stable swap functions,
+	// whose prices do not follow the ratio of the reserve balances
+	if (msg.FunctionType == WeightedSwapperFunction ||
+		msg.FunctionType == StableSwapFunction) && !msg.SanityRate.IsZero() {
+		return sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, "SanityRate")
 	}
 
-	// Check FeePercentages not negative and don't add up to 100
-	if msg.TxFeePercentage.IsNegative() {
-		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "TxFeePercentage")
-	} else if msg.ExitFeePercentage.IsNegative() {
-		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "ExitFeePercentage")
-	} else if msg.TxFeePercentage.Add(msg.ExitFeePercentage).GTE(sdk.NewDec(100)) {
+	// Check FeePercentages are valid percentages and don't add up to 100
+	if err = NewPercentage(msg.TxFeePercentage).Validate(); err != nil {
+		return sdkerrors.Wrap(err, "TxFeePercentage")
+	} else if err = NewPercentage(msg.ExitFeePercentage).Validate(); err != nil {
+		return sdkerrors.Wrap(err, "ExitFeePercentage")
+	} else if msg.TxFeePercentage.Add(msg.ExitFeePercentage).GTE(MaxPercentage) {
 		return sdkerrors.Wrap(ErrFeesCannotBeOrExceed100Percent, msg.TxFeePercentage.Add(msg.ExitFeePercentage).String())
 	}
 
 	// Check that not zero
-	if msg.BatchBlocks.IsZero() {
+	if msg.BatchBlocks == (sdk.Uint{}) || msg.BatchBlocks.IsZero() {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "BatchBlocks")
 	} else if msg.MaxSupply.Amount.IsZero() {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "MaxSupply")
 	}
 
+	// Validate milestones
+	if err = ValidateMilestones(msg.Milestones, msg.ReserveTokens,
+		msg.FunctionType, msg.FunctionParameters); err != nil {
+		return err
+	}
+
+	// Validate soft cap and raise deadline
+	if err = ValidateSoftCap(msg.SoftCap, msg.RaiseDeadline,
+		msg.ReserveTokens, msg.FunctionType); err != nil {
+		return err
+	}
+
+	// Validate event attributes
+	if err = msg.EventAttributes.Validate(); err != nil {
+		return err
+	}
+
+	// Validate at max supply behavior (blank means allow rebuys)
+	if msg.AtMaxSupplyBehavior != "" {
+		if err = CheckAtMaxSupplyBehavior(msg.AtMaxSupplyBehavior); err != nil {
+			return err
+		}
+	}
+
 	// Note: uniqueness of reserve tokens checked when parsing
 
 	return nil
@@ -156,67 +296,161 @@ func (msg MsgCreateBond) Route() string { return RouterKey }
 
 func (msg MsgCreateBond) Type() string { return TypeMsgCreateBond }
 
+// MsgEditBond only edits the bond fields that are set (i.e. non-nil), so that
+// the remaining fields are left exactly as they are. Fields that are set to an
+// empty or zero value (e.g. an empty quote denom, or a net sell cap of zero)
+// are reset, which for most fields disables the respective feature.
 type MsgEditBond struct {
 	Token                  string           `json:"token" yaml:"token"`
-	Name                   string           `json:"name" yaml:"name"`
-	Description            string           `json:"description" yaml:"description"`
-	OrderQuantityLimits    string           `json:"order_quantity_limits" yaml:"order_quantity_limits"`
-	SanityRate             string           `json:"sanity_rate" yaml:"sanity_rate"`
-	SanityMarginPercentage string           `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	Name                   *string          `json:"name,omitempty" yaml:"name,omitempty"`
+	Description            *string          `json:"description,omitempty" yaml:"description,omitempty"`
+	OrderQuantityLimits    *sdk.Coins       `json:"order_quantity_limits,omitempty" yaml:"order_quantity_limits,omitempty"`
+	SanityRate             *sdk.Dec         `json:"sanity_rate,omitempty" yaml:"sanity_rate,omitempty"`
+	SanityMarginPercentage *sdk.Dec         `json:"sanity_margin_percentage,omitempty" yaml:"sanity_margin_percentage,omitempty"`
+	NetSellCap             *sdk.Coin        `json:"net_sell_cap,omitempty" yaml:"net_sell_cap,omitempty"`
+	NetSellCapPercentage   *sdk.Dec         `json:"net_sell_cap_percentage,omitempty" yaml:"net_sell_cap_percentage,omitempty"`
+	DemurrageRate          *sdk.Dec         `json:"demurrage_rate,omitempty" yaml:"demurrage_rate,omitempty"`
+	QuoteDenom             *string          `json:"quote_denom,omitempty" yaml:"quote_denom,omitempty"`
+	MinReserve             *sdk.Coins       `json:"min_reserve,omitempty" yaml:"min_reserve,omitempty"`
+	MinReservePercentage   *sdk.Dec         `json:"min_reserve_percentage,omitempty" yaml:"min_reserve_percentage,omitempty"`
+	FeeAddress             *sdk.AccAddress  `json:"fee_address,omitempty" yaml:"fee_address,omitempty"`
+	SignerThreshold        *SignerThreshold `json:"signer_threshold,omitempty" yaml:"signer_threshold,omitempty"`
 	Editor                 sdk.AccAddress   `json:"editor" yaml:"editor"`
 	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
 }
 
-func NewMsgEditBond(token, name, description, orderQuantityLimits, sanityRate,
-	sanityMarginPercentage string, editor sdk.AccAddress,
-	signers []sdk.AccAddress) MsgEditBond {
+// NewMsgEditBond returns a message that does not edit any field yet. The
+// fields to be edited are then set individually, e.g. msg.Name = &name.
+func NewMsgEditBond(token string, editor sdk.AccAddress, signers []sdk.AccAddress) MsgEditBond {
 	return MsgEditBond{
-		Token:                  token,
-		Name:                   name,
-		Description:            description,
-		OrderQuantityLimits:    orderQuantityLimits,
-		SanityRate:             sanityRate,
-		SanityMarginPercentage: sanityMarginPercentage,
-		Editor:                 editor,
-		Signers:                signers,
+		Token:   token,
+		Editor:  editor,
+		Signers: signers,
 	}
 }
 
+// EditedFields returns the names of the fields that the message edits, in
+// the order in which they are declared.
+func (msg MsgEditBond) EditedFields() (fields []string) {
+	edited := []struct {
+		name string
+		set  bool
+	}{
+		{"name", msg.Name != nil},
+		{"description", msg.Description != nil},
+		{"order_quantity_limits", msg.OrderQuantityLimits != nil},
+		{"sanity_rate", msg.SanityRate != nil},
+		{"sanity_margin_percentage", msg.SanityMarginPercentage != nil},
+		{"net_sell_cap", msg.NetSellCap != nil},
+		{"net_sell_cap_percentage", msg.NetSellCapPercentage != nil},
+		{"demurrage_rate", msg.DemurrageRate != nil},
+		{"quote_denom", msg.QuoteDenom != nil},
+		{"min_reserve", msg.MinReserve != nil},
+		{"min_reserve_percentage", msg.MinReservePercentage != nil},
+		{"fee_address", msg.FeeAddress != nil},
+		{"signer_threshold", msg.SignerThreshold != nil},
+	}
+	for _, f := range edited {
+		if f.set {
+			fields = append(fields, f.name)
+		}
+	}
+	return fields
+}
+
 func (msg MsgEditBond) ValidateBasic() error {
 	// Check if empty
 	if strings.TrimSpace(msg.Token) == "" {
 		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Token")
-	} else if strings.TrimSpace(msg.Name) == "" {
-		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Name")
-	} else if strings.TrimSpace(msg.Description) == "" {
-		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Description")
-	} else if strings.TrimSpace(msg.SanityRate) == "" {
-		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityRate")
-	} else if strings.TrimSpace(msg.SanityMarginPercentage) == "" {
-		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityMarginPercentage")
 	} else if msg.Editor.Empty() {
 		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
 	}
-	// Note: order quantity limits can be blank
 
-	// Check that at least one editable was edited. Fields that will not
-	// be edited should be "DoNotModifyField", and not an empty string
-	inputList := []string{
-		msg.Name, msg.Description, msg.OrderQuantityLimits,
-		msg.SanityRate, msg.SanityMarginPercentage,
+	// Validate bond token
+	if err := CheckCoinDenom(msg.Token); err != nil {
+		return err
 	}
-	atLeaseOneEdit := false
-	for _, e := range inputList {
-		if e != DoNotModifyField {
-			atLeaseOneEdit = true
-			break
+
+	// Check that at least one field was edited
+	if len(msg.EditedFields()) == 0 {
+		return ErrDidNotEditAnything
+	}
+
+	// Name and description cannot be reset, and cannot be too long
+	if msg.Name != nil {
+		if strings.TrimSpace(*msg.Name) == "" {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Name")
+		} else if err := CheckNameLength(*msg.Name, MaxBondNameLength); err != nil {
+			return err
 		}
 	}
-	if !atLeaseOneEdit {
-		return ErrDidNotEditAnything
+	if msg.Description != nil {
+		if strings.TrimSpace(*msg.Description) == "" {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Description")
+		} else if err := CheckDescriptionLength(*msg.Description, MaxBondDescriptionLength); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	// Note: order quantity limits, sanity values, net sell caps, demurrage
+	// rate, quote denom, and min reserves can be reset (i.e. empty or zero)
+	if msg.OrderQuantityLimits != nil && !isValidCoins(*msg.OrderQuantityLimits) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.OrderQuantityLimits.String())
+	}
+	if msg.SanityRate != nil {
+		if msg.SanityRate.IsNil() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityRate")
+		} else if msg.SanityRate.IsNegative() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "SanityRate")
+		}
+	}
+	if msg.NetSellCap != nil && !isValidCoin(*msg.NetSellCap) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.NetSellCap.String())
+	}
+	if msg.DemurrageRate != nil {
+		if err := ValidateDemurrageRate(*msg.DemurrageRate); err != nil {
+			return sdkerrors.Wrap(err, "DemurrageRate")
+		}
+	}
+	if msg.QuoteDenom != nil && *msg.QuoteDenom != "" {
+		if err := sdk.ValidateDenom(*msg.QuoteDenom); err != nil {
+			return sdkerrors.Wrap(err, "QuoteDenom")
+		}
+	}
+	if msg.MinReserve != nil && !isValidCoins(*msg.MinReserve) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.MinReserve.String())
+	}
+
+	// Check that percentages being edited are valid percentages
+	percentages := []struct {
+		name  string
+		value *sdk.Dec
+	}{
+		{"SanityMarginPercentage", msg.SanityMarginPercentage},
+		{"NetSellCapPercentage", msg.NetSellCapPercentage},
+		{"MinReservePercentage", msg.MinReservePercentage},
+	}
+	for _, p := range percentages {
+		if p.value == nil {
+			continue
+		} else if err := NewPercentage(*p.value).Validate(); err != nil {
+			return sdkerrors.Wrap(err, p.name)
+		}
+	}
+
+	// Fee address cannot be reset, and cannot be a bonds module account
+	if msg.FeeAddress != nil {
+		if msg.FeeAddress.Empty() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "FeeAddress")
+		} else if err := CheckFeeAddress(*msg.FeeAddress); err != nil {
+			return err
+		}
+	}
+
+	// Note: signer threshold validated against the bond's signers in handler
+
+	// Validate signers
+	return CheckSigners(msg.Signers)
 }
 
 func (msg MsgEditBond) GetSignBytes() []byte {
@@ -231,10 +465,18 @@ func (msg MsgEditBond) Route() string { return RouterKey }
 
 func (msg MsgEditBond) Type() string { return TypeMsgEditBond }
 
+// MsgBuy optionally holds an opaque callback payload, which is included in the
+// events emitted for the resulting buy order, so that the buyer (e.g. a smart
+// contract) can resume its workflow once the order is fulfilled or cancelled.
+// Like all order messages, it can also hold a memo (e.g. an internal reference
+// number), which is stored with the order and echoed in the order's events.
 type MsgBuy struct {
-	Buyer     sdk.AccAddress `json:"buyer" yaml:"buyer"`
-	Amount    sdk.Coin       `json:"amount" yaml:"amount"`
-	MaxPrices sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	Buyer           sdk.AccAddress `json:"buyer" yaml:"buyer"`
+	Amount          sdk.Coin       `json:"amount" yaml:"amount"`
+	MaxPrices       sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	CallbackPayload string         `json:"callback_payload,omitempty" yaml:"callback_payload,omitempty"`
+	Memo            string         `json:"memo,omitempty" yaml:"memo,omitempty"`
+	PriorityFee     sdk.Coins      `json:"priority_fee,omitempty" yaml:"priority_fee,omitempty"`
 }
 
 func NewMsgBuy(buyer sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) MsgBuy {
@@ -252,18 +494,32 @@ func (msg MsgBuy) ValidateBasic() error {
 	}
 
 	// Check that amount valid and non zero
-	if !msg.Amount.IsValid() {
+	if !isValidCoin(msg.Amount) {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
 	} else if msg.Amount.Amount.IsZero() {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
 	}
 
-	// Check that maxPrices valid
-	if !msg.MaxPrices.IsValid() {
+	// Check that maxPrices valid and non-empty
+	if !isValidCoins(msg.MaxPrices) {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "maxprices is invalid")
+	} else if msg.MaxPrices.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "MaxPrices")
 	}
 
-	return nil
+	// Check that priority fee (optional) is valid
+	if !isValidCoins(msg.PriorityFee) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "priority fee is invalid")
+	}
+
+	// Check that callback payload is not too long
+	if len(msg.CallbackPayload) > MaxCallbackPayloadLength {
+		return sdkerrors.Wrapf(ErrArgumentTooLong,
+			"CallbackPayload is longer than %d characters", MaxCallbackPayloadLength)
+	}
+
+	// Check that memo is not too long
+	return CheckOrderMemoLength(msg.Memo)
 }
 
 func (msg MsgBuy) GetSignBytes() []byte {
@@ -281,6 +537,7 @@ func (msg MsgBuy) Type() string { return TypeMsgBuy }
 type MsgSell struct {
 	Seller sdk.AccAddress `json:"seller" yaml:"seller"`
 	Amount sdk.Coin       `json:"amount" yaml:"amount"`
+	Memo   string         `json:"memo,omitempty" yaml:"memo,omitempty"`
 }
 
 func NewMsgSell(seller sdk.AccAddress, amount sdk.Coin) MsgSell {
@@ -297,13 +554,14 @@ func (msg MsgSell) ValidateBasic() error {
 	}
 
 	// Check that amount valid and non zero
-	if !msg.Amount.IsValid() {
+	if !isValidCoin(msg.Amount) {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
 	} else if msg.Amount.Amount.IsZero() {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
 	}
 
-	return nil
+	// Check that memo is not too long
+	return CheckOrderMemoLength(msg.Memo)
 }
 
 func (msg MsgSell) GetSignBytes() []byte {
@@ -318,11 +576,64 @@ func (msg MsgSell) Route() string { return RouterKey }
 
 func (msg MsgSell) Type() string { return TypeMsgSell }
 
+type MsgSellByValue struct {
+	Seller    sdk.AccAddress `json:"seller" yaml:"seller"`
+	Returns   sdk.Coins      `json:"returns" yaml:"returns"`
+	MaxAmount sdk.Coin       `json:"max_amount" yaml:"max_amount"`
+	Memo      string         `json:"memo,omitempty" yaml:"memo,omitempty"`
+}
+
+func NewMsgSellByValue(seller sdk.AccAddress, returns sdk.Coins, maxAmount sdk.Coin) MsgSellByValue {
+	return MsgSellByValue{
+		Seller:    seller,
+		Returns:   returns,
+		MaxAmount: maxAmount,
+	}
+}
+
+func (msg MsgSellByValue) ValidateBasic() error {
+	// Check if empty
+	if msg.Seller.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Seller")
+	}
+
+	// Check that returns valid and non zero
+	if !isValidCoins(msg.Returns) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "returns is invalid")
+	} else if msg.Returns.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Returns")
+	}
+
+	// Check that max amount valid and non zero
+	if !isValidCoin(msg.MaxAmount) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "max amount is invalid")
+	} else if msg.MaxAmount.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "MaxAmount")
+	}
+
+	// Check that memo is not too long
+	return CheckOrderMemoLength(msg.Memo)
+}
+
+func (msg MsgSellByValue) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSellByValue) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Seller}
+}
+
+func (msg MsgSellByValue) Route() string { return RouterKey }
+
+func (msg MsgSellByValue) Type() string { return TypeMsgSellByValue }
+
 type MsgSwap struct {
 	Swapper   sdk.AccAddress `json:"swapper" yaml:"swapper"`
 	BondToken string         `json:"bond_token" yaml:"bond_token"`
 	From      sdk.Coin       `json:"from" yaml:"from"`
 	ToToken   string         `json:"to_token" yaml:"to_token"`
+	Memo      string         `json:"memo,omitempty" yaml:"memo,omitempty"`
+	MinReturn *sdk.Coin      `json:"min_return,omitempty" yaml:"min_return,omitempty"`
 }
 
 func NewMsgSwap(swapper sdk.AccAddress, bondToken string, from sdk.Coin, toToken string) MsgSwap {
@@ -345,12 +656,16 @@ func (msg MsgSwap) ValidateBasic() error {
 	}
 
 	// Validate from amount
-	if !msg.From.IsValid() {
+	if !isValidCoin(msg.From) {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "from amount is invalid")
 	}
 
-	// Validate to token
-	err := CheckCoinDenom(msg.ToToken)
+	// Validate bond token and to token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+	err = CheckCoinDenom(msg.ToToken)
 	if err != nil {
 		return err
 	}
@@ -365,6 +680,24 @@ func (msg MsgSwap) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FromAmount")
 	}
 
+	// Check that the min return (if any) is a positive amount of the to token
+	if msg.MinReturn != nil {
+		if !isValidCoin(*msg.MinReturn) {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "min return is invalid")
+		} else if msg.MinReturn.Denom != msg.ToToken {
+			return sdkerrors.Wrapf(ErrInvalidCoinDenomination,
+				"min return %s is not in to token %s", msg.MinReturn, msg.ToToken)
+		} else if msg.MinReturn.Amount.IsZero() {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "MinReturn")
+		}
+	}
+
+	// Check that memo is not too long
+	err = CheckOrderMemoLength(msg.Memo)
+	if err != nil {
+		return err
+	}
+
 	// Note: From denom and amount must be valid since sdk.Coin
 	return nil
 }
@@ -462,3 +795,553 @@ func (msg MsgWithdrawShare) GetSigners() []sdk.AccAddress {
 func (msg MsgWithdrawShare) Route() string { return RouterKey }
 
 func (msg MsgWithdrawShare) Type() string { return TypeMsgWithdrawShare }
+
+type MsgAuthorizedTransfer struct {
+	From    sdk.AccAddress   `json:"from" yaml:"from"`
+	To      sdk.AccAddress   `json:"to" yaml:"to"`
+	Amount  sdk.Coin         `json:"amount" yaml:"amount"`
+	Reason  string           `json:"reason" yaml:"reason"`
+	Signers []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgAuthorizedTransfer(from, to sdk.AccAddress, amount sdk.Coin,
+	reason string, signers []sdk.AccAddress) MsgAuthorizedTransfer {
+	return MsgAuthorizedTransfer{
+		From:    from,
+		To:      to,
+		Amount:  amount,
+		Reason:  reason,
+		Signers: signers,
+	}
+}
+
+func (msg MsgAuthorizedTransfer) ValidateBasic() error {
+	// Check if empty
+	if msg.From.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "From")
+	} else if msg.To.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "To")
+	} else if strings.TrimSpace(msg.Reason) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Reason")
+	}
+
+	// Check that from and to are different
+	if msg.From.Equals(msg.To) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "from and to addresses cannot be the same")
+	}
+
+	// Check that amount valid and non zero
+	if !isValidCoin(msg.Amount) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	} else if msg.Amount.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+	}
+
+	// Validate signers
+	return CheckSigners(msg.Signers)
+}
+
+func (msg MsgAuthorizedTransfer) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgAuthorizedTransfer) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgAuthorizedTransfer) Route() string { return RouterKey }
+
+func (msg MsgAuthorizedTransfer) Type() string { return TypeMsgAuthorizedTransfer }
+
+type MsgScheduleParamChange struct {
+	BondToken          string           `json:"bond_token" yaml:"bond_token"`
+	FunctionParameters FunctionParams   `json:"function_parameters" yaml:"function_parameters"`
+	EffectiveHeight    int64            `json:"effective_height" yaml:"effective_height"`
+	Interpolate        bool             `json:"interpolate" yaml:"interpolate"`
+	Editor             sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers            []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgScheduleParamChange(bondToken string, functionParameters FunctionParams,
+	effectiveHeight int64, interpolate bool, editor sdk.AccAddress,
+	signers []sdk.AccAddress) MsgScheduleParamChange {
+	return MsgScheduleParamChange{
+		BondToken:          bondToken,
+		FunctionParameters: functionParameters,
+		EffectiveHeight:    effectiveHeight,
+		Interpolate:        interpolate,
+		Editor:             editor,
+		Signers:            signers,
+	}
+}
+
+func (msg MsgScheduleParamChange) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if len(msg.FunctionParameters) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "FunctionParameters")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	}
+
+	// Validate bond token
+	if err := CheckCoinDenom(msg.BondToken); err != nil {
+		return err
+	}
+
+	// Check that function parameter values are set
+	for _, p := range msg.FunctionParameters {
+		if p.Value.IsNil() {
+			return sdkerrors.Wrap(ErrFunctionParameterMissingOrNonFloat, p.Param)
+		}
+	}
+
+	// Check that effective height is positive. Whether it is in the future and
+	// whether the function parameters are valid depend on the current height
+	// and the bond's function type, so these are checked by the handler.
+	if msg.EffectiveHeight <= 0 {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "EffectiveHeight")
+	}
+
+	// Validate signers
+	return CheckSigners(msg.Signers)
+}
+
+func (msg MsgScheduleParamChange) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgScheduleParamChange) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgScheduleParamChange) Route() string { return RouterKey }
+
+func (msg MsgScheduleParamChange) Type() string { return TypeMsgScheduleParamChange }
+
+type MsgCancelParamChange struct {
+	BondToken string           `json:"bond_token" yaml:"bond_token"`
+	Editor    sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers   []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgCancelParamChange(bondToken string, editor sdk.AccAddress,
+	signers []sdk.AccAddress) MsgCancelParamChange {
+	return MsgCancelParamChange{
+		BondToken: bondToken,
+		Editor:    editor,
+		Signers:   signers,
+	}
+}
+
+func (msg MsgCancelParamChange) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	}
+
+	// Validate bond token
+	if err := CheckCoinDenom(msg.BondToken); err != nil {
+		return err
+	}
+
+	// Validate signers
+	return CheckSigners(msg.Signers)
+}
+
+func (msg MsgCancelParamChange) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelParamChange) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgCancelParamChange) Route() string { return RouterKey }
+
+func (msg MsgCancelParamChange) Type() string { return TypeMsgCancelParamChange }
+
+type MsgSubmitBondProposal struct {
+	BondToken        string         `json:"bond_token" yaml:"bond_token"`
+	Proposer         sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	Title            string         `json:"title" yaml:"title"`
+	Description      string         `json:"description" yaml:"description"`
+	ProposalType     string         `json:"proposal_type" yaml:"proposal_type"`
+	FundingRecipient sdk.AccAddress `json:"funding_recipient" yaml:"funding_recipient"`
+	FundingAmount    sdk.Coins      `json:"funding_amount" yaml:"funding_amount"`
+}
+
+func NewMsgSubmitBondProposal(bondToken string, proposer sdk.AccAddress,
+	title, description, proposalType string, fundingRecipient sdk.AccAddress,
+	fundingAmount sdk.Coins) MsgSubmitBondProposal {
+	return MsgSubmitBondProposal{
+		BondToken:        bondToken,
+		Proposer:         proposer,
+		Title:            title,
+		Description:      description,
+		ProposalType:     proposalType,
+		FundingRecipient: fundingRecipient,
+		FundingAmount:    fundingAmount,
+	}
+}
+
+func (msg MsgSubmitBondProposal) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if msg.Proposer.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Proposer")
+	}
+
+	// Validate bond token
+	if err := CheckCoinDenom(msg.BondToken); err != nil {
+		return err
+	}
+
+	// Validate proposal content
+	return ValidateBondProposalContent(msg.Title, msg.Description,
+		msg.ProposalType, msg.FundingRecipient, msg.FundingAmount)
+}
+
+func (msg MsgSubmitBondProposal) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSubmitBondProposal) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Proposer}
+}
+
+func (msg MsgSubmitBondProposal) Route() string { return RouterKey }
+
+func (msg MsgSubmitBondProposal) Type() string { return TypeMsgSubmitBondProposal }
+
+type MsgVoteBondProposal struct {
+	ProposalID uint64         `json:"proposal_id" yaml:"proposal_id"`
+	Voter      sdk.AccAddress `json:"voter" yaml:"voter"`
+	Option     string         `json:"option" yaml:"option"`
+}
+
+func NewMsgVoteBondProposal(proposalID uint64, voter sdk.AccAddress,
+	option string) MsgVoteBondProposal {
+	return MsgVoteBondProposal{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Option:     option,
+	}
+}
+
+func (msg MsgVoteBondProposal) ValidateBasic() error {
+	// Check if empty
+	if msg.Voter.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Voter")
+	}
+
+	// Check that proposal ID is positive, since proposal IDs start from 1
+	if msg.ProposalID == 0 {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "ProposalID")
+	}
+
+	// Check vote option
+	return CheckVoteOption(msg.Option)
+}
+
+func (msg MsgVoteBondProposal) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgVoteBondProposal) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
+
+func (msg MsgVoteBondProposal) Route() string { return RouterKey }
+
+func (msg MsgVoteBondProposal) Type() string { return TypeMsgVoteBondProposal }
+
+type MsgSetBondTranslations struct {
+	BondToken    string           `json:"bond_token" yaml:"bond_token"`
+	Translations BondTranslations `json:"translations" yaml:"translations"`
+	Editor       sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers      []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgSetBondTranslations(bondToken string, translations BondTranslations,
+	editor sdk.AccAddress, signers []sdk.AccAddress) MsgSetBondTranslations {
+	return MsgSetBondTranslations{
+		BondToken:    bondToken,
+		Translations: translations,
+		Editor:       editor,
+		Signers:      signers,
+	}
+}
+
+func (msg MsgSetBondTranslations) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	}
+
+	// Validate bond token
+	if err := CheckCoinDenom(msg.BondToken); err != nil {
+		return err
+	}
+
+	// Validate translations (an empty list removes all translations)
+	if err := msg.Translations.Validate(); err != nil {
+		return err
+	}
+
+	// Validate signers
+	return CheckSigners(msg.Signers)
+}
+
+func (msg MsgSetBondTranslations) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetBondTranslations) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgSetBondTranslations) Route() string { return RouterKey }
+
+func (msg MsgSetBondTranslations) Type() string { return TypeMsgSetBondTranslations }
+
+type MsgSetSanityRate struct {
+	BondToken              string           `json:"bond_token" yaml:"bond_token"`
+	SanityRate             sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage sdk.Dec          `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	Editor                 sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgSetSanityRate(bondToken string, sanityRate, sanityMarginPercentage sdk.Dec,
+	editor sdk.AccAddress, signers []sdk.AccAddress) MsgSetSanityRate {
+	return MsgSetSanityRate{
+		BondToken:              bondToken,
+		SanityRate:             sanityRate,
+		SanityMarginPercentage: sanityMarginPercentage,
+		Editor:                 editor,
+		Signers:                signers,
+	}
+}
+
+func (msg MsgSetSanityRate) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if msg.SanityRate.IsNil() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityRate")
+	} else if msg.SanityMarginPercentage.IsNil() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityMarginPercentage")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	}
+
+	// Validate bond token
+	if err := CheckCoinDenom(msg.BondToken); err != nil {
+		return err
+	}
+
+	// Check that sanity rate is not negative and that sanity margin
+	// percentage is a valid percentage
+	if msg.SanityRate.IsNegative() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "SanityRate")
+	} else if err := NewPercentage(msg.SanityMarginPercentage).Validate(); err != nil {
+		return sdkerrors.Wrap(err, "SanityMarginPercentage")
+	}
+
+	// Validate signers
+	return CheckSigners(msg.Signers)
+}
+
+func (msg MsgSetSanityRate) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetSanityRate) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgSetSanityRate) Route() string { return RouterKey }
+
+func (msg MsgSetSanityRate) Type() string { return TypeMsgSetSanityRate }
+
+type MsgRebalanceSwap struct {
+	Swapper   sdk.AccAddress   `json:"swapper" yaml:"swapper"`
+	BondToken string           `json:"bond_token" yaml:"bond_token"`
+	From      sdk.Coin         `json:"from" yaml:"from"`
+	ToToken   string           `json:"to_token" yaml:"to_token"`
+	Signers   []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgRebalanceSwap(swapper sdk.AccAddress, bondToken string, from sdk.Coin,
+	toToken string, signers []sdk.AccAddress) MsgRebalanceSwap {
+	return MsgRebalanceSwap{
+		Swapper:   swapper,
+		BondToken: bondToken,
+		From:      from,
+		ToToken:   toToken,
+		Signers:   signers,
+	}
+}
+
+func (msg MsgRebalanceSwap) ValidateBasic() error {
+	// Validate the swap itself as a normal swap
+	err := NewMsgSwap(msg.Swapper, msg.BondToken, msg.From, msg.ToToken).ValidateBasic()
+	if err != nil {
+		return err
+	}
+
+	// Validate signers
+	err = CheckSigners(msg.Signers)
+	if err != nil {
+		return err
+	}
+
+	// Check that the swapper is one of the signers, since the swapper's
+	// tokens are the ones being swapped
+	for _, s := range msg.Signers {
+		if s.Equals(msg.Swapper) {
+			return nil
+		}
+	}
+	return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "swapper must be one of the signers")
+}
+
+func (msg MsgRebalanceSwap) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRebalanceSwap) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgRebalanceSwap) Route() string { return RouterKey }
+
+func (msg MsgRebalanceSwap) Type() string { return TypeMsgRebalanceSwap }
+
+type MsgSweepFeeDust struct {
+	Owner       sdk.AccAddress `json:"owner" yaml:"owner"`
+	TargetDenom string         `json:"target_denom" yaml:"target_denom"`
+}
+
+func NewMsgSweepFeeDust(owner sdk.AccAddress, targetDenom string) MsgSweepFeeDust {
+	return MsgSweepFeeDust{
+		Owner:       owner,
+		TargetDenom: targetDenom,
+	}
+}
+
+func (msg MsgSweepFeeDust) ValidateBasic() error {
+	// Check if empty
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Owner")
+	}
+	// Note: target denom can be blank
+
+	// Validate target denom
+	if msg.TargetDenom != "" {
+		if err := sdk.ValidateDenom(msg.TargetDenom); err != nil {
+			return sdkerrors.Wrap(err, "TargetDenom")
+		}
+	}
+
+	return nil
+}
+
+func (msg MsgSweepFeeDust) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSweepFeeDust) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+func (msg MsgSweepFeeDust) Route() string { return RouterKey }
+
+func (msg MsgSweepFeeDust) Type() string { return TypeMsgSweepFeeDust }
+
+type MsgRegisterNotifications struct {
+	Relayer           sdk.AccAddress `json:"relayer" yaml:"relayer"`
+	BondToken         string         `json:"bond_token" yaml:"bond_token"`
+	NotificationTypes []string       `json:"notification_types" yaml:"notification_types"`
+}
+
+func NewMsgRegisterNotifications(relayer sdk.AccAddress, bondToken string,
+	notificationTypes []string) MsgRegisterNotifications {
+	return MsgRegisterNotifications{
+		Relayer:           relayer,
+		BondToken:         bondToken,
+		NotificationTypes: notificationTypes,
+	}
+}
+
+func (msg MsgRegisterNotifications) ValidateBasic() error {
+	// Check if empty
+	if msg.Relayer.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Relayer")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Validate bond token
+	if err := CheckCoinDenom(msg.BondToken); err != nil {
+		return err
+	}
+
+	// Validate notification types
+	return ValidateNotificationTypes(msg.NotificationTypes)
+}
+
+func (msg MsgRegisterNotifications) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRegisterNotifications) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Relayer}
+}
+
+func (msg MsgRegisterNotifications) Route() string { return RouterKey }
+
+func (msg MsgRegisterNotifications) Type() string { return TypeMsgRegisterNotifications }
+
+type MsgUnregisterNotifications struct {
+	Relayer   sdk.AccAddress `json:"relayer" yaml:"relayer"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+}
+
+func NewMsgUnregisterNotifications(relayer sdk.AccAddress, bondToken string) MsgUnregisterNotifications {
+	return MsgUnregisterNotifications{
+		Relayer:   relayer,
+		BondToken: bondToken,
+	}
+}
+
+func (msg MsgUnregisterNotifications) ValidateBasic() error {
+	// Check if empty
+	if msg.Relayer.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Relayer")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Validate bond token
+	return CheckCoinDenom(msg.BondToken)
+}
+
+func (msg MsgUnregisterNotifications) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgUnregisterNotifications) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Relayer}
+}
+
+func (msg MsgUnregisterNotifications) Route() string { return RouterKey }
+
+func (msg MsgUnregisterNotifications) Type() string { return TypeMsgUnregisterNotifications }
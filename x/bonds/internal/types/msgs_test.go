@@ -1,11 +1,215 @@
 package types
 
 import (
+	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 )
 
+// MsgCreateBond: Exponential function
+
+func TestValidateBasicMsgCreateExponentialBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = ExponentialFunction
+	message.FunctionParameters = functionParametersExponential()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreateExponentialBondExponentTooLargeGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = ExponentialFunction
+	message.FunctionParameters = functionParametersExponential()
+	message.MaxSupply = sdk.NewInt64Coin(message.Token, 100001) // b=0.001
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCreateBond: Polynomial function
+
+func TestValidateBasicMsgCreatePolynomialBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = PolynomialFunction
+	message.FunctionParameters = functionParametersPolynomial()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreatePolynomialBondMaxSupplyTooLargeGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = PolynomialFunction
+	message.FunctionParameters = functionParametersPolynomial()
+	message.MaxSupply = sdk.NewCoin(message.Token, sdk.NewIntWithDecimal(1, 26)) // x^3 > 2^255
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCreateBond: Bancor function
+
+func TestValidateBasicMsgCreateBancorBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = BancorFunction
+	message.FunctionParameters = functionParametersBancor()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreateBancorBondMaxSupplyTooLargeGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = BancorFunction
+	message.FunctionParameters = functionParametersBancor()
+	message.MaxSupply = sdk.NewCoin(message.Token, sdk.NewIntWithDecimal(6, 24)) // s0=1000, cw=0.5
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateWeightedSwapperBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = WeightedSwapperFunction
+	message.FunctionParameters = functionParametersWeightedSwapper()
+	message.ReserveTokens = weightedSwapperReserves()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreateWeightedSwapperBondWeightsMismatchGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = WeightedSwapperFunction
+	message.FunctionParameters = functionParametersWeightedSwapper()
+	message.ReserveTokens = swapperReserves() // 3 weights but 2 reserve tokens
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateWeightedSwapperBondWithSanityRateGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = WeightedSwapperFunction
+	message.FunctionParameters = functionParametersWeightedSwapper()
+	message.ReserveTokens = weightedSwapperReserves()
+	message.SanityRate = sdk.OneDec()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateStableSwapBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = StableSwapFunction
+	message.FunctionParameters = functionParametersStableSwap()
+	message.ReserveTokens = swapperReserves()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreateStableSwapBondWithThreeReserveTokensGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = StableSwapFunction
+	message.FunctionParameters = functionParametersStableSwap()
+	message.ReserveTokens = weightedSwapperReserves()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateStableSwapBondWithSanityRateGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = StableSwapFunction
+	message.FunctionParameters = functionParametersStableSwap()
+	message.ReserveTokens = swapperReserves()
+	message.SanityRate = sdk.OneDec()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreatePiecewiseBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = PiecewiseFunction
+	message.FunctionParameters = nil
+	message.CurveSegments = piecewiseSegments()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreatePiecewiseBondWithFunctionParamsGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = PiecewiseFunction
+	message.CurveSegments = piecewiseSegments()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreatePowerBondWithCurveSegmentsGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.CurveSegments = piecewiseSegments()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCreateBond: Soft cap
+
+func TestValidateBasicMsgCreateBondWithSoftCapCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.SoftCap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000))
+	message.RaiseDeadline = 100
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreateBondWithSoftCapWithoutRaiseDeadlineGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.SoftCap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000))
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateBondWithRaiseDeadlineWithoutSoftCapGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.RaiseDeadline = 100
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateBondWithNonReserveSoftCapGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.SoftCap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 10000))
+	message.RaiseDeadline = 100
+
+	err := message.ValidateBasic()
+	require.True(t, ErrReserveDenomsMismatch.Is(err))
+}
+
+func TestValidateBasicMsgCreateSwapperBondWithSoftCapGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionType = SwapperFunction
+	message.FunctionParameters = nil
+	message.ReserveTokens = swapperReserves()
+	message.SoftCap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000))
+	message.RaiseDeadline = 100
+
+	err := message.ValidateBasic()
+	require.True(t, ErrFunctionNotAvailableForFunctionType.Is(err))
+}
+
 // MsgCreateBond: Missing arguments
 
 func TestValidateBasicMsgCreateTokenArgumentMissingGivesError(t *testing.T) {
@@ -193,6 +397,14 @@ func TestValidateBasicMsgCreateNegativeSanityPercentageGivesError(t *testing.T)
 	require.NotNil(t, err)
 }
 
+func TestValidateBasicMsgCreateSanityPercentageAbove100GivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.SanityMarginPercentage = sdk.MustNewDecFromStr("100.1")
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
 // MsgCreateBond: Fee percentages must be positive and not add up to 100
 
 func TestValidateBasicMsgCreateTxFeeIsNegativeGivesError(t *testing.T) {
@@ -254,6 +466,21 @@ func TestValidateBasicMsgCreate100PercentFeeGivesError(t *testing.T) {
 	require.Nil(t, message.ValidateBasic())
 }
 
+func TestValidateBasicMsgCreateFeeWithTooManyDecimalPlacesGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+
+	message.TxFeePercentage = sdk.MustNewDecFromStr("0.0000001")
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrTooManyDecimalPlaces.Is(err))
+
+	message.TxFeePercentage = sdk.ZeroDec()
+	message.ExitFeePercentage = sdk.MustNewDecFromStr("0.0000001")
+	err = message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrTooManyDecimalPlaces.Is(err))
+}
+
 // MsgCreateBond: Batch blocks and max supply cannot be zero
 
 func TestValidateBasicMsgCreateZeroBatchBlocksGivesError(t *testing.T) {
@@ -272,243 +499,1372 @@ func TestValidateBasicMsgCreateZeroMaxSupplyGivesError(t *testing.T) {
 	require.NotNil(t, err)
 }
 
-// MsgCreateBond: Valid bond creation
+// MsgCreateBond: Fee address and signers
 
-func TestValidateBasicMsgCreateBondCorrectlyGivesNoError(t *testing.T) {
+func TestValidateBasicMsgCreateBondFeeAddressIsReserveAccountGivesError(t *testing.T) {
 	message := newValidMsgCreateBond()
+	message.FeeAddress = supply.NewModuleAddress(BondsReserveAccount)
 
 	err := message.ValidateBasic()
-	require.Nil(t, err)
+	require.NotNil(t, err)
+	require.True(t, ErrFeeAddressCannotBeModuleAccount.Is(err))
 }
 
-// MsgEditBond: missing arguments
-
-func TestValidateBasicMsgEditBondTokenArgumentMissingGivesError(t *testing.T) {
-	message := newEmptyStringsMsgEditBond()
-	message.Token = ""
+func TestValidateBasicMsgCreateBondFeeAddressIsBatchesAccountGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FeeAddress = supply.NewModuleAddress(BatchesIntermediaryAccount)
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrFeeAddressCannotBeModuleAccount.Is(err))
 }
 
-func TestValidateBasicMsgEditBondNameArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgEditBond()
-	message.Name = ""
+func TestValidateBasicMsgCreateBondFeeAddressIsMintBurnAccountGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FeeAddress = supply.NewModuleAddress(BondsMintBurnAccount)
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrFeeAddressCannotBeModuleAccount.Is(err))
 }
 
-func TestValidateBasicMsgEditBondDescriptionArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgEditBond()
-	message.Description = ""
+func TestValidateBasicMsgCreateBondNoSignersGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.Signers = []sdk.AccAddress{}
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrArgumentCannotBeEmpty.Is(err))
 }
 
-func TestValidateBasicMsgEditBondOrderQuantityLimitsArgumentMissingGivesNoError(t *testing.T) {
-	message := newValidMsgEditBond()
-	message.OrderQuantityLimits = ""
+func TestValidateBasicMsgCreateBondDuplicateSignersGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.Signers = []sdk.AccAddress{initCreator, initCreator}
 
 	err := message.ValidateBasic()
-	require.Nil(t, err)
+	require.NotNil(t, err)
+	require.True(t, ErrDuplicateSigner.Is(err))
 }
 
-func TestValidateBasicMsgEditBondSanityRateArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgEditBond()
-	message.SanityRate = ""
+func TestValidateBasicMsgCreateBondMilestoneTrancheExceedingThresholdGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.Milestones = []Milestone{
+		NewMilestone(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)),
+			sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 101)), false, sdk.ZeroDec(), false),
+	}
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrInvalidMilestone.Is(err))
 }
 
-func TestValidateBasicMsgEditBondSanityMarginPercentageArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgEditBond()
-	message.SanityMarginPercentage = ""
+func TestValidateBasicMsgCreateBondMilestoneThresholdsNotIncreasingGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.Milestones = []Milestone{
+		NewMilestone(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)),
+			sdk.NewCoins(), false, sdk.ZeroDec(), false),
+		NewMilestone(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)),
+			sdk.NewCoins(), false, sdk.ZeroDec(), true),
+	}
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrInvalidMilestone.Is(err))
 }
 
-func TestValidateBasicMsgEditBondEditorArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgEditBond()
-	message.Editor = nil
+func TestValidateBasicMsgCreateBondMilestoneNonReserveDenomGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.Milestones = []Milestone{
+		NewMilestone(sdk.NewCoins(sdk.NewInt64Coin("xyz", 100)),
+			sdk.NewCoins(), false, sdk.ZeroDec(), false),
+	}
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrReserveDenomsMismatch.Is(err))
 }
 
-// MsgEditBond: no edits
-
-func TestValidateBasicMsgEditBondNoEditsGivesError(t *testing.T) {
-	message := NewMsgEditBond(DoNotModifyField, DoNotModifyField,
-		DoNotModifyField, DoNotModifyField, DoNotModifyField,
-		DoNotModifyField, initCreator, initSigners)
+func TestValidateBasicMsgCreateBondMilestoneThetaForPowerFunctionGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.Milestones = []Milestone{
+		NewMilestone(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)),
+			sdk.NewCoins(), true, sdk.MustNewDecFromStr("0.1"), false),
+	}
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrFunctionNotAvailableForFunctionType.Is(err))
 }
 
-// MsgEditBond: correct edit
-
-func TestValidateBasicMsgEditBondCorrectlyGivesNoError(t *testing.T) {
-	message := newValidMsgEditBond()
+func TestValidateBasicMsgCreateBondNameTooLongGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.Name = strings.Repeat("a", MaxBondNameLength+1)
 
 	err := message.ValidateBasic()
-	require.Nil(t, err)
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentTooLong.Is(err))
 }
 
-// MsgBuy: missing arguments
-
-func TestValidateBasicMsgBuyBuyerArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgBuy()
-	message.Buyer = sdk.AccAddress{}
+func TestValidateBasicMsgCreateBondDescriptionTooLongGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.Description = strings.Repeat("a", MaxBondDescriptionLength+1)
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrArgumentTooLong.Is(err))
 }
 
-// MsgBuy: invalid arguments
-
-func TestValidateBasicMsgBuyInvalidAmountGivesError(t *testing.T) {
-	message := newValidMsgBuy()
-	message.Amount.Amount = message.Amount.Amount.Neg()
+func TestValidateBasicMsgCreateBondTooManyFunctionParamsGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	for i := 0; i <= MaxFunctionParams; i++ {
+		message.FunctionParameters = append(message.FunctionParameters,
+			NewFunctionParam(fmt.Sprintf("p%d", i), sdk.OneDec()))
+	}
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrIncorrectNumberOfFunctionParameters.Is(err))
 }
 
-func TestValidateBasicMsgBuyZeroAmountGivesError(t *testing.T) {
-	message := newValidMsgBuy()
-	message.Amount.Amount = sdk.ZeroInt()
+func TestValidateBasicMsgCreateBondFunctionParamNameTooLongGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.FunctionParameters[0].Param = strings.Repeat("m", MaxFunctionParamNameLength+1)
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrArgumentTooLong.Is(err))
 }
 
-func TestValidateBasicMsgBuyMaxPricesInvalidGivesError(t *testing.T) {
-	message := newValidMsgBuy()
-	message.MaxPrices[0].Amount = sdk.ZeroInt()
+func TestValidateBasicMsgCreateBondTooManyEventAttributesGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	for i := 0; i <= MaxEventAttributes; i++ {
+		message.EventAttributes = append(message.EventAttributes,
+			NewEventAttribute(fmt.Sprintf("key_%d", i), "value"))
+	}
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrInvalidEventAttribute.Is(err))
 }
 
-// MsgBuy: correct buy
-
-func TestValidateBasicMsgBuyCorrectlyGivesNoError(t *testing.T) {
-	message := newValidMsgBuy()
+func TestValidateBasicMsgCreateBondInvalidEventAttributesGivesError(t *testing.T) {
+	testCases := []EventAttributes{
+		{NewEventAttribute("", "value")},
+		{NewEventAttribute("Project_ID", "value")},
+		{NewEventAttribute("1project", "value")},
+		{NewEventAttribute("project-id", "value")},
+		{NewEventAttribute(AttributeKeyBond, "value")},
+		{NewEventAttribute("project_id", "")},
+		{NewEventAttribute("project_id", strings.Repeat("a", MaxEventAttributeValueLength+1))},
+		{NewEventAttribute("project_id", "a"), NewEventAttribute("project_id", "b")},
+	}
+	for i, tc := range testCases {
+		message := newValidMsgCreateBond()
+		message.EventAttributes = tc
 
-	err := message.ValidateBasic()
-	require.Nil(t, err)
+		err := message.ValidateBasic()
+		require.True(t, ErrInvalidEventAttribute.Is(err),
+			"unexpected result for test case #%d", i)
+	}
 }
 
-// MsgSell: missing arguments
-
-func TestValidateBasicMsgSellSellerArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgSell()
-	message.Seller = sdk.AccAddress{}
+func TestValidateBasicMsgCreateBondInvalidAtMaxSupplyBehaviorGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.AtMaxSupplyBehavior = "close"
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
+	require.True(t, ErrInvalidAtMaxSupplyBehavior.Is(err))
 }
 
-// MsgSell: invalid arguments
-
-func TestValidateBasicMsgSellInvalidAmountGivesError(t *testing.T) {
-	message := newValidMsgSell()
-	message.Amount.Amount = message.Amount.Amount.Neg()
+func TestValidateBasicMsgCreateBondBlankAtMaxSupplyBehaviorIsValid(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.AtMaxSupplyBehavior = ""
 
-	err := message.ValidateBasic()
-	require.NotNil(t, err)
+	require.Nil(t, message.ValidateBasic())
 }
 
-func TestValidateBasicMsgSellZeroAmountGivesError(t *testing.T) {
-	message := newValidMsgSell()
-	message.Amount.Amount = sdk.ZeroInt()
+// MsgCreateBond: Valid bond creation
+
+func TestValidateBasicMsgCreateBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCreateBond()
 
 	err := message.ValidateBasic()
-	require.NotNil(t, err)
+	require.Nil(t, err)
 }
 
-// MsgSell: correct sell
-
-func TestValidateBasicMsgSellCorrectlyGivesNoError(t *testing.T) {
-	message := newValidMsgSell()
+func TestValidateBasicMsgCreateBondWithEventAttributesGivesNoError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.EventAttributes = EventAttributes{
+		NewEventAttribute("project_id", "did:ixo:abc"),
+		NewEventAttribute("region", "eu"),
+	}
 
 	err := message.ValidateBasic()
 	require.Nil(t, err)
 }
 
-// MsgSwap: missing arguments
+// MsgEditBond: missing arguments
 
-func TestValidateBasicMsgSwapSwapperArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgSwap()
-	message.Swapper = sdk.AccAddress{}
+func TestValidateBasicMsgEditBondTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.Token = ""
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
 }
 
-func TestValidateBasicMsgSwapBondTokenArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgSwap()
-	message.BondToken = ""
+func TestValidateBasicMsgEditBondNameArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.Name = stringPtr("")
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgEditBondDescriptionArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.Description = stringPtr("")
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgEditBondOrderQuantityLimitsArgumentMissingGivesNoError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.OrderQuantityLimits = coinsPtr(nil)
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgEditBondSanityRateArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.SanityRate = &sdk.Dec{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgEditBondSanityMarginPercentageArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.SanityMarginPercentage = &sdk.Dec{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgEditBondFeeAddressArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.FeeAddress = addressPtr(sdk.AccAddress{})
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgEditBondEditorArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.Editor = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgEditBond: no edits
+
+func TestValidateBasicMsgEditBondNameTooLongGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.Name = stringPtr(strings.Repeat("a", MaxBondNameLength+1))
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentTooLong.Is(err))
+}
+
+func TestValidateBasicMsgEditBondNoEditsGivesError(t *testing.T) {
+	message := NewMsgEditBond(initToken, initCreator, initSigners)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrDidNotEditAnything.Is(err))
+}
+
+func TestValidateBasicMsgEditBondNegativeSanityRateGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.SanityRate = decPtr(sdk.NewDec(-1))
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentCannotBeNegative.Is(err))
+}
+
+func TestValidateBasicMsgEditBondInvalidNetSellCapGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.NetSellCap = &sdk.Coin{Denom: initToken, Amount: sdk.NewInt(-1)}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, sdkerrors.ErrInvalidCoins.Is(err))
+}
+
+func TestValidateBasicMsgEditBondInvalidDemurrageRateGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.DemurrageRate = decPtr(sdk.NewDec(100))
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentMustBeBetween.Is(err))
+}
+
+func TestValidateBasicMsgEditBondInvalidQuoteDenomGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.QuoteDenom = stringPtr("1usd")
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgEditBondInvalidMinReserveGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+
+	message.MinReserve = coinsPtr(sdk.Coins{{Denom: "res", Amount: sdk.NewInt(-100)}})
+	require.NotNil(t, message.ValidateBasic())
+
+	message.MinReserve = coinsPtr(nil)
+	message.MinReservePercentage = decPtr(sdk.MustNewDecFromStr("100.1"))
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgEditBondModuleAccountFeeAddressGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.FeeAddress = addressPtr(supply.NewModuleAddress(BondsReserveAccount))
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrFeeAddressCannotBeModuleAccount.Is(err))
+}
+
+// MsgEditBond: invalid percentages
+
+func TestValidateBasicMsgEditBondInvalidSanityMarginPercentageGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+	message.SanityRate = decPtr(sdk.MustNewDecFromStr("0.5"))
+
+	message.SanityMarginPercentage = decPtr(sdk.NewDec(-1))
+	require.NotNil(t, message.ValidateBasic())
+
+	message.SanityMarginPercentage = decPtr(sdk.MustNewDecFromStr("100.1"))
+	require.NotNil(t, message.ValidateBasic())
+
+	message.SanityMarginPercentage = decPtr(sdk.MustNewDecFromStr("0.0000001"))
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgEditBondInvalidNetSellCapPercentageGivesError(t *testing.T) {
+	message := newValidMsgEditBond()
+
+	message.NetSellCapPercentage = decPtr(sdk.NewDec(-1))
+	require.NotNil(t, message.ValidateBasic())
+
+	message.NetSellCapPercentage = decPtr(sdk.MustNewDecFromStr("100.1"))
+	require.NotNil(t, message.ValidateBasic())
+
+	message.NetSellCapPercentage = decPtr(sdk.MustNewDecFromStr("0.0000001"))
+	require.NotNil(t, message.ValidateBasic())
+}
+
+// MsgEditBond: correct edit
+
+func TestValidateBasicMsgEditBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgEditBond()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgEditBondResetsGiveNoError(t *testing.T) {
+	message := NewMsgEditBond(initToken, initCreator, initSigners)
+	message.OrderQuantityLimits = coinsPtr(nil)
+	message.SanityRate = decPtr(sdk.ZeroDec())
+	message.SanityMarginPercentage = decPtr(sdk.ZeroDec())
+	message.NetSellCap = &sdk.Coin{Denom: initToken, Amount: sdk.ZeroInt()}
+	message.NetSellCapPercentage = decPtr(sdk.ZeroDec())
+	message.DemurrageRate = decPtr(sdk.ZeroDec())
+	message.QuoteDenom = stringPtr("")
+	message.MinReserve = coinsPtr(nil)
+	message.MinReservePercentage = decPtr(sdk.ZeroDec())
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestMsgEditBondEditedFields(t *testing.T) {
+	message := NewMsgEditBond(initToken, initCreator, initSigners)
+	require.Empty(t, message.EditedFields())
+
+	message.FeeAddress = addressPtr(initCreator)
+	message.QuoteDenom = stringPtr("")
+	message.Name = stringPtr("newName")
+	require.Equal(t, []string{"name", "quote_denom", "fee_address"}, message.EditedFields())
+}
+
+func TestMsgEditBondSignBytesOnlyIncludeEditedFields(t *testing.T) {
+	message := NewMsgEditBond(initToken, initCreator, initSigners)
+	message.SanityRate = decPtr(sdk.ZeroDec())
+
+	signBytes := string(message.GetSignBytes())
+	require.Contains(t, signBytes, `"sanity_rate":"0.000000000000000000"`)
+	require.NotContains(t, signBytes, "sanity_margin_percentage")
+	require.NotContains(t, signBytes, "name")
+
+	// Unedited fields are still unset after an amino round trip
+	var decoded MsgEditBond
+	ModuleCdc.MustUnmarshalBinaryBare(ModuleCdc.MustMarshalBinaryBare(message), &decoded)
+	require.Equal(t, message.EditedFields(), decoded.EditedFields())
+	require.True(t, decoded.SanityRate.IsZero())
+}
+
+// MsgBuy: missing arguments
+
+func TestValidateBasicMsgBuyBuyerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgBuy()
+	message.Buyer = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgBuy: invalid arguments
+
+func TestValidateBasicMsgBuyInvalidAmountGivesError(t *testing.T) {
+	message := newValidMsgBuy()
+	message.Amount.Amount = message.Amount.Amount.Neg()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgBuy()
+	message.Amount.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyMaxPricesInvalidGivesError(t *testing.T) {
+	message := newValidMsgBuy()
+	message.MaxPrices[0].Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyMaxPricesEmptyGivesError(t *testing.T) {
+	message := newValidMsgBuy()
+	message.MaxPrices = sdk.Coins{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyPriorityFeeInvalidGivesError(t *testing.T) {
+	message := newValidMsgBuy()
+	message.PriorityFee = sdk.Coins{sdk.NewInt64Coin(reserveToken, 0)}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyCallbackPayloadTooLongGivesError(t *testing.T) {
+	message := newValidMsgBuy()
+	message.CallbackPayload = strings.Repeat("a", MaxCallbackPayloadLength+1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyMemoTooLongGivesError(t *testing.T) {
+	message := newValidMsgBuy()
+	message.Memo = strings.Repeat("a", MaxOrderMemoLength+1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgBuy: correct buy
+
+func TestValidateBasicMsgBuyCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgBuy()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgBuyWithCallbackPayloadGivesNoError(t *testing.T) {
+	message := newValidMsgBuy()
+	message.CallbackPayload = strings.Repeat("a", MaxCallbackPayloadLength)
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSell: missing arguments
+
+func TestValidateBasicMsgSellSellerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSell()
+	message.Seller = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSell: invalid arguments
+
+func TestValidateBasicMsgSellInvalidAmountGivesError(t *testing.T) {
+	message := newValidMsgSell()
+	message.Amount.Amount = message.Amount.Amount.Neg()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSellZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgSell()
+	message.Amount.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSellMemoTooLongGivesError(t *testing.T) {
+	message := newValidMsgSell()
+	message.Memo = strings.Repeat("a", MaxOrderMemoLength+1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSell: correct sell
+
+func TestValidateBasicMsgSellCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgSell()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSellByValue: missing arguments
+
+func TestValidateBasicMsgSellByValueSellerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSellByValue()
+	message.Seller = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSellByValue: invalid arguments
+
+func TestValidateBasicMsgSellByValueZeroReturnsGivesError(t *testing.T) {
+	message := newValidMsgSellByValue()
+	message.Returns = sdk.Coins{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSellByValueInvalidMaxAmountGivesError(t *testing.T) {
+	message := newValidMsgSellByValue()
+	message.MaxAmount.Amount = message.MaxAmount.Amount.Neg()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSellByValueZeroMaxAmountGivesError(t *testing.T) {
+	message := newValidMsgSellByValue()
+	message.MaxAmount.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSellByValue: correct sell
+
+func TestValidateBasicMsgSellByValueCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgSellByValue()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSwap: missing arguments
+
+func TestValidateBasicMsgSwapSwapperArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.Swapper = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapBondTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.BondToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapToTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.ToToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSwap: invalid arguments
+
+func TestValidateBasicMsgSwapInvalidFromAmountGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.From.Amount = message.From.Amount.Neg()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapInvalidToTokenGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.ToToken = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapZeroFromAmountGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.From.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSwap: fromToken==toToken
+
+func TestValidateBasicMsgSwapFromAndToSameTokenGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.From = sdk.NewInt64Coin(reserveToken, 10)
+	message.ToToken = message.From.Denom
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapMemoTooLongGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.Memo = strings.Repeat("a", MaxOrderMemoLength+1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapInvalidMinReturnGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	wrongDenom := sdk.NewInt64Coin(message.From.Denom, 10)
+	zero := sdk.NewInt64Coin(message.ToToken, 0)
+	negative := sdk.Coin{Denom: message.ToToken, Amount: sdk.NewInt(-10)}
+
+	for _, minReturn := range []sdk.Coin{wrongDenom, zero, negative} {
+		minReturn := minReturn
+		message.MinReturn = &minReturn
+		err := message.ValidateBasic()
+		require.NotNil(t, err, minReturn.String())
+	}
+}
+
+// MsgSwap: correct swap
+
+func TestValidateBasicMsgSwapCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgSwap()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgSwapWithMinReturnCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgSwap()
+	minReturn := sdk.NewInt64Coin(message.ToToken, 10)
+	message.MinReturn = &minReturn
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgAuthorizedTransfer: missing arguments
+
+func TestValidateBasicMsgAuthorizedTransferFromMissingGivesError(t *testing.T) {
+	message := newValidMsgAuthorizedTransfer()
+	message.From = sdk.AccAddress{}
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
 }
 
-func TestValidateBasicMsgSwapToTokenArgumentMissingGivesError(t *testing.T) {
-	message := newValidMsgSwap()
-	message.ToToken = ""
+func TestValidateBasicMsgAuthorizedTransferToMissingGivesError(t *testing.T) {
+	message := newValidMsgAuthorizedTransfer()
+	message.To = sdk.AccAddress{}
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
 }
 
-// MsgSwap: invalid arguments
+func TestValidateBasicMsgAuthorizedTransferReasonMissingGivesError(t *testing.T) {
+	message := newValidMsgAuthorizedTransfer()
+	message.Reason = " "
 
-func TestValidateBasicMsgSwapInvalidFromAmountGivesError(t *testing.T) {
-	message := newValidMsgSwap()
-	message.From.Amount = message.From.Amount.Neg()
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgAuthorizedTransferSignersMissingGivesError(t *testing.T) {
+	message := newValidMsgAuthorizedTransfer()
+	message.Signers = nil
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
 }
 
-func TestValidateBasicMsgSwapInvalidToTokenGivesError(t *testing.T) {
-	message := newValidMsgSwap()
-	message.ToToken = "123abc"
+// MsgAuthorizedTransfer: invalid arguments
+
+func TestValidateBasicMsgAuthorizedTransferSameFromAndToGivesError(t *testing.T) {
+	message := newValidMsgAuthorizedTransfer()
+	message.To = message.From
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
 }
 
-func TestValidateBasicMsgSwapZeroFromAmountGivesError(t *testing.T) {
-	message := newValidMsgSwap()
-	message.From.Amount = sdk.ZeroInt()
+func TestValidateBasicMsgAuthorizedTransferZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgAuthorizedTransfer()
+	message.Amount = sdk.NewInt64Coin(initToken, 0)
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
 }
 
-// MsgSwap: fromToken==toToken
+// MsgAuthorizedTransfer: correct transfer
 
-func TestValidateBasicMsgSwapFromAndToSameTokenGivesError(t *testing.T) {
-	message := newValidMsgSwap()
-	message.From = sdk.NewInt64Coin(reserveToken, 10)
-	message.ToToken = message.From.Denom
+func TestValidateBasicMsgAuthorizedTransferCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgAuthorizedTransfer()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgScheduleParamChange: missing arguments
+
+func TestValidateBasicMsgScheduleParamChangeTokenMissingGivesError(t *testing.T) {
+	message := newValidMsgScheduleParamChange()
+	message.BondToken = " "
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
 }
 
-// MsgSwap: correct swap
+func TestValidateBasicMsgScheduleParamChangeFunctionParametersMissingGivesError(t *testing.T) {
+	message := newValidMsgScheduleParamChange()
+	message.FunctionParameters = nil
 
-func TestValidateBasicMsgSwapCorrectlyGivesNoError(t *testing.T) {
-	message := newValidMsgSwap()
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgScheduleParamChangeSignersMissingGivesError(t *testing.T) {
+	message := newValidMsgScheduleParamChange()
+	message.Signers = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgScheduleParamChange: invalid arguments
+
+func TestValidateBasicMsgScheduleParamChangeZeroEffectiveHeightGivesError(t *testing.T) {
+	message := newValidMsgScheduleParamChange()
+	message.EffectiveHeight = 0
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentMustBePositive.Is(err))
+}
+
+// MsgScheduleParamChange: correct schedule
+
+func TestValidateBasicMsgScheduleParamChangeCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgScheduleParamChange()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCancelParamChange: missing arguments
+
+func TestValidateBasicMsgCancelParamChangeTokenMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelParamChange()
+	message.BondToken = " "
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelParamChangeSignersMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelParamChange()
+	message.Signers = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCancelParamChange: correct cancellation
+
+func TestValidateBasicMsgCancelParamChangeCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCancelParamChange()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSubmitBondProposal: missing arguments
+
+func TestValidateBasicMsgSubmitBondProposalTitleMissingGivesError(t *testing.T) {
+	message := newValidMsgSubmitBondProposal()
+	message.Title = " "
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSubmitBondProposalFundingRecipientMissingGivesError(t *testing.T) {
+	message := newValidMsgSubmitBondProposal()
+	message.FundingRecipient = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSubmitBondProposal: invalid arguments
+
+func TestValidateBasicMsgSubmitBondProposalInvalidTypeGivesError(t *testing.T) {
+	message := newValidMsgSubmitBondProposal()
+	message.ProposalType = "dummy_type"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidBondProposalType.Is(err))
+}
+
+func TestValidateBasicMsgSubmitBondProposalTextWithFundingGivesError(t *testing.T) {
+	message := newValidMsgSubmitBondProposal()
+	message.ProposalType = BondProposalTypeText
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidBondProposalType.Is(err))
+}
+
+// MsgSubmitBondProposal: correct submission
+
+func TestValidateBasicMsgSubmitBondProposalCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgSubmitBondProposal()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgVoteBondProposal: invalid arguments
+
+func TestValidateBasicMsgVoteBondProposalInvalidOptionGivesError(t *testing.T) {
+	message := newValidMsgVoteBondProposal()
+	message.Option = "abstain"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidVoteOption.Is(err))
+}
+
+// MsgVoteBondProposal: correct vote
+
+func TestValidateBasicMsgVoteBondProposalCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgVoteBondProposal()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSetBondTranslations: invalid arguments
+
+func TestValidateBasicMsgSetBondTranslationsInvalidLocaleGivesError(t *testing.T) {
+	message := newValidMsgSetBondTranslations()
+	message.Translations[0].Locale = "French"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidBondTranslation.Is(err))
+}
+
+func TestValidateBasicMsgSetBondTranslationsDuplicateLocaleGivesError(t *testing.T) {
+	message := newValidMsgSetBondTranslations()
+	message.Translations[1].Locale = message.Translations[0].Locale
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidBondTranslation.Is(err))
+}
+
+func TestValidateBasicMsgSetBondTranslationsEmptyNameGivesError(t *testing.T) {
+	message := newValidMsgSetBondTranslations()
+	message.Translations[0].Name = " "
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidBondTranslation.Is(err))
+}
+
+func TestValidateBasicMsgSetBondTranslationsDescriptionTooLongGivesError(t *testing.T) {
+	message := newValidMsgSetBondTranslations()
+	message.Translations[0].Description = strings.Repeat("a", MaxBondDescriptionLength+1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentTooLong.Is(err))
+}
+
+func TestValidateBasicMsgSetBondTranslationsTooManyGivesError(t *testing.T) {
+	message := newValidMsgSetBondTranslations()
+	message.Translations = nil
+	for i := 0; i <= MaxBondTranslations; i++ {
+		locale := "a" + string(rune('a'+i))
+		message.Translations = append(message.Translations,
+			NewBondTranslation(locale, "name", "description"))
+	}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidBondTranslation.Is(err))
+}
+
+// MsgSetBondTranslations: correct translations
+
+func TestValidateBasicMsgSetBondTranslationsCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgSetBondTranslations()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgSetBondTranslationsEmptyGivesNoError(t *testing.T) {
+	message := newValidMsgSetBondTranslations()
+	message.Translations = nil
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSetSanityRate: missing or invalid values
+
+func TestValidateBasicMsgSetSanityRateNegativeRateGivesError(t *testing.T) {
+	message := NewMsgSetSanityRate(initToken, sdk.NewDec(-1), sdk.NewDec(10),
+		initCreator, initSigners)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentCannotBeNegative.Is(err))
+}
+
+func TestValidateBasicMsgSetSanityRateInvalidMarginGivesError(t *testing.T) {
+	message := NewMsgSetSanityRate(initToken, sdk.OneDec(), sdk.NewDec(101),
+		initCreator, initSigners)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSetSanityRate: correct values
+
+func TestValidateBasicMsgSetSanityRateCorrectlyGivesNoError(t *testing.T) {
+	message := NewMsgSetSanityRate(initToken, sdk.OneDec(), sdk.NewDec(10),
+		initCreator, initSigners)
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCreateBond: invalid pre-mine
+
+func TestValidateBasicMsgCreatePreMineInOtherDenomGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.PreMine = sdk.NewCoins(sdk.NewInt64Coin("othertoken", 10))
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreatePreMineAboveMaxSupplyGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.PreMine = sdk.NewCoins(initMaxSupply.Add(sdk.NewInt64Coin(initToken, 1)))
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrPreMineTooLarge.Is(err))
+}
+
+// MsgRebalanceSwap: missing or invalid values
+
+func TestValidateBasicMsgRebalanceSwapSwapperNotASignerGivesError(t *testing.T) {
+	message := NewMsgRebalanceSwap(initCreator, initToken,
+		sdk.NewInt64Coin(reserveToken, 10), reserveToken2, []sdk.AccAddress{initFeeAddress})
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgRebalanceSwap: correct values
+
+func TestValidateBasicMsgRebalanceSwapCorrectlyGivesNoError(t *testing.T) {
+	message := NewMsgRebalanceSwap(initCreator, initToken,
+		sdk.NewInt64Coin(reserveToken, 10), reserveToken2, initSigners)
 
 	err := message.ValidateBasic()
 	require.Nil(t, err)
 }
+
+// MsgSweepFeeDust: missing or invalid values
+
+func TestValidateBasicMsgSweepFeeDustNoOwnerGivesError(t *testing.T) {
+	message := NewMsgSweepFeeDust(sdk.AccAddress{}, reserveToken)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSweepFeeDustInvalidTargetDenomGivesError(t *testing.T) {
+	message := NewMsgSweepFeeDust(initFeeAddress, "123")
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSweepFeeDust: correct values
+
+func TestValidateBasicMsgSweepFeeDustCorrectlyGivesNoError(t *testing.T) {
+	message := NewMsgSweepFeeDust(initFeeAddress, reserveToken)
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+
+	// Blank target denom sends dust to the community pool
+	message = NewMsgSweepFeeDust(initFeeAddress, "")
+	err = message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgRegisterNotifications: missing or invalid values
+
+func TestValidateBasicMsgRegisterNotificationsNoRelayerGivesError(t *testing.T) {
+	message := NewMsgRegisterNotifications(sdk.AccAddress{}, initToken,
+		[]string{NotificationTypeFills})
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgRegisterNotificationsNoBondTokenGivesError(t *testing.T) {
+	message := NewMsgRegisterNotifications(initFeeAddress, "",
+		[]string{NotificationTypeFills})
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgRegisterNotificationsInvalidTypesGivesError(t *testing.T) {
+	invalidTypes := [][]string{
+		nil,
+		{"unknown"},
+		{NotificationTypeFills, NotificationTypeFills},
+	}
+
+	for _, notificationTypes := range invalidTypes {
+		message := NewMsgRegisterNotifications(initFeeAddress, initToken, notificationTypes)
+
+		err := message.ValidateBasic()
+		require.NotNil(t, err)
+	}
+}
+
+// MsgRegisterNotifications: correct values
+
+func TestValidateBasicMsgRegisterNotificationsCorrectlyGivesNoError(t *testing.T) {
+	message := NewMsgRegisterNotifications(initFeeAddress, initToken,
+		[]string{NotificationTypeFills, NotificationTypeSettlements})
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgUnregisterNotifications: missing or invalid values
+
+func TestValidateBasicMsgUnregisterNotificationsNoRelayerGivesError(t *testing.T) {
+	message := NewMsgUnregisterNotifications(sdk.AccAddress{}, initToken)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgUnregisterNotificationsNoBondTokenGivesError(t *testing.T) {
+	message := NewMsgUnregisterNotifications(initFeeAddress, "")
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgUnregisterNotifications: correct values
+
+func TestValidateBasicMsgUnregisterNotificationsCorrectlyGivesNoError(t *testing.T) {
+	message := NewMsgUnregisterNotifications(initFeeAddress, initToken)
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// ValidateBasic: table-driven rejection paths
+
+func TestValidateBasicRejectsInvalidMessages(t *testing.T) {
+	nilAmountCoin := sdk.Coin{Denom: initToken}
+	unsortedCoins := sdk.Coins{sdk.NewInt64Coin(reserveToken2, 1), sdk.NewInt64Coin(reserveToken, 1)}
+	duplicateSigners := []sdk.AccAddress{initCreator, initCreator}
+
+	testCases := []struct {
+		name     string
+		msg      func() sdk.Msg
+		expected *sdkerrors.Error
+	}{
+		// MsgCreateBond
+		{"create bond nil sanity rate", func() sdk.Msg {
+			m := newValidMsgCreateBond()
+			m.SanityRate = sdk.Dec{}
+			return m
+		}, ErrArgumentCannotBeEmpty},
+		{"create bond nil batch blocks", func() sdk.Msg {
+			m := newValidMsgCreateBond()
+			m.BatchBlocks = sdk.Uint{}
+			return m
+		}, ErrArgumentMustBePositive},
+		{"create bond nil max supply amount", func() sdk.Msg {
+			m := newValidMsgCreateBond()
+			m.MaxSupply = nilAmountCoin
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+		{"create bond unsorted order quantity limits", func() sdk.Msg {
+			m := newValidMsgCreateBond()
+			m.OrderQuantityLimits = unsortedCoins
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+		{"create bond nil function parameter value", func() sdk.Msg {
+			m := newValidMsgCreateBond()
+			m.FunctionParameters[0].Value = sdk.Dec{}
+			return m
+		}, ErrFunctionParameterMissingOrNonFloat},
+		{"create bond duplicate signers", func() sdk.Msg {
+			m := newValidMsgCreateBond()
+			m.Signers = duplicateSigners
+			return m
+		}, ErrDuplicateSigner},
+		{"create bond invalid token denom", func() sdk.Msg {
+			m := newValidMsgCreateBond()
+			m.Token = "1token"
+			return m
+		}, nil},
+
+		// MsgEditBond
+		{"edit bond invalid token denom", func() sdk.Msg {
+			m := newValidMsgEditBond()
+			m.Token = "1token"
+			return m
+		}, nil},
+		{"edit bond unsorted order quantity limits", func() sdk.Msg {
+			m := newValidMsgEditBond()
+			m.OrderQuantityLimits = coinsPtr(unsortedCoins)
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+		{"edit bond duplicate signers", func() sdk.Msg {
+			m := newValidMsgEditBond()
+			m.Signers = duplicateSigners
+			return m
+		}, ErrDuplicateSigner},
+
+		// MsgBuy
+		{"buy empty buyer", func() sdk.Msg {
+			m := newValidMsgBuy()
+			m.Buyer = nil
+			return m
+		}, ErrArgumentCannotBeEmpty},
+		{"buy nil amount", func() sdk.Msg {
+			m := newValidMsgBuy()
+			m.Amount = nilAmountCoin
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+		{"buy nil max price amount", func() sdk.Msg {
+			m := newValidMsgBuy()
+			m.MaxPrices = sdk.Coins{nilAmountCoin}
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+		{"buy unsorted max prices", func() sdk.Msg {
+			m := newValidMsgBuy()
+			m.MaxPrices = unsortedCoins
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+		{"buy unsorted priority fee", func() sdk.Msg {
+			m := newValidMsgBuy()
+			m.PriorityFee = unsortedCoins
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+
+		// MsgSell
+		{"sell empty seller", func() sdk.Msg {
+			m := newValidMsgSell()
+			m.Seller = nil
+			return m
+		}, ErrArgumentCannotBeEmpty},
+		{"sell nil amount", func() sdk.Msg {
+			m := newValidMsgSell()
+			m.Amount = nilAmountCoin
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+
+		// MsgSellByValue
+		{"sell by value unsorted returns", func() sdk.Msg {
+			m := newValidMsgSellByValue()
+			m.Returns = unsortedCoins
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+		{"sell by value nil max amount", func() sdk.Msg {
+			m := newValidMsgSellByValue()
+			m.MaxAmount = nilAmountCoin
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+
+		// MsgSwap
+		{"swap invalid bond token denom", func() sdk.Msg {
+			m := newValidMsgSwap()
+			m.BondToken = "1token"
+			return m
+		}, nil},
+		{"swap nil from amount", func() sdk.Msg {
+			m := newValidMsgSwap()
+			m.From = sdk.Coin{Denom: reserveToken}
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+		{"swap nil min return amount", func() sdk.Msg {
+			m := newValidMsgSwap()
+			m.MinReturn = &sdk.Coin{Denom: reserveToken2}
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+
+		// MsgMakeOutcomePayment and MsgWithdrawShare
+		{"outcome payment empty sender", func() sdk.Msg {
+			return NewMsgMakeOutcomePayment(nil, initToken)
+		}, ErrArgumentCannotBeEmpty},
+		{"withdraw share invalid bond token denom", func() sdk.Msg {
+			return NewMsgWithdrawShare(initCreator, "1token")
+		}, nil},
+
+		// MsgAuthorizedTransfer
+		{"authorized transfer nil amount", func() sdk.Msg {
+			m := newValidMsgAuthorizedTransfer()
+			m.Amount = nilAmountCoin
+			return m
+		}, sdkerrors.ErrInvalidCoins},
+		{"authorized transfer duplicate signers", func() sdk.Msg {
+			m := newValidMsgAuthorizedTransfer()
+			m.Signers = duplicateSigners
+			return m
+		}, ErrDuplicateSigner},
+
+		// MsgScheduleParamChange and MsgCancelParamChange
+		{"schedule param change invalid bond token denom", func() sdk.Msg {
+			m := newValidMsgScheduleParamChange()
+			m.BondToken = "1token"
+			return m
+		}, nil},
+		{"schedule param change nil function parameter value", func() sdk.Msg {
+			m := newValidMsgScheduleParamChange()
+			m.FunctionParameters[0].Value = sdk.Dec{}
+			return m
+		}, ErrFunctionParameterMissingOrNonFloat},
+		{"cancel param change invalid bond token denom", func() sdk.Msg {
+			m := newValidMsgCancelParamChange()
+			m.BondToken = "1token"
+			return m
+		}, nil},
+		{"cancel param change duplicate signers", func() sdk.Msg {
+			m := newValidMsgCancelParamChange()
+			m.Signers = duplicateSigners
+			return m
+		}, ErrDuplicateSigner},
+
+		// MsgSubmitBondProposal and MsgVoteBondProposal
+		{"submit bond proposal invalid bond token denom", func() sdk.Msg {
+			m := newValidMsgSubmitBondProposal()
+			m.BondToken = "1token"
+			return m
+		}, nil},
+		{"vote bond proposal zero proposal ID", func() sdk.Msg {
+			m := newValidMsgVoteBondProposal()
+			m.ProposalID = 0
+			return m
+		}, ErrArgumentMustBePositive},
+
+		// MsgSetBondTranslations and MsgSetSanityRate
+		{"set bond translations invalid bond token denom", func() sdk.Msg {
+			m := newValidMsgSetBondTranslations()
+			m.BondToken = "1token"
+			return m
+		}, nil},
+		{"set sanity rate invalid bond token denom", func() sdk.Msg {
+			return NewMsgSetSanityRate("1token", sdk.OneDec(), sdk.OneDec(),
+				initCreator, initSigners)
+		}, nil},
+		{"set sanity rate duplicate signers", func() sdk.Msg {
+			return NewMsgSetSanityRate(initToken, sdk.OneDec(), sdk.OneDec(),
+				initCreator, duplicateSigners)
+		}, ErrDuplicateSigner},
+
+		// MsgRebalanceSwap and MsgSweepFeeDust
+		{"rebalance swap invalid bond token denom", func() sdk.Msg {
+			return NewMsgRebalanceSwap(initCreator, "1token",
+				sdk.NewInt64Coin(reserveToken, 10), reserveToken2, initSigners)
+		}, nil},
+		{"sweep fee dust invalid target denom", func() sdk.Msg {
+			return NewMsgSweepFeeDust(initCreator, "1denom")
+		}, nil},
+
+		// MsgRegisterNotifications and MsgUnregisterNotifications
+		{"register notifications invalid bond token denom", func() sdk.Msg {
+			return NewMsgRegisterNotifications(initCreator, "1token",
+				[]string{NotificationTypeFills})
+		}, nil},
+		{"unregister notifications invalid bond token denom", func() sdk.Msg {
+			return NewMsgUnregisterNotifications(initCreator, "1token")
+		}, nil},
+	}
+
+	for _, tc := range testCases {
+		var err error
+		require.NotPanics(t, func() { err = tc.msg().ValidateBasic() }, tc.name)
+		require.Error(t, err, tc.name)
+		if tc.expected != nil {
+			require.True(t, tc.expected.Is(err), "%s: %s", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Notification types that a relayer can register for
+const (
+	// NotificationTypeFills notifies the relayer of each settlement of one of
+	// the bond's batches in which any orders were fulfilled
+	NotificationTypeFills = "fills"
+	// NotificationTypeSettlements notifies the relayer of each settlement of
+	// one of the bond's batches that had any orders
+	NotificationTypeSettlements = "settlements"
+)
+
+// NotificationRegistration records the interest of an off-chain relayer in
+// notifications about a bond. The deposit taken from the relayer when it
+// registered is held by the notification deposits account and is returned to
+// the relayer when it unregisters.
+type NotificationRegistration struct {
+	BondToken         string         `json:"bond_token" yaml:"bond_token"`
+	Relayer           sdk.AccAddress `json:"relayer" yaml:"relayer"`
+	NotificationTypes []string       `json:"notification_types" yaml:"notification_types"`
+	Deposit           sdk.Coins      `json:"deposit" yaml:"deposit"`
+	Height            int64          `json:"height" yaml:"height"`
+}
+
+func NewNotificationRegistration(bondToken string, relayer sdk.AccAddress,
+	notificationTypes []string, deposit sdk.Coins, height int64) NotificationRegistration {
+	return NotificationRegistration{
+		BondToken:         bondToken,
+		Relayer:           relayer,
+		NotificationTypes: notificationTypes,
+		Deposit:           deposit,
+		Height:            height,
+	}
+}
+
+// ValidateNotificationTypes checks that at least one notification type was
+// specified and that each type is recognised and specified only once.
+func ValidateNotificationTypes(notificationTypes []string) error {
+	if len(notificationTypes) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "NotificationTypes")
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range notificationTypes {
+		if t != NotificationTypeFills && t != NotificationTypeSettlements {
+			return sdkerrors.Wrap(ErrInvalidNotificationType, t)
+		} else if seen[t] {
+			return sdkerrors.Wrapf(ErrInvalidNotificationType, "duplicate type %s", t)
+		}
+		seen[t] = true
+	}
+	return nil
+}
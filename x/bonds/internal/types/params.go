@@ -0,0 +1,736 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace is the default paramspace for the bonds module
+const DefaultParamspace = ModuleName
+
+// Bond creation fee destinations
+const (
+	CreationFeeDestinationBurn          = "burn"
+	CreationFeeDestinationCommunityPool = "community_pool"
+)
+
+// Default parameter values
+var (
+	DefaultBondProposalQuorum     = sdk.MustNewDecFromStr("33.4") // 33.4%
+	DefaultBondCreationFee        = sdk.Coins(nil)                // no fee
+	DefaultCreationFeeDestination = CreationFeeDestinationBurn
+	DefaultMaxNameLength          = uint64(MaxBondNameLength)
+	DefaultMaxDescriptionLength   = uint64(MaxBondDescriptionLength)
+	DefaultBuySpendCap            = sdk.Coins(nil) // no cap
+	DefaultSpendCapWindowBlocks   = uint64(17280)  // ~1 day at 5s blocks
+
+	DefaultMaxSanityRateStepPercentage   = sdk.NewDec(5)  // 5%
+	DefaultMaxSanityRateWindowPercentage = sdk.NewDec(20) // 20%
+	DefaultSanityRateWindowBlocks        = uint64(17280)  // ~1 day at 5s blocks
+
+	DefaultMaxPreMinePercentage = sdk.NewDec(10)  // 10%
+	DefaultPreMineVestingBlocks = uint64(6307200) // ~1 year at 5s blocks
+
+	DefaultAlertChangePercentage = sdk.ZeroDec() // no alerts
+	DefaultAlertWindowBlocks     = uint64(17280) // ~1 day at 5s blocks
+
+	DefaultMaxTotalValueLocked = sdk.Coins(nil) // no cap
+	DefaultMaxBondValueLocked  = sdk.Coins(nil) // no cap
+
+	DefaultFeeDustThreshold   = sdk.NewInt(100)
+	DefaultFeeDustDenom       = ""        // sent to the community pool
+	DefaultFeeDustSweepBlocks = uint64(0) // no periodic sweeps
+
+	DefaultNotificationDeposit       = sdk.Coins(nil) // no deposit
+	DefaultMaxBondNotificationRelays = uint64(100)
+
+	DefaultStressPriceImpactPercentage = sdk.ZeroDec() // no price impact threshold
+	DefaultStressVolumePercentage      = sdk.ZeroDec() // no volume threshold
+	DefaultStressConsecutiveBatches    = uint64(2)
+	DefaultStressExtraBlocks           = uint64(10)
+	DefaultStressMaxExtraBlocks        = uint64(100)
+
+	DefaultMinBatchBlocks = uint64(1)
+	DefaultMaxBatchBlocks = uint64(1000)
+
+	DefaultOrderReceiptRetentionBlocks = uint64(518400) // ~30 days at 5s blocks
+	DefaultLedgerEntryRetentionBlocks  = uint64(518400) // ~30 days at 5s blocks
+)
+
+// Parameter store keys
+var (
+	KeyOrderSubmissionHalted  = []byte("OrderSubmissionHalted")
+	KeyBondProposalQuorum     = []byte("BondProposalQuorum")
+	KeyBondCreationFee        = []byte("BondCreationFee")
+	KeyCreationFeeDestination = []byte("CreationFeeDestination")
+	KeyMaxNameLength          = []byte("MaxNameLength")
+	KeyMaxDescriptionLength   = []byte("MaxDescriptionLength")
+	KeyBuySpendCap            = []byte("BuySpendCap")
+	KeySpendCapWindowBlocks   = []byte("SpendCapWindowBlocks")
+
+	KeyMaxSanityRateStepPercentage   = []byte("MaxSanityRateStepPercentage")
+	KeyMaxSanityRateWindowPercentage = []byte("MaxSanityRateWindowPercentage")
+	KeySanityRateWindowBlocks        = []byte("SanityRateWindowBlocks")
+
+	KeyMaxPreMinePercentage = []byte("MaxPreMinePercentage")
+	KeyPreMineVestingBlocks = []byte("PreMineVestingBlocks")
+
+	KeyAlertChangePercentage = []byte("AlertChangePercentage")
+	KeyAlertWindowBlocks     = []byte("AlertWindowBlocks")
+
+	KeyMaxTotalValueLocked = []byte("MaxTotalValueLocked")
+	KeyMaxBondValueLocked  = []byte("MaxBondValueLocked")
+
+	KeyFeeDustThreshold   = []byte("FeeDustThreshold")
+	KeyFeeDustDenom       = []byte("FeeDustDenom")
+	KeyFeeDustSweepBlocks = []byte("FeeDustSweepBlocks")
+
+	KeyNotificationDeposit       = []byte("NotificationDeposit")
+	KeyMaxBondNotificationRelays = []byte("MaxBondNotificationRelays")
+
+	KeyStressPriceImpactPercentage = []byte("StressPriceImpactPercentage")
+	KeyStressVolumePercentage      = []byte("StressVolumePercentage")
+	KeyStressConsecutiveBatches    = []byte("StressConsecutiveBatches")
+	KeyStressExtraBlocks           = []byte("StressExtraBlocks")
+	KeyStressMaxExtraBlocks        = []byte("StressMaxExtraBlocks")
+
+	KeyMinBatchBlocks = []byte("MinBatchBlocks")
+	KeyMaxBatchBlocks = []byte("MaxBatchBlocks")
+
+	KeyOrderReceiptRetentionBlocks = []byte("OrderReceiptRetentionBlocks")
+	KeyLedgerEntryRetentionBlocks  = []byte("LedgerEntryRetentionBlocks")
+)
+
+// ParamKeyTable returns the parameter key table for the bonds module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// Params are module-wide parameters, which can be changed through governance
+// (i.e. using a parameter change proposal).
+type Params struct {
+	// OrderSubmissionHalted acts as an emergency kill switch which, while set,
+	// halts the submission of new orders (buys, sells, and swaps) for all bonds.
+	OrderSubmissionHalted bool `json:"order_submission_halted" yaml:"order_submission_halted"`
+	// BondProposalQuorum is the minimum percentage of a bond's supply that
+	// has to vote on a bond proposal for the proposal to be able to pass.
+	BondProposalQuorum sdk.Dec `json:"bond_proposal_quorum" yaml:"bond_proposal_quorum"`
+	// BondCreationFee is a flat fee charged to the creator of every new bond,
+	// which is either burned or sent to the community pool.
+	BondCreationFee sdk.Coins `json:"bond_creation_fee" yaml:"bond_creation_fee"`
+	// CreationFeeDestination is where the bond creation fee goes, i.e. either
+	// "burn" or "community_pool".
+	CreationFeeDestination string `json:"creation_fee_destination" yaml:"creation_fee_destination"`
+	// MaxNameLength is the maximum length of a bond's name, which can be set
+	// to at most the hard cap of MaxBondNameLength.
+	MaxNameLength uint64 `json:"max_name_length" yaml:"max_name_length"`
+	// MaxDescriptionLength is the maximum length of a bond's description,
+	// which can be set to at most the hard cap of MaxBondDescriptionLength.
+	MaxDescriptionLength uint64 `json:"max_description_length" yaml:"max_description_length"`
+	// BuySpendCap is the maximum amount that a single address can commit to
+	// buys (across all bonds) within the spend cap window, as a form of retail
+	// protection. Only the denominations present in the cap are capped, so an
+	// empty cap disables spend caps.
+	BuySpendCap sdk.Coins `json:"buy_spend_cap" yaml:"buy_spend_cap"`
+	// SpendCapWindowBlocks is the length in blocks of the rolling window over
+	// which the amounts committed to buys are summed and checked against the
+	// buy spend cap.
+	SpendCapWindowBlocks uint64 `json:"spend_cap_window_blocks" yaml:"spend_cap_window_blocks"`
+	// MaxSanityRateStepPercentage is the maximum change (as a percentage of
+	// the current value) that a single update can make to a swapper bond's
+	// sanity rate or sanity margin percentage.
+	MaxSanityRateStepPercentage sdk.Dec `json:"max_sanity_rate_step_percentage" yaml:"max_sanity_rate_step_percentage"`
+	// MaxSanityRateWindowPercentage is the maximum total change (as a
+	// percentage of the value at the start of the window) that updates can
+	// make to a swapper bond's sanity rate or sanity margin percentage within
+	// a sanity rate window.
+	MaxSanityRateWindowPercentage sdk.Dec `json:"max_sanity_rate_window_percentage" yaml:"max_sanity_rate_window_percentage"`
+	// SanityRateWindowBlocks is the length in blocks of the window over which
+	// the total change of a swapper bond's sanity values is limited.
+	SanityRateWindowBlocks uint64 `json:"sanity_rate_window_blocks" yaml:"sanity_rate_window_blocks"`
+	// MaxPreMinePercentage is the maximum amount (as a percentage of the
+	// bond's max supply) that can be pre-mined for the creator of a bond.
+	MaxPreMinePercentage sdk.Dec `json:"max_pre_mine_percentage" yaml:"max_pre_mine_percentage"`
+	// PreMineVestingBlocks is the length in blocks of the vesting schedule
+	// under which pre-mined tokens are released to the bond's creator.
+	PreMineVestingBlocks uint64 `json:"pre_mine_vesting_blocks" yaml:"pre_mine_vesting_blocks"`
+	// AlertChangePercentage is the change (as a percentage of the value at
+	// the start of the alert window) in a bond's spot price, reserve, or
+	// supply above which an alert event is emitted. Zero disables alerts.
+	AlertChangePercentage sdk.Dec `json:"alert_change_percentage" yaml:"alert_change_percentage"`
+	// AlertWindowBlocks is the length in blocks of the window over which the
+	// changes in a bond's spot price, reserve, and supply are measured.
+	AlertWindowBlocks uint64 `json:"alert_window_blocks" yaml:"alert_window_blocks"`
+	// MaxTotalValueLocked is the maximum total reserve that all bonds can hold
+	// together, as a launch-phase risk limit. Buys that would take the total
+	// reserve above the cap are deferred to the next batch. Only the
+	// denominations present in the cap are capped, so an empty cap disables it.
+	MaxTotalValueLocked sdk.Coins `json:"max_total_value_locked" yaml:"max_total_value_locked"`
+	// MaxBondValueLocked is the maximum reserve that each individual bond can
+	// hold, enforced in the same way as the max total value locked. An empty
+	// cap disables it.
+	MaxBondValueLocked sdk.Coins `json:"max_bond_value_locked" yaml:"max_bond_value_locked"`
+	// FeeDustThreshold is the amount below which a balance (in any
+	// denomination) is considered to be dust when sweeping fee dust.
+	FeeDustThreshold sdk.Int `json:"fee_dust_threshold" yaml:"fee_dust_threshold"`
+	// FeeDustDenom is the denomination into which the periodic sweeps
+	// consolidate the fee dust of bonds' fee addresses using swapper bonds.
+	// Dust that cannot be swapped is sent to the community pool, so a blank
+	// denomination sends all of the dust to the community pool.
+	FeeDustDenom string `json:"fee_dust_denom" yaml:"fee_dust_denom"`
+	// FeeDustSweepBlocks is the number of blocks between periodic sweeps of
+	// the fee dust of bonds' fee addresses. Zero disables periodic sweeps.
+	FeeDustSweepBlocks uint64 `json:"fee_dust_sweep_blocks" yaml:"fee_dust_sweep_blocks"`
+	// NotificationDeposit is the deposit taken from a relayer for each bond
+	// that it registers for notifications about, to deter spam. The deposit
+	// is returned to the relayer when it unregisters.
+	NotificationDeposit sdk.Coins `json:"notification_deposit" yaml:"notification_deposit"`
+	// MaxBondNotificationRelays is the maximum number of relayers that can be
+	// registered for notifications about any one bond. Zero disables new
+	// registrations.
+	MaxBondNotificationRelays uint64 `json:"max_bond_notification_relays" yaml:"max_bond_notification_relays"`
+	// StressPriceImpactPercentage is the change (as a percentage of the value
+	// before the batch) in a bond's spot price caused by a batch above which
+	// the batch is considered to be stressed. Zero disables the threshold.
+	StressPriceImpactPercentage sdk.Dec `json:"stress_price_impact_percentage" yaml:"stress_price_impact_percentage"`
+	// StressVolumePercentage is the amount of bond tokens bought and sold in
+	// a batch (as a percentage of the bond's supply before the batch) above
+	// which the batch is considered to be stressed. Zero disables the
+	// threshold, and batches are never stressed if both thresholds are zero.
+	StressVolumePercentage sdk.Dec `json:"stress_volume_percentage" yaml:"stress_volume_percentage"`
+	// StressConsecutiveBatches is the number of consecutive stressed batches
+	// of a bond from which the bond's batches start to be lengthened.
+	StressConsecutiveBatches uint64 `json:"stress_consecutive_batches" yaml:"stress_consecutive_batches"`
+	// StressExtraBlocks is the number of blocks by which a bond's batches are
+	// lengthened for each stressed batch, once enough consecutive batches
+	// have been stressed. The extra blocks halve after every batch that is
+	// not stressed.
+	StressExtraBlocks uint64 `json:"stress_extra_blocks" yaml:"stress_extra_blocks"`
+	// StressMaxExtraBlocks is the maximum number of blocks by which a bond's
+	// batches can be lengthened.
+	StressMaxExtraBlocks uint64 `json:"stress_max_extra_blocks" yaml:"stress_max_extra_blocks"`
+	// MinBatchBlocks is the minimum number of blocks of a new bond's batches,
+	// since batches that are too short defeat the purpose of batching.
+	MinBatchBlocks uint64 `json:"min_batch_blocks" yaml:"min_batch_blocks"`
+	// MaxBatchBlocks is the maximum number of blocks of a new bond's batches,
+	// since batches that are too long lock the funds of orders in escrow.
+	MaxBatchBlocks uint64 `json:"max_batch_blocks" yaml:"max_batch_blocks"`
+	// OrderReceiptRetentionBlocks is the number of blocks for which order
+	// receipts are kept after the order was submitted, after which they are
+	// pruned. Zero keeps order receipts forever.
+	OrderReceiptRetentionBlocks uint64 `json:"order_receipt_retention_blocks" yaml:"order_receipt_retention_blocks"`
+	// LedgerEntryRetentionBlocks is the number of blocks for which ledger
+	// entries are kept after they were recorded, after which they are pruned.
+	// Pruning entries does not affect the ledgers' balances. Zero keeps ledger
+	// entries forever.
+	LedgerEntryRetentionBlocks uint64 `json:"ledger_entry_retention_blocks" yaml:"ledger_entry_retention_blocks"`
+}
+
+func NewParams(orderSubmissionHalted bool, bondProposalQuorum sdk.Dec,
+	bondCreationFee sdk.Coins, creationFeeDestination string,
+	maxNameLength, maxDescriptionLength uint64, buySpendCap sdk.Coins,
+	spendCapWindowBlocks uint64, maxSanityRateStepPercentage,
+	maxSanityRateWindowPercentage sdk.Dec, sanityRateWindowBlocks uint64,
+	maxPreMinePercentage sdk.Dec, preMineVestingBlocks uint64,
+	alertChangePercentage sdk.Dec, alertWindowBlocks uint64,
+	maxTotalValueLocked, maxBondValueLocked sdk.Coins, feeDustThreshold sdk.Int,
+	feeDustDenom string, feeDustSweepBlocks uint64, notificationDeposit sdk.Coins,
+	maxBondNotificationRelays uint64, stressPriceImpactPercentage,
+	stressVolumePercentage sdk.Dec, stressConsecutiveBatches, stressExtraBlocks,
+	stressMaxExtraBlocks, minBatchBlocks, maxBatchBlocks,
+	orderReceiptRetentionBlocks, ledgerEntryRetentionBlocks uint64) Params {
+	return Params{
+		OrderSubmissionHalted:  orderSubmissionHalted,
+		BondProposalQuorum:     bondProposalQuorum,
+		BondCreationFee:        bondCreationFee,
+		CreationFeeDestination: creationFeeDestination,
+		MaxNameLength:          maxNameLength,
+		MaxDescriptionLength:   maxDescriptionLength,
+		BuySpendCap:            buySpendCap,
+		SpendCapWindowBlocks:   spendCapWindowBlocks,
+
+		MaxSanityRateStepPercentage:   maxSanityRateStepPercentage,
+		MaxSanityRateWindowPercentage: maxSanityRateWindowPercentage,
+		SanityRateWindowBlocks:        sanityRateWindowBlocks,
+
+		MaxPreMinePercentage: maxPreMinePercentage,
+		PreMineVestingBlocks: preMineVestingBlocks,
+
+		AlertChangePercentage: alertChangePercentage,
+		AlertWindowBlocks:     alertWindowBlocks,
+
+		MaxTotalValueLocked: maxTotalValueLocked,
+		MaxBondValueLocked:  maxBondValueLocked,
+
+		FeeDustThreshold:   feeDustThreshold,
+		FeeDustDenom:       feeDustDenom,
+		FeeDustSweepBlocks: feeDustSweepBlocks,
+
+		NotificationDeposit:       notificationDeposit,
+		MaxBondNotificationRelays: maxBondNotificationRelays,
+
+		StressPriceImpactPercentage: stressPriceImpactPercentage,
+		StressVolumePercentage:      stressVolumePercentage,
+		StressConsecutiveBatches:    stressConsecutiveBatches,
+		StressExtraBlocks:           stressExtraBlocks,
+		StressMaxExtraBlocks:        stressMaxExtraBlocks,
+
+		MinBatchBlocks: minBatchBlocks,
+		MaxBatchBlocks: maxBatchBlocks,
+
+		OrderReceiptRetentionBlocks: orderReceiptRetentionBlocks,
+		LedgerEntryRetentionBlocks:  ledgerEntryRetentionBlocks,
+	}
+}
+
+func DefaultParams() Params {
+	return NewParams(false, DefaultBondProposalQuorum,
+		DefaultBondCreationFee, DefaultCreationFeeDestination,
+		DefaultMaxNameLength, DefaultMaxDescriptionLength,
+		DefaultBuySpendCap, DefaultSpendCapWindowBlocks,
+		DefaultMaxSanityRateStepPercentage, DefaultMaxSanityRateWindowPercentage,
+		DefaultSanityRateWindowBlocks, DefaultMaxPreMinePercentage,
+		DefaultPreMineVestingBlocks, DefaultAlertChangePercentage,
+		DefaultAlertWindowBlocks, DefaultMaxTotalValueLocked,
+		DefaultMaxBondValueLocked, DefaultFeeDustThreshold, DefaultFeeDustDenom,
+		DefaultFeeDustSweepBlocks, DefaultNotificationDeposit,
+		DefaultMaxBondNotificationRelays, DefaultStressPriceImpactPercentage,
+		DefaultStressVolumePercentage, DefaultStressConsecutiveBatches,
+		DefaultStressExtraBlocks, DefaultStressMaxExtraBlocks,
+		DefaultMinBatchBlocks, DefaultMaxBatchBlocks,
+		DefaultOrderReceiptRetentionBlocks, DefaultLedgerEntryRetentionBlocks)
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf(`Bonds Params:
+  Order Submission Halted:  %t
+  Bond Proposal Quorum:     %s
+  Bond Creation Fee:        %s
+  Creation Fee Destination: %s
+  Max Name Length:          %d
+  Max Description Length:   %d
+  Buy Spend Cap:            %s
+  Spend Cap Window Blocks:  %d
+  Max Sanity Rate Step:     %s
+  Max Sanity Rate Window:   %s
+  Sanity Window Blocks:     %d
+  Max Pre-Mine Percentage:  %s
+  Pre-Mine Vesting Blocks:  %d
+  Alert Change Percentage:  %s
+  Alert Window Blocks:      %d
+  Max Total Value Locked:   %s
+  Max Bond Value Locked:    %s
+  Fee Dust Threshold:       %s
+  Fee Dust Denom:           %s
+  Fee Dust Sweep Blocks:    %d
+  Notification Deposit:     %s
+  Max Notification Relays:  %d
+  Stress Price Impact:      %s
+  Stress Volume:            %s
+  Stress Batches:           %d
+  Stress Extra Blocks:      %d
+  Stress Max Extra Blocks:  %d
+  Min Batch Blocks:         %d
+  Max Batch Blocks:         %d
+  Receipt Retention Blocks: %d
+  Ledger Retention Blocks:  %d
+`, p.OrderSubmissionHalted, p.BondProposalQuorum, p.BondCreationFee,
+		p.CreationFeeDestination, p.MaxNameLength, p.MaxDescriptionLength,
+		p.BuySpendCap, p.SpendCapWindowBlocks, p.MaxSanityRateStepPercentage,
+		p.MaxSanityRateWindowPercentage, p.SanityRateWindowBlocks,
+		p.MaxPreMinePercentage, p.PreMineVestingBlocks,
+		p.AlertChangePercentage, p.AlertWindowBlocks,
+		p.MaxTotalValueLocked, p.MaxBondValueLocked, p.FeeDustThreshold,
+		p.FeeDustDenom, p.FeeDustSweepBlocks, p.NotificationDeposit,
+		p.MaxBondNotificationRelays, p.StressPriceImpactPercentage,
+		p.StressVolumePercentage, p.StressConsecutiveBatches,
+		p.StressExtraBlocks, p.StressMaxExtraBlocks, p.MinBatchBlocks,
+		p.MaxBatchBlocks, p.OrderReceiptRetentionBlocks,
+		p.LedgerEntryRetentionBlocks)
+}
+
+// ParamSetPairs implements the params.ParamSet interface
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(KeyOrderSubmissionHalted, &p.OrderSubmissionHalted, validateOrderSubmissionHalted),
+		params.NewParamSetPair(KeyBondProposalQuorum, &p.BondProposalQuorum, validateBondProposalQuorum),
+		params.NewParamSetPair(KeyBondCreationFee, &p.BondCreationFee, validateBondCreationFee),
+		params.NewParamSetPair(KeyCreationFeeDestination, &p.CreationFeeDestination, validateCreationFeeDestination),
+		params.NewParamSetPair(KeyMaxNameLength, &p.MaxNameLength, validateMaxNameLength),
+		params.NewParamSetPair(KeyMaxDescriptionLength, &p.MaxDescriptionLength, validateMaxDescriptionLength),
+		params.NewParamSetPair(KeyBuySpendCap, &p.BuySpendCap, validateBuySpendCap),
+		params.NewParamSetPair(KeySpendCapWindowBlocks, &p.SpendCapWindowBlocks, validateSpendCapWindowBlocks),
+		params.NewParamSetPair(KeyMaxSanityRateStepPercentage, &p.MaxSanityRateStepPercentage, validateMaxSanityRateChangePercentage),
+		params.NewParamSetPair(KeyMaxSanityRateWindowPercentage, &p.MaxSanityRateWindowPercentage, validateMaxSanityRateChangePercentage),
+		params.NewParamSetPair(KeySanityRateWindowBlocks, &p.SanityRateWindowBlocks, validateSanityRateWindowBlocks),
+		params.NewParamSetPair(KeyMaxPreMinePercentage, &p.MaxPreMinePercentage, validateMaxPreMinePercentage),
+		params.NewParamSetPair(KeyPreMineVestingBlocks, &p.PreMineVestingBlocks, validatePreMineVestingBlocks),
+		params.NewParamSetPair(KeyAlertChangePercentage, &p.AlertChangePercentage, validateAlertChangePercentage),
+		params.NewParamSetPair(KeyAlertWindowBlocks, &p.AlertWindowBlocks, validateAlertWindowBlocks),
+		params.NewParamSetPair(KeyMaxTotalValueLocked, &p.MaxTotalValueLocked, validateMaxValueLocked),
+		params.NewParamSetPair(KeyMaxBondValueLocked, &p.MaxBondValueLocked, validateMaxValueLocked),
+		params.NewParamSetPair(KeyFeeDustThreshold, &p.FeeDustThreshold, validateFeeDustThreshold),
+		params.NewParamSetPair(KeyFeeDustDenom, &p.FeeDustDenom, validateFeeDustDenom),
+		params.NewParamSetPair(KeyFeeDustSweepBlocks, &p.FeeDustSweepBlocks, validateFeeDustSweepBlocks),
+		params.NewParamSetPair(KeyNotificationDeposit, &p.NotificationDeposit, validateNotificationDeposit),
+		params.NewParamSetPair(KeyMaxBondNotificationRelays, &p.MaxBondNotificationRelays, validateMaxBondNotificationRelays),
+		params.NewParamSetPair(KeyStressPriceImpactPercentage, &p.StressPriceImpactPercentage, validateStressPercentage),
+		params.NewParamSetPair(KeyStressVolumePercentage, &p.StressVolumePercentage, validateStressPercentage),
+		params.NewParamSetPair(KeyStressConsecutiveBatches, &p.StressConsecutiveBatches, validateStressConsecutiveBatches),
+		params.NewParamSetPair(KeyStressExtraBlocks, &p.StressExtraBlocks, validateStressExtraBlocks),
+		params.NewParamSetPair(KeyStressMaxExtraBlocks, &p.StressMaxExtraBlocks, validateStressExtraBlocks),
+		params.NewParamSetPair(KeyMinBatchBlocks, &p.MinBatchBlocks, validateBatchBlocksBound),
+		params.NewParamSetPair(KeyMaxBatchBlocks, &p.MaxBatchBlocks, validateBatchBlocksBound),
+		params.NewParamSetPair(KeyOrderReceiptRetentionBlocks, &p.OrderReceiptRetentionBlocks, validateRetentionBlocks),
+		params.NewParamSetPair(KeyLedgerEntryRetentionBlocks, &p.LedgerEntryRetentionBlocks, validateRetentionBlocks),
+	}
+}
+
+func (p Params) Validate() error {
+	if err := validateOrderSubmissionHalted(p.OrderSubmissionHalted); err != nil {
+		return err
+	}
+	if err := validateBondProposalQuorum(p.BondProposalQuorum); err != nil {
+		return err
+	}
+	if err := validateBondCreationFee(p.BondCreationFee); err != nil {
+		return err
+	}
+	if err := validateCreationFeeDestination(p.CreationFeeDestination); err != nil {
+		return err
+	}
+	if err := validateMaxNameLength(p.MaxNameLength); err != nil {
+		return err
+	}
+	if err := validateMaxDescriptionLength(p.MaxDescriptionLength); err != nil {
+		return err
+	}
+	if err := validateBuySpendCap(p.BuySpendCap); err != nil {
+		return err
+	}
+	if err := validateSpendCapWindowBlocks(p.SpendCapWindowBlocks); err != nil {
+		return err
+	}
+	if err := validateMaxSanityRateChangePercentage(p.MaxSanityRateStepPercentage); err != nil {
+		return err
+	}
+	if err := validateMaxSanityRateChangePercentage(p.MaxSanityRateWindowPercentage); err != nil {
+		return err
+	}
+	if err := validateSanityRateWindowBlocks(p.SanityRateWindowBlocks); err != nil {
+		return err
+	}
+	if err := validateMaxPreMinePercentage(p.MaxPreMinePercentage); err != nil {
+		return err
+	}
+	if err := validatePreMineVestingBlocks(p.PreMineVestingBlocks); err != nil {
+		return err
+	}
+	if err := validateAlertChangePercentage(p.AlertChangePercentage); err != nil {
+		return err
+	}
+	if err := validateAlertWindowBlocks(p.AlertWindowBlocks); err != nil {
+		return err
+	}
+	if err := validateMaxValueLocked(p.MaxTotalValueLocked); err != nil {
+		return err
+	}
+	if err := validateMaxValueLocked(p.MaxBondValueLocked); err != nil {
+		return err
+	}
+	if err := validateFeeDustThreshold(p.FeeDustThreshold); err != nil {
+		return err
+	}
+	if err := validateFeeDustDenom(p.FeeDustDenom); err != nil {
+		return err
+	}
+	if err := validateFeeDustSweepBlocks(p.FeeDustSweepBlocks); err != nil {
+		return err
+	}
+	if err := validateNotificationDeposit(p.NotificationDeposit); err != nil {
+		return err
+	}
+	if err := validateMaxBondNotificationRelays(p.MaxBondNotificationRelays); err != nil {
+		return err
+	}
+	if err := validateStressPercentage(p.StressPriceImpactPercentage); err != nil {
+		return err
+	}
+	if err := validateStressPercentage(p.StressVolumePercentage); err != nil {
+		return err
+	}
+	if err := validateStressConsecutiveBatches(p.StressConsecutiveBatches); err != nil {
+		return err
+	}
+	if err := validateStressExtraBlocks(p.StressExtraBlocks); err != nil {
+		return err
+	}
+	if err := validateStressExtraBlocks(p.StressMaxExtraBlocks); err != nil {
+		return err
+	}
+	if err := validateBatchBlocksBound(p.MinBatchBlocks); err != nil {
+		return err
+	}
+	if err := validateBatchBlocksBound(p.MaxBatchBlocks); err != nil {
+		return err
+	}
+	if p.MinBatchBlocks > p.MaxBatchBlocks {
+		return fmt.Errorf("min batch blocks %d cannot exceed max batch blocks %d",
+			p.MinBatchBlocks, p.MaxBatchBlocks)
+	}
+	if err := validateRetentionBlocks(p.OrderReceiptRetentionBlocks); err != nil {
+		return err
+	}
+	if err := validateRetentionBlocks(p.LedgerEntryRetentionBlocks); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateOrderSubmissionHalted(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateBondProposalQuorum(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v.IsNil() {
+		return fmt.Errorf("bond proposal quorum cannot be nil")
+	}
+	return NewPercentage(v).Validate()
+}
+
+func validateBondCreationFee(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if !v.IsValid() {
+		return fmt.Errorf("invalid bond creation fee: %s", v)
+	}
+	return nil
+}
+
+func validateCreationFeeDestination(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v != CreationFeeDestinationBurn && v != CreationFeeDestinationCommunityPool {
+		return fmt.Errorf("invalid creation fee destination: %s", v)
+	}
+	return nil
+}
+
+func validateMaxNameLength(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == 0 || v > MaxBondNameLength {
+		return fmt.Errorf("max name length must be between 1 and %d: %d", MaxBondNameLength, v)
+	}
+	return nil
+}
+
+func validateMaxDescriptionLength(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == 0 || v > MaxBondDescriptionLength {
+		return fmt.Errorf("max description length must be between 1 and %d: %d", MaxBondDescriptionLength, v)
+	}
+	return nil
+}
+
+func validateBuySpendCap(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if !v.IsValid() {
+		return fmt.Errorf("invalid buy spend cap: %s", v)
+	}
+	return nil
+}
+
+func validateSpendCapWindowBlocks(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == 0 {
+		return fmt.Errorf("spend cap window blocks must be positive: %d", v)
+	}
+	return nil
+}
+
+func validateMaxSanityRateChangePercentage(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v.IsNil() {
+		return fmt.Errorf("max sanity rate change percentage cannot be nil")
+	}
+	return NewPercentage(v).Validate()
+}
+
+func validateSanityRateWindowBlocks(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == 0 {
+		return fmt.Errorf("sanity rate window blocks must be positive: %d", v)
+	}
+	return nil
+}
+
+func validateMaxPreMinePercentage(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v.IsNil() {
+		return fmt.Errorf("max pre-mine percentage cannot be nil")
+	}
+	return NewPercentage(v).Validate()
+}
+
+func validatePreMineVestingBlocks(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == 0 {
+		return fmt.Errorf("pre-mine vesting blocks must be positive: %d", v)
+	}
+	return nil
+}
+
+func validateAlertChangePercentage(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v.IsNil() {
+		return fmt.Errorf("alert change percentage cannot be nil")
+	} else if v.IsNegative() {
+		return fmt.Errorf("alert change percentage cannot be negative: %s", v)
+	}
+	return nil
+}
+
+func validateAlertWindowBlocks(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == 0 {
+		return fmt.Errorf("alert window blocks must be positive: %d", v)
+	}
+	return nil
+}
+
+func validateMaxValueLocked(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if !v.IsValid() {
+		return fmt.Errorf("invalid max value locked: %s", v)
+	}
+	return nil
+}
+
+func validateFeeDustThreshold(i interface{}) error {
+	v, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == (sdk.Int{}) {
+		return fmt.Errorf("fee dust threshold cannot be nil")
+	} else if v.IsNegative() {
+		return fmt.Errorf("fee dust threshold cannot be negative: %s", v)
+	}
+	return nil
+}
+
+func validateFeeDustDenom(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == "" {
+		return nil
+	}
+	return sdk.ValidateDenom(v)
+}
+
+func validateFeeDustSweepBlocks(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateNotificationDeposit(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if !v.IsValid() {
+		return fmt.Errorf("invalid notification deposit: %s", v)
+	}
+	return nil
+}
+
+func validateMaxBondNotificationRelays(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateStressPercentage(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v.IsNil() {
+		return fmt.Errorf("stress percentage cannot be nil")
+	} else if v.IsNegative() {
+		return fmt.Errorf("stress percentage cannot be negative: %s", v)
+	}
+	return nil
+}
+
+func validateStressConsecutiveBatches(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == 0 {
+		return fmt.Errorf("stress consecutive batches must be positive: %d", v)
+	}
+	return nil
+}
+
+func validateStressExtraBlocks(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateBatchBlocksBound(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	} else if v == 0 {
+		return fmt.Errorf("batch blocks bound must be positive: %d", v)
+	}
+	return nil
+}
+
+func validateRetentionBlocks(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// PendingBondEdit holds the edits of an EditBondProposal or MsgEditBond that
+// change the terms on which a bond's orders are performed (i.e. its fees and
+// fee address, whether it allows sells, its net sell caps, its demurrage rate,
+// and its min reserve). These are not applied when the edit is made, since
+// orders already in the bond's current batch would then be performed on terms
+// other than those that they were submitted under. They are instead applied
+// once the current batch is settled. Fields that are nil are not edited.
+//
+// Note: amino decodes a pointer to empty coins as nil, so the min reserve is
+// instead accompanied by whether it is edited, so that it can also be reset.
+type PendingBondEdit struct {
+	BondToken            string          `json:"bond_token" yaml:"bond_token"`
+	TxFeePercentage      *sdk.Dec        `json:"tx_fee_percentage,omitempty" yaml:"tx_fee_percentage,omitempty"`
+	ExitFeePercentage    *sdk.Dec        `json:"exit_fee_percentage,omitempty" yaml:"exit_fee_percentage,omitempty"`
+	AllowSells           *bool           `json:"allow_sells,omitempty" yaml:"allow_sells,omitempty"`
+	NetSellCap           *sdk.Coin       `json:"net_sell_cap,omitempty" yaml:"net_sell_cap,omitempty"`
+	NetSellCapPercentage *sdk.Dec        `json:"net_sell_cap_percentage,omitempty" yaml:"net_sell_cap_percentage,omitempty"`
+	DemurrageRate        *sdk.Dec        `json:"demurrage_rate,omitempty" yaml:"demurrage_rate,omitempty"`
+	MinReserve           sdk.Coins       `json:"min_reserve,omitempty" yaml:"min_reserve,omitempty"`
+	EditsMinReserve      bool            `json:"edits_min_reserve,omitempty" yaml:"edits_min_reserve,omitempty"`
+	MinReservePercentage *sdk.Dec        `json:"min_reserve_percentage,omitempty" yaml:"min_reserve_percentage,omitempty"`
+	FeeAddress           *sdk.AccAddress `json:"fee_address,omitempty" yaml:"fee_address,omitempty"`
+}
+
+func NewPendingBondEdit(bondToken string, txFeePercentage,
+	exitFeePercentage *sdk.Dec, allowSells *bool) PendingBondEdit {
+	return PendingBondEdit{
+		BondToken:         bondToken,
+		TxFeePercentage:   txFeePercentage,
+		ExitFeePercentage: exitFeePercentage,
+		AllowSells:        allowSells,
+	}
+}
+
+// NewPendingBondEditFromMsg returns the pending edit of the fields edited by
+// the MsgEditBond that change the terms on which the bond's orders are
+// performed.
+func NewPendingBondEditFromMsg(msg MsgEditBond) PendingBondEdit {
+	edit := PendingBondEdit{
+		BondToken:            msg.Token,
+		NetSellCap:           msg.NetSellCap,
+		NetSellCapPercentage: msg.NetSellCapPercentage,
+		DemurrageRate:        msg.DemurrageRate,
+		MinReservePercentage: msg.MinReservePercentage,
+		FeeAddress:           msg.FeeAddress,
+	}
+	if msg.MinReserve != nil {
+		edit.MinReserve = *msg.MinReserve
+		edit.EditsMinReserve = true
+	}
+	return edit
+}
+
+// IsEmpty returns true if the pending edit does not edit anything.
+func (e PendingBondEdit) IsEmpty() bool {
+	return e.TxFeePercentage == nil && e.ExitFeePercentage == nil && e.AllowSells == nil &&
+		e.NetSellCap == nil && e.NetSellCapPercentage == nil && e.DemurrageRate == nil &&
+		!e.EditsMinReserve && e.MinReservePercentage == nil && e.FeeAddress == nil
+}
+
+// Merge returns the pending edit with the fields set in the newer edit
+// replacing its own, so that the latest of several edits of a field wins.
+func (e PendingBondEdit) Merge(newer PendingBondEdit) PendingBondEdit {
+	if newer.TxFeePercentage != nil {
+		e.TxFeePercentage = newer.TxFeePercentage
+	}
+	if newer.ExitFeePercentage != nil {
+		e.ExitFeePercentage = newer.ExitFeePercentage
+	}
+	if newer.AllowSells != nil {
+		e.AllowSells = newer.AllowSells
+	}
+	if newer.NetSellCap != nil {
+		e.NetSellCap = newer.NetSellCap
+	}
+	if newer.NetSellCapPercentage != nil {
+		e.NetSellCapPercentage = newer.NetSellCapPercentage
+	}
+	if newer.DemurrageRate != nil {
+		e.DemurrageRate = newer.DemurrageRate
+	}
+	if newer.EditsMinReserve {
+		e.MinReserve = newer.MinReserve
+		e.EditsMinReserve = true
+	}
+	if newer.MinReservePercentage != nil {
+		e.MinReservePercentage = newer.MinReservePercentage
+	}
+	if newer.FeeAddress != nil {
+		e.FeeAddress = newer.FeeAddress
+	}
+	return e
+}
+
+// ApplyTo returns the bond with the pending edit applied to it at the
+// specified height, or an error if the bond's fees would then add up to 100%
+// or more.
+func (e PendingBondEdit) ApplyTo(bond Bond, height int64) (Bond, error) {
+	if e.TxFeePercentage != nil {
+		bond.TxFeePercentage = *e.TxFeePercentage
+	}
+	if e.ExitFeePercentage != nil {
+		bond.ExitFeePercentage = *e.ExitFeePercentage
+	}
+	if e.AllowSells != nil {
+		bond.AllowSells = *e.AllowSells
+	}
+	if e.NetSellCap != nil {
+		bond.NetSellCap = *e.NetSellCap
+	}
+	if e.NetSellCapPercentage != nil {
+		bond.NetSellCapPercentage = *e.NetSellCapPercentage
+	}
+	if e.DemurrageRate != nil {
+		// Bring the index up to date at the old rate before changing the rate
+		bond.DemurrageIndex = bond.GetDemurrageIndexAt(height)
+		bond.DemurrageHeight = height
+		bond.DemurrageRate = *e.DemurrageRate
+	}
+	if e.EditsMinReserve {
+		bond.MinReserve = e.MinReserve
+	}
+	if e.MinReservePercentage != nil {
+		bond.MinReservePercentage = *e.MinReservePercentage
+	}
+	if e.FeeAddress != nil {
+		bond.FeeAddress = *e.FeeAddress
+	}
+
+	totalFeePercentage := bond.TxFeePercentage.Add(bond.ExitFeePercentage)
+	if totalFeePercentage.GTE(MaxPercentage) {
+		return Bond{}, sdkerrors.Wrap(ErrFeesCannotBeOrExceed100Percent, totalFeePercentage.String())
+	}
+	return bond, nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPendingBondEditMergeKeepsLatestEdits(t *testing.T) {
+	txFee1 := sdk.NewDec(1)
+	txFee2 := sdk.NewDec(2)
+	exitFee := sdk.NewDec(3)
+	allowSells := false
+
+	older := NewPendingBondEdit("abc", &txFee1, &exitFee, nil)
+	newer := NewPendingBondEdit("abc", &txFee2, nil, &allowSells)
+	merged := older.Merge(newer)
+
+	require.Equal(t, txFee2, *merged.TxFeePercentage)
+	require.Equal(t, exitFee, *merged.ExitFeePercentage)
+	require.False(t, *merged.AllowSells)
+	require.True(t, NewPendingBondEdit("abc", nil, nil, nil).IsEmpty())
+	require.False(t, merged.IsEmpty())
+}
+
+func TestPendingBondEditApplyTo(t *testing.T) {
+	bond := getValidBond()
+	bond.TxFeePercentage = sdk.NewDec(1)
+	bond.ExitFeePercentage = sdk.NewDec(1)
+	bond.AllowSells = true
+
+	txFee := sdk.NewDec(5)
+	allowSells := false
+	edited, err := NewPendingBondEdit(bond.Token, &txFee, nil, &allowSells).ApplyTo(bond, 0)
+	require.Nil(t, err)
+	require.Equal(t, txFee, edited.TxFeePercentage)
+	require.Equal(t, sdk.NewDec(1), edited.ExitFeePercentage)
+	require.False(t, edited.AllowSells)
+
+	// Fees cannot add up to 100% or more
+	exitFee := sdk.NewDec(99)
+	_, err = NewPendingBondEdit(bond.Token, nil, &exitFee, nil).ApplyTo(bond, 0)
+	require.Error(t, err)
+	require.True(t, ErrFeesCannotBeOrExceed100Percent.Is(err))
+}
+
+func TestPendingBondEditFromMsgCanResetMinReserve(t *testing.T) {
+	bond := getValidBond()
+	bond.MinReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+
+	// Min reserve reset to empty is kept when the edit is stored
+	msg := NewMsgEditBond(bond.Token, initCreator, initSigners)
+	msg.MinReserve = &sdk.Coins{}
+	var edit PendingBondEdit
+	ModuleCdc.MustUnmarshalBinaryBare(
+		ModuleCdc.MustMarshalBinaryBare(NewPendingBondEditFromMsg(msg)), &edit)
+	require.False(t, edit.IsEmpty())
+
+	edited, err := edit.ApplyTo(bond, 0)
+	require.Nil(t, err)
+	require.True(t, edited.MinReserve.Empty())
+}
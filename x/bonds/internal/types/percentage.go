@@ -0,0 +1,64 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxPercentageDecimalPlaces is the max number of decimal places allowed in a
+// percentage (e.g. 0.000001 is the smallest non-zero percentage allowed)
+const MaxPercentageDecimalPlaces = 6
+
+var (
+	// MaxPercentage is the max value of a percentage (inclusive)
+	MaxPercentage = sdk.NewDec(100)
+
+	smallestPercentage = sdk.NewDecWithPrec(1, MaxPercentageDecimalPlaces)
+)
+
+// Percentage is a percentage value (e.g. 12.5 for 12.5%) which, to be valid,
+// has to be between 0 and 100 (inclusive) and have at most
+// MaxPercentageDecimalPlaces decimal places. Percentages are stored in bonds
+// and messages as plain sdk.Dec values, so that this type is only used to
+// validate them and to work with them.
+type Percentage struct {
+	sdk.Dec
+}
+
+func NewPercentage(dec sdk.Dec) Percentage {
+	return Percentage{Dec: dec}
+}
+
+// ParsePercentage parses a percentage from a decimal string and validates it.
+func ParsePercentage(str string) (Percentage, error) {
+	dec, err := sdk.NewDecFromStr(str)
+	if err != nil {
+		return Percentage{}, sdkerrors.Wrap(ErrArgumentMissingOrNonFloat, str)
+	}
+
+	p := NewPercentage(dec)
+	if err := p.Validate(); err != nil {
+		return Percentage{}, err
+	}
+	return p, nil
+}
+
+// Validate checks that the percentage is between 0 and 100 (inclusive) and
+// that it does not have more than MaxPercentageDecimalPlaces decimal places.
+func (p Percentage) Validate() error {
+	if p.Dec.IsNil() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "percentage")
+	} else if p.IsNegative() || p.GT(MaxPercentage) {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"percentage %s must be between 0 and %s", p, MaxPercentage)
+	} else if !p.Quo(smallestPercentage).IsInteger() {
+		return sdkerrors.Wrapf(ErrTooManyDecimalPlaces,
+			"percentage %s has more than %d decimal places", p, MaxPercentageDecimalPlaces)
+	}
+	return nil
+}
+
+// AsFraction returns the percentage as a fraction (e.g. 0.125 for 12.5%).
+func (p Percentage) AsFraction() sdk.Dec {
+	return p.QuoInt64(100)
+}
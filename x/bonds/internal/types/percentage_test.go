@@ -0,0 +1,61 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPercentageValidate(t *testing.T) {
+	testCases := []struct {
+		percentage string
+		valid      bool
+	}{
+		{"0", true},
+		{"12.5", true},
+		{"100", true},
+		{"0.000001", true},
+		{"99.999999", true},
+		{"-0.000001", false},
+		{"100.000001", false},
+		{"0.0000001", false},
+		{"12.3456789", false},
+	}
+	for _, tc := range testCases {
+		err := NewPercentage(sdk.MustNewDecFromStr(tc.percentage)).Validate()
+		if tc.valid {
+			require.Nil(t, err, tc.percentage)
+		} else {
+			require.NotNil(t, err, tc.percentage)
+		}
+	}
+}
+
+func TestPercentageValidateNilGivesError(t *testing.T) {
+	require.NotNil(t, NewPercentage(sdk.Dec{}).Validate())
+}
+
+func TestParsePercentage(t *testing.T) {
+	p, err := ParsePercentage("12.5")
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("12.5"), p.Dec)
+
+	_, err = ParsePercentage("12.5t")
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentMissingOrNonFloat.Is(err))
+
+	_, err = ParsePercentage("101")
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentMustBeBetween.Is(err))
+
+	_, err = ParsePercentage("0.0000001")
+	require.NotNil(t, err)
+	require.True(t, ErrTooManyDecimalPlaces.Is(err))
+}
+
+func TestPercentageAsFraction(t *testing.T) {
+	require.Equal(t, sdk.ZeroDec(), NewPercentage(sdk.ZeroDec()).AsFraction())
+	require.Equal(t, sdk.MustNewDecFromStr("0.125"),
+		NewPercentage(sdk.MustNewDecFromStr("12.5")).AsFraction())
+	require.Equal(t, sdk.OneDec(), NewPercentage(sdk.NewDec(100)).AsFraction())
+}
@@ -0,0 +1,170 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The piecewise function stitches together multiple curve segments, each with
+// its own function type and parameters, e.g. a flat price (a polynomial with
+// only c0) until a supply S1 and a power function from then on. A segment
+// applies from its supply threshold up to the next segment's threshold, and
+// the first segment's threshold is zero. Each segment's function is evaluated
+// at the bond's supply itself (not at the supply past the threshold), so the
+// price at supply x is the price of the segment that x falls in, and the
+// reserve at supply x is the sum of the integrals of each segment's price
+// between its threshold and the next one (or x, for the segment that x falls
+// in). The piecewise function itself has no function parameters.
+
+// MaxCurveSegments is the max number of segments of a piecewise function
+const MaxCurveSegments = 10
+
+// SegmentFunctionTypes are the function types that curve segments can have,
+// i.e. the function types whose prices only depend on the supply
+var SegmentFunctionTypes = []string{
+	PowerFunction, SigmoidFunction, ExponentialFunction,
+	LogarithmicFunction, PolynomialFunction, BancorFunction,
+}
+
+// CurveSegment is a segment of a piecewise function, which applies from its
+// supply threshold up to the next segment's supply threshold.
+type CurveSegment struct {
+	SupplyThreshold    sdk.Int        `json:"supply_threshold" yaml:"supply_threshold"`
+	FunctionType       string         `json:"function_type" yaml:"function_type"`
+	FunctionParameters FunctionParams `json:"function_parameters" yaml:"function_parameters"`
+}
+
+func NewCurveSegment(supplyThreshold sdk.Int, functionType string,
+	functionParameters FunctionParams) CurveSegment {
+	return CurveSegment{
+		SupplyThreshold:    supplyThreshold,
+		FunctionType:       functionType,
+		FunctionParameters: functionParameters,
+	}
+}
+
+func isSegmentFunctionType(functionType string) bool {
+	for _, ft := range SegmentFunctionTypes {
+		if ft == functionType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCurveSegments checks that the curve segments of a bond with the
+// specified function type and max supply are valid. Only piecewise functions
+// have curve segments, which must start at a supply of zero, have increasing
+// supply thresholds below the max supply, and be evaluable up to the max
+// supply with their function types and parameters.
+func ValidateCurveSegments(segments []CurveSegment, functionType string, maxSupply sdk.Int) error {
+	if functionType != PiecewiseFunction {
+		if len(segments) != 0 {
+			return sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, functionType)
+		}
+		return nil
+	}
+
+	if len(segments) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "CurveSegments")
+	} else if len(segments) > MaxCurveSegments {
+		return sdkerrors.Wrapf(ErrInvalidCurveSegment,
+			"cannot have more than %d segments", MaxCurveSegments)
+	}
+
+	for i, s := range segments {
+		if s.SupplyThreshold == (sdk.Int{}) {
+			return sdkerrors.Wrapf(ErrInvalidCurveSegment, "segment %d has no supply threshold", i)
+		} else if i == 0 && !s.SupplyThreshold.IsZero() {
+			return sdkerrors.Wrap(ErrInvalidCurveSegment, "first segment must start at a supply of zero")
+		} else if i > 0 && s.SupplyThreshold.LTE(segments[i-1].SupplyThreshold) {
+			return sdkerrors.Wrapf(ErrInvalidCurveSegment,
+				"segment %d supply threshold must be greater than the previous one", i)
+		} else if s.SupplyThreshold.GTE(maxSupply) {
+			return sdkerrors.Wrapf(ErrInvalidCurveSegment,
+				"segment %d supply threshold must be less than the max supply", i)
+		}
+
+		if !isSegmentFunctionType(s.FunctionType) {
+			return sdkerrors.Wrapf(ErrInvalidCurveSegment,
+				"segment %d cannot have function type %s", i, s.FunctionType)
+		} else if err := s.FunctionParameters.Validate(s.FunctionType); err != nil {
+			return sdkerrors.Wrapf(err, "segment %d", i)
+		}
+
+		// Check that the segment's function can be evaluated up to the max
+		// supply (which is looser than necessary for all but the last one)
+		var err error
+		switch s.FunctionType {
+		case ExponentialFunction:
+			err = CheckExponentialMaxSupply(s.FunctionParameters.AsMap(), maxSupply)
+		case PolynomialFunction:
+			err = CheckPolynomialMaxSupply(s.FunctionParameters.AsMap(), maxSupply)
+		case BancorFunction:
+			err = CheckBancorMaxSupply(s.FunctionParameters.AsMap(), maxSupply)
+		}
+		if err != nil {
+			return sdkerrors.Wrapf(err, "segment %d", i)
+		}
+	}
+	return nil
+}
+
+// curveSegmentIndex returns the index of the curve segment that the supply
+// falls in, i.e. the last segment whose supply threshold does not exceed it
+func (bond Bond) curveSegmentIndex(supply sdk.Int) int {
+	index := 0
+	for i, s := range bond.CurveSegments {
+		if s.SupplyThreshold.GT(supply) {
+			break
+		}
+		index = i
+	}
+	return index
+}
+
+// curveSegmentBond returns a copy of the bond with the function type and
+// parameters of the curve segment at the index, so that the segment can be
+// evaluated like any other bond. The copy's parameters are cached separately
+// from the bond's, under the bond token suffixed by the segment index.
+func (bond Bond) curveSegmentBond(index int) Bond {
+	segment := bond.CurveSegments[index]
+	bond.Token = fmt.Sprintf("%s/%d", bond.Token, index)
+	bond.FunctionType = segment.FunctionType
+	bond.FunctionParameters = segment.FunctionParameters
+	bond.CurveSegments = nil
+	return bond
+}
+
+// piecewisePricesAtSupply returns the prices of the curve segment that the
+// supply falls in
+func (bond Bond) piecewisePricesAtSupply(supply sdk.Int) (sdk.DecCoins, error) {
+	if len(bond.CurveSegments) == 0 {
+		panic(fmt.Sprintf("no curve segments for bond %s", bond.Token))
+	}
+	return bond.curveSegmentBond(bond.curveSegmentIndex(supply)).getPricesAtSupplyV1(supply)
+}
+
+// piecewiseReserveAtSupply returns the sum of the integrals of the prices of
+// the curve segments up to the supply
+func (bond Bond) piecewiseReserveAtSupply(supply sdk.Int) sdk.Dec {
+	if len(bond.CurveSegments) == 0 {
+		panic(fmt.Sprintf("no curve segments for bond %s", bond.Token))
+	}
+
+	result := sdk.ZeroDec()
+	last := bond.curveSegmentIndex(supply)
+	for i := 0; i <= last; i++ {
+		segmentBond := bond.curveSegmentBond(i)
+		from := bond.CurveSegments[i].SupplyThreshold
+		to := supply
+		if i < last {
+			to = bond.CurveSegments[i+1].SupplyThreshold
+		}
+		result = result.Add(segmentBond.reserveAtSupplyV1(to).Sub(
+			segmentBond.reserveAtSupplyV1(from)))
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// piecewiseSegments returns the segments of a piecewise function with a flat
+// price of 10 until a supply of 100, and a price equal to the supply after
+func piecewiseSegments() []CurveSegment {
+	return []CurveSegment{
+		NewCurveSegment(sdk.ZeroInt(), PolynomialFunction, FunctionParams{
+			NewFunctionParam("c0", sdk.NewDec(10))}),
+		NewCurveSegment(sdk.NewInt(100), PowerFunction, FunctionParams{
+			NewFunctionParam("m", sdk.OneDec()),
+			NewFunctionParam("n", sdk.OneDec()),
+			NewFunctionParam("c", sdk.ZeroDec())}),
+	}
+}
+
+func getValidPiecewiseFunctionBond() Bond {
+	bond := getValidPowerFunctionBond()
+	bond.FunctionType = PiecewiseFunction
+	bond.FunctionParameters = nil
+	bond.CurveSegments = piecewiseSegments()
+	return bond
+}
+
+func TestValidateCurveSegments(t *testing.T) {
+	maxSupply := sdk.NewInt(1000)
+	flat := FunctionParams{NewFunctionParam("c0", sdk.OneDec())}
+
+	testCases := []struct {
+		name         string
+		segments     []CurveSegment
+		functionType string
+		expectError  bool
+	}{
+		{"valid", piecewiseSegments(), PiecewiseFunction, false},
+		{"single segment", piecewiseSegments()[:1], PiecewiseFunction, false},
+		{"no segments", nil, PiecewiseFunction, true},
+		{"segments for other function type", piecewiseSegments(), PowerFunction, true},
+		{"no segments for other function type", nil, PowerFunction, false},
+		{"first segment not at zero", []CurveSegment{
+			NewCurveSegment(sdk.OneInt(), PolynomialFunction, flat)}, PiecewiseFunction, true},
+		{"thresholds not increasing", []CurveSegment{
+			NewCurveSegment(sdk.ZeroInt(), PolynomialFunction, flat),
+			NewCurveSegment(sdk.ZeroInt(), PolynomialFunction, flat)}, PiecewiseFunction, true},
+		{"threshold at max supply", []CurveSegment{
+			NewCurveSegment(sdk.ZeroInt(), PolynomialFunction, flat),
+			NewCurveSegment(maxSupply, PolynomialFunction, flat)}, PiecewiseFunction, true},
+		{"nested piecewise function", []CurveSegment{
+			NewCurveSegment(sdk.ZeroInt(), PiecewiseFunction, nil)}, PiecewiseFunction, true},
+		{"swapper segment", []CurveSegment{
+			NewCurveSegment(sdk.ZeroInt(), SwapperFunction, nil)}, PiecewiseFunction, true},
+		{"invalid segment params", []CurveSegment{
+			NewCurveSegment(sdk.ZeroInt(), PolynomialFunction, nil)}, PiecewiseFunction, true},
+	}
+	for _, tc := range testCases {
+		err := ValidateCurveSegments(tc.segments, tc.functionType, maxSupply)
+		if tc.expectError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestPiecewiseFunctionPricesAndReserve(t *testing.T) {
+	bond := getValidPiecewiseFunctionBond()
+
+	testCases := []struct {
+		supply          int64
+		expectedPrice   int64
+		expectedReserve int64
+	}{
+		{0, 10, 0},
+		{50, 10, 500},     // 50*10
+		{99, 10, 990},     // 99*10
+		{100, 100, 1000},  // 100*10
+		{150, 150, 7250},  // 100*10 + (150^2 - 100^2)/2
+		{200, 200, 16000}, // 100*10 + (200^2 - 100^2)/2
+	}
+	for _, tc := range testCases {
+		supply := sdk.NewInt(tc.supply)
+
+		prices, err := bond.GetPricesAtSupply(supply)
+		require.NoError(t, err)
+		require.Equal(t, bond.GetNewReserveDecCoins(sdk.NewDec(tc.expectedPrice)), prices)
+
+		require.Equal(t, sdk.NewDec(tc.expectedReserve), bond.ReserveAtSupply(supply))
+	}
+}
+
+func TestPiecewiseFunctionMatchesSingleSegment(t *testing.T) {
+	bond := getValidPowerFunctionBond()
+	piecewise := getValidPiecewiseFunctionBond()
+	piecewise.CurveSegments = []CurveSegment{NewCurveSegment(
+		sdk.ZeroInt(), bond.FunctionType, bond.FunctionParameters)}
+
+	for _, s := range []int64{0, 1, 10, 1000} {
+		supply := sdk.NewInt(s)
+
+		expected, err := bond.GetPricesAtSupply(supply)
+		require.NoError(t, err)
+		actual, err := piecewise.GetPricesAtSupply(supply)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		require.Equal(t, bond.ReserveAtSupply(supply), piecewise.ReserveAtSupply(supply))
+	}
+}
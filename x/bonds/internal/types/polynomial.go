@@ -0,0 +1,115 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The polynomial function's price c0 + c1*x + ... + cN*x^N and reserve
+// c0*x + c1*x^2/2 + ... + cN*x^(N+1)/(N+1) are computed term by term using
+// sdk.Dec arithmetic only. Its parameters are the coefficients c0 to cN, so
+// unlike other function types, the number of parameters is not fixed.
+
+// maxPolynomialBitLen is the max bit length of the integer part of the sum of
+// the terms ci*x^(i+1) at a polynomial bond's max supply, which bounds both
+// its price and reserve so that they fit in an sdk.Int.
+const maxPolynomialBitLen = 255
+
+// PolynomialCoefficient returns the name of the coefficient of x^i of the
+// polynomial function, i.e. "ci"
+func PolynomialCoefficient(i int) string {
+	return fmt.Sprintf("c%d", i)
+}
+
+// PolynomialCoefficients returns the names of the coefficients c0 to cN of a
+// polynomial function with the number of parameters
+func PolynomialCoefficients(noOfParams int) []string {
+	names := make([]string, noOfParams)
+	for i := range names {
+		names[i] = PolynomialCoefficient(i)
+	}
+	return names
+}
+
+// polynomialCoefficientValues returns the values of the coefficients c0 to cN
+// in the parameters, in order
+func polynomialCoefficientValues(paramsMap map[string]sdk.Dec) (coefficients []sdk.Dec) {
+	for i := 0; ; i++ {
+		c, ok := paramsMap[PolynomialCoefficient(i)]
+		if !ok {
+			return coefficients
+		}
+		coefficients = append(coefficients, c)
+	}
+}
+
+// polynomialFunctionPrice returns the polynomial function's price at the
+// supply x, i.e. the sum of the terms ci*x^i
+func polynomialFunctionPrice(paramsMap map[string]sdk.Dec, x sdk.Dec) sdk.Dec {
+	result, xi := sdk.ZeroDec(), sdk.OneDec()
+	for i, c := range polynomialCoefficientValues(paramsMap) {
+		if i > 0 {
+			xi = xi.Mul(x)
+		}
+		result = result.Add(c.Mul(xi))
+	}
+	return result
+}
+
+// PolynomialCurveIntegral returns the polynomial function's reserve at the
+// supply x, i.e. the integral of the price from 0 to x, computed as the sum of
+// the antiderivatives ci*x^(i+1)/(i+1) of each of the terms
+func PolynomialCurveIntegral(paramsMap map[string]sdk.Dec, x sdk.Dec) sdk.Dec {
+	result, xi1 := sdk.ZeroDec(), x
+	for i, c := range polynomialCoefficientValues(paramsMap) {
+		if i > 0 {
+			xi1 = xi1.Mul(x)
+		}
+		result = result.Add(c.Mul(xi1).QuoInt64(int64(i + 1)))
+	}
+	return result
+}
+
+// CheckPolynomialMaxSupply returns an error if the polynomial function with
+// the parameters cannot be evaluated up to the max supply, i.e. if x^(N+1) or
+// the sum of the terms ci*x^(i+1) at the max supply does not fit in an sdk.Int.
+// The terms are summed exactly, using the coefficients' underlying integers.
+func CheckPolynomialMaxSupply(paramsMap map[string]sdk.Dec, maxSupply sdk.Int) error {
+	x := maxSupply.BigInt()
+	xi1 := new(big.Int).Set(x)
+	sum := new(big.Int)
+	for i, c := range polynomialCoefficientValues(paramsMap) {
+		if i > 0 {
+			xi1.Mul(xi1, x)
+		}
+		if xi1.BitLen() > maxPolynomialBitLen {
+			return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+				"max supply %s to the power of %d is too large", maxSupply, i+1)
+		}
+		sum.Add(sum, new(big.Int).Mul(c.Int, xi1))
+	}
+
+	// The coefficients' integers are scaled up by 10^sdk.Precision
+	precisionMultiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(sdk.Precision), nil)
+	if sum.Quo(sum, precisionMultiplier).BitLen() > maxPolynomialBitLen {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"FunctionParams are too large to be evaluated up to max supply %s", maxSupply)
+	}
+	return nil
+}
+
+func polynomialParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Polynomial exception 1: cN != 0, so that the degree N of the polynomial
+	// is given by the number of parameters and the price is not always zero
+	p := PolynomialCoefficient(len(paramsMap) - 1)
+	val, ok := paramsMap[p]
+	if !ok {
+		panic("did not find parameter " + p + " for polynomial function")
+	} else if !val.IsPositive() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:"+p)
+	}
+	return nil
+}
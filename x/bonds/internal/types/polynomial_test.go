@@ -0,0 +1,109 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// polynomialParamsOfOnes returns the parameters of a polynomial function with
+// the number of coefficients, all of which are one
+func polynomialParamsOfOnes(noOfParams int) (fps FunctionParams) {
+	for _, p := range PolynomialCoefficients(noOfParams) {
+		fps = append(fps, NewFunctionParam(p, sdk.OneDec()))
+	}
+	return fps
+}
+
+func TestPolynomialCoefficients(t *testing.T) {
+	require.Equal(t, []string{"c0"}, PolynomialCoefficients(1))
+	require.Equal(t, []string{"c0", "c1", "c2", "c3"}, PolynomialCoefficients(4))
+}
+
+func TestPolynomialFunctionParamsValidate(t *testing.T) {
+	one := sdk.OneDec()
+	testCases := []struct {
+		name        string
+		fps         FunctionParams
+		expectError bool
+	}{
+		{"constant", FunctionParams{NewFunctionParam("c0", one)}, false},
+		{"quadratic", functionParametersPolynomial(), false},
+		{"coefficients in any order", FunctionParams{
+			NewFunctionParam("c1", one), NewFunctionParam("c0", one)}, false},
+		{"no coefficients", nil, true},
+		{"missing coefficient", FunctionParams{
+			NewFunctionParam("c0", one), NewFunctionParam("c2", one)}, true},
+		{"unexpected parameter", FunctionParams{
+			NewFunctionParam("c0", one), NewFunctionParam("m", one)}, true},
+		{"zero highest coefficient", FunctionParams{
+			NewFunctionParam("c0", one), NewFunctionParam("c1", sdk.ZeroDec())}, true},
+		{"negative coefficient", FunctionParams{
+			NewFunctionParam("c0", one.Neg()), NewFunctionParam("c1", one)}, true},
+		{"too many coefficients", polynomialParamsOfOnes(
+			MaxFunctionParams + 1), true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.fps.Validate(PolynomialFunction)
+		if tc.expectError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.Nil(t, err, tc.name)
+		}
+	}
+}
+
+func TestPolynomialFunctionMatchesPowerFunction(t *testing.T) {
+	// 12*x^2 + 100 as a polynomial and as a power function
+	polynomialBond := getValidBond()
+	polynomialBond.FunctionType = PolynomialFunction
+	polynomialBond.FunctionParameters = FunctionParams{
+		NewFunctionParam("c0", sdk.NewDec(100)),
+		NewFunctionParam("c1", sdk.ZeroDec()),
+		NewFunctionParam("c2", sdk.NewDec(12))}
+	powerBond := getValidBond()
+	powerBond.FunctionType = PowerFunction
+	powerBond.FunctionParameters = functionParametersPower()
+
+	for _, supply := range []int64{0, 1, 7, 1000, 9999} {
+		x := sdk.NewInt(supply)
+		polynomialPrices, err := polynomialBond.GetPricesAtSupply(x)
+		require.Nil(t, err)
+		powerPrices, err := powerBond.GetPricesAtSupply(x)
+		require.Nil(t, err)
+		require.Equal(t, powerPrices.String(), polynomialPrices.String())
+		require.Equal(t, powerBond.ReserveAtSupply(x).String(),
+			polynomialBond.ReserveAtSupply(x).String())
+	}
+}
+
+func TestCheckPolynomialMaxSupply(t *testing.T) {
+	// 10^38 to the power of 7 (i.e. 10^266) does not fit in 255 bits
+	maxSupply, ok := sdk.NewIntFromString("100000000000000000000000000000000000000")
+	require.True(t, ok)
+	fps := FunctionParams{NewFunctionParam("c0", sdk.OneDec())}
+	require.Nil(t, CheckPolynomialMaxSupply(fps.AsMap(), maxSupply))
+	fps = polynomialParamsOfOnes(7)
+	require.Error(t, CheckPolynomialMaxSupply(fps.AsMap(), maxSupply))
+
+	// The reserve at a max supply of 10^20 with c1 = 10^40 is 10^80, which
+	// does not fit in 255 bits, but does with c1 = 10^30
+	maxSupply = sdk.NewIntWithDecimal(1, 20)
+	fps = FunctionParams{
+		NewFunctionParam("c0", sdk.ZeroDec()),
+		NewFunctionParam("c1", sdk.NewDecFromInt(sdk.NewIntWithDecimal(1, 40)))}
+	require.Error(t, CheckPolynomialMaxSupply(fps.AsMap(), maxSupply))
+	fps[1] = NewFunctionParam("c1", sdk.NewDecFromInt(sdk.NewIntWithDecimal(1, 30)))
+	require.Nil(t, CheckPolynomialMaxSupply(fps.AsMap(), maxSupply))
+
+	// The price and reserve can be evaluated at the max supply
+	bond := getValidBond()
+	bond.FunctionType = PolynomialFunction
+	bond.FunctionParameters = fps
+	require.NotPanics(t, func() {
+		_, err := bond.GetPricesAtSupply(maxSupply)
+		require.Nil(t, err)
+		bond.ReserveAtSupply(maxSupply)
+	})
+}
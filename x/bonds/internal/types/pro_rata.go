@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ProRataAmount returns the share of the total that is pro-rata to the weight
+// out of the total weight, i.e. total*weight/totalWeight rounded down. The
+// share is calculated using integer arithmetic only, so it is exact before
+// rounding, regardless of the size of the amounts.
+func ProRataAmount(total, weight, totalWeight sdk.Int) sdk.Int {
+	if !totalWeight.IsPositive() {
+		panic(fmt.Sprintf("non-positive total weight %s", totalWeight))
+	}
+	return total.Mul(weight).Quo(totalWeight)
+}
+
+// ProRataCoins returns the share of each of the coins that is pro-rata to the
+// weight out of the total weight (see ProRataAmount), omitting zero shares.
+func ProRataCoins(total sdk.Coins, weight, totalWeight sdk.Int) (shares sdk.Coins) {
+	for _, c := range total {
+		share := ProRataAmount(c.Amount, weight, totalWeight)
+		if share.IsPositive() {
+			shares = append(shares, sdk.NewCoin(c.Denom, share))
+		}
+	}
+	return shares
+}
+
+// DistributeProRata distributes the total among the weights pro-rata, such
+// that the returned shares add up to exactly the total. Each share is first
+// rounded down (see ProRataAmount, with the sum of the weights as the total
+// weight). The remainder is then assigned one unit at a time to the entries
+// with a positive weight, in order of their index, starting from the first,
+// which means that for orders in order of arrival, the earliest orders are
+// assigned the remainder. Since the remainder is less than the number of
+// entries with a positive weight, each entry is assigned at most one unit.
+//
+// Entries with a zero weight are always given a zero share, and if the total
+// does not exceed the sum of the weights, no share exceeds its weight. The
+// weights cannot be negative and their sum must be positive.
+func DistributeProRata(total sdk.Int, weights []sdk.Int) []sdk.Int {
+	totalWeight := sdk.ZeroInt()
+	for _, w := range weights {
+		if w.IsNegative() {
+			panic(fmt.Sprintf("negative weight %s", w))
+		}
+		totalWeight = totalWeight.Add(w)
+	}
+
+	shares := make([]sdk.Int, len(weights))
+	remainder := total
+	for i, w := range weights {
+		shares[i] = ProRataAmount(total, w, totalWeight)
+		remainder = remainder.Sub(shares[i])
+	}
+
+	for i, w := range weights {
+		if !remainder.IsPositive() {
+			break
+		} else if w.IsPositive() {
+			shares[i] = shares[i].AddRaw(1)
+			remainder = remainder.SubRaw(1)
+		}
+	}
+
+	return shares
+}
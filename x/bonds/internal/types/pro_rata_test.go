@@ -0,0 +1,130 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func ints(values ...int64) (result []sdk.Int) {
+	for _, v := range values {
+		result = append(result, sdk.NewInt(v))
+	}
+	return result
+}
+
+func TestProRataAmount(t *testing.T) {
+	testCases := []struct {
+		total       int64
+		weight      int64
+		totalWeight int64
+		expected    int64
+	}{
+		{100, 1, 4, 25},    // exact share
+		{100, 1, 3, 33},    // rounded down
+		{100, 2, 3, 66},    // rounded down, not up
+		{100, 0, 3, 0},     // zero weight
+		{100, 3, 3, 100},   // full weight
+		{0, 1, 3, 0},       // zero total
+		{7, 10, 10, 7},     // weight equal to total weight
+		{1, 1, 1000000, 0}, // tiny share rounded down to zero
+	}
+
+	for _, tc := range testCases {
+		actual := ProRataAmount(sdk.NewInt(tc.total), sdk.NewInt(tc.weight), sdk.NewInt(tc.totalWeight))
+		require.Equal(t, tc.expected, actual.Int64())
+	}
+}
+
+func TestProRataAmountIsExactForLargeAmounts(t *testing.T) {
+	// 10^30 * (10^30 - 1) / 10^30 cannot be calculated exactly using an sdk.Dec
+	// share, which would be rounded to 1 at 18 decimal places
+	e30, ok := sdk.NewIntFromString("1000000000000000000000000000000")
+	require.True(t, ok)
+	weight := e30.SubRaw(1)
+
+	require.True(t, weight.Equal(ProRataAmount(e30, weight, e30)))
+}
+
+func TestProRataAmountNonPositiveTotalWeightPanics(t *testing.T) {
+	require.Panics(t, func() { ProRataAmount(sdk.NewInt(1), sdk.ZeroInt(), sdk.ZeroInt()) })
+}
+
+func TestProRataCoins(t *testing.T) {
+	total := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100), sdk.NewInt64Coin(reserveToken2, 1))
+
+	// Zero shares are omitted
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 33)),
+		ProRataCoins(total, sdk.NewInt(1), sdk.NewInt(3)))
+	require.Equal(t, total, ProRataCoins(total, sdk.NewInt(3), sdk.NewInt(3)))
+	require.True(t, ProRataCoins(total, sdk.ZeroInt(), sdk.NewInt(3)).Empty())
+}
+
+func TestDistributeProRata(t *testing.T) {
+	testCases := []struct {
+		name     string
+		total    int64
+		weights  []sdk.Int
+		expected []sdk.Int
+	}{
+		{"exact shares", 50, ints(10, 20, 70), ints(5, 10, 35)},
+		{"remainder to first", 10, ints(1, 1, 1), ints(4, 3, 3)},
+		{"remainder to first two", 11, ints(1, 1, 1), ints(4, 4, 3)},
+		{"remainder skips zero weight", 10, ints(0, 1, 1, 1), ints(0, 4, 3, 3)},
+		{"remainder in index order, not by size", 5, ints(1, 3, 3), ints(1, 2, 2)},
+		{"zero total", 0, ints(1, 2, 3), ints(0, 0, 0)},
+		{"total equal to total weight", 6, ints(1, 2, 3), ints(1, 2, 3)},
+		{"single weight", 7, ints(3), ints(7)},
+		{"total greater than total weight", 13, ints(1, 2, 3), ints(3, 4, 6)},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, fmt.Sprint(tc.expected),
+			fmt.Sprint(DistributeProRata(sdk.NewInt(tc.total), tc.weights)), tc.name)
+	}
+}
+
+func TestDistributeProRataInvariants(t *testing.T) {
+	// Exhaustively check every total up to the total weight for every
+	// combination of three weights from 0 to 6 (with a positive total weight)
+	for a := int64(0); a <= 6; a++ {
+		for b := int64(0); b <= 6; b++ {
+			for c := int64(0); c <= 6; c++ {
+				weights := ints(a, b, c)
+				totalWeight := a + b + c
+				if totalWeight == 0 {
+					continue
+				}
+
+				for total := int64(0); total <= totalWeight; total++ {
+					shares := DistributeProRata(sdk.NewInt(total), weights)
+
+					sum := sdk.ZeroInt()
+					for i, share := range shares {
+						sum = sum.Add(share)
+
+						// No share exceeds its weight or is more than one
+						// unit above its rounded down pro-rata share
+						floor := ProRataAmount(sdk.NewInt(total), weights[i], sdk.NewInt(totalWeight))
+						require.True(t, share.LTE(weights[i]))
+						require.True(t, share.GTE(floor))
+						require.True(t, share.LTE(floor.AddRaw(1)))
+						if weights[i].IsZero() {
+							require.True(t, share.IsZero())
+						}
+					}
+					require.Equal(t, total, sum.Int64())
+				}
+			}
+		}
+	}
+}
+
+func TestDistributeProRataNegativeWeightPanics(t *testing.T) {
+	require.Panics(t, func() { DistributeProRata(sdk.NewInt(1), ints(1, -1)) })
+}
+
+func TestDistributeProRataZeroTotalWeightPanics(t *testing.T) {
+	require.Panics(t, func() { DistributeProRata(sdk.NewInt(1), ints(0, 0)) })
+}
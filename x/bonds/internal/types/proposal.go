@@ -0,0 +1,393 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeClaimStuckFunds defines the type for a ClaimStuckFundsProposal
+	ProposalTypeClaimStuckFunds = "ClaimStuckFunds"
+	// ProposalTypeMigrateCurveVersion defines the type for a MigrateCurveVersionProposal
+	ProposalTypeMigrateCurveVersion = "MigrateCurveVersion"
+	// ProposalTypeMigrateReserveToken defines the type for a MigrateReserveTokenProposal
+	ProposalTypeMigrateReserveToken = "MigrateReserveToken"
+	// ProposalTypeEditBond defines the type for an EditBondProposal
+	ProposalTypeEditBond = "EditBond"
+)
+
+// Assert proposals implement govtypes.Content at compile-time
+var (
+	_ govtypes.Content = ClaimStuckFundsProposal{}
+	_ govtypes.Content = MigrateCurveVersionProposal{}
+	_ govtypes.Content = MigrateReserveTokenProposal{}
+	_ govtypes.Content = EditBondProposal{}
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeClaimStuckFunds)
+	govtypes.RegisterProposalTypeCodec(ClaimStuckFundsProposal{}, "bonds/ClaimStuckFundsProposal")
+	govtypes.RegisterProposalType(ProposalTypeMigrateCurveVersion)
+	govtypes.RegisterProposalTypeCodec(MigrateCurveVersionProposal{}, "bonds/MigrateCurveVersionProposal")
+	govtypes.RegisterProposalType(ProposalTypeMigrateReserveToken)
+	govtypes.RegisterProposalTypeCodec(MigrateReserveTokenProposal{}, "bonds/MigrateReserveTokenProposal")
+	govtypes.RegisterProposalType(ProposalTypeEditBond)
+	govtypes.RegisterProposalTypeCodec(EditBondProposal{}, "bonds/EditBondProposal")
+}
+
+// ClaimStuckFundsProposal is a governance proposal to return funds that are
+// held by one of the bonds module accounts without being accounted for by any
+// bond or order (e.g. as a result of a past bug) to a specified recipient.
+type ClaimStuckFundsProposal struct {
+	Title         string         `json:"title" yaml:"title"`
+	Description   string         `json:"description" yaml:"description"`
+	ModuleAccount string         `json:"module_account" yaml:"module_account"`
+	Recipient     sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	Amount        sdk.Coins      `json:"amount" yaml:"amount"`
+}
+
+func NewClaimStuckFundsProposal(title, description, moduleAccount string,
+	recipient sdk.AccAddress, amount sdk.Coins) ClaimStuckFundsProposal {
+	return ClaimStuckFundsProposal{
+		Title:         title,
+		Description:   description,
+		ModuleAccount: moduleAccount,
+		Recipient:     recipient,
+		Amount:        amount,
+	}
+}
+
+func (p ClaimStuckFundsProposal) GetTitle() string { return p.Title }
+
+func (p ClaimStuckFundsProposal) GetDescription() string { return p.Description }
+
+func (p ClaimStuckFundsProposal) ProposalRoute() string { return RouterKey }
+
+func (p ClaimStuckFundsProposal) ProposalType() string { return ProposalTypeClaimStuckFunds }
+
+func (p ClaimStuckFundsProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+
+	// Check that module account is one of the bonds module accounts
+	if strings.TrimSpace(p.ModuleAccount) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "ModuleAccount")
+	} else if !IsBondsModuleAccount(p.ModuleAccount) {
+		return sdkerrors.Wrap(ErrUnknownBondsModuleAccount, p.ModuleAccount)
+	}
+
+	// Check that recipient is not empty
+	if p.Recipient.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Recipient")
+	}
+
+	// Check that amount is valid and not empty
+	if !p.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	} else if p.Amount.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Amount")
+	}
+
+	return nil
+}
+
+func (p ClaimStuckFundsProposal) String() string {
+	return fmt.Sprintf(`Claim Stuck Funds Proposal:
+  Title:          %s
+  Description:    %s
+  Module Account: %s
+  Recipient:      %s
+  Amount:         %s
+`, p.Title, p.Description, p.ModuleAccount, p.Recipient, p.Amount)
+}
+
+// MigrateCurveVersionProposal is a governance proposal to migrate a bond to a
+// newer curve engine version, for example so that it benefits from a fix to
+// the curve math, which is otherwise only applied to newly created bonds.
+type MigrateCurveVersionProposal struct {
+	Title        string `json:"title" yaml:"title"`
+	Description  string `json:"description" yaml:"description"`
+	BondToken    string `json:"bond_token" yaml:"bond_token"`
+	CurveVersion uint64 `json:"curve_version" yaml:"curve_version"`
+}
+
+func NewMigrateCurveVersionProposal(title, description, bondToken string,
+	curveVersion uint64) MigrateCurveVersionProposal {
+	return MigrateCurveVersionProposal{
+		Title:        title,
+		Description:  description,
+		BondToken:    bondToken,
+		CurveVersion: curveVersion,
+	}
+}
+
+func (p MigrateCurveVersionProposal) GetTitle() string { return p.Title }
+
+func (p MigrateCurveVersionProposal) GetDescription() string { return p.Description }
+
+func (p MigrateCurveVersionProposal) ProposalRoute() string { return RouterKey }
+
+func (p MigrateCurveVersionProposal) ProposalType() string {
+	return ProposalTypeMigrateCurveVersion
+}
+
+func (p MigrateCurveVersionProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+
+	// Check that bond token is not empty
+	if strings.TrimSpace(p.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Check that curve version is a known version
+	if !IsValidCurveVersion(p.CurveVersion) {
+		return sdkerrors.Wrapf(ErrUnrecognizedCurveVersion, "%d", p.CurveVersion)
+	}
+
+	return nil
+}
+
+func (p MigrateCurveVersionProposal) String() string {
+	return fmt.Sprintf(`Migrate Curve Version Proposal:
+  Title:         %s
+  Description:   %s
+  Bond Token:    %s
+  Curve Version: %d
+`, p.Title, p.Description, p.BondToken, p.CurveVersion)
+}
+
+// MigrateReserveTokenProposal is a governance proposal to migrate one of a
+// bond's reserve tokens to a new denomination (e.g. after a redenomination of
+// the token), converting the bond's reserve at the specified rate, which is
+// the number of new tokens per old token.
+type MigrateReserveTokenProposal struct {
+	Title       string  `json:"title" yaml:"title"`
+	Description string  `json:"description" yaml:"description"`
+	BondToken   string  `json:"bond_token" yaml:"bond_token"`
+	FromDenom   string  `json:"from_denom" yaml:"from_denom"`
+	ToDenom     string  `json:"to_denom" yaml:"to_denom"`
+	Rate        sdk.Dec `json:"rate" yaml:"rate"`
+}
+
+func NewMigrateReserveTokenProposal(title, description, bondToken, fromDenom,
+	toDenom string, rate sdk.Dec) MigrateReserveTokenProposal {
+	return MigrateReserveTokenProposal{
+		Title:       title,
+		Description: description,
+		BondToken:   bondToken,
+		FromDenom:   fromDenom,
+		ToDenom:     toDenom,
+		Rate:        rate,
+	}
+}
+
+func (p MigrateReserveTokenProposal) GetTitle() string { return p.Title }
+
+func (p MigrateReserveTokenProposal) GetDescription() string { return p.Description }
+
+func (p MigrateReserveTokenProposal) ProposalRoute() string { return RouterKey }
+
+func (p MigrateReserveTokenProposal) ProposalType() string {
+	return ProposalTypeMigrateReserveToken
+}
+
+func (p MigrateReserveTokenProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+
+	// Check that bond token is not empty
+	if strings.TrimSpace(p.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Check that denominations are valid and different
+	if err := sdk.ValidateDenom(p.FromDenom); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	} else if err := sdk.ValidateDenom(p.ToDenom); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	} else if p.FromDenom == p.ToDenom {
+		return sdkerrors.Wrap(ErrInvalidReserveMigration, "denominations must be different")
+	}
+
+	// Check that rate is positive
+	if p.Rate.IsNil() || !p.Rate.IsPositive() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Rate")
+	}
+
+	return nil
+}
+
+func (p MigrateReserveTokenProposal) String() string {
+	return fmt.Sprintf(`Migrate Reserve Token Proposal:
+  Title:       %s
+  Description: %s
+  Bond Token:  %s
+  From Denom:  %s
+  To Denom:    %s
+  Rate:        %s
+`, p.Title, p.Description, p.BondToken, p.FromDenom, p.ToDenom, p.Rate)
+}
+
+// EditBondProposal is a governance proposal to edit a subset of a bond's
+// fields, such as its fees or sanity values, or to disable its sells, without
+// the approval of the bond's signers (e.g. if a misbehaving bond's signers are
+// unresponsive). Fields that are left unset (nil) are not edited.
+type EditBondProposal struct {
+	Title                  string     `json:"title" yaml:"title"`
+	Description            string     `json:"description" yaml:"description"`
+	BondToken              string     `json:"bond_token" yaml:"bond_token"`
+	TxFeePercentage        *sdk.Dec   `json:"tx_fee_percentage,omitempty" yaml:"tx_fee_percentage,omitempty"`
+	ExitFeePercentage      *sdk.Dec   `json:"exit_fee_percentage,omitempty" yaml:"exit_fee_percentage,omitempty"`
+	OrderQuantityLimits    *sdk.Coins `json:"order_quantity_limits,omitempty" yaml:"order_quantity_limits,omitempty"`
+	SanityRate             *sdk.Dec   `json:"sanity_rate,omitempty" yaml:"sanity_rate,omitempty"`
+	SanityMarginPercentage *sdk.Dec   `json:"sanity_margin_percentage,omitempty" yaml:"sanity_margin_percentage,omitempty"`
+	AllowSells             *bool      `json:"allow_sells,omitempty" yaml:"allow_sells,omitempty"`
+}
+
+func NewEditBondProposal(title, description, bondToken string, txFeePercentage,
+	exitFeePercentage *sdk.Dec, orderQuantityLimits *sdk.Coins, sanityRate,
+	sanityMarginPercentage *sdk.Dec, allowSells *bool) EditBondProposal {
+	return EditBondProposal{
+		Title:                  title,
+		Description:            description,
+		BondToken:              bondToken,
+		TxFeePercentage:        txFeePercentage,
+		ExitFeePercentage:      exitFeePercentage,
+		OrderQuantityLimits:    orderQuantityLimits,
+		SanityRate:             sanityRate,
+		SanityMarginPercentage: sanityMarginPercentage,
+		AllowSells:             allowSells,
+	}
+}
+
+func (p EditBondProposal) GetTitle() string { return p.Title }
+
+func (p EditBondProposal) GetDescription() string { return p.Description }
+
+func (p EditBondProposal) ProposalRoute() string { return RouterKey }
+
+func (p EditBondProposal) ProposalType() string { return ProposalTypeEditBond }
+
+// EditedFields returns the names of the fields that the proposal edits.
+func (p EditBondProposal) EditedFields() (fields []string) {
+	edited := []struct {
+		name string
+		set  bool
+	}{
+		{"tx_fee_percentage", p.TxFeePercentage != nil},
+		{"exit_fee_percentage", p.ExitFeePercentage != nil},
+		{"order_quantity_limits", p.OrderQuantityLimits != nil},
+		{"sanity_rate", p.SanityRate != nil},
+		{"sanity_margin_percentage", p.SanityMarginPercentage != nil},
+		{"allow_sells", p.AllowSells != nil},
+	}
+	for _, f := range edited {
+		if f.set {
+			fields = append(fields, f.name)
+		}
+	}
+	return fields
+}
+
+func (p EditBondProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+
+	// Check that bond token is not empty
+	if strings.TrimSpace(p.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Check that at least one field was edited
+	if len(p.EditedFields()) == 0 {
+		return ErrDidNotEditAnything
+	}
+
+	// Check that percentages being edited are valid percentages. The sum of
+	// the fees is checked against the bond's existing fees in the handler.
+	percentages := []struct {
+		name  string
+		value *sdk.Dec
+	}{
+		{"TxFeePercentage", p.TxFeePercentage},
+		{"ExitFeePercentage", p.ExitFeePercentage},
+		{"SanityMarginPercentage", p.SanityMarginPercentage},
+	}
+	for _, pc := range percentages {
+		if pc.value == nil {
+			continue
+		} else if err := NewPercentage(*pc.value).Validate(); err != nil {
+			return sdkerrors.Wrap(err, pc.name)
+		}
+	}
+
+	// Note: order quantity limits and sanity values can be reset (i.e. empty
+	// or zero), as with MsgEditBond
+	if p.OrderQuantityLimits != nil && !isValidCoins(*p.OrderQuantityLimits) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, p.OrderQuantityLimits.String())
+	}
+	if p.SanityRate != nil {
+		if p.SanityRate.IsNil() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityRate")
+		} else if p.SanityRate.IsNegative() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "SanityRate")
+		}
+	}
+
+	return nil
+}
+
+func (p EditBondProposal) String() string {
+	edited := func(value fmt.Stringer, set bool) string {
+		if !set {
+			return "-"
+		}
+		return value.String()
+	}
+	allowSells := "-"
+	if p.AllowSells != nil {
+		allowSells = strconv.FormatBool(*p.AllowSells)
+	}
+	return fmt.Sprintf(`Edit Bond Proposal:
+  Title:                    %s
+  Description:              %s
+  Bond Token:               %s
+  Tx Fee Percentage:        %s
+  Exit Fee Percentage:      %s
+  Order Quantity Limits:    %s
+  Sanity Rate:              %s
+  Sanity Margin Percentage: %s
+  Allow Sells:              %s
+`, p.Title, p.Description, p.BondToken,
+		edited(p.TxFeePercentage, p.TxFeePercentage != nil),
+		edited(p.ExitFeePercentage, p.ExitFeePercentage != nil),
+		edited(p.OrderQuantityLimits, p.OrderQuantityLimits != nil),
+		edited(p.SanityRate, p.SanityRate != nil),
+		edited(p.SanityMarginPercentage, p.SanityMarginPercentage != nil),
+		allowSells)
+}
+
+// IsBondsModuleAccount returns true if the name is that of one of the
+// accounts owned by the bonds module.
+func IsBondsModuleAccount(name string) bool {
+	return name == BondsMintBurnAccount ||
+		name == BatchesIntermediaryAccount ||
+		name == BondsReserveAccount ||
+		name == BondProposalsAccount ||
+		name == BondVestingAccount ||
+		name == NotificationDepositsAccount
+}
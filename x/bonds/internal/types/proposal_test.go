@@ -0,0 +1,170 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func newValidClaimStuckFundsProposal() ClaimStuckFundsProposal {
+	return NewClaimStuckFundsProposal("title", "description",
+		BondsReserveAccount, sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)))
+}
+
+func TestValidateBasicClaimStuckFundsProposalValid(t *testing.T) {
+	proposal := newValidClaimStuckFundsProposal()
+
+	err := proposal.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicClaimStuckFundsProposalTitleMissingGivesError(t *testing.T) {
+	proposal := newValidClaimStuckFundsProposal()
+	proposal.Title = ""
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicClaimStuckFundsProposalUnknownModuleAccountGivesError(t *testing.T) {
+	proposal := newValidClaimStuckFundsProposal()
+	proposal.ModuleAccount = "distribution"
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicClaimStuckFundsProposalRecipientMissingGivesError(t *testing.T) {
+	proposal := newValidClaimStuckFundsProposal()
+	proposal.Recipient = sdk.AccAddress{}
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicClaimStuckFundsProposalAmountMissingGivesError(t *testing.T) {
+	proposal := newValidClaimStuckFundsProposal()
+	proposal.Amount = sdk.Coins{}
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func newValidMigrateCurveVersionProposal() MigrateCurveVersionProposal {
+	return NewMigrateCurveVersionProposal("title", "description",
+		initToken, LatestCurveVersion)
+}
+
+func TestValidateBasicMigrateCurveVersionProposalValid(t *testing.T) {
+	proposal := newValidMigrateCurveVersionProposal()
+
+	err := proposal.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMigrateCurveVersionProposalBondTokenMissingGivesError(t *testing.T) {
+	proposal := newValidMigrateCurveVersionProposal()
+	proposal.BondToken = ""
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMigrateCurveVersionProposalUnknownCurveVersionGivesError(t *testing.T) {
+	proposal := newValidMigrateCurveVersionProposal()
+
+	proposal.CurveVersion = 0
+	require.NotNil(t, proposal.ValidateBasic())
+
+	proposal.CurveVersion = LatestCurveVersion + 1
+	require.NotNil(t, proposal.ValidateBasic())
+}
+
+func newValidMigrateReserveTokenProposal() MigrateReserveTokenProposal {
+	return NewMigrateReserveTokenProposal("title", "description",
+		initToken, reserveToken, reserveToken2, sdk.NewDec(1000))
+}
+
+func TestValidateBasicMigrateReserveTokenProposalValid(t *testing.T) {
+	proposal := newValidMigrateReserveTokenProposal()
+
+	err := proposal.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMigrateReserveTokenProposalInvalidDenomGivesError(t *testing.T) {
+	proposal := newValidMigrateReserveTokenProposal()
+	proposal.ToDenom = "123"
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMigrateReserveTokenProposalSameDenomsGivesError(t *testing.T) {
+	proposal := newValidMigrateReserveTokenProposal()
+	proposal.ToDenom = proposal.FromDenom
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMigrateReserveTokenProposalNonPositiveRateGivesError(t *testing.T) {
+	proposal := newValidMigrateReserveTokenProposal()
+
+	proposal.Rate = sdk.ZeroDec()
+	require.NotNil(t, proposal.ValidateBasic())
+
+	proposal.Rate = sdk.NewDec(-1)
+	require.NotNil(t, proposal.ValidateBasic())
+}
+
+func newValidEditBondProposal() EditBondProposal {
+	return NewEditBondProposal("title", "description", initToken,
+		decPtr(sdk.NewDec(1)), nil, nil, nil, nil, boolPtr(false))
+}
+
+func TestValidateBasicEditBondProposalValid(t *testing.T) {
+	proposal := newValidEditBondProposal()
+
+	err := proposal.ValidateBasic()
+	require.Nil(t, err)
+	require.Equal(t, []string{"tx_fee_percentage", "allow_sells"},
+		proposal.EditedFields())
+}
+
+func TestValidateBasicEditBondProposalBondTokenMissingGivesError(t *testing.T) {
+	proposal := newValidEditBondProposal()
+	proposal.BondToken = ""
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicEditBondProposalNothingEditedGivesError(t *testing.T) {
+	proposal := NewEditBondProposal("title", "description", initToken,
+		nil, nil, nil, nil, nil, nil)
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrDidNotEditAnything.Is(err))
+}
+
+func TestValidateBasicEditBondProposalInvalidPercentageGivesError(t *testing.T) {
+	proposal := newValidEditBondProposal()
+
+	proposal.ExitFeePercentage = decPtr(sdk.NewDec(-1))
+	require.NotNil(t, proposal.ValidateBasic())
+
+	proposal.ExitFeePercentage = decPtr(sdk.NewDec(101))
+	require.NotNil(t, proposal.ValidateBasic())
+}
+
+func TestValidateBasicEditBondProposalNegativeSanityRateGivesError(t *testing.T) {
+	proposal := newValidEditBondProposal()
+	proposal.SanityRate = decPtr(sdk.NewDec(-1))
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
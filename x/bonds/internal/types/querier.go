@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"sort"
 	"strings"
 )
 
@@ -11,24 +12,437 @@ func (b QueryBonds) String() string {
 	return strings.Join(b[:], "\n")
 }
 
+// QueryBond is a bond along with read-only fields derived from its state at
+// the time of the query, so that clients do not need to query its prices,
+// reserve, and batch separately. The spot prices are the bond's current
+// prices per token. The implied reserve is the reserve implied by the bond's
+// curve at its supply, and the reserve ratio is the implied reserve divided by
+// the market cap at the spot price (e.g. cw for a Bancor function); both are
+// left out for swapper bonds, since their reserve is not implied by their
+// supply. The phase is the bond's state and the next batch height is the
+// height at the end of which the bond's current batch will be performed.
+type QueryBond struct {
+	Bond            Bond         `json:"bond" yaml:"bond"`
+	SpotPrices      sdk.DecCoins `json:"spot_prices" yaml:"spot_prices"`
+	ImpliedReserve  sdk.DecCoins `json:"implied_reserve,omitempty" yaml:"implied_reserve,omitempty"`
+	ReserveRatio    sdk.Dec      `json:"reserve_ratio" yaml:"reserve_ratio"`
+	Phase           string       `json:"phase" yaml:"phase"`
+	NextBatchHeight int64        `json:"next_batch_height" yaml:"next_batch_height"`
+}
+
+// NewQueryBond derives the read-only fields of the bond from its reserve
+// balances and the blocks remaining in its current batch at the height. The
+// spot prices are left empty if they cannot be calculated, e.g. for swapper
+// bonds without liquidity.
+func NewQueryBond(bond Bond, reserveBalances sdk.Coins,
+	blocksRemaining sdk.Uint, height int64) QueryBond {
+	spotPrices, err := bond.GetCurrentPricesPT(reserveBalances)
+	if err != nil {
+		spotPrices = nil
+	}
+
+	var impliedReserve sdk.DecCoins
+	reserveRatio := sdk.ZeroDec()
+	if !IsSwapperFunctionType(bond.FunctionType) {
+		supply := bond.CurrentSupply.Amount
+		reserve := bond.ReserveAtSupply(supply)
+		impliedReserve = bond.GetNewReserveDecCoins(reserve)
+
+		if len(bond.ReserveTokens) > 0 {
+			marketCap := spotPrices.AmountOf(bond.ReserveTokens[0]).MulInt(supply)
+			if marketCap.IsPositive() {
+				reserveRatio = reserve.Quo(marketCap)
+			}
+		}
+	}
+
+	return QueryBond{
+		Bond:            bond,
+		SpotPrices:      spotPrices,
+		ImpliedReserve:  impliedReserve,
+		ReserveRatio:    reserveRatio,
+		Phase:           bond.State,
+		NextBatchHeight: height + int64(blocksRemaining.Uint64()),
+	}
+}
+
 type QueryBuyPrice struct {
-	AdjustedSupply sdk.Coin  `json:"adjusted_supply" yaml:"asdjusted_supply"`
-	Prices         sdk.Coins `json:"prices" yaml:"prices"`
-	TxFees         sdk.Coins `json:"tx_fees" yaml:"tx_fees"`
-	TotalPrices    sdk.Coins `json:"total_prices" yaml:"total_prices"`
-	TotalFees      sdk.Coins `json:"total_fees" yaml:"total_fees"`
+	AdjustedSupply   sdk.Coin     `json:"adjusted_supply" yaml:"asdjusted_supply"`
+	Prices           sdk.Coins    `json:"prices" yaml:"prices"`
+	TxFees           sdk.Coins    `json:"tx_fees" yaml:"tx_fees"`
+	TotalPrices      sdk.Coins    `json:"total_prices" yaml:"total_prices"`
+	TotalFees        sdk.Coins    `json:"total_fees" yaml:"total_fees"`
+	QuoteTotalPrices sdk.DecCoins `json:"quote_total_prices,omitempty" yaml:"quote_total_prices,omitempty"`
 }
 
 type QuerySellReturn struct {
-	AdjustedSupply sdk.Coin  `json:"adjusted_supply" yaml:"asdjusted_supply"`
-	Returns        sdk.Coins `json:"returns" yaml:"returns"`
-	TxFees         sdk.Coins `json:"tx_fees" yaml:"tx_fees"`
-	ExitFees       sdk.Coins `json:"exit_fees" yaml:"exit_fees"`
-	TotalReturns   sdk.Coins `json:"total_returns" yaml:"total_returns"`
-	TotalFees      sdk.Coins `json:"total_fees" yaml:"total_fees"`
+	AdjustedSupply    sdk.Coin     `json:"adjusted_supply" yaml:"asdjusted_supply"`
+	Returns           sdk.Coins    `json:"returns" yaml:"returns"`
+	TxFees            sdk.Coins    `json:"tx_fees" yaml:"tx_fees"`
+	ExitFees          sdk.Coins    `json:"exit_fees" yaml:"exit_fees"`
+	TotalReturns      sdk.Coins    `json:"total_returns" yaml:"total_returns"`
+	TotalFees         sdk.Coins    `json:"total_fees" yaml:"total_fees"`
+	Demurrage         sdk.Coins    `json:"demurrage" yaml:"demurrage"`
+	QuoteTotalReturns sdk.DecCoins `json:"quote_total_returns,omitempty" yaml:"quote_total_returns,omitempty"`
+}
+
+// QueryQuotePrice is a bond's current price in its reserve tokens, along with
+// the same price converted into the bond's quote denomination.
+type QueryQuotePrice struct {
+	Prices     sdk.DecCoins `json:"prices" yaml:"prices"`
+	QuotePrice sdk.DecCoin  `json:"quote_price" yaml:"quote_price"`
 }
 
 type QuerySwapReturn struct {
 	TotalReturns sdk.Coins `json:"total_returns" yaml:"total_returns"`
 	TotalFees    sdk.Coins `json:"total_fees" yaml:"total_fees"`
 }
+
+// QueryFeePreview is the tx and exit fee that a bond would charge on reserve
+// amounts, both before and after rounding. Fees are rounded up per reserve
+// token, so the exact fees show how much of each fee is due to rounding. The
+// total fees of a buy, sell, or swap of the reserve amounts are the fees
+// charged by the bond's fee chain, i.e. a sell's fees are capped at its
+// rounded returns.
+type QueryFeePreview struct {
+	ReserveAmounts sdk.DecCoins `json:"reserve_amounts" yaml:"reserve_amounts"`
+	ExactTxFees    sdk.DecCoins `json:"exact_tx_fees" yaml:"exact_tx_fees"`
+	ExactExitFees  sdk.DecCoins `json:"exact_exit_fees" yaml:"exact_exit_fees"`
+	TxFees         sdk.Coins    `json:"tx_fees" yaml:"tx_fees"`
+	ExitFees       sdk.Coins    `json:"exit_fees" yaml:"exit_fees"`
+	BuyTotalFees   sdk.Coins    `json:"buy_total_fees" yaml:"buy_total_fees"`
+	SellTotalFees  sdk.Coins    `json:"sell_total_fees" yaml:"sell_total_fees"`
+	SwapTotalFees  sdk.Coins    `json:"swap_total_fees" yaml:"swap_total_fees"`
+}
+
+// NewQueryFeePreview calculates the fees that the bond would charge on the
+// reserve amounts, using the same fee chain as the bond's orders.
+func NewQueryFeePreview(bond Bond, reserveAmounts sdk.DecCoins) QueryFeePreview {
+	return QueryFeePreview{
+		ReserveAmounts: reserveAmounts,
+		ExactTxFees:    GetExactPercentageFees(reserveAmounts, bond.TxFeePercentage),
+		ExactExitFees:  GetExactPercentageFees(reserveAmounts, bond.ExitFeePercentage),
+		TxFees:         bond.GetFees(reserveAmounts, bond.TxFeePercentage),
+		ExitFees:       bond.GetFees(reserveAmounts, bond.ExitFeePercentage),
+		BuyTotalFees:   bond.GetOrderFees(AttributeValueBuyOrder, reserveAmounts).Total,
+		SellTotalFees:  bond.GetOrderFees(AttributeValueSellOrder, reserveAmounts).Total,
+		SwapTotalFees:  bond.GetOrderFees(AttributeValueSwapOrder, reserveAmounts).Total,
+	}
+}
+
+type PriceImpact struct {
+	Denom      string  `json:"denom" yaml:"denom"`
+	Percentage sdk.Dec `json:"percentage" yaml:"percentage"`
+}
+
+type QueryPriceImpact struct {
+	PreTradePrices    sdk.DecCoins  `json:"pre_trade_prices" yaml:"pre_trade_prices"`
+	PostTradePrices   sdk.DecCoins  `json:"post_trade_prices" yaml:"post_trade_prices"`
+	ImpactPercentages []PriceImpact `json:"impact_percentages" yaml:"impact_percentages"`
+}
+
+// NewQueryPriceImpact calculates the percentage change from the pre-trade to
+// the post-trade price of each denomination. The change is zero for any
+// denomination with a zero pre-trade price.
+func NewQueryPriceImpact(preTradePrices, postTradePrices sdk.DecCoins) QueryPriceImpact {
+	impacts := make([]PriceImpact, len(preTradePrices))
+	for i, pre := range preTradePrices {
+		percentage := sdk.ZeroDec()
+		if !pre.Amount.IsZero() {
+			post := postTradePrices.AmountOf(pre.Denom)
+			percentage = post.Sub(pre.Amount).Quo(pre.Amount).MulInt64(100)
+		}
+		impacts[i] = PriceImpact{Denom: pre.Denom, Percentage: percentage}
+	}
+	return QueryPriceImpact{
+		PreTradePrices:    preTradePrices,
+		PostTradePrices:   postTradePrices,
+		ImpactPercentages: impacts,
+	}
+}
+
+// QueryBatchAuction summarises the current batch of a bond as a batch auction.
+// Since all buys in a batch are performed at the same prices, the batch's buy
+// prices (re-calculated against the latest reserve) are its indicative
+// clearing prices. Bids only include the amounts of the pending buys, largest
+// first, so that they cannot be linked to the buyers or their max prices.
+type QueryBatchAuction struct {
+	Bids                  []sdk.Coin   `json:"bids" yaml:"bids"`
+	ClearingPrices        sdk.DecCoins `json:"clearing_prices" yaml:"clearing_prices"`
+	TotalBidAmount        sdk.Coin     `json:"total_bid_amount" yaml:"total_bid_amount"`
+	AvailableSupply       sdk.Coin     `json:"available_supply" yaml:"available_supply"`
+	OversubscriptionRatio sdk.Dec      `json:"oversubscription_ratio" yaml:"oversubscription_ratio"`
+	BlocksRemaining       sdk.Uint     `json:"blocks_remaining" yaml:"blocks_remaining"`
+}
+
+// NewQueryBatchAuction summarises the batch given its clearing prices and the
+// supply available to it. The oversubscription ratio is the total amount bid
+// divided by the available supply, and is zero if there is no supply left.
+func NewQueryBatchAuction(batch Batch, clearingPrices sdk.DecCoins, availableSupply sdk.Coin) QueryBatchAuction {
+	bids := make([]sdk.Coin, 0, len(batch.Buys))
+	for _, bo := range batch.Buys {
+		if !bo.IsCancelled() {
+			bids = append(bids, bo.Amount)
+		}
+	}
+	sort.SliceStable(bids, func(i, j int) bool {
+		return bids[j].Amount.LT(bids[i].Amount)
+	})
+
+	ratio := sdk.ZeroDec()
+	if availableSupply.IsPositive() {
+		ratio = batch.TotalBuyAmount.Amount.ToDec().QuoInt(availableSupply.Amount)
+	}
+
+	return QueryBatchAuction{
+		Bids:                  bids,
+		ClearingPrices:        clearingPrices,
+		TotalBidAmount:        batch.TotalBuyAmount,
+		AvailableSupply:       availableSupply,
+		OversubscriptionRatio: ratio,
+		BlocksRemaining:       batch.BlocksRemaining,
+	}
+}
+
+// QuerySimulateBatch is the outcome of settling a bond's current batch against
+// the current state without committing any changes. The batch includes the
+// outcome of each order (cancellations and buy refunds), the result includes
+// the clearing prices and totals, and the total fees are the fees that would
+// be collected. The batch is settled regardless of the blocks remaining.
+type QuerySimulateBatch struct {
+	Batch           Batch       `json:"batch" yaml:"batch"`
+	Result          BatchResult `json:"result" yaml:"result"`
+	TotalFees       sdk.Coins   `json:"total_fees" yaml:"total_fees"`
+	BlocksRemaining sdk.Uint    `json:"blocks_remaining" yaml:"blocks_remaining"`
+}
+
+// BatchSummary summarises the orders pending in a bond's current batch. Only
+// orders that have not been cancelled are counted.
+type BatchSummary struct {
+	BlocksRemaining sdk.Uint     `json:"blocks_remaining" yaml:"blocks_remaining"`
+	BuyOrders       uint64       `json:"buy_orders" yaml:"buy_orders"`
+	SellOrders      uint64       `json:"sell_orders" yaml:"sell_orders"`
+	SwapOrders      uint64       `json:"swap_orders" yaml:"swap_orders"`
+	TotalBuyAmount  sdk.Coin     `json:"total_buy_amount" yaml:"total_buy_amount"`
+	TotalSellAmount sdk.Coin     `json:"total_sell_amount" yaml:"total_sell_amount"`
+	TotalSwapAmount sdk.Coins    `json:"total_swap_amount" yaml:"total_swap_amount"`
+	BuyPrices       sdk.DecCoins `json:"buy_prices" yaml:"buy_prices"`
+	SellPrices      sdk.DecCoins `json:"sell_prices" yaml:"sell_prices"`
+}
+
+func NewBatchSummary(batch Batch) BatchSummary {
+	summary := BatchSummary{
+		BlocksRemaining: batch.BlocksRemaining,
+		TotalBuyAmount:  batch.TotalBuyAmount,
+		TotalSellAmount: batch.TotalSellAmount,
+		TotalSwapAmount: sdk.Coins{},
+		BuyPrices:       batch.BuyPrices,
+		SellPrices:      batch.SellPrices,
+	}
+	for _, bo := range batch.Buys {
+		if !bo.IsCancelled() {
+			summary.BuyOrders += 1
+		}
+	}
+	for _, so := range batch.Sells {
+		if !so.IsCancelled() {
+			summary.SellOrders += 1
+		}
+	}
+	for _, so := range batch.Swaps {
+		if !so.IsCancelled() {
+			summary.SwapOrders += 1
+			summary.TotalSwapAmount = summary.TotalSwapAmount.Add(so.Amount)
+		}
+	}
+	return summary
+}
+
+// QueryBondAdmin bundles the administrative state of a bond needed by its
+// issuer: the bond's configuration, signers and signer threshold, its
+// scheduled parameter change (if any), the fees it has collected, the balance
+// of its fee address (which also receives the funding portion of hatch-phase
+// buys), whether order submission is halted, and a summary of its current
+// batch.
+type QueryBondAdmin struct {
+	Bond                  Bond                  `json:"bond" yaml:"bond"`
+	Signers               []sdk.AccAddress      `json:"signers" yaml:"signers"`
+	SignerThreshold       SignerThreshold       `json:"signer_threshold" yaml:"signer_threshold"`
+	ScheduledParamChange  *ScheduledParamChange `json:"scheduled_param_change" yaml:"scheduled_param_change"`
+	FeesCollected         sdk.Coins             `json:"fees_collected" yaml:"fees_collected"`
+	FundingPoolBalance    sdk.Coins             `json:"funding_pool_balance" yaml:"funding_pool_balance"`
+	OrderSubmissionHalted bool                  `json:"order_submission_halted" yaml:"order_submission_halted"`
+	PendingBatch          BatchSummary          `json:"pending_batch" yaml:"pending_batch"`
+}
+
+// SupplyHistoryEntry is a bond's supply at the height of one of its batches.
+type SupplyHistoryEntry struct {
+	Height int64    `json:"height" yaml:"height"`
+	Supply sdk.Coin `json:"supply" yaml:"supply"`
+}
+
+type QuerySupplyHistory []SupplyHistoryEntry
+
+func NewQuerySupplyHistory(history BondHistory) QuerySupplyHistory {
+	result := make(QuerySupplyHistory, len(history))
+	for i, s := range history {
+		result[i] = SupplyHistoryEntry{Height: s.Height, Supply: s.Supply}
+	}
+	return result
+}
+
+// ReserveHistoryEntry is a bond's reserve at the height of one of its batches.
+type ReserveHistoryEntry struct {
+	Height  int64     `json:"height" yaml:"height"`
+	Reserve sdk.Coins `json:"reserve" yaml:"reserve"`
+}
+
+type QueryReserveHistory []ReserveHistoryEntry
+
+func NewQueryReserveHistory(history BondHistory) QueryReserveHistory {
+	result := make(QueryReserveHistory, len(history))
+	for i, s := range history {
+		result[i] = ReserveHistoryEntry{Height: s.Height, Reserve: s.Reserve}
+	}
+	return result
+}
+
+// QueryBondAtHeight is a bond's supply, reserve, and prices at the last batch
+// boundary at or before a height, as recorded in the bond's history.
+type QueryBondAtHeight struct {
+	Bond           string       `json:"bond" yaml:"bond"`
+	Height         int64        `json:"height" yaml:"height"`
+	SnapshotHeight int64        `json:"snapshot_height" yaml:"snapshot_height"`
+	Supply         sdk.Coin     `json:"supply" yaml:"supply"`
+	Reserve        sdk.Coins    `json:"reserve" yaml:"reserve"`
+	Prices         sdk.DecCoins `json:"prices" yaml:"prices"`
+}
+
+func NewQueryBondAtHeight(token string, height int64, snapshot BondSnapshot) QueryBondAtHeight {
+	return QueryBondAtHeight{
+		Bond:           token,
+		Height:         height,
+		SnapshotHeight: snapshot.Height,
+		Supply:         snapshot.Supply,
+		Reserve:        snapshot.Reserve,
+		Prices:         snapshot.Prices,
+	}
+}
+
+// BlocksPerYear is the number of blocks assumed to make up a year when
+// annualising yields (~1 year at 5s blocks).
+const BlocksPerYear = 6307200
+
+type ReserveAPR struct {
+	Denom      string  `json:"denom" yaml:"denom"`
+	Percentage sdk.Dec `json:"percentage" yaml:"percentage"`
+}
+
+// QueryEffectiveAPR is the trailing annualised yield of a bond token, measured
+// as the growth of the reserve backing each bond token between the oldest and
+// newest snapshots of the bond's history at which the bond had a supply.
+type QueryEffectiveAPR struct {
+	FromHeight          int64        `json:"from_height" yaml:"from_height"`
+	ToHeight            int64        `json:"to_height" yaml:"to_height"`
+	FromReservePerToken sdk.DecCoins `json:"from_reserve_per_token" yaml:"from_reserve_per_token"`
+	ToReservePerToken   sdk.DecCoins `json:"to_reserve_per_token" yaml:"to_reserve_per_token"`
+	APRPercentages      []ReserveAPR `json:"apr_percentages" yaml:"apr_percentages"`
+}
+
+// NewQueryEffectiveAPR calculates the annualised percentage change in the
+// reserve per bond token of each reserve denomination over the bond's history.
+// Only the denominations backing the bond token at the start of the history
+// are included. False is returned if the history does not have two snapshots
+// at different heights at which the bond had a supply.
+func NewQueryEffectiveAPR(history BondHistory) (QueryEffectiveAPR, bool) {
+	var from, to *BondSnapshot
+	for i := range history {
+		if !history[i].Supply.Amount.IsPositive() {
+			continue
+		}
+		if from == nil {
+			from = &history[i]
+		}
+		to = &history[i]
+	}
+	if from == nil || to.Height <= from.Height {
+		return QueryEffectiveAPR{}, false
+	}
+
+	fromPerToken := sdk.NewDecCoinsFromCoins(from.Reserve...).QuoDec(from.Supply.Amount.ToDec())
+	toPerToken := sdk.NewDecCoinsFromCoins(to.Reserve...).QuoDec(to.Supply.Amount.ToDec())
+	blocks := to.Height - from.Height
+
+	aprs := make([]ReserveAPR, len(fromPerToken))
+	for i, f := range fromPerToken {
+		growth := toPerToken.AmountOf(f.Denom).Sub(f.Amount).Quo(f.Amount)
+		aprs[i] = ReserveAPR{
+			Denom:      f.Denom,
+			Percentage: growth.MulInt64(100).MulInt64(BlocksPerYear).QuoInt64(blocks),
+		}
+	}
+
+	return QueryEffectiveAPR{
+		FromHeight:          from.Height,
+		ToHeight:            to.Height,
+		FromReservePerToken: fromPerToken,
+		ToReservePerToken:   toPerToken,
+		APRPercentages:      aprs,
+	}, true
+}
+
+// QuerySanityCheck reports whether the reserves resulting from a swapper
+// order would violate the bond's sanity rate. The distance from the band is
+// how far the resulting exchange rate lies outside of the allowed band of
+// rates, and is zero if the rate is within the band or if the bond has no
+// sanity rate (in which case the sanity rate is never violated).
+type QuerySanityCheck struct {
+	NewReserves        sdk.Coins `json:"new_reserves" yaml:"new_reserves"`
+	ExchangeRate       sdk.Dec   `json:"exchange_rate" yaml:"exchange_rate"`
+	MinRate            sdk.Dec   `json:"min_rate" yaml:"min_rate"`
+	MaxRate            sdk.Dec   `json:"max_rate" yaml:"max_rate"`
+	ViolatesSanityRate bool      `json:"violates_sanity_rate" yaml:"violates_sanity_rate"`
+	DistanceFromBand   sdk.Dec   `json:"distance_from_band" yaml:"distance_from_band"`
+}
+
+func NewQuerySanityCheck(bond Bond, newReserves sdk.Coins) QuerySanityCheck {
+	exchangeRate := bond.GetReservesExchangeRate(newReserves)
+	minRate, maxRate := bond.GetSanityRateBand()
+
+	distance := sdk.ZeroDec()
+	if !bond.SanityRate.IsZero() {
+		if exchangeRate.LT(minRate) {
+			distance = minRate.Sub(exchangeRate)
+		} else if exchangeRate.GT(maxRate) {
+			distance = exchangeRate.Sub(maxRate)
+		}
+	}
+
+	return QuerySanityCheck{
+		NewReserves:        newReserves,
+		ExchangeRate:       exchangeRate,
+		MinRate:            minRate,
+		MaxRate:            maxRate,
+		ViolatesSanityRate: bond.ReservesViolateSanityRate(newReserves),
+		DistanceFromBand:   distance,
+	}
+}
+
+// QueryEffectiveBatchBlocks reports the number of blocks of a bond's batches,
+// i.e. its batch blocks plus any extra blocks by which its batches are
+// currently lengthened due to consecutive stressed batches.
+type QueryEffectiveBatchBlocks struct {
+	BatchBlocks          sdk.Uint `json:"batch_blocks" yaml:"batch_blocks"`
+	ExtraBlocks          uint64   `json:"extra_blocks" yaml:"extra_blocks"`
+	EffectiveBatchBlocks sdk.Uint `json:"effective_batch_blocks" yaml:"effective_batch_blocks"`
+	StressedBatches      uint64   `json:"stressed_batches" yaml:"stressed_batches"`
+}
+
+func NewQueryEffectiveBatchBlocks(bond Bond, stress BatchStress) QueryEffectiveBatchBlocks {
+	return QueryEffectiveBatchBlocks{
+		BatchBlocks:          bond.BatchBlocks,
+		ExtraBlocks:          stress.ExtraBlocks,
+		EffectiveBatchBlocks: stress.EffectiveBatchBlocks(bond.BatchBlocks),
+		StressedBatches:      stress.StressedBatches,
+	}
+}
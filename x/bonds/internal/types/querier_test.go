@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -22,3 +23,73 @@ func TestStringMessageForQueryBondIsAsExpected(t *testing.T) {
 
 	require.Equal(t, expectedResult, b.String())
 }
+
+func TestNewQueryPriceImpact(t *testing.T) {
+	pre := sdk.DecCoins{
+		sdk.NewInt64DecCoin("res", 100),
+		sdk.NewInt64DecCoin("rez", 0),
+	}
+	post := sdk.DecCoins{
+		sdk.NewInt64DecCoin("res", 80),
+		sdk.NewInt64DecCoin("rez", 50),
+	}
+
+	result := NewQueryPriceImpact(pre, post)
+	require.Equal(t, pre, result.PreTradePrices)
+	require.Equal(t, post, result.PostTradePrices)
+
+	// Zero pre-trade price gives zero impact
+	expectedImpacts := []PriceImpact{
+		{Denom: "res", Percentage: sdk.NewDec(-20)},
+		{Denom: "rez", Percentage: sdk.ZeroDec()},
+	}
+	require.Equal(t, expectedImpacts, result.ImpactPercentages)
+}
+
+func TestNewQueryEffectiveAPR(t *testing.T) {
+	history := BondHistory{
+		{Height: 10, Supply: sdk.NewInt64Coin(initToken, 0), Reserve: nil},
+		{Height: 100, Supply: sdk.NewInt64Coin(initToken, 100),
+			Reserve: sdk.NewCoins(sdk.NewInt64Coin("res", 1000))},
+		{Height: 100 + BlocksPerYear/2, Supply: sdk.NewInt64Coin(initToken, 200),
+			Reserve: sdk.NewCoins(sdk.NewInt64Coin("res", 2200), sdk.NewInt64Coin("rez", 50))},
+	}
+
+	// Snapshots without a supply are skipped
+	result, ok := NewQueryEffectiveAPR(history)
+	require.True(t, ok)
+	require.Equal(t, int64(100), result.FromHeight)
+	require.Equal(t, 100+int64(BlocksPerYear/2), result.ToHeight)
+	require.Equal(t, sdk.DecCoins{sdk.NewInt64DecCoin("res", 10)}, result.FromReservePerToken)
+
+	// Reserve per token grew by 10% in half a year, and rez did not back the
+	// bond token at the start of the history so it has no APR
+	expectedAPRs := []ReserveAPR{{Denom: "res", Percentage: sdk.NewDec(20)}}
+	require.Equal(t, expectedAPRs, result.APRPercentages)
+
+	// Not enough history with a supply
+	_, ok = NewQueryEffectiveAPR(history[:2])
+	require.False(t, ok)
+	_, ok = NewQueryEffectiveAPR(BondHistory{})
+	require.False(t, ok)
+}
+
+func TestNewQueryBatchOrdersPagination(t *testing.T) {
+	batch := NewBatch(initToken, sdk.OneUint())
+	for i := int64(1); i <= 5; i++ {
+		batch.Sells = append(batch.Sells,
+			NewSellOrder(initCreator, sdk.NewInt64Coin(initToken, i)))
+	}
+
+	// Last page is partially filled
+	result := NewQueryBatchOrders(batch, NewQueryBatchOrdersParams(3, 2, "", nil, ""))
+	require.Equal(t, uint64(5), result.Total)
+	require.Len(t, result.Orders, 1)
+	require.Equal(t, uint64(4), result.Orders[0].Index)
+	require.Equal(t, BatchOrderStatusPending, result.Orders[0].Status)
+
+	// Page beyond the last page is empty
+	result = NewQueryBatchOrders(batch, NewQueryBatchOrdersParams(4, 2, "", nil, ""))
+	require.Equal(t, uint64(5), result.Total)
+	require.Empty(t, result.Orders)
+}
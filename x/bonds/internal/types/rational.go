@@ -0,0 +1,132 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"math/big"
+	"strings"
+)
+
+// decPrecisionMultiplier is 10^Precision, i.e. the denominator of every sdk.Dec
+var decPrecisionMultiplier = new(big.Int).Exp(big.NewInt(10), big.NewInt(sdk.Precision), nil)
+
+// Rational is an exact fraction, used for function parameters that cannot be
+// represented exactly as an sdk.Dec (e.g. an exponent of 1/3). A Rational is
+// always stored in its reduced form, with a positive denominator.
+type Rational struct {
+	Numerator   sdk.Int `json:"numerator" yaml:"numerator"`
+	Denominator sdk.Int `json:"denominator" yaml:"denominator"`
+}
+
+// NewRational returns the reduced fraction numerator/denominator. It panics
+// if the denominator is zero.
+func NewRational(numerator, denominator sdk.Int) Rational {
+	if denominator.IsZero() {
+		panic("rational with zero denominator")
+	}
+	r := new(big.Rat).SetFrac(numerator.BigInt(), denominator.BigInt())
+	return newRationalFromBigRat(r)
+}
+
+// NewRationalFromDec returns the fraction that is exactly equal to the dec
+func NewRationalFromDec(dec sdk.Dec) Rational {
+	r, ok := new(big.Rat).SetString(dec.String())
+	if !ok {
+		panic(fmt.Sprintf("could not convert %s to a rational", dec))
+	}
+	return newRationalFromBigRat(r)
+}
+
+func newRationalFromBigRat(r *big.Rat) Rational {
+	return Rational{
+		Numerator:   sdk.NewIntFromBigInt(r.Num()),
+		Denominator: sdk.NewIntFromBigInt(r.Denom()),
+	}
+}
+
+// ParseRational parses a fraction of the form "1/3"
+func ParseRational(str string) (Rational, error) {
+	parts := strings.Split(str, "/")
+	if len(parts) != 2 {
+		return Rational{}, fmt.Errorf("%s is not a fraction", str)
+	}
+	numerator, ok := sdk.NewIntFromString(strings.TrimSpace(parts[0]))
+	if !ok {
+		return Rational{}, fmt.Errorf("invalid numerator in %s", str)
+	}
+	denominator, ok := sdk.NewIntFromString(strings.TrimSpace(parts[1]))
+	if !ok {
+		return Rational{}, fmt.Errorf("invalid denominator in %s", str)
+	} else if denominator.IsZero() {
+		return Rational{}, fmt.Errorf("zero denominator in %s", str)
+	}
+	return NewRational(numerator, denominator), nil
+}
+
+// Validate checks that the fraction is in its reduced form, with a positive
+// denominator, as it would be if it was created using NewRational.
+func (r Rational) Validate() error {
+	if r.Numerator == (sdk.Int{}) || r.Denominator == (sdk.Int{}) {
+		return sdkerrors.Wrap(ErrInvalidFunctionParameter, "rational cannot be empty")
+	} else if !r.Denominator.IsPositive() {
+		return sdkerrors.Wrap(ErrInvalidFunctionParameter, "rational denominator must be positive")
+	}
+
+	reduced := NewRational(r.Numerator, r.Denominator)
+	if !r.Numerator.Equal(reduced.Numerator) || !r.Denominator.Equal(reduced.Denominator) {
+		return sdkerrors.Wrapf(ErrInvalidFunctionParameter, "rational %s is not reduced", r)
+	}
+	return nil
+}
+
+func (r Rational) bigRat() *big.Rat {
+	return new(big.Rat).SetFrac(r.Numerator.BigInt(), r.Denominator.BigInt())
+}
+
+// Dec returns the fraction as an sdk.Dec, truncated to sdk.Precision decimal
+// places. This is where the precision of fractions such as 1/3 is lost, so it
+// should only be used once exact arithmetic is no longer feasible.
+func (r Rational) Dec() sdk.Dec {
+	scaled := new(big.Int).Mul(r.Numerator.BigInt(), decPrecisionMultiplier)
+	return sdk.NewDecFromBigIntWithPrec(scaled.Quo(scaled, r.Denominator.BigInt()), sdk.Precision)
+}
+
+func (r Rational) Add(r2 Rational) Rational {
+	return newRationalFromBigRat(new(big.Rat).Add(r.bigRat(), r2.bigRat()))
+}
+
+func (r Rational) Sub(r2 Rational) Rational {
+	return newRationalFromBigRat(new(big.Rat).Sub(r.bigRat(), r2.bigRat()))
+}
+
+func (r Rational) Mul(r2 Rational) Rational {
+	return newRationalFromBigRat(new(big.Rat).Mul(r.bigRat(), r2.bigRat()))
+}
+
+func (r Rational) MulInt(i sdk.Int) Rational {
+	return r.Mul(NewRational(i, sdk.OneInt()))
+}
+
+func (r Rational) QuoInt(i sdk.Int) Rational {
+	return r.Mul(NewRational(sdk.OneInt(), i))
+}
+
+// Ceil returns the smallest integer that is not less than the fraction
+func (r Rational) Ceil() sdk.Int {
+	quo, rem := new(big.Int).QuoRem(r.Numerator.BigInt(), r.Denominator.BigInt(), new(big.Int))
+	if rem.Sign() > 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return sdk.NewIntFromBigInt(quo)
+}
+
+func (r Rational) Cmp(r2 Rational) int    { return r.bigRat().Cmp(r2.bigRat()) }
+func (r Rational) Equal(r2 Rational) bool { return r.Cmp(r2) == 0 }
+func (r Rational) IsNegative() bool       { return r.Numerator.IsNegative() }
+func (r Rational) IsPositive() bool       { return r.Numerator.IsPositive() }
+func (r Rational) IsInteger() bool        { return r.bigRat().IsInt() }
+
+func (r Rational) String() string {
+	return fmt.Sprintf("%s/%s", r.Numerator, r.Denominator)
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewRationalIsReduced(t *testing.T) {
+	r := NewRational(sdk.NewInt(-4), sdk.NewInt(-6))
+	require.Equal(t, sdk.NewInt(2), r.Numerator)
+	require.Equal(t, sdk.NewInt(3), r.Denominator)
+	require.Nil(t, r.Validate())
+
+	r = NewRational(sdk.NewInt(4), sdk.NewInt(-6))
+	require.Equal(t, "-2/3", r.String())
+	require.True(t, r.IsNegative())
+
+	require.Panics(t, func() { NewRational(sdk.OneInt(), sdk.ZeroInt()) })
+}
+
+func TestRationalValidate(t *testing.T) {
+	testCases := []struct {
+		rational    Rational
+		expectError bool
+	}{
+		{Rational{sdk.NewInt(1), sdk.NewInt(3)}, false},
+		{Rational{sdk.NewInt(0), sdk.NewInt(1)}, false},
+		{Rational{}, true}, // empty
+		{Rational{sdk.NewInt(1), sdk.NewInt(0)}, true},    // zero denominator
+		{Rational{sdk.NewInt(1), sdk.NewInt(-3)}, true},   // negative denominator
+		{Rational{sdk.NewInt(2), sdk.NewInt(6)}, true},    // not reduced
+		{Rational{sdk.NewInt(0), sdk.NewInt(2)}, true},    // not reduced
+		{Rational{sdk.NewInt(-1), sdk.NewInt(3)}, false},  // negative allowed
+		{Rational{sdk.NewInt(10), sdk.NewInt(1)}, false},  // integer allowed
+		{Rational{sdk.NewInt(-10), sdk.NewInt(1)}, false}, // negative integer allowed
+	}
+	for i, tc := range testCases {
+		err := tc.rational.Validate()
+		if tc.expectError {
+			require.Error(t, err, "test case #%d", i)
+		} else {
+			require.Nil(t, err, "test case #%d", i)
+		}
+	}
+}
+
+func TestParseRational(t *testing.T) {
+	r, err := ParseRational("2/6")
+	require.Nil(t, err)
+	require.Equal(t, NewRational(sdk.NewInt(1), sdk.NewInt(3)), r)
+
+	for _, invalid := range []string{"", "1", "1/", "/3", "1/0", "1/2/3", "0.5/2"} {
+		_, err = ParseRational(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestRationalDecIsTruncated(t *testing.T) {
+	third := NewRational(sdk.NewInt(1), sdk.NewInt(3))
+	require.Equal(t, sdk.MustNewDecFromStr("0.333333333333333333"), third.Dec())
+
+	twoThirds := NewRational(sdk.NewInt(2), sdk.NewInt(3))
+	require.Equal(t, sdk.MustNewDecFromStr("0.666666666666666666"), twoThirds.Dec())
+
+	minusTwoThirds := NewRational(sdk.NewInt(-2), sdk.NewInt(3))
+	require.Equal(t, sdk.MustNewDecFromStr("-0.666666666666666666"), minusTwoThirds.Dec())
+}
+
+func TestNewRationalFromDecIsExact(t *testing.T) {
+	r := NewRationalFromDec(sdk.MustNewDecFromStr("1.25"))
+	require.Equal(t, NewRational(sdk.NewInt(5), sdk.NewInt(4)), r)
+	require.Equal(t, sdk.MustNewDecFromStr("1.25"), r.Dec())
+
+	r = NewRationalFromDec(sdk.MustNewDecFromStr("-0.000000000000000001"))
+	require.Equal(t, "-1/1000000000000000000", r.String())
+}
+
+func TestRationalArithmetic(t *testing.T) {
+	third := NewRational(sdk.NewInt(1), sdk.NewInt(3))
+	one := NewRational(sdk.OneInt(), sdk.OneInt())
+
+	require.True(t, third.Add(third).Add(third).Equal(one))
+	require.True(t, one.Sub(third).Equal(NewRational(sdk.NewInt(2), sdk.NewInt(3))))
+	require.True(t, third.MulInt(sdk.NewInt(3)).IsInteger())
+	require.True(t, third.QuoInt(sdk.NewInt(3)).Equal(NewRational(sdk.OneInt(), sdk.NewInt(9))))
+	require.Equal(t, -1, third.Cmp(one))
+
+	require.Equal(t, sdk.NewInt(1), third.Ceil())
+	require.Equal(t, sdk.NewInt(3), third.MulInt(sdk.NewInt(9)).Ceil())
+	require.True(t, third.Sub(one).Ceil().IsZero())
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RebuildIndexesReport summarises the derived data regenerated from the
+// bonds and batches in state by a rebuild of the module's indexes, i.e. the
+// bond search index, the module stats, and the bond histories.
+type RebuildIndexesReport struct {
+	Bonds               uint64      `json:"bonds" yaml:"bonds"`
+	SearchIndexEntries  uint64      `json:"search_index_entries" yaml:"search_index_entries"`
+	SearchIndexChanges  uint64      `json:"search_index_changes" yaml:"search_index_changes"`
+	StatsBefore         ModuleStats `json:"stats_before" yaml:"stats_before"`
+	StatsAfter          ModuleStats `json:"stats_after" yaml:"stats_after"`
+	SeededBondHistories []string    `json:"seeded_bond_histories" yaml:"seeded_bond_histories"`
+}
+
+// HasChanges returns true if the rebuild changed any of the derived data
+func (r RebuildIndexesReport) HasChanges() bool {
+	return r.SearchIndexChanges > 0 || len(r.SeededBondHistories) > 0 ||
+		r.StatsBefore.String() != r.StatsAfter.String()
+}
+
+func (r RebuildIndexesReport) String() string {
+	return fmt.Sprintf(`Bonds:                 %d
+Search Index Entries:  %d (%d changed)
+Seeded Bond Histories: %d (%s)
+Stats Before:
+%s
+Stats After:
+%s`,
+		r.Bonds, r.SearchIndexEntries, r.SearchIndexChanges,
+		len(r.SeededBondHistories), strings.Join(r.SeededBondHistories, ", "),
+		r.StatsBefore, r.StatsAfter)
+}
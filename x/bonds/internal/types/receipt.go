@@ -0,0 +1,89 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"strconv"
+	"strings"
+)
+
+// OrderReceipt is a record of a submitted order, identified by a receipt that
+// binds the bond token, order ID, order type, account, amount, and limits of
+// the order. The limits of an order are the max prices for a buy and the to
+// token for a swap (sells have no limits).
+type OrderReceipt struct {
+	Receipt   string         `json:"receipt" yaml:"receipt"`
+	OrderID   uint64         `json:"order_id" yaml:"order_id"`
+	OrderType string         `json:"order_type" yaml:"order_type"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	Address   sdk.AccAddress `json:"address" yaml:"address"`
+	Amount    sdk.Coin       `json:"amount" yaml:"amount"`
+	MaxPrices sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	ToToken   string         `json:"to_token" yaml:"to_token"`
+	Height    int64          `json:"height" yaml:"height"`
+}
+
+func NewBuyOrderReceipt(orderID uint64, address sdk.AccAddress, amount sdk.Coin,
+	maxPrices sdk.Coins, height int64) OrderReceipt {
+	return newOrderReceipt(orderID, AttributeValueBuyOrder, amount.Denom,
+		address, amount, maxPrices, "", height)
+}
+
+func NewSellOrderReceipt(orderID uint64, address sdk.AccAddress, amount sdk.Coin,
+	height int64) OrderReceipt {
+	return newOrderReceipt(orderID, AttributeValueSellOrder, amount.Denom,
+		address, amount, nil, "", height)
+}
+
+func NewSwapOrderReceipt(orderID uint64, bondToken string, address sdk.AccAddress,
+	from sdk.Coin, toToken string, height int64) OrderReceipt {
+	return newOrderReceipt(orderID, AttributeValueSwapOrder, bondToken,
+		address, from, nil, toToken, height)
+}
+
+func newOrderReceipt(orderID uint64, orderType, bondToken string, address sdk.AccAddress,
+	amount sdk.Coin, maxPrices sdk.Coins, toToken string, height int64) OrderReceipt {
+	receipt := OrderReceipt{
+		OrderID:   orderID,
+		OrderType: orderType,
+		BondToken: bondToken,
+		Address:   address,
+		Amount:    amount,
+		MaxPrices: maxPrices,
+		ToToken:   toToken,
+		Height:    height,
+	}
+	receipt.Receipt = receipt.GetReceiptHash()
+	return receipt
+}
+
+// GetReceiptHash returns the hex-encoded SHA-256 hash of the bond token, order
+// ID, order type, address, amount, and limits of the order, joined by slashes
+// in this order (e.g. "abc/1/buy/cosmos1.../10abc/100res" for a buy order with
+// ID 1). The height is not included, so that the receipt can be recomputed by
+// anyone who knows the order details and ID.
+func (r OrderReceipt) GetReceiptHash() string {
+	limits := r.ToToken
+	if r.OrderType == AttributeValueBuyOrder {
+		limits = r.MaxPrices.String()
+	}
+
+	preimage := strings.Join([]string{
+		r.BondToken,
+		strconv.FormatUint(r.OrderID, 10),
+		r.OrderType,
+		r.Address.String(),
+		r.Amount.String(),
+		limits,
+	}, "/")
+
+	hash := sha256.Sum256([]byte(preimage))
+	return hex.EncodeToString(hash[:])
+}
+
+// IsValidReceipt checks that the receipt is a hex-encoded SHA-256 hash
+func IsValidReceipt(receipt string) bool {
+	bz, err := hex.DecodeString(receipt)
+	return err == nil && len(bz) == sha256.Size
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOrderReceiptIsDeterministic(t *testing.T) {
+	amount := sdk.NewInt64Coin(initToken, 10)
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+
+	receipt1 := NewBuyOrderReceipt(1, initCreator, amount, maxPrices, 5)
+	receipt2 := NewBuyOrderReceipt(1, initCreator, amount, maxPrices, 6)
+	require.Equal(t, receipt1.Receipt, receipt2.Receipt)
+	require.True(t, IsValidReceipt(receipt1.Receipt))
+
+	// Receipt is the hash of the order details joined by slashes
+	preimage := initToken + "/1/buy/" + initCreator.String() + "/" +
+		amount.String() + "/" + maxPrices.String()
+	hash := sha256.Sum256([]byte(preimage))
+	require.Equal(t, hex.EncodeToString(hash[:]), receipt1.Receipt)
+}
+
+func TestOrderReceiptBindsOrderDetails(t *testing.T) {
+	amount := sdk.NewInt64Coin(initToken, 10)
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	receipt := NewBuyOrderReceipt(1, initCreator, amount, maxPrices, 5).Receipt
+
+	otherAmount := sdk.NewInt64Coin(initToken, 11)
+	otherMaxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 101))
+	otherAddress := sdk.AccAddress([]byte("someotheraddress"))
+
+	require.NotEqual(t, receipt, NewBuyOrderReceipt(
+		2, initCreator, amount, maxPrices, 5).Receipt)
+	require.NotEqual(t, receipt, NewBuyOrderReceipt(
+		1, otherAddress, amount, maxPrices, 5).Receipt)
+	require.NotEqual(t, receipt, NewBuyOrderReceipt(
+		1, initCreator, otherAmount, maxPrices, 5).Receipt)
+	require.NotEqual(t, receipt, NewBuyOrderReceipt(
+		1, initCreator, amount, otherMaxPrices, 5).Receipt)
+	require.NotEqual(t, receipt, NewSellOrderReceipt(
+		1, initCreator, amount, 5).Receipt)
+}
+
+func TestSwapOrderReceiptBindsToToken(t *testing.T) {
+	from := sdk.NewInt64Coin(reserveToken, 10)
+
+	receipt1 := NewSwapOrderReceipt(1, initToken, initCreator, from, "rez", 5)
+	receipt2 := NewSwapOrderReceipt(1, initToken, initCreator, from, "rex", 5)
+	require.Equal(t, initToken, receipt1.BondToken)
+	require.NotEqual(t, receipt1.Receipt, receipt2.Receipt)
+}
+
+func TestIsValidReceipt(t *testing.T) {
+	hash := sha256.Sum256([]byte("dummy"))
+	require.True(t, IsValidReceipt(hex.EncodeToString(hash[:])))
+	require.False(t, IsValidReceipt(""))
+	require.False(t, IsValidReceipt("abc"))
+	require.False(t, IsValidReceipt(hex.EncodeToString(hash[:31])))
+	require.False(t, IsValidReceipt("zz"+hex.EncodeToString(hash[:31])))
+}
+
+func TestValidateGenesisChecksOrderReceipts(t *testing.T) {
+	amount := sdk.NewInt64Coin(initToken, 10)
+	receipt := NewSellOrderReceipt(3, initCreator, amount, 5)
+
+	genesis := DefaultGenesisState()
+	genesis.NextOrderID = 4
+	genesis.OrderReceipts = []OrderReceipt{receipt}
+	require.Nil(t, ValidateGenesis(genesis))
+
+	// The order ID must be less than the next order ID
+	genesis.NextOrderID = 3
+	require.True(t, ErrInvalidOrderReceipt.Is(ValidateGenesis(genesis)))
+
+	// The receipt must match the order details
+	genesis.NextOrderID = 4
+	receipt.Amount = sdk.NewInt64Coin(initToken, 11)
+	genesis.OrderReceipts = []OrderReceipt{receipt}
+	require.True(t, ErrInvalidOrderReceipt.Is(ValidateGenesis(genesis)))
+}
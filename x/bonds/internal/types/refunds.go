@@ -0,0 +1,49 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Refund is the total amount refunded to an account from a bond's escrow
+// account during the settlement of the bond's batch, along with the number of
+// (cancelled or partially filled) orders that the refund is for.
+type Refund struct {
+	Address sdk.AccAddress `json:"address" yaml:"address"`
+	Amount  sdk.Coins      `json:"amount" yaml:"amount"`
+	Orders  uint64         `json:"orders" yaml:"orders"`
+}
+
+// PendingRefunds are the refunds accumulated while a bond's batch is being
+// settled, which are paid out once the settlement is done, so that each
+// account is sent a single refund per batch. Refunds are kept in the order in
+// which each account was first refunded, which is the order they are paid in.
+type PendingRefunds struct {
+	Token   string   `json:"token" yaml:"token"`
+	Refunds []Refund `json:"refunds" yaml:"refunds"`
+}
+
+func NewPendingRefunds(token string) PendingRefunds {
+	return PendingRefunds{
+		Token:   token,
+		Refunds: nil,
+	}
+}
+
+// Add returns the pending refunds with the amount added to the account's
+// refund, or with a new refund for the account if it has none yet.
+func (p PendingRefunds) Add(address sdk.AccAddress, amount sdk.Coins) PendingRefunds {
+	refunds := append([]Refund{}, p.Refunds...)
+	for i, r := range refunds {
+		if r.Address.Equals(address) {
+			refunds[i].Amount = r.Amount.Add(amount...)
+			refunds[i].Orders += 1
+			return PendingRefunds{Token: p.Token, Refunds: refunds}
+		}
+	}
+	refunds = append(refunds, Refund{
+		Address: address,
+		Amount:  amount,
+		Orders:  1,
+	})
+	return PendingRefunds{Token: p.Token, Refunds: refunds}
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPendingRefundsAggregatedPerAddressInOrder(t *testing.T) {
+	refunds := NewPendingRefunds(token).
+		Add(initFeeAddress, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))).
+		Add(initCreator, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))).
+		Add(initFeeAddress, sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 20))).
+		Add(initFeeAddress, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1)))
+
+	// One refund per address, in the order each address was first refunded
+	require.Len(t, refunds.Refunds, 2)
+	require.Equal(t, initFeeAddress, refunds.Refunds[0].Address)
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 101),
+		sdk.NewInt64Coin(reserveToken2, 20),
+	), refunds.Refunds[0].Amount)
+	require.Equal(t, uint64(3), refunds.Refunds[0].Orders)
+
+	require.Equal(t, initCreator, refunds.Refunds[1].Address)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)), refunds.Refunds[1].Amount)
+	require.Equal(t, uint64(1), refunds.Refunds[1].Orders)
+}
+
+func TestPendingRefundsAddDoesNotModifyOriginal(t *testing.T) {
+	refunds := NewPendingRefunds(token).
+		Add(initCreator, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)))
+	_ = refunds.Add(initCreator, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5)))
+
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)), refunds.Refunds[0].Amount)
+	require.Equal(t, uint64(1), refunds.Refunds[0].Orders)
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SanityRateWindow records a swapper bond's sanity values at the start of
+// the current sanity rate window, against which the total change made to the
+// sanity values within the window is limited.
+type SanityRateWindow struct {
+	StartHeight            int64   `json:"start_height" yaml:"start_height"`
+	SanityRate             sdk.Dec `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage sdk.Dec `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+}
+
+func NewSanityRateWindow(startHeight int64, sanityRate,
+	sanityMarginPercentage sdk.Dec) SanityRateWindow {
+	return SanityRateWindow{
+		StartHeight:            startHeight,
+		SanityRate:             sanityRate,
+		SanityMarginPercentage: sanityMarginPercentage,
+	}
+}
+
+// Validate returns an error if the window starts at a negative height or if
+// its sanity values are unset or negative.
+func (w SanityRateWindow) Validate() error {
+	if w.StartHeight < 0 {
+		return fmt.Errorf("start height %d is negative", w.StartHeight)
+	} else if w.SanityRate.IsNil() || w.SanityRate.IsNegative() {
+		return fmt.Errorf("sanity rate %s is unset or negative", w.SanityRate)
+	} else if w.SanityMarginPercentage.IsNil() || w.SanityMarginPercentage.IsNegative() {
+		return fmt.Errorf("sanity margin percentage %s is unset or negative",
+			w.SanityMarginPercentage)
+	}
+	return nil
+}
+
+// HasEnded returns true if the window of the specified number of blocks has
+// ended at the specified height.
+func (w SanityRateWindow) HasEnded(height int64, windowBlocks uint64) bool {
+	return height >= w.StartHeight+int64(windowBlocks)
+}
+
+// CheckSanityValueChange returns an error if changing a sanity value from one
+// value to another changes it by more than the max percentage of the original
+// value. A zero original value can be changed to any value, since a zero
+// sanity rate disables the sanity check.
+func CheckSanityValueChange(name string, from, to, maxPercentage sdk.Dec) error {
+	if from.IsZero() {
+		return nil
+	}
+
+	change := to.Sub(from).Abs().Quo(from).MulInt64(100)
+	if change.GT(maxPercentage) {
+		return sdkerrors.Wrapf(ErrSanityRateChangeTooLarge,
+			"changing %s from %s to %s is a change of %s%%; max is %s%%",
+			name, from, to, change, maxPercentage)
+	}
+	return nil
+}
+
+// SanityRateWindowEntry is a bond's current sanity rate window along with the
+// bond's token, as included in the genesis state.
+type SanityRateWindowEntry struct {
+	BondToken string           `json:"bond_token" yaml:"bond_token"`
+	Window    SanityRateWindow `json:"window" yaml:"window"`
+}
+
+func NewSanityRateWindowEntry(bondToken string, window SanityRateWindow) SanityRateWindowEntry {
+	return SanityRateWindowEntry{
+		BondToken: bondToken,
+		Window:    window,
+	}
+}
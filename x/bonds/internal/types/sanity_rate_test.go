@@ -0,0 +1,62 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCheckSanityValueChange(t *testing.T) {
+	max := sdk.NewDec(5)
+	testCases := []struct {
+		from    string
+		to      string
+		isValid bool
+	}{
+		{"1", "1", true},
+		{"1", "1.05", true},
+		{"1", "0.95", true},
+		{"1", "1.051", false},
+		{"1", "0.949", false},
+		{"0", "1000", true},
+		{"1", "0", false},
+	}
+	for _, tc := range testCases {
+		err := CheckSanityValueChange("sanity rate", sdk.MustNewDecFromStr(tc.from),
+			sdk.MustNewDecFromStr(tc.to), max)
+		if tc.isValid {
+			require.Nil(t, err, tc.from+"->"+tc.to)
+		} else {
+			require.True(t, ErrSanityRateChangeTooLarge.Is(err), tc.from+"->"+tc.to)
+		}
+	}
+}
+
+func TestSanityRateWindowHasEnded(t *testing.T) {
+	window := NewSanityRateWindow(100, sdk.OneDec(), sdk.NewDec(10))
+
+	require.False(t, window.HasEnded(100, 10))
+	require.False(t, window.HasEnded(109, 10))
+	require.True(t, window.HasEnded(110, 10))
+}
+
+func TestValidateGenesisChecksSanityRateWindows(t *testing.T) {
+	genesis := DefaultGenesisState()
+	genesis.SanityRateWindows = []SanityRateWindowEntry{NewSanityRateWindowEntry(
+		initToken, NewSanityRateWindow(1, sdk.NewDec(2), sdk.NewDec(10)))}
+	require.Nil(t, ValidateGenesis(genesis))
+
+	// Window starting at a negative height
+	genesis.SanityRateWindows[0].Window.StartHeight = -1
+	require.Error(t, ValidateGenesis(genesis))
+	genesis.SanityRateWindows[0].Window.StartHeight = 1
+
+	// Window with a negative sanity rate
+	genesis.SanityRateWindows[0].Window.SanityRate = sdk.NewDec(-2)
+	require.Error(t, ValidateGenesis(genesis))
+
+	// Window with an unset sanity margin percentage
+	genesis.SanityRateWindows[0].Window.SanityRate = sdk.NewDec(2)
+	genesis.SanityRateWindows[0].Window.SanityMarginPercentage = sdk.Dec{}
+	require.Error(t, ValidateGenesis(genesis))
+}
@@ -0,0 +1,179 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ScheduledParamChange is a change to a bond's function parameters scheduled
+// by the bond's signers to take effect at a future block height, so that
+// traders are given advance notice of the change instead of being repriced
+// instantly. It is applied at the end of the block at the effective height
+// (i.e. after any batch due at that height is performed) unless cancelled.
+// If the change is interpolated, the bond's parameters are instead moved
+// linearly from the start parameters (the bond's parameters when the change
+// was scheduled) to the new parameters at the end of every block until then.
+type ScheduledParamChange struct {
+	BondToken               string         `json:"bond_token" yaml:"bond_token"`
+	FunctionParameters      FunctionParams `json:"function_parameters" yaml:"function_parameters"`
+	EffectiveHeight         int64          `json:"effective_height" yaml:"effective_height"`
+	ScheduledHeight         int64          `json:"scheduled_height" yaml:"scheduled_height"`
+	Interpolate             bool           `json:"interpolate" yaml:"interpolate"`
+	StartFunctionParameters FunctionParams `json:"start_function_parameters,omitempty" yaml:"start_function_parameters,omitempty"`
+}
+
+func NewScheduledParamChange(bondToken string, functionParameters FunctionParams,
+	effectiveHeight, scheduledHeight int64) ScheduledParamChange {
+	return ScheduledParamChange{
+		BondToken:          bondToken,
+		FunctionParameters: functionParameters,
+		EffectiveHeight:    effectiveHeight,
+		ScheduledHeight:    scheduledHeight,
+	}
+}
+
+// NewInterpolatedParamChange returns a scheduled change that moves the bond's
+// function parameters from the start parameters to the new parameters
+// gradually, rather than switching to the new parameters at once.
+func NewInterpolatedParamChange(bondToken string, startFunctionParameters,
+	functionParameters FunctionParams, effectiveHeight, scheduledHeight int64) ScheduledParamChange {
+	return ScheduledParamChange{
+		BondToken:               bondToken,
+		FunctionParameters:      functionParameters,
+		EffectiveHeight:         effectiveHeight,
+		ScheduledHeight:         scheduledHeight,
+		Interpolate:             true,
+		StartFunctionParameters: startFunctionParameters,
+	}
+}
+
+// IsDue returns true if the change should be applied at the specified height.
+func (c ScheduledParamChange) IsDue(height int64) bool {
+	return height >= c.EffectiveHeight
+}
+
+// ParamsAtHeight returns the function parameters that an interpolated change
+// sets at the specified height, i.e. each parameter's start value plus the
+// fraction of the way from the scheduled height to the effective height of
+// the difference to its new value. The new parameters are returned once the
+// change is due, and the start parameters before it was scheduled.
+func (c ScheduledParamChange) ParamsAtHeight(height int64) FunctionParams {
+	if c.IsDue(height) {
+		return c.FunctionParameters
+	} else if height <= c.ScheduledHeight {
+		return c.StartFunctionParameters
+	}
+
+	fraction := sdk.NewDec(height - c.ScheduledHeight).QuoInt64(
+		c.EffectiveHeight - c.ScheduledHeight)
+	startParams := c.StartFunctionParameters.AsMap()
+	params := make(FunctionParams, len(c.FunctionParameters))
+	for i, p := range c.FunctionParameters {
+		start := startParams[p.Param]
+		params[i] = NewFunctionParam(p.Param, start.Add(p.Value.Sub(start).Mul(fraction)))
+	}
+	return params
+}
+
+// CheckFunctionTypeAllowsParamChanges returns an error if the function
+// parameters of bonds with the specified function type cannot be changed. The
+// swapper function has no parameters and the augmented function's parameters
+// determine its hatch phase (and derived R0, S0, V0), so only the power,
+// sigmoid, exponential, logarithmic, polynomial, and Bancor functions allow
+// parameter changes.
+func CheckFunctionTypeAllowsParamChanges(functionType string) error {
+	if functionType != PowerFunction && functionType != SigmoidFunction &&
+		functionType != ExponentialFunction && functionType != LogarithmicFunction &&
+		functionType != PolynomialFunction && functionType != BancorFunction {
+		return sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, functionType)
+	}
+	return nil
+}
+
+// ValidateScheduledParamChange checks that the scheduled parameter change is
+// valid for the bond, assuming that the change is being scheduled at height.
+func ValidateScheduledParamChange(bond Bond, change ScheduledParamChange, height int64) error {
+	if err := CheckFunctionTypeAllowsParamChanges(bond.FunctionType); err != nil {
+		return err
+	} else if err := ValidateFunctionParamsForBond(bond, change.FunctionParameters); err != nil {
+		return err
+	} else if err := CheckParamsBackedByReserve(bond, change.FunctionParameters); err != nil {
+		return err
+	} else if change.EffectiveHeight <= height {
+		return sdkerrors.Wrapf(ErrInvalidEffectiveHeight,
+			"%d is not after the current height %d", change.EffectiveHeight, height)
+	} else if change.Interpolate {
+		return checkParamsCanBeInterpolated(bond.FunctionType,
+			change.StartFunctionParameters, change.FunctionParameters)
+	}
+	return nil
+}
+
+// checkParamsCanBeInterpolated checks that the bond's function parameters can
+// be moved gradually from the start parameters to the new parameters, i.e.
+// that both have the same parameters (e.g. a polynomial function cannot gain
+// coefficients) and that no parameter that must be an integer changes, which
+// only applies to the power function's n.
+func checkParamsCanBeInterpolated(functionType string, start, target FunctionParams) error {
+	startParams := start.AsMap()
+	if len(startParams) != len(target) {
+		return sdkerrors.Wrap(ErrCannotInterpolateFunctionParams,
+			"new function parameters must have the same parameters as the current ones")
+	}
+	for _, p := range target {
+		startValue, ok := startParams[p.Param]
+		if !ok {
+			return sdkerrors.Wrapf(ErrCannotInterpolateFunctionParams,
+				"current function parameters do not include %s", p.Param)
+		} else if functionType == PowerFunction && p.Param == "n" && !p.Value.Equal(startValue) {
+			return sdkerrors.Wrap(ErrCannotInterpolateFunctionParams,
+				"FunctionParams:n must be an integer so it cannot be changed gradually")
+		}
+	}
+	return nil
+}
+
+// ValidateFunctionParamsForBond checks that the function parameters are valid
+// for the bond's function type and can be evaluated up to its max supply.
+func ValidateFunctionParamsForBond(bond Bond, fps FunctionParams) error {
+	if err := fps.Validate(bond.FunctionType); err != nil {
+		return err
+	}
+	return checkParamsWithinMaxSupply(bond, fps)
+}
+
+// CheckParamsBackedByReserve checks that the bond's current reserve covers the
+// reserve that the bond's function requires at the bond's current supply with
+// the function parameters, i.e. ReserveAtSupply(supply) <= CurrentReserve in
+// every reserve token, so that a parameter change never leaves the reserve
+// unable to pay out sells of the whole supply.
+func CheckParamsBackedByReserve(bond Bond, fps FunctionParams) error {
+	bond.FunctionParameters = fps
+	bond = bond.WithFunctionParamsCache(nil)
+
+	required := bond.ReserveAtSupply(bond.CurrentSupply.Amount).Ceil().TruncateInt()
+	for _, rt := range bond.ReserveTokens {
+		if bond.CurrentReserve.AmountOf(rt).LT(required) {
+			return sdkerrors.Wrapf(ErrParamsNotBackedByReserve,
+				"reserve of %s%s at supply %s is less than the %s%s required",
+				bond.CurrentReserve.AmountOf(rt), rt, bond.CurrentSupply, required, rt)
+		}
+	}
+	return nil
+}
+
+// checkParamsWithinMaxSupply checks that the bond's function can be evaluated
+// with the function parameters up to the bond's max supply, which only limits
+// the parameters of the exponential, polynomial, and Bancor functions.
+func checkParamsWithinMaxSupply(bond Bond, fps FunctionParams) error {
+	switch bond.FunctionType {
+	case ExponentialFunction:
+		return CheckExponentialMaxSupply(fps.AsMap(), bond.MaxSupply.Amount)
+	case PolynomialFunction:
+		return CheckPolynomialMaxSupply(fps.AsMap(), bond.MaxSupply.Amount)
+	case BancorFunction:
+		return CheckBancorMaxSupply(fps.AsMap(), bond.MaxSupply.Amount)
+	default:
+		return nil
+	}
+}
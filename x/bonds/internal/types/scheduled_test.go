@@ -0,0 +1,72 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInterpolatedParamChangeParamsAtHeight(t *testing.T) {
+	start := functionParametersPower()
+	end := FunctionParams{
+		NewFunctionParam("m", sdk.NewDec(2)),
+		NewFunctionParam("n", sdk.NewDec(2)),
+		NewFunctionParam("c", sdk.NewDec(200))}
+	change := NewInterpolatedParamChange(initToken, start, end, 20, 10)
+
+	require.Equal(t, start, change.ParamsAtHeight(5))
+	require.Equal(t, start, change.ParamsAtHeight(10))
+	require.Equal(t, FunctionParams{
+		NewFunctionParam("m", sdk.NewDec(7)),
+		NewFunctionParam("n", sdk.NewDec(2)),
+		NewFunctionParam("c", sdk.NewDec(150))}, change.ParamsAtHeight(15))
+	require.Equal(t, end, change.ParamsAtHeight(20))
+	require.Equal(t, end, change.ParamsAtHeight(25))
+}
+
+func TestValidateInterpolatedParamChange(t *testing.T) {
+	bond := getValidBond()
+
+	// Same parameters with the same n is valid
+	end := FunctionParams{
+		NewFunctionParam("m", sdk.NewDec(2)),
+		NewFunctionParam("n", sdk.NewDec(2)),
+		NewFunctionParam("c", sdk.NewDec(200))}
+	change := NewInterpolatedParamChange(initToken, bond.FunctionParameters, end, 20, 10)
+	require.NoError(t, ValidateScheduledParamChange(bond, change, 10))
+
+	// Changing n is only valid if the change is not interpolated
+	end[1] = NewFunctionParam("n", sdk.NewDec(3))
+	change = NewInterpolatedParamChange(initToken, bond.FunctionParameters, end, 20, 10)
+	err := ValidateScheduledParamChange(bond, change, 10)
+	require.Error(t, err)
+	require.True(t, ErrCannotInterpolateFunctionParams.Is(err))
+	require.NoError(t, ValidateScheduledParamChange(bond,
+		NewScheduledParamChange(initToken, end, 20, 10), 10))
+}
+
+func TestCheckParamsBackedByReserve(t *testing.T) {
+	bond := getValidBond()
+
+	// Reserve at a supply of 10 with m:12,n:2,c:100 is (12/3)*10^3 + 100*10 = 5000
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 10)
+	bond.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	require.NoError(t, CheckParamsBackedByReserve(bond, bond.FunctionParameters))
+
+	// Lower parameters are backed by the reserve
+	lower := FunctionParams{
+		NewFunctionParam("m", sdk.NewDec(10)),
+		NewFunctionParam("n", sdk.NewDec(2)),
+		NewFunctionParam("c", sdk.NewDec(50))}
+	require.NoError(t, CheckParamsBackedByReserve(bond, lower))
+
+	// Higher parameters require (12/3)*10^3 + 101*10 = 5010 > 5000
+	higher := FunctionParams{
+		NewFunctionParam("m", sdk.NewDec(12)),
+		NewFunctionParam("n", sdk.NewDec(2)),
+		NewFunctionParam("c", sdk.NewDec(101))}
+	err := CheckParamsBackedByReserve(bond, higher)
+	require.True(t, ErrParamsNotBackedByReserve.Is(err))
+	err = ValidateScheduledParamChange(bond, NewScheduledParamChange(initToken, higher, 20, 10), 10)
+	require.True(t, ErrParamsNotBackedByReserve.Is(err))
+}
@@ -0,0 +1,35 @@
+package types
+
+import "strings"
+
+const (
+	// DefaultBondSearchLimit is the number of results returned by a bond
+	// search if no limit is specified
+	DefaultBondSearchLimit = 20
+
+	// MaxBondSearchLimit is the maximum number of results returned by a bond
+	// search, to bound the amount of work done by a single query
+	MaxBondSearchLimit = 100
+)
+
+// BondSearchIndexEntry holds a bond's name and description in lowercase, and
+// is kept in a separate index so that bonds can be searched without loading
+// (and unmarshalling) every bond in the store.
+type BondSearchIndexEntry struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+func NewBondSearchIndexEntry(name, description string) BondSearchIndexEntry {
+	return BondSearchIndexEntry{
+		Name:        strings.ToLower(name),
+		Description: strings.ToLower(description),
+	}
+}
+
+// Matches returns true if the (case-insensitive) query is a substring of the
+// indexed name or description.
+func (e BondSearchIndexEntry) Matches(query string) bool {
+	query = strings.ToLower(query)
+	return strings.Contains(e.Name, query) || strings.Contains(e.Description, query)
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxNoOfSigners is the max number of signers of a bond
+const MaxNoOfSigners = 20
+
+// SignerThreshold allows a subset of a bond's signers to authorise the bond's
+// administrative messages, e.g. any 2 of 3 signers. Each signer has the weight
+// at its index in Weights, or a weight of 1 if no weights are given, and the
+// signers of a message must be distinct signers of the bond whose weights add
+// up to at least the Threshold. A zero Threshold (the default) requires all
+// of the bond's signers to sign, in the order in which they are listed.
+type SignerThreshold struct {
+	Threshold uint64   `json:"threshold" yaml:"threshold"`
+	Weights   []uint64 `json:"weights" yaml:"weights"`
+}
+
+func NewSignerThreshold(threshold uint64, weights []uint64) SignerThreshold {
+	return SignerThreshold{
+		Threshold: threshold,
+		Weights:   weights,
+	}
+}
+
+// IsZero returns true if all of the bond's signers are required to sign
+func (t SignerThreshold) IsZero() bool {
+	return t.Threshold == 0
+}
+
+// Validate returns an error if the threshold is not valid for the number of
+// signers, i.e. if the weights (if any) are not one positive weight per
+// signer, or if the threshold exceeds the signers' total weight.
+func (t SignerThreshold) Validate(noOfSigners int) error {
+	if t.IsZero() {
+		if len(t.Weights) != 0 {
+			return sdkerrors.Wrap(ErrInvalidSignerThreshold, "weights require a threshold")
+		}
+		return nil
+	}
+
+	if len(t.Weights) != 0 && len(t.Weights) != noOfSigners {
+		return sdkerrors.Wrapf(ErrInvalidSignerThreshold,
+			"expected one weight per signer (%d)", noOfSigners)
+	}
+	for _, w := range t.Weights {
+		if w == 0 {
+			return sdkerrors.Wrap(ErrInvalidSignerThreshold, "weights must be positive")
+		}
+	}
+
+	if total := t.TotalWeight(noOfSigners); t.Threshold > total {
+		return sdkerrors.Wrapf(ErrInvalidSignerThreshold,
+			"threshold %d exceeds total weight %d", t.Threshold, total)
+	}
+	return nil
+}
+
+// WeightOf returns the weight of the signer at the index
+func (t SignerThreshold) WeightOf(index int) uint64 {
+	if len(t.Weights) == 0 {
+		return 1
+	}
+	return t.Weights[index]
+}
+
+// TotalWeight returns the total weight of the number of signers
+func (t SignerThreshold) TotalWeight(noOfSigners int) (total uint64) {
+	for i := 0; i < noOfSigners; i++ {
+		total += t.WeightOf(i)
+	}
+	return total
+}
+
+func (t SignerThreshold) String() string {
+	if t.IsZero() {
+		return "all signers"
+	}
+	weights := make([]string, len(t.Weights))
+	for i, w := range t.Weights {
+		weights[i] = fmt.Sprint(w)
+	}
+	return fmt.Sprintf("%d (weights: [%s])", t.Threshold, strings.Join(weights, ","))
+}
+
+// SignersMeetThreshold returns true if the signers are allowed to authorise
+// the bond's administrative messages, i.e. if they are distinct signers of the
+// bond whose weights meet the bond's signer threshold, or if they are exactly
+// the bond's signers (in order) if the bond has no threshold.
+func (bond Bond) SignersMeetThreshold(signers []sdk.AccAddress) bool {
+	if bond.SignerThreshold.IsZero() {
+		return bond.SignersEqualTo(signers)
+	}
+
+	weight := uint64(0)
+	seen := make(map[string]bool)
+	for _, s := range signers {
+		index := bond.signerIndex(s)
+		if index < 0 || seen[s.String()] {
+			return false
+		}
+		seen[s.String()] = true
+		weight += bond.SignerThreshold.WeightOf(index)
+	}
+	return weight >= bond.SignerThreshold.Threshold
+}
+
+// signerIndex returns the index of the signer among the bond's signers, or -1
+// if it is not one of the bond's signers
+func (bond Bond) signerIndex(signer sdk.AccAddress) int {
+	for i, s := range bond.Signers {
+		if s.Equals(signer) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestSignerThresholdValidate(t *testing.T) {
+	testCases := []struct {
+		threshold SignerThreshold
+		expectErr bool
+	}{
+		{NewSignerThreshold(0, nil), false},                 // All signers
+		{NewSignerThreshold(2, nil), false},                 // 2 of 3
+		{NewSignerThreshold(3, nil), false},                 // 3 of 3
+		{NewSignerThreshold(4, nil), true},                  // Exceeds total weight
+		{NewSignerThreshold(3, []uint64{2, 1, 1}), false},   // Weighted
+		{NewSignerThreshold(4, []uint64{2, 1, 1}), false},   // Total weight
+		{NewSignerThreshold(5, []uint64{2, 1, 1}), true},    // Exceeds total weight
+		{NewSignerThreshold(2, []uint64{2, 1}), true},       // Missing weight
+		{NewSignerThreshold(2, []uint64{2, 1, 1, 1}), true}, // Extra weight
+		{NewSignerThreshold(2, []uint64{2, 0, 1}), true},    // Zero weight
+		{NewSignerThreshold(0, []uint64{1, 1, 1}), true},    // Weights without threshold
+	}
+	for _, tc := range testCases {
+		err := tc.threshold.Validate(3)
+		if tc.expectErr {
+			require.Error(t, err, tc.threshold.String())
+		} else {
+			require.NoError(t, err, tc.threshold.String())
+		}
+	}
+}
+
+func TestCheckSignersTooMany(t *testing.T) {
+	signers := make([]sdk.AccAddress, MaxNoOfSigners+1)
+	for i := range signers {
+		signers[i] = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	}
+
+	require.NoError(t, CheckSigners(signers[:MaxNoOfSigners]))
+	require.Error(t, CheckSigners(signers))
+}
+
+func TestSignersMeetThreshold(t *testing.T) {
+	bond := getValidBond()
+
+	addr1 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr2 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr3 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr4 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	bond.Signers = []sdk.AccAddress{addr1, addr2, addr3}
+
+	testCases := []struct {
+		threshold SignerThreshold
+		signers   []sdk.AccAddress
+		expected  bool
+	}{
+		// No threshold, so all signers must sign in order
+		{SignerThreshold{}, []sdk.AccAddress{addr1, addr2, addr3}, true},
+		{SignerThreshold{}, []sdk.AccAddress{addr1, addr2}, false},
+		{SignerThreshold{}, []sdk.AccAddress{addr2, addr1, addr3}, false},
+		// 2 of 3, in any order
+		{NewSignerThreshold(2, nil), []sdk.AccAddress{addr1, addr2}, true},
+		{NewSignerThreshold(2, nil), []sdk.AccAddress{addr3, addr1}, true},
+		{NewSignerThreshold(2, nil), []sdk.AccAddress{addr1, addr2, addr3}, true},
+		{NewSignerThreshold(2, nil), []sdk.AccAddress{addr2}, false},
+		{NewSignerThreshold(2, nil), []sdk.AccAddress{addr2, addr2}, false}, // Duplicate
+		{NewSignerThreshold(2, nil), []sdk.AccAddress{addr1, addr4}, false}, // Not a signer
+		// Weighted, where addr1 alone or addr2 and addr3 together suffice
+		{NewSignerThreshold(2, []uint64{2, 1, 1}), []sdk.AccAddress{addr1}, true},
+		{NewSignerThreshold(2, []uint64{2, 1, 1}), []sdk.AccAddress{addr2, addr3}, true},
+		{NewSignerThreshold(2, []uint64{2, 1, 1}), []sdk.AccAddress{addr3}, false},
+	}
+	for i, tc := range testCases {
+		bond.SignerThreshold = tc.threshold
+		require.Equal(t, tc.expected, bond.SignersMeetThreshold(tc.signers), i)
+	}
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// A bond's soft cap is the minimum raise, in its reserve tokens, that the
+// bond's reserve must reach by the bond's raise deadline (a block height). If
+// the reserve has not reached the soft cap by the first batch settled at or
+// after the raise deadline, the bond enters its FAILED state, in which no more
+// orders are accepted and the bond's holders can burn their bond tokens for a
+// pro-rata share of the bond's full reserve, without any fees.
+
+// HasSoftCap returns true if the bond has a soft cap, i.e. if it can fail to
+// raise its minimum raise by its raise deadline.
+func (bond Bond) HasSoftCap() bool {
+	return !bond.SoftCap.Empty()
+}
+
+// IsSoftCapMet returns true if the reserve meets the bond's soft cap.
+func (bond Bond) IsSoftCapMet(reserve sdk.Coins) bool {
+	return reserve.IsAllGTE(bond.SoftCap)
+}
+
+// ValidateSoftCap checks that the soft cap and raise deadline of a bond with
+// the specified reserve tokens and function type are valid. Both are optional
+// but must be set together.
+func ValidateSoftCap(softCap sdk.Coins, raiseDeadline int64,
+	reserveTokens []string, functionType string) error {
+
+	if softCap.Empty() {
+		if raiseDeadline != 0 {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SoftCap")
+		}
+		return nil
+	} else if raiseDeadline <= 0 {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "RaiseDeadline")
+	}
+
+	// Swapper bonds are liquidity pools rather than raises
+	if IsSwapperFunctionType(functionType) {
+		return sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, "SoftCap")
+	}
+
+	// Check soft cap is a valid amount of reserve tokens
+	if !isValidCoins(softCap) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, softCap.String())
+	}
+	reserveDenoms := make(map[string]bool)
+	for _, r := range reserveTokens {
+		reserveDenoms[r] = true
+	}
+	for _, c := range softCap {
+		if !reserveDenoms[c.Denom] {
+			return sdkerrors.Wrapf(ErrReserveDenomsMismatch,
+				"soft cap denom %s is not a reserve token", c.Denom)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SpendRecord records the amount that an address committed to a buy (i.e. the
+// max prices of the buy) at the height at which the buy was submitted. Once
+// the buy is performed or cancelled, the part of the amount that was returned
+// to the address is released from the record, so that only the amount that
+// was actually charged remains.
+type SpendRecord struct {
+	Height int64     `json:"height" yaml:"height"`
+	Amount sdk.Coins `json:"amount" yaml:"amount"`
+}
+
+func NewSpendRecord(height int64, amount sdk.Coins) SpendRecord {
+	return SpendRecord{
+		Height: height,
+		Amount: amount,
+	}
+}
+
+func (r SpendRecord) String() string {
+	return fmt.Sprintf("%d: %s", r.Height, r.Amount)
+}
+
+// SpendRecords are an address's spend records, from oldest to newest.
+type SpendRecords []SpendRecord
+
+// Prune returns the records that are still within the rolling window of the
+// specified number of blocks ending at (and including) the specified height.
+func (rs SpendRecords) Prune(height int64, windowBlocks uint64) SpendRecords {
+	minHeight := height - int64(windowBlocks) + 1
+	for i, r := range rs {
+		if r.Height >= minHeight {
+			return rs[i:]
+		}
+	}
+	return SpendRecords{}
+}
+
+// Release returns the records with the amount released from the records at
+// the specified height, dropping any records that are left empty. Any part of
+// the amount exceeding the records at the height is ignored, e.g. if they have
+// already been pruned.
+func (rs SpendRecords) Release(height int64, amount sdk.Coins) SpendRecords {
+	released := SpendRecords{}
+	for _, r := range rs {
+		if r.Height == height {
+			for _, c := range r.Amount {
+				toRelease := sdk.MinInt(c.Amount, amount.AmountOf(c.Denom))
+				if toRelease.IsPositive() {
+					coin := sdk.NewCoin(c.Denom, toRelease)
+					r.Amount = r.Amount.Sub(sdk.Coins{coin})
+					amount = amount.Sub(sdk.Coins{coin})
+				}
+			}
+		}
+		if !r.Amount.IsZero() {
+			released = append(released, r)
+		}
+	}
+	return released
+}
+
+// Total returns the sum of the amounts of the records.
+func (rs SpendRecords) Total() sdk.Coins {
+	total := sdk.Coins{}
+	for _, r := range rs {
+		total = total.Add(r.Amount...)
+	}
+	return total
+}
+
+// CheckSpendCap returns an error if the amount spent so far plus the amount
+// to be spent exceeds the spend cap in any of the cap's denominations. Any
+// denomination not present in the cap is not capped.
+func CheckSpendCap(spendCap, spent, amount sdk.Coins) error {
+	total := spent.Add(amount...)
+	for _, c := range spendCap {
+		if total.AmountOf(c.Denom).GT(c.Amount) {
+			return sdkerrors.Wrapf(ErrSpendCapExceeded,
+				"spending %s would exceed the cap of %s (already spent %s)",
+				amount, spendCap, spent)
+		}
+	}
+	return nil
+}
+
+// SpendRecordsEntry is an address's spend records along with the address, as
+// included in the genesis state.
+type SpendRecordsEntry struct {
+	Address sdk.AccAddress `json:"address" yaml:"address"`
+	Records SpendRecords   `json:"records" yaml:"records"`
+}
+
+func NewSpendRecordsEntry(address sdk.AccAddress, records SpendRecords) SpendRecordsEntry {
+	return SpendRecordsEntry{
+		Address: address,
+		Records: records,
+	}
+}
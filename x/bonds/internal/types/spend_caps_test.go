@@ -0,0 +1,93 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestSpendRecordsPruneDropsRecordsOutsideOfWindow(t *testing.T) {
+	records := SpendRecords{
+		NewSpendRecord(1, sdk.NewCoins(sdk.NewInt64Coin("res", 10))),
+		NewSpendRecord(5, sdk.NewCoins(sdk.NewInt64Coin("res", 20))),
+		NewSpendRecord(9, sdk.NewCoins(sdk.NewInt64Coin("res", 30))),
+	}
+
+	// Window of 5 blocks ending at height 9 covers heights 5 to 9
+	pruned := records.Prune(9, 5)
+	require.Len(t, pruned, 2)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("res", 50)), pruned.Total())
+
+	// Window of 1 block ending at height 10 covers nothing
+	require.Empty(t, records.Prune(10, 1))
+}
+
+func TestSpendRecordsReleaseOnlyReleasesFromRecordsAtHeight(t *testing.T) {
+	records := SpendRecords{
+		NewSpendRecord(1, sdk.NewCoins(sdk.NewInt64Coin("res", 10))),
+		NewSpendRecord(5, sdk.NewCoins(sdk.NewInt64Coin("res", 20), sdk.NewInt64Coin("rez", 5))),
+		NewSpendRecord(5, sdk.NewCoins(sdk.NewInt64Coin("res", 30))),
+	}
+
+	// Releasing from the records at height 5 spans both of its records
+	released := records.Release(5, sdk.NewCoins(sdk.NewInt64Coin("res", 25)))
+	require.Equal(t, SpendRecords{
+		NewSpendRecord(1, sdk.NewCoins(sdk.NewInt64Coin("res", 10))),
+		NewSpendRecord(5, sdk.NewCoins(sdk.NewInt64Coin("rez", 5))),
+		NewSpendRecord(5, sdk.NewCoins(sdk.NewInt64Coin("res", 25))),
+	}, released)
+
+	// Releasing more than recorded at the height drops its records
+	released = records.Release(5, sdk.NewCoins(
+		sdk.NewInt64Coin("res", 100), sdk.NewInt64Coin("rez", 100)))
+	require.Equal(t, SpendRecords{
+		NewSpendRecord(1, sdk.NewCoins(sdk.NewInt64Coin("res", 10))),
+	}, released)
+
+	// Releasing from a height without records changes nothing
+	require.Equal(t, records, records.Release(3, sdk.NewCoins(sdk.NewInt64Coin("res", 10))))
+}
+
+func TestValidateGenesisChecksSpendRecords(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	genesis := DefaultGenesisState()
+	genesis.SpendRecords = []SpendRecordsEntry{NewSpendRecordsEntry(address, SpendRecords{
+		NewSpendRecord(1, sdk.NewCoins(sdk.NewInt64Coin("res", 10))),
+		NewSpendRecord(5, sdk.NewCoins(sdk.NewInt64Coin("res", 20))),
+	})}
+	require.Nil(t, ValidateGenesis(genesis))
+
+	// Records without an address
+	genesis.SpendRecords[0].Address = nil
+	require.Error(t, ValidateGenesis(genesis))
+	genesis.SpendRecords[0].Address = address
+
+	// Records that are not ordered by height
+	genesis.SpendRecords[0].Records[0].Height = 6
+	require.Error(t, ValidateGenesis(genesis))
+	genesis.SpendRecords[0].Records[0].Height = 1
+
+	// Records with an invalid amount
+	genesis.SpendRecords[0].Records[1].Amount = sdk.Coins{
+		sdk.Coin{Denom: "res", Amount: sdk.NewInt(-1)}}
+	require.Error(t, ValidateGenesis(genesis))
+}
+
+func TestCheckSpendCap(t *testing.T) {
+	spendCap := sdk.NewCoins(sdk.NewInt64Coin("res", 100))
+	spent := sdk.NewCoins(sdk.NewInt64Coin("res", 60))
+
+	// Reaching the cap exactly is allowed
+	err := CheckSpendCap(spendCap, spent, sdk.NewCoins(sdk.NewInt64Coin("res", 40)))
+	require.NoError(t, err)
+
+	// Exceeding the cap is not allowed
+	err = CheckSpendCap(spendCap, spent, sdk.NewCoins(sdk.NewInt64Coin("res", 41)))
+	require.Error(t, err)
+	require.True(t, ErrSpendCapExceeded.Is(err))
+
+	// Denominations not in the cap are not capped
+	err = CheckSpendCap(spendCap, spent, sdk.NewCoins(sdk.NewInt64Coin("res2", 1000)))
+	require.NoError(t, err)
+}
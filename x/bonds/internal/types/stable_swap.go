@@ -0,0 +1,136 @@
+package types
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The stable swap function is a swapper function for reserve tokens of like
+// value (e.g. two stablecoins pegged to the same asset), following Curve's
+// stableswap invariant for two reserve balances x and y:
+// 4A(x+y) + D = 4AD + D^3/(4xy), where D is the total value of the reserve
+// when x = y. The amplification A makes the invariant behave like a constant
+// sum (no slippage) close to the peg and like a constant product (as for the
+// swapper function) far from it. As for the swapper function, liquidity is
+// added and removed in proportion to the reserve balances.
+//
+// D and the resulting balances are found by Newton's method using integer
+// arithmetic only, so every node computes identical results.
+
+const (
+	// MaxStableSwapAmplification is the max amplification A of the stable
+	// swap function, as in Curve's pools
+	MaxStableSwapAmplification = 1000000
+
+	// stableSwapIterations is the max number of iterations of Newton's
+	// method, which converges in far fewer iterations in practice
+	stableSwapIterations = 255
+
+	stableSwapNoOfReserves = 2
+)
+
+// stableSwapInvariant returns the stableswap invariant D of the reserve
+// balances, given the amplification A multiplied by the number of reserves
+func stableSwapInvariant(balances []*big.Int, ann *big.Int) *big.Int {
+	n := big.NewInt(stableSwapNoOfReserves)
+	sum := new(big.Int)
+	for _, b := range balances {
+		sum.Add(sum, b)
+	}
+	if sum.Sign() == 0 {
+		return sum
+	}
+
+	d := new(big.Int).Set(sum)
+	annMinusOne := new(big.Int).Sub(ann, big.NewInt(1))
+	nPlusOne := big.NewInt(stableSwapNoOfReserves + 1)
+	for i := 0; i < stableSwapIterations; i++ {
+		// dP = D^(n+1) / (n^n * prod(balances))
+		dP := new(big.Int).Set(d)
+		for _, b := range balances {
+			dP.Mul(dP, d)
+			dP.Quo(dP, new(big.Int).Mul(b, n))
+		}
+		prev := new(big.Int).Set(d)
+
+		// D = (Ann*S + n*dP) * D / ((Ann-1)*D + (n+1)*dP)
+		numerator := new(big.Int).Mul(ann, sum)
+		numerator.Add(numerator, new(big.Int).Mul(dP, n))
+		numerator.Mul(numerator, d)
+		denominator := new(big.Int).Mul(annMinusOne, d)
+		denominator.Add(denominator, new(big.Int).Mul(nPlusOne, dP))
+		d.Quo(numerator, denominator)
+
+		if new(big.Int).Sub(d, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			break
+		}
+	}
+	return d
+}
+
+// stableSwapBalance returns the balance y of the other reserve token that
+// keeps the invariant D given the new balance x of one reserve token
+func stableSwapBalance(x, d, ann *big.Int) *big.Int {
+	n := big.NewInt(stableSwapNoOfReserves)
+
+	// c = D^(n+1) / (n^n * x * Ann * n), b = x + D/Ann
+	c := new(big.Int).Mul(d, d)
+	c.Quo(c, new(big.Int).Mul(x, n))
+	c.Mul(c, d)
+	c.Quo(c, new(big.Int).Mul(ann, n))
+	b := new(big.Int).Add(x, new(big.Int).Quo(d, ann))
+
+	// y = (y^2 + c) / (2y + b - D)
+	y := new(big.Int).Set(d)
+	for i := 0; i < stableSwapIterations; i++ {
+		prev := new(big.Int).Set(y)
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Mul(y, big.NewInt(2))
+		denominator.Add(denominator, b)
+		denominator.Sub(denominator, d)
+		y.Quo(numerator, denominator)
+
+		if new(big.Int).Sub(y, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			break
+		}
+	}
+	return y
+}
+
+// stableSwapReturn returns the amount of the out reserve token given for the
+// amount of the in reserve token, such that the stableswap invariant of the
+// reserve balances is kept. The result is rounded down by one extra token (as
+// in Curve's pools) so that rounding never favours the swapper.
+func stableSwapReturn(inAmt, inRes, outRes sdk.Int, amplification sdk.Dec) sdk.Int {
+	if !inRes.IsPositive() || !outRes.IsPositive() {
+		return sdk.ZeroInt()
+	}
+
+	ann := new(big.Int).Mul(amplification.TruncateInt().BigInt(),
+		big.NewInt(stableSwapNoOfReserves))
+
+	d := stableSwapInvariant([]*big.Int{inRes.BigInt(), outRes.BigInt()}, ann)
+	y := stableSwapBalance(new(big.Int).Add(inRes.BigInt(), inAmt.BigInt()), d, ann)
+
+	outAmt := sdk.NewIntFromBigInt(new(big.Int).Sub(outRes.BigInt(), y)).SubRaw(1)
+	if outAmt.IsNegative() {
+		return sdk.ZeroInt()
+	}
+	return outAmt
+}
+
+func stableSwapParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Stable swap exception 1: 1 <= A <= MaxStableSwapAmplification, since a
+	// zero A would result in divisions by zero
+	val, ok := paramsMap["A"]
+	if !ok {
+		panic("did not find parameter A for stable swap function")
+	} else if val.LT(sdk.OneDec()) || val.GT(sdk.NewDec(MaxStableSwapAmplification)) {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween,
+			"FunctionParams:A must be between 1 and %d", MaxStableSwapAmplification)
+	}
+	return nil
+}
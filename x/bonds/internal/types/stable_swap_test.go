@@ -0,0 +1,82 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+func getValidStableSwapBond() Bond {
+	bond := getValidBond()
+	bond.FunctionType = StableSwapFunction
+	bond.FunctionParameters = functionParametersStableSwap()
+	bond.ReserveTokens = swapperReserves()
+	bond.TxFeePercentage = sdk.ZeroDec()
+	return bond
+}
+
+func TestStableSwapFunctionParamsValidate(t *testing.T) {
+	require.Nil(t, functionParametersStableSwap().Validate(StableSwapFunction))
+
+	testCases := []FunctionParams{
+		nil,
+		{NewFunctionParam("A", sdk.ZeroDec())},
+		{NewFunctionParam("A", sdk.MustNewDecFromStr("10.5"))},
+		{NewFunctionParam("A", sdk.NewDec(MaxStableSwapAmplification+1))},
+		{NewFunctionParam("a", sdk.NewDec(100))},
+	}
+	for _, tc := range testCases {
+		require.Error(t, tc.Validate(StableSwapFunction), tc.String())
+	}
+}
+
+func TestGetReturnsForSwapStableSwap(t *testing.T) {
+	testCases := []struct {
+		amplification  int64
+		inRes          int64
+		outRes         int64
+		in             int64
+		expectedReturn int64
+	}{
+		{100, 1000000, 1000000, 1000, 999},      // constant product: 999
+		{100, 1000000, 1000000, 100000, 99900},  // constant product: 90909
+		{1, 1000000, 1000000, 100000, 95227},    // lower A, more slippage
+		{100, 1000000, 1000000, 500000, 496752}, // constant product: 333333
+		{100, 1000000, 500000, 1000, 991},       // off peg, constant product: 499
+		{1000000, 1000000, 1000000, 1000, 999},  // max A
+		{100, 1000000, 1000000, 1, 0},           // too small to give any return
+	}
+	for _, tc := range testCases {
+		bond := getValidStableSwapBond()
+		bond.FunctionParameters = FunctionParams{
+			NewFunctionParam("A", sdk.NewDec(tc.amplification))}
+		reserveBalances := sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, tc.inRes),
+			sdk.NewInt64Coin(reserveToken2, tc.outRes),
+		)
+		from := sdk.NewInt64Coin(reserveToken, tc.in)
+
+		returns, _, err := bond.GetReturnsForSwap(from, reserveToken2, reserveBalances)
+		if tc.expectedReturn == 0 {
+			require.Error(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedReturn, returns.AmountOf(reserveToken2).Int64())
+	}
+}
+
+func TestStableSwapReturnKeepsInvariant(t *testing.T) {
+	inRes, outRes := sdk.NewInt(123456789), sdk.NewInt(98765432)
+	inAmt := sdk.NewInt(5000000)
+	outAmt := stableSwapReturn(inAmt, inRes, outRes, sdk.NewDec(50))
+
+	// The invariant after the swap is at least the invariant before it, since
+	// the return is rounded down
+	ann := sdk.NewInt(100).BigInt()
+	before := stableSwapInvariant([]*big.Int{inRes.BigInt(), outRes.BigInt()}, ann)
+	after := stableSwapInvariant([]*big.Int{
+		inRes.Add(inAmt).BigInt(), outRes.Sub(outAmt).BigInt()}, ann)
+	require.True(t, after.Cmp(before) >= 0)
+}
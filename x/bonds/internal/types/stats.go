@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"strings"
+)
+
+type BondCount struct {
+	FunctionType string `json:"function_type" yaml:"function_type"`
+	Count        uint64 `json:"count" yaml:"count"`
+}
+
+// ModuleStats holds module-wide aggregates. These are updated incrementally
+// whenever bonds and batches are set, and whenever fees are charged, so that
+// they can be queried without having to iterate through all bonds.
+type ModuleStats struct {
+	BondCounts         []BondCount `json:"bond_counts" yaml:"bond_counts"`
+	TotalValueLocked   sdk.Coins   `json:"total_value_locked" yaml:"total_value_locked"`
+	TotalFeesCollected sdk.Coins   `json:"total_fees_collected" yaml:"total_fees_collected"`
+	ActiveBatches      uint64      `json:"active_batches" yaml:"active_batches"`
+}
+
+func NewModuleStats() ModuleStats {
+	return ModuleStats{
+		BondCounts:         nil,
+		TotalValueLocked:   sdk.Coins{},
+		TotalFeesCollected: sdk.Coins{},
+		ActiveBatches:      0,
+	}
+}
+
+// AddBond returns the stats with a bond of the function type counted
+func (s ModuleStats) AddBond(functionType string) ModuleStats {
+	for i, bc := range s.BondCounts {
+		if bc.FunctionType == functionType {
+			s.BondCounts[i].Count += 1
+			return s
+		}
+	}
+	s.BondCounts = append(s.BondCounts,
+		BondCount{FunctionType: functionType, Count: 1})
+	return s
+}
+
+func (s ModuleStats) TotalBonds() (total uint64) {
+	for _, bc := range s.BondCounts {
+		total += bc.Count
+	}
+	return total
+}
+
+func (s ModuleStats) BondCountOf(functionType string) uint64 {
+	for _, bc := range s.BondCounts {
+		if bc.FunctionType == functionType {
+			return bc.Count
+		}
+	}
+	return 0
+}
+
+func (s ModuleStats) String() string {
+	var bondCounts []string
+	for _, bc := range s.BondCounts {
+		bondCounts = append(bondCounts, fmt.Sprintf("%s: %d", bc.FunctionType, bc.Count))
+	}
+	return fmt.Sprintf(`Bonds:                %d (%s)
+Total Value Locked:   %s
+Total Fees Collected: %s
+Active Batches:       %d`,
+		s.TotalBonds(), strings.Join(bondCounts, ", "), s.TotalValueLocked,
+		s.TotalFeesCollected, s.ActiveBatches)
+}
+
+// BondFeesEntry is the total fees collected by a bond along with the bond's
+// token, as included in the genesis state.
+type BondFeesEntry struct {
+	BondToken string    `json:"bond_token" yaml:"bond_token"`
+	Fees      sdk.Coins `json:"fees" yaml:"fees"`
+}
+
+func NewBondFeesEntry(bondToken string, fees sdk.Coins) BondFeesEntry {
+	return BondFeesEntry{
+		BondToken: bondToken,
+		Fees:      fees,
+	}
+}
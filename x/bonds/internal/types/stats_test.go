@@ -0,0 +1,26 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestValidateGenesisChecksFeesCollected(t *testing.T) {
+	bondFees := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	genesis := DefaultGenesisState()
+	genesis.BondFeesCollected = []BondFeesEntry{NewBondFeesEntry(initToken, bondFees)}
+
+	// Total fees collected lower than the sum of the bonds' fees
+	genesis.TotalFeesCollected = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 9))
+	require.Error(t, ValidateGenesis(genesis))
+
+	// Total fees collected that include fees of bonds that no longer exist
+	genesis.TotalFeesCollected = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 11))
+	require.Nil(t, ValidateGenesis(genesis))
+
+	// Invalid fees collected by a bond
+	genesis.BondFeesCollected = []BondFeesEntry{NewBondFeesEntry(initToken,
+		sdk.Coins{sdk.Coin{Denom: reserveToken, Amount: sdk.NewInt(-1)}})}
+	require.Error(t, ValidateGenesis(genesis))
+}
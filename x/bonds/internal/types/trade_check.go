@@ -0,0 +1,75 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// QueryIsTradeAllowedParams describe a prospective order to be checked by an
+// is trade allowed query. The amount is the bond tokens bought or sold, or the
+// from amount of a swap. The max prices are only used for buys and the to
+// token is only used for swaps.
+type QueryIsTradeAllowedParams struct {
+	Side      string         `json:"side" yaml:"side"`
+	Account   sdk.AccAddress `json:"account" yaml:"account"`
+	Amount    sdk.Coin       `json:"amount" yaml:"amount"`
+	MaxPrices sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	ToToken   string         `json:"to_token" yaml:"to_token"`
+}
+
+func NewQueryIsTradeAllowedParams(side string, account sdk.AccAddress,
+	amount sdk.Coin, maxPrices sdk.Coins, toToken string) QueryIsTradeAllowedParams {
+	return QueryIsTradeAllowedParams{
+		Side:      side,
+		Account:   account,
+		Amount:    amount,
+		MaxPrices: maxPrices,
+		ToToken:   toToken,
+	}
+}
+
+// Msg returns the order message that the prospective order would be submitted
+// as, for the bond with the bond token. The bond tokens bought or sold must be
+// the bond's own tokens.
+func (p QueryIsTradeAllowedParams) Msg(bondToken string) (sdk.Msg, error) {
+	switch p.Side {
+	case AttributeValueBuyOrder, AttributeValueSellOrder:
+		if p.Amount.Denom != bondToken {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest,
+				"amount denom %s does not match bond token %s", p.Amount.Denom, bondToken)
+		} else if p.Side == AttributeValueBuyOrder {
+			return NewMsgBuy(p.Account, p.Amount, p.MaxPrices), nil
+		}
+		return NewMsgSell(p.Account, p.Amount), nil
+	case AttributeValueSwapOrder:
+		return NewMsgSwap(p.Account, bondToken, p.Amount, p.ToToken), nil
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest,
+			"side must be one of %s, %s or %s", AttributeValueBuyOrder,
+			AttributeValueSellOrder, AttributeValueSwapOrder)
+	}
+}
+
+// QueryIsTradeAllowed is the result of an is trade allowed query. If the
+// order is not allowed, the codespace, code, and message of the error that
+// the order would be rejected with are included, so that clients can show
+// the precise reason.
+type QueryIsTradeAllowed struct {
+	Allowed   bool   `json:"allowed" yaml:"allowed"`
+	Codespace string `json:"codespace" yaml:"codespace"`
+	Code      uint32 `json:"code" yaml:"code"`
+	Error     string `json:"error" yaml:"error"`
+}
+
+func NewQueryIsTradeAllowed(err error) QueryIsTradeAllowed {
+	if err == nil {
+		return QueryIsTradeAllowed{Allowed: true}
+	}
+	codespace, code, log := sdkerrors.ABCIInfo(err, false)
+	return QueryIsTradeAllowed{
+		Allowed:   false,
+		Codespace: codespace,
+		Code:      code,
+		Error:     log,
+	}
+}
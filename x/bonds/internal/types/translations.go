@@ -0,0 +1,94 @@
+package types
+
+import (
+	"fmt"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"regexp"
+	"strings"
+)
+
+const MaxBondTranslations = 10
+
+// Locales are BCP 47-like language tags, e.g. "fr", "pt-BR" or "zh-Hant".
+var reLocale = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// BondTranslation is a localized name and description of a bond, which allows
+// bond issuers to present their bond in multiple languages from chain state.
+type BondTranslation struct {
+	Locale      string `json:"locale" yaml:"locale"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+func NewBondTranslation(locale, name, description string) BondTranslation {
+	return BondTranslation{
+		Locale:      locale,
+		Name:        name,
+		Description: description,
+	}
+}
+
+func (bt BondTranslation) String() string {
+	return fmt.Sprintf("%s:%s", bt.Locale, bt.Name)
+}
+
+// Validate checks that the locale is valid, that the name and description are
+// not empty, and that they do not exceed the specified maximum lengths.
+func (bt BondTranslation) Validate(maxNameLength, maxDescriptionLength uint64) error {
+	if !reLocale.MatchString(bt.Locale) {
+		return sdkerrors.Wrapf(ErrInvalidBondTranslation, "invalid locale %s", bt.Locale)
+	} else if strings.TrimSpace(bt.Name) == "" {
+		return sdkerrors.Wrapf(ErrInvalidBondTranslation, "empty name for locale %s", bt.Locale)
+	} else if strings.TrimSpace(bt.Description) == "" {
+		return sdkerrors.Wrapf(ErrInvalidBondTranslation, "empty description for locale %s", bt.Locale)
+	}
+
+	if err := CheckNameLength(bt.Name, maxNameLength); err != nil {
+		return sdkerrors.Wrapf(err, "locale %s", bt.Locale)
+	} else if err := CheckDescriptionLength(bt.Description, maxDescriptionLength); err != nil {
+		return sdkerrors.Wrapf(err, "locale %s", bt.Locale)
+	}
+	return nil
+}
+
+type BondTranslations []BondTranslation
+
+// Validate checks that there are at most MaxBondTranslations translations,
+// that each translation is valid with respect to the hard caps on the bond
+// name and description lengths, and that there is one translation per locale.
+func (bts BondTranslations) Validate() error {
+	if len(bts) > MaxBondTranslations {
+		return sdkerrors.Wrapf(ErrInvalidBondTranslation,
+			"cannot have more than %d translations", MaxBondTranslations)
+	}
+
+	locales := make(map[string]bool)
+	for _, bt := range bts {
+		err := bt.Validate(MaxBondNameLength, MaxBondDescriptionLength)
+		if err != nil {
+			return err
+		} else if locales[bt.Locale] {
+			return sdkerrors.Wrapf(ErrInvalidBondTranslation, "duplicate locale %s", bt.Locale)
+		}
+		locales[bt.Locale] = true
+	}
+	return nil
+}
+
+// Locales returns the locales of the translations, in the order in which the
+// translations were specified.
+func (bts BondTranslations) Locales() []string {
+	locales := make([]string, len(bts))
+	for i, bt := range bts {
+		locales[i] = bt.Locale
+	}
+	return locales
+}
+
+func (bts BondTranslations) String() string {
+	strs := make([]string, len(bts))
+	for i, bt := range bts {
+		strs[i] = bt.String()
+	}
+	return strings.Join(strs, ",")
+}
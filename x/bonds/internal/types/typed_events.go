@@ -0,0 +1,582 @@
+package types
+
+//go:generate go run gen_event_keys.go
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TypedEvent is an event emitted by the bonds module, defined as a struct
+// whose fields are the event's attributes, in order. The key of each
+// attribute is given by the field's `attr` tag. A field tagged with the
+// omitempty option is left out of the event if it is left unset.
+//
+// The AttributeKey constants are generated from the fields of the typed
+// events (see gen_event_keys.go), so any new event or attribute should be
+// added here, followed by running go generate.
+type TypedEvent interface {
+	EventType() string
+}
+
+// NewEvent converts a typed event into an sdk.Event.
+func NewEvent(e TypedEvent) sdk.Event {
+	v := reflect.ValueOf(e)
+	t := v.Type()
+
+	attributes := make([]sdk.Attribute, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("attr"), ",")
+		if len(tag) > 1 && tag[1] == "omitempty" && v.Field(i).IsZero() {
+			continue
+		}
+		attributes = append(attributes, sdk.NewAttribute(
+			tag[0], attributeValueString(v.Field(i).Interface())))
+	}
+
+	return sdk.NewEvent(e.EventType(), attributes...)
+}
+
+func attributeValueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case []string:
+		return StringsToString(v)
+	case []sdk.AccAddress:
+		return AccAddressesToString(v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		panic(fmt.Sprintf("unsupported event attribute type %T", value))
+	}
+}
+
+type CreateBondEvent struct {
+	Bond                   string           `attr:"bond"`
+	Name                   string           `attr:"name"`
+	Description            string           `attr:"description"`
+	FunctionType           string           `attr:"function_type"`
+	FunctionParameters     FunctionParams   `attr:"function_parameters"`
+	ReserveTokens          []string         `attr:"reserve_tokens"`
+	TxFeePercentage        sdk.Dec          `attr:"tx_fee_percentage"`
+	ExitFeePercentage      sdk.Dec          `attr:"exit_fee_percentage"`
+	FeeAddress             sdk.AccAddress   `attr:"fee_address"`
+	MaxSupply              sdk.Coin         `attr:"max_supply"`
+	OrderQuantityLimits    sdk.Coins        `attr:"order_quantity_limits"`
+	SanityRate             sdk.Dec          `attr:"sanity_rate"`
+	SanityMarginPercentage sdk.Dec          `attr:"sanity_margin_percentage"`
+	AllowSells             bool             `attr:"allow_sells"`
+	NonTransferable        bool             `attr:"non_transferable"`
+	RequireAttestation     bool             `attr:"require_attestation"`
+	Signers                []sdk.AccAddress `attr:"signers"`
+	BatchBlocks            sdk.Uint         `attr:"batch_blocks"`
+	OutcomePayment         sdk.Coins        `attr:"outcome_payment"`
+	ProposalVotingBlocks   uint64           `attr:"proposal_voting_blocks"`
+	CreationFee            sdk.Coins        `attr:"creation_fee"`
+	State                  string           `attr:"state"`
+	CurveVersion           uint64           `attr:"curve_version"`
+	PreMine                sdk.Coins        `attr:"pre_mine,omitempty"`
+	AtMaxSupplyBehavior    string           `attr:"at_max_supply_behavior"`
+	SoftCap                sdk.Coins        `attr:"soft_cap,omitempty"`
+	RaiseDeadline          int64            `attr:"raise_deadline,omitempty"`
+}
+
+func (CreateBondEvent) EventType() string { return EventTypeCreateBond }
+
+// EditBondEvent lists the fields that were edited and holds their new values.
+// Fields that were not edited are left out, as are fields that were reset to
+// an empty value, which can be told apart using EditedFields.
+type EditBondEvent struct {
+	Bond                   string   `attr:"bond"`
+	EditedFields           []string `attr:"edited_fields"`
+	Name                   string   `attr:"name,omitempty"`
+	Description            string   `attr:"description,omitempty"`
+	OrderQuantityLimits    string   `attr:"order_quantity_limits,omitempty"`
+	SanityRate             string   `attr:"sanity_rate,omitempty"`
+	SanityMarginPercentage string   `attr:"sanity_margin_percentage,omitempty"`
+	NetSellCap             string   `attr:"net_sell_cap,omitempty"`
+	NetSellCapPercentage   string   `attr:"net_sell_cap_percentage,omitempty"`
+	DemurrageRate          string   `attr:"demurrage_rate,omitempty"`
+	QuoteDenom             string   `attr:"quote_denom,omitempty"`
+	MinReserve             string   `attr:"min_reserve,omitempty"`
+	MinReservePercentage   string   `attr:"min_reserve_percentage,omitempty"`
+	FeeAddress             string   `attr:"fee_address,omitempty"`
+	SignerThreshold        string   `attr:"signer_threshold,omitempty"`
+}
+
+func (EditBondEvent) EventType() string { return EventTypeEditBond }
+
+type BuyEvent struct {
+	Bond            string    `attr:"bond"`
+	Amount          sdk.Int   `attr:"amount"`
+	MaxPrices       sdk.Coins `attr:"max_prices"`
+	OrderID         uint64    `attr:"order_id"`
+	OrderReceipt    string    `attr:"order_receipt"`
+	CallbackPayload string    `attr:"callback_payload,omitempty"`
+	Memo            string    `attr:"memo,omitempty"`
+	CallerModule    string    `attr:"caller_module,omitempty"`
+	PriorityFee     sdk.Coins `attr:"priority_fee,omitempty"`
+}
+
+func (BuyEvent) EventType() string { return EventTypeBuy }
+
+type InitSwapperEvent struct {
+	Bond            string    `attr:"bond"`
+	Amount          sdk.Int   `attr:"amount"`
+	ChargedPrices   sdk.Coins `attr:"charged_prices"`
+	OrderID         uint64    `attr:"order_id"`
+	OrderReceipt    string    `attr:"order_receipt"`
+	CallbackPayload string    `attr:"callback_payload,omitempty"`
+	Memo            string    `attr:"memo,omitempty"`
+	CallerModule    string    `attr:"caller_module,omitempty"`
+}
+
+func (InitSwapperEvent) EventType() string { return EventTypeInitSwapper }
+
+type SellEvent struct {
+	Bond         string  `attr:"bond"`
+	Amount       sdk.Int `attr:"amount"`
+	OrderID      uint64  `attr:"order_id"`
+	OrderReceipt string  `attr:"order_receipt"`
+	Memo         string  `attr:"memo,omitempty"`
+	CallerModule string  `attr:"caller_module,omitempty"`
+}
+
+func (SellEvent) EventType() string { return EventTypeSell }
+
+type SwapEvent struct {
+	Bond          string   `attr:"bond"`
+	Amount        sdk.Int  `attr:"amount"`
+	SwapFromToken string   `attr:"from_token"`
+	SwapToToken   string   `attr:"to_token"`
+	Via           string   `attr:"via,omitempty"`
+	MinReturn     sdk.Coin `attr:"min_return,omitempty"`
+	OrderID       uint64   `attr:"order_id"`
+	OrderReceipt  string   `attr:"order_receipt"`
+	Memo          string   `attr:"memo,omitempty"`
+	CallerModule  string   `attr:"caller_module,omitempty"`
+}
+
+func (SwapEvent) EventType() string { return EventTypeSwap }
+
+type RebalanceSwapEvent struct {
+	Bond          string  `attr:"bond"`
+	Amount        sdk.Int `attr:"amount"`
+	SwapFromToken string  `attr:"from_token"`
+	SwapToToken   string  `attr:"to_token"`
+	SanityRate    sdk.Dec `attr:"sanity_rate"`
+	OracleRate    sdk.Dec `attr:"oracle_rate"`
+	OrderID       uint64  `attr:"order_id"`
+	OrderReceipt  string  `attr:"order_receipt"`
+}
+
+func (RebalanceSwapEvent) EventType() string { return EventTypeRebalanceSwap }
+
+type MakeOutcomePaymentEvent struct {
+	Bond    string         `attr:"bond"`
+	Address sdk.AccAddress `attr:"address"`
+}
+
+func (MakeOutcomePaymentEvent) EventType() string { return EventTypeMakeOutcomePayment }
+
+type WithdrawShareEvent struct {
+	Bond    string         `attr:"bond"`
+	Address sdk.AccAddress `attr:"address"`
+	Amount  sdk.Coins      `attr:"amount"`
+}
+
+func (WithdrawShareEvent) EventType() string { return EventTypeWithdrawShare }
+
+type AuthorizedTransferEvent struct {
+	Bond        string           `attr:"bond"`
+	FromAddress sdk.AccAddress   `attr:"from_address"`
+	ToAddress   sdk.AccAddress   `attr:"to_address"`
+	Amount      sdk.Int          `attr:"amount"`
+	Reason      string           `attr:"reason"`
+	Signers     []sdk.AccAddress `attr:"signers"`
+}
+
+func (AuthorizedTransferEvent) EventType() string { return EventTypeAuthorizedTransfer }
+
+type ScheduleParamChangeEvent struct {
+	Bond               string         `attr:"bond"`
+	FunctionParameters FunctionParams `attr:"function_parameters"`
+	EffectiveHeight    int64          `attr:"effective_height"`
+	Interpolate        bool           `attr:"interpolate"`
+}
+
+func (ScheduleParamChangeEvent) EventType() string { return EventTypeScheduleChange }
+
+type CancelParamChangeEvent struct {
+	Bond               string         `attr:"bond"`
+	FunctionParameters FunctionParams `attr:"function_parameters"`
+	EffectiveHeight    int64          `attr:"effective_height"`
+}
+
+func (CancelParamChangeEvent) EventType() string { return EventTypeCancelChange }
+
+type ApplyParamChangeEvent struct {
+	Bond              string         `attr:"bond"`
+	EffectiveHeight   int64          `attr:"effective_height"`
+	OldFunctionParams FunctionParams `attr:"old_function_parameters"`
+	NewFunctionParams FunctionParams `attr:"new_function_parameters"`
+}
+
+func (ApplyParamChangeEvent) EventType() string { return EventTypeApplyChange }
+
+type SubmitBondProposalEvent struct {
+	Bond             string         `attr:"bond"`
+	ProposalID       uint64         `attr:"proposal_id"`
+	ProposalType     string         `attr:"proposal_type"`
+	FundingRecipient sdk.AccAddress `attr:"funding_recipient"`
+	FundingAmount    sdk.Coins      `attr:"funding_amount"`
+	VotingEndHeight  int64          `attr:"voting_end_height"`
+}
+
+func (SubmitBondProposalEvent) EventType() string { return EventTypeSubmitProposal }
+
+type VoteBondProposalEvent struct {
+	Bond        string         `attr:"bond"`
+	ProposalID  uint64         `attr:"proposal_id"`
+	Voter       sdk.AccAddress `attr:"voter"`
+	VoteOption  string         `attr:"vote_option"`
+	VotingPower sdk.Int        `attr:"voting_power"`
+}
+
+func (VoteBondProposalEvent) EventType() string { return EventTypeVoteProposal }
+
+type TallyBondProposalEvent struct {
+	Bond           string  `attr:"bond"`
+	ProposalID     uint64  `attr:"proposal_id"`
+	YesVotes       sdk.Int `attr:"yes_votes"`
+	NoVotes        sdk.Int `attr:"no_votes"`
+	ProposalStatus string  `attr:"proposal_status"`
+}
+
+func (TallyBondProposalEvent) EventType() string { return EventTypeTallyProposal }
+
+// SetBondTranslationsEvent holds the comma-separated locales of the bond's
+// new translations.
+type SetBondTranslationsEvent struct {
+	Bond    string `attr:"bond"`
+	Locales string `attr:"locales"`
+}
+
+func (SetBondTranslationsEvent) EventType() string { return EventTypeSetTranslations }
+
+type SetSanityRateEvent struct {
+	Bond                      string  `attr:"bond"`
+	OldSanityRate             sdk.Dec `attr:"old_sanity_rate"`
+	OldSanityMarginPercentage sdk.Dec `attr:"old_sanity_margin_percentage"`
+	NewSanityRate             sdk.Dec `attr:"new_sanity_rate"`
+	NewSanityMarginPercentage sdk.Dec `attr:"new_sanity_margin_percentage"`
+}
+
+func (SetSanityRateEvent) EventType() string { return EventTypeSetSanityRate }
+
+type RegisterNotificationsEvent struct {
+	Bond              string         `attr:"bond"`
+	Relayer           sdk.AccAddress `attr:"relayer"`
+	NotificationTypes []string       `attr:"notification_types"`
+	Deposit           sdk.Coins      `attr:"deposit"`
+}
+
+func (RegisterNotificationsEvent) EventType() string { return EventTypeRegisterNotifications }
+
+type UnregisterNotificationsEvent struct {
+	Bond     string         `attr:"bond"`
+	Relayer  sdk.AccAddress `attr:"relayer"`
+	Refunded sdk.Coins      `attr:"refunded"`
+}
+
+func (UnregisterNotificationsEvent) EventType() string { return EventTypeUnregisterNotifications }
+
+type ReleaseVestedEvent struct {
+	Bond      string         `attr:"bond"`
+	Recipient sdk.AccAddress `attr:"recipient"`
+	Amount    sdk.Coin       `attr:"amount"`
+}
+
+func (ReleaseVestedEvent) EventType() string { return EventTypeReleaseVested }
+
+type ConvertReserveEvent struct {
+	Bond       string         `attr:"bond"`
+	Address    sdk.AccAddress `attr:"address"`
+	Derivative sdk.Coin       `attr:"derivative"`
+	Underlying sdk.Coin       `attr:"underlying"`
+}
+
+func (ConvertReserveEvent) EventType() string { return EventTypeConvertReserve }
+
+type BondAlertEvent struct {
+	Bond              string       `attr:"bond"`
+	Metric            string       `attr:"metric"`
+	WindowStartHeight int64        `attr:"window_start_height"`
+	OldValue          sdk.DecCoins `attr:"old_value"`
+	NewValue          sdk.DecCoins `attr:"new_value"`
+	ChangePercentage  sdk.Dec      `attr:"change_percentage"`
+}
+
+func (BondAlertEvent) EventType() string { return EventTypeBondAlert }
+
+// BuyOrderFulfillEvent holds the split of the charged prices between the
+// reserve and the funding pool only if the buy was performed during the
+// hatch phase of an augmented bond, and the order's callback payload and memo
+// only if they were specified by the buyer.
+type BuyOrderFulfillEvent struct {
+	Bond                 string         `attr:"bond"`
+	OrderType            string         `attr:"order_type"`
+	Address              sdk.AccAddress `attr:"address"`
+	TokensMinted         sdk.Int        `attr:"tokens_minted"`
+	ChargedPrices        sdk.Coins      `attr:"charged_prices"`
+	ChargedFees          sdk.Coins      `attr:"charged_fees"`
+	ReturnedToAddress    sdk.Coins      `attr:"returned_to_address"`
+	NewBondTokenBalance  sdk.Int        `attr:"new_bond_token_balance"`
+	ChargedPricesReserve sdk.Int        `attr:"charged_prices_of_which_reserve,omitempty"`
+	ChargedPricesFunding sdk.Coins      `attr:"charged_prices_of_which_funding,omitempty"`
+	CallbackPayload      string         `attr:"callback_payload,omitempty"`
+	Memo                 string         `attr:"memo,omitempty"`
+}
+
+func (BuyOrderFulfillEvent) EventType() string { return EventTypeOrderFulfill }
+
+type SellOrderFulfillEvent struct {
+	Bond                string         `attr:"bond"`
+	OrderType           string         `attr:"order_type"`
+	Address             sdk.AccAddress `attr:"address"`
+	TokensBurned        sdk.Int        `attr:"tokens_burned"`
+	ChargedFees         sdk.Coins      `attr:"charged_fees"`
+	ReturnedToAddress   sdk.Coins      `attr:"returned_to_address"`
+	NewBondTokenBalance sdk.Int        `attr:"new_bond_token_balance"`
+	ChargedDemurrage    sdk.Coins      `attr:"charged_demurrage,omitempty"`
+	Memo                string         `attr:"memo,omitempty"`
+}
+
+func (SellOrderFulfillEvent) EventType() string { return EventTypeOrderFulfill }
+
+type SwapOrderFulfillEvent struct {
+	Bond              string         `attr:"bond"`
+	OrderType         string         `attr:"order_type"`
+	Address           sdk.AccAddress `attr:"address"`
+	TokensSwapped     sdk.Coin       `attr:"tokens_swapped"`
+	ChargedFees       sdk.Coin       `attr:"charged_fees"`
+	ReturnedToAddress sdk.Coins      `attr:"returned_to_address"`
+	OracleRate        sdk.Dec        `attr:"oracle_rate,omitempty"`
+	Memo              string         `attr:"memo,omitempty"`
+}
+
+func (SwapOrderFulfillEvent) EventType() string { return EventTypeOrderFulfill }
+
+// RoutedSwapFulfillEvent is emitted once both legs of a routed swap have been
+// performed, in addition to the order fulfill events of the individual legs.
+type RoutedSwapFulfillEvent struct {
+	Bond              string         `attr:"bond"`
+	Address           sdk.AccAddress `attr:"address"`
+	Via               string         `attr:"via"`
+	TokensSwapped     sdk.Coin       `attr:"tokens_swapped"`
+	ViaTokens         sdk.Coin       `attr:"via_tokens"`
+	ReturnedToAddress sdk.Coins      `attr:"returned_to_address"`
+	MinReturn         sdk.Coin       `attr:"min_return,omitempty"`
+	Memo              string         `attr:"memo,omitempty"`
+}
+
+func (RoutedSwapFulfillEvent) EventType() string { return EventTypeRoutedSwapFulfill }
+
+type OrderDeferEvent struct {
+	Bond           string         `attr:"bond"`
+	OrderType      string         `attr:"order_type"`
+	Address        sdk.AccAddress `attr:"address"`
+	TokensDeferred sdk.Int        `attr:"tokens_deferred"`
+	Memo           string         `attr:"memo,omitempty"`
+}
+
+func (OrderDeferEvent) EventType() string { return EventTypeOrderDefer }
+
+type OrderCancelEvent struct {
+	Bond            string         `attr:"bond"`
+	OrderType       string         `attr:"order_type"`
+	Address         sdk.AccAddress `attr:"address"`
+	CancelReason    string         `attr:"cancel_reason"`
+	CallbackPayload string         `attr:"callback_payload,omitempty"`
+	Memo            string         `attr:"memo,omitempty"`
+}
+
+func (OrderCancelEvent) EventType() string { return EventTypeOrderCancel }
+
+type RefundEvent struct {
+	Bond    string         `attr:"bond"`
+	Address sdk.AccAddress `attr:"address"`
+	Amount  sdk.Coins      `attr:"amount"`
+	Orders  uint64         `attr:"orders"`
+}
+
+func (RefundEvent) EventType() string { return EventTypeRefund }
+
+type StateChangeEvent struct {
+	Bond     string `attr:"bond"`
+	OldState string `attr:"old_state"`
+	NewState string `attr:"new_state"`
+}
+
+func (StateChangeEvent) EventType() string { return EventTypeStateChange }
+
+type MaxSupplyReachedEvent struct {
+	Bond                string   `attr:"bond"`
+	MaxSupply           sdk.Coin `attr:"max_supply"`
+	AtMaxSupplyBehavior string   `attr:"at_max_supply_behavior"`
+}
+
+func (MaxSupplyReachedEvent) EventType() string { return EventTypeMaxSupplyReached }
+
+// SoftCapReachedEvent is emitted when a bond's reserve first reaches the
+// bond's soft cap, after which the bond can no longer fail its raise.
+type SoftCapReachedEvent struct {
+	Bond          string    `attr:"bond"`
+	SoftCap       sdk.Coins `attr:"soft_cap"`
+	RaiseDeadline int64     `attr:"raise_deadline"`
+}
+
+func (SoftCapReachedEvent) EventType() string { return EventTypeSoftCapReached }
+
+// RaiseFailedEvent is emitted when a bond's reserve has not reached the
+// bond's soft cap by its raise deadline, and gives the reserve that the
+// bond's holders can withdraw their share of now that the bond has failed.
+type RaiseFailedEvent struct {
+	Bond          string    `attr:"bond"`
+	SoftCap       sdk.Coins `attr:"soft_cap"`
+	RaiseDeadline int64     `attr:"raise_deadline"`
+	Reserve       sdk.Coins `attr:"reserve"`
+}
+
+func (RaiseFailedEvent) EventType() string { return EventTypeRaiseFailed }
+
+type SweepFeeDustEvent struct {
+	Address             sdk.AccAddress `attr:"address"`
+	TargetDenom         string         `attr:"target_denom,omitempty"`
+	Swapped             sdk.Coins      `attr:"swapped"`
+	SentToCommunityPool sdk.Coins      `attr:"sent_to_community_pool"`
+	CallerModule        string         `attr:"caller_module,omitempty"`
+}
+
+func (SweepFeeDustEvent) EventType() string { return EventTypeSweepFeeDust }
+
+type MilestoneReachedEvent struct {
+	Bond               string         `attr:"bond"`
+	Milestone          uint64         `attr:"milestone"`
+	ReserveThreshold   sdk.Coins      `attr:"reserve_threshold"`
+	FundingTranche     sdk.Coins      `attr:"funding_tranche"`
+	FunctionParameters FunctionParams `attr:"function_parameters"`
+	AllowSells         bool           `attr:"allow_sells"`
+}
+
+func (MilestoneReachedEvent) EventType() string { return EventTypeMilestoneReached }
+
+type ClaimStuckFundsEvent struct {
+	ModuleAccount string         `attr:"module_account"`
+	Recipient     sdk.AccAddress `attr:"recipient"`
+	Amount        sdk.Coins      `attr:"amount"`
+	StuckFunds    sdk.Coins      `attr:"stuck_funds"`
+}
+
+func (ClaimStuckFundsEvent) EventType() string { return EventTypeClaimStuckFunds }
+
+type MigrateCurveVersionEvent struct {
+	Bond            string `attr:"bond"`
+	OldCurveVersion uint64 `attr:"old_curve_version"`
+	NewCurveVersion uint64 `attr:"new_curve_version"`
+}
+
+func (MigrateCurveVersionEvent) EventType() string { return EventTypeMigrateCurve }
+
+type MigrateReserveTokenEvent struct {
+	Bond               string         `attr:"bond"`
+	FromDenom          string         `attr:"from_denom"`
+	ToDenom            string         `attr:"to_denom"`
+	Rate               sdk.Dec        `attr:"rate"`
+	OldReserve         sdk.Coins      `attr:"old_reserve"`
+	NewReserve         sdk.Coins      `attr:"new_reserve"`
+	FunctionParameters FunctionParams `attr:"function_parameters"`
+}
+
+func (MigrateReserveTokenEvent) EventType() string { return EventTypeMigrateReserve }
+
+// EditBondProposalEvent lists the fields that were edited by governance and
+// holds their new values, as with EditBondEvent.
+type EditBondProposalEvent struct {
+	Bond                   string   `attr:"bond"`
+	EditedFields           []string `attr:"edited_fields"`
+	TxFeePercentage        string   `attr:"tx_fee_percentage,omitempty"`
+	ExitFeePercentage      string   `attr:"exit_fee_percentage,omitempty"`
+	OrderQuantityLimits    string   `attr:"order_quantity_limits,omitempty"`
+	SanityRate             string   `attr:"sanity_rate,omitempty"`
+	SanityMarginPercentage string   `attr:"sanity_margin_percentage,omitempty"`
+	AllowSells             string   `attr:"allow_sells,omitempty"`
+}
+
+func (EditBondProposalEvent) EventType() string { return EventTypeEditBondProposal }
+
+// ApplyBondEditEvent holds the new values of the fields set by a pending edit
+// that was applied once the bond's batch was settled.
+type ApplyBondEditEvent struct {
+	Bond                 string `attr:"bond"`
+	TxFeePercentage      string `attr:"tx_fee_percentage,omitempty"`
+	ExitFeePercentage    string `attr:"exit_fee_percentage,omitempty"`
+	AllowSells           string `attr:"allow_sells,omitempty"`
+	NetSellCap           string `attr:"net_sell_cap,omitempty"`
+	NetSellCapPercentage string `attr:"net_sell_cap_percentage,omitempty"`
+	DemurrageRate        string `attr:"demurrage_rate,omitempty"`
+	MinReserve           string `attr:"min_reserve,omitempty"`
+	MinReservePercentage string `attr:"min_reserve_percentage,omitempty"`
+	FeeAddress           string `attr:"fee_address,omitempty"`
+}
+
+func (ApplyBondEditEvent) EventType() string { return EventTypeApplyBondEdit }
+
+// BondNotificationEvent is emitted for each relayer registered for
+// notifications about a bond when one of the bond's batches is settled, so
+// that the relayer can index its notifications using the relayer attribute
+// alone instead of processing every order outcome of the bond.
+type BondNotificationEvent struct {
+	Relayer          sdk.AccAddress `attr:"relayer"`
+	Bond             string         `attr:"bond"`
+	NotificationType string         `attr:"notification_type"`
+	Fills            uint64         `attr:"fills"`
+	Cancellations    uint64         `attr:"cancellations"`
+}
+
+func (BondNotificationEvent) EventType() string { return EventTypeBondNotification }
+
+// BuyPriorityEvent is emitted when a batch's buys cannot all be performed
+// (e.g. due to the value locked caps), and gives the priority ordering of the
+// batch's buys that was used to choose which of the buys to defer, from the
+// highest to the lowest priority. The i-th priority is that of the i-th buyer.
+type BuyPriorityEvent struct {
+	Bond       string           `attr:"bond"`
+	Buyers     []sdk.AccAddress `attr:"buyers"`
+	Priorities []string         `attr:"priorities"`
+}
+
+func (BuyPriorityEvent) EventType() string { return EventTypeBuyPriority }
+
+// BatchIntervalEvent is emitted when the number of blocks by which a bond's
+// batches are lengthened changes due to stressed batches, and gives the
+// resulting effective number of blocks of the bond's batches.
+type BatchIntervalEvent struct {
+	Bond                 string   `attr:"bond"`
+	BatchBlocks          sdk.Uint `attr:"batch_blocks"`
+	ExtraBlocks          uint64   `attr:"extra_blocks"`
+	EffectiveBatchBlocks sdk.Uint `attr:"effective_batch_blocks"`
+	StressedBatches      uint64   `attr:"stressed_batches"`
+}
+
+func (BatchIntervalEvent) EventType() string { return EventTypeBatchInterval }
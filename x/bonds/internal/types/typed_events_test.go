@@ -0,0 +1,64 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewEventFormatsAttributesInOrder(t *testing.T) {
+	event := NewEvent(AuthorizedTransferEvent{
+		Bond:        initToken,
+		FromAddress: initCreator,
+		ToAddress:   initCreator,
+		Amount:      sdk.NewInt(10),
+		Reason:      "recovery",
+		Signers:     []sdk.AccAddress{initCreator, initCreator},
+	})
+
+	expected := sdk.NewEvent(EventTypeAuthorizedTransfer,
+		sdk.NewAttribute(AttributeKeyBond, initToken),
+		sdk.NewAttribute(AttributeKeyFromAddress, initCreator.String()),
+		sdk.NewAttribute(AttributeKeyToAddress, initCreator.String()),
+		sdk.NewAttribute(AttributeKeyAmount, "10"),
+		sdk.NewAttribute(AttributeKeyReason, "recovery"),
+		sdk.NewAttribute(AttributeKeySigners,
+			AccAddressesToString([]sdk.AccAddress{initCreator, initCreator})),
+	)
+	require.Equal(t, expected, event)
+}
+
+func TestNewEventFormatsIntegersAndBools(t *testing.T) {
+	event := NewEvent(MilestoneReachedEvent{
+		Bond:       initToken,
+		Milestone:  3,
+		AllowSells: true,
+	})
+
+	attributes := make(map[string]string)
+	for _, attr := range event.Attributes {
+		attributes[string(attr.Key)] = string(attr.Value)
+	}
+	require.Equal(t, "3", attributes[AttributeKeyMilestone])
+	require.Equal(t, "true", attributes[AttributeKeyAllowSells])
+	require.Equal(t, "", attributes[AttributeKeyReserveThreshold])
+}
+
+func TestNewEventOmitsUnsetOmitemptyAttributes(t *testing.T) {
+	event := BuyOrderFulfillEvent{
+		Bond:                initToken,
+		OrderType:           AttributeValueBuyOrder,
+		Address:             initCreator,
+		TokensMinted:        sdk.NewInt(10),
+		NewBondTokenBalance: sdk.NewInt(10),
+	}
+	require.Len(t, NewEvent(event).Attributes, 8)
+
+	event.ChargedPricesReserve = sdk.ZeroInt()
+	event.ChargedPricesFunding = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5))
+	attributes := NewEvent(event).Attributes
+	require.Len(t, attributes, 10)
+	require.Equal(t, AttributeKeyChargedPricesReserve, string(attributes[8].Key))
+	require.Equal(t, "0", string(attributes[8].Value))
+	require.Equal(t, AttributeKeyChargedPricesFunding, string(attributes[9].Key))
+}
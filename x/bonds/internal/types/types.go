@@ -3,6 +3,7 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 )
 
 func CheckReserveTokenNames(resTokens []string, token string) error {
@@ -56,6 +57,68 @@ func CheckCoinDenom(denom string) (err error) {
 	return nil
 }
 
+// isValidCoin is like sdk.Coin.IsValid, except that it does not panic if the
+// coin's amount is not set, as can be the case in a decoded message.
+func isValidCoin(coin sdk.Coin) bool {
+	return coin.Amount != (sdk.Int{}) && coin.IsValid()
+}
+
+// isValidCoins is like sdk.Coins.IsValid (which also requires the coins to be
+// sorted, positive, and without duplicate denoms), except that it does not
+// panic if any of the amounts is not set.
+func isValidCoins(coins sdk.Coins) bool {
+	for _, c := range coins {
+		if c.Amount == (sdk.Int{}) {
+			return false
+		}
+	}
+	return coins.IsValid()
+}
+
+func CheckFeeAddress(feeAddress sdk.AccAddress) error {
+	// Check that fee address is not one of the bonds module accounts, to
+	// avoid fees getting mixed up with the reserve or with batched orders
+	moduleAccounts := []string{
+		BondsMintBurnAccount, BatchesIntermediaryAccount, BondsReserveAccount,
+		BondProposalsAccount, BondVestingAccount, NotificationDepositsAccount}
+	for _, acc := range moduleAccounts {
+		if feeAddress.Equals(supply.NewModuleAddress(acc)) {
+			return sdkerrors.Wrap(ErrFeeAddressCannotBeModuleAccount, acc)
+		}
+	}
+	return nil
+}
+
+// GetBondEscrowAddress returns the address of the escrow account holding the
+// funds of the pending orders of the bond with the specified token. Each bond
+// has its own escrow account, derived from the batches intermediary account
+// name and the bond token, so that a bond's batch can never draw on the funds
+// escrowed for another bond's orders.
+func GetBondEscrowAddress(token string) sdk.AccAddress {
+	return supply.NewModuleAddress(BatchesIntermediaryAccount + "/" + token)
+}
+
+func CheckSigners(signers []sdk.AccAddress) error {
+	// Check that there is at least one signer (but not too many) and that no
+	// signer is duplicate
+	if len(signers) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Signers")
+	} else if len(signers) > MaxNoOfSigners {
+		return sdkerrors.Wrapf(ErrTooManySigners, "max %d", MaxNoOfSigners)
+	}
+
+	uniqueSigners := make(map[string]string)
+	for _, s := range signers {
+		if s.Empty() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Signer")
+		} else if _, ok := uniqueSigners[s.String()]; ok {
+			return sdkerrors.Wrap(ErrDuplicateSigner, s.String())
+		}
+		uniqueSigners[s.String()] = ""
+	}
+	return nil
+}
+
 func GetRequiredParamsForFunctionType(fnType string) (fnParams []string, err error) {
 	expectedParams, ok := RequiredParamsForFunctionType[fnType]
 	if !ok {
@@ -0,0 +1,32 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DenomsExceedingValueLockedCap returns the denominations in which the value
+// locked (i.e. the reserve held) exceeds the cap. Any denomination not present
+// in the cap is not capped, so an empty cap is never exceeded.
+func DenomsExceedingValueLockedCap(valueLocked, valueLockedCap sdk.Coins) (exceeded []string) {
+	for _, c := range valueLockedCap {
+		if valueLocked.AmountOf(c.Denom).GT(c.Amount) {
+			exceeded = append(exceeded, c.Denom)
+		}
+	}
+	return exceeded
+}
+
+// GetBuysReserve returns the reserve that the batch's buys (excluding any
+// cancelled buys) add to the bond's reserve at the batch's buy prices. For
+// augmented bonds in the hatch phase, this includes the part of the buys that
+// goes to the funding pool rather than to the reserve.
+func (b Batch) GetBuysReserve() sdk.Coins {
+	reserve := sdk.Coins{}
+	for _, bo := range b.Buys {
+		if !bo.IsCancelled() {
+			reservePrices := MultiplyDecCoinsByInt(b.BuyPrices, bo.Amount.Amount)
+			reserve = reserve.Add(RoundReservePrices(reservePrices)...)
+		}
+	}
+	return reserve
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDenomsExceedingValueLockedCap(t *testing.T) {
+	valueLocked := sdk.NewCoins(
+		sdk.NewInt64Coin("res", 100),
+		sdk.NewInt64Coin("rez", 200),
+	)
+
+	// Empty cap is never exceeded
+	require.Empty(t, DenomsExceedingValueLockedCap(valueLocked, nil))
+
+	// Reaching the cap does not exceed it
+	valueLockedCap := sdk.NewCoins(sdk.NewInt64Coin("res", 100))
+	require.Empty(t, DenomsExceedingValueLockedCap(valueLocked, valueLockedCap))
+
+	// Only the denominations present in the cap are capped
+	valueLockedCap = sdk.NewCoins(
+		sdk.NewInt64Coin("res", 99),
+		sdk.NewInt64Coin("rex", 1),
+	)
+	require.Equal(t, []string{"res"},
+		DenomsExceedingValueLockedCap(valueLocked, valueLockedCap))
+}
+
+func TestBatchGetBuysReserveExcludesCancelledBuys(t *testing.T) {
+	batch := NewBatch(initToken, sdk.OneUint())
+	batch.BuyPrices = sdk.NewDecCoins(sdk.NewDecCoinFromDec("res", sdk.MustNewDecFromStr("1.5")))
+
+	cancelled := NewBuyOrder(initCreator, sdk.NewInt64Coin(initToken, 100), nil)
+	cancelled.Cancelled = true
+	batch.Buys = []BuyOrder{
+		NewBuyOrder(initCreator, sdk.NewInt64Coin(initToken, 3), nil),
+		cancelled,
+		NewBuyOrder(initCreator, sdk.NewInt64Coin(initToken, 5), nil),
+	}
+
+	// 1.5*3 rounded up (5) plus 1.5*5 rounded up (8)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("res", 13)), batch.GetBuysReserve())
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// VestingSchedule locks the tokens pre-mined for the creator of a bond, which
+// are held by the bond vesting account and released to the recipient linearly
+// from the start height to the end height.
+type VestingSchedule struct {
+	BondToken   string         `json:"bond_token" yaml:"bond_token"`
+	Recipient   sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	Total       sdk.Coin       `json:"total" yaml:"total"`
+	Released    sdk.Coin       `json:"released" yaml:"released"`
+	StartHeight int64          `json:"start_height" yaml:"start_height"`
+	EndHeight   int64          `json:"end_height" yaml:"end_height"`
+}
+
+func NewVestingSchedule(bondToken string, recipient sdk.AccAddress,
+	total sdk.Coin, startHeight, endHeight int64) VestingSchedule {
+	return VestingSchedule{
+		BondToken:   bondToken,
+		Recipient:   recipient,
+		Total:       total,
+		Released:    sdk.NewCoin(total.Denom, sdk.ZeroInt()),
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+}
+
+// VestedAt returns the part of the total that has vested at the specified
+// height, rounded down.
+func (s VestingSchedule) VestedAt(height int64) sdk.Coin {
+	if height <= s.StartHeight {
+		return sdk.NewCoin(s.Total.Denom, sdk.ZeroInt())
+	} else if height >= s.EndHeight {
+		return s.Total
+	}
+
+	elapsed := sdk.NewInt(height - s.StartHeight)
+	duration := sdk.NewInt(s.EndHeight - s.StartHeight)
+	return sdk.NewCoin(s.Total.Denom, s.Total.Amount.Mul(elapsed).Quo(duration))
+}
+
+// ReleasableAt returns the part of the total that has vested at the specified
+// height but has not been released yet.
+func (s VestingSchedule) ReleasableAt(height int64) sdk.Coin {
+	return s.VestedAt(height).Sub(s.Released)
+}
+
+// Locked returns the part of the total that has not been released yet.
+func (s VestingSchedule) Locked() sdk.Coin {
+	return s.Total.Sub(s.Released)
+}
+
+// CheckPreMine returns an error if the pre-mine exceeds the max percentage of
+// the bond's max supply. An empty pre-mine is always allowed.
+func CheckPreMine(preMine sdk.Coins, maxSupply sdk.Coin, maxPercentage sdk.Dec) error {
+	if preMine.Empty() {
+		return nil
+	}
+
+	maxPreMine := NewPercentage(maxPercentage).AsFraction().MulInt(maxSupply.Amount).TruncateInt()
+	if preMine.AmountOf(maxSupply.Denom).GT(maxPreMine) {
+		return sdkerrors.Wrapf(ErrPreMineTooLarge,
+			"%s exceeds %s%% of max supply %s", preMine, maxPercentage, maxSupply)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestVestingScheduleReleasesLinearly(t *testing.T) {
+	schedule := NewVestingSchedule(initToken, initCreator,
+		sdk.NewInt64Coin(initToken, 1000), 100, 200)
+
+	require.True(t, schedule.VestedAt(50).IsZero())
+	require.True(t, schedule.VestedAt(100).IsZero())
+	require.Equal(t, sdk.NewInt64Coin(initToken, 10), schedule.VestedAt(101))
+	require.Equal(t, sdk.NewInt64Coin(initToken, 500), schedule.VestedAt(150))
+	require.Equal(t, sdk.NewInt64Coin(initToken, 1000), schedule.VestedAt(200))
+	require.Equal(t, sdk.NewInt64Coin(initToken, 1000), schedule.VestedAt(300))
+
+	// Only the part that has not been released yet is releasable
+	schedule.Released = sdk.NewInt64Coin(initToken, 500)
+	require.Equal(t, sdk.NewInt64Coin(initToken, 100), schedule.ReleasableAt(160))
+	require.Equal(t, sdk.NewInt64Coin(initToken, 500), schedule.Locked())
+}
+
+func TestCheckPreMine(t *testing.T) {
+	maxSupply := sdk.NewInt64Coin(initToken, 10000)
+	maxPercentage := sdk.NewDec(10)
+
+	require.Nil(t, CheckPreMine(nil, maxSupply, maxPercentage))
+	require.Nil(t, CheckPreMine(sdk.NewCoins(
+		sdk.NewInt64Coin(initToken, 1000)), maxSupply, maxPercentage))
+
+	err := CheckPreMine(sdk.NewCoins(
+		sdk.NewInt64Coin(initToken, 1001)), maxSupply, maxPercentage)
+	require.True(t, ErrPreMineTooLarge.Is(err))
+}
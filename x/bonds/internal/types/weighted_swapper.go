@@ -0,0 +1,127 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The weighted swapper function generalises the swapper function to any number
+// of reserve tokens, each with a weight wi, following the Balancer invariant:
+// swaps keep the product of Bi^wi over all of the reserve balances Bi
+// constant. Its parameters are the weights w0 to wN of the reserve tokens, in
+// the order in which the reserve tokens are given when creating the bond, and
+// they are re-ordered along with the reserve tokens when these are sorted. As
+// for the swapper function, liquidity is added and removed in proportion to
+// the reserve balances.
+
+// WeightedSwapperWeight returns the name of the weight of the reserve token at
+// index i of the weighted swapper function, i.e. "wi"
+func WeightedSwapperWeight(i int) string {
+	return fmt.Sprintf("w%d", i)
+}
+
+// WeightedSwapperWeights returns the names of the weights w0 to wN of a
+// weighted swapper function with the number of parameters
+func WeightedSwapperWeights(noOfParams int) []string {
+	names := make([]string, noOfParams)
+	for i := range names {
+		names[i] = WeightedSwapperWeight(i)
+	}
+	return names
+}
+
+// IsSwapperFunctionType returns true if the function type is one of the
+// swapper function types, i.e. if bonds of the function type are liquidity
+// pools of their reserve tokens that allow swaps between them.
+func IsSwapperFunctionType(functionType string) bool {
+	return functionType == SwapperFunction || functionType == WeightedSwapperFunction ||
+		functionType == StableSwapFunction
+}
+
+// CheckWeightedSwapperReserveTokens returns an error if the number of weights
+// of a weighted swapper function does not match the number of reserve tokens
+func CheckWeightedSwapperReserveTokens(fps FunctionParams, resTokens []string) error {
+	if len(resTokens) < 2 {
+		return sdkerrors.Wrap(ErrIncorrectNumberOfReserveTokens, "expected at least 2")
+	} else if len(fps) != len(resTokens) {
+		return sdkerrors.Wrapf(ErrIncorrectNumberOfFunctionParameters,
+			"expected one weight per reserve token (%d)", len(resTokens))
+	}
+	return nil
+}
+
+// sortedWeightedSwapperWeights returns the weights of a weighted swapper
+// function given in the order of the reserve tokens, re-ordered to follow the
+// reserve tokens once sorted. The weights are returned as they are if they are
+// not exactly the weights w0 to wN of the reserve tokens.
+func sortedWeightedSwapperWeights(fps FunctionParams, resTokens []string) FunctionParams {
+	if len(fps) != len(resTokens) {
+		return fps
+	}
+	paramsMap := fps.AsMap()
+
+	sortedTokens := append([]string{}, resTokens...)
+	sort.Strings(sortedTokens)
+	indexOf := make(map[string]int)
+	for i, r := range resTokens {
+		indexOf[r] = i
+	}
+
+	sorted := make(FunctionParams, len(sortedTokens))
+	for i, r := range sortedTokens {
+		weight, ok := paramsMap[WeightedSwapperWeight(indexOf[r])]
+		if !ok {
+			return fps
+		}
+		sorted[i] = NewFunctionParam(WeightedSwapperWeight(i), weight)
+	}
+	return sorted
+}
+
+// weightOfReserveToken returns the weight of the reserve token of a weighted
+// swapper bond
+func (bond Bond) weightOfReserveToken(denom string) sdk.Dec {
+	args := bond.FunctionParamsMap()
+	for i, r := range bond.ReserveTokens {
+		if r == denom {
+			return args[WeightedSwapperWeight(i)]
+		}
+	}
+	panic(fmt.Sprintf("%s is not a reserve token of bond %s", denom, bond.Token))
+}
+
+// weightedSwapReturn returns the amount of the out reserve token given for the
+// amount of the in reserve token, following the Balancer invariant:
+// Δo = Bo*(1-(Bi/(Bi+Δi))^(wi/wo)). If the weights are equal, this is the
+// swapper function's Uniswap formula, which is evaluated exactly. Otherwise,
+// the fractional power is approximated and the result is rounded down.
+func weightedSwapReturn(inAmt, inRes, outRes sdk.Int, inWeight, outWeight sdk.Dec) (sdk.Int, error) {
+	if inWeight.Equal(outWeight) {
+		return inAmt.Mul(outRes).Quo(inRes.Add(inAmt)), nil
+	}
+
+	base := inRes.ToDec().Quo(inRes.Add(inAmt).ToDec())
+	power, err := ApproxPower(base, inWeight.Quo(outWeight))
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	return outRes.ToDec().Mul(sdk.OneDec().Sub(power)).TruncateInt(), nil
+}
+
+func weightedSwapperParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Weighted swapper exception 1: wi != 0 for every weight, otherwise the
+	// reserve token does not take part in the invariant
+	for i := 0; i < len(paramsMap); i++ {
+		p := WeightedSwapperWeight(i)
+		val, ok := paramsMap[p]
+		if !ok {
+			panic("did not find parameter " + p + " for weighted swapper function")
+		} else if !val.IsPositive() {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:"+p)
+		}
+	}
+	return nil
+}
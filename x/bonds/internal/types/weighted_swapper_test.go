@@ -0,0 +1,118 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func getValidWeightedSwapperBond() Bond {
+	bond := getValidBond()
+	bond.FunctionType = WeightedSwapperFunction
+	bond.FunctionParameters = functionParametersWeightedSwapper()
+	bond.ReserveTokens = weightedSwapperReserves()
+	bond.TxFeePercentage = sdk.ZeroDec()
+	return bond
+}
+
+func TestWeightedSwapperFunctionParamsValidate(t *testing.T) {
+	require.Nil(t, functionParametersWeightedSwapper().Validate(WeightedSwapperFunction))
+
+	testCases := []FunctionParams{
+		nil,
+		{NewFunctionParam("w0", sdk.OneDec()), NewFunctionParam("w2", sdk.OneDec())},
+		{NewFunctionParam("w0", sdk.OneDec()), NewFunctionParam("w1", sdk.ZeroDec())},
+		{NewFunctionParam("a", sdk.OneDec()), NewFunctionParam("b", sdk.OneDec())},
+	}
+	for _, tc := range testCases {
+		require.Error(t, tc.Validate(WeightedSwapperFunction), tc.String())
+	}
+}
+
+func TestGetReturnsForSwapWeightedSwapper(t *testing.T) {
+	bond := getValidWeightedSwapperBond() // w0=0.5, w1=0.25, w2=0.25
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+		sdk.NewInt64Coin(reserveToken3, 20000),
+	)
+
+	// Expected returns are Bo*(1-(Bi/(Bi+Δi))^(wi/wo)), rounded down
+	testCases := []struct {
+		from           sdk.Coin
+		to             string
+		expectedReturn int64
+	}{
+		{sdk.NewInt64Coin(reserveToken, 1000), reserveToken2, 1735}, // ^2
+		{sdk.NewInt64Coin(reserveToken2, 1000), reserveToken, 465},  // ^0.5
+		{sdk.NewInt64Coin(reserveToken3, 500), reserveToken, 122},   // ^0.5
+		{sdk.NewInt64Coin(reserveToken2, 500), reserveToken3, 952},  // equal weights
+		{sdk.NewInt64Coin(reserveToken, 10), reserveToken3, 39},     // ^2
+		{sdk.NewInt64Coin(reserveToken2, 1), reserveToken3, 1},      // equal weights
+	}
+	for _, tc := range testCases {
+		returns, txFee, err := bond.GetReturnsForSwap(tc.from, tc.to, reserveBalances)
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedReturn, returns.AmountOf(tc.to).Int64(), tc.from.String())
+		require.True(t, txFee.IsZero())
+	}
+}
+
+func TestGetReturnsForSwapWeightedSwapperEqualWeightsMatchesSwapper(t *testing.T) {
+	bond := getValidWeightedSwapperBond()
+	bond.FunctionParameters = FunctionParams{
+		NewFunctionParam("w0", sdk.OneDec()),
+		NewFunctionParam("w1", sdk.OneDec())}
+	bond.ReserveTokens = swapperReserves()
+
+	swapper := bond
+	swapper.FunctionType = SwapperFunction
+	swapper.FunctionParameters = nil
+
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 12345),
+		sdk.NewInt64Coin(reserveToken2, 67890),
+	)
+	for _, amount := range []int64{1, 7, 100, 5000, 1000000} {
+		from := sdk.NewInt64Coin(reserveToken, amount)
+		expected, _, err := swapper.GetReturnsForSwap(from, reserveToken2, reserveBalances)
+		require.Nil(t, err)
+		actual, _, err := bond.GetReturnsForSwap(from, reserveToken2, reserveBalances)
+		require.Nil(t, err)
+		require.Equal(t, expected.String(), actual.String())
+	}
+}
+
+func TestGetReturnsForSwapWeightedSwapperInvalidTokensGiveError(t *testing.T) {
+	bond := getValidWeightedSwapperBond()
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+		sdk.NewInt64Coin(reserveToken3, 20000),
+	)
+
+	_, _, err := bond.GetReturnsForSwap(sdk.NewInt64Coin("dummytoken", 100), reserveToken, reserveBalances)
+	require.Error(t, err)
+	_, _, err = bond.GetReturnsForSwap(sdk.NewInt64Coin(reserveToken, 100), "dummytoken", reserveBalances)
+	require.Error(t, err)
+}
+
+func TestGetPricesToMintWeightedSwapperIsProportionalToReserve(t *testing.T) {
+	bond := getValidWeightedSwapperBond()
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 100)
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 2000),
+		sdk.NewInt64Coin(reserveToken3, 3000),
+	)
+
+	prices, err := bond.GetPricesToMint(sdk.NewInt(10), reserveBalances)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(100).String(), prices.AmountOf(reserveToken).String())
+	require.Equal(t, sdk.NewDec(200).String(), prices.AmountOf(reserveToken2).String())
+	require.Equal(t, sdk.NewDec(300).String(), prices.AmountOf(reserveToken3).String())
+
+	returns, err := bond.GetReturnsForBurn(sdk.NewInt(10), reserveBalances)
+	require.Nil(t, err)
+	require.Equal(t, prices.String(), returns.String())
+}
@@ -0,0 +1,220 @@
+package bonds
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/ixoworld/bonds/x/bonds/client/cli"
+	"github.com/ixoworld/bonds/x/bonds/client/rest"
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"strconv"
+)
+
+// ClaimStuckFundsProposalHandler is the governance client proposal handler
+// for the ClaimStuckFundsProposal, to be registered with the gov module.
+var ClaimStuckFundsProposalHandler = govclient.NewProposalHandler(
+	cli.GetCmdSubmitClaimStuckFundsProposal, rest.ClaimStuckFundsProposalRESTHandler)
+
+// MigrateCurveVersionProposalHandler is the governance client proposal
+// handler for the MigrateCurveVersionProposal, to be registered with the gov
+// module.
+var MigrateCurveVersionProposalHandler = govclient.NewProposalHandler(
+	cli.GetCmdSubmitMigrateCurveVersionProposal, rest.MigrateCurveVersionProposalRESTHandler)
+
+// MigrateReserveTokenProposalHandler is the governance client proposal
+// handler for the MigrateReserveTokenProposal, to be registered with the gov
+// module.
+var MigrateReserveTokenProposalHandler = govclient.NewProposalHandler(
+	cli.GetCmdSubmitMigrateReserveTokenProposal, rest.MigrateReserveTokenProposalRESTHandler)
+
+// EditBondProposalHandler is the governance client proposal handler for the
+// EditBondProposal, to be registered with the gov module.
+var EditBondProposalHandler = govclient.NewProposalHandler(
+	cli.GetCmdSubmitEditBondProposal, rest.EditBondProposalRESTHandler)
+
+func NewProposalHandler(keeper keeper.Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case types.ClaimStuckFundsProposal:
+			return handleClaimStuckFundsProposal(ctx, keeper, c)
+		case types.MigrateCurveVersionProposal:
+			return handleMigrateCurveVersionProposal(ctx, keeper, c)
+		case types.MigrateReserveTokenProposal:
+			return handleMigrateReserveTokenProposal(ctx, keeper, c)
+		case types.EditBondProposal:
+			return handleEditBondProposal(ctx, keeper, c)
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "Unrecognized bonds proposal content type: %T", c)
+		}
+	}
+}
+
+func handleClaimStuckFundsProposal(ctx sdk.Context, keeper keeper.Keeper, p types.ClaimStuckFundsProposal) error {
+
+	stuckFunds, err := keeper.ClaimStuckFunds(ctx, p.ModuleAccount, p.Recipient, p.Amount)
+	if err != nil {
+		return err
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info("claimed stuck funds", "module_account", p.ModuleAccount,
+		"recipient", p.Recipient.String(), "amount", p.Amount.String())
+
+	ctx.EventManager().EmitEvent(
+		types.NewEvent(types.ClaimStuckFundsEvent{
+			ModuleAccount: p.ModuleAccount,
+			Recipient:     p.Recipient,
+			Amount:        p.Amount,
+			StuckFunds:    stuckFunds,
+		}),
+	)
+
+	return nil
+}
+
+func handleMigrateCurveVersionProposal(ctx sdk.Context, keeper keeper.Keeper, p types.MigrateCurveVersionProposal) error {
+
+	bond, found := keeper.GetBond(ctx, p.BondToken)
+	if !found {
+		return sdkerrors.Wrap(types.ErrBondDoesNotExist, p.BondToken)
+	}
+
+	// Bonds can only be migrated forwards, since legacy curve versions are only
+	// kept to preserve the prices of bonds created under them
+	oldCurveVersion := bond.GetCurveVersion()
+	if p.CurveVersion <= oldCurveVersion {
+		return sdkerrors.Wrapf(types.ErrCurveVersionNotNewer,
+			"%d is not newer than %d", p.CurveVersion, oldCurveVersion)
+	}
+
+	bond.CurveVersion = p.CurveVersion
+	keeper.SetBond(ctx, bond.Token, bond)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s migrated from curve version %d to %d",
+		bond.Token, oldCurveVersion, p.CurveVersion))
+
+	ctx.EventManager().EmitEvent(
+		types.NewEvent(types.MigrateCurveVersionEvent{
+			Bond:            bond.Token,
+			OldCurveVersion: oldCurveVersion,
+			NewCurveVersion: p.CurveVersion,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+	)
+
+	return nil
+}
+
+func handleMigrateReserveTokenProposal(ctx sdk.Context, keeper keeper.Keeper, p types.MigrateReserveTokenProposal) error {
+
+	oldReserve, newReserve, err := keeper.MigrateReserveToken(
+		ctx, p.BondToken, p.FromDenom, p.ToDenom, p.Rate)
+	if err != nil {
+		return err
+	}
+
+	bond := keeper.MustGetBond(ctx, p.BondToken)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s migrated reserve token %s to %s at rate %s",
+		bond.Token, p.FromDenom, p.ToDenom, p.Rate.String()))
+
+	ctx.EventManager().EmitEvent(
+		types.NewEvent(types.MigrateReserveTokenEvent{
+			Bond:               bond.Token,
+			FromDenom:          p.FromDenom,
+			ToDenom:            p.ToDenom,
+			Rate:               p.Rate,
+			OldReserve:         oldReserve,
+			NewReserve:         newReserve,
+			FunctionParameters: bond.FunctionParameters,
+		}).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+	)
+
+	return nil
+}
+
+func handleEditBondProposal(ctx sdk.Context, keeper keeper.Keeper, p types.EditBondProposal) error {
+
+	bond, found := keeper.GetBond(ctx, p.BondToken)
+	if !found {
+		return sdkerrors.Wrap(types.ErrBondDoesNotExist, p.BondToken)
+	}
+
+	// Settled and failed bonds only allow their holders to withdraw their
+	// share of the reserve, so there is nothing left to edit
+	if types.IsFinalBondState(bond.State) {
+		return sdkerrors.Wrapf(types.ErrInvalidStateForAction,
+			"cannot edit a bond in state %s", bond.State)
+	}
+
+	if p.OrderQuantityLimits != nil {
+		bond.OrderQuantityLimits = *p.OrderQuantityLimits
+	}
+
+	if p.SanityRate != nil || p.SanityMarginPercentage != nil {
+		if p.SanityRate != nil {
+			bond.SanityRate = *p.SanityRate
+		}
+		if p.SanityMarginPercentage != nil {
+			bond.SanityMarginPercentage = *p.SanityMarginPercentage
+		}
+
+		// Unlike with MsgEditBond, the sanity values of swapper bonds are not
+		// limited in how much they can change, so that governance can correct
+		// them. A new sanity rate window is started from the new values, so
+		// that later changes by the signers are limited relative to them.
+		if bond.FunctionType == types.SwapperFunction {
+			keeper.SetSanityRateWindow(ctx, bond.Token, types.NewSanityRateWindow(
+				ctx.BlockHeight(), bond.SanityRate, bond.SanityMarginPercentage))
+		} else if (bond.FunctionType == types.WeightedSwapperFunction ||
+			bond.FunctionType == types.StableSwapFunction) && !bond.SanityRate.IsZero() {
+			return sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, "SanityRate")
+		}
+	}
+
+	// The fees and sells are only edited once the bond's current batch is
+	// settled, so that the orders in it are performed on the terms that they
+	// were submitted under
+	pendingEdit := types.NewPendingBondEdit(bond.Token,
+		p.TxFeePercentage, p.ExitFeePercentage, p.AllowSells)
+	if !pendingEdit.IsEmpty() {
+		if err := keeper.AddPendingBondEdit(ctx, pendingEdit); err != nil {
+			return err
+		}
+	}
+
+	keeper.SetBond(ctx, bond.Token, bond)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s edited by governance", bond.Token))
+
+	// Only the edited fields are included in the event
+	edited := func(value fmt.Stringer, set bool) string {
+		if !set {
+			return ""
+		}
+		return value.String()
+	}
+	event := types.EditBondProposalEvent{
+		Bond:                   bond.Token,
+		EditedFields:           p.EditedFields(),
+		TxFeePercentage:        edited(p.TxFeePercentage, p.TxFeePercentage != nil),
+		ExitFeePercentage:      edited(p.ExitFeePercentage, p.ExitFeePercentage != nil),
+		OrderQuantityLimits:    edited(p.OrderQuantityLimits, p.OrderQuantityLimits != nil),
+		SanityRate:             edited(p.SanityRate, p.SanityRate != nil),
+		SanityMarginPercentage: edited(p.SanityMarginPercentage, p.SanityMarginPercentage != nil),
+	}
+	if p.AllowSells != nil {
+		event.AllowSells = strconv.FormatBool(*p.AllowSells)
+	}
+
+	ctx.EventManager().EmitEvent(
+		types.NewEvent(event).AppendAttributes(bond.EventAttributes.AsSDKAttributes()...),
+	)
+
+	return nil
+}
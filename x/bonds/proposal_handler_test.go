@@ -0,0 +1,232 @@
+package bonds_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateCurveVersionProposalForNonExistentBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewProposalHandler(app.BondsKeeper)
+
+	proposal := types.NewMigrateCurveVersionProposal(
+		"title", "description", token, types.LatestCurveVersion)
+	err := h(ctx, proposal)
+
+	require.Error(t, err)
+	require.True(t, types.ErrBondDoesNotExist.Is(err))
+}
+
+func TestMigrateCurveVersionProposalToSameVersionFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewProposalHandler(app.BondsKeeper)
+
+	// Create bond (under the latest curve version)
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	require.Equal(t, types.LatestCurveVersion,
+		app.BondsKeeper.MustGetBond(ctx, token).CurveVersion)
+
+	// Migrate bond to the same version
+	proposal := types.NewMigrateCurveVersionProposal(
+		"title", "description", token, types.LatestCurveVersion)
+	err = ph(ctx, proposal)
+
+	require.Error(t, err)
+	require.True(t, types.ErrCurveVersionNotNewer.Is(err))
+}
+
+func TestMigrateCurveVersionProposalForUnversionedBond(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewProposalHandler(app.BondsKeeper)
+
+	// Create bond and clear its version, as for bonds created before versioning
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	bond.CurveVersion = 0
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	// Unversioned bond is on curve version 1, so cannot be migrated to it
+	proposal := types.NewMigrateCurveVersionProposal(
+		"title", "description", token, types.CurveVersion1)
+	err = ph(ctx, proposal)
+
+	require.Error(t, err)
+	require.True(t, types.ErrCurveVersionNotNewer.Is(err))
+}
+
+func TestMigrateReserveTokenProposalForNonReserveTokenFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewProposalHandler(app.BondsKeeper)
+
+	// Create bond
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+
+	// Migrate a token that is not one of the bond's reserve tokens
+	proposal := types.NewMigrateReserveTokenProposal("title", "description",
+		token, reserveToken2, "newres", sdk.OneDec())
+	err = ph(ctx, proposal)
+
+	require.Error(t, err)
+	require.True(t, types.ErrTokenIsNotAValidReserveToken.Is(err))
+}
+
+func TestMigrateReserveTokenProposalSettlesBatchAndConvertsReserve(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewProposalHandler(app.BondsKeeper)
+
+	// Create bond
+	createMsg := newValidMsgCreateBond()
+	createMsg.BatchBlocks = sdk.NewUint(3)
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Mint reserve tokens to user (so that the old reserve tokens can be
+	// burned from the total supply) and buy 4 tokens (pending in the batch)
+	reserveCoins := sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)}
+	err = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, reserveCoins)
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.BondsMintBurnAccount, userAddress, reserveCoins)
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(4, 10000))
+	require.NoError(t, err)
+
+	// Migrate reserve at a rate of 10 new tokens per old token
+	proposal := types.NewMigrateReserveTokenProposal("title", "description",
+		token, reserveToken, "newres", sdk.NewDec(10))
+	err = ph(ctx, proposal)
+	require.NoError(t, err)
+
+	// Pending buy was performed before the migration
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewInt64Coin(token, 4), bond.CurrentSupply)
+	require.False(t, app.BondsKeeper.MustGetBatch(ctx, token).HasOrders())
+
+	// Reserve (656res) converted to the new denomination
+	require.Equal(t, []string{"newres"}, bond.ReserveTokens)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("newres", 6560)), bond.CurrentReserve)
+	reserveAccount := app.SupplyKeeper.GetModuleAccount(ctx, types.BondsReserveAccount)
+	require.Equal(t, bond.CurrentReserve, reserveAccount.GetCoins())
+
+	// Function parameters scaled, so that the reserve still matches the supply
+	require.Equal(t, sdk.NewDec(120), bond.FunctionParamsMap()["m"])
+	require.Equal(t, sdk.NewDec(1000), bond.FunctionParamsMap()["c"])
+	require.Equal(t, bond.CurrentReserve.AmountOf("newres"),
+		bond.ReserveAtSupply(bond.CurrentSupply.Amount).TruncateInt())
+}
+
+func TestEditBondProposalForNonExistentBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewProposalHandler(app.BondsKeeper)
+
+	proposal := types.NewEditBondProposal("title", "description", token,
+		nil, nil, nil, nil, nil, boolPtr(false))
+	err := h(ctx, proposal)
+
+	require.Error(t, err)
+	require.True(t, types.ErrBondDoesNotExist.Is(err))
+}
+
+func TestEditBondProposalEditsFeesAndDisablesSells(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewProposalHandler(app.BondsKeeper)
+
+	// Create bond (allowing sells)
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+
+	// Fees cannot add up to 100% or more
+	proposal := types.NewEditBondProposal("title", "description", token,
+		nil, decPtr(sdk.NewDec(100).Sub(initTxFeePercentage)), nil, nil, nil, nil)
+	err = ph(ctx, proposal)
+	require.Error(t, err)
+	require.True(t, types.ErrFeesCannotBeOrExceed100Percent.Is(err))
+
+	// Edit the tx fee and the order quantity limits, and disable sells
+	limits := sdk.NewCoins(sdk.NewInt64Coin(token, 10))
+	proposal = types.NewEditBondProposal("title", "description", token,
+		decPtr(sdk.NewDec(2)), nil, coinsPtr(limits), nil, nil, boolPtr(false))
+	err = ph(ctx, proposal)
+	require.NoError(t, err)
+
+	// Limits apply immediately but fees and sells only at the batch boundary
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, initTxFeePercentage, bond.TxFeePercentage)
+	require.Equal(t, limits, bond.OrderQuantityLimits)
+	require.True(t, bond.AllowSells)
+	_, found := app.BondsKeeper.GetPendingBondEdit(ctx, token)
+	require.True(t, found)
+
+	// Settle the current batch
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewDec(2), bond.TxFeePercentage)
+	require.Equal(t, initExitFeePercentage, bond.ExitFeePercentage)
+	require.False(t, bond.AllowSells)
+	_, found = app.BondsKeeper.GetPendingBondEdit(ctx, token)
+	require.False(t, found)
+
+	// Sells are no longer allowed
+	_, err = h(ctx, newValidMsgSell(1))
+	require.Error(t, err)
+	require.True(t, types.ErrBondDoesNotAllowSelling.Is(err))
+}
+
+func TestEditBondProposalForSettledBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewProposalHandler(app.BondsKeeper)
+
+	// Create bond and move it to a final state
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	bond.State = types.SettleState
+	app.BondsKeeper.SetBond(ctx, token, bond)
+
+	proposal := types.NewEditBondProposal("title", "description", token,
+		decPtr(sdk.NewDec(2)), nil, nil, nil, nil, nil)
+	err = ph(ctx, proposal)
+	require.Error(t, err)
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+
+	_, found := app.BondsKeeper.GetPendingBondEdit(ctx, token)
+	require.False(t, found)
+}
+
+func TestEditBondProposalSanityRateChangeIsNotLimited(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewProposalHandler(app.BondsKeeper)
+
+	// Create swapper bond and enable its sanity check
+	_, err := h(ctx, newValidMsgCreateSwapperBond())
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgSetSanityRate(token, sdk.OneDec(),
+		sdk.NewDec(10), initCreator, initSigners))
+	require.NoError(t, err)
+
+	// Governance can change the sanity rate by more than the max step
+	proposal := types.NewEditBondProposal("title", "description", token,
+		nil, nil, nil, decPtr(sdk.NewDec(2)), nil, nil)
+	err = ph(ctx, proposal)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(2), app.BondsKeeper.MustGetBond(ctx, token).SanityRate)
+
+	// A new window starts from the new rate, so a 5% step is allowed
+	_, err = h(ctx, types.NewMsgSetSanityRate(token, sdk.MustNewDecFromStr("2.1"),
+		sdk.NewDec(10), initCreator, initSigners))
+	require.NoError(t, err)
+}
@@ -2,6 +2,9 @@ package simulation
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"math/rand"
 )
 
 var (
@@ -12,6 +15,8 @@ var (
 	blankSanityRate             = sdk.MustNewDecFromStr("0")
 	blankSanityMarginPercentage = sdk.MustNewDecFromStr("0")
 
+	smallestPercentage = sdk.NewDecWithPrec(1, types.MaxPercentageDecimalPlaces)
+
 	tokenPrefix    = "token"
 	totalBondCount = 0 // Updated for each bond created
 	maxBondCount   = 0 // Set during genesis creation
@@ -19,3 +24,10 @@ var (
 
 	swapperBonds []string
 )
+
+// getRandomPercentage returns a random percentage between 0 and max (inclusive)
+// with no more than the max number of decimal places allowed in a percentage
+func getRandomPercentage(r *rand.Rand, max sdk.Dec) sdk.Dec {
+	percentage := simulation.RandomDecAmount(r, max)
+	return percentage.Quo(smallestPercentage).TruncateDec().Mul(smallestPercentage)
+}
@@ -31,6 +31,66 @@ func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
 		cdc.MustUnmarshalBinaryBare(kvB.Value, &batchB)
 		return fmt.Sprintf("%v\n%v", batchA, batchB)
 
+	case bytes.Equal(kvA.Key[:1], types.LastBatchResultsKeyPrefix):
+		var resultA, resultB types.BatchResult
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &resultA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &resultB)
+		return fmt.Sprintf("%v\n%v", resultA, resultB)
+
+	case bytes.Equal(kvA.Key[:1], types.ModuleStatsKey):
+		var statsA, statsB types.ModuleStats
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &statsA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &statsB)
+		return fmt.Sprintf("%v\n%v", statsA, statsB)
+
+	case bytes.Equal(kvA.Key[:1], types.BondSearchIndexKeyPrefix):
+		var entryA, entryB types.BondSearchIndexEntry
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &entryA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &entryB)
+		return fmt.Sprintf("%v\n%v", entryA, entryB)
+
+	case bytes.Equal(kvA.Key[:1], types.NextOrderIDKey):
+		var orderIDA, orderIDB uint64
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &orderIDA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &orderIDB)
+		return fmt.Sprintf("%v\n%v", orderIDA, orderIDB)
+
+	case bytes.Equal(kvA.Key[:1], types.OrderReceiptsKeyPrefix):
+		var receiptA, receiptB types.OrderReceipt
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &receiptA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &receiptB)
+		return fmt.Sprintf("%v\n%v", receiptA, receiptB)
+
+	case bytes.Equal(kvA.Key[:1], types.ScheduledChangesKeyPrefix):
+		var changeA, changeB types.ScheduledParamChange
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &changeA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &changeB)
+		return fmt.Sprintf("%v\n%v", changeA, changeB)
+
+	case bytes.Equal(kvA.Key[:1], types.BondProposalsKeyPrefix):
+		var proposalA, proposalB types.BondProposal
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &proposalA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &proposalB)
+		return fmt.Sprintf("%v\n%v", proposalA, proposalB)
+
+	case bytes.Equal(kvA.Key[:1], types.BondProposalVotesPrefix):
+		var voteA, voteB types.BondProposalVote
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &voteA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &voteB)
+		return fmt.Sprintf("%v\n%v", voteA, voteB)
+
+	case bytes.Equal(kvA.Key[:1], types.NextBondProposalIDKey):
+		var proposalIDA, proposalIDB uint64
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &proposalIDA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &proposalIDB)
+		return fmt.Sprintf("%v\n%v", proposalIDA, proposalIDB)
+
+	case bytes.Equal(kvA.Key[:1], types.BondHistoriesKeyPrefix):
+		var historyA, historyB types.BondHistory
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &historyA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &historyB)
+		return fmt.Sprintf("%v\n%v", historyA, historyB)
+
 	default:
 		panic(fmt.Sprintf("invalid %s key prefix %X", types.ModuleName, kvA.Key[:1]))
 	}
@@ -46,6 +46,8 @@ func TestDecodeStore(t *testing.T) {
 	sanityRate := sdk.MustNewDecFromStr("0.3")
 	sanityMarginPercentage := sdk.MustNewDecFromStr("0.4")
 	allowSell := true
+	nonTransferable := false
+	requireAttestation := false
 	signers := []sdk.AccAddress{creator}
 	batchBlocks := sdk.NewUint(10)
 	outcomePayment := sdk.NewCoins(
@@ -58,9 +60,19 @@ func TestDecodeStore(t *testing.T) {
 	bond := types.NewBond(token, name, description, creator, functionType,
 		functionParameters, reserveTokens, txFeePercentage, exitFeePercentage,
 		feeAddress, maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
-		allowSell, signers, batchBlocks, outcomePayment, state)
+		allowSell, nonTransferable, requireAttestation, signers, batchBlocks,
+		outcomePayment, nil, 0, nil, state)
 	batch := types.NewBatch(bond.Token, bond.BatchBlocks)
 	lastBatch := types.NewBatch(bond.Token, bond.BatchBlocks)
+	searchIndexEntry := types.NewBondSearchIndexEntry(bond.Name, bond.Description)
+	nextOrderID := uint64(2)
+	orderReceipt := types.NewSellOrderReceipt(1, creator, sdk.NewInt64Coin(token, 10), 5)
+	scheduledChange := types.NewScheduledParamChange(token, functionParameters, 100, 5)
+	bondProposal := types.NewBondProposal(1, token, creator, "title",
+		"description", types.BondProposalTypeText, nil, nil, 100)
+	bondProposalVote := types.NewBondProposalVote(1, creator, types.VoteOptionYes, sdk.NewInt(10))
+	nextBondProposalID := uint64(2)
+	bondHistory := types.BondHistory{types.NewBondSnapshot(bond, nil, 100)}
 
 	kvPairs := tmkv.Pairs{
 		tmkv.Pair{Key: types.GetBondKey(token),
@@ -69,6 +81,22 @@ func TestDecodeStore(t *testing.T) {
 			Value: cdc.MustMarshalBinaryBare(batch)},
 		tmkv.Pair{Key: types.GetLastBatchKey(token),
 			Value: cdc.MustMarshalBinaryBare(lastBatch)},
+		tmkv.Pair{Key: types.GetBondSearchIndexKey(token),
+			Value: cdc.MustMarshalBinaryBare(searchIndexEntry)},
+		tmkv.Pair{Key: types.NextOrderIDKey,
+			Value: cdc.MustMarshalBinaryBare(nextOrderID)},
+		tmkv.Pair{Key: types.GetOrderReceiptKey(orderReceipt.Receipt),
+			Value: cdc.MustMarshalBinaryBare(orderReceipt)},
+		tmkv.Pair{Key: types.GetScheduledChangeKey(token),
+			Value: cdc.MustMarshalBinaryBare(scheduledChange)},
+		tmkv.Pair{Key: types.GetBondProposalKey(bondProposal.ProposalID),
+			Value: cdc.MustMarshalBinaryBare(bondProposal)},
+		tmkv.Pair{Key: types.GetBondProposalVoteKey(1, creator),
+			Value: cdc.MustMarshalBinaryBare(bondProposalVote)},
+		tmkv.Pair{Key: types.NextBondProposalIDKey,
+			Value: cdc.MustMarshalBinaryBare(nextBondProposalID)},
+		tmkv.Pair{Key: types.GetBondHistoryKey(token),
+			Value: cdc.MustMarshalBinaryBare(bondHistory)},
 		tmkv.Pair{Key: []byte{0x99}, Value: []byte{0x99}},
 	}
 
@@ -79,6 +107,14 @@ func TestDecodeStore(t *testing.T) {
 		{"bonds", fmt.Sprintf("%v\n%v", bond, bond)},
 		{"batches", fmt.Sprintf("%v\n%v", batch, batch)},
 		{"lastBatches", fmt.Sprintf("%v\n%v", lastBatch, lastBatch)},
+		{"bondSearchIndex", fmt.Sprintf("%v\n%v", searchIndexEntry, searchIndexEntry)},
+		{"nextOrderID", fmt.Sprintf("%v\n%v", nextOrderID, nextOrderID)},
+		{"orderReceipts", fmt.Sprintf("%v\n%v", orderReceipt, orderReceipt)},
+		{"scheduledChanges", fmt.Sprintf("%v\n%v", scheduledChange, scheduledChange)},
+		{"bondProposals", fmt.Sprintf("%v\n%v", bondProposal, bondProposal)},
+		{"bondProposalVotes", fmt.Sprintf("%v\n%v", bondProposalVote, bondProposalVote)},
+		{"nextBondProposalID", fmt.Sprintf("%v\n%v", nextBondProposalID, nextBondProposalID)},
+		{"bondHistories", fmt.Sprintf("%v\n%v", bondHistory, bondHistory)},
 		{"other", ""},
 	}
 
@@ -51,6 +51,7 @@ func RandomizedGenState(simState *module.SimulationState) {
 
 	var bonds []types.Bond
 	var batches []types.Batch
+	var ledgers []types.BondLedger
 	for i := 0; i < int(initialBonds); i++ {
 		simAccount, _ := simulation.RandomAcc(r, simState.Accounts)
 		address := simAccount.Address
@@ -80,8 +81,8 @@ func RandomizedGenState(simState *module.SimulationState) {
 		functionParameters := getRandomFunctionParameters(r, functionType, true)
 
 		// Max fee is 100, so exit fee uses 100-txFee as max
-		txFeePercentage := simulation.RandomDecAmount(r, sdk.NewDec(100))
-		exitFeePercentage := simulation.RandomDecAmount(r, sdk.NewDec(100).Sub(txFeePercentage))
+		txFeePercentage := getRandomPercentage(r, sdk.NewDec(100))
+		exitFeePercentage := getRandomPercentage(r, sdk.NewDec(100).Sub(txFeePercentage))
 
 		// Addresses
 		feeAddress := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
@@ -98,19 +99,21 @@ func RandomizedGenState(simState *module.SimulationState) {
 		bond := types.NewBond(token, name, desc, creator, functionType,
 			functionParameters, reserveTokens, txFeePercentage,
 			exitFeePercentage, feeAddress, maxSupply, blankOrderQuantityLimits,
-			blankSanityRate, blankSanityMarginPercentage, allowSells, signers,
-			batchBlocks, outcomePayment, state)
+			blankSanityRate, blankSanityMarginPercentage, allowSells, false,
+			false, signers, batchBlocks, outcomePayment, nil, 0, nil, state)
 		batch := types.NewBatch(bond.Token, bond.BatchBlocks)
 
 		bonds = append(bonds, bond)
 		batches = append(batches, batch)
+		ledgers = append(ledgers, types.NewBondLedger(bond.Token))
 		incrementBondCount()
 		if bond.FunctionType == types.SwapperFunction {
 			newSwapperBond(bond.Token)
 		}
 	}
 
-	bondsGenesis := types.NewGenesisState(bonds, batches)
+	bondsGenesis := types.NewGenesisState(bonds, batches, nil, nil, nil, nil, nil,
+		ledgers, nil, nil, 1, nil, nil, nil, nil, nil, nil, types.DefaultParams())
 
 	fmt.Printf("Selected randomly generated bonds genesis state:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, bondsGenesis))
 	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(bondsGenesis)
@@ -131,15 +131,15 @@ func SimulateMsgCreateBond(ak auth.AccountKeeper) simulation.Operation {
 		functionParameters := getRandomFunctionParameters(r, functionType, false)
 
 		// Max fee is 100, so exit fee uses 100-txFee as max
-		txFeePercentage := simulation.RandomDecAmount(r, sdk.NewDec(100))
-		exitFeePercentage := simulation.RandomDecAmount(r, sdk.NewDec(100).Sub(txFeePercentage))
+		txFeePercentage := getRandomPercentage(r, sdk.NewDec(100))
+		exitFeePercentage := getRandomPercentage(r, sdk.NewDec(100).Sub(txFeePercentage))
 
 		// Since 100 is not allowed, a small number is subtracted from one of the fees
 		if txFeePercentage.Add(exitFeePercentage).Equal(sdk.NewDec(100)) {
 			if txFeePercentage.GT(sdk.ZeroDec()) {
-				txFeePercentage = txFeePercentage.Sub(sdk.MustNewDecFromStr("0.000000000000000001"))
+				txFeePercentage = txFeePercentage.Sub(smallestPercentage)
 			} else {
-				exitFeePercentage = exitFeePercentage.Sub(sdk.MustNewDecFromStr("0.000000000000000001"))
+				exitFeePercentage = exitFeePercentage.Sub(smallestPercentage)
 			}
 		}
 
@@ -156,7 +156,9 @@ func SimulateMsgCreateBond(ak auth.AccountKeeper) simulation.Operation {
 		msg := types.NewMsgCreateBond(token, name, desc, creator, functionType,
 			functionParameters, reserveTokens, txFeePercentage, exitFeePercentage,
 			feeAddress, maxSupply, blankOrderQuantityLimits, blankSanityRate,
-			blankSanityMarginPercentage, allowSells, signers, batchBlocks, blankOutcomePayment)
+			blankSanityMarginPercentage, allowSells, false, false, signers,
+			batchBlocks, blankOutcomePayment, nil, 0, nil, nil,
+			types.AtMaxSupplyAllowRebuys)
 		if msg.ValidateBasic() != nil {
 			return simulation.NoOpMsg(types.ModuleName), nil,
 				fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
@@ -209,9 +211,9 @@ func SimulateMsgEditBond(ak auth.AccountKeeper, k keeper.Keeper) simulation.Oper
 		editor := address
 		signers := []sdk.AccAddress{editor}
 
-		msg := types.NewMsgEditBond(token, name, desc,
-			types.DoNotModifyField, types.DoNotModifyField,
-			types.DoNotModifyField, editor, signers)
+		msg := types.NewMsgEditBond(token, editor, signers)
+		msg.Name = &name
+		msg.Description = &desc
 		if msg.ValidateBasic() != nil {
 			return simulation.NoOpMsg(types.ModuleName), nil,
 				fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
@@ -324,7 +326,7 @@ func getBuyIntoNonSwapper(r *rand.Rand, ctx sdk.Context, k keeper.Keeper,
 	// If not an augmented function in hatch state, just pick a random amount.
 	var toBuyInt sdk.Int
 	if bond.FunctionType == types.AugmentedFunction && bond.State == types.HatchState {
-		S0 := bond.FunctionParameters.AsMap()["S0"].Ceil().TruncateInt()
+		S0 := bond.FunctionParamsMap()["S0"].Ceil().TruncateInt()
 		remainingForS0 := S0.Sub(bond.CurrentSupply.Amount)
 		if remainingForS0.LTE(maxBuyAmount) && simulation.RandIntBetween(r, 1, 2) == 1 {
 			toBuyInt = remainingForS0